@@ -2,20 +2,28 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/studiowebux/restcli/internal/analytics"
 	"github.com/studiowebux/restcli/internal/cli"
 	"github.com/studiowebux/restcli/internal/config"
 	"github.com/studiowebux/restcli/internal/converter"
+	"github.com/studiowebux/restcli/internal/keybinds"
 	"github.com/studiowebux/restcli/internal/mock"
+	"github.com/studiowebux/restcli/internal/parser"
 	"github.com/studiowebux/restcli/internal/proxy"
 	"github.com/studiowebux/restcli/internal/session"
+	"github.com/studiowebux/restcli/internal/stresstest"
+	"github.com/studiowebux/restcli/internal/types"
 	"github.com/studiowebux/restcli/internal/tui"
 )
 
@@ -48,6 +56,9 @@ Examples:
   restcli run api -p dev               # Use 'dev' profile (no prompts)
   restcli run api -e userId=123        # Provide var, prompt for others
   restcli run api -e env=dev -e v=2    # Multiple variables
+  restcli run health --assert          # Exit 1 when expectations fail
+  restcli run signup --seed 42         # Reproducible {{$faker.*}} values
+  restcli run export --download a.zip  # Stream the body to a file
   restcli --help                       # Show help`,
 	Version: version,
 	Args:    cobra.MaximumNArgs(1),
@@ -57,6 +68,8 @@ Examples:
 			return fmt.Errorf("failed to initialize config: %w", err)
 		}
 
+		applySeed(cmd)
+
 		// If a file is provided, run in CLI mode
 		if len(args) > 0 {
 			return runCLI(cmd, args[0])
@@ -75,10 +88,40 @@ var runCmd = &cobra.Command{
 		if err := config.Initialize(); err != nil {
 			return fmt.Errorf("failed to initialize config: %w", err)
 		}
+		applySeed(cmd)
 		return runCLI(cmd, args[0])
 	},
 }
 
+var batchCmd = &cobra.Command{
+	Use:   "batch <file|dir|glob>...",
+	Short: "Execute several HTTP request files and summarize the results",
+	Long: `Execute each matching request file and print a pass/fail summary.
+
+A file passes when its expectations hold (2xx unless @expectedStatusCodes says otherwise).
+Directories are searched recursively for .http files. Files with @depends run their chain
+first. Requests marked @confirmation are skipped. Exits with 1 when a file failed.`,
+	Example: `  restcli batch requests/
+  restcli batch 'users/*.http' orders.http --parallel 4
+  restcli batch smoke/ --junit report.xml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Initialize(); err != nil {
+			return fmt.Errorf("failed to initialize config: %w", err)
+		}
+		applySeed(cmd)
+		return cli.RunBatch(cli.BatchOptions{
+			Patterns:    args,
+			Profile:     flagProfile,
+			Environment: flagEnv,
+			ExtraVars:   flagExtraVars,
+			EnvFile:     flagEnvFile,
+			Parallel:    batchParallel,
+			JUnitPath:   flagJUnit,
+		})
+	},
+}
+
 var curl2httpCmd = &cobra.Command{
 	Use:   "curl2http [curl command]",
 	Short: "Convert cURL command to .http file",
@@ -115,6 +158,20 @@ recorded HTTP requests. This command converts them to editable request files.`,
 	},
 }
 
+var postman2httpCmd = &cobra.Command{
+	Use:   "postman2http <collection-file>",
+	Short: "Convert Postman collection to .http files",
+	Long: `Convert a Postman collection (v2.1) to .http files.
+
+Folders become directories and {{variables}} are kept as-is. Pre-request and
+test scripts are added as comments. Use --env to import a Postman environment
+(and the collection variables) into a profile.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPostman2Http(cmd, args[0])
+	},
+}
+
 var mockCmd = &cobra.Command{
 	Use:   "mock",
 	Short: "Manage mock HTTP server",
@@ -131,7 +188,10 @@ var mockStartCmd = &cobra.Command{
 
 If no config file is provided, looks for .mock.yaml or .mock.json files in:
   - mocks/ directory
-  - current directory`,
+  - current directory
+
+Use --openapi to serve example responses for every operation of an OpenAPI
+spec instead of a mock config file.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runMockStart(cmd, args)
@@ -178,6 +238,93 @@ The proxy will capture all HTTP traffic and display it in the TUI.`,
 	},
 }
 
+var analyticsCmd = &cobra.Command{
+	Use:   "analytics",
+	Short: "Manage request analytics",
+}
+
+var analyticsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export analytics entries to CSV or JSON",
+	Long: `Export every recorded request (file path, normalized path, method, status,
+request/response size, duration, profile and timestamp) to CSV or JSON.
+
+Use --profile to only export the entries of one profile and --since to only
+export the recent ones.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAnalyticsExport(cmd)
+	},
+}
+
+var analyticsCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Fail when an endpoint's recent error rate is above the threshold",
+	Long: `Print the error rate of every endpoint called within the rolling window and
+exit with 1 when one is above the threshold, e.g. to gate a deploy.
+
+The threshold and window default to the profile's errorRateThreshold (10%) and
+errorRateWindow (60 minutes). Errors are 4xx/5xx responses and network errors.`,
+	Example: `  restcli analytics check -p staging
+  restcli analytics check --threshold 2.5 --window 15m`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.Initialize(); err != nil {
+			return fmt.Errorf("failed to initialize config: %w", err)
+		}
+		return cli.RunAnalyticsCheck(cli.AnalyticsCheckOptions{
+			Profile:   flagProfile,
+			Threshold: analyticsThreshold,
+			Window:    analyticsWindow,
+		})
+	},
+}
+
+var keybindsCmd = &cobra.Command{
+	Use:   "keybinds",
+	Short: "Export and import keybindings",
+}
+
+var keybindsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the active keybindings (defaults and overrides)",
+	Long: `Export every active keybinding, defaults merged with ~/.restcli/keybinds.json,
+in the keybinds.json format. Use it as a starting point for your own bindings.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runKeybindsExport()
+	},
+}
+
+var keybindsImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import keybindings into ~/.restcli/keybinds.json",
+	Long: `Validate a keybinds.json file and write it, merged with the defaults,
+to ~/.restcli/keybinds.json.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runKeybindsImport(args[0])
+	},
+}
+
+var stressCmd = &cobra.Command{
+	Use:   "stress",
+	Short: "Inspect stress test runs",
+}
+
+var stressReportCmd = &cobra.Command{
+	Use:   "report [run-id]",
+	Short: "Show the results of a stress test run",
+	Long: `Show the results of a stress test run (default: the latest run of the profile).
+
+Use --prometheus to also write the run in the Prometheus text exposition format,
+computed from the persisted request metrics so past runs can be exported again.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStressReport(cmd, args)
+	},
+}
+
 var completionCmd = &cobra.Command{
 	Use:   "completion [bash|zsh|fish|powershell]",
 	Short: "Generate shell completion scripts",
@@ -239,14 +386,31 @@ Zsh (macOS/Linux):
 // Flags for root/run command
 var (
 	flagProfile   string
+	flagEnv       string
 	flagOutput    string
+	flagTemplate  string
 	flagSave      string
 	flagBody      string
+	flagRawBody   bool
 	flagFull      bool
 	flagExtraVars []string
 	flagEnvFile   string
 	flagFilter    string
 	flagQuery     string
+	flagAssert    bool
+	flagJUnit     string
+	flagAsCurl    bool
+	flagWatch     bool
+	flagRepeat    int
+	flagInterval  time.Duration
+	flagDiff      bool
+	flagSeed      int64
+	flagDownload  string
+)
+
+// Flags for batch
+var (
+	batchParallel int
 )
 
 // Flags for curl2http
@@ -263,6 +427,13 @@ var (
 	openapiFormat     string
 )
 
+// Flags for mock start
+var (
+	mockOpenAPISpec string
+	mockPort        int
+	mockChaos       float64
+)
+
 // Flags for har2http
 var (
 	harOutputDir     string
@@ -271,32 +442,87 @@ var (
 	harFilter        string
 )
 
+// Flags for postman2http
+var (
+	postmanOutputDir   string
+	postmanFormat      string
+	postmanEnvFile     string
+	postmanProfileName string
+)
+
+// Flags for analytics export
+var (
+	analyticsFormat     string
+	analyticsOutputFile string
+	analyticsSince      time.Duration
+)
+
+// Flags for analytics check
+var (
+	analyticsThreshold float64
+	analyticsWindow    time.Duration
+)
+
+// Flags for keybinds export
+var (
+	keybindsOutputFile string
+)
+
+// Flags for stress report
+var (
+	stressPrometheusFile string
+)
+
 // Flags for proxy
 var (
-	proxyPort int
+	proxyPort        int
+	proxyRecordPath  string
+	proxyRecordHosts []string
 )
 
 func init() {
 	// Root command flags
 	rootCmd.PersistentFlags().StringVarP(&flagProfile, "profile", "p", "", "Profile to use")
-	rootCmd.Flags().StringVarP(&flagOutput, "output", "o", "", "Output format (json/yaml/text)")
+	rootCmd.PersistentFlags().StringVar(&flagEnv, "env", "", "Environment of the profile to layer over its variables")
+	rootCmd.PersistentFlags().Int64Var(&flagSeed, "seed", 0, "Seed the {{$faker.*}} generators for reproducible test data")
+	rootCmd.Flags().StringVarP(&flagOutput, "output", "o", "", "Output format (json/yaml/text/csv/tsv)")
+	rootCmd.Flags().StringVar(&flagTemplate, "output-template", "", "Go template for the output, e.g. '{{.Status}} {{.Duration}}ms' (functions: json, jmespath, upper, lower)")
 	rootCmd.Flags().StringVarP(&flagSave, "save", "s", "", "Save response to file")
-	rootCmd.Flags().StringVarP(&flagBody, "body", "b", "", "Override request body")
+	rootCmd.Flags().StringVarP(&flagBody, "body", "b", "", "Override request body (- reads stdin, @file reads a file)")
+	rootCmd.Flags().BoolVar(&flagRawBody, "raw-body", false, "Send the --body/stdin body as-is, without resolving {{variables}}")
 	rootCmd.Flags().BoolVarP(&flagFull, "full", "f", false, "Show full output (status, headers, body)")
 	rootCmd.Flags().StringArrayVarP(&flagExtraVars, "extra-vars", "e", []string{}, "Set variable (key=value), can be repeated")
-	rootCmd.Flags().StringVar(&flagEnvFile, "env-file", "", "Load environment variables from file")
-	rootCmd.Flags().StringVar(&flagFilter, "filter", "", "JMESPath filter expression to apply to response")
-	rootCmd.Flags().StringVarP(&flagQuery, "query", "q", "", "JMESPath query or $(bash command) to transform response")
+	rootCmd.Flags().StringVar(&flagEnvFile, "env-file", "", "Load environment variables from file (.env, JSON, YAML or TOML)")
+	rootCmd.Flags().StringVar(&flagFilter, "filter", "", "JMESPath, jq:expr or xpath:expr filter expression to apply to response")
+	rootCmd.Flags().StringVarP(&flagQuery, "query", "q", "", "JMESPath query, jq:expr, xpath:expr or $(bash command) to transform response")
+	rootCmd.Flags().BoolVar(&flagAssert, "assert", false, "Evaluate request expectations and exit 1 if one fails")
+	rootCmd.Flags().StringVar(&flagJUnit, "junit", "", "Write a JUnit XML report of the expectations (implies --assert)")
+	rootCmd.Flags().BoolVar(&flagAsCurl, "as-curl", false, "Print the resolved request as a curl command instead of executing it")
+	rootCmd.Flags().BoolVarP(&flagWatch, "watch", "w", false, "Re-run the request whenever its file or a dependency changes")
+	rootCmd.Flags().IntVar(&flagRepeat, "repeat", 1, "Run the request N times (0 until interrupted); with --assert, stop once expectations pass")
+	rootCmd.Flags().DurationVar(&flagInterval, "interval", time.Second, "Delay between repeated runs")
+	rootCmd.Flags().BoolVar(&flagDiff, "diff", false, "When repeating, print only how each response differs from the previous one")
+	rootCmd.Flags().StringVar(&flagDownload, "download", "", "Stream the response body to this file instead of printing it")
 
 	// Run command flags (same as root)
-	runCmd.Flags().StringVarP(&flagOutput, "output", "o", "", "Output format (json/yaml/text)")
+	runCmd.Flags().StringVarP(&flagOutput, "output", "o", "", "Output format (json/yaml/text/csv/tsv)")
+	runCmd.Flags().StringVar(&flagTemplate, "output-template", "", "Go template for the output, e.g. '{{.Status}} {{.Duration}}ms' (functions: json, jmespath, upper, lower)")
 	runCmd.Flags().StringVarP(&flagSave, "save", "s", "", "Save response to file")
-	runCmd.Flags().StringVarP(&flagBody, "body", "b", "", "Override request body")
+	runCmd.Flags().StringVarP(&flagBody, "body", "b", "", "Override request body (- reads stdin, @file reads a file)")
+	runCmd.Flags().BoolVar(&flagRawBody, "raw-body", false, "Send the --body/stdin body as-is, without resolving {{variables}}")
 	runCmd.Flags().BoolVarP(&flagFull, "full", "f", false, "Show full output (status, headers, body)")
 	runCmd.Flags().StringArrayVarP(&flagExtraVars, "extra-vars", "e", []string{}, "Set variable (key=value), can be repeated")
-	runCmd.Flags().StringVar(&flagEnvFile, "env-file", "", "Load environment variables from file")
-	runCmd.Flags().StringVar(&flagFilter, "filter", "", "JMESPath filter expression to apply to response")
-	runCmd.Flags().StringVarP(&flagQuery, "query", "q", "", "JMESPath query or $(bash command) to transform response")
+	runCmd.Flags().StringVar(&flagEnvFile, "env-file", "", "Load environment variables from file (.env, JSON, YAML or TOML)")
+	runCmd.Flags().StringVar(&flagFilter, "filter", "", "JMESPath, jq:expr or xpath:expr filter expression to apply to response")
+	runCmd.Flags().StringVarP(&flagQuery, "query", "q", "", "JMESPath query, jq:expr, xpath:expr or $(bash command) to transform response")
+	runCmd.Flags().BoolVar(&flagAssert, "assert", false, "Evaluate request expectations and exit 1 if one fails")
+	runCmd.Flags().StringVar(&flagJUnit, "junit", "", "Write a JUnit XML report of the expectations (implies --assert)")
+	runCmd.Flags().BoolVar(&flagAsCurl, "as-curl", false, "Print the resolved request as a curl command instead of executing it")
+	runCmd.Flags().BoolVarP(&flagWatch, "watch", "w", false, "Re-run the request whenever its file or a dependency changes")
+	runCmd.Flags().IntVar(&flagRepeat, "repeat", 1, "Run the request N times (0 until interrupted); with --assert, stop once expectations pass")
+	runCmd.Flags().DurationVar(&flagInterval, "interval", time.Second, "Delay between repeated runs")
+	runCmd.Flags().BoolVar(&flagDiff, "diff", false, "When repeating, print only how each response differs from the previous one")
+	runCmd.Flags().StringVar(&flagDownload, "download", "", "Stream the response body to this file instead of printing it")
 
 	// curl2http flags
 	curl2httpCmd.Flags().StringVarP(&curlOutputFile, "output", "o", "", "Output file path")
@@ -314,6 +540,12 @@ func init() {
 	har2httpCmd.Flags().StringVarP(&harFormat, "format", "f", "http", "Output format (http/json/yaml)")
 	har2httpCmd.Flags().StringVar(&harFilter, "filter", "", "Filter requests by URL pattern")
 
+	// postman2http flags
+	postman2httpCmd.Flags().StringVarP(&postmanOutputDir, "output", "o", "requests", "Output directory")
+	postman2httpCmd.Flags().StringVarP(&postmanFormat, "format", "f", "http", "Output format (http/json/yaml)")
+	postman2httpCmd.Flags().StringVar(&postmanEnvFile, "env", "", "Postman environment file to import into a profile")
+	postman2httpCmd.Flags().StringVar(&postmanProfileName, "profile-name", "", "Profile to create or update with the variables (default: environment name)")
+
 	// Helper function to get .http files in a directory
 	getHttpFilesInDir := func(dir string) []string {
 		var httpFiles []string
@@ -467,33 +699,85 @@ func init() {
 	rootCmd.AddCommand(curl2httpCmd)
 	rootCmd.AddCommand(openapi2httpCmd)
 	rootCmd.AddCommand(har2httpCmd)
+	rootCmd.AddCommand(postman2httpCmd)
 	rootCmd.AddCommand(completionCmd)
 
+	// Add batch command
+	batchCmd.Flags().IntVar(&batchParallel, "parallel", 1, "Number of files run at the same time")
+	batchCmd.Flags().StringArrayVarP(&flagExtraVars, "extra-vars", "e", []string{}, "Set variable (key=value), can be repeated")
+	batchCmd.Flags().StringVar(&flagEnvFile, "env-file", "", "Load environment variables from file (.env, JSON, YAML or TOML)")
+	batchCmd.Flags().StringVar(&flagJUnit, "junit", "", "Write a JUnit XML report with one test case per file")
+	rootCmd.AddCommand(batchCmd)
+
 	// Add mock subcommands
+	mockStartCmd.Flags().StringVar(&mockOpenAPISpec, "openapi", "", "Serve example responses from an OpenAPI spec (file or URL)")
+	mockStartCmd.Flags().IntVar(&mockPort, "port", 0, "Server port (default: config port or 8080)")
+	mockStartCmd.Flags().Float64Var(&mockChaos, "chaos", 0, "Baseline failure rate applied to all routes (0-1)")
 	mockCmd.AddCommand(mockStartCmd)
 	mockCmd.AddCommand(mockStopCmd)
 	mockCmd.AddCommand(mockLogsCmd)
 	rootCmd.AddCommand(mockCmd)
 
+	// Add analytics subcommands
+	analyticsExportCmd.Flags().StringVarP(&analyticsFormat, "format", "f", "csv", "Export format (csv/json)")
+	analyticsExportCmd.Flags().StringVarP(&analyticsOutputFile, "output", "o", "", "Output file (default: stdout)")
+	analyticsExportCmd.Flags().DurationVar(&analyticsSince, "since", 0, "Only export entries recorded within this duration, e.g. 24h (default: all)")
+	analyticsCmd.AddCommand(analyticsExportCmd)
+	analyticsCheckCmd.Flags().Float64Var(&analyticsThreshold, "threshold", 0, "Error rate in percent (default: profile errorRateThreshold, 10)")
+	analyticsCheckCmd.Flags().DurationVar(&analyticsWindow, "window", 0, "Rolling window, e.g. 15m or 24h (default: profile errorRateWindow, 60m)")
+	analyticsCmd.AddCommand(analyticsCheckCmd)
+	rootCmd.AddCommand(analyticsCmd)
+
+	// Add keybinds subcommands
+	keybindsExportCmd.Flags().StringVarP(&keybindsOutputFile, "output", "o", "", "Output file (default: stdout)")
+	keybindsCmd.AddCommand(keybindsExportCmd)
+	keybindsCmd.AddCommand(keybindsImportCmd)
+	rootCmd.AddCommand(keybindsCmd)
+
+	// Add stress subcommands
+	stressReportCmd.Flags().StringVar(&stressPrometheusFile, "prometheus", "", "Write the run metrics in Prometheus text format to this file (- for stdout)")
+	stressCmd.AddCommand(stressReportCmd)
+	rootCmd.AddCommand(stressCmd)
+
 	// Add proxy subcommands
 	proxyStartCmd.Flags().IntVar(&proxyPort, "proxy-port", 8888, "Proxy port")
+	proxyStartCmd.Flags().StringVar(&proxyRecordPath, "record", "", "Write captured traffic to a mock config (.mock.yaml) on exit")
+	proxyStartCmd.Flags().StringSliceVar(&proxyRecordHosts, "record-host", nil, "Only record requests to these hosts (repeatable)")
 	proxyCmd.AddCommand(proxyStartCmd)
 	rootCmd.AddCommand(proxyCmd)
 }
 
+// applySeed makes the faker generators deterministic when --seed is set
+func applySeed(cmd *cobra.Command) {
+	if cmd.Flags().Changed("seed") {
+		parser.SetFakerSeed(flagSeed)
+	}
+}
+
 // runCLI executes a request file in CLI mode
 func runCLI(cmd *cobra.Command, filePath string) error {
 	opts := cli.RunOptions{
-		FilePath:     filePath,
-		Profile:      flagProfile,
-		OutputFormat: flagOutput,
-		SavePath:     flagSave,
-		BodyOverride: flagBody,
-		ShowFull:     flagFull,
-		ExtraVars:    flagExtraVars,
-		EnvFile:      flagEnvFile,
-		Filter:       flagFilter,
-		Query:        flagQuery,
+		FilePath:       filePath,
+		Profile:        flagProfile,
+		Environment:    flagEnv,
+		OutputFormat:   flagOutput,
+		OutputTemplate: flagTemplate,
+		SavePath:       flagSave,
+		BodyOverride:   flagBody,
+		RawBody:        flagRawBody,
+		ShowFull:       flagFull,
+		ExtraVars:      flagExtraVars,
+		EnvFile:        flagEnvFile,
+		Filter:         flagFilter,
+		Query:          flagQuery,
+		Assert:         flagAssert,
+		JUnitPath:      flagJUnit,
+		AsCurl:         flagAsCurl,
+		Watch:          flagWatch,
+		Repeat:         flagRepeat,
+		Interval:       flagInterval,
+		Diff:           flagDiff,
+		DownloadPath:   flagDownload,
 	}
 	return cli.Run(opts)
 }
@@ -589,23 +873,52 @@ func runMockStart(cmd *cobra.Command, args []string) error {
 		configPath = args[0]
 	}
 
-	// Find config file
-	foundPath, err := findMockConfig(configPath)
-	if err != nil {
-		return err
-	}
+	var config *mock.Config
+	var foundPath, workdir string
+	var err error
 
-	// Load config
-	config, err := mock.LoadConfig(foundPath)
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+	if mockOpenAPISpec != "" {
+		if configPath != "" {
+			return fmt.Errorf("--openapi cannot be combined with a config file")
+		}
+		config, err = converter.OpenAPIToMockConfig(mockOpenAPISpec)
+		if err != nil {
+			return err
+		}
+		foundPath = mockOpenAPISpec
+		workdir, _ = os.Getwd()
+	} else {
+		// Find config file
+		foundPath, err = findMockConfig(configPath)
+		if err != nil {
+			return err
+		}
+
+		// Load config
+		config, err = mock.LoadConfig(foundPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		// Get workdir for resolving relative paths
+		workdir = filepath.Dir(foundPath)
 	}
 
-	// Get workdir for resolving relative paths
-	workdir := filepath.Dir(foundPath)
+	if mockPort != 0 {
+		config.Port = mockPort
+	}
+	if cmd.Flags().Changed("chaos") {
+		if mockChaos < 0 || mockChaos > 1 {
+			return fmt.Errorf("--chaos must be between 0 and 1")
+		}
+		config.Chaos = mockChaos
+	}
 
 	// Create and start server
 	server := mock.NewServer(config, workdir)
+	server.OnInjectedFailure(func(entry mock.RequestLog) {
+		fmt.Printf("[%s] Injected %d for %s %s\n", entry.Timestamp.Format("15:04:05"), entry.Status, entry.Method, entry.Path)
+	})
 	if err := server.Start(); err != nil {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
@@ -613,6 +926,9 @@ func runMockStart(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Mock server started at %s\n", server.GetAddress())
 	fmt.Printf("Config: %s\n", foundPath)
 	fmt.Printf("Routes: %d\n", len(config.Routes))
+	if config.Chaos > 0 {
+		fmt.Printf("Chaos: %.0f%% baseline failure rate\n", config.Chaos*100)
+	}
 	fmt.Println("\nPress Ctrl+C to stop")
 
 	// Wait indefinitely
@@ -631,8 +947,17 @@ func runMockLogs(cmd *cobra.Command) error {
 
 // runProxyStart starts the debug proxy server
 func runProxyStart(cmd *cobra.Command) error {
+	if proxyRecordPath != "" {
+		switch strings.ToLower(filepath.Ext(proxyRecordPath)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return fmt.Errorf("--record must be a .yaml, .yml or .json file")
+		}
+	}
+
 	// Create proxy
 	p := proxy.NewProxy(proxyPort)
+	p.SetRecordHosts(proxyRecordHosts)
 
 	// Start proxy
 	if err := p.Start(); err != nil {
@@ -646,6 +971,9 @@ func runProxyStart(cmd *cobra.Command) error {
 	fmt.Fprintf(os.Stderr, "  export HTTP_PROXY=http://localhost:%d\n", proxyPort)
 	fmt.Fprintf(os.Stderr, "  export http_proxy=http://localhost:%d\n\n", proxyPort)
 	fmt.Fprintf(os.Stderr, "Press 'y' in TUI to view captured traffic\n")
+	if proxyRecordPath != "" {
+		fmt.Fprintf(os.Stderr, "Recording traffic to %s\n", proxyRecordPath)
+	}
 	fmt.Fprintf(os.Stderr, "Press Ctrl+C to stop\n\n")
 
 	// Initialize config
@@ -674,6 +1002,179 @@ func runProxyStart(cmd *cobra.Command) error {
 		return fmt.Errorf("TUI error: %w", err)
 	}
 
+	if proxyRecordPath != "" {
+		count, err := p.WriteMockConfig(proxyRecordPath)
+		if err != nil {
+			return fmt.Errorf("failed to write mock config: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Recorded %d routes to %s\n", count, proxyRecordPath)
+		fmt.Fprintf(os.Stderr, "Replay with: restcli mock start %s\n", proxyRecordPath)
+	}
+
+	return nil
+}
+
+// runAnalyticsExport writes the analytics entries to a file or stdout
+func runAnalyticsExport(cmd *cobra.Command) error {
+	if err := config.Initialize(); err != nil {
+		return err
+	}
+
+	mgr, err := analytics.NewManager(config.DatabasePath)
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	var filter analytics.ExportFilter
+	if flagProfile != "" {
+		filter.ProfileName = &flagProfile
+	}
+	if analyticsSince > 0 {
+		filter.Since = time.Now().Add(-analyticsSince)
+	}
+
+	out := os.Stdout
+	if analyticsOutputFile != "" {
+		out, err = os.Create(analyticsOutputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer out.Close()
+	}
+
+	count, err := mgr.ExportFiltered(out, analyticsFormat, filter)
+	if err != nil {
+		return err
+	}
+	if analyticsOutputFile != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d analytics entries to %s\n", count, analyticsOutputFile)
+	}
+	return nil
+}
+
+// runKeybindsExport writes the effective keybindings as a keybinds.json config
+func runKeybindsExport() error {
+	configPath, err := keybinds.GetDefaultConfigPath()
+	if err != nil {
+		return err
+	}
+	registry, err := keybinds.LoadOrDefault(configPath)
+	if err != nil {
+		return err
+	}
+
+	exported := registry.Export()
+	if keybindsOutputFile != "" {
+		if err := keybinds.SaveConfig(exported, keybindsOutputFile); err != nil {
+			return fmt.Errorf("failed to write keybindings: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d keybindings to %s\n", exported.Count(), keybindsOutputFile)
+		return nil
+	}
+
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// runKeybindsImport validates a keybinds file and saves it, merged with the defaults, as keybinds.json
+func runKeybindsImport(path string) error {
+	imported, err := keybinds.LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	if result := keybinds.NewValidator().ValidateConfig(imported); result.HasErrors() {
+		return fmt.Errorf("invalid keybindings:\n%s", result.String())
+	}
+
+	registry := keybinds.NewDefaultRegistry()
+	if err := keybinds.ApplyConfig(registry, imported); err != nil {
+		return err
+	}
+
+	configPath, err := keybinds.GetDefaultConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	exported := registry.Export()
+	if err := keybinds.SaveConfig(exported, configPath); err != nil {
+		return fmt.Errorf("failed to write keybindings: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Imported %d keybindings into %s\n", exported.Count(), configPath)
+	return nil
+}
+
+// runStressReport prints a stress test run summary and optionally writes Prometheus metrics
+func runStressReport(cmd *cobra.Command, args []string) error {
+	if err := config.Initialize(); err != nil {
+		return err
+	}
+
+	mgr, err := stresstest.NewManager(config.DatabasePath)
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	var run *stresstest.Run
+	if len(args) == 1 {
+		runID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid run id: %s", args[0])
+		}
+		if run, err = mgr.GetRun(runID); err != nil {
+			return fmt.Errorf("stress test run %d not found", runID)
+		}
+	} else {
+		runs, err := mgr.ListRuns(flagProfile, 1)
+		if err != nil {
+			return fmt.Errorf("failed to list stress test runs: %w", err)
+		}
+		if len(runs) == 0 {
+			return fmt.Errorf("no stress test runs found")
+		}
+		run = runs[0]
+	}
+
+	if stressPrometheusFile == "-" {
+		return mgr.WritePrometheus(os.Stdout, run.ID)
+	}
+
+	fmt.Printf("Run #%d: %s (%s)\n", run.ID, run.ConfigName, run.Status)
+	fmt.Printf("  Request file: %s\n", run.RequestFile)
+	fmt.Printf("  Requests:     %d completed / %d sent\n", run.TotalRequestsCompleted, run.TotalRequestsSent)
+	fmt.Printf("  Errors:       %d network, %d validation\n", run.TotalErrors, run.TotalValidationErrors)
+	fmt.Printf("  Latency:      avg %.1fms, p50 %dms, p95 %dms, p99 %dms\n", run.AvgDurationMs, run.P50DurationMs, run.P95DurationMs, run.P99DurationMs)
+	if run.AchievedRPS > 0 {
+		if run.TargetRPS > 0 {
+			fmt.Printf("  Throughput:   %.1f req/s (target %d)\n", run.AchievedRPS, run.TargetRPS)
+		} else {
+			fmt.Printf("  Throughput:   %.1f req/s\n", run.AchievedRPS)
+		}
+	}
+	if run.ThinkTimeMaxMs > 0 {
+		// Think time is part of each worker's cycle, so it bounds throughput
+		fmt.Printf("  Think time:   %d-%dms per worker (limits achievable throughput)\n", run.ThinkTimeMinMs, run.ThinkTimeMaxMs)
+	}
+
+	if stressPrometheusFile != "" {
+		file, err := os.Create(stressPrometheusFile)
+		if err != nil {
+			return fmt.Errorf("failed to create prometheus file: %w", err)
+		}
+		defer file.Close()
+		if err := mgr.WritePrometheus(file, run.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Prometheus metrics written to %s\n", stressPrometheusFile)
+	}
 	return nil
 }
 
@@ -689,3 +1190,58 @@ func runHar2Http(cmd *cobra.Command, harFile string) error {
 
 	return converter.Har2Http(opts)
 }
+
+// runPostman2Http converts a Postman collection to .http files and optionally imports a profile
+func runPostman2Http(cmd *cobra.Command, collectionFile string) error {
+	opts := converter.Postman2HttpOptions{
+		CollectionFile: collectionFile,
+		OutputDir:      postmanOutputDir,
+		Format:         postmanFormat,
+	}
+
+	if err := converter.Postman2Http(opts); err != nil {
+		return err
+	}
+
+	if postmanEnvFile == "" && postmanProfileName == "" {
+		return nil
+	}
+
+	profile, err := converter.PostmanProfile(collectionFile, postmanEnvFile, postmanProfileName)
+	if err != nil {
+		return err
+	}
+	profile.Workdir = postmanOutputDir
+
+	if err := config.Initialize(); err != nil {
+		return err
+	}
+	mgr := session.NewManager()
+	if err := mgr.Load(); err != nil {
+		return err
+	}
+
+	// Merge into an existing profile with the same name, keeping its other settings
+	for _, existing := range mgr.GetProfiles() {
+		if existing.Name != profile.Name {
+			continue
+		}
+		if existing.Variables == nil {
+			existing.Variables = make(map[string]types.VariableValue)
+		}
+		for name, value := range profile.Variables {
+			existing.Variables[name] = value
+		}
+		if err := mgr.UpdateProfile(existing.Name, existing); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Updated profile %s with %d variables\n", profile.Name, len(profile.Variables))
+		return nil
+	}
+
+	if err := mgr.AddProfile(*profile); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Created profile %s with %d variables\n", profile.Name, len(profile.Variables))
+	return nil
+}
@@ -22,6 +22,9 @@ type Entry struct {
 	RequestSize    int64
 	ResponseSize   int64
 	DurationMs     int64
+	TTFBMs         int64 // Time to first byte (0 = unknown)
+	BudgetMs       int64 // Latency budget (maxDurationMs) in effect (0 = none)
+	BudgetMet      bool  // Duration within BudgetMs (false when there is no budget)
 	ErrorMessage   string
 	Timestamp      time.Time
 	ProfileName    string
@@ -38,6 +41,9 @@ type Stats struct {
 	AvgDurationMs  float64
 	MinDurationMs  int64
 	MaxDurationMs  int64
+	AvgTTFBMs      float64 // Average over entries with a recorded TTFB
+	BudgetCalls    int     // Calls made with a latency budget
+	BudgetMetCount int     // Of BudgetCalls, calls within the budget
 	TotalReqSize   int64
 	TotalRespSize  int64
 	StatusCodes    map[int]int
@@ -79,8 +85,8 @@ func NewManager(dbPath string) (*Manager, error) {
 
 func (m *Manager) Save(entry Entry) error {
 	query := `
-		INSERT INTO analytics (file_path, normalized_path, method, status_code, request_size, response_size, duration_ms, error_message, timestamp, profile_name)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO analytics (file_path, normalized_path, method, status_code, request_size, response_size, duration_ms, ttfb_ms, budget_ms, budget_met, error_message, timestamp, profile_name)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	// Format timestamp for SQLite in local time (YYYY-MM-DD HH:MM:SS)
@@ -94,6 +100,9 @@ func (m *Manager) Save(entry Entry) error {
 		entry.RequestSize,
 		entry.ResponseSize,
 		entry.DurationMs,
+		entry.TTFBMs,
+		entry.BudgetMs,
+		entry.BudgetMet,
 		entry.ErrorMessage,
 		timestampStr,
 		entry.ProfileName,
@@ -111,7 +120,7 @@ func (m *Manager) Save(entry Entry) error {
 
 func (m *Manager) LoadForFile(filePath string, profileName string, limit int) ([]Entry, error) {
 	query := `
-		SELECT id, file_path, normalized_path, method, status_code, request_size, response_size, duration_ms, error_message, timestamp, COALESCE(profile_name, '')
+		SELECT id, file_path, normalized_path, method, status_code, request_size, response_size, duration_ms, ttfb_ms, budget_ms, budget_met, error_message, timestamp, COALESCE(profile_name, '')
 		FROM analytics
 		WHERE file_path = ? AND (profile_name = ? OR (profile_name IS NULL AND ? = ''))
 		ORDER BY timestamp DESC
@@ -129,7 +138,7 @@ func (m *Manager) LoadForFile(filePath string, profileName string, limit int) ([
 
 func (m *Manager) LoadForNormalizedPath(normalizedPath string, profileName string, limit int) ([]Entry, error) {
 	query := `
-		SELECT id, file_path, normalized_path, method, status_code, request_size, response_size, duration_ms, error_message, timestamp, COALESCE(profile_name, '')
+		SELECT id, file_path, normalized_path, method, status_code, request_size, response_size, duration_ms, ttfb_ms, budget_ms, budget_met, error_message, timestamp, COALESCE(profile_name, '')
 		FROM analytics
 		WHERE normalized_path = ? AND (profile_name = ? OR (profile_name IS NULL AND ? = ''))
 		ORDER BY timestamp DESC
@@ -147,7 +156,7 @@ func (m *Manager) LoadForNormalizedPath(normalizedPath string, profileName strin
 
 func (m *Manager) LoadAll(profileName string, limit int) ([]Entry, error) {
 	query := `
-		SELECT id, file_path, normalized_path, method, status_code, request_size, response_size, duration_ms, error_message, timestamp, COALESCE(profile_name, '')
+		SELECT id, file_path, normalized_path, method, status_code, request_size, response_size, duration_ms, ttfb_ms, budget_ms, budget_met, error_message, timestamp, COALESCE(profile_name, '')
 		FROM analytics
 		WHERE profile_name = ? OR (profile_name IS NULL AND ? = '')
 		ORDER BY timestamp DESC
@@ -167,46 +176,58 @@ func (m *Manager) scanEntries(rows *sql.Rows) ([]Entry, error) {
 	var entries []Entry
 
 	for rows.Next() {
-		var e Entry
-		var timestamp string
-		var errorMsg sql.NullString
-
-		err := rows.Scan(
-			&e.ID,
-			&e.FilePath,
-			&e.NormalizedPath,
-			&e.Method,
-			&e.StatusCode,
-			&e.RequestSize,
-			&e.ResponseSize,
-			&e.DurationMs,
-			&errorMsg,
-			&timestamp,
-			&e.ProfileName,
-		)
+		e, err := scanEntry(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan analytics entry: %w", err)
+			return nil, err
 		}
+		entries = append(entries, e)
+	}
 
-		if errorMsg.Valid {
-			e.ErrorMessage = errorMsg.String
-		}
+	return entries, rows.Err()
+}
 
-		// Parse as local time (SQLite stores without timezone info)
-		e.Timestamp, err = time.ParseInLocation("2006-01-02 15:04:05", timestamp, time.Local)
+// scanEntry scans the current row of an analytics entry query
+func scanEntry(rows *sql.Rows) (Entry, error) {
+	var e Entry
+	var timestamp string
+	var errorMsg sql.NullString
+
+	err := rows.Scan(
+		&e.ID,
+		&e.FilePath,
+		&e.NormalizedPath,
+		&e.Method,
+		&e.StatusCode,
+		&e.RequestSize,
+		&e.ResponseSize,
+		&e.DurationMs,
+		&e.TTFBMs,
+		&e.BudgetMs,
+		&e.BudgetMet,
+		&errorMsg,
+		&timestamp,
+		&e.ProfileName,
+	)
+	if err != nil {
+		return e, fmt.Errorf("failed to scan analytics entry: %w", err)
+	}
+
+	if errorMsg.Valid {
+		e.ErrorMessage = errorMsg.String
+	}
+
+	// Parse as local time (SQLite stores without timezone info)
+	e.Timestamp, err = time.ParseInLocation("2006-01-02 15:04:05", timestamp, time.Local)
+	if err != nil {
+		// Try RFC3339 format as fallback
+		e.Timestamp, err = time.Parse(time.RFC3339, timestamp)
 		if err != nil {
-			// Try RFC3339 format as fallback
-			e.Timestamp, err = time.Parse(time.RFC3339, timestamp)
-			if err != nil {
-				// If both fail, use current time to avoid zero value
-				e.Timestamp = time.Now()
-			}
+			// If both fail, use current time to avoid zero value
+			e.Timestamp = time.Now()
 		}
-
-		entries = append(entries, e)
 	}
 
-	return entries, rows.Err()
+	return e, nil
 }
 
 func (m *Manager) GetStatsPerFile(profileName string) ([]Stats, error) {
@@ -248,6 +269,9 @@ func (m *Manager) GetStatsPerFile(profileName string) ([]Stats, error) {
 			AVG(a.duration_ms) as avg_duration,
 			MIN(a.duration_ms) as min_duration,
 			MAX(a.duration_ms) as max_duration,
+			COALESCE(AVG(NULLIF(a.ttfb_ms, 0)), 0) as avg_ttfb,
+			SUM(CASE WHEN a.budget_ms > 0 THEN 1 ELSE 0 END) as budget_calls,
+			SUM(CASE WHEN a.budget_ms > 0 AND a.budget_met THEN 1 ELSE 0 END) as budget_met,
 			SUM(a.request_size) as total_req_size,
 			SUM(a.response_size) as total_resp_size,
 			MAX(a.timestamp) as last_called,
@@ -282,6 +306,9 @@ func (m *Manager) GetStatsPerFile(profileName string) ([]Stats, error) {
 			&s.AvgDurationMs,
 			&s.MinDurationMs,
 			&s.MaxDurationMs,
+			&s.AvgTTFBMs,
+			&s.BudgetCalls,
+			&s.BudgetMetCount,
 			&s.TotalReqSize,
 			&s.TotalRespSize,
 			&lastCalled,
@@ -373,6 +400,9 @@ func (m *Manager) GetStatsPerNormalizedPath(profileName string) ([]Stats, error)
 			AVG(a.duration_ms) as avg_duration,
 			MIN(a.duration_ms) as min_duration,
 			MAX(a.duration_ms) as max_duration,
+			COALESCE(AVG(NULLIF(a.ttfb_ms, 0)), 0) as avg_ttfb,
+			SUM(CASE WHEN a.budget_ms > 0 THEN 1 ELSE 0 END) as budget_calls,
+			SUM(CASE WHEN a.budget_ms > 0 AND a.budget_met THEN 1 ELSE 0 END) as budget_met,
 			SUM(a.request_size) as total_req_size,
 			SUM(a.response_size) as total_resp_size,
 			MAX(a.timestamp) as last_called,
@@ -406,6 +436,9 @@ func (m *Manager) GetStatsPerNormalizedPath(profileName string) ([]Stats, error)
 			&s.AvgDurationMs,
 			&s.MinDurationMs,
 			&s.MaxDurationMs,
+			&s.AvgTTFBMs,
+			&s.BudgetCalls,
+			&s.BudgetMetCount,
 			&s.TotalReqSize,
 			&s.TotalRespSize,
 			&lastCalled,
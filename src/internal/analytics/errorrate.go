@@ -0,0 +1,62 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrorRate is the error rate of one endpoint over a recent window
+type ErrorRate struct {
+	NormalizedPath string
+	Method         string
+	Calls          int
+	Errors         int // Status >= 400 or network errors
+}
+
+// Percent returns the share of failed calls in percent
+func (r ErrorRate) Percent() float64 {
+	if r.Calls == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Calls) * 100
+}
+
+// Exceeds reports whether the error rate is above threshold (in percent)
+func (r ErrorRate) Exceeds(threshold float64) bool {
+	return r.Errors > 0 && r.Percent() > threshold
+}
+
+// GetRecentErrorRates returns the error rate per normalized path of the calls made since since
+// Endpoints are ordered by error rate, highest first.
+func (m *Manager) GetRecentErrorRates(profileName string, since time.Time) ([]ErrorRate, error) {
+	query := `
+		SELECT
+			normalized_path,
+			method,
+			COUNT(*) as calls,
+			SUM(CASE WHEN status_code >= 400 OR status_code = 0 THEN 1 ELSE 0 END) as errors
+		FROM analytics
+		WHERE (profile_name = ? OR (profile_name IS NULL AND ? = ''))
+			AND timestamp >= ?
+		GROUP BY normalized_path, method
+		ORDER BY CAST(errors AS REAL) / calls DESC, calls DESC
+	`
+
+	// Timestamps are stored in local time
+	rows, err := m.db.Query(query, profileName, profileName, since.Local().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get error rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []ErrorRate
+	for rows.Next() {
+		var r ErrorRate
+		if err := rows.Scan(&r.NormalizedPath, &r.Method, &r.Calls, &r.Errors); err != nil {
+			return nil, fmt.Errorf("failed to scan error rate: %w", err)
+		}
+		rates = append(rates, r)
+	}
+
+	return rates, rows.Err()
+}
@@ -0,0 +1,173 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export formats
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+)
+
+// ExportFilter narrows and orders the exported entries
+type ExportFilter struct {
+	ProfileName *string   // Only export this profile (nil = all profiles)
+	Since       time.Time // Only export entries recorded at or after this time (zero = all)
+	GroupByPath bool      // Order by normalized path instead of file path
+}
+
+// exportRow is the JSON representation of an exported entry
+type exportRow struct {
+	FilePath       string `json:"filePath"`
+	NormalizedPath string `json:"normalizedPath"`
+	Method         string `json:"method"`
+	StatusCode     int    `json:"status"`
+	RequestSize    int64  `json:"requestSize"`
+	ResponseSize   int64  `json:"responseSize"`
+	DurationMs     int64  `json:"durationMs"`
+	BudgetMs       int64  `json:"budgetMs,omitempty"`
+	BudgetMet      *bool  `json:"budgetMet,omitempty"` // nil without a budget
+	ProfileName    string `json:"profile"`
+	Timestamp      string `json:"timestamp"`
+	ErrorMessage   string `json:"error,omitempty"`
+}
+
+var exportCSVHeader = []string{"file_path", "normalized_path", "method", "status", "request_size", "response_size", "duration_ms", "budget_ms", "budget_met", "profile", "timestamp", "error"}
+
+// Export writes all analytics entries to w as csv or json
+func (m *Manager) Export(w io.Writer, format string) (int, error) {
+	return m.ExportFiltered(w, format, ExportFilter{})
+}
+
+// ExportFiltered writes the analytics entries matching filter to w as csv or json
+// Rows are streamed from the database one at a time and the number written is returned
+func (m *Manager) ExportFiltered(w io.Writer, format string, filter ExportFilter) (int, error) {
+	format = strings.ToLower(format)
+	if format != ExportCSV && format != ExportJSON {
+		return 0, fmt.Errorf("unsupported export format: %s (expected csv or json)", format)
+	}
+
+	query := `
+		SELECT id, file_path, normalized_path, method, status_code, request_size, response_size, duration_ms, ttfb_ms, budget_ms, budget_met, error_message, timestamp, COALESCE(profile_name, '')
+		FROM analytics
+	`
+	var conditions []string
+	var args []interface{}
+	if filter.ProfileName != nil {
+		conditions = append(conditions, `(profile_name = ? OR (profile_name IS NULL AND ? = ''))`)
+		args = append(args, *filter.ProfileName, *filter.ProfileName)
+	}
+	if !filter.Since.IsZero() {
+		// Timestamps are stored in local time
+		conditions = append(conditions, `timestamp >= ?`)
+		args = append(args, filter.Since.Local().Format("2006-01-02 15:04:05"))
+	}
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	if filter.GroupByPath {
+		query += ` ORDER BY normalized_path, method, timestamp DESC`
+	} else {
+		query += ` ORDER BY file_path, method, timestamp DESC`
+	}
+
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query analytics for export: %w", err)
+	}
+	defer rows.Close()
+
+	var csvWriter *csv.Writer
+	if format == ExportCSV {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(exportCSVHeader); err != nil {
+			return 0, fmt.Errorf("failed to write export: %w", err)
+		}
+	} else {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return 0, fmt.Errorf("failed to write export: %w", err)
+		}
+	}
+
+	count := 0
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return count, err
+		}
+		row := exportRow{
+			FilePath:       e.FilePath,
+			NormalizedPath: e.NormalizedPath,
+			Method:         e.Method,
+			StatusCode:     e.StatusCode,
+			RequestSize:    e.RequestSize,
+			ResponseSize:   e.ResponseSize,
+			DurationMs:     e.DurationMs,
+			BudgetMs:       e.BudgetMs,
+			ProfileName:    e.ProfileName,
+			Timestamp:      e.Timestamp.Format(time.RFC3339),
+			ErrorMessage:   e.ErrorMessage,
+		}
+		budgetMet := ""
+		if e.BudgetMs > 0 {
+			row.BudgetMet = &e.BudgetMet
+			budgetMet = strconv.FormatBool(e.BudgetMet)
+		}
+
+		if csvWriter != nil {
+			err = csvWriter.Write([]string{
+				row.FilePath,
+				row.NormalizedPath,
+				row.Method,
+				strconv.Itoa(row.StatusCode),
+				strconv.FormatInt(row.RequestSize, 10),
+				strconv.FormatInt(row.ResponseSize, 10),
+				strconv.FormatInt(row.DurationMs, 10),
+				strconv.FormatInt(row.BudgetMs, 10),
+				budgetMet,
+				row.ProfileName,
+				row.Timestamp,
+				row.ErrorMessage,
+			})
+		} else {
+			var data []byte
+			if data, err = json.Marshal(row); err == nil {
+				separator := "\n  "
+				if count > 0 {
+					separator = ",\n  "
+				}
+				_, err = io.WriteString(w, separator+string(data))
+			}
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to write export: %w", err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("failed to read analytics for export: %w", err)
+	}
+
+	if csvWriter != nil {
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return count, fmt.Errorf("failed to write export: %w", err)
+		}
+		return count, nil
+	}
+	closing := "]\n"
+	if count > 0 {
+		closing = "\n]\n"
+	}
+	if _, err := io.WriteString(w, closing); err != nil {
+		return count, fmt.Errorf("failed to write export: %w", err)
+	}
+	return count, nil
+}
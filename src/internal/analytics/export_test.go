@@ -0,0 +1,169 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestManager returns a manager backed by a database in a temporary directory
+func newTestManager(t *testing.T, entries ...Entry) *Manager {
+	t.Helper()
+	m, err := NewManager(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	t.Cleanup(func() { m.Close() })
+	for _, entry := range entries {
+		if err := m.Save(entry); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	return m
+}
+
+func TestExportFiltered_CSV(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	m := newTestManager(t,
+		Entry{FilePath: "/w/users.http", NormalizedPath: "/users/{id}", Method: "GET", StatusCode: 200, RequestSize: 10, ResponseSize: 200, DurationMs: 30, BudgetMs: 50, BudgetMet: true, ProfileName: "dev", Timestamp: now},
+		Entry{FilePath: "/w/search.http", NormalizedPath: "/search?q=a,b&t=\"x\"\nnext", Method: "POST", ErrorMessage: "dial tcp: refused", ProfileName: "dev", Timestamp: now},
+	)
+
+	var out bytes.Buffer
+	count, err := m.ExportFiltered(&out, ExportCSV, ExportFilter{})
+	if err != nil {
+		t.Fatalf("ExportFiltered failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 rows, got %d", count)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("Export is not valid CSV: %v\n%s", err, out.String())
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected a header and 2 rows, got %d records", len(records))
+	}
+	if strings.Join(records[0], ",") != strings.Join(exportCSVHeader, ",") {
+		t.Errorf("Unexpected header: %v", records[0])
+	}
+
+	// Ordered by file path
+	search, users := records[1], records[2]
+	if search[1] != "/search?q=a,b&t=\"x\"\nnext" {
+		t.Errorf("Expected commas, quotes and newlines to round-trip, got %q", search[1])
+	}
+	if search[3] != "0" || search[8] != "" || search[11] != "dial tcp: refused" {
+		t.Errorf("Unexpected network error row: %v", search)
+	}
+	expected := []string{"/w/users.http", "/users/{id}", "GET", "200", "10", "200", "30", "50", "true", "dev", now.Format(time.RFC3339), ""}
+	if strings.Join(users, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v, got %v", expected, users)
+	}
+}
+
+func TestExportFiltered_JSON(t *testing.T) {
+	m := newTestManager(t,
+		Entry{FilePath: "/w/a.http", NormalizedPath: "/a", Method: "GET", StatusCode: 200, DurationMs: 5, ProfileName: "dev", Timestamp: time.Now()},
+		Entry{FilePath: "/w/b.http", NormalizedPath: "/b", Method: "GET", StatusCode: 500, DurationMs: 9, BudgetMs: 5, ProfileName: "dev", Timestamp: time.Now()},
+	)
+
+	var out bytes.Buffer
+	if _, err := m.ExportFiltered(&out, "JSON", ExportFilter{}); err != nil {
+		t.Fatalf("ExportFiltered failed: %v", err)
+	}
+
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &rows); err != nil {
+		t.Fatalf("Export is not a JSON array: %v\n%s", err, out.String())
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if rows[0]["filePath"] != "/w/a.http" || rows[0]["status"] != float64(200) || rows[0]["profile"] != "dev" {
+		t.Errorf("Unexpected first row: %v", rows[0])
+	}
+	// budgetMet is only present with a budget
+	if _, ok := rows[0]["budgetMet"]; ok {
+		t.Errorf("Expected no budgetMet without a budget, got %v", rows[0])
+	}
+	if rows[1]["budgetMet"] != false {
+		t.Errorf("Expected budgetMet false over budget, got %v", rows[1]["budgetMet"])
+	}
+}
+
+func TestExportFiltered_Empty(t *testing.T) {
+	m := newTestManager(t)
+
+	var out bytes.Buffer
+	count, err := m.ExportFiltered(&out, ExportJSON, ExportFilter{})
+	if err != nil || count != 0 {
+		t.Fatalf("Expected 0 rows, got %d (%v)", count, err)
+	}
+	if out.String() != "[]\n" {
+		t.Errorf("Expected an empty JSON array, got %q", out.String())
+	}
+
+	out.Reset()
+	if _, err := m.ExportFiltered(&out, ExportCSV, ExportFilter{}); err != nil {
+		t.Fatalf("ExportFiltered failed: %v", err)
+	}
+	if out.String() != strings.Join(exportCSVHeader, ",")+"\n" {
+		t.Errorf("Expected only the CSV header, got %q", out.String())
+	}
+}
+
+func TestExportFiltered_Filters(t *testing.T) {
+	now := time.Now()
+	m := newTestManager(t,
+		Entry{FilePath: "/w/dev-recent.http", Method: "GET", ProfileName: "dev", Timestamp: now.Add(-time.Hour)},
+		Entry{FilePath: "/w/dev-old.http", Method: "GET", ProfileName: "dev", Timestamp: now.Add(-48 * time.Hour)},
+		Entry{FilePath: "/w/prod-recent.http", Method: "GET", ProfileName: "prod", Timestamp: now.Add(-time.Hour)},
+		Entry{FilePath: "/w/none.http", Method: "GET", Timestamp: now.Add(-time.Hour)},
+	)
+	dev, none := "dev", ""
+
+	tests := []struct {
+		name     string
+		filter   ExportFilter
+		expected []string
+	}{
+		{"all", ExportFilter{}, []string{"/w/dev-old.http", "/w/dev-recent.http", "/w/none.http", "/w/prod-recent.http"}},
+		{"profile", ExportFilter{ProfileName: &dev}, []string{"/w/dev-old.http", "/w/dev-recent.http"}},
+		{"entries without a profile", ExportFilter{ProfileName: &none}, []string{"/w/none.http"}},
+		{"since", ExportFilter{Since: now.Add(-24 * time.Hour)}, []string{"/w/dev-recent.http", "/w/none.http", "/w/prod-recent.http"}},
+		{"profile and since", ExportFilter{ProfileName: &dev, Since: now.Add(-24 * time.Hour)}, []string{"/w/dev-recent.http"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if _, err := m.ExportFiltered(&out, ExportCSV, tt.filter); err != nil {
+				t.Fatalf("ExportFiltered failed: %v", err)
+			}
+			records, err := csv.NewReader(&out).ReadAll()
+			if err != nil {
+				t.Fatalf("Export is not valid CSV: %v", err)
+			}
+			var files []string
+			for _, record := range records[1:] {
+				files = append(files, record[0])
+			}
+			if strings.Join(files, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, files)
+			}
+		})
+	}
+}
+
+func TestExportFiltered_UnsupportedFormat(t *testing.T) {
+	m := newTestManager(t)
+	if _, err := m.ExportFiltered(&bytes.Buffer{}, "xml", ExportFilter{}); err == nil || !strings.Contains(err.Error(), "unsupported export format") {
+		t.Errorf("Expected an unsupported format error, got %v", err)
+	}
+}
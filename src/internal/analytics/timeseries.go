@@ -0,0 +1,119 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+)
+
+// Bucket is the time window entries are grouped by in a time series
+type Bucket string
+
+// Bucket sizes
+const (
+	BucketHour Bucket = "hour"
+	BucketDay  Bucket = "day"
+)
+
+// TimeSeriesQuery selects the entries of one endpoint for a time series
+type TimeSeriesQuery struct {
+	ProfileName    string
+	FilePath       string // Empty matches every file (grouping by normalized path)
+	NormalizedPath string
+	Method         string
+	Bucket         Bucket
+	Since          time.Time
+}
+
+// TimeSeriesPoint aggregates the entries of one bucket
+type TimeSeriesPoint struct {
+	Start         time.Time
+	Calls         int
+	Errors        int // Status >= 400 or network errors
+	AvgDurationMs float64
+}
+
+// truncate returns the start of the bucket containing t
+func (b Bucket) truncate(t time.Time) time.Time {
+	t = t.Local()
+	if b == BucketDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, time.Local)
+}
+
+// next returns the start of the bucket following start
+func (b Bucket) next(start time.Time) time.Time {
+	if b == BucketDay {
+		return start.AddDate(0, 0, 1)
+	}
+	return start.Add(time.Hour)
+}
+
+// GetTimeSeries groups the entries matching q by time window, oldest first
+// Every bucket between q.Since and now is returned; buckets without calls have zero values.
+func (m *Manager) GetTimeSeries(q TimeSeriesQuery) ([]TimeSeriesPoint, error) {
+	format := "%Y-%m-%d %H:00:00"
+	if q.Bucket == BucketDay {
+		format = "%Y-%m-%d 00:00:00"
+	} else if q.Bucket != BucketHour {
+		return nil, fmt.Errorf("unsupported bucket: %s (expected hour or day)", q.Bucket)
+	}
+
+	// Timestamps are stored in local time, so the bucket strings are local too
+	query := `
+		SELECT
+			strftime('` + format + `', timestamp) as bucket,
+			COUNT(*) as calls,
+			SUM(CASE WHEN status_code >= 400 OR status_code = 0 THEN 1 ELSE 0 END) as errors,
+			AVG(duration_ms) as avg_duration
+		FROM analytics
+		WHERE (profile_name = ? OR (profile_name IS NULL AND ? = ''))
+			AND (file_path = ? OR ? = '')
+			AND normalized_path = ? AND method = ?
+			AND timestamp >= ?
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+
+	since := q.Bucket.truncate(q.Since)
+	rows, err := m.db.Query(query,
+		q.ProfileName, q.ProfileName,
+		q.FilePath, q.FilePath,
+		q.NormalizedPath, q.Method,
+		since.Format("2006-01-02 15:04:05"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time series: %w", err)
+	}
+	defer rows.Close()
+
+	byStart := make(map[int64]TimeSeriesPoint)
+	for rows.Next() {
+		var bucket string
+		var p TimeSeriesPoint
+		if err := rows.Scan(&bucket, &p.Calls, &p.Errors, &p.AvgDurationMs); err != nil {
+			return nil, fmt.Errorf("failed to scan time series: %w", err)
+		}
+		p.Start, err = time.ParseInLocation("2006-01-02 15:04:05", bucket, time.Local)
+		if err != nil {
+			continue
+		}
+		byStart[p.Start.Unix()] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Fill the gaps so each point is one bucket wide
+	var points []TimeSeriesPoint
+	end := q.Bucket.truncate(time.Now())
+	for start := since; !start.After(end); start = q.Bucket.next(start) {
+		p, ok := byStart[start.Unix()]
+		if !ok {
+			p = TimeSeriesPoint{Start: start}
+		}
+		points = append(points, p)
+	}
+
+	return points, nil
+}
@@ -0,0 +1,192 @@
+// Package assertion evaluates request expectations (status codes, body checks, latency budget) against responses.
+// It is shared by the CLI (--assert, --junit) and stress testing.
+package assertion
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Result is the outcome of a single expectation
+type Result struct {
+	Name    string // Expectation name (status, bodyExact, bodyContains, bodyPattern, bodyField.<name>, maxDuration)
+	Passed  bool
+	Message string // Failure reason (empty when passed)
+}
+
+// Evaluate checks a response against every expectation set on the request
+// The status expectation always applies (defaults to 2xx when no codes are set)
+// A request that never got a response yields a single failed "request" result
+func Evaluate(req *types.HttpRequest, result *types.RequestResult) []Result {
+	if result.Status == 0 && result.Error != "" {
+		return []Result{{Name: "request", Message: "request failed: " + result.Error}}
+	}
+
+	results := []Result{check("status", checkStatus(req, result.Status))}
+
+	if req.ExpectedBodyExact != "" {
+		results = append(results, check("bodyExact", checkBodyExact(req.ExpectedBodyExact, result.Body)))
+	}
+	if req.ExpectedBodyContains != "" {
+		results = append(results, check("bodyContains", checkBodyContains(req.ExpectedBodyContains, result.Body)))
+	}
+	if req.ExpectedBodyPattern != "" {
+		results = append(results, check("bodyPattern", checkBodyPattern(req.ExpectedBodyPattern, result.Body)))
+	}
+	if len(req.ExpectedBodyFields) > 0 {
+		results = append(results, checkBodyFields(req.ExpectedBodyFields, result.Body)...)
+	}
+	if result.MaxDurationMs > 0 {
+		results = append(results, check("maxDuration", checkDuration(result)))
+	}
+
+	return results
+}
+
+// Failed returns the failed results
+func Failed(results []Result) []Result {
+	var failed []Result
+	for _, r := range results {
+		if !r.Passed {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// CheckBody validates the response body against the request body expectations
+// Returns an empty string if validation passes, or the first failure message
+func CheckBody(req *types.HttpRequest, body string) string {
+	if req.ExpectedBodyExact != "" {
+		if msg := checkBodyExact(req.ExpectedBodyExact, body); msg != "" {
+			return msg
+		}
+	}
+	if req.ExpectedBodyContains != "" {
+		if msg := checkBodyContains(req.ExpectedBodyContains, body); msg != "" {
+			return msg
+		}
+	}
+	if req.ExpectedBodyPattern != "" {
+		if msg := checkBodyPattern(req.ExpectedBodyPattern, body); msg != "" {
+			return msg
+		}
+	}
+	if len(req.ExpectedBodyFields) > 0 {
+		for _, r := range checkBodyFields(req.ExpectedBodyFields, body) {
+			if !r.Passed {
+				return r.Message
+			}
+		}
+	}
+	return ""
+}
+
+// check builds a result from a failure message (empty = passed)
+func check(name, message string) Result {
+	return Result{Name: name, Passed: message == "", Message: message}
+}
+
+func checkStatus(req *types.HttpRequest, status int) string {
+	if req.IsExpectedStatus(status) {
+		return ""
+	}
+	expected := "2xx"
+	if len(req.ExpectedStatusCodes) > 0 {
+		codes := make([]string, len(req.ExpectedStatusCodes))
+		for i, code := range req.ExpectedStatusCodes {
+			codes[i] = strconv.Itoa(code)
+		}
+		expected = strings.Join(codes, ", ")
+	}
+	return fmt.Sprintf("unexpected status %d (expected %s)", status, expected)
+}
+
+// checkDuration checks the latency budget the executor applied (request or profile maxDurationMs)
+func checkDuration(result *types.RequestResult) string {
+	if result.OverBudget() {
+		return fmt.Sprintf("took %dms, over the %dms budget", result.Duration, result.MaxDurationMs)
+	}
+	return ""
+}
+
+func checkBodyExact(expected, body string) string {
+	if body != expected {
+		return fmt.Sprintf("body does not match expected exact value (expected: %q, got: %q)", expected, body)
+	}
+	return ""
+}
+
+func checkBodyContains(expected, body string) string {
+	if !strings.Contains(body, expected) {
+		return fmt.Sprintf("body does not contain expected substring: %s", expected)
+	}
+	return ""
+}
+
+func checkBodyPattern(pattern, body string) string {
+	matched, err := regexp.MatchString(pattern, body)
+	if err != nil {
+		return fmt.Sprintf("invalid body pattern regex: %v", err)
+	}
+	if !matched {
+		return fmt.Sprintf("body does not match expected pattern: %s", pattern)
+	}
+	return ""
+}
+
+// checkBodyFields validates top-level JSON fields (values wrapped in /.../ are regex patterns)
+// Fields are checked in name order so reports are stable
+func checkBodyFields(fields map[string]string, body string) []Result {
+	var bodyJSON map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &bodyJSON); err != nil {
+		return []Result{check("bodyFields", fmt.Sprintf("failed to parse JSON body for field validation: %v", err))}
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]Result, 0, len(names))
+	for _, fieldName := range names {
+		results = append(results, check("bodyField."+fieldName, checkBodyField(bodyJSON, fieldName, fields[fieldName])))
+	}
+	return results
+}
+
+func checkBodyField(bodyJSON map[string]interface{}, fieldName, expectedValue string) string {
+	actualValue, exists := bodyJSON[fieldName]
+	if !exists {
+		return fmt.Sprintf("expected field '%s' not found in response", fieldName)
+	}
+
+	// Convert actual value to string for comparison
+	actualStr := fmt.Sprintf("%v", actualValue)
+
+	// Check if expected value is a regex pattern (starts and ends with /)
+	if strings.HasPrefix(expectedValue, "/") && strings.HasSuffix(expectedValue, "/") {
+		pattern := expectedValue[1 : len(expectedValue)-1]
+		matched, err := regexp.MatchString(pattern, actualStr)
+		if err != nil {
+			return fmt.Sprintf("invalid regex pattern for field '%s': %v", fieldName, err)
+		}
+		if !matched {
+			return fmt.Sprintf("field '%s' value '%s' does not match pattern '%s'", fieldName, actualStr, pattern)
+		}
+		return ""
+	}
+
+	// Literal value comparison
+	if actualStr != expectedValue {
+		return fmt.Sprintf("field '%s' expected '%s' but got '%s'", fieldName, expectedValue, actualStr)
+	}
+	return ""
+}
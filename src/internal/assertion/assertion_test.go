@@ -0,0 +1,135 @@
+package assertion
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestEvaluate_AllPassed(t *testing.T) {
+	req := &types.HttpRequest{
+		ExpectedStatusCodes:  []int{201},
+		ExpectedBodyContains: "alice",
+		ExpectedBodyPattern:  `"id":\s*\d+`,
+		ExpectedBodyFields:   map[string]string{"name": "alice", "id": "/^[0-9]+$/"},
+	}
+	result := &types.RequestResult{Status: 201, Body: `{"id": 42, "name": "alice"}`}
+
+	results := Evaluate(req, result)
+	if len(results) != 5 {
+		t.Fatalf("Expected 5 results, got %d: %+v", len(results), results)
+	}
+	if failed := Failed(results); len(failed) != 0 {
+		t.Errorf("Expected all assertions to pass, got %+v", failed)
+	}
+	if results[3].Name != "bodyField.id" || results[4].Name != "bodyField.name" {
+		t.Errorf("Expected body fields in name order, got %s, %s", results[3].Name, results[4].Name)
+	}
+}
+
+func TestEvaluate_DefaultStatus(t *testing.T) {
+	results := Evaluate(&types.HttpRequest{}, &types.RequestResult{Status: 500})
+
+	failed := Failed(results)
+	if len(failed) != 1 || failed[0].Name != "status" || !strings.Contains(failed[0].Message, "expected 2xx") {
+		t.Errorf("Expected default 2xx status failure, got %+v", results)
+	}
+}
+
+func TestEvaluate_MaxDuration(t *testing.T) {
+	within := Evaluate(&types.HttpRequest{}, &types.RequestResult{Status: 200, Duration: 500, MaxDurationMs: 500})
+	if len(within) != 2 || within[1].Name != "maxDuration" || !within[1].Passed {
+		t.Errorf("Expected a passing maxDuration check, got %+v", within)
+	}
+
+	failed := Failed(Evaluate(&types.HttpRequest{}, &types.RequestResult{Status: 200, Duration: 812, MaxDurationMs: 500}))
+	if len(failed) != 1 || failed[0].Message != "took 812ms, over the 500ms budget" {
+		t.Errorf("Expected a maxDuration failure, got %+v", failed)
+	}
+
+	// No budget, no check
+	if results := Evaluate(&types.HttpRequest{}, &types.RequestResult{Status: 200, Duration: 812}); len(results) != 1 {
+		t.Errorf("Expected only the status check, got %+v", results)
+	}
+}
+
+func TestEvaluate_Failures(t *testing.T) {
+	req := &types.HttpRequest{
+		ExpectedStatusCodes:  []int{200, 204},
+		ExpectedBodyContains: "missing",
+		ExpectedBodyFields:   map[string]string{"name": "bob"},
+	}
+	result := &types.RequestResult{Status: 404, Body: `{"name": "alice"}`}
+
+	failed := Failed(Evaluate(req, result))
+	if len(failed) != 3 {
+		t.Fatalf("Expected 3 failures, got %+v", failed)
+	}
+	if failed[0].Message != "unexpected status 404 (expected 200, 204)" {
+		t.Errorf("Unexpected status message: %s", failed[0].Message)
+	}
+	if failed[2].Message != "field 'name' expected 'bob' but got 'alice'" {
+		t.Errorf("Unexpected field message: %s", failed[2].Message)
+	}
+}
+
+func TestEvaluate_RequestError(t *testing.T) {
+	results := Evaluate(&types.HttpRequest{}, &types.RequestResult{Error: "connection refused"})
+
+	if len(results) != 1 || results[0].Name != "request" || results[0].Passed {
+		t.Errorf("Expected a single failed request result, got %+v", results)
+	}
+}
+
+func TestCheckBody(t *testing.T) {
+	req := &types.HttpRequest{ExpectedBodyExact: "ok"}
+	if msg := CheckBody(req, "ok"); msg != "" {
+		t.Errorf("Expected body to pass, got %q", msg)
+	}
+	if msg := CheckBody(req, "nope"); !strings.Contains(msg, "expected exact value") {
+		t.Errorf("Expected exact match failure, got %q", msg)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	cases := []TestCase{
+		{Name: "Health", ClassName: "health.http", DurationMs: 125, Results: []Result{{Name: "status", Passed: true}}},
+		{Name: "Users", ClassName: "users.http", DurationMs: 40, Results: []Result{
+			{Name: "status", Passed: true},
+			{Name: "bodyContains", Message: "body does not contain expected substring: alice"},
+		}},
+		{Name: "Down", ClassName: "down.http", Results: []Result{{Name: "request", Message: "request failed: connection refused"}}},
+	}
+
+	if err := WriteJUnit(path, "smoke", cases); err != nil {
+		t.Fatalf("WriteJUnit failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Report is not valid XML: %v", err)
+	}
+	suite := report.Suites[0]
+	if suite.Tests != 3 || suite.Failures != 1 || suite.Errors != 1 {
+		t.Errorf("Unexpected counts: tests=%d failures=%d errors=%d", suite.Tests, suite.Failures, suite.Errors)
+	}
+	if suite.Cases[0].Time != "0.125" || suite.Cases[0].Failure != nil {
+		t.Errorf("Unexpected passing case: %+v", suite.Cases[0])
+	}
+	if suite.Cases[1].Failure == nil || suite.Cases[1].Failure.Type != "bodyContains" {
+		t.Errorf("Expected bodyContains failure, got %+v", suite.Cases[1])
+	}
+	if suite.Cases[2].Error == nil {
+		t.Errorf("Expected request error, got %+v", suite.Cases[2])
+	}
+}
@@ -0,0 +1,101 @@
+package assertion
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/config"
+)
+
+// TestCase is one executed request and its assertion results
+type TestCase struct {
+	Name       string // Request name
+	ClassName  string // Request file path
+	DurationMs int64
+	Results    []Result
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes a JUnit XML report with one test case per request
+// Failed assertions become a <failure>; a request without a response becomes an <error>
+func WriteJUnit(path, suiteName string, cases []TestCase) error {
+	suite := junitTestSuite{
+		Name:      suiteName,
+		Tests:     len(cases),
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	var totalMs int64
+	for _, tc := range cases {
+		totalMs += tc.DurationMs
+		jc := junitTestCase{
+			Name:      tc.Name,
+			ClassName: tc.ClassName,
+			Time:      formatSeconds(tc.DurationMs),
+		}
+
+		if failed := Failed(tc.Results); len(failed) > 0 {
+			lines := make([]string, len(failed))
+			for i, r := range failed {
+				lines[i] = r.Name + ": " + r.Message
+			}
+			problem := &junitProblem{Message: failed[0].Message, Type: failed[0].Name, Text: strings.Join(lines, "\n")}
+			if failed[0].Name == "request" {
+				jc.Error = problem
+				suite.Errors++
+			} else {
+				jc.Failure = problem
+				suite.Failures++
+			}
+		}
+		suite.Cases = append(suite.Cases, jc)
+	}
+	suite.Time = formatSeconds(totalMs)
+
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), append(data, '\n')...)
+
+	if err := os.WriteFile(path, data, config.FilePermissions); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+// formatSeconds formats milliseconds as JUnit seconds (e.g. "0.125")
+func formatSeconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
@@ -213,6 +213,13 @@ func FormatDependencyInfo(req *types.HttpRequest) string {
 		info = append(info, fmt.Sprintf("Extract: %s", strings.Join(extracts, ", ")))
 	}
 
+	if req.Condition != "" {
+		info = append(info, fmt.Sprintf("Condition: %s", req.Condition))
+	}
+	if req.ForEach != "" {
+		info = append(info, fmt.Sprintf("For each: %s", req.ForEach))
+	}
+
 	if len(info) == 0 {
 		return ""
 	}
@@ -0,0 +1,74 @@
+package chain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Comparison condition: <left> <op> <right>
+	comparisonPattern = regexp.MustCompile(`^(.+?)\s+(==|!=|<|>)\s+(.+)$`)
+
+	// Existence condition: {{varName}} exists
+	existsPattern = regexp.MustCompile(`^\{\{\s*([^}]+?)\s*\}\}\s+exists$`)
+
+	// Variable placeholder inside an operand
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([^}]+?)\s*\}\}`)
+)
+
+// LookupFunc returns the value of a variable and whether it is defined
+type LookupFunc func(name string) (string, bool)
+
+// EvaluateCondition evaluates a chain step condition such as "{{status}} == 200"
+// Supported forms: ==, != (string comparison), <, > (numeric comparison) and "{{var}} exists"
+// Undefined variables compare as empty strings; exists is false for undefined or empty values
+func EvaluateCondition(condition string, lookup LookupFunc) (bool, error) {
+	condition = strings.TrimSpace(condition)
+	if condition == "" {
+		return true, nil
+	}
+
+	if match := existsPattern.FindStringSubmatch(condition); match != nil {
+		value, ok := lookup(match[1])
+		return ok && value != "", nil
+	}
+
+	match := comparisonPattern.FindStringSubmatch(condition)
+	if match == nil {
+		return false, fmt.Errorf("invalid condition %q (expected <left> ==|!=|<|> <right> or {{var}} exists)", condition)
+	}
+
+	left := resolveOperand(match[1], lookup)
+	right := resolveOperand(match[3], lookup)
+
+	switch match[2] {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	default:
+		leftNum, errLeft := strconv.ParseFloat(left, 64)
+		rightNum, errRight := strconv.ParseFloat(right, 64)
+		if errLeft != nil || errRight != nil {
+			return false, fmt.Errorf("condition %q: %s needs numeric operands (got %q and %q)", condition, match[2], left, right)
+		}
+		if match[2] == "<" {
+			return leftNum < rightNum, nil
+		}
+		return leftNum > rightNum, nil
+	}
+}
+
+// resolveOperand substitutes variables in an operand and strips surrounding quotes
+func resolveOperand(operand string, lookup LookupFunc) string {
+	operand = strings.TrimSpace(operand)
+	if len(operand) >= 2 && (operand[0] == '"' || operand[0] == '\'') && operand[len(operand)-1] == operand[0] {
+		operand = operand[1 : len(operand)-1]
+	}
+	return placeholderPattern.ReplaceAllStringFunc(operand, func(placeholder string) string {
+		value, _ := lookup(placeholderPattern.FindStringSubmatch(placeholder)[1])
+		return value
+	})
+}
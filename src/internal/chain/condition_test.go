@@ -0,0 +1,55 @@
+package chain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	vars := map[string]string{"status": "200", "role": "admin", "count": "3", "empty": "", "greeting": "hello world"}
+	lookup := func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{"{{status}} == 200", true},
+		{"{{status}} != 200", false},
+		{"{{role}} == admin", true},
+		{"{{role}} == \"admin\"", true},
+		{"{{greeting}} == 'hello world'", true},
+		{"{{count}} > 2", true},
+		{"{{count}} < 2", false},
+		{"{{role}} exists", true},
+		{"{{empty}} exists", false},
+		{"{{missing}} exists", false},
+		{"{{missing}} == \"\"", true},
+		{"{{missing}} != admin", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		result, err := EvaluateCondition(tt.condition, lookup)
+		if err != nil {
+			t.Errorf("EvaluateCondition(%q) failed: %v", tt.condition, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("EvaluateCondition(%q) = %v, expected %v", tt.condition, result, tt.expected)
+		}
+	}
+}
+
+func TestEvaluateCondition_Errors(t *testing.T) {
+	lookup := func(name string) (string, bool) { return "admin", true }
+
+	if _, err := EvaluateCondition("{{role}}", lookup); err == nil || !strings.Contains(err.Error(), "invalid condition") {
+		t.Errorf("Expected invalid condition error, got %v", err)
+	}
+	if _, err := EvaluateCondition("{{role}} > 2", lookup); err == nil || !strings.Contains(err.Error(), "numeric") {
+		t.Errorf("Expected numeric operand error, got %v", err)
+	}
+}
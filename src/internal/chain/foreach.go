@@ -0,0 +1,85 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// ForEachVariable returns the variable name referenced by a @forEach directive ("{{ids}}" or "ids")
+func ForEachVariable(forEach string) string {
+	name := strings.TrimSpace(forEach)
+	name = strings.TrimPrefix(name, "{{")
+	name = strings.TrimSuffix(name, "}}")
+	return strings.TrimSpace(name)
+}
+
+// ForEachItems decodes an extracted JSON array into one string per element
+// Strings are used as-is, other values (numbers, objects) as their JSON encoding
+func ForEachItems(value string) ([]string, error) {
+	var elements []interface{}
+	if err := json.Unmarshal([]byte(value), &elements); err != nil {
+		return nil, fmt.Errorf("value is not a JSON array: %s", value)
+	}
+
+	items := make([]string, len(elements))
+	for i, element := range elements {
+		if s, ok := element.(string); ok {
+			items[i] = s
+			continue
+		}
+		encoded, err := json.Marshal(element)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode item %d: %w", i, err)
+		}
+		items[i] = string(encoded)
+	}
+	return items, nil
+}
+
+// ForEachIteration is the response of one @forEach iteration
+type ForEachIteration struct {
+	Item   string
+	Result *types.RequestResult
+}
+
+// AggregateForEach combines @forEach iteration responses into a single result
+// The body is a JSON array of {item, status, body} (JSON bodies are embedded as-is);
+// the status is the highest status seen and durations and sizes are summed
+func AggregateForEach(iterations []ForEachIteration, total int) *types.RequestResult {
+	type entry struct {
+		Item   string      `json:"item"`
+		Status int         `json:"status"`
+		Error  string      `json:"error,omitempty"`
+		Body   interface{} `json:"body,omitempty"`
+	}
+
+	aggregated := &types.RequestResult{Headers: make(map[string]string)}
+	entries := make([]entry, 0, len(iterations))
+	for _, it := range iterations {
+		e := entry{Item: it.Item, Status: it.Result.Status, Error: it.Result.Error}
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(it.Result.Body), &parsed); err == nil {
+			e.Body = parsed
+		} else if it.Result.Body != "" {
+			e.Body = it.Result.Body
+		}
+		entries = append(entries, e)
+
+		if it.Result.Status > aggregated.Status {
+			aggregated.Status = it.Result.Status
+		}
+		aggregated.Duration += it.Result.Duration
+		aggregated.RequestSize += it.Result.RequestSize
+		aggregated.ResponseSize += it.Result.ResponseSize
+		aggregated.Headers = it.Result.Headers
+		aggregated.Timestamp = it.Result.Timestamp
+	}
+
+	body, _ := json.MarshalIndent(entries, "", "  ")
+	aggregated.Body = string(body)
+	aggregated.StatusText = fmt.Sprintf("forEach: %d/%d iterations", len(iterations), total)
+	return aggregated
+}
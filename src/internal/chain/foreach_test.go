@@ -0,0 +1,64 @@
+package chain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestForEachVariable(t *testing.T) {
+	for _, input := range []string{"{{ids}}", "{{ ids }}", "ids"} {
+		if got := ForEachVariable(input); got != "ids" {
+			t.Errorf("ForEachVariable(%q) = %q, want ids", input, got)
+		}
+	}
+}
+
+func TestForEachItems(t *testing.T) {
+	items, err := ForEachItems(`["a", 2, {"id": 3}]`)
+	if err != nil {
+		t.Fatalf("ForEachItems failed: %v", err)
+	}
+	want := []string{"a", "2", `{"id":3}`}
+	if len(items) != len(want) {
+		t.Fatalf("Expected %d items, got %v", len(want), items)
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("Item %d = %q, want %q", i, items[i], want[i])
+		}
+	}
+}
+
+func TestForEachItems_NotArray(t *testing.T) {
+	if _, err := ForEachItems("42"); err == nil {
+		t.Error("Expected error for non-array value")
+	}
+}
+
+func TestAggregateForEach(t *testing.T) {
+	iterations := []ForEachIteration{
+		{Item: "1", Result: &types.RequestResult{Status: 200, Body: `{"id": 1}`, Duration: 10, ResponseSize: 9}},
+		{Item: "2", Result: &types.RequestResult{Status: 404, Body: "not found", Duration: 5, ResponseSize: 9}},
+	}
+
+	result := AggregateForEach(iterations, 3)
+	if result.Status != 404 || result.Duration != 15 || result.ResponseSize != 18 {
+		t.Errorf("Unexpected aggregate: status=%d duration=%d size=%d", result.Status, result.Duration, result.ResponseSize)
+	}
+	if result.StatusText != "forEach: 2/3 iterations" {
+		t.Errorf("Unexpected status text: %s", result.StatusText)
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(result.Body), &entries); err != nil {
+		t.Fatalf("Aggregate body is not JSON: %v", err)
+	}
+	if len(entries) != 2 || entries[1]["body"] != "not found" {
+		t.Errorf("Unexpected entries: %v", entries)
+	}
+	if body, ok := entries[0]["body"].(map[string]interface{}); !ok || body["id"] != float64(1) {
+		t.Errorf("Expected JSON body to be embedded, got %v", entries[0]["body"])
+	}
+}
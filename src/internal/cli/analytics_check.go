@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/analytics"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/session"
+)
+
+// AnalyticsCheckOptions contains options for checking recent error rates
+type AnalyticsCheckOptions struct {
+	Profile   string        // Profile whose analytics are checked (empty = active profile)
+	Threshold float64       // Error rate in percent (0 = the profile's errorRateThreshold)
+	Window    time.Duration // Rolling window (0 = the profile's errorRateWindow)
+}
+
+// RunAnalyticsCheck prints the recent error rate of every endpoint of a profile
+// Exits with 1 when an endpoint is above the threshold, so it can gate a deploy.
+func RunAnalyticsCheck(opts AnalyticsCheckOptions) error {
+	mgr := session.NewManager()
+	if err := mgr.Load(); err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	profile := mgr.GetActiveProfile()
+	if opts.Profile != "" {
+		if profile = mgr.GetProfile(opts.Profile); profile == nil {
+			return fmt.Errorf("profile not found: %s", opts.Profile)
+		}
+	}
+
+	threshold := opts.Threshold
+	if threshold == 0 {
+		threshold = profile.GetErrorRateThreshold()
+	}
+	window := opts.Window
+	if window == 0 {
+		window = time.Duration(profile.GetErrorRateWindow()) * time.Minute
+	}
+
+	analyticsMgr, err := analytics.NewManager(config.DatabasePath)
+	if err != nil {
+		return err
+	}
+	defer analyticsMgr.Close()
+
+	rates, err := analyticsMgr.GetRecentErrorRates(profile.Name, time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+	if len(rates) == 0 {
+		fmt.Fprintf(os.Stderr, "No requests recorded for profile '%s' in the last %s\n", profile.Name, window)
+		return nil
+	}
+
+	if alerts := printErrorRates(os.Stdout, rates, threshold, window); alerts > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// printErrorRates prints one line per endpoint and returns how many are above threshold
+func printErrorRates(w io.Writer, rates []analytics.ErrorRate, threshold float64, window time.Duration) int {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ENDPOINT\tCALLS\tERRORS\tRATE\tRESULT\t")
+	alerts := 0
+	for _, rate := range rates {
+		verdict := colorGreen + "OK" + colorReset
+		if rate.Exceeds(threshold) {
+			alerts++
+			verdict = colorRed + "ALERT" + colorReset
+		}
+		fmt.Fprintf(table, "%s %s\t%d\t%d\t%.1f%%\t%s\t\n",
+			rate.Method, rate.NormalizedPath, rate.Calls, rate.Errors, rate.Percent(), verdict)
+	}
+	table.Flush()
+
+	fmt.Fprintf(w, "\n%d of %d endpoints above %.1f%% errors in the last %s\n", alerts, len(rates), threshold, window)
+	return alerts
+}
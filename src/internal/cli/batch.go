@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/studiowebux/restcli/internal/assertion"
+	"github.com/studiowebux/restcli/internal/chain"
+	"github.com/studiowebux/restcli/internal/executor"
+)
+
+// BatchOptions contains options for running several request files in one invocation
+type BatchOptions struct {
+	Patterns    []string // Files, directories (searched for .http files) or glob patterns
+	Profile     string
+	Environment string   // Environment of the profile, see RunOptions.Environment
+	ExtraVars   []string // key=value pairs from -e flag
+	EnvFile     string   // path to .env file
+	Parallel    int      // Number of files run at the same time
+	JUnitPath   string   // Write a JUnit XML report with one test case per file
+}
+
+// batchResult is the outcome of one file of a batch
+type batchResult struct {
+	File       string
+	Name       string
+	Status     int // 0 when no response was received
+	DurationMs int64
+	Verdict    string // PASS, FAIL or SKIP
+	Message    string // Failure or skip reason
+	Assertions []assertion.Result
+}
+
+// RunBatch runs every matching request file and prints a pass/fail summary
+// A file passes when its expectations hold (status 2xx unless @expectedStatusCodes says
+// otherwise). Files with @depends run their chain first, passing @extract values along.
+// Exits with 1 when a file failed.
+func RunBatch(opts BatchOptions) error {
+	workdir, err := profileWorkdir(opts.Profile)
+	if err != nil {
+		return err
+	}
+	files, err := expandBatchFiles(opts.Patterns, workdir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no request files found")
+	}
+
+	parallel := opts.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt)
+	defer signal.Stop(sigChan)
+
+	// Files share keep-alive connections, one per worker and host
+	connections := executor.NewConnectionPool(parallel)
+
+	results := make([]*batchResult, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var progress sync.Mutex
+	done := 0
+
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := runBatchFile(opts, files[i], workdir, connections)
+				results[i] = &result
+
+				progress.Lock()
+				done++
+				fmt.Fprintf(os.Stderr, "[%d/%d] %s %s\n", done, len(files), result.Verdict, filepath.Base(result.File))
+				progress.Unlock()
+			}
+		}()
+	}
+
+	interrupted := false
+feed:
+	for i := range files {
+		select {
+		case jobs <- i:
+		case <-sigChan:
+			interrupted = true
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	connections.Close()
+
+	var completed []batchResult
+	for _, result := range results {
+		if result != nil {
+			completed = append(completed, *result)
+		}
+	}
+
+	printBatchSummary(os.Stdout, completed)
+	if interrupted {
+		fmt.Fprintf(os.Stderr, "Interrupted: %d of %d files not run\n", len(files)-len(completed), len(files))
+	}
+
+	if opts.JUnitPath != "" {
+		cases := make([]assertion.TestCase, 0, len(completed))
+		for _, result := range completed {
+			cases = append(cases, batchTestCase(result))
+		}
+		if err := assertion.WriteJUnit(opts.JUnitPath, "restcli batch", cases); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "JUnit report saved to %s\n", opts.JUnitPath)
+	}
+
+	if code := batchExitCode(completed, interrupted); code != 0 {
+		os.Exit(code)
+	}
+	return nil
+}
+
+// batchExitCode is 1 when a file failed or the batch was interrupted, 0 otherwise
+// Skipped files do not fail the batch.
+func batchExitCode(results []batchResult, interrupted bool) int {
+	if interrupted {
+		return 1
+	}
+	for _, result := range results {
+		if result.Verdict == "FAIL" {
+			return 1
+		}
+	}
+	return 0
+}
+
+// runBatchFile runs one file of a batch, after the files it depends on
+func runBatchFile(opts BatchOptions, filePath, workdir string, connections *executor.ConnectionPool) batchResult {
+	result := batchResult{File: filePath}
+
+	graph := chain.NewGraph(workdir)
+	if err := graph.BuildGraph(filePath); err != nil {
+		result.Verdict, result.Message = "FAIL", err.Error()
+		return result
+	}
+	order, err := graph.GetExecutionOrder(filePath)
+	if err != nil {
+		result.Verdict, result.Message = "FAIL", err.Error()
+		return result
+	}
+
+	extraVars := append([]string{}, opts.ExtraVars...)
+	for i, step := range order {
+		runOpts := RunOptions{
+			FilePath:    step,
+			Profile:     opts.Profile,
+			Environment: opts.Environment,
+			ExtraVars:   extraVars,
+			EnvFile:     opts.EnvFile,
+			Assert:      true,
+			batch:       true,
+			connections: connections,
+		}
+		outcome, err := runOnce(runOpts, io.Discard)
+		last := i == len(order)-1
+
+		switch {
+		case errors.Is(err, errNeedsConfirmation):
+			result.Verdict = "SKIP"
+			result.Message = fmt.Sprintf("%s requires confirmation", filepath.Base(step))
+			return result
+		case err != nil:
+			result.Verdict, result.Message = "FAIL", err.Error()
+			if !last {
+				result.Message = fmt.Sprintf("dependency %s: %v", filepath.Base(step), err)
+			}
+			return result
+		case !last && outcome.Failed:
+			result.Verdict = "FAIL"
+			result.Message = fmt.Sprintf("dependency %s: %s", filepath.Base(step), failureMessage(outcome.Assertions))
+			return result
+		case !last:
+			for name, value := range outcome.Extracted {
+				extraVars = append(extraVars, name+"="+value)
+			}
+			continue
+		}
+
+		result.Name = outcome.Name
+		result.Status = outcome.Status
+		result.DurationMs = outcome.Duration
+		result.Assertions = outcome.Assertions
+		result.Verdict = "PASS"
+		if outcome.Failed {
+			result.Verdict = "FAIL"
+			result.Message = failureMessage(outcome.Assertions)
+		}
+	}
+	return result
+}
+
+// failureMessage returns the reason of the first failed expectation
+func failureMessage(results []assertion.Result) string {
+	failed := assertion.Failed(results)
+	if len(failed) == 0 {
+		return ""
+	}
+	message := failed[0].Name + ": " + failed[0].Message
+	if len(failed) > 1 {
+		message += fmt.Sprintf(" (+%d more)", len(failed)-1)
+	}
+	return message
+}
+
+// batchTestCase turns a batch result into a JUnit test case
+// Files that failed or were skipped before any expectation ran get a single result carrying the reason.
+func batchTestCase(result batchResult) assertion.TestCase {
+	name := result.Name
+	if name == "" {
+		name = filepath.Base(result.File)
+	}
+	results := result.Assertions
+	if len(results) == 0 {
+		results = []assertion.Result{{Name: "request", Passed: result.Verdict != "FAIL", Message: result.Message}}
+	}
+	return assertion.TestCase{
+		Name:       name,
+		ClassName:  result.File,
+		DurationMs: result.DurationMs,
+		Results:    results,
+	}
+}
+
+// printBatchSummary prints one row per file and the totals
+func printBatchSummary(w io.Writer, results []batchResult) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FILE\tREQUEST\tSTATUS\tTIME\tRESULT\t")
+	passed, failed, skipped := 0, 0, 0
+	for _, result := range results {
+		status := "-"
+		if result.Status != 0 {
+			status = fmt.Sprintf("%d", result.Status)
+		}
+		color := colorGreen
+		switch result.Verdict {
+		case "PASS":
+			passed++
+		case "FAIL":
+			failed++
+			color = colorRed
+		case "SKIP":
+			skipped++
+			color = colorYellow
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%dms\t%s%s%s\t%s\n",
+			filepath.Base(result.File), result.Name, status, result.DurationMs, color, result.Verdict, colorReset, result.Message)
+	}
+	table.Flush()
+
+	summary := fmt.Sprintf("\n%d passed, %d failed", passed, failed)
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", skipped)
+	}
+	fmt.Fprintln(w, summary)
+}
+
+// expandBatchFiles turns the command line arguments into request files
+// Each argument is a request file, a directory searched recursively for .http files, or a
+// glob pattern. Relative names are looked up in the current directory, then the workdir.
+// A file matched twice runs once.
+func expandBatchFiles(patterns []string, workdir string) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if strings.ContainsAny(pattern, "*?[") {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+			}
+			if len(matches) == 0 && !filepath.IsAbs(pattern) {
+				matches, _ = filepath.Glob(filepath.Join(workdir, pattern))
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %s", pattern)
+			}
+			for _, match := range matches {
+				add(match)
+			}
+			continue
+		}
+
+		if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+			err := filepath.WalkDir(pattern, func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !entry.IsDir() && filepath.Ext(path) == ".http" {
+					add(path)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read directory %s: %w", pattern, err)
+			}
+			continue
+		}
+
+		path, err := resolveFilePath(pattern, workdir)
+		if err != nil {
+			return nil, err
+		}
+		add(path)
+	}
+	return files, nil
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeBatchTree creates request files (and files that are not requests) under a temporary directory
+func writeBatchTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{"a.http", "b.http", "sub/c.http", "notes.txt", "d.json"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("GET https://example.com\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestExpandBatchFiles(t *testing.T) {
+	dir := writeBatchTree(t)
+	in := func(names ...string) []string {
+		paths := make([]string, len(names))
+		for i, name := range names {
+			paths[i] = filepath.Join(dir, name)
+		}
+		return paths
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		expected []string
+	}{
+		{"directory is searched for .http files", []string{dir}, in("a.http", "b.http", "sub/c.http")},
+		{"glob", []string{filepath.Join(dir, "*.http")}, in("a.http", "b.http")},
+		{"relative glob in workdir", []string{"sub/*.http"}, in("sub/c.http")},
+		{"file without extension", []string{filepath.Join(dir, "d")}, in("d.json")},
+		{"relative file in workdir", []string{"b"}, in("b.http")},
+		{"duplicates run once", []string{filepath.Join(dir, "b.http"), dir, filepath.Join(dir, "*.http")}, in("b.http", "a.http", "sub/c.http")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := expandBatchFiles(tt.patterns, dir)
+			if err != nil {
+				t.Fatalf("expandBatchFiles failed: %v", err)
+			}
+			if !reflect.DeepEqual(files, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, files)
+			}
+		})
+	}
+}
+
+func TestExpandBatchFiles_Errors(t *testing.T) {
+	dir := writeBatchTree(t)
+
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  string
+	}{
+		{"glob without match", []string{filepath.Join(dir, "*.yaml")}, "no files match"},
+		{"invalid glob", []string{filepath.Join(dir, "[")}, "invalid pattern"},
+		{"missing file", []string{filepath.Join(dir, "missing")}, "file not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expandBatchFiles(tt.patterns, dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPrintBatchSummary(t *testing.T) {
+	results := []batchResult{
+		{File: "/w/health.http", Name: "Health", Status: 200, DurationMs: 12, Verdict: "PASS"},
+		{File: "/w/orders.http", Name: "Orders", Status: 500, DurationMs: 30, Verdict: "FAIL", Message: "status: expected 2xx, got 500"},
+		{File: "/w/delete.http", Verdict: "SKIP", Message: "delete.http requires confirmation"},
+		{File: "/w/users.http", Name: "Users", Status: 200, DurationMs: 8, Verdict: "PASS"},
+	}
+
+	var out bytes.Buffer
+	printBatchSummary(&out, results)
+	output := out.String()
+
+	if !strings.HasSuffix(output, "\n2 passed, 1 failed, 1 skipped\n") {
+		t.Errorf("Unexpected totals:\n%s", output)
+	}
+	lines := strings.Split(output, "\n")
+	if !strings.HasPrefix(lines[0], "FILE") || !strings.Contains(lines[2], "orders.http") || !strings.Contains(lines[2], "status: expected 2xx, got 500") {
+		t.Errorf("Expected one row per file in order:\n%s", output)
+	}
+	// No response received
+	if fields := strings.Fields(lines[3]); len(fields) < 3 || fields[1] != "-" {
+		t.Errorf("Expected a dash for the missing status, got %q", lines[3])
+	}
+
+	out.Reset()
+	printBatchSummary(&out, results[:1])
+	if !strings.HasSuffix(out.String(), "\n1 passed, 0 failed\n") {
+		t.Errorf("Expected skipped files to be left out of the totals when there are none:\n%s", out.String())
+	}
+}
+
+func TestBatchExitCode(t *testing.T) {
+	pass := batchResult{Verdict: "PASS"}
+	fail := batchResult{Verdict: "FAIL"}
+	skip := batchResult{Verdict: "SKIP"}
+
+	tests := []struct {
+		name        string
+		results     []batchResult
+		interrupted bool
+		expected    int
+	}{
+		{"all passed", []batchResult{pass, pass}, false, 0},
+		{"skipped files do not fail", []batchResult{pass, skip}, false, 0},
+		{"one failure", []batchResult{pass, fail, skip}, false, 1},
+		{"interrupted", []batchResult{pass}, true, 1},
+		{"nothing run", nil, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchExitCode(tt.results, tt.interrupted); got != tt.expected {
+				t.Errorf("batchExitCode() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
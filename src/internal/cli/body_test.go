@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/config"
+)
+
+// bodyServer records the body of every request it receives
+type bodyServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (s *bodyServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.bodies...)
+}
+
+// setupBodyTest points the config at a temporary directory and writes a POST request file
+// The session has history disabled so runs leave nothing behind; stdout is discarded
+func setupBodyTest(t *testing.T) (*bodyServer, string) {
+	t.Helper()
+	server := &bodyServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		server.mu.Lock()
+		server.bodies = append(server.bodies, string(data))
+		server.mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	originalSession, originalProfiles, originalRequests := config.SessionFile, config.ProfilesFile, config.RequestsDir
+	config.SessionFile = filepath.Join(dir, ".session.json")
+	config.ProfilesFile = filepath.Join(dir, ".profiles.json")
+	config.RequestsDir = dir
+	t.Cleanup(func() {
+		config.SessionFile, config.ProfilesFile, config.RequestsDir = originalSession, originalProfiles, originalRequests
+	})
+	if err := os.WriteFile(config.SessionFile, []byte(`{"variables":{},"historyEnabled":false}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	requestFile := filepath.Join(dir, "create.http")
+	content := "### Create\nPOST " + server.URL + "/items\nContent-Type: application/json\n\n{\"from\": \"file\"}\n"
+	if err := os.WriteFile(requestFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	originalStdout := os.Stdout
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = devNull
+	t.Cleanup(func() {
+		os.Stdout = originalStdout
+		devNull.Close()
+	})
+
+	return server, requestFile
+}
+
+// pipeStdin replaces os.Stdin with a pipe carrying data
+func pipeStdin(t *testing.T, data string) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		writer.WriteString(data)
+		writer.Close()
+	}()
+	original := os.Stdin
+	os.Stdin = reader
+	t.Cleanup(func() {
+		os.Stdin = original
+		reader.Close()
+	})
+}
+
+func TestRun_BodyOverride(t *testing.T) {
+	tests := []struct {
+		name     string
+		stdin    string
+		file     string // Written to a temporary file passed as @path
+		opts     RunOptions
+		expected string
+	}{
+		{"request file body", "", "", RunOptions{}, `{"from": "file"}`},
+		{"inline", "", "", RunOptions{BodyOverride: `{"from": "{{source}}"}`, ExtraVars: []string{"source=flag"}}, `{"from": "flag"}`},
+		{"stdin", `{"from": "{{source}}"}`, "", RunOptions{BodyOverride: "-", ExtraVars: []string{"source=stdin"}}, `{"from": "stdin"}`},
+		{"empty stdin with -", "", "", RunOptions{BodyOverride: "-"}, ""},
+		{"file", "", `{"from": "{{source}}"}`, RunOptions{ExtraVars: []string{"source=disk"}}, `{"from": "disk"}`},
+		{"raw stdin keeps a leading @ and variables", "@{{user}} hello", "", RunOptions{BodyOverride: "-", RawBody: true}, "@{{user}} hello"},
+		{"raw inline body", "", "", RunOptions{BodyOverride: `{"tpl": "{{name}}"}`, RawBody: true}, `{"tpl": "{{name}}"}`},
+		{"raw file keeps a leading @", "", "@{{user}}", RunOptions{RawBody: true}, "@{{user}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requestFile := setupBodyTest(t)
+			opts := tt.opts
+			opts.FilePath = requestFile
+			opts.Repeat = 1
+			if tt.file != "" {
+				bodyFile := filepath.Join(t.TempDir(), "payload.json")
+				if err := os.WriteFile(bodyFile, []byte(tt.file), 0644); err != nil {
+					t.Fatal(err)
+				}
+				opts.BodyOverride = "@" + bodyFile
+			}
+			// Tests run without a terminal on stdin, so every case gets a pipe
+			pipeStdin(t, tt.stdin)
+
+			if err := Run(opts); err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if received := server.received(); len(received) != 1 || received[0] != tt.expected {
+				t.Errorf("Expected body %q, got %q", tt.expected, received)
+			}
+		})
+	}
+}
+
+func TestRun_BodyFileMissing(t *testing.T) {
+	server, requestFile := setupBodyTest(t)
+	pipeStdin(t, "")
+
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	err := Run(RunOptions{FilePath: requestFile, BodyOverride: "@" + missing, Repeat: 1})
+	if err == nil || !strings.Contains(err.Error(), "failed to read body file") || !strings.Contains(err.Error(), "missing.json") {
+		t.Errorf("Expected a body file error, got %v", err)
+	}
+	if received := server.received(); len(received) != 0 {
+		t.Errorf("Expected no request to be sent, got %q", received)
+	}
+}
+
+func TestRun_StdinReadOnceAcrossRepeats(t *testing.T) {
+	server, requestFile := setupBodyTest(t)
+	pipeStdin(t, `{"batch": 1}`)
+
+	if err := Run(RunOptions{FilePath: requestFile, BodyOverride: "-", Repeat: 3}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	received := server.received()
+	if len(received) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(received))
+	}
+	for i, body := range received {
+		if body != `{"batch": 1}` {
+			t.Errorf("Run %d: expected the stdin body, got %q", i+1, body)
+		}
+	}
+}
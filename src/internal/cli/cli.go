@@ -4,13 +4,18 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"text/template"
+	"time"
 
+	"github.com/studiowebux/restcli/internal/assertion"
+	"github.com/studiowebux/restcli/internal/chain"
 	"github.com/studiowebux/restcli/internal/config"
 	"github.com/studiowebux/restcli/internal/executor"
 	"github.com/studiowebux/restcli/internal/filter"
@@ -40,24 +45,90 @@ func isInteractive() bool {
 
 // RunOptions contains options for running a request in CLI mode
 type RunOptions struct {
-	FilePath     string
-	Profile      string
-	OutputFormat string   // json, yaml, text
-	SavePath     string
-	BodyOverride string
-	ShowFull     bool
-	ExtraVars    []string // key=value pairs from -e flag
-	EnvFile      string   // path to .env file
-	Filter       string   // JMESPath filter expression
-	Query        string   // JMESPath query or $(bash command)
+	FilePath       string
+	Profile        string
+	OutputFormat   string // json, yaml, text, csv, tsv
+	OutputTemplate string // Go template rendered against the result, replaces OutputFormat
+	SavePath       string
+	BodyOverride   string // Inline body, "-" to read stdin or "@path" to read a file
+	RawBody        bool   // Send the body override without resolving its variables
+	ShowFull       bool
+	ExtraVars      []string      // key=value pairs from -e flag
+	EnvFile        string        // path to .env file
+	Filter         string        // JMESPath filter expression
+	Query          string        // JMESPath query or $(bash command)
+	Assert         bool          // Evaluate request expectations and exit 1 when one fails
+	JUnitPath      string        // Write a JUnit XML report of the expectations (implies Assert)
+	AsCurl         bool          // Print the resolved request as a curl command instead of executing it
+	Watch          bool          // Re-run the request whenever its file or one of its dependencies changes
+	Repeat         int           // Number of runs, 0 to run until interrupted
+	Interval       time.Duration // Delay between repeated runs
+	Diff           bool          // When repeating, print only how each response differs from the previous one
+	DownloadPath   string        // Write the response body to this file as it arrives instead of keeping it in memory
+	Environment    string        // Environment of the profile layered over its variables, replaces the one selected in the session
+
+	stdinBody   *string                  // Body read from stdin by Run, nil when stdin was not used
+	batch       bool                     // Run by RunBatch: never prompt or touch the session, report assertions in the outcome only
+	connections *executor.ConnectionPool // Shared by the runs of a repeat or batch session, nil for one-shot runs
 }
 
 // Run executes a request file in CLI mode
 func Run(opts RunOptions) error {
+	// Stdin is read once here so repeated and watched runs all send the same body
+	if opts.BodyOverride == "-" || (opts.BodyOverride == "" && !isInteractive()) {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read body from stdin: %w", err)
+		}
+		body := string(data)
+		opts.stdinBody = &body
+	}
+
+	if opts.Watch {
+		return watch(opts)
+	}
+
+	failed, err := runRepeated(opts)
+	if err != nil {
+		return err
+	}
+	if failed {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// runOutcome is what a single run of the request produced
+type runOutcome struct {
+	Name       string // Request name
+	Status     int
+	StatusText string
+	Body       string // Response body after filter/query
+	Duration   int64  // milliseconds
+	Failed     bool   // The request failed or an expectation did not hold
+	Assertions []assertion.Result
+	Extracted  map[string]string // @extract values, only computed for batch runs
+}
+
+// errNeedsConfirmation is returned instead of prompting when a batch reaches an @confirmation request
+var errNeedsConfirmation = errors.New("request requires confirmation")
+
+// runOnce executes the request a single time, writing the response to stdout
+// A failed request is reported in the outcome, leaving the exit code to the caller.
+func runOnce(opts RunOptions, stdout io.Writer) (runOutcome, error) {
+	var outputTemplate *template.Template
+	if opts.OutputTemplate != "" {
+		tmpl, err := parseOutputTemplate(opts.OutputTemplate)
+		if err != nil {
+			return runOutcome{}, err
+		}
+		outputTemplate = tmpl
+	}
+
 	// Load session manager
 	mgr := session.NewManager()
 	if err := mgr.Load(); err != nil {
-		return fmt.Errorf("failed to load session: %w", err)
+		return runOutcome{}, fmt.Errorf("failed to load session: %w", err)
 	}
 
 	// Determine if we're using a profile or interactive mode
@@ -65,14 +136,23 @@ func Run(opts RunOptions) error {
 	var profile *types.Profile
 	var profileVars map[string]types.VariableValue
 	var sessionVars map[string]string
+	var err error
 
 	if useProfile {
-		// Set active profile if specified
-		if err := mgr.SetActiveProfile(opts.Profile); err != nil {
-			return fmt.Errorf("failed to set profile: %w", err)
+		// Set active profile if specified (batch runs share the session file, so they leave it untouched)
+		if opts.batch {
+			if profile = mgr.GetProfile(opts.Profile); profile == nil {
+				return runOutcome{}, fmt.Errorf("failed to set profile: profile not found: %s", opts.Profile)
+			}
+		} else {
+			if err := mgr.SetActiveProfile(opts.Profile); err != nil {
+				return runOutcome{}, fmt.Errorf("failed to set profile: %w", err)
+			}
+			profile = mgr.GetActiveProfile()
+		}
+		if profileVars, err = profileVariables(mgr, profile, opts.Environment); err != nil {
+			return runOutcome{}, err
 		}
-		profile = mgr.GetActiveProfile()
-		profileVars = profile.Variables
 		sessionVars = mgr.GetSession().Variables
 	} else {
 		// No profile - use empty vars (will prompt for missing)
@@ -84,29 +164,57 @@ func Run(opts RunOptions) error {
 	// Determine working directory
 	workdir, err := config.GetWorkingDirectory(profile.Workdir)
 	if err != nil {
-		return err
+		return runOutcome{}, err
 	}
 
 	// Resolve file path (supports extension-less names like "get-user" -> "get-user.http")
 	filePath, err := resolveFilePath(opts.FilePath, workdir)
 	if err != nil {
-		return err
+		return runOutcome{}, err
 	}
 
 	// Parse the request file
 	requests, err := parser.Parse(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to parse file: %w", err)
+		return runOutcome{}, fmt.Errorf("failed to parse file: %w", err)
 	}
 
 	if len(requests) == 0 {
-		return fmt.Errorf("no requests found in file: %s", filePath)
+		return runOutcome{}, fmt.Errorf("no requests found in file: %s", filePath)
 	}
 
 	// Use first request (TODO(#TODO-003): support selecting specific request by name - See TODO.md for details)
 	request := requests[0]
 
+	// A request declaring @profile runs with that profile, overriding --profile
+	if request.Profile != "" {
+		if override := mgr.GetProfile(request.Profile); override != nil {
+			useProfile = true
+			profile = override
+			if profileVars, err = profileVariables(mgr, profile, opts.Environment); err != nil {
+				return runOutcome{}, err
+			}
+			sessionVars = mgr.GetSession().Variables
+		} else if useProfile {
+			fmt.Fprintf(os.Stderr, "Warning: profile '%s' not found, using '%s'\n", request.Profile, profile.Name)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: profile '%s' not found, running without a profile\n", request.Profile)
+		}
+	}
+
+	if opts.Environment != "" && !useProfile {
+		return runOutcome{}, fmt.Errorf("--env requires a profile")
+	}
+
+	// Profile signing applies unless the request has its own @sign.* block
+	if useProfile && request.Signing == nil {
+		request.Signing = profile.Signing
+	}
+
 	// Check if confirmation is required
+	if request.RequiresConfirmation && opts.batch {
+		return runOutcome{}, errNeedsConfirmation
+	}
 	if request.RequiresConfirmation {
 		fmt.Printf("Request '%s' requires confirmation.\n", request.Name)
 		fmt.Printf("Method: %s\n", request.Method)
@@ -117,23 +225,35 @@ func Run(opts RunOptions) error {
 		fmt.Scanln(&response)
 		response = strings.ToLower(strings.TrimSpace(response))
 		if response != "y" && response != "yes" {
-			return fmt.Errorf("request execution cancelled by user")
+			return runOutcome{}, fmt.Errorf("request execution cancelled by user")
 		}
 	}
 
-	// Check if stdin is being piped (for body override)
-	stdinPiped := false
-	if opts.BodyOverride != "" {
-		request.Body = opts.BodyOverride
-	} else {
-		stat, _ := os.Stdin.Stat()
-		if (stat.Mode() & os.ModeCharDevice) == 0 {
-			// Data is being piped in
-			stdinPiped = true
-			bodyBytes, err := io.ReadAll(os.Stdin)
-			if err == nil && len(bodyBytes) > 0 {
-				request.Body = string(bodyBytes)
-			}
+	// Body override: inline --body, "@path" for a file, "-" or a pipe for stdin (read by Run)
+	stdinPiped := opts.stdinBody != nil
+	var bodyOverride string
+	hasBodyOverride := false
+	switch {
+	case stdinPiped:
+		// A pipe only overrides the body when it carries data, --body - always does
+		bodyOverride = *opts.stdinBody
+		hasBodyOverride = opts.BodyOverride == "-" || bodyOverride != ""
+	case strings.HasPrefix(opts.BodyOverride, "@"):
+		data, err := os.ReadFile(strings.TrimPrefix(opts.BodyOverride, "@"))
+		if err != nil {
+			return runOutcome{}, fmt.Errorf("failed to read body file: %w", err)
+		}
+		bodyOverride = string(data)
+		hasBodyOverride = true
+	case opts.BodyOverride != "":
+		bodyOverride = opts.BodyOverride
+		hasBodyOverride = true
+	}
+	if hasBodyOverride {
+		request.Body = bodyOverride
+		// A raw body is sent as-is, so its {{...}} are neither prompted for nor resolved
+		if opts.RawBody {
+			request.Body = ""
 		}
 	}
 
@@ -159,7 +279,7 @@ func Run(opts RunOptions) error {
 
 			// Check if the value is an alias for a multi-value variable (only if using profile)
 			if useProfile {
-				if profileVar, ok := profile.Variables[varName]; ok && profileVar.IsMultiValue() {
+				if profileVar, ok := profileVars[varName]; ok && profileVar.IsMultiValue() {
 					if profileVar.MultiValue.Aliases != nil {
 						if idx, aliasFound := profileVar.MultiValue.Aliases[varValue]; aliasFound {
 							// Resolve alias to actual value
@@ -178,6 +298,21 @@ func Run(opts RunOptions) error {
 		}
 	}
 
+	// @before hooks run first, their captured output fills the variables not set with -e
+	var hookProfile *types.Profile
+	if useProfile {
+		hookProfile = profile
+	}
+	hookVars, err := executor.RunBeforeHooks(context.Background(), &request, hookProfile)
+	if err != nil {
+		return runOutcome{}, err
+	}
+	for name, value := range hookVars {
+		if _, ok := cliVars[name]; !ok {
+			cliVars[name] = value
+		}
+	}
+
 	// Load environment variables
 	envVars := parser.LoadSystemEnv()
 
@@ -185,7 +320,7 @@ func Run(opts RunOptions) error {
 	if opts.EnvFile != "" {
 		fileEnvVars, err := parser.LoadEnvFile(opts.EnvFile)
 		if err != nil {
-			return fmt.Errorf("failed to load env file: %w", err)
+			return runOutcome{}, fmt.Errorf("failed to load env file: %w", err)
 		}
 		// File vars override system vars
 		for k, v := range fileEnvVars {
@@ -218,16 +353,16 @@ func Run(opts RunOptions) error {
 		// Prompt for missing variables
 		if len(missingVars) > 0 {
 			if stdinPiped {
-				return fmt.Errorf("cannot prompt for variables while stdin is piped (missing: %s)", strings.Join(missingVars, ", "))
+				return runOutcome{}, fmt.Errorf("cannot prompt for variables while stdin is piped (missing: %s)", strings.Join(missingVars, ", "))
 			}
-			if !isInteractive() {
-				return fmt.Errorf("missing variables (non-interactive mode): %s", strings.Join(missingVars, ", "))
+			if opts.batch || !isInteractive() {
+				return runOutcome{}, fmt.Errorf("missing variables (non-interactive mode): %s", strings.Join(missingVars, ", "))
 			}
 
 			for _, varName := range missingVars {
 				value, err := promptForVariable(varName)
 				if err != nil {
-					return fmt.Errorf("failed to read input for '%s': %w", varName, err)
+					return runOutcome{}, fmt.Errorf("failed to read input for '%s': %w", varName, err)
 				}
 				cliVars[varName] = value
 			}
@@ -251,16 +386,16 @@ func Run(opts RunOptions) error {
 
 				// Always prompt for multi-value variables (unless -e was used)
 				if stdinPiped {
-					return fmt.Errorf("multi-value variable '%s' requires selection. Use -e %s=<value> or -e %s=<alias>", varName, varName, varName)
+					return runOutcome{}, fmt.Errorf("multi-value variable '%s' requires selection. Use -e %s=<value> or -e %s=<alias>", varName, varName, varName)
 				}
-				if !isInteractive() {
-					return fmt.Errorf("multi-value variable '%s' requires selection (non-interactive mode). Use -e %s=<value>", varName, varName)
+				if opts.batch || !isInteractive() {
+					return runOutcome{}, fmt.Errorf("multi-value variable '%s' requires selection (non-interactive mode). Use -e %s=<value>", varName, varName)
 				}
 
 				// Show interactive selector (active index will be the default)
 				value, err := promptForMultiValueVariable(varName, mv)
 				if err != nil {
-					return fmt.Errorf("failed to select value for '%s': %w", varName, err)
+					return runOutcome{}, fmt.Errorf("failed to select value for '%s': %w", varName, err)
 				}
 				cliVars[varName] = value
 			}
@@ -276,16 +411,16 @@ func Run(opts RunOptions) error {
 			// Check if this variable is marked as interactive in the profile
 			if varValue, ok := profileVars[varName]; ok && varValue.Interactive {
 				if stdinPiped {
-					return fmt.Errorf("interactive variable '%s' requires input. Use -e %s=<value>", varName, varName)
+					return runOutcome{}, fmt.Errorf("interactive variable '%s' requires input. Use -e %s=<value>", varName, varName)
 				}
-				if !isInteractive() {
-					return fmt.Errorf("interactive variable '%s' requires input (non-interactive mode). Use -e %s=<value>", varName, varName)
+				if opts.batch || !isInteractive() {
+					return runOutcome{}, fmt.Errorf("interactive variable '%s' requires input (non-interactive mode). Use -e %s=<value>", varName, varName)
 				}
 
 				// Prompt for the value
 				value, err := promptForVariable(varName)
 				if err != nil {
-					return fmt.Errorf("failed to read input for interactive variable '%s': %w", varName, err)
+					return runOutcome{}, fmt.Errorf("failed to read input for interactive variable '%s': %w", varName, err)
 				}
 				cliVars[varName] = value
 			}
@@ -296,7 +431,10 @@ func Run(opts RunOptions) error {
 	resolver := parser.NewVariableResolver(profileVars, sessionVars, cliVars, envVars)
 	resolvedRequest, err := resolver.ResolveRequest(&request)
 	if err != nil {
-		return fmt.Errorf("failed to resolve variables: %w", err)
+		return runOutcome{}, fmt.Errorf("failed to resolve variables: %w", err)
+	}
+	if hasBodyOverride && opts.RawBody {
+		resolvedRequest.Body = bodyOverride
 	}
 
 	// Warn about unresolved variables
@@ -320,6 +458,12 @@ func Run(opts RunOptions) error {
 		tlsConfig = request.TLS
 	}
 
+	// Print the equivalent curl command without executing
+	if opts.AsCurl {
+		fmt.Fprintln(stdout, executor.ToCurl(resolvedRequest, tlsConfig))
+		return runOutcome{}, nil
+	}
+
 	// Execute request with streaming support (matches TUI behavior)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -327,33 +471,50 @@ func Run(opts RunOptions) error {
 	// Handle Ctrl+C for graceful cancellation
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt)
+	defer signal.Stop(sigChan)
 	go func() {
-		<-sigChan
-		fmt.Fprintln(os.Stderr, "\nStream cancelled by user")
-		cancel()
+		select {
+		case <-sigChan:
+			fmt.Fprintln(os.Stderr, "\nStream cancelled by user")
+			cancel()
+		case <-ctx.Done():
+		}
 	}()
 
+	// Downloads are written to the file as they arrive, with a progress bar on stderr
+	if opts.DownloadPath != "" {
+		file, err := os.Create(opts.DownloadPath)
+		if err != nil {
+			return runOutcome{}, fmt.Errorf("failed to create download file: %w", err)
+		}
+		defer file.Close()
+		ctx = executor.WithBodyWriter(ctx, file, downloadProgress(os.Stderr))
+	}
+
 	// Use streaming executor with real-time output callback
 	var activeProfile *types.Profile
 	if useProfile {
 		activeProfile = profile
 	}
-	result, err := executor.ExecuteWithStreaming(ctx, resolvedRequest, tlsConfig, activeProfile, func(chunk []byte, done bool) {
+	result, err := executor.ExecuteWithStreaming(ctx, resolvedRequest, tlsConfig, activeProfile, nil, opts.connections, func(chunk []byte, done bool) {
 		if !done {
 			// Write chunks directly to stdout for real-time output
-			os.Stdout.Write(chunk)
+			stdout.Write(chunk)
 		}
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return runOutcome{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	if opts.DownloadPath != "" {
+		result.DownloadPath = opts.DownloadPath
+		fmt.Fprintf(os.Stderr, "\nDownloaded %s to %s\n", executor.FormatSize(result.ResponseSize), opts.DownloadPath)
 	}
 
 	// Save to history if enabled (check both global and profile settings)
 	shouldSaveHistory := mgr.IsHistoryEnabled()
 	if useProfile {
-		profile := mgr.GetActiveProfile()
-		if profile != nil && profile.HistoryEnabled != nil {
+		if profile.HistoryEnabled != nil {
 			// Profile setting overrides global
 			shouldSaveHistory = *profile.HistoryEnabled
 		}
@@ -366,7 +527,7 @@ func Run(opts RunOptions) error {
 	}
 
 	// Auto-extract tokens if there's a token pattern in the response
-	if result.Status >= 200 && result.Status < 300 {
+	if result.Status >= 200 && result.Status < 300 && !opts.batch {
 		// Try to extract access_token from JSON response
 		if token, err := parser.ExtractJSONToken(result.Body, "access_token"); err == nil {
 			resolver.AddSessionVariable("token", token)
@@ -379,6 +540,13 @@ func Run(opts RunOptions) error {
 		}
 	}
 
+	// Evaluate expectations against the raw response (before filter/query)
+	rawBody := result.Body
+	var assertions []assertion.Result
+	if opts.Assert || opts.JUnitPath != "" {
+		assertions = assertion.Evaluate(resolvedRequest, result)
+	}
+
 	// Apply filter and query to response body
 	// Priority: CLI flags > request-level > profile defaults
 	filterExpr := opts.Filter
@@ -397,9 +565,9 @@ func Run(opts RunOptions) error {
 		queryExpr = profile.DefaultQuery
 	}
 
-	// Apply filter/query if specified
-	if filterExpr != "" || queryExpr != "" {
-		filteredBody, err := filter.Apply(result.Body, filterExpr, queryExpr)
+	// Apply filter/query if specified (a downloaded body is only in the file)
+	if (filterExpr != "" || queryExpr != "") && result.DownloadPath == "" {
+		filteredBody, err := filter.ApplyContext(ctx, result.Body, filterExpr, queryExpr)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: filter/query error: %v\n", err)
 		} else {
@@ -429,28 +597,90 @@ func Run(opts RunOptions) error {
 		}
 	}
 
-	// Format and output response
-	output, err := formatOutput(result, outputFormat, opts.ShowFull)
-	if err != nil {
-		return fmt.Errorf("failed to format output: %w", err)
+	// Format and output response (a template replaces the output format)
+	var output string
+	if outputTemplate != nil {
+		output, err = renderOutputTemplate(outputTemplate, result, resolvedRequest)
+		if err != nil {
+			return runOutcome{}, err
+		}
+	} else {
+		output, err = formatOutput(result, outputFormat, opts.ShowFull)
+		if err != nil {
+			return runOutcome{}, fmt.Errorf("failed to format output: %w", err)
+		}
 	}
 
 	// Save to file if specified
 	if opts.SavePath != "" {
 		if err := os.WriteFile(opts.SavePath, []byte(output), config.FilePermissions); err != nil {
-			return fmt.Errorf("failed to save response: %w", err)
+			return runOutcome{}, fmt.Errorf("failed to save response: %w", err)
 		}
 		fmt.Fprintf(os.Stderr, "Response saved to %s\n", opts.SavePath)
 	} else {
-		fmt.Print(output)
+		fmt.Fprint(stdout, output)
 	}
 
-	// Exit with error code if request failed
-	if result.Error != "" || result.Status >= 400 {
-		os.Exit(1)
+	// @after hooks get the raw body, a failing hook fails the run
+	rawResult := *result
+	rawResult.Body = rawBody
+	if err := executor.RunAfterHooks(ctx, resolvedRequest, hookProfile, &rawResult); err != nil {
+		return runOutcome{}, err
 	}
 
-	return nil
+	outcome := runOutcome{
+		Name:       request.Name,
+		Status:     result.Status,
+		StatusText: result.StatusText,
+		Body:       result.Body,
+		Duration:   result.Duration,
+		Assertions: assertions,
+	}
+
+	// @extract values are passed on to the next step of a batch chain
+	if opts.batch && chain.HasExtractions(&request) {
+		outcome.Extracted, err = chain.ExtractVariables(&request, rawBody)
+		if err != nil {
+			return runOutcome{}, fmt.Errorf("failed to extract variables: %w", err)
+		}
+	}
+
+	// With assertions, the expectations decide the exit code
+	if opts.Assert || opts.JUnitPath != "" {
+		if opts.JUnitPath != "" {
+			name := request.Name
+			if name == "" {
+				name = filepath.Base(filePath)
+			}
+			testCase := assertion.TestCase{
+				Name:       name,
+				ClassName:  filePath,
+				DurationMs: result.Duration,
+				Results:    assertions,
+			}
+			if err := assertion.WriteJUnit(opts.JUnitPath, filepath.Base(filePath), []assertion.TestCase{testCase}); err != nil {
+				return runOutcome{}, err
+			}
+			fmt.Fprintf(os.Stderr, "JUnit report saved to %s\n", opts.JUnitPath)
+		}
+
+		failed := assertion.Failed(assertions)
+		outcome.Failed = len(failed) > 0
+		if opts.batch {
+			return outcome, nil
+		}
+		for _, r := range failed {
+			fmt.Fprintf(os.Stderr, "%sAssertion failed: %s: %s%s\n", colorRed, r.Name, r.Message, colorReset)
+		}
+		if !outcome.Failed {
+			fmt.Fprintf(os.Stderr, "%sAll %d assertions passed%s\n", colorGreen, len(assertions), colorReset)
+		}
+		return outcome, nil
+	}
+
+	// Exit with error code if request failed
+	outcome.Failed = result.Error != "" || result.Status >= 400
+	return outcome, nil
 }
 
 // formatOutput formats the result based on the output format
@@ -474,6 +704,18 @@ func formatOutput(result *types.RequestResult, format string, showFull bool) (st
 		// Just return the body
 		return result.Body, nil
 
+	case "csv", "tsv":
+		// Tabular body (a JSON array of objects) with a header row
+		table, err := filter.ToTable(result.Body)
+		if err != nil {
+			return "", fmt.Errorf("%s output: %w", format, err)
+		}
+		sep := ','
+		if format == "tsv" {
+			sep = '\t'
+		}
+		return table.Delimited(sep)
+
 	case "text":
 		fallthrough
 	default:
@@ -488,8 +730,26 @@ func formatOutput(result *types.RequestResult, format string, showFull bool) (st
 		sb.WriteString(fmt.Sprintf("Duration: %s | Size: %s\n",
 			executor.FormatDuration(result.Duration),
 			executor.FormatSize(result.ResponseSize)))
+		if result.OverBudget() {
+			sb.WriteString(fmt.Sprintf("%sSlow: over the %s budget (maxDurationMs)%s\n", colorYellow, executor.FormatDuration(result.MaxDurationMs), colorReset))
+		}
 
 		if showFull {
+			// Phase timings
+			if result.Timings != nil {
+				sb.WriteString(fmt.Sprintf("Timing: %s\n", executor.FormatTimings(result.Timings, result.Duration)))
+			}
+			if len(result.Redirects) > 0 {
+				sb.WriteString(fmt.Sprintf("Redirects: %s\n", executor.FormatRedirectChain(result.Redirects, result.Status, result.Duration)))
+			}
+			if result.TLS != nil {
+				sb.WriteString(fmt.Sprintf("TLS: %s %s\n", result.TLS.Version, result.TLS.CipherSuite))
+				for i, cert := range result.TLS.Certificates {
+					expiry, _ := executor.CertificateExpiry(cert, time.Now())
+					sb.WriteString(fmt.Sprintf("  [%d] %s (issuer %s), %s\n", i, cert.Subject, cert.Issuer, expiry))
+				}
+			}
+
 			// Headers
 			if len(result.Headers) > 0 {
 				sb.WriteString("\nHeaders:\n")
@@ -510,6 +770,14 @@ func formatOutput(result *types.RequestResult, format string, showFull bool) (st
 			sb.WriteString("\n")
 		}
 
+		// GraphQL errors (reported with a 2xx status)
+		for _, message := range result.GraphQLErrors {
+			sb.WriteString(fmt.Sprintf("\n%sGraphQL error: %s%s", colorRed, message, colorReset))
+		}
+		if len(result.GraphQLErrors) > 0 {
+			sb.WriteString("\n")
+		}
+
 		// Error
 		if result.Error != "" {
 			sb.WriteString(fmt.Sprintf("\n%sError: %s%s\n", colorRed, result.Error, colorReset))
@@ -536,11 +804,54 @@ func getStatusColor(status int) string {
 	return colorYellow
 }
 
+// locateRequestFile resolves the request file and the working directory of the selected profile
+func locateRequestFile(opts RunOptions) (string, string, error) {
+	workdir, err := profileWorkdir(opts.Profile)
+	if err != nil {
+		return "", "", err
+	}
+	filePath, err := resolveFilePath(opts.FilePath, workdir)
+	if err != nil {
+		return "", "", err
+	}
+	return filePath, workdir, nil
+}
+
+// profileWorkdir returns the working directory of a profile, or the default one without a profile
+func profileWorkdir(name string) (string, error) {
+	mgr := session.NewManager()
+	if err := mgr.Load(); err != nil {
+		return "", fmt.Errorf("failed to load session: %w", err)
+	}
+
+	var workdir string
+	if name != "" {
+		profile := mgr.GetProfile(name)
+		if profile == nil {
+			return "", fmt.Errorf("failed to set profile: profile not found: %s", name)
+		}
+		workdir = profile.Workdir
+	}
+	return config.GetWorkingDirectory(workdir)
+}
+
+// profileVariables returns the variables of a profile with an environment layered over them
+// An empty env uses the environment selected for the profile in the session.
+func profileVariables(mgr *session.Manager, profile *types.Profile, env string) (map[string]types.VariableValue, error) {
+	if env == "" {
+		return mgr.ProfileVariables(profile), nil
+	}
+	if _, ok := profile.Environments[env]; !ok {
+		return nil, fmt.Errorf("environment '%s' not found in profile '%s'", env, profile.Name)
+	}
+	return profile.VariablesForEnvironment(env), nil
+}
+
 // resolveFilePath attempts to find the actual file path, trying common extensions
 // if the exact path doesn't exist. Returns the resolved path and any error.
 func resolveFilePath(basePath, workdir string) (string, error) {
 	// Supported extensions in priority order (empty string = exact match first)
-	extensions := []string{"", ".http", ".yaml", ".yml", ".json"}
+	extensions := []string{"", ".http", ".yaml", ".yml", ".json", ".graphql", ".grpc"}
 
 	// If absolute path, only check with extensions
 	if filepath.IsAbs(basePath) {
@@ -550,7 +861,7 @@ func resolveFilePath(basePath, workdir string) (string, error) {
 				return candidate, nil
 			}
 		}
-		return "", fmt.Errorf("file not found: %s (tried .http, .yaml, .yml, .json extensions)", basePath)
+		return "", fmt.Errorf("file not found: %s (tried .http, .yaml, .yml, .json, .graphql, .grpc extensions)", basePath)
 	}
 
 	// Check in current directory first
@@ -569,5 +880,5 @@ func resolveFilePath(basePath, workdir string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("file not found: %s (searched current directory and %s, tried .http, .yaml, .yml, .json extensions)", basePath, workdir)
+	return "", fmt.Errorf("file not found: %s (searched current directory and %s, tried .http, .yaml, .yml, .json, .graphql, .grpc extensions)", basePath, workdir)
 }
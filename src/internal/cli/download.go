@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/executor"
+)
+
+// downloadBarWidth is the width of the download progress bar in characters
+const downloadBarWidth = 30
+
+// downloadProgress redraws a progress bar on w as a download advances
+// Without a known size (no Content-Length, or a compressed body) only the bytes written are shown.
+func downloadProgress(w io.Writer) executor.DownloadProgress {
+	return func(written, total int64) {
+		if total <= 0 {
+			fmt.Fprintf(w, "\rDownloading... %s", executor.FormatSize(int(written)))
+			return
+		}
+		ratio := min(float64(written)/float64(total), 1)
+		filled := int(ratio * downloadBarWidth)
+		fmt.Fprintf(w, "\r[%s%s] %3.0f%% %s / %s",
+			strings.Repeat("=", filled), strings.Repeat(" ", downloadBarWidth-filled),
+			ratio*100, executor.FormatSize(int(written)), executor.FormatSize(int(total)))
+	}
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/executor"
+)
+
+// runRepeated runs the request opts.Repeat times (until interrupted when 0), opts.Interval apart
+// With assertions it stops at the first run whose expectations hold, so a status endpoint can be
+// polled until it flips. Returns true when the last run failed.
+func runRepeated(opts RunOptions) (bool, error) {
+	if opts.Repeat == 1 {
+		outcome, err := runOnce(opts, os.Stdout)
+		return outcome.Failed, err
+	}
+	if opts.Repeat < 0 {
+		return false, fmt.Errorf("--repeat must be 0 (until interrupted) or more")
+	}
+
+	// Reuse keep-alive connections so durations measure the requests, not the handshakes
+	opts.connections = executor.NewConnectionPool(1)
+	defer opts.connections.Close()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt)
+	defer signal.Stop(sigChan)
+
+	polling := opts.Assert || opts.JUnitPath != ""
+	statuses := make(map[int]int) // Status code to count, 0 for runs that errored
+	var previous *runOutcome
+	var last runOutcome
+	var lastErr error
+	runs := 0
+
+loop:
+	for run := 1; opts.Repeat == 0 || run <= opts.Repeat; run++ {
+		if run > 1 {
+			select {
+			case <-sigChan:
+				break loop
+			case <-time.After(opts.Interval):
+			}
+			printSeparator(fmt.Sprintf("run %d at %s", run, time.Now().Format("15:04:05")))
+		}
+
+		// With --diff only the first response is printed in full
+		var stdout io.Writer = os.Stdout
+		if opts.Diff && previous != nil {
+			stdout = io.Discard
+		}
+		last, lastErr = runOnce(opts, stdout)
+		runs++
+
+		if lastErr != nil {
+			fmt.Fprintf(os.Stderr, "%sError: %v%s\n", colorRed, lastErr, colorReset)
+			statuses[0]++
+		} else {
+			statuses[last.Status]++
+			if opts.Diff && previous != nil {
+				fmt.Print(diffOutcomes(*previous, last))
+			}
+			previous = &last
+
+			if polling && !last.Failed {
+				fmt.Fprintf(os.Stderr, "%sAssertions passed on run %d%s\n", colorGreen, run, colorReset)
+				break
+			}
+		}
+
+		// Ctrl+C while the request was running
+		select {
+		case <-sigChan:
+			break loop
+		default:
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, formatRunSummary(runs, statuses))
+	if lastErr != nil {
+		return true, nil
+	}
+	return last.Failed, nil
+}
+
+// formatRunSummary lists the status codes seen across the runs
+func formatRunSummary(runs int, statuses map[int]int) string {
+	codes := make([]int, 0, len(statuses))
+	for code := range statuses {
+		if code != 0 {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+
+	var parts []string
+	for _, code := range codes {
+		parts = append(parts, fmt.Sprintf("%d ×%d", code, statuses[code]))
+	}
+	if statuses[0] > 0 {
+		parts = append(parts, fmt.Sprintf("error ×%d", statuses[0]))
+	}
+
+	plural := "s"
+	if runs == 1 {
+		plural = ""
+	}
+	return fmt.Sprintf("\n%d run%s: %s", runs, plural, strings.Join(parts, ", "))
+}
+
+// diffOutcomes shows how a run differs from the previous one
+// Body lines are compared by position, only changed lines are printed.
+func diffOutcomes(previous, current runOutcome) string {
+	var sb strings.Builder
+	if previous.StatusText != current.StatusText {
+		sb.WriteString(fmt.Sprintf("%s- %s%s\n", colorRed, previous.StatusText, colorReset))
+		sb.WriteString(fmt.Sprintf("%s+ %s%s\n", colorGreen, current.StatusText, colorReset))
+	}
+	if previous.Body == current.Body {
+		if sb.Len() == 0 {
+			return fmt.Sprintf("%s (unchanged)\n", current.StatusText)
+		}
+		return sb.String()
+	}
+
+	previousLines := strings.Split(previous.Body, "\n")
+	currentLines := strings.Split(current.Body, "\n")
+	for i := 0; i < len(previousLines) || i < len(currentLines); i++ {
+		var before, after string
+		if i < len(previousLines) {
+			before = previousLines[i]
+		}
+		if i < len(currentLines) {
+			after = currentLines[i]
+		}
+		if before == after {
+			continue
+		}
+		if i < len(previousLines) {
+			sb.WriteString(fmt.Sprintf("%s- %s%s\n", colorRed, before, colorReset))
+		}
+		if i < len(currentLines) {
+			sb.WriteString(fmt.Sprintf("%s+ %s%s\n", colorGreen, after, colorReset))
+		}
+	}
+	return sb.String()
+}
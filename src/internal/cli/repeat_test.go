@@ -0,0 +1,82 @@
+package cli
+
+import "testing"
+
+func TestDiffOutcomes(t *testing.T) {
+	ok := runOutcome{Status: 200, StatusText: "200 OK", Body: "{\n  \"state\": \"pending\",\n  \"id\": 1\n}"}
+
+	tests := []struct {
+		name     string
+		previous runOutcome
+		current  runOutcome
+		expected string
+	}{
+		{
+			name:     "unchanged",
+			previous: ok,
+			current:  ok,
+			expected: "200 OK (unchanged)\n",
+		},
+		{
+			name:     "status changed",
+			previous: ok,
+			current:  runOutcome{Status: 503, StatusText: "503 Service Unavailable", Body: ok.Body},
+			expected: colorRed + "- 200 OK" + colorReset + "\n" + colorGreen + "+ 503 Service Unavailable" + colorReset + "\n",
+		},
+		{
+			name:     "body line changed",
+			previous: ok,
+			current:  runOutcome{Status: 200, StatusText: "200 OK", Body: "{\n  \"state\": \"done\",\n  \"id\": 1\n}"},
+			expected: colorRed + "-   \"state\": \"pending\"," + colorReset + "\n" + colorGreen + "+   \"state\": \"done\"," + colorReset + "\n",
+		},
+		{
+			name:     "status and body changed",
+			previous: runOutcome{StatusText: "202 Accepted", Body: "queued"},
+			current:  runOutcome{StatusText: "200 OK", Body: "done"},
+			expected: colorRed + "- 202 Accepted" + colorReset + "\n" + colorGreen + "+ 200 OK" + colorReset + "\n" +
+				colorRed + "- queued" + colorReset + "\n" + colorGreen + "+ done" + colorReset + "\n",
+		},
+		{
+			name:     "lines added",
+			previous: runOutcome{StatusText: "200 OK", Body: "a"},
+			current:  runOutcome{StatusText: "200 OK", Body: "a\nb"},
+			expected: colorGreen + "+ b" + colorReset + "\n",
+		},
+		{
+			name:     "lines removed",
+			previous: runOutcome{StatusText: "200 OK", Body: "a\nb"},
+			current:  runOutcome{StatusText: "200 OK", Body: "a"},
+			expected: colorRed + "- b" + colorReset + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffOutcomes(tt.previous, tt.current); got != tt.expected {
+				t.Errorf("diffOutcomes() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatRunSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		runs     int
+		statuses map[int]int
+		expected string
+	}{
+		{"single run", 1, map[int]int{200: 1}, "\n1 run: 200 ×1"},
+		{"sorted by code", 5, map[int]int{503: 2, 200: 3}, "\n5 runs: 200 ×3, 503 ×2"},
+		{"errors last", 4, map[int]int{0: 1, 404: 1, 200: 2}, "\n4 runs: 200 ×2, 404 ×1, error ×1"},
+		{"only errors", 2, map[int]int{0: 2}, "\n2 runs: error ×2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatRunSummary(tt.runs, tt.statuses); got != tt.expected {
+				t.Errorf("formatRunSummary() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/studiowebux/restcli/internal/filter"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// templateData is what an output template is executed against
+// Result fields are promoted, so {{.Status}} and {{.Body}} work directly.
+type templateData struct {
+	*types.RequestResult
+	Request *types.HttpRequest // Resolved request (variables substituted)
+}
+
+// templateFuncs are the helpers available in output templates
+var templateFuncs = template.FuncMap{
+	"json": func(value any) (string, error) {
+		// Output goes to a terminal or a pipe, keep <, > and & readable
+		var sb strings.Builder
+		encoder := json.NewEncoder(&sb)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(sb.String(), "\n"), nil
+	},
+	"jmespath": func(expression, body string) (string, error) {
+		return filter.Apply(body, expression, "")
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// parseOutputTemplate parses an output template so mistakes are reported before the request is sent
+func parseOutputTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renderOutputTemplate formats the result with a parsed output template
+func renderOutputTemplate(tmpl *template.Template, result *types.RequestResult, request *types.HttpRequest) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, templateData{RequestResult: result, Request: request}); err != nil {
+		return "", fmt.Errorf("failed to render output template: %w", err)
+	}
+
+	// End with a newline so the shell prompt starts on its own line
+	output := sb.String()
+	if !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+	return output, nil
+}
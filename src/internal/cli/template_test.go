@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestRenderOutputTemplate(t *testing.T) {
+	result := &types.RequestResult{
+		Status:     201,
+		StatusText: "201 Created",
+		Body:       `{"id": 7, "name": "<b>Tom & \"Jerry\"</b>"}`,
+		Duration:   42,
+		Headers:    map[string]string{"Location": "/items/7"},
+	}
+	request := &types.HttpRequest{Method: "POST", URL: "https://api.example.com/items"}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"result fields", "{{.Status}} {{.Duration}}ms", "201 42ms\n"},
+		{"request fields", "{{.Request.Method}} {{.Request.URL}}", "POST https://api.example.com/items\n"},
+		{"headers", `{{index .Headers "Location"}}`, "/items/7\n"},
+		{"jmespath", `{{jmespath "id" .Body}}`, "7\n"},
+		{"upper", "{{upper .Request.Method}} {{lower .StatusText}}", "POST 201 created\n"},
+		{"trailing newline kept", "{{.Status}}\n", "201\n"},
+		// Plain text output: markup is not HTML-escaped, json escapes quotes for the shell
+		{"no html escaping", "{{.Body}}", result.Body + "\n"},
+		{"json escaping", "{{json .Body}}", `"{\"id\": 7, \"name\": \"<b>Tom & \\\"Jerry\\\"</b>\"}"` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parseOutputTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("parseOutputTemplate failed: %v", err)
+			}
+			got, err := renderOutputTemplate(tmpl, result, request)
+			if err != nil {
+				t.Fatalf("renderOutputTemplate failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseOutputTemplate_Invalid(t *testing.T) {
+	if _, err := parseOutputTemplate("{{.Status"); err == nil || !strings.Contains(err.Error(), "invalid output template") {
+		t.Errorf("Expected a parse error, got %v", err)
+	}
+	if _, err := parseOutputTemplate("{{nope .Body}}"); err == nil || !strings.Contains(err.Error(), `"nope" not defined`) {
+		t.Errorf("Expected an unknown function error, got %v", err)
+	}
+}
+
+func TestRenderOutputTemplate_UnknownField(t *testing.T) {
+	tmpl, err := parseOutputTemplate("{{.Nope}}")
+	if err != nil {
+		t.Fatalf("parseOutputTemplate failed: %v", err)
+	}
+	_, err = renderOutputTemplate(tmpl, &types.RequestResult{Status: 200}, &types.HttpRequest{})
+	if err == nil || !strings.Contains(err.Error(), "failed to render output template") || !strings.Contains(err.Error(), "Nope") {
+		t.Errorf("Expected an unknown field error, got %v", err)
+	}
+
+	// missingkey=error also applies to map lookups
+	tmpl, err = parseOutputTemplate("{{.Headers.Missing}}")
+	if err != nil {
+		t.Fatalf("parseOutputTemplate failed: %v", err)
+	}
+	_, err = renderOutputTemplate(tmpl, &types.RequestResult{Headers: map[string]string{}}, &types.HttpRequest{})
+	if err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("Expected a missing key error, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/watcher"
+)
+
+// watch runs the request, then runs it again each time its file or a dependency changes
+// It returns when interrupted with Ctrl+C.
+func watch(opts RunOptions) error {
+	files, err := watchedFiles(opts)
+	if err != nil {
+		return err
+	}
+
+	w, err := watcher.New(files, watcher.DefaultDebounce)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt)
+	defer signal.Stop(sigChan)
+
+	runWatched(opts)
+	fmt.Fprintf(os.Stderr, "Watching %d file(s) for changes (Ctrl+C to stop)\n", len(files))
+
+	for {
+		select {
+		case <-sigChan:
+			fmt.Fprintln(os.Stderr, "\nStopped watching")
+			return nil
+
+		case path, ok := <-w.Changes():
+			if !ok {
+				return nil
+			}
+			printSeparator(fmt.Sprintf("%s changed at %s", filepath.Base(path), time.Now().Format("15:04:05")))
+			runWatched(opts)
+
+			// The edit may have added or removed dependencies
+			if files, err := watchedFiles(opts); err == nil {
+				if err := w.SetFiles(files); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				}
+			}
+		}
+	}
+}
+
+// printSeparator marks the start of a new result in the output
+func printSeparator(label string) {
+	bar := strings.Repeat("─", 8)
+	fmt.Printf("\n%s %s %s\n", bar, label, bar)
+}
+
+// runWatched runs the request (repeatedly with --repeat), reporting failures without exiting
+func runWatched(opts RunOptions) {
+	failed, err := runRepeated(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%sError: %v%s\n", colorRed, err, colorReset)
+		return
+	}
+	if failed {
+		fmt.Fprintf(os.Stderr, "%sRequest failed%s\n", colorRed, colorReset)
+	}
+}
+
+// watchedFiles returns the request file, its dependencies and the files it is configured from
+func watchedFiles(opts RunOptions) ([]string, error) {
+	filePath, workdir, err := locateRequestFile(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files := watcher.Files(filePath, workdir)
+	if opts.EnvFile != "" {
+		files = append(files, opts.EnvFile)
+	}
+	if bodyFile, ok := strings.CutPrefix(opts.BodyOverride, "@"); ok {
+		files = append(files, bodyFile)
+	}
+	if opts.Profile != "" {
+		files = append(files, config.GetProfilesFilePath())
+	}
+	return files, nil
+}
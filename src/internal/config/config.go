@@ -84,29 +84,41 @@ func GetWorkingDirectory(profileWorkdir string) (string, error) {
 		return RequestsDir, nil
 	}
 
+	workdir, err := ResolvePath(profileWorkdir)
+	if err != nil {
+		return "", err
+	}
+	// Absolute and home paths are used as-is
+	if filepath.IsAbs(profileWorkdir) || strings.HasPrefix(profileWorkdir, "~/") {
+		return workdir, nil
+	}
+
+	// Ensure the relative directory exists
+	if err := os.MkdirAll(workdir, DirPermissions); err != nil {
+		return "", fmt.Errorf("failed to create working directory %s: %w", workdir, err)
+	}
+
+	return workdir, nil
+}
+
+// ResolvePath expands a leading ~/ and makes relative profile paths relative to the config directory
+func ResolvePath(path string) (string, error) {
 	// Expand tilde to home directory
-	if strings.HasPrefix(profileWorkdir, "~/") {
+	if strings.HasPrefix(path, "~/") {
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
 			return "", fmt.Errorf("failed to get home directory: %w", err)
 		}
-		profileWorkdir = filepath.Join(homeDir, profileWorkdir[2:])
+		path = filepath.Join(homeDir, path[2:])
 	}
 
 	// If it's an absolute path, use it directly
-	if filepath.IsAbs(profileWorkdir) {
-		return profileWorkdir, nil
+	if filepath.IsAbs(path) {
+		return path, nil
 	}
 
 	// Otherwise, it's relative to config directory
-	workdir := filepath.Join(ConfigDir, profileWorkdir)
-
-	// Ensure the directory exists
-	if err := os.MkdirAll(workdir, DirPermissions); err != nil {
-		return "", fmt.Errorf("failed to create working directory %s: %w", workdir, err)
-	}
-
-	return workdir, nil
+	return filepath.Join(ConfigDir, path), nil
 }
 
 // LocalConfigExists checks if there's a local .session.json or .profiles.json
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/studiowebux/restcli/internal/config"
@@ -276,9 +277,14 @@ func generateHttpFileFromHAR(req HARRequest, headers map[string]string, body str
 	// Request line
 	sb.WriteString(fmt.Sprintf("%s %s\n", req.Method, req.URL))
 
-	// Headers
-	for name, value := range headers {
-		sb.WriteString(fmt.Sprintf("%s: %s\n", name, value))
+	// Headers, sorted so the output is stable
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", name, headers[name]))
 	}
 
 	// Body
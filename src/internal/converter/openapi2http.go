@@ -97,8 +97,14 @@ type OpenAPIRequestBody struct {
 }
 
 type OpenAPIMediaType struct {
-	Schema  map[string]interface{} `json:"schema,omitempty" yaml:"schema,omitempty"`
-	Example interface{}            `json:"example,omitempty" yaml:"example,omitempty"`
+	Schema   map[string]interface{}    `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example  interface{}               `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples map[string]OpenAPIExample `json:"examples,omitempty" yaml:"examples,omitempty"`
+}
+
+type OpenAPIExample struct {
+	Summary string      `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Value   interface{} `json:"value,omitempty" yaml:"value,omitempty"`
 }
 
 type OpenAPIResponse struct {
@@ -0,0 +1,316 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/mock"
+)
+
+// maxMockSchemaDepth limits recursion when synthesizing bodies from self-referencing schemas
+const maxMockSchemaDepth = 8
+
+// openAPIPathParam matches {param} segments in an OpenAPI path
+var openAPIPathParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// OpenAPIToMockConfig loads an OpenAPI spec and builds a mock server config with one route per operation
+func OpenAPIToMockConfig(specPath string) (*mock.Config, error) {
+	spec, err := loadOpenAPISpec(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+
+	routes := mockRoutesFromSpec(spec)
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("no operations found in OpenAPI spec")
+	}
+
+	return &mock.Config{Routes: routes, Logging: true}, nil
+}
+
+// mockRoutesFromSpec converts every operation of the spec into a mock route
+// Paths without parameters come first so that /users/me wins over /users/{id}
+func mockRoutesFromSpec(spec *OpenAPISpec) []mock.Route {
+	basePath := specBasePath(spec)
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		pi, pj := strings.Count(paths[i], "{"), strings.Count(paths[j], "{")
+		if pi != pj {
+			return pi < pj
+		}
+		return paths[i] < paths[j]
+	})
+
+	var routes []mock.Route
+	for _, path := range paths {
+		item := spec.Paths[path]
+		operations := []struct {
+			method    string
+			operation *OpenAPIOperation
+		}{
+			{"GET", item.Get},
+			{"POST", item.Post},
+			{"PUT", item.Put},
+			{"PATCH", item.Patch},
+			{"DELETE", item.Delete},
+		}
+
+		for _, op := range operations {
+			if op.operation == nil {
+				continue
+			}
+			routes = append(routes, operationToMockRoute(op.method, basePath+path, op.operation, spec))
+		}
+	}
+
+	return routes
+}
+
+// specBasePath returns the path of the first server URL (e.g. /v1), ignoring templated URLs
+func specBasePath(spec *OpenAPISpec) string {
+	if len(spec.Servers) == 0 || strings.Contains(spec.Servers[0].URL, "{") {
+		return ""
+	}
+	parsed, err := url.Parse(spec.Servers[0].URL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(parsed.Path, "/")
+}
+
+// operationToMockRoute builds a route returning the operation's example response
+func operationToMockRoute(method, path string, operation *OpenAPIOperation, spec *OpenAPISpec) mock.Route {
+	route := mock.Route{
+		Name:        operation.Summary,
+		Method:      method,
+		Path:        path,
+		Description: operation.Description,
+	}
+	if route.Name == "" {
+		route.Name = method + " " + path
+	}
+
+	// Path parameters become named regex groups so templates can use them
+	if openAPIPathParam.MatchString(path) {
+		route.PathType = "regex"
+		route.Path = "^" + openAPIPathToRegex(path) + "$"
+	}
+
+	code, response := pickMockResponse(operation.Responses)
+	route.Status = code
+
+	contentType, media, ok := pickMediaType(response.Content)
+	if !ok {
+		return route
+	}
+
+	route.Headers = map[string]string{"Content-Type": contentType}
+	if body, ok := mediaTypeExample(media, spec); ok {
+		route.Body = encodeMockBody(body, contentType)
+	}
+
+	return route
+}
+
+// openAPIPathToRegex quotes the literal parts of a path and turns {param} segments into named groups
+func openAPIPathToRegex(path string) string {
+	var sb strings.Builder
+	last := 0
+	for _, match := range openAPIPathParam.FindAllStringSubmatchIndex(path, -1) {
+		sb.WriteString(regexp.QuoteMeta(path[last:match[0]]))
+		name := sanitizeGroupName(path[match[2]:match[3]])
+		sb.WriteString("(?P<" + name + ">[^/]+)")
+		last = match[1]
+	}
+	sb.WriteString(regexp.QuoteMeta(path[last:]))
+	return sb.String()
+}
+
+// sanitizeGroupName makes a parameter name usable as a regex group name
+func sanitizeGroupName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	if sanitized == "" || (sanitized[0] >= '0' && sanitized[0] <= '9') {
+		sanitized = "p" + sanitized
+	}
+	return sanitized
+}
+
+// pickMockResponse selects the response to mock: the lowest 2xx, then default, then the lowest declared code
+func pickMockResponse(responses map[string]OpenAPIResponse) (int, OpenAPIResponse) {
+	codes := make([]int, 0, len(responses))
+	for key := range responses {
+		if code, err := strconv.Atoi(key); err == nil {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+
+	for _, code := range codes {
+		if code >= 200 && code < 300 {
+			return code, responses[strconv.Itoa(code)]
+		}
+	}
+	if response, ok := responses["default"]; ok {
+		return 200, response
+	}
+	if len(codes) > 0 {
+		return codes[0], responses[strconv.Itoa(codes[0])]
+	}
+	return 200, OpenAPIResponse{}
+}
+
+// pickMediaType prefers a JSON content type, falling back to the first one alphabetically
+func pickMediaType(content map[string]OpenAPIMediaType) (string, OpenAPIMediaType, bool) {
+	if len(content) == 0 {
+		return "", OpenAPIMediaType{}, false
+	}
+
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+
+	for _, contentType := range contentTypes {
+		if strings.Contains(contentType, "json") {
+			return contentType, content[contentType], true
+		}
+	}
+	return contentTypes[0], content[contentTypes[0]], true
+}
+
+// mediaTypeExample returns the example for a media type: example, then the first named example, then the schema
+func mediaTypeExample(media OpenAPIMediaType, spec *OpenAPISpec) (interface{}, bool) {
+	if media.Example != nil {
+		return media.Example, true
+	}
+
+	names := make([]string, 0, len(media.Examples))
+	for name := range media.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if value := media.Examples[name].Value; value != nil {
+			return value, true
+		}
+	}
+
+	if media.Schema != nil {
+		return synthesizeFromSchema(media.Schema, spec, 0), true
+	}
+	return nil, false
+}
+
+// synthesizeFromSchema builds a plausible value from a schema, using example, default and enum when declared
+func synthesizeFromSchema(schema map[string]interface{}, spec *OpenAPISpec, depth int) interface{} {
+	if depth > maxMockSchemaDepth {
+		return nil
+	}
+	schema = resolveSchema(schema, spec)
+
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+	if def, ok := schema["default"]; ok {
+		return def
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	// allOf merges the properties of every sub-schema
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		merged := make(map[string]interface{})
+		for _, sub := range allOf {
+			subMap, ok := sub.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if value, ok := synthesizeFromSchema(subMap, spec, depth+1).(map[string]interface{}); ok {
+				for key, v := range value {
+					merged[key] = v
+				}
+			}
+		}
+		return merged
+	}
+
+	schemaType := getSchemaType(schema)
+	if _, typed := schema["type"]; !typed && hasProperties(schema) {
+		schemaType = "object"
+	}
+
+	switch schemaType {
+	case "object":
+		result := make(map[string]interface{})
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			for key, propSchema := range properties {
+				if propMap, ok := propSchema.(map[string]interface{}); ok {
+					result[key] = synthesizeFromSchema(propMap, spec, depth+1)
+				}
+			}
+		}
+		return result
+
+	case "array":
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			return []interface{}{synthesizeFromSchema(items, spec, depth+1)}
+		}
+		return []interface{}{}
+
+	case "string":
+		switch getStringFromSchema(schema, "format") {
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "date":
+			return "2024-01-01"
+		case "email":
+			return "user@example.com"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "uri", "url":
+			return "https://example.com"
+		}
+		return "string"
+
+	case "integer":
+		return 0
+
+	case "number":
+		return 0.0
+
+	case "boolean":
+		return false
+
+	default:
+		return nil
+	}
+}
+
+// encodeMockBody serializes an example value for the response content type
+func encodeMockBody(value interface{}, contentType string) string {
+	if text, ok := value.(string); ok && !strings.Contains(contentType, "json") {
+		return text
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
@@ -0,0 +1,103 @@
+package converter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const mockSpec = `
+openapi: 3.0.0
+info:
+  title: Users
+  version: "1"
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /users/{user-id}:
+    get:
+      summary: Get user
+      responses:
+        "404":
+          description: Not found
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+  /users/me:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              examples:
+                me:
+                  value: {"id": 1, "name": "me"}
+  /users:
+    post:
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              example: {"id": 2}
+components:
+  schemas:
+    User:
+      properties:
+        id:
+          type: integer
+        email:
+          type: string
+          format: email
+        role:
+          type: string
+          enum: [admin, member]
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+func TestOpenAPIToMockConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, []byte(mockSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := OpenAPIToMockConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Routes) != 3 {
+		t.Fatalf("Expected 3 routes, got %d", len(config.Routes))
+	}
+
+	created, me, user := config.Routes[0], config.Routes[1], config.Routes[2]
+	if created.Method != "POST" || created.Path != "/v1/users" || created.Status != 201 || created.Body != "{\n  \"id\": 2\n}" {
+		t.Errorf("Unexpected POST route: %+v", created)
+	}
+	if me.Path != "/v1/users/me" || me.PathType != "" {
+		t.Errorf("Expected /users/me to be an exact route before the parameterized one, got %+v", me)
+	}
+	if user.Name != "Get user" || user.PathType != "regex" || user.Path != `^/v1/users/(?P<user_id>[^/]+)$` || user.Status != 200 {
+		t.Errorf("Unexpected parameterized route: %+v", user)
+	}
+	if user.Headers["Content-Type"] != "application/json" {
+		t.Errorf("Expected the JSON content type, got %v", user.Headers)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(user.Body), &body); err != nil {
+		t.Fatalf("Expected a JSON body, got %q", user.Body)
+	}
+	want := map[string]interface{}{"id": 0.0, "email": "user@example.com", "role": "admin", "tags": []interface{}{"string"}}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("Unexpected synthesized body: %v", body)
+	}
+}
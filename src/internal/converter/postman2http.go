@@ -0,0 +1,688 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Postman2HttpOptions contains options for postman2http conversion
+type Postman2HttpOptions struct {
+	CollectionFile string
+	OutputDir      string
+	Format         string // http, json, yaml (default: http)
+}
+
+// PostmanCollection represents a Postman collection (v2.1)
+type PostmanCollection struct {
+	Info     PostmanInfo       `json:"info"`
+	Item     []PostmanItem     `json:"item"`
+	Variable []PostmanVariable `json:"variable,omitempty"`
+	Auth     *PostmanAuth      `json:"auth,omitempty"`
+	Event    []PostmanEvent    `json:"event,omitempty"`
+}
+
+// PostmanInfo represents the collection metadata
+type PostmanInfo struct {
+	Name        string             `json:"name"`
+	Schema      string             `json:"schema"`
+	Description PostmanDescription `json:"description,omitempty"`
+}
+
+// PostmanItem is either a folder (Item set) or a request (Request set)
+type PostmanItem struct {
+	Name        string             `json:"name"`
+	Description PostmanDescription `json:"description,omitempty"`
+	Item        []PostmanItem      `json:"item,omitempty"`
+	Request     *PostmanRequest    `json:"request,omitempty"`
+	Auth        *PostmanAuth       `json:"auth,omitempty"`
+	Event       []PostmanEvent     `json:"event,omitempty"`
+}
+
+// PostmanRequest represents a request definition
+type PostmanRequest struct {
+	Method      string             `json:"method"`
+	Header      []PostmanKeyValue  `json:"header,omitempty"`
+	Body        *PostmanBody       `json:"body,omitempty"`
+	URL         PostmanURL         `json:"url"`
+	Auth        *PostmanAuth       `json:"auth,omitempty"`
+	Description PostmanDescription `json:"description,omitempty"`
+}
+
+// PostmanURL is the request URL (a plain string or an object with a raw field)
+type PostmanURL struct {
+	Raw string
+}
+
+// PostmanDescription is a description (a plain string or an object with a content field)
+type PostmanDescription string
+
+// PostmanValue is a scalar value stored as a string (Postman allows numbers and booleans)
+type PostmanValue string
+
+// PostmanKeyValue represents a header, form field or auth attribute
+type PostmanKeyValue struct {
+	Key      string       `json:"key"`
+	Value    PostmanValue `json:"value"`
+	Type     string       `json:"type,omitempty"`
+	Disabled bool         `json:"disabled,omitempty"`
+}
+
+// PostmanVariable represents a collection variable
+type PostmanVariable struct {
+	Key      string       `json:"key"`
+	Value    PostmanValue `json:"value"`
+	Disabled bool         `json:"disabled,omitempty"`
+}
+
+// PostmanBody represents a request body
+type PostmanBody struct {
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw,omitempty"`
+	URLEncoded []PostmanKeyValue `json:"urlencoded,omitempty"`
+	FormData   []PostmanKeyValue `json:"formdata,omitempty"`
+	GraphQL    *PostmanGraphQL   `json:"graphql,omitempty"`
+	Options    struct {
+		Raw struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options,omitempty"`
+}
+
+// PostmanGraphQL represents a GraphQL body
+type PostmanGraphQL struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables,omitempty"`
+}
+
+// PostmanAuth represents collection, folder or request authentication
+type PostmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []PostmanKeyValue `json:"bearer,omitempty"`
+	Basic  []PostmanKeyValue `json:"basic,omitempty"`
+	APIKey []PostmanKeyValue `json:"apikey,omitempty"`
+}
+
+// PostmanEvent represents a pre-request or test script
+type PostmanEvent struct {
+	Listen string        `json:"listen"`
+	Script PostmanScript `json:"script"`
+}
+
+// PostmanScript holds the script source lines
+type PostmanScript struct {
+	Exec PostmanLines `json:"exec"`
+}
+
+// PostmanLines is a list of lines (Postman also accepts a single string)
+type PostmanLines []string
+
+// PostmanEnvironment represents an exported Postman environment
+type PostmanEnvironment struct {
+	Name   string `json:"name"`
+	Values []struct {
+		Key     string       `json:"key"`
+		Value   PostmanValue `json:"value"`
+		Enabled *bool        `json:"enabled,omitempty"`
+	} `json:"values"`
+}
+
+// UnmarshalJSON accepts a URL string or a URL object
+func (u *PostmanURL) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		u.Raw = raw
+		return nil
+	}
+
+	var obj struct {
+		Raw      string   `json:"raw"`
+		Protocol string   `json:"protocol"`
+		Host     []string `json:"host"`
+		Path     []string `json:"path"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	u.Raw = obj.Raw
+	if u.Raw == "" && len(obj.Host) > 0 {
+		// Rebuild from parts when raw is missing
+		u.Raw = strings.Join(obj.Host, ".")
+		if obj.Protocol != "" {
+			u.Raw = obj.Protocol + "://" + u.Raw
+		}
+		if len(obj.Path) > 0 {
+			u.Raw += "/" + strings.Join(obj.Path, "/")
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON accepts a request object or a plain URL string (GET)
+func (r *PostmanRequest) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		r.Method = "GET"
+		r.URL.Raw = raw
+		return nil
+	}
+
+	type plain PostmanRequest
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// UnmarshalJSON accepts a description string or an object with content
+func (d *PostmanDescription) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*d = PostmanDescription(raw)
+		return nil
+	}
+
+	var obj struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*d = PostmanDescription(obj.Content)
+	return nil
+}
+
+// UnmarshalJSON accepts any scalar value
+func (v *PostmanValue) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*v = PostmanValue(raw)
+		return nil
+	}
+	if string(data) == "null" {
+		*v = ""
+		return nil
+	}
+	*v = PostmanValue(strings.TrimSpace(string(data)))
+	return nil
+}
+
+// UnmarshalJSON accepts a list of lines or a single string
+func (l *PostmanLines) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*l = strings.Split(raw, "\n")
+		return nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return err
+	}
+	*l = lines
+	return nil
+}
+
+// postmanScripts are scripts that apply to a request, with where they come from
+type postmanScripts struct {
+	source string // "collection", "folder <name>" or "" for the request itself
+	events []PostmanEvent
+}
+
+// Postman2Http converts a Postman collection (v2.1) to request files
+// Folders become directories; scripts are kept as comments since they cannot be executed
+func Postman2Http(opts Postman2HttpOptions) error {
+	collection, err := loadPostmanCollection(opts.CollectionFile)
+	if err != nil {
+		return err
+	}
+
+	if len(collection.Item) == 0 {
+		return fmt.Errorf("no items found in Postman collection")
+	}
+
+	// Create output directory
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		outputDir = "requests"
+	}
+	if err := os.MkdirAll(outputDir, config.DirPermissions); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Determine format
+	format := opts.Format
+	if format == "" {
+		format = "http"
+	}
+
+	var scripts []postmanScripts
+	if len(collection.Event) > 0 {
+		scripts = append(scripts, postmanScripts{source: "collection", events: collection.Event})
+	}
+
+	converted, total := 0, 0
+	convertPostmanItems(collection.Item, outputDir, format, collection.Auth, scripts, &converted, &total)
+
+	fmt.Fprintf(os.Stderr, "Converted %d/%d requests to %s/\n", converted, total, outputDir)
+	return nil
+}
+
+// loadPostmanCollection reads and validates a Postman collection file
+func loadPostmanCollection(path string) (*PostmanCollection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Postman collection: %w", err)
+	}
+
+	var collection PostmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("failed to parse Postman collection: %w", err)
+	}
+
+	if collection.Info.Schema != "" && !strings.Contains(collection.Info.Schema, "v2.") {
+		return nil, fmt.Errorf("unsupported Postman collection schema %s (expected v2.1)", collection.Info.Schema)
+	}
+
+	return &collection, nil
+}
+
+// convertPostmanItems writes each request in items, recursing into folders
+// Auth and scripts are inherited from the enclosing folders, like in Postman
+func convertPostmanItems(items []PostmanItem, dir, format string, auth *PostmanAuth, scripts []postmanScripts, converted, total *int) {
+	usedNames := make(map[string]bool)
+
+	for _, item := range items {
+		// Folder: recurse into a subdirectory
+		if item.Request == nil {
+			if len(item.Item) == 0 {
+				continue
+			}
+			folderAuth := auth
+			if item.Auth != nil && item.Auth.Type != "inherit" {
+				folderAuth = item.Auth
+			}
+			folderScripts := scripts
+			if len(item.Event) > 0 {
+				folderScripts = append(append([]postmanScripts{}, scripts...), postmanScripts{source: "folder " + item.Name, events: item.Event})
+			}
+
+			subDir := filepath.Join(dir, postmanSlug(item.Name, "folder"))
+			if err := os.MkdirAll(subDir, config.DirPermissions); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to create folder %s: %v\n", item.Name, err)
+				continue
+			}
+			convertPostmanItems(item.Item, subDir, format, folderAuth, folderScripts, converted, total)
+			continue
+		}
+
+		*total++
+
+		requestAuth := auth
+		if item.Request.Auth != nil && item.Request.Auth.Type != "inherit" {
+			requestAuth = item.Request.Auth
+		}
+		itemScripts := scripts
+		if len(item.Event) > 0 {
+			itemScripts = append(append([]postmanScripts{}, scripts...), postmanScripts{events: item.Event})
+		}
+
+		// Avoid overwriting requests with the same name in one folder
+		slug := postmanSlug(item.Name, "request")
+		base := slug
+		for n := 2; usedNames[base]; n++ {
+			base = fmt.Sprintf("%s-%d", slug, n)
+		}
+		usedNames[base] = true
+
+		if err := convertPostmanRequest(item, requestAuth, itemScripts, filepath.Join(dir, base), format); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to convert %s: %v\n", item.Name, err)
+			continue
+		}
+		*converted++
+	}
+}
+
+// convertPostmanRequest converts a single Postman request item and writes it to basePath + extension
+func convertPostmanRequest(item PostmanItem, auth *PostmanAuth, scripts []postmanScripts, basePath, format string) error {
+	httpReq, comments := postmanToHttpRequest(item, auth, scripts)
+
+	var content string
+	var ext string
+
+	switch format {
+	case "json":
+		httpReq.Documentation = postmanDocumentation(comments)
+		data, err := json.MarshalIndent(httpReq, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		content = string(data)
+		ext = ".json"
+	case "yaml":
+		httpReq.Documentation = postmanDocumentation(comments)
+		data, err := yaml.Marshal(httpReq)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		content = string(data)
+		ext = ".yaml"
+	default: // "http"
+		content = generateHttpFileFromPostman(httpReq, comments)
+		ext = ".http"
+	}
+
+	if err := os.WriteFile(basePath+ext, []byte(content), config.FilePermissions); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// postmanToHttpRequest converts a Postman request to an HttpRequest and its comment lines
+// Postman {{var}} placeholders are kept as-is since restcli uses the same syntax
+func postmanToHttpRequest(item PostmanItem, auth *PostmanAuth, scripts []postmanScripts) (types.HttpRequest, []string) {
+	req := item.Request
+	var comments []string
+
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	description := string(req.Description)
+	if description == "" {
+		description = string(item.Description)
+	}
+	if description != "" {
+		// @description is single-line, keep the rest as plain comments
+		lines := strings.Split(strings.TrimSpace(description), "\n")
+		comments = append(comments, "# @description "+strings.TrimSpace(lines[0]))
+		for _, line := range lines[1:] {
+			comments = append(comments, strings.TrimRight("# "+line, " "))
+		}
+	}
+
+	headers := make(map[string]string)
+	for _, h := range req.Header {
+		if h.Disabled || h.Key == "" {
+			continue
+		}
+		headers[h.Key] = string(h.Value)
+	}
+
+	url := req.URL.Raw
+	url, comments = applyPostmanAuth(auth, headers, url, comments)
+
+	body := ""
+	if req.Body != nil {
+		body, comments = postmanBody(req.Body, headers, comments)
+	}
+
+	comments = append(comments, postmanScriptComments(scripts)...)
+
+	name := item.Name
+	if name == "" {
+		name = method + " " + extractPath(url)
+	}
+
+	return types.HttpRequest{
+		Name:    name,
+		Method:  method,
+		URL:     url,
+		Headers: headers,
+		Body:    body,
+	}, comments
+}
+
+// applyPostmanAuth maps bearer and API key auth to headers (or query); other types are noted
+func applyPostmanAuth(auth *PostmanAuth, headers map[string]string, url string, comments []string) (string, []string) {
+	if auth == nil {
+		return url, comments
+	}
+	for name := range headers {
+		if strings.EqualFold(name, "Authorization") {
+			return url, comments // Explicit header wins
+		}
+	}
+
+	switch auth.Type {
+	case "", "noauth":
+	case "bearer":
+		if token := postmanAttr(auth.Bearer, "token"); token != "" {
+			headers["Authorization"] = "Bearer " + token
+		}
+	case "apikey":
+		key := postmanAttr(auth.APIKey, "key")
+		value := postmanAttr(auth.APIKey, "value")
+		if key == "" {
+			break
+		}
+		if postmanAttr(auth.APIKey, "in") == "query" {
+			separator := "?"
+			if strings.Contains(url, "?") {
+				separator = "&"
+			}
+			url += separator + key + "=" + value
+		} else {
+			headers[key] = value
+		}
+	case "basic":
+		comments = append(comments, fmt.Sprintf("# Postman basic auth (username: %s) not converted, add an Authorization: Basic header", postmanAttr(auth.Basic, "username")))
+	default:
+		comments = append(comments, fmt.Sprintf("# Postman %s auth not converted", auth.Type))
+	}
+	return url, comments
+}
+
+// postmanBody converts a Postman body and sets a matching Content-Type when none is set
+func postmanBody(body *PostmanBody, headers map[string]string, comments []string) (string, []string) {
+	setContentType := func(contentType string) {
+		for name := range headers {
+			if strings.EqualFold(name, "Content-Type") {
+				return
+			}
+		}
+		headers["Content-Type"] = contentType
+	}
+
+	switch body.Mode {
+	case "raw":
+		if body.Options.Raw.Language == "json" {
+			setContentType("application/json")
+		}
+		return body.Raw, comments
+	case "urlencoded":
+		var pairs []string
+		for _, field := range body.URLEncoded {
+			if !field.Disabled {
+				pairs = append(pairs, field.Key+"="+string(field.Value))
+			}
+		}
+		setContentType("application/x-www-form-urlencoded")
+		return strings.Join(pairs, "&"), comments
+	case "graphql":
+		if body.GraphQL == nil {
+			return "", comments
+		}
+		payload := map[string]interface{}{"query": body.GraphQL.Query}
+		if vars := strings.TrimSpace(body.GraphQL.Variables); vars != "" {
+			payload["variables"] = json.RawMessage(vars)
+		}
+		data, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			// Variables are not valid JSON, keep the query only
+			data, _ = json.MarshalIndent(map[string]string{"query": body.GraphQL.Query}, "", "  ")
+		}
+		setContentType("application/json")
+		return string(data), comments
+	case "formdata":
+		// Multipart bodies have no .http equivalent, keep the fields for reference
+		comments = append(comments, "# Postman form-data fields (not converted):")
+		for _, field := range body.FormData {
+			if field.Disabled {
+				continue
+			}
+			if field.Type == "file" {
+				comments = append(comments, fmt.Sprintf("#   %s: <file>", field.Key))
+			} else {
+				comments = append(comments, fmt.Sprintf("#   %s: %s", field.Key, field.Value))
+			}
+		}
+		return "", comments
+	case "file":
+		comments = append(comments, "# Postman file body not converted")
+	}
+	return "", comments
+}
+
+// postmanScriptComments turns pre-request and test scripts into comment lines
+func postmanScriptComments(scripts []postmanScripts) []string {
+	var comments []string
+	for _, s := range scripts {
+		for _, event := range s.events {
+			lines := event.Script.Exec
+			if strings.TrimSpace(strings.Join(lines, "")) == "" {
+				continue
+			}
+
+			label := "test script"
+			if event.Listen == "prerequest" {
+				label = "pre-request script"
+			}
+			if s.source != "" {
+				label += " from " + s.source
+			}
+
+			comments = append(comments, fmt.Sprintf("# Postman %s (not executed):", label))
+			for _, line := range lines {
+				comments = append(comments, strings.TrimRight("#   "+line, " "))
+			}
+		}
+	}
+	return comments
+}
+
+// postmanDocumentation keeps the comment lines in the serialized description for json/yaml output
+// Scripts and unconverted auth or bodies would otherwise be dropped, since DocumentationLines is not serialized
+func postmanDocumentation(comments []string) *types.Documentation {
+	if len(comments) == 0 {
+		return nil
+	}
+	lines := make([]string, len(comments))
+	for i, line := range comments {
+		line = strings.TrimPrefix(line, "#")
+		line = strings.TrimPrefix(line, " ")
+		lines[i] = strings.TrimPrefix(line, "@description ")
+	}
+	return &types.Documentation{Description: strings.Join(lines, "\n")}
+}
+
+// generateHttpFileFromPostman generates .http format content
+func generateHttpFileFromPostman(req types.HttpRequest, comments []string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("### %s\n", req.Name))
+	for _, line := range comments {
+		sb.WriteString(line + "\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("%s %s\n", req.Method, req.URL))
+
+	// Sorted for stable output
+	names := make([]string, 0, len(req.Headers))
+	for name := range req.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", name, req.Headers[name]))
+	}
+
+	if req.Body != "" {
+		sb.WriteString("\n")
+		sb.WriteString(req.Body)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// PostmanProfile builds a profile from the collection variables and an optional Postman environment
+// Environment values override collection variables with the same name; disabled values are skipped
+func PostmanProfile(collectionFile, environmentFile, name string) (*types.Profile, error) {
+	collection, err := loadPostmanCollection(collectionFile)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]types.VariableValue)
+	setVariable := func(key string, value PostmanValue) {
+		v := string(value)
+		variables[key] = types.VariableValue{StringValue: &v}
+	}
+
+	for _, v := range collection.Variable {
+		if !v.Disabled && v.Key != "" {
+			setVariable(v.Key, v.Value)
+		}
+	}
+
+	if environmentFile != "" {
+		data, err := os.ReadFile(environmentFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Postman environment: %w", err)
+		}
+		var env PostmanEnvironment
+		if err := json.Unmarshal(data, &env); err != nil {
+			return nil, fmt.Errorf("failed to parse Postman environment: %w", err)
+		}
+		for _, v := range env.Values {
+			if (v.Enabled == nil || *v.Enabled) && v.Key != "" {
+				setVariable(v.Key, v.Value)
+			}
+		}
+		if name == "" {
+			name = env.Name
+		}
+	}
+
+	if name == "" {
+		name = collection.Info.Name
+	}
+	if name == "" {
+		return nil, fmt.Errorf("profile name is required")
+	}
+
+	return &types.Profile{
+		Name:      name,
+		Headers:   make(map[string]string),
+		Variables: variables,
+	}, nil
+}
+
+// postmanAttr returns the value of an auth attribute
+func postmanAttr(attrs []PostmanKeyValue, key string) string {
+	for _, a := range attrs {
+		if a.Key == key {
+			return string(a.Value)
+		}
+	}
+	return ""
+}
+
+// postmanSlug turns an item or folder name into a file name
+func postmanSlug(name, fallback string) string {
+	slug := strings.ToLower(strings.TrimSpace(name))
+	slug = regexp.MustCompile(`[^a-z0-9_-]+`).ReplaceAllString(slug, "-")
+	slug = strings.Trim(slug, "-")
+	if slug == "" {
+		return fallback
+	}
+	return slug
+}
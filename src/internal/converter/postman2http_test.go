@@ -0,0 +1,291 @@
+package converter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+const postmanCollection = `{
+  "info": {"name": "Shop", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+  "event": [{"listen": "prerequest", "script": {"exec": ["pm.variables.set('ts', Date.now());"]}}],
+  "variable": [
+    {"key": "baseUrl", "value": "https://api.example.com"},
+    {"key": "token", "value": "collection-token"},
+    {"key": "retries", "value": 3},
+    {"key": "legacy", "value": "x", "disabled": true}
+  ],
+  "item": [
+    {
+      "name": "Orders",
+      "auth": {"type": "apikey", "apikey": [{"key": "key", "value": "X-Api-Key"}, {"key": "value", "value": "{{apiKey}}"}]},
+      "item": [
+        {
+          "name": "Archive",
+          "item": [
+            {"name": "List Archived", "request": {"method": "get", "url": "{{baseUrl}}/orders/archived"}}
+          ]
+        },
+        {
+          "name": "Create Order",
+          "event": [{"listen": "test", "script": {"exec": "pm.test('created', () => pm.response.to.have.status(201));"}}],
+          "request": {
+            "method": "POST",
+            "url": {"raw": "{{baseUrl}}/orders"},
+            "body": {"mode": "raw", "raw": "{\"sku\": \"A1\"}", "options": {"raw": {"language": "json"}}}
+          }
+        }
+      ]
+    },
+    {"name": "Health", "request": {"method": "GET", "url": "{{baseUrl}}/health", "auth": {"type": "noauth"}}}
+  ]
+}`
+
+// writePostmanCollection writes content to a collection file in a temporary directory
+func writePostmanCollection(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "collection.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readConverted returns the content of a converted file
+func readConverted(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected %s to be written: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestPostman2Http_FlattensFolders(t *testing.T) {
+	outputDir := t.TempDir()
+	if err := Postman2Http(Postman2HttpOptions{CollectionFile: writePostmanCollection(t, postmanCollection), OutputDir: outputDir}); err != nil {
+		t.Fatalf("Postman2Http failed: %v", err)
+	}
+
+	archived := readConverted(t, filepath.Join(outputDir, "orders", "archive", "list-archived.http"))
+	if !strings.Contains(archived, "### List Archived\n") || !strings.Contains(archived, "GET {{baseUrl}}/orders/archived\n") {
+		t.Errorf("Unexpected nested request:\n%s", archived)
+	}
+	// Folder auth is inherited through nested folders
+	if !strings.Contains(archived, "X-Api-Key: {{apiKey}}\n") {
+		t.Errorf("Expected the folder API key to be inherited:\n%s", archived)
+	}
+
+	create := readConverted(t, filepath.Join(outputDir, "orders", "create-order.http"))
+	if !strings.Contains(create, "Content-Type: application/json\n") || !strings.Contains(create, "\n{\"sku\": \"A1\"}\n") {
+		t.Errorf("Expected the JSON body with its content type:\n%s", create)
+	}
+
+	health := readConverted(t, filepath.Join(outputDir, "health.http"))
+	if strings.Contains(health, "Authorization") {
+		t.Errorf("Expected noauth to skip the collection token:\n%s", health)
+	}
+}
+
+func TestApplyPostmanAuth(t *testing.T) {
+	tests := []struct {
+		name        string
+		auth        *PostmanAuth
+		headers     map[string]string
+		url         string
+		wantURL     string
+		wantHeaders map[string]string
+		wantComment string
+	}{
+		{
+			name:        "bearer",
+			auth:        &PostmanAuth{Type: "bearer", Bearer: []PostmanKeyValue{{Key: "token", Value: "{{token}}"}}},
+			url:         "https://api.example.com",
+			wantURL:     "https://api.example.com",
+			wantHeaders: map[string]string{"Authorization": "Bearer {{token}}"},
+		},
+		{
+			name: "api key in query",
+			auth: &PostmanAuth{Type: "apikey", APIKey: []PostmanKeyValue{
+				{Key: "key", Value: "api_key"}, {Key: "value", Value: "{{apiKey}}"}, {Key: "in", Value: "query"},
+			}},
+			url:         "https://api.example.com/items?page=1",
+			wantURL:     "https://api.example.com/items?page=1&api_key={{apiKey}}",
+			wantHeaders: map[string]string{},
+		},
+		{
+			name:        "api key in header",
+			auth:        &PostmanAuth{Type: "apikey", APIKey: []PostmanKeyValue{{Key: "key", Value: "X-Api-Key"}, {Key: "value", Value: "secret"}}},
+			url:         "https://api.example.com",
+			wantURL:     "https://api.example.com",
+			wantHeaders: map[string]string{"X-Api-Key": "secret"},
+		},
+		{
+			name:        "explicit header wins",
+			auth:        &PostmanAuth{Type: "bearer", Bearer: []PostmanKeyValue{{Key: "token", Value: "{{token}}"}}},
+			headers:     map[string]string{"authorization": "Basic abc"},
+			url:         "https://api.example.com",
+			wantURL:     "https://api.example.com",
+			wantHeaders: map[string]string{"authorization": "Basic abc"},
+		},
+		{
+			name:        "basic is noted",
+			auth:        &PostmanAuth{Type: "basic", Basic: []PostmanKeyValue{{Key: "username", Value: "admin"}}},
+			url:         "https://api.example.com",
+			wantURL:     "https://api.example.com",
+			wantHeaders: map[string]string{},
+			wantComment: "# Postman basic auth (username: admin) not converted, add an Authorization: Basic header",
+		},
+		{
+			name:        "unsupported is noted",
+			auth:        &PostmanAuth{Type: "oauth2"},
+			url:         "https://api.example.com",
+			wantURL:     "https://api.example.com",
+			wantHeaders: map[string]string{},
+			wantComment: "# Postman oauth2 auth not converted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := tt.headers
+			if headers == nil {
+				headers = map[string]string{}
+			}
+			url, comments := applyPostmanAuth(tt.auth, headers, tt.url, nil)
+			if url != tt.wantURL {
+				t.Errorf("Expected URL %q, got %q", tt.wantURL, url)
+			}
+			if len(headers) != len(tt.wantHeaders) {
+				t.Errorf("Expected headers %v, got %v", tt.wantHeaders, headers)
+			}
+			for name, value := range tt.wantHeaders {
+				if headers[name] != value {
+					t.Errorf("Expected header %s: %s, got %v", name, value, headers)
+				}
+			}
+			if tt.wantComment == "" && len(comments) > 0 {
+				t.Errorf("Expected no comments, got %v", comments)
+			}
+			if tt.wantComment != "" && (len(comments) != 1 || comments[0] != tt.wantComment) {
+				t.Errorf("Expected comment %q, got %v", tt.wantComment, comments)
+			}
+		})
+	}
+}
+
+func TestPostmanProfile_Variables(t *testing.T) {
+	environment := filepath.Join(t.TempDir(), "staging.postman_environment.json")
+	content := `{
+  "name": "Staging",
+  "values": [
+    {"key": "baseUrl", "value": "https://staging.example.com", "enabled": true},
+    {"key": "token", "value": "unused", "enabled": false},
+    {"key": "apiKey", "value": "staging-key"}
+  ]
+}`
+	if err := os.WriteFile(environment, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := PostmanProfile(writePostmanCollection(t, postmanCollection), environment, "")
+	if err != nil {
+		t.Fatalf("PostmanProfile failed: %v", err)
+	}
+	if profile.Name != "Staging" {
+		t.Errorf("Expected the environment name, got %q", profile.Name)
+	}
+
+	expected := map[string]string{
+		"baseUrl": "https://staging.example.com",
+		"token":   "collection-token",
+		"retries": "3",
+		"apiKey":  "staging-key",
+	}
+	if len(profile.Variables) != len(expected) {
+		t.Errorf("Expected %d variables, got %v", len(expected), profile.Variables)
+	}
+	for key, want := range expected {
+		v, ok := profile.Variables[key]
+		if !ok || v.StringValue == nil || *v.StringValue != want {
+			t.Errorf("Expected %s = %q, got %+v", key, want, v)
+		}
+	}
+}
+
+func TestPostman2Http_KeepsScripts(t *testing.T) {
+	collection := writePostmanCollection(t, postmanCollection)
+	createOrder := "orders/create-order"
+
+	decoders := map[string]func([]byte, *types.HttpRequest) error{
+		"json": func(data []byte, req *types.HttpRequest) error { return json.Unmarshal(data, req) },
+		"yaml": func(data []byte, req *types.HttpRequest) error { return yaml.Unmarshal(data, req) },
+	}
+
+	for _, format := range []string{"http", "json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			outputDir := t.TempDir()
+			if err := Postman2Http(Postman2HttpOptions{CollectionFile: collection, OutputDir: outputDir, Format: format}); err != nil {
+				t.Fatalf("Postman2Http failed: %v", err)
+			}
+			content := readConverted(t, filepath.Join(outputDir, createOrder+"."+format))
+
+			notes := content
+			if decode, ok := decoders[format]; ok {
+				var req types.HttpRequest
+				if err := decode([]byte(content), &req); err != nil {
+					t.Fatalf("Failed to decode %s output: %v", format, err)
+				}
+				if req.Documentation == nil {
+					t.Fatalf("Expected the scripts in the documentation:\n%s", content)
+				}
+				notes = req.Documentation.Description
+			}
+
+			for _, want := range []string{
+				"Postman pre-request script from collection (not executed):",
+				"pm.variables.set('ts', Date.now());",
+				"Postman test script (not executed):",
+				"pm.test('created', () => pm.response.to.have.status(201));",
+			} {
+				if !strings.Contains(notes, want) {
+					t.Errorf("Expected %q in the %s output:\n%s", want, format, notes)
+				}
+			}
+		})
+	}
+}
+
+func TestPostman2Http_DeduplicatesNames(t *testing.T) {
+	content := `{
+  "info": {"name": "Dupes"},
+  "item": [
+    {"name": "Get", "request": {"method": "GET", "url": "https://example.com/first"}},
+    {"name": "Get 2", "request": {"method": "GET", "url": "https://example.com/named"}},
+    {"name": "Get", "request": {"method": "GET", "url": "https://example.com/second"}},
+    {"name": "get", "request": {"method": "GET", "url": "https://example.com/third"}}
+  ]
+}`
+	outputDir := t.TempDir()
+	if err := Postman2Http(Postman2HttpOptions{CollectionFile: writePostmanCollection(t, content), OutputDir: outputDir}); err != nil {
+		t.Fatalf("Postman2Http failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"get.http":   "https://example.com/first",
+		"get-2.http": "https://example.com/named",
+		"get-3.http": "https://example.com/second",
+		"get-4.http": "https://example.com/third",
+	}
+	for file, url := range expected {
+		if got := readConverted(t, filepath.Join(outputDir, file)); !strings.Contains(got, "GET "+url+"\n") {
+			t.Errorf("Expected %s to hold %s, got:\n%s", file, url, got)
+		}
+	}
+}
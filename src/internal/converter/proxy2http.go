@@ -0,0 +1,78 @@
+package converter
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// CapturedRequest is a request recorded by the debug proxy
+type CapturedRequest struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    []byte
+}
+
+// sensitiveHeaderVariables maps credential headers to the variable replacing their value
+var sensitiveHeaderVariables = map[string]string{
+	"Authorization": "token",
+	"Cookie":        "cookie",
+	"X-Auth-Token":  "authToken",
+	"X-Api-Key":     "apiKey",
+}
+
+// proxyOnlyHeaders are connection-level headers that should not be replayed
+var proxyOnlyHeaders = map[string]bool{
+	"Connection":          true,
+	"Content-Length":      true,
+	"Keep-Alive":          true,
+	"Proxy-Authorization": true,
+	"Proxy-Connection":    true,
+	"Te":                  true,
+	"Trailer":             true,
+	"Transfer-Encoding":   true,
+	"Upgrade":             true,
+}
+
+// CapturedToHttp converts a captured request to .http file content
+// Sensitive header values are replaced with {{variable}} placeholders unless importHeaders is true
+func CapturedToHttp(req CapturedRequest, importHeaders bool) string {
+	headers := make(map[string]string)
+	for name, values := range req.Headers {
+		name = http.CanonicalHeaderKey(name)
+		if proxyOnlyHeaders[name] {
+			continue
+		}
+		value := strings.Join(values, ", ")
+
+		if variable, ok := sensitiveHeaderVariables[name]; ok && !importHeaders {
+			value = placeholderValue(name, value, variable)
+		}
+		headers[name] = value
+	}
+
+	// Binary bodies cannot be written to a text file
+	body := ""
+	if utf8.Valid(req.Body) {
+		body = string(req.Body)
+	}
+
+	return generateHttpFileFromHAR(HARRequest{Method: req.Method, URL: req.URL}, headers, body, nil)
+}
+
+// placeholderValue replaces a credential with a variable, keeping the auth scheme (e.g. "Bearer {{token}}")
+func placeholderValue(name, value, variable string) string {
+	placeholder := "{{" + variable + "}}"
+	if name == "Authorization" {
+		if scheme, _, ok := strings.Cut(value, " "); ok {
+			return scheme + " " + placeholder
+		}
+	}
+	return placeholder
+}
+
+// SuggestCapturedFilename suggests a .http filename for a captured request
+func SuggestCapturedFilename(req CapturedRequest) string {
+	return suggestFilenameFromURL(req.URL, req.Method, 1)
+}
@@ -0,0 +1,240 @@
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// cborBreak is the stop code ending indefinite-length items
+type cborBreak struct{}
+
+// decodeCBOR decodes a CBOR body
+func decodeCBOR(data []byte, _ *types.ProtobufSchema) ([]byte, error) {
+	return decodeAll(data, func(r *reader, depth int) (interface{}, error) {
+		value, err := readCBOR(r, depth)
+		if _, ok := value.(cborBreak); ok {
+			return nil, errors.New("unexpected break")
+		}
+		return value, err
+	})
+}
+
+// readCBOR reads one CBOR data item (RFC 8949)
+func readCBOR(r *reader, depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, errors.New("nesting too deep")
+	}
+	initial, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	major, info := initial>>5, initial&0x1f
+
+	if major == 7 {
+		return readCBORSimple(r, info)
+	}
+
+	indefinite := info == 31
+	var arg uint64
+	if !indefinite {
+		if arg, err = readCBORArgument(r, info); err != nil {
+			return nil, err
+		}
+	} else if major < 2 || major == 6 {
+		return nil, fmt.Errorf("indefinite length not allowed for major type %d", major)
+	}
+
+	switch major {
+	case 0:
+		return arg, nil
+	case 1:
+		if arg > math.MaxInt64 {
+			return new(big.Int).Sub(big.NewInt(-1), new(big.Int).SetUint64(arg)).String(), nil
+		}
+		return -1 - int64(arg), nil
+	case 2, 3:
+		var data []byte
+		if indefinite {
+			data, err = readCBORChunks(r, major, depth)
+		} else {
+			data, err = r.bytes(arg)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if major == 3 {
+			return string(data), nil
+		}
+		return data, nil
+	case 4:
+		array := make([]interface{}, 0, r.capacity(arg))
+		for i := uint64(0); indefinite || i < arg; i++ {
+			item, err := readCBOR(r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := item.(cborBreak); ok {
+				if indefinite {
+					break
+				}
+				return nil, errors.New("unexpected break")
+			}
+			array = append(array, item)
+		}
+		return array, nil
+	case 5:
+		object := make(map[interface{}]interface{}, r.capacity(arg))
+		for i := uint64(0); indefinite || i < arg; i++ {
+			key, err := readCBOR(r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := key.(cborBreak); ok {
+				if indefinite {
+					break
+				}
+				return nil, errors.New("unexpected break")
+			}
+			value, err := readCBOR(r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := value.(cborBreak); ok {
+				return nil, errors.New("unexpected break")
+			}
+			object[hashableKey(key)] = value
+		}
+		return object, nil
+	default: // 6, tag
+		value, err := readCBOR(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := value.(cborBreak); ok {
+			return nil, errors.New("unexpected break")
+		}
+		return cborTag(arg, value), nil
+	}
+}
+
+// readCBORArgument reads the argument following an initial byte
+func readCBORArgument(r *reader, info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info <= 27:
+		return r.uint(1 << (info - 24))
+	}
+	return 0, fmt.Errorf("invalid additional information %d", info)
+}
+
+// readCBORChunks concatenates the definite-length chunks of an indefinite string
+func readCBORChunks(r *reader, major byte, depth int) ([]byte, error) {
+	var data []byte
+	for {
+		chunk, err := readCBOR(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		switch c := chunk.(type) {
+		case cborBreak:
+			return data, nil
+		case []byte:
+			if major == 2 {
+				data = append(data, c...)
+				continue
+			}
+		case string:
+			if major == 3 {
+				data = append(data, c...)
+				continue
+			}
+		}
+		return nil, errors.New("invalid chunk in indefinite-length string")
+	}
+}
+
+// readCBORSimple reads a major type 7 item: simple values, floats and the break code
+func readCBORSimple(r *reader, info byte) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23: // null, undefined
+		return nil, nil
+	case 24:
+		value, err := r.byte()
+		return fmt.Sprintf("simple(%d)", value), err
+	case 25:
+		n, err := r.uint(2)
+		return halfToFloat(uint16(n)), err
+	case 26:
+		n, err := r.uint(4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 27:
+		n, err := r.uint(8)
+		return math.Float64frombits(n), err
+	case 31:
+		return cborBreak{}, nil
+	}
+	if info < 20 {
+		return fmt.Sprintf("simple(%d)", info), nil
+	}
+	return nil, fmt.Errorf("invalid simple value %d", info)
+}
+
+// halfToFloat converts an IEEE 754 half-precision float
+func halfToFloat(h uint16) float64 {
+	exponent := int(h>>10) & 0x1f
+	mantissa := float64(h & 0x3ff)
+	var value float64
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, -24)
+	case 31:
+		if mantissa == 0 {
+			value = math.Inf(1)
+		} else {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	if h&0x8000 != 0 {
+		return -value
+	}
+	return value
+}
+
+// cborTag interprets the common tags: date/time and bignums; others keep their number
+func cborTag(tag uint64, value interface{}) interface{} {
+	switch tag {
+	case 0: // RFC 3339 date/time string
+		return value
+	case 1: // epoch date/time
+		switch v := value.(type) {
+		case uint64:
+			return time.Unix(int64(v), 0).UTC().Format(time.RFC3339Nano)
+		case int64:
+			return time.Unix(v, 0).UTC().Format(time.RFC3339Nano)
+		case float64:
+			sec, frac := math.Modf(v)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC().Format(time.RFC3339Nano)
+		}
+	case 2, 3: // unsigned and negative bignums
+		if data, ok := value.([]byte); ok {
+			n := new(big.Int).SetBytes(data)
+			if tag == 3 {
+				n.Sub(big.NewInt(-1), n)
+			}
+			return n.String()
+		}
+	}
+	return map[interface{}]interface{}{"tag": tag, "value": value}
+}
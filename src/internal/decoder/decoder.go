@@ -0,0 +1,169 @@
+// Package decoder turns binary response bodies (MessagePack, CBOR, protobuf) into JSON for display, and decodes JWTs
+package decoder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// ErrNoSchema is returned when a body cannot be decoded without a schema (protobuf)
+var ErrNoSchema = errors.New("no schema")
+
+// maxDepth bounds the nesting of decoded values, so a malicious body cannot exhaust the stack
+const maxDepth = 512
+
+// Func decodes a body into JSON, schema is nil when the request names none
+type Func func(data []byte, schema *types.ProtobufSchema) ([]byte, error)
+
+// registry maps media types (without parameters) to their decoder
+var registry = map[string]Func{}
+
+func init() {
+	for _, mediaType := range []string{"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"} {
+		Register(mediaType, decodeMsgpack)
+	}
+	Register("application/cbor", decodeCBOR)
+	for _, mediaType := range []string{"application/x-protobuf", "application/protobuf", "application/vnd.google.protobuf"} {
+		Register(mediaType, decodeProtobuf)
+	}
+}
+
+// Register sets the decoder of a media type, replacing any previous one
+func Register(mediaType string, decoder Func) {
+	registry[strings.ToLower(mediaType)] = decoder
+}
+
+// Lookup returns the decoder of a Content-Type header value, ignoring its parameters
+func Lookup(contentType string) (Func, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+	decoder, ok := registry[mediaType]
+	return decoder, ok
+}
+
+// Decode decodes a body of the given Content-Type into JSON
+// Returns ok=false when no decoder handles the content type.
+func Decode(contentType string, data []byte, schema *types.ProtobufSchema) (decoded []byte, ok bool, err error) {
+	decoder, ok := Lookup(contentType)
+	if !ok {
+		return nil, false, nil
+	}
+	decoded, err = decoder(data, schema)
+	return decoded, true, err
+}
+
+// toJSON marshals a decoded MessagePack or CBOR value
+func toJSON(value interface{}) ([]byte, error) {
+	return json.Marshal(jsonValue(value))
+}
+
+// jsonValue converts a decoded value into one encoding/json accepts
+// Map keys become strings, bytes become base64, and non-finite floats become strings.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			object[mapKey(key)] = jsonValue(item)
+		}
+		return object
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonValue(item)
+		}
+		return v
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Sprint(v)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// mapKey renders a map key as a JSON object key
+func mapKey(key interface{}) string {
+	switch k := key.(type) {
+	case string:
+		return k
+	case []byte:
+		return base64.StdEncoding.EncodeToString(k)
+	case nil:
+		return "null"
+	default:
+		data, err := json.Marshal(jsonValue(k))
+		if err != nil {
+			return fmt.Sprint(k)
+		}
+		return string(data)
+	}
+}
+
+// reader walks a binary body, failing on truncated input
+type reader struct {
+	data []byte
+	pos  int
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errTruncated
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, errTruncated
+	}
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// uint reads a big-endian unsigned integer of size bytes
+func (r *reader) uint(size int) (uint64, error) {
+	b, err := r.bytes(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n, nil
+}
+
+// capacity bounds a preallocation by the bytes left, each element taking at least one byte
+func (r *reader) capacity(n uint64) int {
+	return int(min(n, uint64(len(r.data)-r.pos)))
+}
+
+var errTruncated = errors.New("unexpected end of data")
+
+// decodeAll decodes a single value that must span the whole body
+func decodeAll(data []byte, decode func(r *reader, depth int) (interface{}, error)) ([]byte, error) {
+	r := &reader{data: data}
+	value, err := decode(r, 0)
+	if err != nil {
+		return nil, fmt.Errorf("at byte %d: %w", r.pos, err)
+	}
+	if r.pos != len(data) {
+		return nil, fmt.Errorf("%d trailing bytes after the value", len(data)-r.pos)
+	}
+	return toJSON(value)
+}
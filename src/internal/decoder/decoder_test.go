@@ -0,0 +1,160 @@
+package decoder
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestLookup(t *testing.T) {
+	for _, contentType := range []string{"application/msgpack", "Application/CBOR", "application/x-protobuf; proto=users.v1.User"} {
+		if _, ok := Lookup(contentType); !ok {
+			t.Errorf("Expected a decoder for %q", contentType)
+		}
+	}
+	if _, ok := Lookup("application/json"); ok {
+		t.Error("Expected no decoder for JSON")
+	}
+}
+
+func TestDecode_Msgpack(t *testing.T) {
+	// {"id": 1, "name": "ada", "tags": ["x", -3], "ok": true, "pi": 1.5, "raw": bin[0xff], "none": nil}
+	data := []byte{
+		0x87,
+		0xa2, 'i', 'd', 0x01,
+		0xa4, 'n', 'a', 'm', 'e', 0xa3, 'a', 'd', 'a',
+		0xa4, 't', 'a', 'g', 's', 0x92, 0xa1, 'x', 0xfd,
+		0xa2, 'o', 'k', 0xc3,
+		0xa2, 'p', 'i', 0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0,
+		0xa3, 'r', 'a', 'w', 0xc4, 0x01, 0xff,
+		0xa4, 'n', 'o', 'n', 'e', 0xc0,
+	}
+	decoded, ok, err := Decode("application/msgpack", data, nil)
+	if !ok || err != nil {
+		t.Fatalf("Expected the body to decode, got %v (%v)", err, ok)
+	}
+	want := `{"id":1,"name":"ada","none":null,"ok":true,"pi":1.5,"raw":"/w==","tags":["x",-3]}`
+	if string(decoded) != want {
+		t.Errorf("Expected %s, got %s", want, decoded)
+	}
+}
+
+func TestDecode_MsgpackIntegers(t *testing.T) {
+	// [int8 -128, int16 -2, uint16 65535, int64 -1]
+	data := []byte{0x94, 0xd0, 0x80, 0xd1, 0xff, 0xfe, 0xcd, 0xff, 0xff, 0xd3, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	decoded, _, err := Decode("application/x-msgpack", data, nil)
+	if err != nil || string(decoded) != "[-128,-2,65535,-1]" {
+		t.Errorf("Unexpected integers %s (%v)", decoded, err)
+	}
+}
+
+func TestDecode_MsgpackErrors(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"truncated string": {0xa5, 'a', 'b'},
+		"huge array":       {0xdd, 0xff, 0xff, 0xff, 0xff},
+		"trailing bytes":   {0x01, 0x02},
+		"reserved type":    {0xc1},
+	} {
+		if _, _, err := Decode("application/msgpack", data, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestDecode_CBOR(t *testing.T) {
+	// {"a": 1, "b": [2, 3], "c": -10, "d": 1.5 (half), "e": h'0102', "f": 1(0)}
+	data := []byte{
+		0xa6,
+		0x61, 'a', 0x01,
+		0x61, 'b', 0x82, 0x02, 0x03,
+		0x61, 'c', 0x29,
+		0x61, 'd', 0xf9, 0x3e, 0x00,
+		0x61, 'e', 0x42, 0x01, 0x02,
+		0x61, 'f', 0xc1, 0x00,
+	}
+	decoded, ok, err := Decode("application/cbor", data, nil)
+	if !ok || err != nil {
+		t.Fatalf("Expected the body to decode, got %v (%v)", err, ok)
+	}
+	want := `{"a":1,"b":[2,3],"c":-10,"d":1.5,"e":"AQI=","f":"1970-01-01T00:00:00Z"}`
+	if string(decoded) != want {
+		t.Errorf("Expected %s, got %s", want, decoded)
+	}
+}
+
+func TestDecode_CBORIndefinite(t *testing.T) {
+	// [_ "ab" as (_ "a" "b"), {_ "k": true}]
+	data := []byte{0x9f, 0x7f, 0x61, 'a', 0x61, 'b', 0xff, 0xbf, 0x61, 'k', 0xf5, 0xff, 0xff}
+	decoded, _, err := Decode("application/cbor", data, nil)
+	if err != nil || string(decoded) != `["ab",{"k":true}]` {
+		t.Errorf("Unexpected indefinite items %s (%v)", decoded, err)
+	}
+
+	if _, _, err := Decode("application/cbor", []byte{0xff}, nil); err == nil {
+		t.Error("Expected a lone break to fail")
+	}
+}
+
+func TestDecode_ProtobufWithoutSchema(t *testing.T) {
+	_, ok, err := Decode("application/x-protobuf", []byte{0x08, 0x01}, nil)
+	if !ok || !errors.Is(err, ErrNoSchema) {
+		t.Errorf("Expected ErrNoSchema, got %v (%v)", err, ok)
+	}
+}
+
+func TestDecode_Protobuf(t *testing.T) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("users.proto"),
+		Package: proto.String("users.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("id"), JsonName: proto.String("id"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				{Name: proto.String("name"), JsonName: proto.String("name"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			},
+		}},
+	}
+	set, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{file}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "users.pb")
+	if err := os.WriteFile(path, set, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := dynamicpb.NewMessage(fd.Messages().ByName("User"))
+	message.Set(fd.Messages().ByName("User").Fields().ByName("id"), protoreflect.ValueOf(int32(7)))
+	message.Set(fd.Messages().ByName("User").Fields().ByName("name"), protoreflect.ValueOf("ada"))
+	body, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, _, err := Decode("application/x-protobuf", body, &types.ProtobufSchema{DescriptorFile: path, Message: "users.v1.User"})
+	if err != nil {
+		t.Fatalf("Expected the body to decode, got %v", err)
+	}
+	if got := strings.ReplaceAll(string(decoded), " ", ""); got != `{"id":7,"name":"ada"}` {
+		t.Errorf("Unexpected decoded message %s", decoded)
+	}
+
+	_, _, err = Decode("application/x-protobuf", body, &types.ProtobufSchema{DescriptorFile: path, Message: "users.v1.Missing"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected an unknown message to fail, got %v", err)
+	}
+}
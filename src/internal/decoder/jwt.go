@@ -0,0 +1,139 @@
+package decoder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// JWT is a decoded JSON Web Token
+// The signature is not verified, only decoded.
+type JWT struct {
+	Header    string     // Pretty-printed header
+	Claims    string     // Pretty-printed claims, in the order of the token
+	Algorithm string     // "alg" of the header
+	ExpiresAt *time.Time // "exp" claim, nil when absent
+	NotBefore *time.Time // "nbf" claim, nil when absent
+	IssuedAt  *time.Time // "iat" claim, nil when absent
+	Signature int        // Signature length in bytes (0 for unsecured tokens)
+}
+
+// LooksLikeJWT reports whether a value has the shape of a JWT: three base64url segments, the first being a JSON object
+func LooksLikeJWT(value string) bool {
+	parts := strings.Split(strings.TrimSpace(value), ".")
+	return len(parts) == 3 && strings.HasPrefix(parts[0], "eyJ") && parts[1] != ""
+}
+
+// DecodeJWT decodes the header and claims of a compact JWT, with or without a "Bearer " prefix
+func DecodeJWT(token string) (*JWT, error) {
+	token = strings.TrimSpace(token)
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected 3 dot-separated segments, got %d", len(parts))
+	}
+
+	header, err := decodeJWTSegment(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	claims, err := decodeJWTSegment(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[2], "="))
+	if err != nil {
+		return nil, fmt.Errorf("signature: invalid base64url: %w", err)
+	}
+
+	jwt := &JWT{Signature: len(signature)}
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, header, "", "  "); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	jwt.Header = pretty.String()
+	pretty.Reset()
+	if err := json.Indent(&pretty, claims, "", "  "); err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+	jwt.Claims = pretty.String()
+
+	var fields struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(header, &fields); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	jwt.Algorithm = fields.Alg
+
+	var times map[string]interface{}
+	if err := json.Unmarshal(claims, &times); err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+	for name, dest := range map[string]**time.Time{"exp": &jwt.ExpiresAt, "nbf": &jwt.NotBefore, "iat": &jwt.IssuedAt} {
+		value, ok := times[name]
+		if !ok {
+			continue
+		}
+		seconds, ok := value.(float64)
+		if !ok || math.IsInf(seconds, 0) || math.Abs(seconds) > 1e11 {
+			return nil, fmt.Errorf("claims: %q is not a NumericDate", name)
+		}
+		t := time.Unix(int64(seconds), 0)
+		*dest = &t
+	}
+	return jwt, nil
+}
+
+// decodeJWTSegment base64url-decodes a segment holding a JSON object
+func decodeJWTSegment(segment string) ([]byte, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64url: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' || !json.Valid(data) {
+		return nil, errors.New("not a JSON object")
+	}
+	return data, nil
+}
+
+// ExpiryStatus describes the validity of the token at now, e.g. "expires in 4m", "EXPIRED 2h ago" or "no expiry"
+func (t *JWT) ExpiryStatus(now time.Time) string {
+	if t.NotBefore != nil && now.Before(*t.NotBefore) {
+		return "NOT YET VALID (valid in " + shortDuration(t.NotBefore.Sub(now)) + ")"
+	}
+	if t.ExpiresAt == nil {
+		return "no expiry"
+	}
+	if !now.Before(*t.ExpiresAt) {
+		return "EXPIRED " + shortDuration(now.Sub(*t.ExpiresAt)) + " ago"
+	}
+	return "expires in " + shortDuration(t.ExpiresAt.Sub(now))
+}
+
+// Expired reports whether the token is past its "exp" claim at now
+func (t *JWT) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// shortDuration formats a duration with its largest unit, e.g. "45s", "4m", "3h", "12d"
+func shortDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,164 @@
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// decodeMsgpack decodes a MessagePack body
+func decodeMsgpack(data []byte, _ *types.ProtobufSchema) ([]byte, error) {
+	return decodeAll(data, readMsgpack)
+}
+
+// readMsgpack reads one MessagePack value (https://github.com/msgpack/msgpack/blob/master/spec.md)
+func readMsgpack(r *reader, depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, errors.New("nesting too deep")
+	}
+	b, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case b <= 0x7f: // positive fixint
+		return int64(b), nil
+	case b >= 0xe0: // negative fixint
+		return int64(int8(b)), nil
+	case b&0xf0 == 0x80: // fixmap
+		return readMsgpackMap(r, uint64(b&0x0f), depth)
+	case b&0xf0 == 0x90: // fixarray
+		return readMsgpackArray(r, uint64(b&0x0f), depth)
+	case b&0xe0 == 0xa0: // fixstr
+		s, err := r.bytes(uint64(b & 0x1f))
+		return string(s), err
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6: // bin 8/16/32
+		n, err := r.uint(1 << (b - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return r.bytes(n)
+	case 0xc7, 0xc8, 0xc9: // ext 8/16/32
+		n, err := r.uint(1 << (b - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackExt(r, n)
+	case 0xca:
+		n, err := r.uint(4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 0xcb:
+		n, err := r.uint(8)
+		return math.Float64frombits(n), err
+	case 0xcc, 0xcd, 0xce, 0xcf: // uint 8/16/32/64
+		return r.uint(1 << (b - 0xcc))
+	case 0xd0, 0xd1, 0xd2, 0xd3: // int 8/16/32/64
+		size := 1 << (b - 0xd0)
+		n, err := r.uint(size)
+		shift := 64 - 8*size
+		return int64(n<<shift) >> shift, err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8: // fixext 1/2/4/8/16
+		return readMsgpackExt(r, 1<<(b-0xd4))
+	case 0xd9, 0xda, 0xdb: // str 8/16/32
+		n, err := r.uint(1 << (b - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		s, err := r.bytes(n)
+		return string(s), err
+	case 0xdc, 0xdd: // array 16/32
+		n, err := r.uint(2 << (b - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackArray(r, n, depth)
+	case 0xde, 0xdf: // map 16/32
+		n, err := r.uint(2 << (b - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackMap(r, n, depth)
+	}
+	return nil, fmt.Errorf("invalid MessagePack type 0x%02x", b)
+}
+
+func readMsgpackArray(r *reader, n uint64, depth int) (interface{}, error) {
+	array := make([]interface{}, 0, r.capacity(n))
+	for i := uint64(0); i < n; i++ {
+		item, err := readMsgpack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, item)
+	}
+	return array, nil
+}
+
+func readMsgpackMap(r *reader, n uint64, depth int) (interface{}, error) {
+	object := make(map[interface{}]interface{}, r.capacity(n))
+	for i := uint64(0); i < n; i++ {
+		key, err := readMsgpack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		value, err := readMsgpack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		object[hashableKey(key)] = value
+	}
+	return object, nil
+}
+
+// readMsgpackExt reads an extension value of n data bytes
+// The timestamp extension (-1) becomes an RFC 3339 string, others keep their type and raw data.
+func readMsgpackExt(r *reader, n uint64) (interface{}, error) {
+	extType, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.bytes(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if int8(extType) == -1 {
+		sub := &reader{data: data}
+		switch n {
+		case 4:
+			sec, _ := sub.uint(4)
+			return time.Unix(int64(sec), 0).UTC().Format(time.RFC3339Nano), nil
+		case 8:
+			v, _ := sub.uint(8)
+			return time.Unix(int64(v&0x3ffffffff), int64(v>>34)).UTC().Format(time.RFC3339Nano), nil
+		case 12:
+			nsec, _ := sub.uint(4)
+			sec, _ := sub.uint(8)
+			return time.Unix(int64(sec), int64(nsec)).UTC().Format(time.RFC3339Nano), nil
+		}
+	}
+	return map[interface{}]interface{}{"type": int64(int8(extType)), "data": data}, nil
+}
+
+// hashableKey makes a map key usable in a Go map, arrays and maps become their JSON text
+func hashableKey(key interface{}) interface{} {
+	switch key.(type) {
+	case []interface{}, map[interface{}]interface{}, []byte:
+		return mapKey(key)
+	default:
+		return key
+	}
+}
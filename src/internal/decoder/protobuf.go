@@ -0,0 +1,58 @@
+package decoder
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// decodeProtobuf decodes a protobuf body with the message of a descriptor set
+// The wire format does not carry field names, so a body without a schema returns ErrNoSchema.
+func decodeProtobuf(data []byte, schema *types.ProtobufSchema) ([]byte, error) {
+	if schema == nil || schema.DescriptorFile == "" || schema.Message == "" {
+		return nil, ErrNoSchema
+	}
+
+	descriptor, err := loadMessageDescriptor(schema)
+	if err != nil {
+		return nil, err
+	}
+	message := dynamicpb.NewMessage(descriptor)
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", schema.Message, err)
+	}
+	return protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(message)
+}
+
+// loadMessageDescriptor finds a message in a FileDescriptorSet file
+func loadMessageDescriptor(schema *types.ProtobufSchema) (protoreflect.MessageDescriptor, error) {
+	content, err := os.ReadFile(schema.DescriptorFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor set %s (compile it with protoc --include_imports -o): %w", schema.DescriptorFile, err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set %s: %w", schema.DescriptorFile, err)
+	}
+
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(schema.Message))
+	if err != nil {
+		return nil, fmt.Errorf("message %s not found in %s", schema.Message, schema.DescriptorFile)
+	}
+	message, ok := descriptor.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message", schema.Message)
+	}
+	return message, nil
+}
@@ -0,0 +1,115 @@
+package executor
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/studiowebux/restcli/internal/parser"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// DefaultMaxAutoReplies caps the auto-replies of one connection, so two rules answering each other stop
+const DefaultMaxAutoReplies = 100
+
+// AutoResponder answers received WebSocket messages with the @on rules of a .ws file
+// One responder serves a single connection; it is not safe for concurrent use.
+type AutoResponder struct {
+	rules     []autoReplyRule
+	max       int
+	replies   int
+	exhausted bool
+}
+
+type autoReplyRule struct {
+	pattern *regexp.Regexp
+	reply   string
+}
+
+// NewAutoResponder compiles the rules of a request, returning nil when it has none
+func NewAutoResponder(rules []types.WebSocketRule, max int) (*AutoResponder, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if max <= 0 {
+		max = DefaultMaxAutoReplies
+	}
+
+	responder := &AutoResponder{max: max}
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule pattern %q: %w", rule.Pattern, err)
+		}
+		responder.rules = append(responder.rules, autoReplyRule{pattern: pattern, reply: rule.Reply})
+	}
+	return responder, nil
+}
+
+// Respond returns the reply to a received message from the first matching rule
+// event describes what happened for the message history, empty when no rule matched. Once the
+// limit is reached no reply is sent, and only the first refused match is reported.
+func (a *AutoResponder) Respond(content string) (reply, event string, ok bool) {
+	if a == nil {
+		return "", "", false
+	}
+	for i, rule := range a.rules {
+		match := rule.pattern.FindStringSubmatchIndex(content)
+		if match == nil {
+			continue
+		}
+		if a.replies >= a.max {
+			if a.exhausted {
+				return "", "", false
+			}
+			a.exhausted = true
+			return "", fmt.Sprintf("Auto-reply limit of %d reached, rule %d (%s) not applied", a.max, i+1, rule.pattern), false
+		}
+		a.replies++
+		reply = string(rule.pattern.ExpandString(nil, rule.reply, content, match))
+		return reply, fmt.Sprintf("Rule %d (%s) matched, auto-reply %d/%d", i+1, rule.pattern, a.replies, a.max), true
+	}
+	return "", "", false
+}
+
+// autoReply applies the rules to a received message and sends the reply
+// Returns the messages to record in the history: the triggered rule, then the sent reply.
+func autoReply(conn *websocket.Conn, responder *AutoResponder, resolver *parser.VariableResolver, received types.ReceivedMessage) []types.ReceivedMessage {
+	reply, event, ok := responder.Respond(received.Content)
+	if event == "" {
+		return nil
+	}
+	messages := []types.ReceivedMessage{systemMessage(event)}
+	if !ok {
+		return messages
+	}
+
+	if resolver != nil {
+		resolved, err := resolver.Resolve(reply)
+		if err != nil {
+			return append(messages, systemMessage(fmt.Sprintf("Auto-reply variable resolution failed: %v", err)))
+		}
+		reply = resolved
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(reply)); err != nil {
+		return append(messages, systemMessage(fmt.Sprintf("Failed to send auto-reply: %v", err)))
+	}
+	return append(messages, types.ReceivedMessage{
+		Type:      "text",
+		Content:   reply,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Direction: "sent",
+		Size:      len(reply),
+	})
+}
+
+// systemMessage builds a system entry of the message history
+func systemMessage(content string) types.ReceivedMessage {
+	return types.ReceivedMessage{
+		Type:      "system",
+		Content:   content,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Direction: "system",
+	}
+}
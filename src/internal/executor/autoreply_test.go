@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestAutoResponder_Respond(t *testing.T) {
+	responder, err := NewAutoResponder([]types.WebSocketRule{
+		{Pattern: `^challenge:(\w+)$`, Reply: "response:$1"},
+		{Pattern: `^ping$`, Reply: "pong"},
+	}, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	reply, event, ok := responder.Respond("challenge:abc")
+	if !ok || reply != "response:abc" {
+		t.Errorf("Expected the expanded reply, got %q (%v)", reply, ok)
+	}
+	if !strings.HasPrefix(event, "Rule 1 ") {
+		t.Errorf("Expected the triggered rule in the event, got %q", event)
+	}
+
+	if _, event, ok := responder.Respond("hello"); ok || event != "" {
+		t.Errorf("Expected no reply to an unmatched message, got %q (%v)", event, ok)
+	}
+
+	if reply, _, _ := responder.Respond("ping"); reply != "pong" {
+		t.Errorf("Expected pong, got %q", reply)
+	}
+
+	// The limit refuses further replies, reported once
+	if _, event, ok := responder.Respond("ping"); ok || !strings.Contains(event, "limit of 2 reached") {
+		t.Errorf("Expected the limit to be reported, got %q (%v)", event, ok)
+	}
+	if _, event, ok := responder.Respond("ping"); ok || event != "" {
+		t.Errorf("Expected the limit to be reported only once, got %q (%v)", event, ok)
+	}
+}
+
+func TestNewAutoResponder_NoRules(t *testing.T) {
+	responder, err := NewAutoResponder(nil, 0)
+	if err != nil || responder != nil {
+		t.Errorf("Expected no responder without rules, got %v (%v)", responder, err)
+	}
+	if _, _, ok := responder.Respond("anything"); ok {
+		t.Error("Expected a nil responder to never reply")
+	}
+}
+
+func TestExecuteWebSocketInteractive_AutoReply(t *testing.T) {
+	answers := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte("challenge:42"))
+		if _, data, err := conn.ReadMessage(); err == nil {
+			answers <- string(data)
+		}
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	responder, _ := NewAutoResponder([]types.WebSocketRule{{Pattern: `challenge:(\d+)`, Reply: "answer:$1"}}, 0)
+
+	var mu sync.Mutex
+	var history []types.ReceivedMessage
+	callback := func(msg *types.ReceivedMessage, done bool) {
+		if msg != nil {
+			mu.Lock()
+			history = append(history, *msg)
+			mu.Unlock()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	go ExecuteWebSocketInteractive(ctx, wsURL, nil, nil, nil, WebSocketKeepalive{}, responder, nil, make(chan string), callback)
+
+	select {
+	case answer := <-answers:
+		if answer != "answer:42" {
+			t.Errorf("Expected the server to receive answer:42, got %q", answer)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected an auto-reply")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var logged bool
+	for _, msg := range history {
+		if msg.Direction == "system" && strings.HasPrefix(msg.Content, "Rule 1 ") {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Errorf("Expected the triggered rule in the history, got %+v", history)
+	}
+}
@@ -0,0 +1,187 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Supported request body compression (and response decompression) encodings
+const (
+	CompressionGzip    = "gzip"
+	CompressionDeflate = "deflate"
+	CompressionBrotli  = "br"
+)
+
+// shouldCompressBody reports whether a body sent with method should be compressed
+// Empty bodies and GET/HEAD requests are always sent as-is
+func shouldCompressBody(encoding, method string, body []byte) bool {
+	if encoding == "" || len(body) == 0 {
+		return false
+	}
+	method = strings.ToUpper(method)
+	return method != "GET" && method != "HEAD"
+}
+
+// compressBody compresses data with the given Content-Encoding
+// HTTP "deflate" is the zlib format (RFC 1950), not a raw deflate stream
+func compressBody(data []byte, encoding string) ([]byte, error) {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+
+	switch strings.ToLower(encoding) {
+	case CompressionGzip:
+		writer = gzip.NewWriter(&buf)
+	case CompressionDeflate:
+		writer = zlib.NewWriter(&buf)
+	case CompressionBrotli:
+		writer = brotli.NewWriter(&buf)
+	default:
+		return nil, fmt.Errorf("unsupported request compression %q (expected gzip, deflate or br)", encoding)
+	}
+
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// buildRequestBody returns the body reader, its size on the wire and the Content-Encoding applied (if any)
+func buildRequestBody(req *types.HttpRequest) (io.Reader, int, string, error) {
+	if req.Body == "" {
+		return nil, 0, "", nil
+	}
+	return encodeRequestBody([]byte(req.Body), req.Method, req.RequestCompression)
+}
+
+// encodeRequestBody compresses data with the @requestCompression encoding when it applies
+// Also used for the GraphQL payload, which is built from the request instead of its body
+func encodeRequestBody(data []byte, method, encoding string) (io.Reader, int, string, error) {
+	if !shouldCompressBody(encoding, method, data) {
+		return bytes.NewReader(data), len(data), "", nil
+	}
+
+	encoding = strings.ToLower(encoding)
+	compressed, err := compressBody(data, encoding)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("failed to compress request body: %w", err)
+	}
+
+	return bytes.NewReader(compressed), len(compressed), encoding, nil
+}
+
+// acceptedEncodings is advertised when auto-decompression is enabled and no Accept-Encoding is set
+const acceptedEncodings = "gzip, deflate, br"
+
+// setAcceptEncoding advertises supported encodings unless the request already sets Accept-Encoding
+func setAcceptEncoding(httpReq *http.Request, autoDecompress bool) {
+	if autoDecompress && httpReq.Header.Get("Accept-Encoding") == "" {
+		httpReq.Header.Set("Accept-Encoding", acceptedEncodings)
+	}
+}
+
+// newDecodingReader wraps body with decoders for a Content-Encoding header value
+// Multiple encodings are listed in the order they were applied, so decode in reverse
+func newDecodingReader(body io.Reader, contentEncoding string) (io.Reader, error) {
+	encodings := strings.Split(contentEncoding, ",")
+	reader := body
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		switch strings.ToLower(strings.TrimSpace(encodings[i])) {
+		case "", "identity":
+			continue
+		case CompressionGzip, "x-gzip":
+			gz, err := gzip.NewReader(reader)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read gzip response: %w", err)
+			}
+			reader = gz
+		case CompressionDeflate:
+			reader = newDeflateReader(reader)
+		case CompressionBrotli:
+			reader = brotli.NewReader(reader)
+		default:
+			return nil, fmt.Errorf("unsupported response encoding %q", encodings[i])
+		}
+	}
+
+	return reader, nil
+}
+
+// newDeflateReader handles both zlib-wrapped (RFC 1950) and raw (RFC 1951) deflate streams
+// Servers disagree on what "deflate" means, so sniff the zlib header
+func newDeflateReader(body io.Reader) io.Reader {
+	buffered := bufio.NewReader(body)
+	if header, err := buffered.Peek(2); err == nil {
+		// zlib header: CM=8 in the low nibble and (CMF*256 + FLG) divisible by 31
+		if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			if zr, err := zlib.NewReader(buffered); err == nil {
+				return zr
+			}
+		}
+	}
+	return flate.NewReader(buffered)
+}
+
+// decompressBody decodes a fully read response body
+func decompressBody(data []byte, contentEncoding string) ([]byte, error) {
+	reader, err := newDecodingReader(bytes.NewReader(data), contentEncoding)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+// decodeResponse decompresses a response body when enabled
+// Returns the body to display and the compressed size (0 when the body was not decoded)
+// Unsupported or corrupt encodings fall back to the raw bytes
+func decodeResponse(data []byte, contentEncoding string, autoDecompress bool) ([]byte, int) {
+	if !autoDecompress || contentEncoding == "" || len(data) == 0 {
+		return data, 0
+	}
+	decoded, err := decompressBody(data, contentEncoding)
+	if err != nil {
+		return data, 0
+	}
+	return decoded, len(data)
+}
+
+// countingReader counts bytes read from the underlying reader (compressed size for streams)
+type countingReader struct {
+	reader io.Reader
+	count  int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += n
+	return n, err
+}
+
+// decodeBodyStream decodes a compressed body on the fly, counting the bytes received on the wire
+// The counter is nil when the body is read as-is (not compressed, disabled or unsupported encoding).
+func decodeBodyStream(body io.Reader, contentEncoding string, autoDecompress bool) (io.Reader, *countingReader) {
+	if !autoDecompress || contentEncoding == "" {
+		return body, nil
+	}
+	wireCounter := &countingReader{reader: body}
+	decoded, err := newDecodingReader(wireCounter, contentEncoding)
+	if err != nil {
+		// Unsupported or invalid encoding: fall back to raw bytes
+		return body, nil
+	}
+	return decoded, wireCounter
+}
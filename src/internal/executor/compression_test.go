@@ -0,0 +1,232 @@
+package executor
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// TestRequestCompression_Encodings tests that the server receives a decodable compressed body
+func TestRequestCompression_Encodings(t *testing.T) {
+	body := `{"message": "hello hello hello hello hello hello hello hello"}`
+
+	tests := []struct {
+		encoding string
+		decode   func(io.Reader) (io.Reader, error)
+	}{
+		{CompressionGzip, func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		// HTTP deflate is zlib-wrapped, a raw flate stream fails the header check
+		{CompressionDeflate, func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) }},
+		{CompressionBrotli, func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			var gotEncoding string
+			var gotLength int64
+			var gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotEncoding = r.Header.Get("Content-Encoding")
+				gotLength = r.ContentLength
+				reader, err := tt.decode(r.Body)
+				if err != nil {
+					t.Errorf("Failed to create decoder: %v", err)
+					return
+				}
+				decoded, _ := io.ReadAll(reader)
+				gotBody = string(decoded)
+			}))
+			defer server.Close()
+
+			req := &types.HttpRequest{Method: "POST", URL: server.URL, Body: body, RequestCompression: tt.encoding}
+			result, err := Execute(req, nil, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if gotEncoding != tt.encoding {
+				t.Errorf("Expected Content-Encoding %s, got %s", tt.encoding, gotEncoding)
+			}
+			if gotBody != body {
+				t.Errorf("Expected decoded body %q, got %q", body, gotBody)
+			}
+			if int64(result.RequestSize) != gotLength {
+				t.Errorf("Expected RequestSize %d to match Content-Length %d", result.RequestSize, gotLength)
+			}
+			if result.RequestSize == len(body) {
+				t.Errorf("Expected RequestSize to reflect compressed size, got uncompressed %d", result.RequestSize)
+			}
+		})
+	}
+}
+
+// TestRequestCompression_Skipped tests that GET requests and empty bodies are sent uncompressed
+func TestRequestCompression_Skipped(t *testing.T) {
+	var gotEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotEncoding = r.Header.Get("Content-Encoding")
+	}))
+	defer server.Close()
+
+	requests := []*types.HttpRequest{
+		{Method: "GET", URL: server.URL, Body: "data", RequestCompression: CompressionGzip},
+		{Method: "POST", URL: server.URL, RequestCompression: CompressionGzip},
+	}
+
+	for _, req := range requests {
+		gotEncoding = ""
+		if _, err := Execute(req, nil, nil); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if gotEncoding != "" {
+			t.Errorf("Expected no Content-Encoding for %s with body %q, got %s", req.Method, req.Body, gotEncoding)
+		}
+	}
+}
+
+// TestRequestCompression_Unsupported tests that unknown encodings return an error
+func TestRequestCompression_Unsupported(t *testing.T) {
+	req := &types.HttpRequest{Method: "POST", URL: "http://localhost", Body: "data", RequestCompression: "zstd"}
+	if _, err := Execute(req, nil, nil); err == nil {
+		t.Fatal("Expected error for unsupported compression")
+	}
+}
+
+// TestResponseDecompression_Encodings tests that compressed responses are decoded into Body
+func TestResponseDecompression_Encodings(t *testing.T) {
+	payload := `{"status": "ok", "items": ["a", "b", "c", "a", "b", "c"]}`
+
+	for _, encoding := range []string{CompressionGzip, CompressionDeflate, CompressionBrotli} {
+		t.Run(encoding, func(t *testing.T) {
+			compressed, err := compressBody([]byte(payload), encoding)
+			if err != nil {
+				t.Fatalf("Failed to compress payload: %v", err)
+			}
+
+			var gotAccept string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAccept = r.Header.Get("Accept-Encoding")
+				w.Header().Set("Content-Encoding", encoding)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write(compressed)
+			}))
+			defer server.Close()
+
+			req := &types.HttpRequest{Method: "GET", URL: server.URL}
+			result, err := Execute(req, nil, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if gotAccept != acceptedEncodings {
+				t.Errorf("Expected Accept-Encoding %q, got %q", acceptedEncodings, gotAccept)
+			}
+			if result.Body != payload {
+				t.Errorf("Expected decompressed body %q, got %q", payload, result.Body)
+			}
+			if result.CompressedSize != len(compressed) {
+				t.Errorf("Expected CompressedSize %d, got %d", len(compressed), result.CompressedSize)
+			}
+			if result.ResponseSize != len(payload) {
+				t.Errorf("Expected ResponseSize %d, got %d", len(payload), result.ResponseSize)
+			}
+			if result.Headers["Content-Encoding"] != encoding {
+				t.Errorf("Expected Content-Encoding header %s to be preserved, got %q", encoding, result.Headers["Content-Encoding"])
+			}
+		})
+	}
+}
+
+// TestResponseDecompression_RawDeflate tests that raw deflate responses from non-conforming servers still decode
+func TestResponseDecompression_RawDeflate(t *testing.T) {
+	payload := "raw deflate payload"
+	var raw bytes.Buffer
+	writer, _ := flate.NewWriter(&raw, flate.DefaultCompression)
+	writer.Write([]byte(payload))
+	writer.Close()
+
+	decoded, err := decompressBody(raw.Bytes(), CompressionDeflate)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if string(decoded) != payload {
+		t.Errorf("Expected %q, got %q", payload, decoded)
+	}
+}
+
+// TestRequestCompression_GraphQL tests that the GraphQL payload is compressed like a regular body
+func TestRequestCompression_GraphQL(t *testing.T) {
+	var gotEncoding string
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotEncoding = r.Header.Get("Content-Encoding")
+		reader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Errorf("Failed to create decoder: %v", err)
+			return
+		}
+		json.NewDecoder(reader).Decode(&received)
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	req := &types.HttpRequest{
+		Method:             "POST",
+		URL:                server.URL,
+		RequestCompression: CompressionGzip,
+		GraphQL:            &types.GraphQLRequest{Query: "{ viewer { id } }"},
+	}
+	if _, err := Execute(req, nil, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if gotEncoding != CompressionGzip {
+		t.Errorf("Expected Content-Encoding gzip, got %q", gotEncoding)
+	}
+	if received["query"] != req.GraphQL.Query {
+		t.Errorf("Expected the decoded GraphQL payload, got %v", received)
+	}
+}
+
+// TestResponseDecompression_Disabled tests that AutoDecompress=false keeps raw bytes
+func TestResponseDecompression_Disabled(t *testing.T) {
+	compressed, err := compressBody([]byte("raw payload"), CompressionBrotli)
+	if err != nil {
+		t.Fatalf("Failed to compress payload: %v", err)
+	}
+
+	var gotAccept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAccept = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", CompressionBrotli)
+		w.Write(compressed)
+	}))
+	defer server.Close()
+
+	disabled := false
+	profile := &types.Profile{Name: "raw", AutoDecompress: &disabled}
+	req := &types.HttpRequest{Method: "GET", URL: server.URL}
+	result, err := Execute(req, nil, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if gotAccept != "" {
+		t.Errorf("Expected no Accept-Encoding when disabled, got %q", gotAccept)
+	}
+	if result.Body != string(compressed) {
+		t.Errorf("Expected raw compressed body when disabled")
+	}
+	if result.CompressedSize != 0 {
+		t.Errorf("Expected CompressedSize 0 when disabled, got %d", result.CompressedSize)
+	}
+}
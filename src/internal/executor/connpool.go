@@ -0,0 +1,93 @@
+package executor
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// ConnectionPool shares transports between requests so keep-alive connections are reused
+// Requests only share a transport when they use the same TLS settings, HTTP version, socket, host mappings and proxy.
+// Without a pool every request builds its own transport and opens new connections.
+type ConnectionPool struct {
+	mu                  sync.Mutex
+	transports          map[transportKey]http.RoundTripper
+	maxIdleConnsPerHost int
+}
+
+// transportKey identifies the settings a transport was built with
+type transportKey struct {
+	tls         string // See tlsKey
+	httpVersion string
+	socketPath  string
+	overrides   string
+	proxy       OutboundProxy
+}
+
+// NewConnectionPool creates a pool keeping up to maxIdleConnsPerHost idle connections per host
+// Set it to the number of requests sent concurrently (values below 1 keep one connection).
+func NewConnectionPool(maxIdleConnsPerHost int) *ConnectionPool {
+	if maxIdleConnsPerHost < 1 {
+		maxIdleConnsPerHost = 1
+	}
+	return &ConnectionPool{
+		transports:          make(map[transportKey]http.RoundTripper),
+		maxIdleConnsPerHost: maxIdleConnsPerHost,
+	}
+}
+
+// transport returns the shared transport for these settings, building it on first use
+func (p *ConnectionPool) transport(tlsConfig *types.TLSConfig, httpVersion string, dial dialSettings) (http.RoundTripper, error) {
+	key := transportKey{httpVersion: httpVersion, socketPath: dial.socketPath, overrides: dial.overrides.key(), proxy: dial.proxy}
+	key.tls = tlsKey(tlsConfig)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if rt, ok := p.transports[key]; ok {
+		return rt, nil
+	}
+
+	rt, err := newTransport(tlsConfig, httpVersion, dial)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := rt.(*http.Transport); ok {
+		// The default (2) closes the extra connections when more requests run at the same time
+		t.MaxIdleConnsPerHost = p.maxIdleConnsPerHost
+	}
+	p.transports[key] = rt
+	return rt, nil
+}
+
+// Close drops the idle connections of every transport in the pool
+// Call it once no request is in flight; the pool can still be used afterwards.
+func (p *ConnectionPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, rt := range p.transports {
+		if closer, ok := rt.(interface{ CloseIdleConnections() }); ok {
+			closer.CloseIdleConnections()
+		}
+		delete(p.transports, key)
+	}
+}
+
+// tlsKey returns a string identifying the TLS settings ("" without a TLS config)
+func tlsKey(tlsConfig *types.TLSConfig) string {
+	if tlsConfig == nil {
+		return ""
+	}
+	return strings.Join([]string{
+		"tls",
+		tlsConfig.CertFile,
+		tlsConfig.KeyFile,
+		tlsConfig.CAFile,
+		strconv.FormatBool(tlsConfig.InsecureSkipVerify),
+		strings.Join(tlsConfig.PinnedCertSHA256, ","),
+	}, "\x00")
+}
@@ -0,0 +1,84 @@
+package executor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// newConnCountingServer returns a server and the number of connections it accepted
+func newConnCountingServer(t *testing.T) (*httptest.Server, *int32) {
+	var conns int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	return server, &conns
+}
+
+// TestConnectionPool_ReusesConnections tests that requests sharing a pool reuse one connection
+func TestConnectionPool_ReusesConnections(t *testing.T) {
+	server, conns := newConnCountingServer(t)
+	pool := NewConnectionPool(1)
+	defer pool.Close()
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL}
+	for i := 0; i < 3; i++ {
+		if _, err := ExecuteWithContext(context.Background(), req, nil, nil, nil, pool); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(conns); got != 1 {
+		t.Errorf("Expected 1 connection, got %d", got)
+	}
+}
+
+// TestConnectionPool_NilPoolOpensNewConnections tests that one-shot requests keep their own transport
+func TestConnectionPool_NilPoolOpensNewConnections(t *testing.T) {
+	server, conns := newConnCountingServer(t)
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL}
+	for i := 0; i < 2; i++ {
+		if _, err := ExecuteWithContext(context.Background(), req, nil, nil, nil, nil); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(conns); got != 2 {
+		t.Errorf("Expected 2 connections, got %d", got)
+	}
+}
+
+// TestConnectionPool_SeparatesTransportSettings tests that different settings get different transports
+func TestConnectionPool_SeparatesTransportSettings(t *testing.T) {
+	pool := NewConnectionPool(4)
+	defer pool.Close()
+
+	plain, err := pool.transport(nil, HTTPVersionAuto, dialSettings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := pool.transport(nil, HTTPVersionAuto, dialSettings{})
+	insecure, _ := pool.transport(&types.TLSConfig{InsecureSkipVerify: true}, HTTPVersionAuto, dialSettings{})
+	http1, _ := pool.transport(nil, HTTPVersionHTTP1, dialSettings{})
+
+	if plain != again {
+		t.Error("Expected the same settings to share a transport")
+	}
+	if plain == insecure || plain == http1 {
+		t.Error("Expected different settings to get their own transport")
+	}
+	if got := plain.(*http.Transport).MaxIdleConnsPerHost; got != 4 {
+		t.Errorf("Expected MaxIdleConnsPerHost 4, got %d", got)
+	}
+}
@@ -0,0 +1,94 @@
+package executor
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sort"
+	"sync"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// CookieJar wraps a standard cookie jar and remembers which origins set cookies
+// The standard library jar cannot be enumerated, so origins are tracked for viewing
+type CookieJar struct {
+	mu      sync.Mutex
+	jar     *cookiejar.Jar
+	origins map[string]*url.URL
+}
+
+// CookieEntry is a cookie visible to a given origin
+type CookieEntry struct {
+	Origin string
+	Name   string
+	Value  string
+}
+
+// NewCookieJar creates an empty cookie jar
+// Domain and path scoping follow RFC 6265 using the public suffix list
+func NewCookieJar() (*CookieJar, error) {
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	if err != nil {
+		return nil, err
+	}
+	return &CookieJar{
+		jar:     jar,
+		origins: make(map[string]*url.URL),
+	}, nil
+}
+
+// SetCookies implements http.CookieJar
+func (c *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.jar.SetCookies(u, cookies)
+	if len(cookies) > 0 {
+		origin := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}
+		c.origins[origin.String()] = origin
+	}
+}
+
+// Cookies implements http.CookieJar
+func (c *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.jar.Cookies(u)
+}
+
+// Entries returns the cookies currently stored, grouped by the origin that set them
+// Expired cookies are dropped by the underlying jar
+func (c *CookieJar) Entries() []CookieEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	origins := make([]string, 0, len(c.origins))
+	for origin := range c.origins {
+		origins = append(origins, origin)
+	}
+	sort.Strings(origins)
+
+	var entries []CookieEntry
+	for _, origin := range origins {
+		for _, cookie := range c.jar.Cookies(c.origins[origin]) {
+			entries = append(entries, CookieEntry{
+				Origin: origin,
+				Name:   cookie.Name,
+				Value:  cookie.Value,
+			})
+		}
+	}
+	return entries
+}
+
+// Clear removes all cookies
+func (c *CookieJar) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Creating a jar with these options cannot fail
+	c.jar, _ = cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	c.origins = make(map[string]*url.URL)
+}
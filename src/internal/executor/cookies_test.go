@@ -0,0 +1,105 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// TestCookieJar_PersistsAcrossRequests tests that a cookie set by one request is sent on the next
+func TestCookieJar_PersistsAcrossRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123", Path: "/"})
+		case "/me":
+			cookie, err := r.Cookie("session")
+			if err != nil || cookie.Value != "abc123" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}
+	}))
+	defer server.Close()
+
+	jar, err := NewCookieJar()
+	if err != nil {
+		t.Fatalf("Failed to create jar: %v", err)
+	}
+
+	ctx := context.Background()
+	login := &types.HttpRequest{Method: "POST", URL: server.URL + "/login"}
+	if _, err := ExecuteWithContext(ctx, login, nil, nil, jar, nil); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	me := &types.HttpRequest{Method: "GET", URL: server.URL + "/me"}
+	result, err := ExecuteWithContext(ctx, me, nil, nil, jar, nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.Status != http.StatusOK {
+		t.Errorf("Expected cookie to be sent (200), got %d", result.Status)
+	}
+
+	// Without a jar the cookie must not be sent
+	result, err = ExecuteWithContext(ctx, me, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.Status != http.StatusUnauthorized {
+		t.Errorf("Expected no cookie without jar (401), got %d", result.Status)
+	}
+}
+
+// TestCookieJar_PathScoping tests that cookies are only sent to matching paths
+func TestCookieJar_PathScoping(t *testing.T) {
+	var gotCookie bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/admin/login" {
+			http.SetCookie(w, &http.Cookie{Name: "admin", Value: "1", Path: "/admin"})
+			return
+		}
+		_, err := r.Cookie("admin")
+		gotCookie = err == nil
+	}))
+	defer server.Close()
+
+	jar, _ := NewCookieJar()
+	ctx := context.Background()
+	ExecuteWithContext(ctx, &types.HttpRequest{Method: "GET", URL: server.URL + "/admin/login"}, nil, nil, jar, nil)
+
+	ExecuteWithContext(ctx, &types.HttpRequest{Method: "GET", URL: server.URL + "/public"}, nil, nil, jar, nil)
+	if gotCookie {
+		t.Error("Expected cookie scoped to /admin not to be sent to /public")
+	}
+
+	ExecuteWithContext(ctx, &types.HttpRequest{Method: "GET", URL: server.URL + "/admin/users"}, nil, nil, jar, nil)
+	if !gotCookie {
+		t.Error("Expected cookie scoped to /admin to be sent to /admin/users")
+	}
+}
+
+// TestCookieJar_EntriesAndClear tests listing and clearing stored cookies
+func TestCookieJar_EntriesAndClear(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "a", Value: "1", Path: "/"})
+		http.SetCookie(w, &http.Cookie{Name: "b", Value: "2", Path: "/"})
+	}))
+	defer server.Close()
+
+	jar, _ := NewCookieJar()
+	ExecuteWithContext(context.Background(), &types.HttpRequest{Method: "GET", URL: server.URL}, nil, nil, jar, nil)
+
+	entries := jar.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 cookies, got %d", len(entries))
+	}
+
+	jar.Clear()
+	if len(jar.Entries()) != 0 {
+		t.Errorf("Expected no cookies after clear, got %d", len(jar.Entries()))
+	}
+}
@@ -0,0 +1,217 @@
+package executor
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// shellSafePattern matches values that can be passed to the shell without quoting
+var shellSafePattern = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// ToCurl renders a resolved request as an equivalent curl command line
+// Multipart bodies become -F (file parts) and --form-string flags, other bodies --data-raw
+func ToCurl(req *types.HttpRequest, tlsConfig *types.TLSConfig) string {
+	method, body, headers := exportedRequest(req)
+
+	url := req.URL
+	args := []string{"curl"}
+	if socketPath, httpURL, ok := SplitUnixSocketURL(req.URL); ok {
+		args = append(args, "--unix-socket", shellQuote(socketPath))
+		url = httpURL
+	}
+
+	formFields, isMultipart := multipartFields(headers, body)
+	if isMultipart {
+		// curl generates its own boundary
+		deleteHeader(headers, "Content-Type")
+	}
+
+	if method != "GET" || body != "" {
+		args = append(args, "-X", method)
+	}
+
+	switch strings.ToLower(req.HTTPVersion) {
+	case "http1":
+		args = append(args, "--http1.1")
+	case "http2":
+		args = append(args, "--http2")
+	case "h2c":
+		args = append(args, "--http2-prior-knowledge")
+	}
+
+	// Host mappings: --connect-to (unlike --resolve) also covers a different target port
+	if overrides, err := resolveHostOverrides(req, nil); err == nil && len(overrides) > 0 {
+		hosts := make([]string, 0, len(overrides))
+		for host := range overrides {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+		for _, host := range hosts {
+			args = append(args, "--connect-to", shellQuote(host+":"+overrides[host]))
+		}
+	}
+
+	switch {
+	case strings.EqualFold(req.ProxyURL, ProxyDirect):
+		args = append(args, "--noproxy", shellQuote("*"))
+	case req.ProxyURL != "":
+		args = append(args, "-x", shellQuote(req.ProxyURL))
+		if req.NoProxy != "" {
+			args = append(args, "--noproxy", shellQuote(req.NoProxy))
+		}
+	}
+
+	if tlsConfig != nil {
+		if tlsConfig.InsecureSkipVerify {
+			args = append(args, "-k")
+		}
+		if tlsConfig.CertFile != "" {
+			args = append(args, "--cert", shellQuote(tlsConfig.CertFile))
+		}
+		if tlsConfig.KeyFile != "" {
+			args = append(args, "--key", shellQuote(tlsConfig.KeyFile))
+		}
+		if tlsConfig.CAFile != "" {
+			args = append(args, "--cacert", shellQuote(tlsConfig.CAFile))
+		}
+	}
+
+	// Sort headers for a stable output
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-H", shellQuote(k+": "+headers[k]))
+	}
+
+	switch {
+	case isMultipart:
+		// -F reads a value starting with @ or < from a file, --form-string sends it as-is
+		for _, field := range formFields {
+			flag := "--form-string"
+			if field.File {
+				flag = "-F"
+			}
+			args = append(args, flag, shellQuote(field.Value))
+		}
+	case body == "":
+	default:
+		// --data and --data-binary read a body starting with @ from a file, --data-raw never does
+		args = append(args, "--data-raw", shellQuote(body))
+	}
+
+	args = append(args, shellQuote(url))
+	return strings.Join(args, " ")
+}
+
+// exportedRequest returns the method, body and headers sent for a request
+// The headers are a copy, safe to modify
+func exportedRequest(req *types.HttpRequest) (string, string, map[string]string) {
+	method := strings.ToUpper(req.Method)
+	body := req.Body
+	headers := make(map[string]string, len(req.Headers))
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+
+	// GraphQL requests are always a JSON POST (matches the executor)
+	if req.IsGraphQL() {
+		method = "POST"
+		payload, _ := json.Marshal(buildGraphQLPayload(req))
+		body = string(payload)
+		if getHeader(headers, "Content-Type") == "" {
+			headers["Content-Type"] = "application/json"
+		}
+	}
+	if method == "" {
+		method = "GET"
+	}
+	return method, body, headers
+}
+
+// formField is one part of a multipart body in curl's name=value form
+type formField struct {
+	Value string
+	File  bool // Value is name=@filename, read from disk by the client
+}
+
+// multipartFields converts a multipart/form-data body into curl form values
+// File parts become name=@filename (curl reads the file from disk)
+// ok is false when the request is not multipart or the body cannot be parsed
+func multipartFields(headers map[string]string, body string) ([]formField, bool) {
+	mediaType, params, err := mime.ParseMediaType(getHeader(headers, "Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return nil, false
+	}
+
+	// .http files usually use LF line endings, multipart requires CRLF
+	normalized := strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n")
+	reader := multipart.NewReader(strings.NewReader(normalized), params["boundary"])
+
+	var fields []formField
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false
+		}
+
+		name := part.FormName()
+		if name == "" {
+			continue
+		}
+		if filename := part.FileName(); filename != "" {
+			field := name + "=@" + filename
+			if contentType := part.Header.Get("Content-Type"); contentType != "" {
+				field += ";type=" + contentType
+			}
+			fields = append(fields, formField{Value: field, File: true})
+			continue
+		}
+
+		value, err := io.ReadAll(part)
+		if err != nil {
+			return nil, false
+		}
+		fields = append(fields, formField{Value: name + "=" + string(value)})
+	}
+	return fields, len(fields) > 0
+}
+
+// shellQuote wraps a value in single quotes when it contains shell metacharacters
+func shellQuote(value string) string {
+	if shellSafePattern.MatchString(value) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// getHeader returns a header value using a case-insensitive name lookup
+func getHeader(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+// deleteHeader removes a header using a case-insensitive name lookup
+func deleteHeader(headers map[string]string, name string) {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			delete(headers, k)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestToCurl(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *types.HttpRequest
+		tls      *types.TLSConfig
+		expected string
+	}{
+		{
+			name:     "simple GET",
+			req:      &types.HttpRequest{Method: "GET", URL: "https://api.example.com/users"},
+			expected: "curl https://api.example.com/users",
+		},
+		{
+			name: "headers are sorted and quoted",
+			req: &types.HttpRequest{
+				Method:  "DELETE",
+				URL:     "https://api.example.com/users/1",
+				Headers: map[string]string{"X-Trace": "a b", "Authorization": "Bearer abc"},
+			},
+			expected: "curl -X DELETE -H 'Authorization: Bearer abc' -H 'X-Trace: a b' https://api.example.com/users/1",
+		},
+		{
+			name:     "body uses --data-raw with escaped quotes",
+			req:      &types.HttpRequest{Method: "POST", URL: "https://api.example.com/notes", Body: `{"text":"it's"}`},
+			expected: `curl -X POST --data-raw '{"text":"it'\''s"}' https://api.example.com/notes`,
+		},
+		{
+			name:     "multi line body is sent untouched",
+			req:      &types.HttpRequest{Method: "PUT", URL: "https://api.example.com/notes", Body: "line1\nline2"},
+			expected: "curl -X PUT --data-raw 'line1\nline2' https://api.example.com/notes",
+		},
+		{
+			name:     "body starting with @ is not read from a file",
+			req:      &types.HttpRequest{Method: "POST", URL: "https://api.example.com/notes", Body: "@user hello"},
+			expected: "curl -X POST --data-raw '@user hello' https://api.example.com/notes",
+		},
+		{
+			name:     "insecure TLS adds -k",
+			req:      &types.HttpRequest{Method: "GET", URL: "https://localhost:8443/"},
+			tls:      &types.TLSConfig{InsecureSkipVerify: true},
+			expected: "curl -k https://localhost:8443/",
+		},
+		{
+			name: "host mappings use --connect-to",
+			req: &types.HttpRequest{
+				Method:  "GET",
+				URL:     "https://api.example.com/health",
+				Resolve: map[string]string{"api.example.com:443": "10.0.0.5:8443"},
+			},
+			expected: "curl --connect-to api.example.com:443:10.0.0.5:8443 https://api.example.com/health",
+		},
+		{
+			name:     "request proxy uses -x",
+			req:      &types.HttpRequest{Method: "GET", URL: "https://api.example.com/", ProxyURL: "socks5h://127.0.0.1:1080", NoProxy: ".internal"},
+			expected: "curl -x socks5h://127.0.0.1:1080 --noproxy .internal https://api.example.com/",
+		},
+		{
+			name: "multipart body becomes form flags",
+			req: &types.HttpRequest{
+				Method:  "POST",
+				URL:     "https://api.example.com/upload",
+				Headers: map[string]string{"Content-Type": "multipart/form-data; boundary=XYZ"},
+				Body: "--XYZ\nContent-Disposition: form-data; name=\"title\"\n\nmy file\n" +
+					"--XYZ\nContent-Disposition: form-data; name=\"file\"; filename=\"report.pdf\"\nContent-Type: application/pdf\n\n...\n--XYZ--\n",
+			},
+			expected: "curl -X POST --form-string 'title=my file' -F 'file=@report.pdf;type=application/pdf' https://api.example.com/upload",
+		},
+		{
+			name: "multipart text starting with @ or < stays literal",
+			req: &types.HttpRequest{
+				Method:  "POST",
+				URL:     "https://api.example.com/upload",
+				Headers: map[string]string{"Content-Type": "multipart/form-data; boundary=XYZ"},
+				Body: "--XYZ\nContent-Disposition: form-data; name=\"handle\"\n\n@user\n" +
+					"--XYZ\nContent-Disposition: form-data; name=\"html\"\n\n<b>hi</b>\n--XYZ--\n",
+			},
+			expected: "curl -X POST --form-string handle=@user --form-string 'html=<b>hi</b>' https://api.example.com/upload",
+		},
+		{
+			name: "GraphQL is sent as a JSON POST",
+			req: &types.HttpRequest{
+				Protocol: "graphql",
+				Method:   "GET",
+				URL:      "https://api.example.com/graphql",
+				Body:     "{ me { id } }",
+			},
+			expected: `curl -X POST -H 'Content-Type: application/json' --data-raw '{"query":"{ me { id } }"}' https://api.example.com/graphql`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToCurl(tt.req, tt.tls); got != tt.expected {
+				t.Errorf("ToCurl() =\n%s\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -117,6 +117,8 @@ Errors include detailed context for troubleshooting.
 		headers,
 		subprotocols,
 		tlsConfig,
+		WebSocketKeepalive{PingInterval: 30 * time.Second},
+		responder, // From NewAutoResponder, nil without @on rules
 		resolver,
 		sendChan,
 		callback,
@@ -0,0 +1,93 @@
+package executor
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// DownloadProgress is called while a response body is written to a file
+// total is the Content-Length, or -1 when unknown (or compressed, since the length is then on the wire).
+type DownloadProgress func(written, total int64)
+
+// downloadProgressInterval throttles progress reports
+const downloadProgressInterval = 100 * time.Millisecond
+
+type bodyWriterKey struct{}
+
+// bodyWriter receives the response body instead of RequestResult.Body
+type bodyWriter struct {
+	w        io.Writer
+	progress DownloadProgress // Optional
+}
+
+// WithBodyWriter returns a context whose requests write the response body to w as it arrives
+// The body is not kept in RequestResult.Body (ResponseSize is the number of bytes written) and
+// streamed content types are written like any other. A writer that can be truncated, such as
+// *os.File, is emptied before each attempt so retries do not append to it.
+func WithBodyWriter(ctx context.Context, w io.Writer, progress DownloadProgress) context.Context {
+	return context.WithValue(ctx, bodyWriterKey{}, &bodyWriter{w: w, progress: progress})
+}
+
+// bodyWriterFrom returns the body writer of ctx (nil = keep the body in memory)
+func bodyWriterFrom(ctx context.Context) *bodyWriter {
+	sink, _ := ctx.Value(bodyWriterKey{}).(*bodyWriter)
+	return sink
+}
+
+// truncater is implemented by writers that can be emptied between attempts
+type truncater interface {
+	Truncate(size int64) error
+	Seek(offset int64, whence int) (int64, error)
+}
+
+// write copies a body to the writer, decoding compressed bodies on the fly
+// Returns the decoded size and the size on the wire (0 = not compressed).
+func (b *bodyWriter) write(body io.Reader, contentEncoding string, autoDecompress bool, contentLength int64) (int, int, error) {
+	if t, ok := b.w.(truncater); ok {
+		if err := t.Truncate(0); err != nil {
+			return 0, 0, err
+		}
+		if _, err := t.Seek(0, io.SeekStart); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	reader, wireCounter := decodeBodyStream(body, contentEncoding, autoDecompress)
+	if wireCounter != nil {
+		contentLength = -1
+	}
+	if b.progress != nil {
+		reader = &progressReader{reader: reader, total: contentLength, report: b.progress}
+	}
+
+	written, err := io.Copy(b.w, reader)
+	if b.progress != nil {
+		b.progress(written, contentLength)
+	}
+
+	compressedSize := 0
+	if wireCounter != nil {
+		compressedSize = wireCounter.count
+	}
+	return int(written), compressedSize, err
+}
+
+// progressReader reports the bytes read, at most once per downloadProgressInterval
+type progressReader struct {
+	reader     io.Reader
+	total      int64
+	read       int64
+	report     DownloadProgress
+	lastReport time.Time
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.reader.Read(buf)
+	p.read += int64(n)
+	if time.Since(p.lastReport) >= downloadProgressInterval {
+		p.lastReport = time.Now()
+		p.report(p.read, p.total)
+	}
+	return n, err
+}
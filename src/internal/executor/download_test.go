@@ -0,0 +1,81 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// TestBodyWriter_WritesBodyToFile tests that a download is written to the file instead of Body
+func TestBodyWriter_WritesBodyToFile(t *testing.T) {
+	body := strings.Repeat("x", 64*1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// No Content-Length: sent chunked, which the streaming executor would otherwise stream
+			w.Write([]byte(body[:100]))
+			w.(http.Flusher).Flush()
+			w.Write([]byte(body[100:]))
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		execute func(context.Context, *types.HttpRequest) (*types.RequestResult, error)
+	}{
+		{"buffered", "/file", func(ctx context.Context, req *types.HttpRequest) (*types.RequestResult, error) {
+			return ExecuteWithContext(ctx, req, nil, nil, nil, nil)
+		}},
+		{"streaming", "/chunked", func(ctx context.Context, req *types.HttpRequest) (*types.RequestResult, error) {
+			return ExecuteWithStreaming(ctx, req, nil, nil, nil, nil, nil)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := os.Create(filepath.Join(t.TempDir(), "download.bin"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			file.WriteString("stale content from a previous attempt")
+
+			var lastWritten, lastTotal int64
+			ctx := WithBodyWriter(context.Background(), file, func(written, total int64) {
+				lastWritten, lastTotal = written, total
+			})
+			result, err := tt.execute(ctx, &types.HttpRequest{Method: "GET", URL: server.URL + tt.path})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if result.Body != "" || result.Truncated {
+				t.Errorf("Expected no body in memory, got %d bytes (truncated %v)", len(result.Body), result.Truncated)
+			}
+			if result.ResponseSize != len(body) {
+				t.Errorf("Expected ResponseSize %d, got %d", len(body), result.ResponseSize)
+			}
+			written, _ := os.ReadFile(file.Name())
+			if string(written) != body {
+				t.Errorf("Expected the file to hold the body only, got %d bytes", len(written))
+			}
+			if lastWritten != int64(len(body)) {
+				t.Errorf("Expected a final progress report of %d bytes, got %d", len(body), lastWritten)
+			}
+			if tt.path == "/file" && lastTotal != int64(len(body)) {
+				t.Errorf("Expected the Content-Length as total, got %d", lastTotal)
+			}
+		})
+	}
+}
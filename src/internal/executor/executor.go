@@ -24,11 +24,37 @@ const (
 
 // Execute performs an HTTP request and returns the result
 func Execute(req *types.HttpRequest, tlsConfig *types.TLSConfig, profile *types.Profile) (*types.RequestResult, error) {
-	return ExecuteWithContext(context.Background(), req, tlsConfig, profile)
+	return ExecuteWithContext(context.Background(), req, tlsConfig, profile, nil, nil)
 }
 
 // ExecuteWithContext performs an HTTP request with cancellation support via context
-func ExecuteWithContext(ctx context.Context, req *types.HttpRequest, tlsConfig *types.TLSConfig, profile *types.Profile) (*types.RequestResult, error) {
+// Failed attempts are retried according to the request/profile retry policy (see retry.go)
+// jar is optional (nil = cookies are neither stored nor sent)
+// pool is optional (nil = new connections for each request)
+func ExecuteWithContext(ctx context.Context, req *types.HttpRequest, tlsConfig *types.TLSConfig, profile *types.Profile, jar http.CookieJar, pool *ConnectionPool) (*types.RequestResult, error) {
+	policy := resolveRetryPolicy(req, profile)
+
+	for attempt := 1; ; attempt++ {
+		result, err := executeAttempt(ctx, req, tlsConfig, profile, jar, pool)
+		if err != nil {
+			// Configuration errors (bad URL, TLS, version) never succeed on retry
+			return nil, err
+		}
+		result.Attempts = attempt
+		result.MaxDurationMs = durationBudget(req, profile)
+
+		if attempt > policy.count || !policy.shouldRetry(ctx, req.Method, result) {
+			return result, nil
+		}
+
+		if !policy.wait(ctx, attempt) {
+			return result, nil
+		}
+	}
+}
+
+// executeAttempt performs a single HTTP request attempt
+func executeAttempt(ctx context.Context, req *types.HttpRequest, tlsConfig *types.TLSConfig, profile *types.Profile, jar http.CookieJar, pool *ConnectionPool) (*types.RequestResult, error) {
 	startTime := time.Now()
 
 	// Get timeout from profile or use default
@@ -37,17 +63,42 @@ func ExecuteWithContext(ctx context.Context, req *types.HttpRequest, tlsConfig *
 		timeout = profile.GetRequestTimeout()
 	}
 
+	// gRPC calls use their own client (unary, resolved through server reflection)
+	if req.IsGRPC() {
+		return executeGRPC(ctx, req, tlsConfig, startTime, timeout)
+	}
+
+	// Unix socket URLs are sent to a rewritten http(s)://localhost URL over the socket
+	req, socketPath, err := resolveUnixSocket(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve and validate the HTTP protocol version
+	httpVersion := resolveHTTPVersion(req, profile)
+	if err := validateHTTPVersion(httpVersion, req.URL); err != nil {
+		return nil, err
+	}
+
+	// Host mappings dial another address for the URL host (like curl --resolve)
+	overrides, err := resolveHostOverrides(req, profile)
+	if err != nil {
+		return nil, err
+	}
+	dial := dialSettings{socketPath: socketPath, overrides: overrides, proxy: ResolveOutboundProxy(req, profile)}
+
+	// Decompress gzip/deflate/br responses unless the profile opts out
+	autoDecompress := profile == nil || profile.GetAutoDecompress()
+
 	// Handle GraphQL protocol
-	if req.Protocol == "graphql" {
-		return executeGraphQL(req, tlsConfig, startTime, timeout)
+	if req.IsGraphQL() {
+		return executeGraphQL(req, tlsConfig, startTime, timeout, httpVersion, autoDecompress, jar, dial, pool, newRedirectRecorder(req, profile))
 	}
 
-	// Create HTTP request
-	var bodyReader io.Reader
-	requestSize := 0
-	if req.Body != "" {
-		bodyReader = bytes.NewBufferString(req.Body)
-		requestSize = len(req.Body)
+	// Create HTTP request (body compressed if requested; size reflects bytes on the wire)
+	bodyReader, requestSize, contentEncoding, err := buildRequestBody(req)
+	if err != nil {
+		return nil, err
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bodyReader)
@@ -59,33 +110,59 @@ func ExecuteWithContext(ctx context.Context, req *types.HttpRequest, tlsConfig *
 	for key, value := range req.Headers {
 		httpReq.Header.Set(key, value)
 	}
+	if contentEncoding != "" {
+		httpReq.Header.Set("Content-Encoding", contentEncoding)
+	}
+	setAcceptEncoding(httpReq, autoDecompress)
+	if err := SignRequest(httpReq, req.Signing); err != nil {
+		return nil, err
+	}
 
-	// Build HTTP client with optional TLS configuration
-	client, err := buildHTTPClient(tlsConfig, time.Duration(timeout)*time.Second)
+	// Build HTTP client with optional TLS configuration and the redirect policy
+	redirects := newRedirectRecorder(req, profile)
+	client, err := buildHTTPClient(tlsConfig, time.Duration(timeout)*time.Second, httpVersion, jar, dial, pool, redirects)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
 	}
 
-	resp, err := client.Do(httpReq)
+	tracer := newPhaseTracer()
+	resp, err := client.Do(tracer.attach(httpReq))
 	duration := time.Since(startTime).Milliseconds()
 
 	if err != nil {
 		return &types.RequestResult{
 			Error:       err.Error(),
 			Duration:    duration,
+			Timings:     tracer.result(),
+			Redirects:   redirects.result(),
 			RequestSize: requestSize,
 		}, nil
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	bodyBytes, err := io.ReadAll(resp.Body)
+	// Read response body (written to the context's writer, or only a preview when it sets a limit)
+	var bodyBytes []byte
+	var responseSize, compressedSize int
+	sink := bodyWriterFrom(ctx)
+	if sink != nil {
+		responseSize, compressedSize, err = sink.write(resp.Body, resp.Header.Get("Content-Encoding"), autoDecompress, resp.ContentLength)
+	} else if limit := previewLimit(ctx); limit > 0 {
+		bodyBytes, responseSize, compressedSize, err = readPreview(resp.Body, resp.Header.Get("Content-Encoding"), autoDecompress, limit)
+	} else if bodyBytes, err = io.ReadAll(resp.Body); err == nil {
+		// Decompress body (headers keep the original Content-Encoding)
+		bodyBytes, compressedSize = decodeResponse(bodyBytes, resp.Header.Get("Content-Encoding"), autoDecompress)
+		responseSize = len(bodyBytes)
+	}
 	if err != nil {
 		return &types.RequestResult{
 			Status:      resp.StatusCode,
 			StatusText:  resp.Status,
+			Protocol:    resp.Proto,
+			TLS:         newTLSInfo(resp.TLS),
 			Error:       fmt.Sprintf("failed to read response body: %v", err),
 			Duration:    duration,
+			Timings:     tracer.result(),
+			Redirects:   redirects.result(),
 			RequestSize: requestSize,
 		}, nil
 	}
@@ -100,14 +177,20 @@ func ExecuteWithContext(ctx context.Context, req *types.HttpRequest, tlsConfig *
 	// to ensure parsing happens as the final step
 
 	result := &types.RequestResult{
-		Status:       resp.StatusCode,
-		StatusText:   resp.Status,
-		Headers:      headers,
-		Body:         string(bodyBytes),
-		Duration:     duration,
-		RequestSize:  requestSize,
-		ResponseSize: len(bodyBytes),
-		Timestamp:    startTime.Format(time.RFC3339),
+		Status:         resp.StatusCode,
+		StatusText:     resp.Status,
+		Protocol:       resp.Proto,
+		TLS:            newTLSInfo(resp.TLS),
+		Headers:        headers,
+		Body:           string(bodyBytes),
+		Duration:       duration,
+		Timings:        tracer.result(),
+		Redirects:      redirects.result(),
+		RequestSize:    requestSize,
+		ResponseSize:   responseSize,
+		CompressedSize: compressedSize,
+		Timestamp:      startTime.Format(time.RFC3339),
+		Truncated:      sink == nil && responseSize > len(bodyBytes),
 	}
 
 	return result, nil
@@ -115,8 +198,32 @@ func ExecuteWithContext(ctx context.Context, req *types.HttpRequest, tlsConfig *
 
 // ExecuteWithStreaming performs an HTTP request with streaming support
 // Auto-detects streaming based on Content-Type and Transfer-Encoding headers
-// Calls streamCallback for each chunk received
-func ExecuteWithStreaming(ctx context.Context, req *types.HttpRequest, tlsConfig *types.TLSConfig, profile *types.Profile, streamCallback types.StreamCallback) (*types.RequestResult, error) {
+// Calls streamCallback for each chunk received (for ndjson: once per complete line)
+// Retries follow the same policy as ExecuteWithContext but only before the body is read
+// jar is optional (nil = cookies are neither stored nor sent)
+// pool is optional (nil = new connections for each request)
+func ExecuteWithStreaming(ctx context.Context, req *types.HttpRequest, tlsConfig *types.TLSConfig, profile *types.Profile, jar http.CookieJar, pool *ConnectionPool, streamCallback types.StreamCallback) (*types.RequestResult, error) {
+	result, err := executeStreaming(ctx, req, tlsConfig, profile, jar, pool, streamCallback)
+	if result != nil {
+		result.MaxDurationMs = durationBudget(req, profile)
+	}
+	return result, err
+}
+
+// durationBudget returns the latency budget of a request in milliseconds
+// The request-level maxDurationMs overrides the profile default (0 = no budget).
+func durationBudget(req *types.HttpRequest, profile *types.Profile) int64 {
+	if req.MaxDurationMs > 0 {
+		return int64(req.MaxDurationMs)
+	}
+	if profile != nil && profile.MaxDurationMs > 0 {
+		return int64(profile.MaxDurationMs)
+	}
+	return 0
+}
+
+// executeStreaming performs the request of ExecuteWithStreaming
+func executeStreaming(ctx context.Context, req *types.HttpRequest, tlsConfig *types.TLSConfig, profile *types.Profile, jar http.CookieJar, pool *ConnectionPool, streamCallback types.StreamCallback) (*types.RequestResult, error) {
 	startTime := time.Now()
 
 	// Get max response size from profile or use default
@@ -131,44 +238,127 @@ func ExecuteWithStreaming(ctx context.Context, req *types.HttpRequest, tlsConfig
 		timeout = profile.GetRequestTimeout()
 	}
 
-	// Handle GraphQL protocol
-	if req.Protocol == "graphql" {
-		return executeGraphQL(req, tlsConfig, startTime, timeout)
+	// gRPC calls use their own client (unary, resolved through server reflection)
+	if req.IsGRPC() {
+		return executeGRPC(ctx, req, tlsConfig, startTime, timeout)
 	}
 
-	// Create HTTP request
-	var bodyReader io.Reader
-	requestSize := 0
-	if req.Body != "" {
-		bodyReader = bytes.NewBufferString(req.Body)
-		requestSize = len(req.Body)
+	// Unix socket URLs are sent to a rewritten http(s)://localhost URL over the socket
+	req, socketPath, err := resolveUnixSocket(req)
+	if err != nil {
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bodyReader)
+	// Resolve and validate the HTTP protocol version
+	httpVersion := resolveHTTPVersion(req, profile)
+	if err := validateHTTPVersion(httpVersion, req.URL); err != nil {
+		return nil, err
+	}
+
+	// Host mappings dial another address for the URL host (like curl --resolve)
+	overrides, err := resolveHostOverrides(req, profile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	dial := dialSettings{socketPath: socketPath, overrides: overrides, proxy: ResolveOutboundProxy(req, profile)}
 
-	// Set headers
-	for key, value := range req.Headers {
-		httpReq.Header.Set(key, value)
+	// Decompress gzip/deflate/br responses unless the profile opts out
+	autoDecompress := profile == nil || profile.GetAutoDecompress()
+
+	// Validate the stream format (ndjson delivers one line per callback)
+	streamFormat := resolveStreamFormat(req)
+	if err := validateStreamFormat(streamFormat); err != nil {
+		return nil, err
+	}
+
+	// Handle GraphQL protocol
+	if req.IsGraphQL() {
+		return executeGraphQL(req, tlsConfig, startTime, timeout, httpVersion, autoDecompress, jar, dial, pool, newRedirectRecorder(req, profile))
 	}
 
-	// Build HTTP client with optional TLS configuration
+	// Build HTTP client with optional TLS configuration and the redirect policy
 	// Use no timeout for streaming requests (timeout is managed by context)
-	client, err := buildHTTPClient(tlsConfig, 0)
+	redirects := newRedirectRecorder(req, profile)
+	client, err := buildHTTPClient(tlsConfig, 0, httpVersion, jar, dial, pool, redirects)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
 	}
 
-	resp, err := client.Do(httpReq)
+	// Retries only happen before any chunk is delivered (network error or retryable status)
+	policy := resolveRetryPolicy(req, profile)
+	var resp *http.Response
+	var requestSize int
+	var tracer *phaseTracer
+	attempts := 0
+
+	for {
+		attempts++
+		redirects.reset() // Only the hops of the last attempt are reported
+
+		// Create HTTP request (body compressed if requested; size reflects bytes on the wire)
+		var bodyReader io.Reader
+		var contentEncoding string
+		bodyReader, requestSize, contentEncoding, err = buildRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+
+		httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Set headers
+		for key, value := range req.Headers {
+			httpReq.Header.Set(key, value)
+		}
+		if contentEncoding != "" {
+			httpReq.Header.Set("Content-Encoding", contentEncoding)
+		}
+		setAcceptEncoding(httpReq, autoDecompress)
+		// Signed on every attempt, the timestamp is part of the signature
+		if err := SignRequest(httpReq, req.Signing); err != nil {
+			return nil, err
+		}
+
+		tracer = newPhaseTracer()
+		resp, err = client.Do(tracer.attach(httpReq))
+
+		outcome := &types.RequestResult{}
+		if err != nil {
+			outcome.Error = err.Error()
+		} else {
+			outcome.Status = resp.StatusCode
+		}
+		if attempts > policy.count || !policy.shouldRetry(ctx, req.Method, outcome) {
+			break
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if !policy.wait(ctx, attempts) {
+			return &types.RequestResult{
+				Status:      outcome.Status,
+				Error:       "Request cancelled",
+				Duration:    time.Since(startTime).Milliseconds(),
+				Timings:     tracer.result(),
+				Redirects:   redirects.result(),
+				RequestSize: requestSize,
+				Attempts:    attempts,
+			}, nil
+		}
+	}
 	duration := time.Since(startTime).Milliseconds()
 
 	if err != nil {
 		return &types.RequestResult{
 			Error:       err.Error(),
 			Duration:    duration,
+			Timings:     tracer.result(),
+			Redirects:   redirects.result(),
 			RequestSize: requestSize,
+			Attempts:    attempts,
 		}, nil
 	}
 	defer resp.Body.Close()
@@ -182,55 +372,101 @@ func ExecuteWithStreaming(ctx context.Context, req *types.HttpRequest, tlsConfig
 	// Detect if response is streaming
 	contentType := resp.Header.Get("Content-Type")
 	transferEncoding := resp.Header.Get("Transfer-Encoding")
-	isStreaming := strings.Contains(contentType, "text/event-stream") ||
+	// Downloads are written to the body writer as they arrive, whatever the content type
+	sink := bodyWriterFrom(ctx)
+	isStreaming := sink == nil && (strings.Contains(contentType, "text/event-stream") ||
 		strings.Contains(contentType, "application/stream+json") ||
 		strings.Contains(contentType, "application/x-ndjson") ||
 		strings.Contains(contentType, "application/jsonlines") ||
-		strings.Contains(transferEncoding, "chunked")
+		strings.Contains(transferEncoding, "chunked") ||
+		streamFormat == StreamFormatNDJSON)
 
 	var bodyBytes []byte
+	var responseSize int
 	var readErr error
 
-	if isStreaming {
+	// Decode compressed streams on the fly, counting the bytes received on the wire
+	responseReader, wireCounter := decodeBodyStream(resp.Body, resp.Header.Get("Content-Encoding"), autoDecompress)
+
+	if sink != nil {
+		// Content-Length is the size on the wire, only meaningful for progress when not decoded
+		contentLength := resp.ContentLength
+		if wireCounter != nil {
+			contentLength = -1
+		}
+		responseSize, _, readErr = sink.write(responseReader, "", false, contentLength)
+	} else if isStreaming {
 		// Stream the response (works with or without callback)
-		bodyBytes, readErr = streamResponse(ctx, resp.Body, maxSize, streamCallback)
+		if streamFormat == StreamFormatNDJSON {
+			bodyBytes, readErr = streamNDJSON(ctx, responseReader, maxSize, streamCallback)
+		} else {
+			bodyBytes, readErr = streamResponse(ctx, responseReader, maxSize, streamCallback)
+		}
+	} else if limit := previewLimit(ctx); limit > 0 {
+		// Non-streaming preview: keep the first bytes, count the rest
+		bodyBytes, responseSize, _, readErr = readPreview(responseReader, "", false, limit)
 	} else {
 		// Non-streaming: read all at once
-		bodyBytes, readErr = io.ReadAll(resp.Body)
+		bodyBytes, readErr = io.ReadAll(responseReader)
+	}
+	if responseSize == 0 {
+		responseSize = len(bodyBytes)
+	}
+
+	compressedSize := 0
+	if wireCounter != nil {
+		compressedSize = wireCounter.count
 	}
 
 	if readErr != nil {
 		// Check if cancelled
 		if ctx.Err() == context.Canceled {
 			return &types.RequestResult{
-				Status:      resp.StatusCode,
-				StatusText:  resp.Status,
-				Headers:     headers,
-				Body:        string(bodyBytes), // Partial body
-				Error:       "Request cancelled",
-				Duration:    time.Since(startTime).Milliseconds(),
-				RequestSize: requestSize,
+				Status:       resp.StatusCode,
+				StatusText:   resp.Status,
+				Protocol:     resp.Proto,
+				TLS:          newTLSInfo(resp.TLS),
+				Headers:      headers,
+				Body:         string(bodyBytes), // Partial body
+				Error:        "Request cancelled",
+				Duration:     time.Since(startTime).Milliseconds(),
+				Timings:      tracer.result(),
+				Redirects:    redirects.result(),
+				RequestSize:  requestSize,
 				ResponseSize: len(bodyBytes),
+				Attempts:     attempts,
 			}, nil
 		}
 		return &types.RequestResult{
 			Status:      resp.StatusCode,
 			StatusText:  resp.Status,
+			Protocol:    resp.Proto,
+			TLS:         newTLSInfo(resp.TLS),
 			Headers:     headers,
 			Error:       fmt.Sprintf("failed to read response body: %v", readErr),
 			Duration:    time.Since(startTime).Milliseconds(),
+			Timings:     tracer.result(),
+			Redirects:   redirects.result(),
 			RequestSize: requestSize,
+			Attempts:    attempts,
 		}, nil
 	}
 
 	result := &types.RequestResult{
-		Status:       resp.StatusCode,
-		StatusText:   resp.Status,
-		Headers:      headers,
-		Body:         string(bodyBytes),
-		Duration:     time.Since(startTime).Milliseconds(),
-		RequestSize:  requestSize,
-		ResponseSize: len(bodyBytes),
+		Status:         resp.StatusCode,
+		StatusText:     resp.Status,
+		Protocol:       resp.Proto,
+		TLS:            newTLSInfo(resp.TLS),
+		Headers:        headers,
+		Body:           string(bodyBytes),
+		Duration:       time.Since(startTime).Milliseconds(),
+		Timings:        tracer.result(),
+		Redirects:      redirects.result(),
+		RequestSize:    requestSize,
+		ResponseSize:   responseSize,
+		CompressedSize: compressedSize,
+		Attempts:       attempts,
+		Truncated:      sink == nil && responseSize > len(bodyBytes),
 	}
 
 	return result, nil
@@ -286,11 +522,47 @@ func streamResponse(ctx context.Context, body io.Reader, maxSize int64, callback
 
 // buildHTTPClient creates an HTTP client with optional TLS/mTLS configuration
 // timeout parameter: 0 = no timeout, > 0 = specific timeout
-func buildHTTPClient(tlsConfig *types.TLSConfig, timeout time.Duration) (*http.Client, error) {
-	transport := &http.Transport{}
+// httpVersion parameter: auto, http1, http2 or h2c (see protocol.go)
+// jar parameter: nil = no cookie handling
+// dial holds the socket, host mappings and outbound proxy (zero value = dial the URL host over TCP)
+// pool is optional (nil = a new transport for this client)
+// redirects is optional (nil = Go's default redirect policy)
+func buildHTTPClient(tlsConfig *types.TLSConfig, timeout time.Duration, httpVersion string, jar http.CookieJar, dial dialSettings, pool *ConnectionPool, redirects *redirectRecorder) (*http.Client, error) {
+	var transport http.RoundTripper
+	var err error
+	if pool != nil {
+		transport, err = pool.transport(tlsConfig, httpVersion, dial)
+	} else {
+		transport, err = newTransport(tlsConfig, httpVersion, dial)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		Jar:       jar,
+	}
+	if redirects != nil {
+		client.CheckRedirect = redirects.checkRedirect
+	}
+	return client, nil
+}
+
+// dialSettings are the connection settings of a transport besides TLS and the HTTP version
+type dialSettings struct {
+	socketPath string        // Unix socket to dial instead of TCP (see unixsocket.go)
+	overrides  hostOverrides // Addresses dialed for mapped hosts (see resolve.go)
+	proxy      OutboundProxy // Outbound proxy, ignored for unix sockets (see outbound_proxy.go)
+}
+
+// newTransport creates the round tripper for the TLS settings, HTTP version and dial settings
+func newTransport(tlsConfig *types.TLSConfig, httpVersion string, dial dialSettings) (http.RoundTripper, error) {
+	var tlsCfg *tls.Config
 
 	if tlsConfig != nil {
-		tlsCfg := &tls.Config{
+		tlsCfg = &tls.Config{
 			InsecureSkipVerify: tlsConfig.InsecureSkipVerify,
 		}
 
@@ -316,13 +588,22 @@ func buildHTTPClient(tlsConfig *types.TLSConfig, timeout time.Duration) (*http.C
 			tlsCfg.RootCAs = caCertPool
 		}
 
-		transport.TLSClientConfig = tlsCfg
+		if err := PinCertificate(tlsCfg, tlsConfig.PinnedCertSHA256); err != nil {
+			return nil, err
+		}
 	}
 
-	return &http.Client{
-		Timeout:   timeout,
-		Transport: transport,
-	}, nil
+	transport := buildTransport(tlsCfg, httpVersion)
+	if dial.socketPath != "" {
+		useUnixSocket(transport, dial.socketPath)
+		return transport, nil
+	}
+	// Host mappings wrap the proxy dialer, so a SOCKS proxy connects to the mapped address
+	if err := useOutboundProxy(transport, dial.proxy); err != nil {
+		return nil, err
+	}
+	useHostOverrides(transport, dial.overrides)
+	return transport, nil
 }
 
 // FormatDuration formats duration in milliseconds to human-readable string
@@ -334,6 +615,23 @@ func FormatDuration(ms int64) string {
 	return fmt.Sprintf("%.2fs", seconds)
 }
 
+// FormatTimings renders the phase breakdown as a one-line waterfall
+// e.g. "DNS 12ms | Connect 30ms | TLS 80ms | TTFB 210ms | Total 250ms"
+func FormatTimings(timings *types.RequestTimings, totalMs int64) string {
+	parts := []string{
+		fmt.Sprintf("DNS %s", FormatDuration(timings.DNS)),
+		fmt.Sprintf("Connect %s", FormatDuration(timings.Connect)),
+	}
+	if timings.TLS > 0 {
+		parts = append(parts, fmt.Sprintf("TLS %s", FormatDuration(timings.TLS)))
+	}
+	parts = append(parts,
+		fmt.Sprintf("TTFB %s", FormatDuration(timings.TTFB)),
+		fmt.Sprintf("Total %s", FormatDuration(totalMs)),
+	)
+	return strings.Join(parts, " | ")
+}
+
 // FormatSize formats byte size to human-readable string
 func FormatSize(bytes int) string {
 	if bytes < 1024 {
@@ -393,22 +691,19 @@ func ParseEscapeSequences(s string) string {
 }
 
 // executeGraphQL handles GraphQL protocol requests
-func executeGraphQL(req *types.HttpRequest, tlsConfig *types.TLSConfig, startTime time.Time, timeout int) (*types.RequestResult, error) {
+func executeGraphQL(req *types.HttpRequest, tlsConfig *types.TLSConfig, startTime time.Time, timeout int, httpVersion string, autoDecompress bool, jar http.CookieJar, dial dialSettings, pool *ConnectionPool, redirects *redirectRecorder) (*types.RequestResult, error) {
 	// Build GraphQL request payload
-	graphqlPayload := map[string]interface{}{
-		"query": req.Body,
-	}
-
-	// Marshal to JSON
-	payloadBytes, err := json.Marshal(graphqlPayload)
+	payloadBytes, err := json.Marshal(buildGraphQLPayload(req))
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal GraphQL query: %w", err)
 	}
 
-	requestSize := len(payloadBytes)
-
 	// Create HTTP POST request (GraphQL is always POST)
-	httpReq, err := http.NewRequest("POST", req.URL, bytes.NewBuffer(payloadBytes))
+	bodyReader, requestSize, contentEncoding, err := encodeRequestBody(payloadBytes, "POST", req.RequestCompression)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest("POST", req.URL, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -420,20 +715,30 @@ func executeGraphQL(req *types.HttpRequest, tlsConfig *types.TLSConfig, startTim
 	for key, value := range req.Headers {
 		httpReq.Header.Set(key, value)
 	}
+	if contentEncoding != "" {
+		httpReq.Header.Set("Content-Encoding", contentEncoding)
+	}
+	setAcceptEncoding(httpReq, autoDecompress)
+	if err := SignRequest(httpReq, req.Signing); err != nil {
+		return nil, err
+	}
 
 	// Build HTTP client with TLS configuration
-	client, err := buildHTTPClient(tlsConfig, time.Duration(timeout)*time.Second)
+	client, err := buildHTTPClient(tlsConfig, time.Duration(timeout)*time.Second, httpVersion, jar, dial, pool, redirects)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure HTTP client: %w", err)
 	}
 
-	resp, err := client.Do(httpReq)
+	tracer := newPhaseTracer()
+	resp, err := client.Do(tracer.attach(httpReq))
 	duration := time.Since(startTime).Milliseconds()
 
 	if err != nil {
 		return &types.RequestResult{
 			Error:       err.Error(),
 			Duration:    duration,
+			Timings:     tracer.result(),
+			Redirects:   redirects.result(),
 			RequestSize: requestSize,
 		}, nil
 	}
@@ -445,8 +750,12 @@ func executeGraphQL(req *types.HttpRequest, tlsConfig *types.TLSConfig, startTim
 		return &types.RequestResult{
 			Status:      resp.StatusCode,
 			StatusText:  resp.Status,
+			Protocol:    resp.Proto,
+			TLS:         newTLSInfo(resp.TLS),
 			Error:       fmt.Sprintf("failed to read response body: %v", err),
 			Duration:    duration,
+			Timings:     tracer.result(),
+			Redirects:   redirects.result(),
 			RequestSize: requestSize,
 		}, nil
 	}
@@ -457,14 +766,19 @@ func executeGraphQL(req *types.HttpRequest, tlsConfig *types.TLSConfig, startTim
 		headers[key] = strings.Join(values, ", ")
 	}
 
+	// Decompress body (headers keep the original Content-Encoding)
+	bodyBytes, compressedSize := decodeResponse(bodyBytes, resp.Header.Get("Content-Encoding"), autoDecompress)
+
 	// Parse GraphQL response to check for errors
 	var graphqlResp struct {
-		Data   interface{}            `json:"data"`
-		Errors []interface{}          `json:"errors,omitempty"`
+		Data   interface{}   `json:"data"`
+		Errors []interface{} `json:"errors,omitempty"`
 	}
 
 	responseBody := string(bodyBytes)
+	var graphqlErrors []string
 	if err := json.Unmarshal(bodyBytes, &graphqlResp); err == nil {
+		graphqlErrors = graphQLErrorMessages(graphqlResp.Errors)
 		// Successfully parsed as GraphQL response
 		if len(graphqlResp.Errors) > 0 {
 			// GraphQL returned errors - format them nicely
@@ -481,18 +795,70 @@ func executeGraphQL(req *types.HttpRequest, tlsConfig *types.TLSConfig, startTim
 	// If parsing fails, just use raw body
 
 	result := &types.RequestResult{
-		Status:       resp.StatusCode,
-		StatusText:   resp.Status,
-		Headers:      headers,
-		Body:         responseBody,
-		Duration:     duration,
-		RequestSize:  requestSize,
-		ResponseSize: len(bodyBytes),
+		Status:         resp.StatusCode,
+		StatusText:     resp.Status,
+		Protocol:       resp.Proto,
+		TLS:            newTLSInfo(resp.TLS),
+		Headers:        headers,
+		Body:           responseBody,
+		Duration:       duration,
+		Timings:        tracer.result(),
+		Redirects:      redirects.result(),
+		RequestSize:    requestSize,
+		ResponseSize:   len(bodyBytes),
+		CompressedSize: compressedSize,
+		GraphQLErrors:  graphqlErrors,
 	}
 
 	return result, nil
 }
 
+// buildGraphQLPayload builds the standard {"query", "variables", "operationName"} body
+// Requests without a GraphQL block use the body as the query
+func buildGraphQLPayload(req *types.HttpRequest) map[string]interface{} {
+	payload := map[string]interface{}{
+		"query": req.Body,
+	}
+	if req.GraphQL == nil {
+		return payload
+	}
+
+	if req.GraphQL.Query != "" {
+		payload["query"] = req.GraphQL.Query
+	}
+	if len(req.GraphQL.Variables) > 0 {
+		payload["variables"] = req.GraphQL.Variables
+	}
+	if req.GraphQL.OperationName != "" {
+		payload["operationName"] = req.GraphQL.OperationName
+	}
+	return payload
+}
+
+// graphQLErrorMessages extracts readable messages from a GraphQL "errors" array
+// Each entry is "message" or "message (path: a.b.0)"; entries without a message are shown as JSON
+func graphQLErrorMessages(errors []interface{}) []string {
+	var messages []string
+	for _, entry := range errors {
+		obj, ok := entry.(map[string]interface{})
+		message, hasMessage := obj["message"].(string)
+		if !ok || !hasMessage {
+			messages = append(messages, formatJSON(entry))
+			continue
+		}
+
+		if path, ok := obj["path"].([]interface{}); ok && len(path) > 0 {
+			segments := make([]string, len(path))
+			for i, segment := range path {
+				segments[i] = fmt.Sprintf("%v", segment)
+			}
+			message = fmt.Sprintf("%s (path: %s)", message, strings.Join(segments, "."))
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
 // formatJSON formats any data structure as JSON
 func formatJSON(data interface{}) string {
 	jsonBytes, err := json.MarshalIndent(data, "", "  ")
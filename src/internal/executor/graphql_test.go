@@ -0,0 +1,101 @@
+package executor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// TestGraphQL_Payload tests that the operation is sent as the standard JSON body
+func TestGraphQL_Payload(t *testing.T) {
+	var received map[string]interface{}
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"data": {"user": {"name": "Ada"}}}`))
+	}))
+	defer server.Close()
+
+	req := &types.HttpRequest{
+		Method: "POST",
+		URL:    server.URL,
+		GraphQL: &types.GraphQLRequest{
+			Query:         "query GetUser($id: ID!) { user(id: $id) { name } }",
+			Variables:     map[string]interface{}{"id": "42"},
+			OperationName: "GetUser",
+		},
+	}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if contentType != "application/json" {
+		t.Errorf("Expected application/json, got %s", contentType)
+	}
+	if received["query"] != req.GraphQL.Query || received["operationName"] != "GetUser" {
+		t.Errorf("Unexpected payload: %v", received)
+	}
+	if variables, ok := received["variables"].(map[string]interface{}); !ok || variables["id"] != "42" {
+		t.Errorf("Expected variables in payload, got %v", received["variables"])
+	}
+	if len(result.GraphQLErrors) != 0 {
+		t.Errorf("Expected no GraphQL errors, got %v", result.GraphQLErrors)
+	}
+}
+
+// TestGraphQL_BodyAsQuery tests the legacy @protocol graphql form without a GraphQL block
+func TestGraphQL_BodyAsQuery(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	req := &types.HttpRequest{Method: "POST", URL: server.URL, Protocol: "graphql", Body: "{ viewer { id } }"}
+	if _, err := Execute(req, nil, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if received["query"] != "{ viewer { id } }" {
+		t.Errorf("Expected body sent as query, got %v", received["query"])
+	}
+	if _, ok := received["variables"]; ok {
+		t.Errorf("Expected no variables key, got %v", received["variables"])
+	}
+}
+
+// TestGraphQL_Errors tests that GraphQL errors are surfaced separately from the HTTP status
+func TestGraphQL_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": null, "errors": [
+			{"message": "Not authorized", "path": ["user", 0, "email"]},
+			{"message": "Rate limited"},
+			{"code": 42}
+		]}`))
+	}))
+	defer server.Close()
+
+	req := &types.HttpRequest{Method: "POST", URL: server.URL, GraphQL: &types.GraphQLRequest{Query: "{ user { email } }"}}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Status != 200 {
+		t.Errorf("Expected HTTP 200, got %d", result.Status)
+	}
+
+	expected := []string{"Not authorized (path: user.0.email)", "Rate limited", "{\n  \"code\": 42\n}"}
+	if len(result.GraphQLErrors) != len(expected) {
+		t.Fatalf("Expected %d GraphQL errors, got %v", len(expected), result.GraphQLErrors)
+	}
+	for i, message := range expected {
+		if result.GraphQLErrors[i] != message {
+			t.Errorf("Error %d: expected %q, got %q", i, message, result.GraphQLErrors[i])
+		}
+	}
+}
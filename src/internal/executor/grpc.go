@@ -0,0 +1,359 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// gRPC URL schemes
+const (
+	grpcScheme  = "grpc://"  // Plaintext (HTTP/2 without TLS)
+	grpcsScheme = "grpcs://" // TLS
+)
+
+// GRPCTarget is a parsed gRPC request URL (grpc://host:port/package.Service/Method)
+type GRPCTarget struct {
+	Address   string // host:port
+	Service   string // Fully-qualified service name (package.Service)
+	Method    string // Method name
+	Plaintext bool   // grpc:// (no TLS)
+}
+
+// FullMethod returns the method path sent on the wire (/package.Service/Method)
+func (t *GRPCTarget) FullMethod() string {
+	return "/" + t.Service + "/" + t.Method
+}
+
+// ParseGRPCURL splits a grpc:// or grpcs:// URL into target address, service and method
+// The port defaults to 80 (grpc://) or 443 (grpcs://) when omitted
+func ParseGRPCURL(rawURL string) (*GRPCTarget, error) {
+	target := &GRPCTarget{}
+	lower := strings.ToLower(rawURL)
+
+	var rest string
+	switch {
+	case strings.HasPrefix(lower, grpcScheme):
+		target.Plaintext = true
+		rest = rawURL[len(grpcScheme):]
+	case strings.HasPrefix(lower, grpcsScheme):
+		rest = rawURL[len(grpcsScheme):]
+	default:
+		return nil, fmt.Errorf("gRPC URL must start with grpc:// or grpcs://: %s", rawURL)
+	}
+
+	host, path, _ := strings.Cut(rest, "/")
+	service, method, ok := strings.Cut(path, "/")
+	if host == "" || !ok || service == "" || method == "" || strings.Contains(method, "/") {
+		return nil, fmt.Errorf("invalid gRPC URL %q (expected grpc://host:port/package.Service/Method)", rawURL)
+	}
+
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		port := "443"
+		if target.Plaintext {
+			port = "80"
+		}
+		host = net.JoinHostPort(host, port)
+	}
+
+	target.Address = host
+	target.Service = service
+	target.Method = method
+	return target, nil
+}
+
+// executeGRPC performs a unary gRPC call, resolving the method through server reflection
+// The JSON body is marshalled into the request message and headers are sent as metadata
+func executeGRPC(ctx context.Context, req *types.HttpRequest, tlsConfig *types.TLSConfig, startTime time.Time, timeout int) (*types.RequestResult, error) {
+	target, err := ParseGRPCURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := insecure.NewCredentials()
+	if !target.Plaintext {
+		tlsCfg := &tls.Config{}
+		if tlsConfig != nil {
+			if tlsCfg, err = buildWebSocketTLSConfig(tlsConfig); err != nil {
+				return nil, fmt.Errorf("TLS configuration error: %w", err)
+			}
+		}
+		creds = credentials.NewTLS(tlsCfg)
+	}
+
+	conn, err := grpc.NewClient(target.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure gRPC client: %w", err)
+	}
+	defer conn.Close()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancel()
+	}
+	ctx = metadata.NewOutgoingContext(ctx, grpcMetadata(req.Headers))
+
+	files, err := fetchServiceDescriptors(ctx, conn, target.Service)
+	if err != nil {
+		return grpcErrorResult(err, startTime), nil
+	}
+
+	method, err := findGRPCMethod(files, target)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := dynamicpb.NewTypes(files)
+	input := dynamicpb.NewMessage(method.Input())
+	if body := strings.TrimSpace(req.Body); body != "" {
+		if err := (protojson.UnmarshalOptions{Resolver: resolver}).Unmarshal([]byte(body), input); err != nil {
+			return nil, fmt.Errorf("failed to convert JSON body to %s: %w", method.Input().FullName(), err)
+		}
+	}
+
+	requestSize := 0
+	if encoded, err := proto.Marshal(input); err == nil {
+		requestSize = len(encoded)
+	}
+
+	output := dynamicpb.NewMessage(method.Output())
+	var header, trailer metadata.MD
+	callErr := conn.Invoke(ctx, target.FullMethod(), input, output, grpc.Header(&header), grpc.Trailer(&trailer))
+	if callErr != nil {
+		result := grpcErrorResult(callErr, startTime)
+		result.Headers = grpcResponseHeaders(header, trailer)
+		result.RequestSize = requestSize
+		return result, nil
+	}
+
+	compact, err := (protojson.MarshalOptions{Resolver: resolver}).Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s response to JSON: %w", method.Output().FullName(), err)
+	}
+
+	// protojson output whitespace is unstable by design: re-indent for consistent display
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact, "", "  "); err != nil {
+		indented.Reset()
+		indented.Write(compact)
+	}
+	bodyBytes := indented.Bytes()
+
+	return &types.RequestResult{
+		Status:       http.StatusOK,
+		StatusText:   grpcStatusText(codes.OK),
+		Protocol:     "gRPC",
+		Headers:      grpcResponseHeaders(header, trailer),
+		Body:         string(bodyBytes),
+		Duration:     time.Since(startTime).Milliseconds(),
+		RequestSize:  requestSize,
+		ResponseSize: len(bodyBytes),
+		Timestamp:    startTime.Format(time.RFC3339),
+	}, nil
+}
+
+// fetchServiceDescriptors loads the file descriptors defining a service through server reflection
+// Dependencies missing from the reflection response are requested by file name, or taken from
+// the descriptors linked into this binary (well-known types)
+func fetchServiceDescriptors(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	protos := make(map[string]*descriptorpb.FileDescriptorProto)
+	order := []string{}
+
+	request := func(reflReq *reflectionpb.ServerReflectionRequest) error {
+		if err := stream.Send(reflReq); err != nil {
+			return err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return status.Error(codes.Code(errResp.GetErrorCode()), errResp.GetErrorMessage())
+		}
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, fd); err != nil {
+				return fmt.Errorf("failed to decode reflection descriptor: %w", err)
+			}
+			if _, exists := protos[fd.GetName()]; !exists {
+				order = append(order, fd.GetName())
+			}
+			protos[fd.GetName()] = fd
+		}
+		return nil
+	}
+
+	if err := request(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}); err != nil {
+		return nil, err
+	}
+
+	for {
+		missing := ""
+		for _, name := range order {
+			for _, dep := range protos[name].GetDependency() {
+				if _, ok := protos[dep]; !ok {
+					missing = dep
+					break
+				}
+			}
+			if missing != "" {
+				break
+			}
+		}
+		if missing == "" {
+			break
+		}
+
+		if linked, err := protoregistry.GlobalFiles.FindFileByPath(missing); err == nil {
+			protos[missing] = protodesc.ToFileDescriptorProto(linked)
+			order = append(order, missing)
+			continue
+		}
+		if err := request(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: missing},
+		}); err != nil {
+			return nil, err
+		}
+		if _, ok := protos[missing]; !ok {
+			return nil, fmt.Errorf("server reflection did not return %s", missing)
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, name := range order {
+		set.File = append(set.File, protos[name])
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build descriptors for %s: %w", service, err)
+	}
+	return files, nil
+}
+
+// findGRPCMethod looks up the method descriptor and rejects streaming methods
+func findGRPCMethod(files *protoregistry.Files, target *GRPCTarget) (protoreflect.MethodDescriptor, error) {
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(target.Service))
+	if err != nil {
+		return nil, fmt.Errorf("service %s not found: %w", target.Service, err)
+	}
+	service, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", target.Service)
+	}
+
+	method := service.Methods().ByName(protoreflect.Name(target.Method))
+	if method == nil {
+		return nil, fmt.Errorf("method %s not found in service %s", target.Method, target.Service)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("streaming gRPC methods are not supported yet, only unary calls: %s", target.FullMethod())
+	}
+	return method, nil
+}
+
+// grpcMetadata converts request headers to outgoing metadata (keys are lowercased)
+func grpcMetadata(headers map[string]string) metadata.MD {
+	md := metadata.MD{}
+	for key, value := range headers {
+		md.Append(strings.ToLower(key), value)
+	}
+	return md
+}
+
+// grpcResponseHeaders merges response header and trailer metadata into a headers map
+func grpcResponseHeaders(header, trailer metadata.MD) map[string]string {
+	headers := make(map[string]string)
+	for _, md := range []metadata.MD{header, trailer} {
+		keys := make([]string, 0, len(md))
+		for key := range md {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			headers[key] = strings.Join(md[key], ", ")
+		}
+	}
+	return headers
+}
+
+// grpcErrorResult builds a result for a failed gRPC call, mapping the status code to HTTP
+func grpcErrorResult(err error, startTime time.Time) *types.RequestResult {
+	st := status.Convert(err)
+	return &types.RequestResult{
+		Status:     httpStatusFromGRPCCode(st.Code()),
+		StatusText: grpcStatusText(st.Code()),
+		Protocol:   "gRPC",
+		Headers:    make(map[string]string),
+		Error:      fmt.Sprintf("gRPC %s: %s", st.Code(), st.Message()),
+		Duration:   time.Since(startTime).Milliseconds(),
+		Timestamp:  startTime.Format(time.RFC3339),
+	}
+}
+
+// grpcStatusText formats a gRPC status code for display (e.g. "NotFound (gRPC 5)")
+func grpcStatusText(code codes.Code) string {
+	return fmt.Sprintf("%s (gRPC %d)", code, uint32(code))
+}
+
+// httpStatusFromGRPCCode maps gRPC status codes to the equivalent HTTP status
+// so status colors, expectations and retries behave like HTTP requests
+func httpStatusFromGRPCCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
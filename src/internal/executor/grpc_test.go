@@ -0,0 +1,154 @@
+package executor
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+)
+
+// newGRPCServer starts a plaintext gRPC server exposing the health service with reflection
+// Incoming "x-echo" metadata is returned as response header metadata
+func newGRPCServer(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	echo := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-echo")) > 0 {
+			grpc.SetHeader(ctx, metadata.Pairs("x-echo", md.Get("x-echo")[0]))
+		}
+		return handler(ctx, req)
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(echo))
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("restcli.Test", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestParseGRPCURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		address   string
+		method    string
+		plaintext bool
+		wantErr   bool
+	}{
+		{"grpc://localhost:50051/helloworld.Greeter/SayHello", "localhost:50051", "/helloworld.Greeter/SayHello", true, false},
+		{"grpcs://api.example.com/pkg.v1.Service/Get", "api.example.com:443", "/pkg.v1.Service/Get", false, false},
+		{"grpc://localhost/pkg.Service/Get", "localhost:80", "/pkg.Service/Get", true, false},
+		{"http://localhost:50051/pkg.Service/Get", "", "", false, true},
+		{"grpc://localhost:50051/pkg.Service", "", "", false, true},
+		{"grpc://localhost:50051/pkg.Service/Get/Extra", "", "", false, true},
+	}
+
+	for _, tt := range tests {
+		target, err := ParseGRPCURL(tt.url)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseGRPCURL(%q) expected error", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseGRPCURL(%q) failed: %v", tt.url, err)
+			continue
+		}
+		if target.Address != tt.address || target.FullMethod() != tt.method || target.Plaintext != tt.plaintext {
+			t.Errorf("ParseGRPCURL(%q) = %+v", tt.url, target)
+		}
+	}
+}
+
+func TestExecuteGRPC_UnaryCall(t *testing.T) {
+	addr := newGRPCServer(t)
+	req := &types.HttpRequest{
+		Method:  "GRPC",
+		URL:     "grpc://" + addr + "/grpc.health.v1.Health/Check",
+		Headers: map[string]string{"X-Echo": "hello"},
+		Body:    `{"service": "restcli.Test"}`,
+	}
+
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Error != "" {
+		t.Fatalf("Unexpected error: %s", result.Error)
+	}
+	if result.Status != 200 || result.Protocol != "gRPC" {
+		t.Errorf("Expected 200 gRPC result, got %d %s", result.Status, result.Protocol)
+	}
+	if !strings.Contains(result.Body, `"status": "SERVING"`) {
+		t.Errorf("Expected SERVING status in body, got %s", result.Body)
+	}
+	if result.Headers["x-echo"] != "hello" {
+		t.Errorf("Expected metadata to round-trip, got headers %v", result.Headers)
+	}
+}
+
+func TestExecuteGRPC_StatusError(t *testing.T) {
+	addr := newGRPCServer(t)
+	req := &types.HttpRequest{
+		Method: "GRPC",
+		URL:    "grpc://" + addr + "/grpc.health.v1.Health/Check",
+		Body:   `{"service": "unknown"}`,
+	}
+
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.Status != 404 || !strings.Contains(result.Error, "NotFound") {
+		t.Errorf("Expected NotFound mapped to 404, got %d %q", result.Status, result.Error)
+	}
+}
+
+func TestExecuteGRPC_StreamingMethodRejected(t *testing.T) {
+	addr := newGRPCServer(t)
+	req := &types.HttpRequest{Method: "GRPC", URL: "grpc://" + addr + "/grpc.health.v1.Health/Watch"}
+
+	_, err := Execute(req, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "not supported yet") {
+		t.Errorf("Expected streaming not supported error, got %v", err)
+	}
+}
+
+func TestExecuteGRPC_UnknownMethod(t *testing.T) {
+	addr := newGRPCServer(t)
+	req := &types.HttpRequest{Method: "GRPC", URL: "grpc://" + addr + "/grpc.health.v1.Health/Missing"}
+
+	_, err := Execute(req, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "method Missing not found") {
+		t.Errorf("Expected method not found error, got %v", err)
+	}
+}
+
+func TestExecuteGRPC_InvalidJSONBody(t *testing.T) {
+	addr := newGRPCServer(t)
+	req := &types.HttpRequest{
+		Method: "GRPC",
+		URL:    "grpc://" + addr + "/grpc.health.v1.Health/Check",
+		Body:   `{"unknownField": 1}`,
+	}
+
+	_, err := Execute(req, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "grpc.health.v1.HealthCheckRequest") {
+		t.Errorf("Expected JSON conversion error, got %v", err)
+	}
+}
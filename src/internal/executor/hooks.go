@@ -0,0 +1,118 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// checkHooksAllowed refuses to run hooks unless the profile opted in
+func checkHooksAllowed(profile *types.Profile) error {
+	if profile == nil || !profile.AllowHooks {
+		return fmt.Errorf("request declares @before/@after hooks but the profile does not allow them (set allowHooks)")
+	}
+	return nil
+}
+
+// hookTimeout returns how long a single hook may run
+func hookTimeout(profile *types.Profile) time.Duration {
+	if profile == nil {
+		return 30 * time.Second
+	}
+	return time.Duration(profile.GetHookTimeout()) * time.Second
+}
+
+// RunBeforeHooks runs the @before hooks of a request in order
+// Returns the captured variables, for the request to be resolved with. Hooks only run when the
+// profile sets AllowHooks; a hook that fails or times out stops the request.
+func RunBeforeHooks(ctx context.Context, req *types.HttpRequest, profile *types.Profile) (map[string]string, error) {
+	if len(req.BeforeHooks) == 0 {
+		return nil, nil
+	}
+	if err := checkHooksAllowed(profile); err != nil {
+		return nil, err
+	}
+
+	vars := make(map[string]string)
+	for _, hook := range req.BeforeHooks {
+		output, err := runHook(ctx, "@before", hook.Command, nil, nil, hookTimeout(profile))
+		if err != nil {
+			return nil, err
+		}
+		if hook.Variable != "" {
+			vars[hook.Variable] = strings.TrimSpace(output)
+		}
+	}
+	return vars, nil
+}
+
+// RunAfterHooks runs the @after hooks of a request in order, each with the response body on stdin
+// A downloaded body is read back from its file. The status code is in $RESTCLI_STATUS.
+func RunAfterHooks(ctx context.Context, req *types.HttpRequest, profile *types.Profile, result *types.RequestResult) error {
+	if len(req.AfterHooks) == 0 {
+		return nil
+	}
+	if err := checkHooksAllowed(profile); err != nil {
+		return err
+	}
+
+	env := []string{"RESTCLI_STATUS=" + strconv.Itoa(result.Status)}
+	for _, command := range req.AfterHooks {
+		var stdin io.Reader = strings.NewReader(result.Body)
+		if result.DownloadPath != "" {
+			file, err := os.Open(result.DownloadPath)
+			if err != nil {
+				return fmt.Errorf("@after hook %q: %w", command, err)
+			}
+			defer file.Close()
+			stdin = file
+		}
+		if _, err := runHook(ctx, "@after", command, stdin, env, hookTimeout(profile)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runHook runs a command with sh -c and returns its stdout
+// A non-zero exit status is an error carrying the command's stderr.
+func runHook(ctx context.Context, kind, command string, stdin io.Reader, env []string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = stdin
+	cmd.Env = append(os.Environ(), env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Children of sh keep the pipes open after a timeout kills it, stop waiting for them
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("%s hook %q timed out after %s", kind, command, timeout)
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			err = fmt.Errorf("exited with status %d", exitErr.ExitCode())
+		} else {
+			err = fmt.Errorf("failed: %w", err)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s hook %q %v: %s", kind, command, err, message)
+		}
+		return "", fmt.Errorf("%s hook %q %v", kind, command, err)
+	}
+	return stdout.String(), nil
+}
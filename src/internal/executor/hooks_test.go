@@ -0,0 +1,99 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// TestRunBeforeHooks_CapturesVariables tests that hook stdout is captured into the named variable
+func TestRunBeforeHooks_CapturesVariables(t *testing.T) {
+	profile := &types.Profile{AllowHooks: true}
+	req := &types.HttpRequest{BeforeHooks: []types.RequestHook{
+		{Command: "echo ignored"},
+		{Command: "printf ' abc123 \\n'", Variable: "token"},
+	}}
+
+	vars, err := RunBeforeHooks(context.Background(), req, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(vars) != 1 || vars["token"] != "abc123" {
+		t.Errorf("Expected token=abc123 only, got %v", vars)
+	}
+}
+
+// TestRunHooks_RequiresOptIn tests that hooks never run without allowHooks
+func TestRunHooks_RequiresOptIn(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	req := &types.HttpRequest{
+		BeforeHooks: []types.RequestHook{{Command: "touch " + marker}},
+		AfterHooks:  []string{"touch " + marker},
+	}
+
+	for _, profile := range []*types.Profile{nil, {}} {
+		if _, err := RunBeforeHooks(context.Background(), req, profile); err == nil || !strings.Contains(err.Error(), "allowHooks") {
+			t.Errorf("Expected an allowHooks error, got: %v", err)
+		}
+		if err := RunAfterHooks(context.Background(), req, profile, &types.RequestResult{}); err == nil {
+			t.Error("Expected an error for the after hook")
+		}
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Expected no hook to run")
+	}
+
+	// Requests without hooks need no opt-in
+	if _, err := RunBeforeHooks(context.Background(), &types.HttpRequest{}, nil); err != nil {
+		t.Errorf("Expected no error without hooks, got: %v", err)
+	}
+}
+
+// TestRunAfterHooks_PipesResponse tests that after hooks get the body on stdin and the status in the environment
+func TestRunAfterHooks_PipesResponse(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.txt")
+	profile := &types.Profile{AllowHooks: true}
+	req := &types.HttpRequest{AfterHooks: []string{`{ echo "$RESTCLI_STATUS"; cat; } > ` + out}}
+
+	err := RunAfterHooks(context.Background(), req, profile, &types.RequestResult{Status: 201, Body: `{"id":1}`})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	data, _ := os.ReadFile(out)
+	if string(data) != "201\n{\"id\":1}" {
+		t.Errorf("Expected the status and body, got %q", data)
+	}
+
+	// A downloaded body is read back from its file
+	downloaded := filepath.Join(t.TempDir(), "body.bin")
+	os.WriteFile(downloaded, []byte("from file"), 0644)
+	req.AfterHooks = []string{"cat > " + out}
+	if err := RunAfterHooks(context.Background(), req, profile, &types.RequestResult{DownloadPath: downloaded}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if data, _ := os.ReadFile(out); string(data) != "from file" {
+		t.Errorf("Expected the downloaded body, got %q", data)
+	}
+}
+
+// TestRunHook_Failures tests that non-zero exits and timeouts are reported as errors
+func TestRunHook_Failures(t *testing.T) {
+	timeout := 1
+	profile := &types.Profile{AllowHooks: true, HookTimeout: &timeout}
+
+	req := &types.HttpRequest{BeforeHooks: []types.RequestHook{{Command: "echo denied >&2; exit 3"}}}
+	_, err := RunBeforeHooks(context.Background(), req, profile)
+	if err == nil || !strings.Contains(err.Error(), "exited with status 3") || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Expected the exit status and stderr, got: %v", err)
+	}
+
+	req = &types.HttpRequest{AfterHooks: []string{"sleep 5"}}
+	err = RunAfterHooks(context.Background(), req, profile, &types.RequestResult{})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected a timeout, got: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package executor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// ToHTTPie renders a resolved request as an equivalent HTTPie command line
+// Multipart bodies become --multipart fields, other bodies are sent with --raw
+// Host mappings, the HTTP version and Unix sockets have no HTTPie equivalent and are left out
+func ToHTTPie(req *types.HttpRequest, tlsConfig *types.TLSConfig) string {
+	method, body, headers := exportedRequest(req)
+
+	args := []string{"http"}
+
+	if req.ProxyURL != "" && !strings.EqualFold(req.ProxyURL, ProxyDirect) {
+		args = append(args, shellQuote("--proxy=http:"+req.ProxyURL), shellQuote("--proxy=https:"+req.ProxyURL))
+	}
+
+	if tlsConfig != nil {
+		switch {
+		case tlsConfig.InsecureSkipVerify:
+			args = append(args, "--verify=no")
+		case tlsConfig.CAFile != "":
+			args = append(args, shellQuote("--verify="+tlsConfig.CAFile))
+		}
+		if tlsConfig.CertFile != "" {
+			args = append(args, shellQuote("--cert="+tlsConfig.CertFile))
+		}
+		if tlsConfig.KeyFile != "" {
+			args = append(args, shellQuote("--cert-key="+tlsConfig.KeyFile))
+		}
+	}
+
+	formFields, isMultipart := multipartFields(headers, body)
+	switch {
+	case isMultipart:
+		// HTTPie generates its own boundary
+		deleteHeader(headers, "Content-Type")
+		args = append(args, "--multipart")
+	case body != "":
+		args = append(args, "--raw", shellQuote(body))
+	}
+
+	args = append(args, method, shellQuote(req.URL))
+
+	// Sort headers for a stable output
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if headers[k] == "" {
+			// "Name:" would unset the header, "Name;" sends it empty
+			args = append(args, shellQuote(k+";"))
+			continue
+		}
+		args = append(args, shellQuote(k+":"+headers[k]))
+	}
+
+	// curl's name=@file becomes HTTPie's name@file
+	for _, field := range formFields {
+		value := field.Value
+		if name, file, ok := strings.Cut(value, "=@"); ok && field.File {
+			value = name + "@" + file
+		}
+		args = append(args, shellQuote(value))
+	}
+
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,77 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestToHTTPie(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *types.HttpRequest
+		tls      *types.TLSConfig
+		expected string
+	}{
+		{
+			name:     "simple GET",
+			req:      &types.HttpRequest{Method: "GET", URL: "https://api.example.com/users"},
+			expected: "http GET https://api.example.com/users",
+		},
+		{
+			name: "headers are sorted and quoted",
+			req: &types.HttpRequest{
+				Method:  "DELETE",
+				URL:     "https://api.example.com/users/1?force=true",
+				Headers: map[string]string{"X-Trace": "a b", "Authorization": "Bearer abc", "X-Empty": ""},
+			},
+			expected: "http DELETE 'https://api.example.com/users/1?force=true' 'Authorization:Bearer abc' 'X-Empty;' 'X-Trace:a b'",
+		},
+		{
+			name:     "body uses --raw",
+			req:      &types.HttpRequest{Method: "POST", URL: "https://api.example.com/notes", Body: `{"text":"it's"}`},
+			expected: `http --raw '{"text":"it'\''s"}' POST https://api.example.com/notes`,
+		},
+		{
+			name:     "TLS and proxy options",
+			req:      &types.HttpRequest{Method: "GET", URL: "https://localhost:8443/", ProxyURL: "http://proxy:3128"},
+			tls:      &types.TLSConfig{CAFile: "ca.pem", CertFile: "client.pem", KeyFile: "client.key"},
+			expected: "http --proxy=http:http://proxy:3128 --proxy=https:http://proxy:3128 --verify=ca.pem --cert=client.pem --cert-key=client.key GET https://localhost:8443/",
+		},
+		{
+			name:     "insecure TLS",
+			req:      &types.HttpRequest{Method: "GET", URL: "https://localhost:8443/"},
+			tls:      &types.TLSConfig{InsecureSkipVerify: true, CAFile: "ca.pem"},
+			expected: "http --verify=no GET https://localhost:8443/",
+		},
+		{
+			name: "multipart body becomes fields",
+			req: &types.HttpRequest{
+				Method:  "POST",
+				URL:     "https://api.example.com/upload",
+				Headers: map[string]string{"Content-Type": "multipart/form-data; boundary=XYZ"},
+				Body: "--XYZ\nContent-Disposition: form-data; name=\"title\"\n\nmy file\n" +
+					"--XYZ\nContent-Disposition: form-data; name=\"file\"; filename=\"report.pdf\"\nContent-Type: application/pdf\n\n...\n--XYZ--\n",
+			},
+			expected: "http --multipart POST https://api.example.com/upload 'title=my file' 'file@report.pdf;type=application/pdf'",
+		},
+		{
+			name: "GraphQL is sent as a JSON POST",
+			req: &types.HttpRequest{
+				Protocol: "graphql",
+				Method:   "GET",
+				URL:      "https://api.example.com/graphql",
+				Body:     "{ me { id } }",
+			},
+			expected: `http --raw '{"query":"{ me { id } }"}' POST https://api.example.com/graphql Content-Type:application/json`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTTPie(tt.req, tt.tls); got != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
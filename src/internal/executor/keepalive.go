@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// ErrKeepaliveTimeout reports a WebSocket peer that stopped answering pings
+var ErrKeepaliveTimeout = errors.New("keepalive timeout")
+
+// pingWriteWait bounds how long writing a ping may block
+const pingWriteWait = 5 * time.Second
+
+// WebSocketKeepalive configures the pings of an interactive WebSocket connection
+type WebSocketKeepalive struct {
+	PingInterval time.Duration // 0 disables the pings
+	PongTimeout  time.Duration // A ping without a pong for this long drops the connection (0 = PingInterval)
+}
+
+// KeepaliveFor returns the keepalive settings of a .ws request, falling back to the profile
+func KeepaliveFor(req *types.WebSocketRequest, profile *types.Profile) WebSocketKeepalive {
+	var interval, timeout int
+	if profile != nil && profile.PingIntervalSec != nil {
+		interval = *profile.PingIntervalSec
+	}
+	if profile != nil && profile.PongTimeoutSec != nil {
+		timeout = *profile.PongTimeoutSec
+	}
+	if req.PingIntervalSec > 0 {
+		interval = req.PingIntervalSec
+	}
+	if req.PongTimeoutSec > 0 {
+		timeout = req.PongTimeoutSec
+	}
+	return WebSocketKeepalive{
+		PingInterval: time.Duration(interval) * time.Second,
+		PongTimeout:  time.Duration(timeout) * time.Second,
+	}
+}
+
+// startKeepalive pings conn until ctx is cancelled, reporting a failed ping or a missing pong on the returned channel
+// The returned stop function cancels the pings and waits for the goroutine to exit. Nothing is started when
+// the keepalive is disabled.
+func startKeepalive(ctx context.Context, conn *websocket.Conn, keepalive WebSocketKeepalive) (<-chan error, func()) {
+	failed := make(chan error, 1)
+	if keepalive.PingInterval <= 0 {
+		return failed, func() {}
+	}
+	timeout := keepalive.PongTimeout
+	if timeout <= 0 {
+		timeout = keepalive.PingInterval
+	}
+
+	// The pong handler runs on the read goroutine, it must not block
+	pongs := make(chan struct{}, 1)
+	conn.SetPongHandler(func(string) error {
+		select {
+		case pongs <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(keepalive.PingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			// Drop a pong that arrived unasked, it must not answer this ping
+			select {
+			case <-pongs:
+			default:
+			}
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(pingWriteWait)); err != nil {
+				failed <- fmt.Errorf("%w: ping failed: %v", ErrKeepaliveTimeout, err)
+				return
+			}
+
+			timer := time.NewTimer(timeout)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-pongs:
+				timer.Stop()
+			case <-timer.C:
+				failed <- fmt.Errorf("%w: no pong within %s", ErrKeepaliveTimeout, timeout)
+				return
+			}
+		}
+	}()
+
+	return failed, func() {
+		cancel()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,129 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestKeepaliveFor(t *testing.T) {
+	interval, timeout := 30, 10
+	profile := &types.Profile{PingIntervalSec: &interval, PongTimeoutSec: &timeout}
+
+	got := KeepaliveFor(&types.WebSocketRequest{}, profile)
+	if got.PingInterval != 30*time.Second || got.PongTimeout != 10*time.Second {
+		t.Errorf("Expected the profile settings, got %+v", got)
+	}
+
+	got = KeepaliveFor(&types.WebSocketRequest{PingIntervalSec: 5}, profile)
+	if got.PingInterval != 5*time.Second || got.PongTimeout != 10*time.Second {
+		t.Errorf("Expected the .ws interval over the profile, got %+v", got)
+	}
+
+	if got := KeepaliveFor(&types.WebSocketRequest{}, nil); got.PingInterval != 0 {
+		t.Errorf("Expected no pings by default, got %+v", got)
+	}
+}
+
+func TestExecuteWebSocketInteractive_PongTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// Never read, so pings are never answered
+		<-release
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var messages []string
+	callback := func(msg *types.ReceivedMessage, done bool) {
+		if msg != nil {
+			mu.Lock()
+			messages = append(messages, msg.Content)
+			mu.Unlock()
+		}
+	}
+
+	keepalive := WebSocketKeepalive{PingInterval: 20 * time.Millisecond, PongTimeout: 50 * time.Millisecond}
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- ExecuteWebSocketInteractive(context.Background(), wsURL, nil, nil, nil, keepalive, nil, nil, make(chan string), callback)
+	}()
+
+	select {
+	case err := <-errChan:
+		if !errors.Is(err, ErrKeepaliveTimeout) {
+			t.Fatalf("Expected a keepalive timeout, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the missing pong to end the session")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if last := messages[len(messages)-1]; !strings.HasPrefix(last, "Connection lost: keepalive timeout") {
+		t.Errorf("Expected the lost connection to be reported, got %q", last)
+	}
+}
+
+func TestExecuteWebSocketInteractive_PongsKeepConnection(t *testing.T) {
+	var pings atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(data string) error {
+			pings.Add(1)
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	keepalive := WebSocketKeepalive{PingInterval: 20 * time.Millisecond, PongTimeout: 200 * time.Millisecond}
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- ExecuteWebSocketInteractive(ctx, wsURL, nil, nil, nil, keepalive, nil, nil, make(chan string), nil)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+
+	// Cancelling returns only once the ping goroutine has stopped
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatalf("Expected a clean close, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the session to end on cancellation")
+	}
+	if pings.Load() < 3 {
+		t.Errorf("Expected periodic pings, got %d", pings.Load())
+	}
+}
@@ -0,0 +1,158 @@
+package executor
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/proxy"
+)
+
+// ProxyDirect as a proxy URL connects directly, ignoring the profile and environment proxies
+const ProxyDirect = "direct"
+
+// Where the effective outbound proxy comes from
+const (
+	ProxySourceRequest     = "request"
+	ProxySourceProfile     = "profile"
+	ProxySourceEnvironment = "environment"
+)
+
+// OutboundProxy is the proxy requests are sent through
+type OutboundProxy struct {
+	URL     string // http, https, socks5 or socks5h URL (empty = environment proxy, or direct for the zero value)
+	NoProxy string // Comma-separated hosts, domains and CIDRs reached directly
+	Source  string // ProxySourceRequest, ProxySourceProfile or ProxySourceEnvironment
+}
+
+// ResolveOutboundProxy returns the effective proxy: request-level overrides profile-level,
+// and without either the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment applies
+func ResolveOutboundProxy(req *types.HttpRequest, profile *types.Profile) OutboundProxy {
+	if req != nil && req.ProxyURL != "" {
+		return OutboundProxy{URL: req.ProxyURL, NoProxy: req.NoProxy, Source: ProxySourceRequest}
+	}
+	if profile != nil && profile.ProxyURL != "" {
+		noProxy := profile.NoProxy
+		if req != nil && req.NoProxy != "" {
+			noProxy = req.NoProxy
+		}
+		return OutboundProxy{URL: profile.ProxyURL, NoProxy: noProxy, Source: ProxySourceProfile}
+	}
+	return OutboundProxy{Source: ProxySourceEnvironment}
+}
+
+// Describe formats the proxy for display, e.g. "socks5://10.0.0.1:1080 (profile, except .internal)"
+// Credentials in the URL are masked.
+func (p OutboundProxy) Describe() string {
+	if p.Source == ProxySourceEnvironment {
+		env := httpproxy.FromEnvironment()
+		switch {
+		case env.HTTPSProxy != "":
+			return redactProxyURL(env.HTTPSProxy) + " (HTTPS_PROXY)"
+		case env.HTTPProxy != "":
+			return redactProxyURL(env.HTTPProxy) + " (HTTP_PROXY)"
+		}
+		return "none"
+	}
+	if strings.EqualFold(p.URL, ProxyDirect) {
+		return "none (direct, " + p.Source + ")"
+	}
+	desc := redactProxyURL(p.URL) + " (" + p.Source
+	if p.NoProxy != "" {
+		desc += ", except " + p.NoProxy
+	}
+	return desc + ")"
+}
+
+// redactProxyURL hides the password of a proxy URL
+func redactProxyURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	return u.Redacted()
+}
+
+// parseProxyURL validates a configured proxy URL
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q (expected e.g. http://proxy:3128 or socks5://proxy:1080)", raw)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	}
+	return nil, fmt.Errorf("unsupported proxy scheme %q (expected http, https, socks5 or socks5h)", u.Scheme)
+}
+
+// useOutboundProxy routes the transport through the proxy
+// HTTP proxies use Transport.Proxy (CONNECT for https URLs); SOCKS proxies replace the dialer,
+// which also works for the HTTP/2-only transports.
+func useOutboundProxy(rt http.RoundTripper, p OutboundProxy) error {
+	if p.Source == ProxySourceEnvironment {
+		if t, ok := rt.(*http.Transport); ok {
+			t.Proxy = http.ProxyFromEnvironment
+		}
+		return nil
+	}
+	if p.URL == "" || strings.EqualFold(p.URL, ProxyDirect) {
+		return nil
+	}
+
+	u, err := parseProxyURL(p.URL)
+	if err != nil {
+		return err
+	}
+
+	if scheme := strings.ToLower(u.Scheme); scheme == "http" || scheme == "https" {
+		t, ok := rt.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("http proxies need httpVersion auto or http1, use a socks5 proxy with http2 or h2c")
+		}
+		proxyFunc := (&httpproxy.Config{HTTPProxy: u.String(), HTTPSProxy: u.String(), NoProxy: p.NoProxy}).ProxyFunc()
+		t.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+		return nil
+	}
+
+	socks, err := proxy.FromURL(u, proxy.Direct)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL %q: %w", p.URL, err)
+	}
+	dialer := proxy.NewPerHost(socks, proxy.Direct)
+	dialer.AddFromString(p.NoProxy)
+	setDialer(rt, dialer.DialContext)
+	return nil
+}
+
+// setDialer makes the transport open its connections with dial
+// TLS still runs on top of the returned connection, except for h2c.
+func setDialer(rt http.RoundTripper, dial func(ctx context.Context, network, addr string) (net.Conn, error)) {
+	switch t := rt.(type) {
+	case *http.Transport:
+		t.DialContext = dial
+	case *http2.Transport:
+		t.DialTLSContext = func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+			conn, err := dial(ctx, network, addr)
+			if err != nil || t.AllowHTTP {
+				// h2c: plain connection
+				return conn, err
+			}
+			tlsConn := tls.Client(conn, cfg)
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			return tlsConn, nil
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// PinCertificate pins the server certificate of a TLS config to SHA-256 hashes
+// A pin matches the DER of the leaf certificate or its SubjectPublicKeyInfo (SPKI), so pinning
+// the key survives a renewal that keeps it. Pins are hex (colons allowed, as printed by openssl)
+// or base64 (as in curl's sha256//...). The check runs after the usual CA verification, and
+// also when InsecureSkipVerify is set, which pins a self-signed certificate.
+// Connections are never resumed (no ClientSessionCache), so every handshake is checked.
+func PinCertificate(cfg *tls.Config, pins []string) error {
+	if len(pins) == 0 {
+		return nil
+	}
+
+	hashes := make([][]byte, 0, len(pins))
+	for _, pin := range pins {
+		hash, err := parseCertificatePin(pin)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("certificate pinning failed: the server presented no certificate")
+		}
+		leaf, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("certificate pinning failed: %w", err)
+		}
+
+		certHash := sha256.Sum256(leaf.Raw)
+		spkiHash := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+		for _, hash := range hashes {
+			if bytes.Equal(hash, certHash[:]) || bytes.Equal(hash, spkiHash[:]) {
+				return nil
+			}
+		}
+		return fmt.Errorf("certificate pin mismatch for %q: certificate sha256 %s, public key sha256//%s match none of the %d pins",
+			leaf.Subject.CommonName, hex.EncodeToString(certHash[:]), base64.StdEncoding.EncodeToString(spkiHash[:]), len(hashes))
+	}
+	return nil
+}
+
+// parseCertificatePin decodes a SHA-256 pin in hex or base64, with an optional sha256// (or sha256/) prefix
+func parseCertificatePin(pin string) ([]byte, error) {
+	value := strings.TrimSpace(pin)
+	switch lower := strings.ToLower(value); {
+	case strings.HasPrefix(lower, "sha256//"): // curl --pinnedpubkey
+		value = value[len("sha256//"):]
+	case strings.HasPrefix(lower, "sha256/"):
+		value = value[len("sha256/"):]
+	}
+
+	if hash, err := hex.DecodeString(strings.ReplaceAll(value, ":", "")); err == nil && len(hash) == sha256.Size {
+		return hash, nil
+	}
+	if hash, err := base64.StdEncoding.DecodeString(value); err == nil && len(hash) == sha256.Size {
+		return hash, nil
+	}
+	return nil, fmt.Errorf("invalid certificate pin %q: expected a SHA-256 hash in hex or base64", pin)
+}
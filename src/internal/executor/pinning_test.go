@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Hashes of testdata/pinning.crt (self-signed for localhost and 127.0.0.1)
+// openssl x509 -in pinning.crt -noout -fingerprint -sha256
+// openssl x509 -in pinning.crt -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64
+const (
+	fixtureCertPin = "9B:3F:75:F9:7B:06:5F:1F:46:AB:A4:7C:90:19:6A:B4:67:1D:81:77:57:EE:3B:55:FD:5A:DE:29:42:20:C5:64"
+	fixtureSPKIPin = "sha256///Nfe7K2MX2/0mN4F8bACbincAqyUcY3l/LXF+Bf06bc="
+	otherPin       = "sha256//AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+)
+
+// newPinnedServer serves HTTPS with the fixture certificate
+func newPinnedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	cert, err := tls.LoadX509KeyPair("testdata/pinning.crt", "testdata/pinning.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pinned"))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestPinning_Accepts tests that a matching certificate or public key pin lets the request through
+func TestPinning_Accepts(t *testing.T) {
+	server := newPinnedServer(t)
+
+	tests := []struct {
+		name string
+		tls  *types.TLSConfig
+	}{
+		{"certificate hash with the CA", &types.TLSConfig{CAFile: "testdata/pinning.crt", PinnedCertSHA256: []string{fixtureCertPin}}},
+		{"public key hash among others", &types.TLSConfig{CAFile: "testdata/pinning.crt", PinnedCertSHA256: []string{otherPin, fixtureSPKIPin}}},
+		{"self-signed without a CA", &types.TLSConfig{InsecureSkipVerify: true, PinnedCertSHA256: []string{fixtureSPKIPin}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Execute(&types.HttpRequest{Method: "GET", URL: server.URL}, tt.tls, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result.Error != "" || result.Body != "pinned" {
+				t.Errorf("Expected the pinned server to answer, got %q (%s)", result.Body, result.Error)
+			}
+		})
+	}
+}
+
+// TestPinning_Rejects tests that a mismatch fails the connection, even when the CA trusts the server
+func TestPinning_Rejects(t *testing.T) {
+	server := newPinnedServer(t)
+
+	tlsConfig := &types.TLSConfig{CAFile: "testdata/pinning.crt", PinnedCertSHA256: []string{otherPin}}
+	result, err := Execute(&types.HttpRequest{Method: "GET", URL: server.URL}, tlsConfig, nil)
+	if err != nil {
+		t.Fatalf("Expected a failed result, got error: %v", err)
+	}
+	if !strings.Contains(result.Error, "certificate pin mismatch") || !strings.Contains(result.Error, fixtureSPKIPin) {
+		t.Errorf("Expected a pin mismatch naming the presented key, got %q", result.Error)
+	}
+	if result.Body != "" {
+		t.Errorf("Expected no response body, got %q", result.Body)
+	}
+}
+
+// TestParseCertificatePin tests the accepted pin formats
+func TestParseCertificatePin(t *testing.T) {
+	for _, pin := range []string{fixtureCertPin, strings.ToLower(strings.ReplaceAll(fixtureCertPin, ":", "")), fixtureSPKIPin, "/Nfe7K2MX2/0mN4F8bACbincAqyUcY3l/LXF+Bf06bc="} {
+		if _, err := parseCertificatePin(pin); err != nil {
+			t.Errorf("%s: expected a valid pin, got %v", pin, err)
+		}
+	}
+
+	for _, pin := range []string{"", "9B:3F", "sha256//not-base64", "md5/0123456789abcdef0123456789abcdef"} {
+		if _, err := parseCertificatePin(pin); err == nil {
+			t.Errorf("%q: expected an invalid pin", pin)
+		}
+	}
+
+	_, err := Execute(&types.HttpRequest{Method: "GET", URL: "https://localhost"}, &types.TLSConfig{PinnedCertSHA256: []string{"abc"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid certificate pin") {
+		t.Errorf("Expected an invalid pin to fail the request, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package executor
+
+import (
+	"context"
+	"io"
+)
+
+type previewLimitKey struct{}
+
+// WithPreviewLimit returns a context whose requests keep at most limit bytes of the response body
+// The rest of the body is read and discarded so ResponseSize is still the full size,
+// and RequestResult.Truncated is set. Streamed responses are not affected.
+func WithPreviewLimit(ctx context.Context, limit int64) context.Context {
+	return context.WithValue(ctx, previewLimitKey{}, limit)
+}
+
+// previewLimit returns the preview limit of ctx (0 = read the full body)
+func previewLimit(ctx context.Context) int64 {
+	limit, _ := ctx.Value(previewLimitKey{}).(int64)
+	return limit
+}
+
+// readPreview keeps the first limit bytes of a body and counts the rest
+// Compressed bodies are decoded on the fly so the preview is readable; the returned
+// sizes are the full decoded size and the size on the wire (0 = not compressed).
+func readPreview(body io.Reader, contentEncoding string, autoDecompress bool, limit int64) ([]byte, int, int, error) {
+	reader, wireCounter := decodeBodyStream(body, contentEncoding, autoDecompress)
+
+	preview, err := io.ReadAll(io.LimitReader(reader, limit))
+	if err != nil {
+		return preview, len(preview), 0, err
+	}
+	rest, err := io.Copy(io.Discard, reader)
+
+	compressedSize := 0
+	if wireCounter != nil {
+		compressedSize = wireCounter.count
+	}
+	return preview, len(preview) + int(rest), compressedSize, err
+}
@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// TestPreviewLimit_KeepsFullSize tests that a preview keeps the first bytes and reports the full size
+func TestPreviewLimit_KeepsFullSize(t *testing.T) {
+	body := strings.Repeat("0123456789", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL}
+	ctx := WithPreviewLimit(context.Background(), 100)
+
+	for name, execute := range map[string]func() (*types.RequestResult, error){
+		"buffered": func() (*types.RequestResult, error) { return ExecuteWithContext(ctx, req, nil, nil, nil, nil) },
+		"streaming": func() (*types.RequestResult, error) {
+			return ExecuteWithStreaming(ctx, req, nil, nil, nil, nil, nil)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := execute()
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result.Body != body[:100] {
+				t.Errorf("Expected the first 100 bytes, got %d bytes", len(result.Body))
+			}
+			if result.ResponseSize != len(body) {
+				t.Errorf("Expected ResponseSize %d, got %d", len(body), result.ResponseSize)
+			}
+			if !result.Truncated {
+				t.Error("Expected the result to be marked as truncated")
+			}
+		})
+	}
+}
+
+// TestPreviewLimit_SmallBody tests that a body under the limit is kept whole
+func TestPreviewLimit_SmallBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	ctx := WithPreviewLimit(context.Background(), 100)
+	result, err := ExecuteWithContext(ctx, &types.HttpRequest{Method: "GET", URL: server.URL}, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Body != "ok" || result.ResponseSize != 2 || result.Truncated {
+		t.Errorf("Expected the full body, got %q (size %d, truncated %v)", result.Body, result.ResponseSize, result.Truncated)
+	}
+}
+
+// TestPreviewLimit_Compressed tests that a compressed preview is decoded and sized after decompression
+func TestPreviewLimit_Compressed(t *testing.T) {
+	body := strings.Repeat("hello ", 2000)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(body))
+	gz.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	ctx := WithPreviewLimit(context.Background(), 12)
+	result, err := ExecuteWithContext(ctx, &types.HttpRequest{Method: "GET", URL: server.URL}, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Body != "hello hello " {
+		t.Errorf("Expected a decoded preview, got %q", result.Body)
+	}
+	if result.ResponseSize != len(body) {
+		t.Errorf("Expected ResponseSize %d, got %d", len(body), result.ResponseSize)
+	}
+	if result.CompressedSize != compressed.Len() {
+		t.Errorf("Expected CompressedSize %d, got %d", compressed.Len(), result.CompressedSize)
+	}
+}
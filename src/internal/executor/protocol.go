@@ -0,0 +1,100 @@
+package executor
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"golang.org/x/net/http2"
+)
+
+// Supported HTTP protocol versions
+const (
+	HTTPVersionAuto  = "auto"  // Negotiate via ALPN (HTTP/2 when the server supports it)
+	HTTPVersionHTTP1 = "http1" // Force HTTP/1.1
+	HTTPVersionHTTP2 = "http2" // Force HTTP/2 over TLS
+	HTTPVersionH2C   = "h2c"   // HTTP/2 over cleartext (prior knowledge)
+)
+
+// resolveHTTPVersion returns the effective HTTP version: request-level overrides profile-level
+func resolveHTTPVersion(req *types.HttpRequest, profile *types.Profile) string {
+	if req.HTTPVersion != "" {
+		return strings.ToLower(req.HTTPVersion)
+	}
+	if profile != nil {
+		return strings.ToLower(profile.GetHTTPVersion())
+	}
+	return HTTPVersionAuto
+}
+
+// validateHTTPVersion checks the version is known and compatible with the URL scheme
+func validateHTTPVersion(version, rawURL string) error {
+	isHTTPS := strings.HasPrefix(strings.ToLower(rawURL), "https://")
+
+	switch version {
+	case HTTPVersionAuto, HTTPVersionHTTP1:
+		return nil
+	case HTTPVersionHTTP2:
+		if !isHTTPS {
+			return fmt.Errorf("http2 requires an https:// URL (use h2c for cleartext HTTP/2): %s", rawURL)
+		}
+		return nil
+	case HTTPVersionH2C:
+		if isHTTPS || !strings.HasPrefix(strings.ToLower(rawURL), "http://") {
+			return fmt.Errorf("h2c requires an http:// URL (h2c is HTTP/2 without TLS): %s", rawURL)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported HTTP version %q (expected auto, http1, http2 or h2c)", version)
+	}
+}
+
+// buildTransport creates the round tripper for the requested HTTP version
+// tlsCfg may be nil when no custom TLS configuration is set
+// Transparent gzip is disabled everywhere: decompression is handled explicitly so the
+// original Content-Encoding header and compressed size are preserved (see compression.go)
+func buildTransport(tlsCfg *tls.Config, httpVersion string) http.RoundTripper {
+	switch httpVersion {
+	case HTTPVersionH2C:
+		// Cleartext HTTP/2: dial plain TCP where the transport expects TLS
+		return &http2.Transport{
+			AllowHTTP:          true,
+			DisableCompression: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, addr)
+			},
+		}
+
+	case HTTPVersionHTTP2:
+		if tlsCfg == nil {
+			tlsCfg = &tls.Config{}
+		}
+		tlsCfg.NextProtos = []string{http2.NextProtoTLS}
+		return &http2.Transport{TLSClientConfig: tlsCfg, DisableCompression: true}
+
+	case HTTPVersionHTTP1:
+		if tlsCfg == nil {
+			tlsCfg = &tls.Config{}
+		}
+		tlsCfg.NextProtos = []string{"http/1.1"}
+		return &http.Transport{
+			TLSClientConfig:    tlsCfg,
+			DisableCompression: true,
+			// A non-nil empty map disables the built-in HTTP/2 upgrade
+			TLSNextProto: map[string]func(string, *tls.Conn) http.RoundTripper{},
+		}
+
+	default:
+		// Custom TLS configs disable HTTP/2 unless explicitly forced
+		return &http.Transport{
+			TLSClientConfig:    tlsCfg,
+			ForceAttemptHTTP2:  true,
+			DisableCompression: true,
+		}
+	}
+}
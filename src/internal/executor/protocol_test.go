@@ -0,0 +1,132 @@
+package executor
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// protoHandler echoes the protocol the server saw
+var protoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprint(w, r.Proto)
+})
+
+// newTLSServer starts an HTTPS test server with HTTP/2 enabled
+func newTLSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewUnstartedServer(protoHandler)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestHTTPVersion_ForcedVersions tests that each forced version is actually used
+func TestHTTPVersion_ForcedVersions(t *testing.T) {
+	server := newTLSServer(t)
+	tlsConfig := &types.TLSConfig{InsecureSkipVerify: true}
+
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"", "HTTP/2.0"},
+		{HTTPVersionAuto, "HTTP/2.0"},
+		{HTTPVersionHTTP1, "HTTP/1.1"},
+		{HTTPVersionHTTP2, "HTTP/2.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			req := &types.HttpRequest{Method: "GET", URL: server.URL, HTTPVersion: tt.version}
+			result, err := Execute(req, tlsConfig, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result.Error != "" {
+				t.Fatalf("Expected no error in result, got: %s", result.Error)
+			}
+			if result.Protocol != tt.expected {
+				t.Errorf("Expected protocol %s, got %s", tt.expected, result.Protocol)
+			}
+			if result.Body != tt.expected {
+				t.Errorf("Expected server to see %s, got %s", tt.expected, result.Body)
+			}
+		})
+	}
+}
+
+// TestHTTPVersion_ProfileDefault tests that the profile default applies and requests override it
+func TestHTTPVersion_ProfileDefault(t *testing.T) {
+	server := newTLSServer(t)
+	tlsConfig := &types.TLSConfig{InsecureSkipVerify: true}
+	profile := &types.Profile{Name: "test", HTTPVersion: HTTPVersionHTTP1}
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL}
+	result, err := Execute(req, tlsConfig, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Protocol != "HTTP/1.1" {
+		t.Errorf("Expected profile default HTTP/1.1, got %s", result.Protocol)
+	}
+
+	req.HTTPVersion = HTTPVersionHTTP2
+	result, err = Execute(req, tlsConfig, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Protocol != "HTTP/2.0" {
+		t.Errorf("Expected request override HTTP/2.0, got %s", result.Protocol)
+	}
+}
+
+// TestHTTPVersion_H2C tests cleartext HTTP/2 with prior knowledge
+func TestHTTPVersion_H2C(t *testing.T) {
+	server := httptest.NewServer(h2c.NewHandler(protoHandler, &http2.Server{}))
+	defer server.Close()
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL, HTTPVersion: HTTPVersionH2C}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Error != "" {
+		t.Fatalf("Expected no error in result, got: %s", result.Error)
+	}
+	if result.Protocol != "HTTP/2.0" {
+		t.Errorf("Expected HTTP/2.0, got %s", result.Protocol)
+	}
+}
+
+// TestHTTPVersion_Validation tests scheme checks and unknown versions
+func TestHTTPVersion_Validation(t *testing.T) {
+	tests := []struct {
+		version string
+		url     string
+		errPart string
+	}{
+		{HTTPVersionH2C, "https://example.com", "h2c requires an http:// URL"},
+		{HTTPVersionHTTP2, "http://example.com", "http2 requires an https:// URL"},
+		{"http3", "https://example.com", "unsupported HTTP version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			req := &types.HttpRequest{Method: "GET", URL: tt.url, HTTPVersion: tt.version}
+			_, err := Execute(req, nil, nil)
+			if err == nil {
+				t.Fatalf("Expected error for %s with %s", tt.version, tt.url)
+			}
+			if !strings.Contains(err.Error(), tt.errPart) {
+				t.Errorf("Expected error containing %q, got: %v", tt.errPart, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package executor
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// defaultMaxRedirects matches the limit of Go's default client
+const defaultMaxRedirects = 10
+
+// redirectPolicy is the effective redirect configuration for a request
+type redirectPolicy struct {
+	follow bool
+	max    int
+}
+
+// resolveRedirectPolicy merges request-level redirect settings over the profile defaults
+func resolveRedirectPolicy(req *types.HttpRequest, profile *types.Profile) redirectPolicy {
+	policy := redirectPolicy{follow: true, max: defaultMaxRedirects}
+	if profile != nil {
+		if profile.FollowRedirects != nil {
+			policy.follow = *profile.FollowRedirects
+		}
+		if profile.MaxRedirects != nil && *profile.MaxRedirects > 0 {
+			policy.max = *profile.MaxRedirects
+		}
+	}
+	if req.FollowRedirects != nil {
+		policy.follow = *req.FollowRedirects
+	}
+	if req.MaxRedirects > 0 {
+		policy.max = req.MaxRedirects
+	}
+	return policy
+}
+
+// redirectRecorder applies a redirect policy to a client and records the hops of one attempt
+type redirectRecorder struct {
+	policy redirectPolicy
+
+	mu   sync.Mutex
+	hops []types.RedirectHop
+	last time.Time // When the previous hop (or the attempt) started
+}
+
+func newRedirectRecorder(req *types.HttpRequest, profile *types.Profile) *redirectRecorder {
+	return &redirectRecorder{policy: resolveRedirectPolicy(req, profile), last: time.Now()}
+}
+
+// checkRedirect is the client's CheckRedirect: req is the next request, req.Response the 3xx that asked for it
+// When following is off the 3xx is returned as the response; past the limit the request fails.
+func (r *redirectRecorder) checkRedirect(req *http.Request, via []*http.Request) error {
+	if !r.policy.follow {
+		return http.ErrUseLastResponse
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if resp := req.Response; resp != nil {
+		now := time.Now()
+		r.hops = append(r.hops, types.RedirectHop{
+			URL:        resp.Request.URL.String(),
+			Status:     resp.StatusCode,
+			Location:   resp.Header.Get("Location"),
+			DurationMs: now.Sub(r.last).Milliseconds(),
+		})
+		r.last = now
+	}
+	if len(via) > r.policy.max {
+		return fmt.Errorf("stopped after %d redirects", r.policy.max)
+	}
+	return nil
+}
+
+// reset forgets the hops of a previous attempt
+func (r *redirectRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hops = nil
+	r.last = time.Now()
+}
+
+// result returns the recorded hops (nil when none)
+func (r *redirectRecorder) result() []types.RedirectHop {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.hops) == 0 {
+		return nil
+	}
+	return append([]types.RedirectHop(nil), r.hops...)
+}
+
+// FormatRedirectChain formats the hops and the final status, e.g. "302 (12ms) → 301 (8ms) → 200 (40ms)"
+// The final response gets whatever part of the total the hops did not use.
+func FormatRedirectChain(hops []types.RedirectHop, finalStatus int, totalMs int64) string {
+	parts := make([]string, 0, len(hops)+1)
+	remaining := totalMs
+	for _, hop := range hops {
+		parts = append(parts, fmt.Sprintf("%d (%s)", hop.Status, FormatDuration(hop.DurationMs)))
+		remaining -= hop.DurationMs
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	if finalStatus > 0 {
+		parts = append(parts, fmt.Sprintf("%d (%s)", finalStatus, FormatDuration(remaining)))
+	} else {
+		parts = append(parts, "failed")
+	}
+	return strings.Join(parts, " → ")
+}
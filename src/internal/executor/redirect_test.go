@@ -0,0 +1,173 @@
+package executor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/parser"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// newRedirectServer redirects /a -> /b (302) -> /c (301), which answers 200
+func newRedirectServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/b", http.StatusFound)
+	})
+	mux.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/c", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/c", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("done"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestRedirects_RecordsChain tests that followed redirects are recorded in order
+func TestRedirects_RecordsChain(t *testing.T) {
+	server := newRedirectServer(t)
+
+	result, err := Execute(&types.HttpRequest{Method: "GET", URL: server.URL + "/a"}, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Status != 200 || result.Body != "done" {
+		t.Fatalf("Expected the final response, got %d %q", result.Status, result.Body)
+	}
+	if len(result.Redirects) != 2 {
+		t.Fatalf("Expected 2 redirects, got %+v", result.Redirects)
+	}
+	first, second := result.Redirects[0], result.Redirects[1]
+	if first.Status != 302 || first.URL != server.URL+"/a" || first.Location != "/b" {
+		t.Errorf("Unexpected first hop: %+v", first)
+	}
+	if second.Status != 301 || second.URL != server.URL+"/b" || second.Location != "/c" {
+		t.Errorf("Unexpected second hop: %+v", second)
+	}
+	if first.DurationMs+second.DurationMs > result.Duration {
+		t.Errorf("Expected hop timings within the total %dms, got %+v", result.Duration, result.Redirects)
+	}
+}
+
+// TestRedirects_NotFollowed tests that the 3xx is returned as-is when following is off
+func TestRedirects_NotFollowed(t *testing.T) {
+	server := newRedirectServer(t)
+	follow := false
+
+	result, err := Execute(&types.HttpRequest{Method: "GET", URL: server.URL + "/a", FollowRedirects: &follow}, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Status != 302 || result.Headers["Location"] != "/b" {
+		t.Errorf("Expected the 302 with its Location, got %d %v", result.Status, result.Headers)
+	}
+	if len(result.Redirects) != 0 {
+		t.Errorf("Expected no recorded redirects, got %+v", result.Redirects)
+	}
+}
+
+// TestRedirects_MaxRedirects tests that exceeding the limit fails with the hops so far
+func TestRedirects_MaxRedirects(t *testing.T) {
+	server := newRedirectServer(t)
+
+	result, err := Execute(&types.HttpRequest{Method: "GET", URL: server.URL + "/a", MaxRedirects: 1}, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(result.Error, "stopped after 1 redirects") {
+		t.Errorf("Expected the redirect limit error, got %q", result.Error)
+	}
+	if len(result.Redirects) != 2 {
+		t.Errorf("Expected both hops up to the limit, got %+v", result.Redirects)
+	}
+}
+
+// TestResolveRedirectPolicy tests that request settings override the profile defaults
+func TestResolveRedirectPolicy(t *testing.T) {
+	if policy := resolveRedirectPolicy(&types.HttpRequest{}, nil); !policy.follow || policy.max != defaultMaxRedirects {
+		t.Errorf("Expected the defaults, got %+v", policy)
+	}
+
+	follow, max := false, 3
+	profile := &types.Profile{FollowRedirects: &follow, MaxRedirects: &max}
+	if policy := resolveRedirectPolicy(&types.HttpRequest{}, profile); policy.follow || policy.max != 3 {
+		t.Errorf("Expected the profile policy, got %+v", policy)
+	}
+
+	requestFollow := true
+	policy := resolveRedirectPolicy(&types.HttpRequest{FollowRedirects: &requestFollow, MaxRedirects: 5}, profile)
+	if !policy.follow || policy.max != 5 {
+		t.Errorf("Expected the request policy, got %+v", policy)
+	}
+}
+
+// TestRedirects_ParsedPolicy tests that the .http directives survive variable resolution
+func TestRedirects_ParsedPolicy(t *testing.T) {
+	server := newRedirectServer(t)
+
+	tests := []struct {
+		name       string
+		directives string
+		wantStatus int
+		wantError  string
+	}{
+		{"follow disabled", "# @followRedirects false\n", 302, ""},
+		{"capped", "# @maxRedirects 1\n", 0, "stopped after 1 redirects"},
+		{"default", "", 200, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "redirect.http")
+			content := "### Redirect\n" + tt.directives + "GET {{baseUrl}}/a\n"
+			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			requests, err := parser.ParseHTTPFile(filePath)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			resolver := parser.NewVariableResolver(nil, map[string]string{"baseUrl": server.URL}, nil, nil)
+			resolved, err := resolver.ResolveRequest(&requests[0])
+			if err != nil {
+				t.Fatalf("ResolveRequest failed: %v", err)
+			}
+
+			result, err := Execute(resolved, nil, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if tt.wantError != "" {
+				if !strings.Contains(result.Error, tt.wantError) {
+					t.Errorf("Expected error containing %q, got %q", tt.wantError, result.Error)
+				}
+				return
+			}
+			if result.Status != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d (%s)", tt.wantStatus, result.Status, result.Error)
+			}
+		})
+	}
+}
+
+// TestFormatRedirectChain tests the hop summary shown in the response view
+func TestFormatRedirectChain(t *testing.T) {
+	hops := []types.RedirectHop{{Status: 302, DurationMs: 12}, {Status: 301, DurationMs: 8}}
+
+	if got := FormatRedirectChain(hops, 200, 60); got != "302 (12ms) → 301 (8ms) → 200 (40ms)" {
+		t.Errorf("Unexpected chain %q", got)
+	}
+	if got := FormatRedirectChain(hops, 0, 20); got != "302 (12ms) → 301 (8ms) → failed" {
+		t.Errorf("Expected a failed final hop, got %q", got)
+	}
+	if got := FormatRedirectChain(hops, 200, 5); got != "302 (12ms) → 301 (8ms) → 200 (0ms)" {
+		t.Errorf("Expected the final time to be clamped, got %q", got)
+	}
+}
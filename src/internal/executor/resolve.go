@@ -0,0 +1,123 @@
+package executor
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"golang.org/x/net/http2"
+)
+
+// hostOverrides maps the host:port of a URL to the address dialed instead (like curl --resolve)
+// Only the TCP connection is redirected: the Host header and TLS server name keep the URL host.
+type hostOverrides map[string]string
+
+// resolveHostOverrides merges the request mappings over the profile ones and validates them
+func resolveHostOverrides(req *types.HttpRequest, profile *types.Profile) (hostOverrides, error) {
+	var overrides hostOverrides
+	add := func(mappings map[string]string) error {
+		for from, to := range mappings {
+			host, addr, err := parseHostOverride(from, to)
+			if err != nil {
+				return err
+			}
+			if overrides == nil {
+				overrides = make(hostOverrides)
+			}
+			overrides[host] = addr
+		}
+		return nil
+	}
+
+	if profile != nil {
+		if err := add(profile.Resolve); err != nil {
+			return nil, err
+		}
+	}
+	if err := add(req.Resolve); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// parseHostOverride validates one mapping and returns its normalized host:port and ip:port
+// The port of the address may be omitted to keep the port of the host.
+func parseHostOverride(from, to string) (string, string, error) {
+	host, port, err := net.SplitHostPort(strings.TrimSpace(from))
+	if err != nil || host == "" || !validPort(port) {
+		return "", "", fmt.Errorf("invalid resolve entry %q: expected host:port (e.g. api.example.com:443)", from)
+	}
+
+	to = strings.TrimSpace(to)
+	ip, targetPort, err := net.SplitHostPort(to)
+	if err != nil {
+		// No port: the whole value is the IP (IPv6 may be bracketed)
+		ip, targetPort = strings.TrimSuffix(strings.TrimPrefix(to, "["), "]"), port
+	}
+	if net.ParseIP(ip) == nil || !validPort(targetPort) {
+		return "", "", fmt.Errorf("invalid resolve address %q for %s: expected ip:port (e.g. 10.0.0.5:443)", to, from)
+	}
+
+	return net.JoinHostPort(strings.ToLower(host), port), net.JoinHostPort(ip, targetPort), nil
+}
+
+// validPort reports whether port is a TCP port number
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// target returns the address to dial for addr
+func (o hostOverrides) target(addr string) string {
+	if to, ok := o[strings.ToLower(addr)]; ok {
+		return to
+	}
+	return addr
+}
+
+// key returns a stable string identifying the mappings (connection pool key)
+func (o hostOverrides) key() string {
+	entries := make([]string, 0, len(o))
+	for from, to := range o {
+		entries = append(entries, from+"="+to)
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// useHostOverrides makes the transport dial the mapped address for overridden hosts
+// It wraps the dialer already set on the transport, so h2c keeps dialing plain TCP.
+func useHostOverrides(rt http.RoundTripper, overrides hostOverrides) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	switch t := rt.(type) {
+	case *http.Transport:
+		dial := t.DialContext
+		if dial == nil {
+			dial = (&net.Dialer{}).DialContext
+		}
+		t.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dial(ctx, network, overrides.target(addr))
+		}
+	case *http2.Transport:
+		dialTLS := t.DialTLSContext
+		if dialTLS == nil {
+			// cfg already carries the server name of the URL host
+			dialTLS = func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+				dialer := tls.Dialer{Config: cfg}
+				return dialer.DialContext(ctx, network, addr)
+			}
+		}
+		t.DialTLSContext = func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return dialTLS(ctx, network, overrides.target(addr), cfg)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package executor
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// TestResolve_DialsOverrideAddress tests that the mapped address is dialed with the original Host
+func TestResolve_DialsOverrideAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host))
+	}))
+	defer server.Close()
+
+	req := &types.HttpRequest{
+		Method:  "GET",
+		URL:     "http://api.example.test/health",
+		Resolve: map[string]string{"api.example.test:80": server.Listener.Addr().String()},
+	}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Error != "" || result.Body != "api.example.test" {
+		t.Errorf("Expected the server to see the original host, got %q (%s)", result.Body, result.Error)
+	}
+}
+
+// TestResolve_KeepsTLSServerName tests that the certificate is verified against the URL host over HTTP/2
+func TestResolve_KeepsTLSServerName(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	// The test certificate is valid for example.com
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profile := &types.Profile{Resolve: map[string]string{"example.com:443": server.Listener.Addr().String()}}
+	req := &types.HttpRequest{Method: "GET", URL: "https://example.com/", HTTPVersion: HTTPVersionHTTP2}
+	result, err := Execute(req, &types.TLSConfig{CAFile: caFile}, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Error != "" || result.Body != "HTTP/2.0" {
+		t.Errorf("Expected an HTTP/2 response from the override, got %q (%s)", result.Body, result.Error)
+	}
+}
+
+// TestResolveHostOverrides tests merging and validation of the mappings
+func TestResolveHostOverrides(t *testing.T) {
+	profile := &types.Profile{Resolve: map[string]string{"API.example.com:443": "10.0.0.1", "cdn.example.com:443": "10.0.0.2:443"}}
+	req := &types.HttpRequest{Resolve: map[string]string{"api.example.com:443": "[::1]:8443"}}
+
+	overrides, err := resolveHostOverrides(req, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := overrides.target("api.example.com:443"); got != "[::1]:8443" {
+		t.Errorf("Expected the request mapping to win, got %s", got)
+	}
+	if got := overrides.target("cdn.example.com:443"); got != "10.0.0.2:443" {
+		t.Errorf("Expected the profile mapping, got %s", got)
+	}
+	if got := overrides.target("other.example.com:443"); got != "other.example.com:443" {
+		t.Errorf("Expected unmapped hosts to be untouched, got %s", got)
+	}
+
+	for from, to := range map[string]string{
+		"api.example.com":     "10.0.0.1:443",
+		"api.example.com:0":   "10.0.0.1:443",
+		"api.example.com:443": "backend.internal:443",
+		"cdn.example.com:443": "10.0.0.1:http",
+	} {
+		_, err := resolveHostOverrides(&types.HttpRequest{Resolve: map[string]string{from: to}}, nil)
+		if err == nil || !strings.Contains(err.Error(), "invalid resolve") {
+			t.Errorf("%s -> %s: expected a format error, got %v", from, to, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package executor
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+const (
+	// defaultRetryBackoffMs is the base delay before the first retry
+	defaultRetryBackoffMs = 200
+	// maxRetryBackoff caps the delay between two attempts
+	maxRetryBackoff = 30 * time.Second
+)
+
+// defaultRetryOnStatus is used when retries are enabled without explicit status codes
+var defaultRetryOnStatus = []int{502, 503, 504}
+
+// idempotentMethods can be retried without RetryUnsafe (RFC 9110 section 9.2.2)
+var idempotentMethods = map[string]bool{
+	"GET":     true,
+	"HEAD":    true,
+	"OPTIONS": true,
+	"TRACE":   true,
+	"PUT":     true,
+	"DELETE":  true,
+}
+
+// retryPolicy is the effective retry configuration for a request
+type retryPolicy struct {
+	count          int
+	backoffMs      int
+	onStatus       []int
+	onNetworkError bool
+	unsafe         bool
+}
+
+// resolveRetryPolicy merges request-level retry settings over the profile defaults
+// Request values win when set (non-zero); boolean flags are enabled by either level
+func resolveRetryPolicy(req *types.HttpRequest, profile *types.Profile) retryPolicy {
+	policy := retryPolicy{
+		count:          req.RetryCount,
+		backoffMs:      req.RetryBackoffMs,
+		onStatus:       req.RetryOnStatus,
+		onNetworkError: req.RetryOnNetworkError,
+		unsafe:         req.RetryUnsafe,
+	}
+
+	if profile != nil {
+		if policy.count == 0 {
+			policy.count = profile.RetryCount
+		}
+		if policy.backoffMs == 0 {
+			policy.backoffMs = profile.RetryBackoffMs
+		}
+		if len(policy.onStatus) == 0 {
+			policy.onStatus = profile.RetryOnStatus
+		}
+		policy.onNetworkError = policy.onNetworkError || profile.RetryOnNetworkError
+		policy.unsafe = policy.unsafe || profile.RetryUnsafe
+	}
+
+	if policy.backoffMs <= 0 {
+		policy.backoffMs = defaultRetryBackoffMs
+	}
+	if len(policy.onStatus) == 0 {
+		policy.onStatus = defaultRetryOnStatus
+	}
+
+	return policy
+}
+
+// shouldRetry reports whether a finished attempt should be retried
+func (p retryPolicy) shouldRetry(ctx context.Context, method string, result *types.RequestResult) bool {
+	if p.count <= 0 || ctx.Err() != nil {
+		return false
+	}
+	if !p.unsafe && !idempotentMethods[strings.ToUpper(method)] {
+		return false
+	}
+
+	// No status means the request never got a response (connection refused, timeout, ...)
+	if result.Status == 0 {
+		return result.Error != "" && p.onNetworkError
+	}
+
+	for _, status := range p.onStatus {
+		if result.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay after the given attempt: exponential growth with equal jitter
+// Half the delay is fixed and half is random so concurrent clients spread out
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := time.Duration(p.backoffMs) * time.Millisecond
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1))
+}
+
+// wait sleeps for the backoff after the given attempt
+// Returns false if the context was cancelled while waiting
+func (p retryPolicy) wait(ctx context.Context, attempt int) bool {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
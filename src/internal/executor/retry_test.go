@@ -0,0 +1,192 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// newFlakyServer fails with the given status until `failures` requests have been served
+func newFlakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// TestRetry_RetriesOnStatus tests that retryable statuses are retried until success
+func TestRetry_RetriesOnStatus(t *testing.T) {
+	server, calls := newFlakyServer(t, 2, http.StatusServiceUnavailable)
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL, RetryCount: 3, RetryBackoffMs: 1}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Status != 200 {
+		t.Errorf("Expected status 200, got %d", result.Status)
+	}
+	if result.Attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", result.Attempts)
+	}
+	if atomic.LoadInt32(calls) != 3 {
+		t.Errorf("Expected 3 server calls, got %d", *calls)
+	}
+}
+
+// TestRetry_GivesUpAfterCount tests that the last response is returned once retries are exhausted
+func TestRetry_GivesUpAfterCount(t *testing.T) {
+	server, calls := newFlakyServer(t, 10, http.StatusBadGateway)
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL, RetryCount: 2, RetryBackoffMs: 1}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Status != http.StatusBadGateway {
+		t.Errorf("Expected status 502, got %d", result.Status)
+	}
+	if result.Attempts != 3 || atomic.LoadInt32(calls) != 3 {
+		t.Errorf("Expected 3 attempts, got %d (server saw %d)", result.Attempts, *calls)
+	}
+}
+
+// TestRetry_StatusNotInList tests that other statuses are not retried
+func TestRetry_StatusNotInList(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusInternalServerError)
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL, RetryCount: 3, RetryBackoffMs: 1}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Attempts != 1 || atomic.LoadInt32(calls) != 1 {
+		t.Errorf("Expected a single attempt, got %d", result.Attempts)
+	}
+
+	req.RetryOnStatus = []int{500}
+	atomic.StoreInt32(calls, 0)
+	result, _ = Execute(req, nil, nil)
+	if result.Status != 200 || result.Attempts != 2 {
+		t.Errorf("Expected success on second attempt with custom status list, got %d after %d attempts", result.Status, result.Attempts)
+	}
+}
+
+// TestRetry_UnsafeMethods tests that POST is only retried when RetryUnsafe is set
+func TestRetry_UnsafeMethods(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+
+	req := &types.HttpRequest{Method: "POST", URL: server.URL, Body: `{"a":1}`, RetryCount: 2, RetryBackoffMs: 1}
+	result, _ := Execute(req, nil, nil)
+	if result.Attempts != 1 || result.Status != http.StatusServiceUnavailable {
+		t.Errorf("Expected POST not to be retried, got %d attempts", result.Attempts)
+	}
+
+	atomic.StoreInt32(calls, 0)
+	req.RetryUnsafe = true
+	result, _ = Execute(req, nil, nil)
+	if result.Attempts != 2 || result.Status != 200 {
+		t.Errorf("Expected POST retried with RetryUnsafe, got %d after %d attempts", result.Status, result.Attempts)
+	}
+}
+
+// TestRetry_NetworkError tests that connection errors are only retried when enabled
+func TestRetry_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close() // Connection refused from now on
+
+	req := &types.HttpRequest{Method: "GET", URL: url, RetryCount: 2, RetryBackoffMs: 1}
+	result, _ := Execute(req, nil, nil)
+	if result.Error == "" || result.Attempts != 1 {
+		t.Errorf("Expected one failed attempt, got %d (error %q)", result.Attempts, result.Error)
+	}
+
+	req.RetryOnNetworkError = true
+	result, _ = Execute(req, nil, nil)
+	if result.Attempts != 3 {
+		t.Errorf("Expected 3 attempts with RetryOnNetworkError, got %d", result.Attempts)
+	}
+}
+
+// TestRetry_ProfileDefaults tests that profile settings apply when the request sets none
+func TestRetry_ProfileDefaults(t *testing.T) {
+	server, _ := newFlakyServer(t, 1, http.StatusTooManyRequests)
+	profile := &types.Profile{Name: "test", RetryCount: 1, RetryBackoffMs: 1, RetryOnStatus: []int{429}}
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL}
+	result, err := Execute(req, nil, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Status != 200 || result.Attempts != 2 {
+		t.Errorf("Expected success after profile retry, got %d after %d attempts", result.Status, result.Attempts)
+	}
+}
+
+// TestRetry_ContextCancelled tests that cancellation stops the backoff wait
+func TestRetry_ContextCancelled(t *testing.T) {
+	server, calls := newFlakyServer(t, 10, http.StatusServiceUnavailable)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL, RetryCount: 5, RetryBackoffMs: 10000}
+	start := time.Now()
+	result, err := ExecuteWithContext(ctx, req, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("Expected cancellation to interrupt backoff")
+	}
+	if result.Attempts != 1 || atomic.LoadInt32(calls) != 1 {
+		t.Errorf("Expected a single attempt before cancellation, got %d", result.Attempts)
+	}
+}
+
+// TestRetry_Streaming tests that streaming requests are retried before the body is delivered
+func TestRetry_Streaming(t *testing.T) {
+	server, _ := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL, RetryCount: 1, RetryBackoffMs: 1}
+	result, err := ExecuteWithStreaming(context.Background(), req, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Status != 200 || result.Attempts != 2 {
+		t.Errorf("Expected success on second attempt, got %d after %d attempts", result.Status, result.Attempts)
+	}
+	if result.Body != "ok" {
+		t.Errorf("Expected body from the successful attempt, got %q", result.Body)
+	}
+}
+
+// TestRetry_Backoff tests exponential growth, jitter bounds and the cap
+func TestRetry_Backoff(t *testing.T) {
+	policy := retryPolicy{backoffMs: 100}
+
+	for attempt, base := range map[int]time.Duration{1: 100, 2: 200, 3: 400} {
+		delay := policy.backoff(attempt)
+		max := base * time.Millisecond
+		if delay < max/2 || delay > max {
+			t.Errorf("Attempt %d: expected delay in [%v, %v], got %v", attempt, max/2, max, delay)
+		}
+	}
+
+	if delay := policy.backoff(50); delay > maxRetryBackoff {
+		t.Errorf("Expected delay capped at %v, got %v", maxRetryBackoff, delay)
+	}
+}
@@ -0,0 +1,308 @@
+package executor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Request signing runs after variable resolution, once the final headers and body
+// are known, right before the request is sent (and again on every retry attempt).
+// Schemes implement Signer and are registered by algorithm name.
+
+// Signer adds authentication headers to a request
+// body is the payload as sent on the wire (nil when there is none)
+type Signer interface {
+	Sign(req *http.Request, body []byte, now time.Time) error
+}
+
+// SignerFactory builds a Signer from a resolved signing configuration
+type SignerFactory func(config *types.SigningConfig) (Signer, error)
+
+var (
+	signersMu sync.RWMutex
+	signers   = map[string]SignerFactory{
+		types.SigningAWSSigV4:   newAWSSigV4Signer,
+		types.SigningHMACSHA256: newHMACSigner,
+	}
+)
+
+// signingNow returns the signing time (replaced in tests)
+var signingNow = time.Now
+
+// RegisterSigner adds or replaces the signing scheme for an algorithm name
+func RegisterSigner(algorithm string, factory SignerFactory) {
+	signersMu.Lock()
+	defer signersMu.Unlock()
+	signers[strings.ToLower(algorithm)] = factory
+}
+
+// SignRequest signs req according to config (no-op when config is nil or "none")
+func SignRequest(req *http.Request, config *types.SigningConfig) error {
+	if config == nil {
+		return nil
+	}
+	algorithm := strings.ToLower(strings.TrimSpace(config.Algorithm))
+	if algorithm == "" || algorithm == types.SigningNone {
+		return nil
+	}
+
+	signersMu.RLock()
+	factory, ok := signers[algorithm]
+	signersMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unsupported signing algorithm %q (expected %s or %s)", config.Algorithm, types.SigningAWSSigV4, types.SigningHMACSHA256)
+	}
+
+	signer, err := factory(config)
+	if err != nil {
+		return fmt.Errorf("invalid %s signing config: %w", algorithm, err)
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return fmt.Errorf("failed to read body for signing: %w", err)
+	}
+
+	if err := signer.Sign(req, body, signingNow().UTC()); err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+	return nil
+}
+
+// readRequestBody returns a copy of the request body without consuming it
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("request body cannot be replayed")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// uriEncode percent-encodes everything except unreserved characters (A-Z a-z 0-9 - _ . ~)
+// The slash is kept when encoding a path
+func uriEncode(value string, keepSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (keepSlash && c == '/') {
+			sb.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&sb, "%%%02X", c)
+	}
+	return sb.String()
+}
+
+// AWS Signature Version 4
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html
+
+const (
+	awsAlgorithm  = "AWS4-HMAC-SHA256"
+	awsDateFormat = "20060102T150405Z"
+)
+
+// awsUnsignedHeaders are left out of the signature because proxies and transports may change them
+var awsUnsignedHeaders = map[string]bool{
+	"authorization":   true,
+	"user-agent":      true,
+	"x-amzn-trace-id": true,
+	"expect":          true,
+	"content-length":  true,
+}
+
+type awsSigV4Signer struct {
+	config *types.SigningConfig
+}
+
+func newAWSSigV4Signer(config *types.SigningConfig) (Signer, error) {
+	var missing []string
+	for name, value := range map[string]string{
+		"accessKey": config.AccessKey,
+		"secretKey": config.SecretKey,
+		"region":    config.Region,
+		"service":   config.Service,
+	} {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	return &awsSigV4Signer{config: config}, nil
+}
+
+// Sign sets X-Amz-Date (and X-Amz-Security-Token, X-Amz-Content-Sha256 for S3) then Authorization
+func (s *awsSigV4Signer) Sign(req *http.Request, body []byte, now time.Time) error {
+	amzDate := now.Format(awsDateFormat)
+	date := amzDate[:8]
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.config.SessionToken)
+	}
+	if s.config.Service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+
+	canonicalHeaders, signedHeaders := awsCanonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		awsCanonicalURI(req),
+		awsCanonicalQuery(req),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.config.Region, s.config.Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		awsAlgorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, s.config.Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsAlgorithm, s.config.AccessKey, scope, signedHeaders, signature))
+	return nil
+}
+
+// awsCanonicalURI encodes each path segment once (empty path is "/")
+func awsCanonicalURI(req *http.Request) string {
+	path := req.URL.Path
+	if path == "" {
+		return "/"
+	}
+	return uriEncode(path, true)
+}
+
+// awsCanonicalQuery sorts the query parameters by name then value, each encoded
+func awsCanonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	pairs := make([]string, 0, len(query))
+	for key, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key, false)+"="+uriEncode(value, false))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// awsCanonicalHeaders returns the lowercase, sorted, trimmed headers block and the signed header list
+func awsCanonicalHeaders(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.Host}
+	if req.Host == "" {
+		headers["host"] = req.URL.Host
+	}
+	for key, values := range req.Header {
+		name := strings.ToLower(key)
+		if awsUnsignedHeaders[name] {
+			continue
+		}
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		headers[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// HMAC-SHA256 signing for APIs with a shared secret
+//
+// String to sign: METHOD \n path?query \n unix timestamp \n hex(sha256(body))
+// Headers: X-Signature-Timestamp, X-Signature (hex HMAC, name configurable) and
+// X-Signature-Key-Id when an access key is configured
+
+const (
+	hmacDefaultHeader   = "X-Signature"
+	hmacTimestampHeader = "X-Signature-Timestamp"
+	hmacKeyIDHeader     = "X-Signature-Key-Id"
+)
+
+type hmacSigner struct {
+	config *types.SigningConfig
+}
+
+func newHMACSigner(config *types.SigningConfig) (Signer, error) {
+	if config.SecretKey == "" {
+		return nil, fmt.Errorf("missing secretKey")
+	}
+	return &hmacSigner{config: config}, nil
+}
+
+// Sign sets the timestamp, key ID and signature headers
+func (s *hmacSigner) Sign(req *http.Request, body []byte, now time.Time) error {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	stringToSign := strings.Join([]string{
+		req.Method,
+		req.URL.RequestURI(),
+		timestamp,
+		sha256Hex(body),
+	}, "\n")
+
+	header := s.config.Header
+	if header == "" {
+		header = hmacDefaultHeader
+	}
+
+	req.Header.Set(hmacTimestampHeader, timestamp)
+	if s.config.AccessKey != "" {
+		req.Header.Set(hmacKeyIDHeader, s.config.AccessKey)
+	}
+	req.Header.Set(header, hex.EncodeToString(hmacSHA256([]byte(s.config.SecretKey), stringToSign)))
+	return nil
+}
@@ -0,0 +1,194 @@
+package executor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// awsTestConfig holds the credentials of the AWS SigV4 test suite
+var awsTestConfig = &types.SigningConfig{
+	Algorithm: types.SigningAWSSigV4,
+	AccessKey: "AKIDEXAMPLE",
+	SecretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	Region:    "us-east-1",
+	Service:   "service",
+}
+
+// fixSigningTime pins the signing clock for the duration of a test
+func fixSigningTime(t *testing.T, now time.Time) {
+	t.Helper()
+	original := signingNow
+	signingNow = func() time.Time { return now }
+	t.Cleanup(func() { signingNow = original })
+}
+
+// TestSignRequest_AWSPublishedExample checks the IAM ListUsers example from the AWS SigV4 documentation
+func TestSignRequest_AWSPublishedExample(t *testing.T) {
+	fixSigningTime(t, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	key := hmacSHA256([]byte("AWS4"+awsTestConfig.SecretKey), "20150830")
+	key = hmacSHA256(key, "us-east-1")
+	key = hmacSHA256(key, "iam")
+	key = hmacSHA256(key, "aws4_request")
+	if got := hex.EncodeToString(key); got != "c4afb1cc5771d871763a393e44b703571b55cc28424d1a5e86da6ed3c154a4b9" {
+		t.Errorf("Signing key = %s", got)
+	}
+
+	req, _ := http.NewRequest("GET", "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	config := *awsTestConfig
+	config.Service = "iam"
+
+	if err := SignRequest(req, &config); err != nil {
+		t.Fatalf("SignRequest failed: %v", err)
+	}
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n  %s\nwant\n  %s", got, want)
+	}
+	if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+		t.Errorf("X-Amz-Date = %q", got)
+	}
+}
+
+// TestSignRequest_AWSCanonicalization checks that equivalent requests produce the same signature
+func TestSignRequest_AWSCanonicalization(t *testing.T) {
+	fixSigningTime(t, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	sign := func(url string, headers map[string]string, body string) string {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req, err := http.NewRequest("POST", url, reader)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		for key, value := range headers {
+			req.Header[key] = []string{value}
+		}
+		if err := SignRequest(req, awsTestConfig); err != nil {
+			t.Fatalf("SignRequest failed: %v", err)
+		}
+		return req.Header.Get("Authorization")
+	}
+
+	base := sign("https://example.amazon.com/?Param1=value1&Param2=value2", map[string]string{"My-Header1": "value1"}, "a=1")
+
+	if got := sign("https://example.amazon.com/?Param2=value2&Param1=value1", map[string]string{"My-Header1": "value1"}, "a=1"); got != base {
+		t.Error("Expected query parameter order not to change the signature")
+	}
+	if got := sign("https://example.amazon.com/?Param1=value1&Param2=value2", map[string]string{"my-header1": "  value1 "}, "a=1"); got != base {
+		t.Error("Expected header name case and surrounding spaces not to change the signature")
+	}
+	if got := sign("https://example.amazon.com/?Param1=value1&Param2=value2", map[string]string{"My-Header1": "value1"}, "a=2"); got == base {
+		t.Error("Expected the body to be part of the signature")
+	}
+	if !strings.Contains(base, "SignedHeaders=host;my-header1;x-amz-date,") {
+		t.Errorf("Unexpected signed headers: %s", base)
+	}
+}
+
+func TestSignRequest_AWSSessionToken(t *testing.T) {
+	fixSigningTime(t, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	config := *awsTestConfig
+	config.SessionToken = "token"
+	req, _ := http.NewRequest("GET", "https://example.amazon.com/", nil)
+
+	if err := SignRequest(req, &config); err != nil {
+		t.Fatalf("SignRequest failed: %v", err)
+	}
+	if req.Header.Get("X-Amz-Security-Token") != "token" {
+		t.Error("Expected the session token header")
+	}
+	if !strings.Contains(req.Header.Get("Authorization"), "SignedHeaders=host;x-amz-date;x-amz-security-token,") {
+		t.Errorf("Expected the session token to be signed, got %s", req.Header.Get("Authorization"))
+	}
+}
+
+func TestSignRequest_HMAC(t *testing.T) {
+	fixSigningTime(t, time.Unix(1700000000, 0))
+
+	req, _ := http.NewRequest("POST", "https://api.example.com/orders?id=1", strings.NewReader(`{"a":1}`))
+	config := &types.SigningConfig{Algorithm: "HMAC-SHA256", AccessKey: "key-1", SecretKey: "secret", Header: "X-Sig"}
+	if err := SignRequest(req, config); err != nil {
+		t.Fatalf("SignRequest failed: %v", err)
+	}
+
+	bodyHash := sha256.Sum256([]byte(`{"a":1}`))
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("POST\n/orders?id=1\n1700000000\n" + hex.EncodeToString(bodyHash[:])))
+
+	if got, want := req.Header.Get("X-Sig"), hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Errorf("X-Sig = %q, want %q", got, want)
+	}
+	if req.Header.Get("X-Signature-Timestamp") != "1700000000" || req.Header.Get("X-Signature-Key-Id") != "key-1" {
+		t.Errorf("Unexpected signing headers: %v", req.Header)
+	}
+}
+
+func TestSignRequest_Errors(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://example.com/", nil)
+
+	if err := SignRequest(req, &types.SigningConfig{Algorithm: "rsa"}); err == nil || !strings.Contains(err.Error(), "unsupported signing algorithm") {
+		t.Errorf("Expected an unsupported algorithm error, got %v", err)
+	}
+	if err := SignRequest(req, &types.SigningConfig{Algorithm: types.SigningAWSSigV4, AccessKey: "a"}); err == nil || !strings.Contains(err.Error(), "missing region, secretKey, service") {
+		t.Errorf("Expected the missing fields to be listed, got %v", err)
+	}
+	if err := SignRequest(req, &types.SigningConfig{Algorithm: types.SigningNone}); err != nil || req.Header.Get("Authorization") != "" {
+		t.Errorf("Expected none to skip signing, got %v", err)
+	}
+}
+
+func TestExecute_SignsRequest(t *testing.T) {
+	var authorization, amzDate string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		amzDate = r.Header.Get("X-Amz-Date")
+	}))
+	defer server.Close()
+
+	req := &types.HttpRequest{Method: "POST", URL: server.URL + "/items", Body: "{}", Signing: awsTestConfig}
+	if _, err := Execute(req, nil, nil); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || amzDate == "" {
+		t.Errorf("Expected a signed request, got Authorization %q, X-Amz-Date %q", authorization, amzDate)
+	}
+}
+
+func TestExecuteWithStreaming_SignsRequest(t *testing.T) {
+	var authorization, amzDate string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		amzDate = r.Header.Get("X-Amz-Date")
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: hello\n\n"))
+	}))
+	defer server.Close()
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL + "/events", Streaming: true, Signing: awsTestConfig}
+	if _, err := ExecuteWithStreaming(context.Background(), req, nil, nil, nil, nil, func(chunk []byte, done bool) {}); err != nil {
+		t.Fatalf("ExecuteWithStreaming failed: %v", err)
+	}
+
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || amzDate == "" {
+		t.Errorf("Expected a signed streaming request, got Authorization %q, X-Amz-Date %q", authorization, amzDate)
+	}
+}
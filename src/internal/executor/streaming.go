@@ -0,0 +1,100 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Supported stream formats
+const (
+	StreamFormatSSE    = "sse"    // Server-Sent Events (chunks delivered as received)
+	StreamFormatNDJSON = "ndjson" // Newline-delimited JSON (one callback per complete line)
+	StreamFormatRaw    = "raw"    // Raw bytes (chunks delivered as received)
+)
+
+// resolveStreamFormat returns the normalized stream format of the request (empty = auto)
+func resolveStreamFormat(req *types.HttpRequest) string {
+	return strings.ToLower(strings.TrimSpace(req.StreamFormat))
+}
+
+// validateStreamFormat checks the stream format is known
+func validateStreamFormat(format string) error {
+	switch format {
+	case "", StreamFormatSSE, StreamFormatNDJSON, StreamFormatRaw:
+		return nil
+	default:
+		return fmt.Errorf("unsupported stream format %q (expected sse, ndjson or raw)", format)
+	}
+}
+
+// streamNDJSON reads a newline-delimited JSON body and calls the callback once per complete line
+// Partial lines are buffered across reads; a final line without a trailing newline is delivered at EOF
+// Each delivered line ends with "\n" and blank lines are skipped
+func streamNDJSON(ctx context.Context, body io.Reader, maxSize int64, callback types.StreamCallback) ([]byte, error) {
+	var fullBody bytes.Buffer
+	var pending []byte
+	buffer := make([]byte, 4096) // 4KB chunks
+
+	emit := func(line []byte) {
+		line = bytes.TrimRight(line, "\r\n")
+		if callback == nil || len(bytes.TrimSpace(line)) == 0 {
+			return
+		}
+		out := make([]byte, len(line)+1)
+		copy(out, line)
+		out[len(line)] = '\n'
+		callback(out, false)
+	}
+
+	for {
+		// Check for cancellation
+		select {
+		case <-ctx.Done():
+			if callback != nil {
+				callback(nil, true) // Signal done with cancellation
+			}
+			return fullBody.Bytes(), context.Canceled
+		default:
+		}
+
+		n, err := body.Read(buffer)
+		if n > 0 {
+			if int64(fullBody.Len())+int64(n) > maxSize {
+				return fullBody.Bytes(), fmt.Errorf("response size exceeds maximum allowed size (%d bytes)", maxSize)
+			}
+
+			fullBody.Write(buffer[:n])
+			pending = append(pending, buffer[:n]...)
+
+			for {
+				idx := bytes.IndexByte(pending, '\n')
+				if idx < 0 {
+					break
+				}
+				emit(pending[:idx])
+				pending = pending[idx+1:]
+			}
+		}
+
+		if err == io.EOF {
+			// Flush the last line when the body doesn't end with a newline
+			if len(pending) > 0 {
+				emit(pending)
+			}
+			if callback != nil {
+				callback(nil, true) // Signal done
+			}
+			break
+		}
+		if err != nil {
+			return fullBody.Bytes(), err
+		}
+	}
+
+	return fullBody.Bytes(), nil
+}
@@ -0,0 +1,120 @@
+package executor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// slowReader returns the configured pieces one Read call at a time
+type slowReader struct {
+	pieces []string
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if len(r.pieces) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.pieces[0])
+	r.pieces = r.pieces[1:]
+	return n, nil
+}
+
+// collectLines runs streamNDJSON and returns every non-done chunk delivered
+func collectLines(t *testing.T, pieces ...string) ([]string, string) {
+	t.Helper()
+	var lines []string
+	done := false
+	body, err := streamNDJSON(context.Background(), &slowReader{pieces: pieces}, MaxResponseSize, func(chunk []byte, isDone bool) {
+		if isDone {
+			done = true
+			return
+		}
+		lines = append(lines, string(chunk))
+	})
+	if err != nil {
+		t.Fatalf("streamNDJSON failed: %v", err)
+	}
+	if !done {
+		t.Error("Expected done callback")
+	}
+	return lines, string(body)
+}
+
+func TestStreamNDJSON_PartialLinesAcrossReads(t *testing.T) {
+	lines, body := collectLines(t, `{"a":1}`+"\n"+`{"b":`, `2}`+"\n", `{"c":3}`+"\n")
+
+	expected := []string{`{"a":1}` + "\n", `{"b":2}` + "\n", `{"c":3}` + "\n"}
+	if strings.Join(lines, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected lines %q, got %q", expected, lines)
+	}
+	if body != `{"a":1}`+"\n"+`{"b":2}`+"\n"+`{"c":3}`+"\n" {
+		t.Errorf("Expected full body to be preserved, got %q", body)
+	}
+}
+
+func TestStreamNDJSON_FinalLineWithoutNewline(t *testing.T) {
+	lines, body := collectLines(t, `{"a":1}`+"\n", `{"last":true}`)
+
+	if len(lines) != 2 || lines[1] != `{"last":true}`+"\n" {
+		t.Errorf("Expected final line to be flushed, got %q", lines)
+	}
+	if body != `{"a":1}`+"\n"+`{"last":true}` {
+		t.Errorf("Expected body without added newline, got %q", body)
+	}
+}
+
+func TestStreamNDJSON_SkipsBlankLinesAndCRLF(t *testing.T) {
+	lines, _ := collectLines(t, `{"a":1}`+"\r\n\r\n", "\n"+`{"b":2}`+"\r\n")
+
+	if len(lines) != 2 || lines[0] != `{"a":1}`+"\n" || lines[1] != `{"b":2}`+"\n" {
+		t.Errorf("Expected two clean lines, got %q", lines)
+	}
+}
+
+func TestExecuteWithStreaming_NDJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Plain JSON content type: ndjson framing comes from the request, not the response
+		w.Header().Set("Content-Type", "application/json")
+		flusher := w.(http.Flusher)
+		w.Write([]byte(`{"event":"start"}` + "\n" + `{"event":`))
+		flusher.Flush()
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`"end"}`))
+	}))
+	defer server.Close()
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL, StreamFormat: "ndjson"}
+
+	var lines []string
+	result, err := ExecuteWithStreaming(context.Background(), req, nil, nil, nil, nil, func(chunk []byte, done bool) {
+		if !done {
+			lines = append(lines, string(chunk))
+		}
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWithStreaming failed: %v", err)
+	}
+
+	if len(lines) != 2 || lines[0] != `{"event":"start"}`+"\n" || lines[1] != `{"event":"end"}`+"\n" {
+		t.Errorf("Expected two complete lines, got %q", lines)
+	}
+	if result.Body != `{"event":"start"}`+"\n"+`{"event":"end"}` {
+		t.Errorf("Unexpected body: %q", result.Body)
+	}
+}
+
+func TestExecuteWithStreaming_InvalidStreamFormat(t *testing.T) {
+	req := &types.HttpRequest{Method: "GET", URL: "http://localhost", StreamFormat: "xml"}
+
+	_, err := ExecuteWithStreaming(context.Background(), req, nil, nil, nil, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "unsupported stream format") {
+		t.Errorf("Expected unsupported stream format error, got %v", err)
+	}
+}
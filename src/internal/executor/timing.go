@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// phaseTracer records DNS, connect, TLS and time-to-first-byte durations via httptrace
+// Each redirect hop adds to the totals, so the result covers the whole request
+type phaseTracer struct {
+	mu            sync.Mutex
+	timings       types.RequestTimings
+	hopStart      time.Time
+	dnsStart      time.Time
+	tlsStart      time.Time
+	connectStarts map[string]time.Time // keyed by address (dual-stack dials run in parallel)
+}
+
+func newPhaseTracer() *phaseTracer {
+	return &phaseTracer{connectStarts: make(map[string]time.Time)}
+}
+
+// attach returns a copy of the request that reports to the tracer
+func (t *phaseTracer) attach(req *http.Request) *http.Request {
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), t.clientTrace()))
+}
+
+func (t *phaseTracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.hopStart = time.Now()
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !t.dnsStart.IsZero() {
+				t.timings.DNS += time.Since(t.dnsStart).Milliseconds()
+				t.dnsStart = time.Time{}
+			}
+		},
+		ConnectStart: func(network, addr string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.connectStarts[addr] = time.Now()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			start, ok := t.connectStarts[addr]
+			delete(t.connectStarts, addr)
+			// Only the dial that won counts; failed fallback attempts are ignored
+			if ok && err == nil {
+				t.timings.Connect += time.Since(start).Milliseconds()
+			}
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !t.tlsStart.IsZero() {
+				t.timings.TLS += time.Since(t.tlsStart).Milliseconds()
+				t.tlsStart = time.Time{}
+			}
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !t.hopStart.IsZero() {
+				t.timings.TTFB += time.Since(t.hopStart).Milliseconds()
+				t.hopStart = time.Time{}
+			}
+		},
+	}
+}
+
+// result returns a snapshot of the recorded timings
+func (t *phaseTracer) result() *types.RequestTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := t.timings
+	return &timings
+}
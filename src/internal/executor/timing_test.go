@@ -0,0 +1,107 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// TestTimings_Recorded tests that connect, TLS and TTFB phases are captured
+func TestTimings_Recorded(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// Use a hostname so the DNS phase runs
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	req := &types.HttpRequest{Method: "GET", URL: url}
+	result, err := Execute(req, &types.TLSConfig{InsecureSkipVerify: true}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Timings == nil {
+		t.Fatal("Expected timings to be recorded")
+	}
+	if result.Timings.TTFB < 20 {
+		t.Errorf("Expected TTFB >= 20ms, got %dms", result.Timings.TTFB)
+	}
+	if result.Timings.TTFB > result.Duration {
+		t.Errorf("Expected TTFB (%dms) <= total (%dms)", result.Timings.TTFB, result.Duration)
+	}
+}
+
+// TestTimings_Redirects tests that per-hop TTFB is summed across redirects
+func TestTimings_Redirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(15 * time.Millisecond)
+		http.Redirect(w, r, "/end", http.StatusFound)
+	})
+	mux.HandleFunc("/end", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(15 * time.Millisecond)
+		w.Write([]byte("done"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL + "/start"}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Body != "done" {
+		t.Fatalf("Expected redirect to be followed, got %q", result.Body)
+	}
+	if result.Timings == nil || result.Timings.TTFB < 30 {
+		t.Errorf("Expected TTFB summed over both hops (>= 30ms), got %+v", result.Timings)
+	}
+}
+
+// TestFormatTimings tests the waterfall rendering
+func TestFormatTimings(t *testing.T) {
+	timings := &types.RequestTimings{DNS: 12, Connect: 30, TLS: 80, TTFB: 210}
+	expected := "DNS 12ms | Connect 30ms | TLS 80ms | TTFB 210ms | Total 250ms"
+	if got := FormatTimings(timings, 250); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	// TLS is omitted for plain HTTP
+	timings.TLS = 0
+	if got := FormatTimings(timings, 250); strings.Contains(got, "TLS") {
+		t.Errorf("Expected no TLS phase, got %q", got)
+	}
+}
+
+// TestDurationBudget tests that the request budget overrides the profile default, for both entry points
+func TestDurationBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	profile := &types.Profile{MaxDurationMs: 5000}
+	result, err := Execute(&types.HttpRequest{Method: "GET", URL: server.URL}, nil, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.MaxDurationMs != 5000 || result.OverBudget() {
+		t.Errorf("Expected the profile budget to be met, got %dms of %dms", result.Duration, result.MaxDurationMs)
+	}
+
+	req := &types.HttpRequest{Method: "GET", URL: server.URL, MaxDurationMs: 5}
+	result, err = ExecuteWithStreaming(context.Background(), req, nil, profile, nil, nil, func([]byte, bool) {})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.MaxDurationMs != 5 || !result.OverBudget() {
+		t.Errorf("Expected the request budget to be exceeded, got %dms of %dms", result.Duration, result.MaxDurationMs)
+	}
+}
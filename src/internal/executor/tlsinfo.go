@@ -0,0 +1,67 @@
+package executor
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// CertExpiryWarning is how close to expiry a certificate is flagged
+const CertExpiryWarning = 30 * 24 * time.Hour
+
+// newTLSInfo captures the negotiated TLS connection of a response (nil for plain HTTP)
+func newTLSInfo(state *tls.ConnectionState) *types.TLSInfo {
+	if state == nil {
+		return nil
+	}
+	info := &types.TLSInfo{
+		Version:      tls.VersionName(state.Version),
+		CipherSuite:  tls.CipherSuiteName(state.CipherSuite),
+		ServerName:   state.ServerName,
+		ALPN:         state.NegotiatedProtocol,
+		Certificates: make([]types.CertificateInfo, 0, len(state.PeerCertificates)),
+	}
+	for _, cert := range state.PeerCertificates {
+		info.Certificates = append(info.Certificates, newCertificateInfo(cert))
+	}
+	return info
+}
+
+// newCertificateInfo extracts the fields shown for a certificate
+func newCertificateInfo(cert *x509.Certificate) types.CertificateInfo {
+	certHash := sha256.Sum256(cert.Raw)
+	spkiHash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	info := types.CertificateInfo{
+		Subject:    cert.Subject.String(),
+		Issuer:     cert.Issuer.String(),
+		NotBefore:  cert.NotBefore,
+		NotAfter:   cert.NotAfter,
+		DNSNames:   cert.DNSNames,
+		SHA256:     hex.EncodeToString(certHash[:]),
+		SPKISHA256: base64.StdEncoding.EncodeToString(spkiHash[:]),
+	}
+	for _, ip := range cert.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	return info
+}
+
+// CertificateExpiry describes when a certificate expires relative to now, e.g. "expires in 12 days"
+// soon is true for expired certificates and those within CertExpiryWarning.
+func CertificateExpiry(cert types.CertificateInfo, now time.Time) (text string, soon bool) {
+	remaining := cert.NotAfter.Sub(now)
+	date := cert.NotAfter.UTC().Format("2006-01-02")
+	switch {
+	case remaining <= 0:
+		return fmt.Sprintf("expired %s (%d days ago)", date, int(-remaining.Hours()/24)), true
+	case now.Before(cert.NotBefore):
+		return fmt.Sprintf("not valid before %s", cert.NotBefore.UTC().Format("2006-01-02")), true
+	}
+	return fmt.Sprintf("expires %s (in %d days)", date, int(remaining.Hours()/24)), remaining < CertExpiryWarning
+}
@@ -0,0 +1,84 @@
+package executor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// TestTLSInfo tests that the negotiated connection and certificate chain are captured
+func TestTLSInfo(t *testing.T) {
+	server := newPinnedServer(t)
+
+	result, err := Execute(&types.HttpRequest{Method: "GET", URL: server.URL}, &types.TLSConfig{CAFile: "testdata/pinning.crt"}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	info := result.TLS
+	if info == nil {
+		t.Fatalf("Expected TLS info, got none (%s)", result.Error)
+	}
+	if !strings.HasPrefix(info.Version, "TLS 1.") || info.CipherSuite == "" {
+		t.Errorf("Expected a negotiated version and cipher, got %q %q", info.Version, info.CipherSuite)
+	}
+	if len(info.Certificates) != 1 {
+		t.Fatalf("Expected the self-signed certificate, got %d certificates", len(info.Certificates))
+	}
+
+	cert := info.Certificates[0]
+	if cert.Subject != "CN=localhost" || cert.Issuer != "CN=localhost" {
+		t.Errorf("Unexpected subject/issuer %q %q", cert.Subject, cert.Issuer)
+	}
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != "localhost" || len(cert.IPAddresses) != 1 || cert.IPAddresses[0] != "127.0.0.1" {
+		t.Errorf("Unexpected SANs %v %v", cert.DNSNames, cert.IPAddresses)
+	}
+	// The fingerprints are usable as pins
+	if "sha256//"+cert.SPKISHA256 != fixtureSPKIPin || !strings.EqualFold(cert.SHA256, strings.ReplaceAll(fixtureCertPin, ":", "")) {
+		t.Errorf("Unexpected fingerprints %s %s", cert.SHA256, cert.SPKISHA256)
+	}
+}
+
+// TestTLSInfo_PlainHTTP tests that plain HTTP responses carry no TLS info
+func TestTLSInfo_PlainHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	result, err := Execute(&types.HttpRequest{Method: "GET", URL: server.URL}, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.TLS != nil {
+		t.Errorf("Expected no TLS info, got %+v", result.TLS)
+	}
+}
+
+// TestCertificateExpiry tests the expiry text and the soon-to-expire flag
+func TestCertificateExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notBefore := now.AddDate(-1, 0, 0)
+
+	tests := []struct {
+		notAfter time.Time
+		want     string
+		soon     bool
+	}{
+		{now.AddDate(0, 6, 0), "expires 2026-07-01 (in 181 days)", false},
+		{now.AddDate(0, 0, 12), "expires 2026-01-13 (in 12 days)", true},
+		{now.AddDate(0, 0, -3), "expired 2025-12-29 (3 days ago)", true},
+	}
+	for _, tt := range tests {
+		text, soon := CertificateExpiry(types.CertificateInfo{NotBefore: notBefore, NotAfter: tt.notAfter}, now)
+		if text != tt.want || soon != tt.soon {
+			t.Errorf("Expected %q (soon=%v), got %q (soon=%v)", tt.want, tt.soon, text, soon)
+		}
+	}
+
+	text, soon := CertificateExpiry(types.CertificateInfo{NotBefore: now.AddDate(0, 0, 1), NotAfter: now.AddDate(1, 0, 0)}, now)
+	if text != "not valid before 2026-01-02" || !soon {
+		t.Errorf("Expected a not yet valid certificate to be flagged, got %q (soon=%v)", text, soon)
+	}
+}
@@ -0,0 +1,88 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// URL schemes for HTTP over a unix domain socket
+// e.g. http+unix:///var/run/docker.sock:/containers/json
+const (
+	unixSchemeHTTP  = "http+unix://"
+	unixSchemeHTTPS = "https+unix://"
+)
+
+// SplitUnixSocketURL splits a unix socket URL into the socket path and a regular HTTP URL
+// The part before the first colon is the socket path, the rest is the HTTP path (defaults to /)
+// ok is false when the URL does not use a unix socket scheme
+func SplitUnixSocketURL(rawURL string) (socketPath, httpURL string, ok bool) {
+	lower := strings.ToLower(rawURL)
+
+	var scheme, rest string
+	switch {
+	case strings.HasPrefix(lower, unixSchemeHTTP):
+		scheme, rest = "http", rawURL[len(unixSchemeHTTP):]
+	case strings.HasPrefix(lower, unixSchemeHTTPS):
+		scheme, rest = "https", rawURL[len(unixSchemeHTTPS):]
+	default:
+		return "", rawURL, false
+	}
+
+	socketPath, path, _ := strings.Cut(rest, ":")
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	// The host is only used for the Host header and TLS server name
+	return socketPath, scheme + "://localhost" + path, true
+}
+
+// validateUnixSocket checks the socket path exists and is a socket
+func validateUnixSocket(socketPath string) error {
+	if socketPath == "" {
+		return fmt.Errorf("missing socket path in unix socket URL (expected http+unix:///path/to.sock:/path)")
+	}
+
+	info, err := os.Stat(socketPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("unix socket not found: %s", socketPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to access unix socket %s: %w", socketPath, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("not a unix socket: %s", socketPath)
+	}
+	return nil
+}
+
+// resolveUnixSocket returns the request to send and the socket to dial (empty for TCP)
+// Unix socket URLs are rewritten to a regular URL on a copy, the original request is untouched
+func resolveUnixSocket(req *types.HttpRequest) (*types.HttpRequest, string, error) {
+	socketPath, httpURL, ok := SplitUnixSocketURL(req.URL)
+	if !ok {
+		return req, "", nil
+	}
+	if err := validateUnixSocket(socketPath); err != nil {
+		return nil, "", err
+	}
+
+	target := *req
+	target.URL = httpURL
+	return &target, socketPath, nil
+}
+
+// useUnixSocket makes the transport dial the socket instead of the URL host
+// TLS (https+unix) still runs on top of the socket connection
+func useUnixSocket(rt http.RoundTripper, socketPath string) {
+	setDialer(rt, func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "unix", socketPath)
+	})
+}
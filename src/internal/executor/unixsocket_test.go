@@ -0,0 +1,157 @@
+package executor
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// newUnixServer starts an HTTP server listening on a unix socket and returns the socket path
+func newUnixServer(t *testing.T, handler http.Handler) string {
+	return startUnixServer(t, handler, false)
+}
+
+func startUnixServer(t *testing.T, handler http.Handler, useTLS bool) string {
+	t.Helper()
+	// Keep the path short: unix socket paths are limited to ~104 bytes
+	dir, err := os.MkdirTemp("", "restcli")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, "api.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	if useTLS {
+		server.EnableHTTP2 = true
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	t.Cleanup(server.Close)
+	return socketPath
+}
+
+// pathHandler echoes the request path, query and host
+var pathHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "%s %s?%s", r.Host, r.URL.Path, r.URL.RawQuery)
+})
+
+func TestSplitUnixSocketURL(t *testing.T) {
+	tests := []struct {
+		url        string
+		socketPath string
+		httpURL    string
+		ok         bool
+	}{
+		{"http+unix:///var/run/docker.sock:/containers/json", "/var/run/docker.sock", "http://localhost/containers/json", true},
+		{"http+unix:///var/run/docker.sock:/v1.43/images/json?all=true", "/var/run/docker.sock", "http://localhost/v1.43/images/json?all=true", true},
+		{"http+unix:///tmp/app.sock", "/tmp/app.sock", "http://localhost/", true},
+		{"HTTPS+UNIX:///tmp/app.sock:/health", "/tmp/app.sock", "https://localhost/health", true},
+		{"http://example.com/path", "", "http://example.com/path", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			socketPath, httpURL, ok := SplitUnixSocketURL(tt.url)
+			if socketPath != tt.socketPath || httpURL != tt.httpURL || ok != tt.ok {
+				t.Errorf("Expected (%q, %q, %v), got (%q, %q, %v)", tt.socketPath, tt.httpURL, tt.ok, socketPath, httpURL, ok)
+			}
+		})
+	}
+}
+
+// TestUnixSocket_Request tests that requests are dialed over the socket with the HTTP path intact
+func TestUnixSocket_Request(t *testing.T) {
+	socketPath := newUnixServer(t, pathHandler)
+
+	req := &types.HttpRequest{Method: "GET", URL: "http+unix://" + socketPath + ":/containers/json?all=1"}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Error != "" {
+		t.Fatalf("Expected no error in result, got: %s", result.Error)
+	}
+	if result.Body != "localhost /containers/json?all=1" {
+		t.Errorf("Unexpected body: %q", result.Body)
+	}
+	if !strings.HasPrefix(req.URL, "http+unix://") {
+		t.Errorf("Expected original request URL to be untouched, got %s", req.URL)
+	}
+}
+
+// TestUnixSocket_H2C tests cleartext HTTP/2 over a unix socket
+func TestUnixSocket_H2C(t *testing.T) {
+	socketPath := newUnixServer(t, h2c.NewHandler(protoHandler, &http2.Server{}))
+
+	req := &types.HttpRequest{Method: "GET", URL: "http+unix://" + socketPath + ":/", HTTPVersion: HTTPVersionH2C}
+	result, err := Execute(req, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Protocol != "HTTP/2.0" {
+		t.Errorf("Expected HTTP/2.0, got %s (error: %s)", result.Protocol, result.Error)
+	}
+}
+
+// TestUnixSocket_TLS tests https+unix with the request TLS configuration
+func TestUnixSocket_TLS(t *testing.T) {
+	socketPath := startUnixServer(t, protoHandler, true)
+	tlsConfig := &types.TLSConfig{InsecureSkipVerify: true}
+
+	for _, version := range []string{HTTPVersionAuto, HTTPVersionHTTP2} {
+		t.Run(version, func(t *testing.T) {
+			req := &types.HttpRequest{Method: "GET", URL: "https+unix://" + socketPath + ":/", HTTPVersion: version}
+			result, err := Execute(req, tlsConfig, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result.Error != "" {
+				t.Fatalf("Expected no error in result, got: %s", result.Error)
+			}
+			if result.Status != 200 {
+				t.Errorf("Expected status 200, got %d", result.Status)
+			}
+		})
+	}
+}
+
+// TestUnixSocket_Errors tests missing sockets and paths that are not sockets
+func TestUnixSocket_Errors(t *testing.T) {
+	regularFile := filepath.Join(t.TempDir(), "file.sock")
+	os.WriteFile(regularFile, []byte("x"), 0644)
+
+	tests := []struct {
+		url     string
+		errPart string
+	}{
+		{"http+unix:///nonexistent/restcli.sock:/", "unix socket not found"},
+		{"http+unix://" + regularFile + ":/", "not a unix socket"},
+		{"http+unix://:/path", "missing socket path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.errPart, func(t *testing.T) {
+			_, err := Execute(&types.HttpRequest{Method: "GET", URL: tt.url}, nil, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+				t.Errorf("Expected error containing %q, got: %v", tt.errPart, err)
+			}
+		})
+	}
+}
@@ -33,6 +33,12 @@ func ExecuteWebSocket(ctx context.Context, req *types.WebSocketRequest, tlsConfi
 		return result, nil
 	}
 
+	responder, err := NewAutoResponder(req.Rules, req.MaxAutoReplies)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+
 	// Build WebSocket dialer with TLS config if needed
 	dialer := &websocket.Dialer{
 		Proxy:            http.ProxyFromEnvironment,
@@ -141,6 +147,17 @@ func ExecuteWebSocket(ctx context.Context, req *types.WebSocketRequest, tlsConfi
 				// Optional: validate received message matches expected
 				// For now, we just record it
 
+				// Scripted conversation: answer messages matching a rule
+				for _, msg := range autoReply(conn, responder, nil, receivedMsg) {
+					if msg.Direction == "sent" {
+						result.SentCount++
+					}
+					result.Messages = append(result.Messages, msg)
+					if callback != nil {
+						callback(&msg, false)
+					}
+				}
+
 			case err := <-receiveErrChan:
 				timer.Stop()
 				result.Error = fmt.Sprintf("Receive error: %v", err)
@@ -269,12 +286,18 @@ func buildWebSocketTLSConfig(tlsConfig *types.TLSConfig) (*tls.Config, error) {
 		config.RootCAs = caCertPool
 	}
 
+	if err := PinCertificate(config, tlsConfig.PinnedCertSHA256); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
 // ExecuteWebSocketInteractive establishes a persistent WebSocket connection
-// and listens for messages to send via sendChan
-func ExecuteWebSocketInteractive(ctx context.Context, url string, headers map[string]string, subprotocols []string, tlsConfig *types.TLSConfig, resolver *parser.VariableResolver, sendChan <-chan string, callback types.WebSocketCallback) error {
+// and listens for messages to send via sendChan. With a keepalive, a peer that stops
+// answering pings ends the session with ErrKeepaliveTimeout. Received messages matching
+// a rule of the responder (nil for none) are answered automatically.
+func ExecuteWebSocketInteractive(ctx context.Context, url string, headers map[string]string, subprotocols []string, tlsConfig *types.TLSConfig, keepalive WebSocketKeepalive, responder *AutoResponder, resolver *parser.VariableResolver, sendChan <-chan string, callback types.WebSocketCallback) error {
 	startTime := time.Now()
 
 	// Build WebSocket dialer
@@ -323,6 +346,10 @@ func ExecuteWebSocketInteractive(ctx context.Context, url string, headers map[st
 		callback(connectMsg, false)
 	}
 
+	// Ping the peer so dead connections behind proxies are noticed, stopped before the connection closes
+	keepaliveErrChan, stopKeepalive := startKeepalive(ctx, conn, keepalive)
+	defer stopKeepalive()
+
 	// Channels for coordination
 	receiveChan := make(chan types.ReceivedMessage, 100)
 	receiveErrChan := make(chan error, 1)
@@ -403,6 +430,13 @@ func ExecuteWebSocketInteractive(ctx context.Context, url string, headers map[st
 				callback(&receivedMsg, false)
 			}
 
+			// Scripted conversation: answer messages matching a rule
+			for _, msg := range autoReply(conn, responder, resolver, receivedMsg) {
+				if callback != nil {
+					callback(&msg, false)
+				}
+			}
+
 		case err := <-receiveErrChan:
 			// Error receiving from WebSocket
 			if callback != nil {
@@ -416,6 +450,19 @@ func ExecuteWebSocketInteractive(ctx context.Context, url string, headers map[st
 			}
 			return err
 
+		case err := <-keepaliveErrChan:
+			// Peer stopped answering pings
+			if callback != nil {
+				lostMsg := &types.ReceivedMessage{
+					Type:      "system",
+					Content:   fmt.Sprintf("Connection lost: %v", err),
+					Timestamp: time.Now().Format(time.RFC3339),
+					Direction: "system",
+				}
+				callback(lostMsg, false)
+			}
+			return err
+
 		case <-done:
 			// Receive goroutine finished (connection closed)
 			duration := time.Since(startTime).Milliseconds()
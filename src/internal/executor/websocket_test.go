@@ -657,7 +657,7 @@ func TestExecuteWebSocketInteractive_BasicFunctionality(t *testing.T) {
 	// Start interactive session
 	errChan := make(chan error, 1)
 	go func() {
-		err := ExecuteWebSocketInteractive(ctx, wsURL, map[string]string{}, []string{}, nil, nil, sendChan, callback)
+		err := ExecuteWebSocketInteractive(ctx, wsURL, map[string]string{}, []string{}, nil, WebSocketKeepalive{}, nil, nil, sendChan, callback)
 		errChan <- err
 	}()
 
@@ -731,7 +731,7 @@ func TestExecuteWebSocketInteractive_ContextCancellation(t *testing.T) {
 	// Start interactive session
 	errChan := make(chan error, 1)
 	go func() {
-		err := ExecuteWebSocketInteractive(ctx, wsURL, map[string]string{}, []string{}, nil, nil, sendChan, callback)
+		err := ExecuteWebSocketInteractive(ctx, wsURL, map[string]string{}, []string{}, nil, WebSocketKeepalive{}, nil, nil, sendChan, callback)
 		errChan <- err
 	}()
 
@@ -848,7 +848,7 @@ func TestExecuteWebSocketInteractive_EmptyMessage(t *testing.T) {
 
 	errChan := make(chan error, 1)
 	go func() {
-		err := ExecuteWebSocketInteractive(ctx, wsURL, map[string]string{}, []string{}, nil, nil, sendChan, callback)
+		err := ExecuteWebSocketInteractive(ctx, wsURL, map[string]string{}, []string{}, nil, WebSocketKeepalive{}, nil, nil, sendChan, callback)
 		errChan <- err
 	}()
 
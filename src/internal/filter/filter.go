@@ -5,12 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/itchyny/gojq"
 	"github.com/jmespath/go-jmespath"
 	"github.com/studiowebux/restcli/internal/types"
 )
@@ -20,6 +22,9 @@ const (
 	QueryShellTimeout = 30 * time.Second
 )
 
+// JqPrefix marks an expression evaluated with jq instead of JMESPath (e.g. jq:.items[] | .name)
+const JqPrefix = "jq:"
+
 var (
 	// Shell command pattern: $(command)
 	shellPattern = regexp.MustCompile(`^\$\((.+)\)$`)
@@ -29,41 +34,132 @@ var (
 // Filter narrows results (e.g., items[?status==`active`])
 // Query transforms/selects fields (e.g., [].name)
 // If query starts with $(...), it's executed as a bash command with body piped to stdin
+// Filter and query starting with jq: are evaluated with jq, and with xpath: as XPath on an XML body
+// Either can chain stages with | (e.g., data.items | $(jq 'length')), evaluated left to right
 func Apply(body string, filter string, query string) (string, error) {
+	return ApplyContext(context.Background(), body, filter, query)
+}
+
+// ApplyContext is Apply with a context; cancelling it stops running shell and jq stages
+func ApplyContext(ctx context.Context, body string, filter string, query string) (string, error) {
 	result := body
 
 	// Apply filter first (if specified)
 	if filter != "" {
-		filtered, err := applyJMESPath(result, filter)
+		filtered, err := applyPipeline(ctx, result, filter, "filter")
 		if err != nil {
-			return "", fmt.Errorf("failed to apply filter: %w", err)
+			return "", err
 		}
 		result = filtered
 	}
 
-	// Apply query (can be JMESPath or shell command)
+	// Apply query (can be JMESPath, jq, XPath or shell command)
 	if query != "" {
-		// Check if it's a shell command
-		if matches := shellPattern.FindStringSubmatch(query); len(matches) > 1 {
-			command := matches[1]
-			queried, err := executeShellCommand(result, command)
-			if err != nil {
-				return "", fmt.Errorf("failed to execute query shell command: %w", err)
-			}
-			result = queried
-		} else {
-			// Apply as JMESPath query
-			queried, err := applyJMESPath(result, query)
-			if err != nil {
-				return "", fmt.Errorf("failed to apply query: %w", err)
+		queried, err := applyPipeline(ctx, result, query, "query")
+		if err != nil {
+			return "", err
+		}
+		result = queried
+	}
+
+	return result, nil
+}
+
+// applyPipeline evaluates each stage of an expression, feeding the output of one stage to the next
+func applyPipeline(ctx context.Context, body string, expression string, role string) (string, error) {
+	stages := SplitPipeline(expression)
+	result := body
+
+	for i, stage := range stages {
+		output, err := applyStage(ctx, result, stage, role)
+		if err != nil {
+			if len(stages) > 1 {
+				return "", fmt.Errorf("stage %d: %w", i+1, err)
 			}
-			result = queried
+			return "", err
 		}
+		result = output
 	}
 
 	return result, nil
 }
 
+// applyStage evaluates a single stage with the engine selected by its prefix
+func applyStage(ctx context.Context, body string, stage string, role string) (string, error) {
+	if matches := shellPattern.FindStringSubmatch(stage); len(matches) > 1 {
+		output, err := executeShellCommand(ctx, body, matches[1])
+		if err != nil {
+			return "", fmt.Errorf("failed to execute %s shell command: %w", role, err)
+		}
+		return output, nil
+	}
+
+	if IsJq(stage) {
+		output, err := applyJq(ctx, body, strings.TrimPrefix(stage, JqPrefix))
+		if err != nil {
+			return "", fmt.Errorf("failed to apply jq %s: %w", role, err)
+		}
+		return output, nil
+	}
+
+	if IsXPath(stage) {
+		output, err := applyXPath(body, strings.TrimPrefix(stage, XPathPrefix))
+		if err != nil {
+			return "", fmt.Errorf("failed to apply XPath %s: %w", role, err)
+		}
+		return output, nil
+	}
+
+	output, err := applyJMESPath(body, stage)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply %s: %w", role, err)
+	}
+	return output, nil
+}
+
+// SplitPipeline splits an expression into stages at top-level pipes
+// JMESPath and jq have their own pipe operator, so a pipe only separates stages
+// when it follows a $(...) stage or precedes a $(...), jq: or xpath: stage
+func SplitPipeline(expression string) []string {
+	var stages []string
+	var quote rune
+	depth := 0
+	start := 0
+
+	for i, r := range expression {
+		switch {
+		case quote != 0:
+			if r == quote && expression[i-1] != '\\' {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case r == '|' && depth == 0:
+			// || is the JMESPath or-expression
+			if strings.HasPrefix(expression[i:], "||") || (i > 0 && expression[i-1] == '|') {
+				continue
+			}
+			current := strings.TrimSpace(expression[start:i])
+			next := strings.TrimSpace(expression[i+1:])
+			if current != "" && (IsShellCommand(current) || startsStage(next)) {
+				stages = append(stages, current)
+				start = i + 1
+			}
+		}
+	}
+
+	return append(stages, strings.TrimSpace(expression[start:]))
+}
+
+// startsStage reports whether an expression starts with a non-JMESPath stage
+func startsStage(expression string) bool {
+	return strings.HasPrefix(expression, "$(") || IsJq(expression) || IsXPath(expression)
+}
+
 // applyJMESPath applies a JMESPath expression to a JSON string
 func applyJMESPath(jsonStr string, expression string) (string, error) {
 	// Parse the JSON
@@ -98,14 +194,84 @@ func applyJMESPath(jsonStr string, expression string) (string, error) {
 	return string(output), nil
 }
 
+// applyJq evaluates a jq expression against a JSON string with gojq (no jq binary needed)
+// A single output is returned as-is; several outputs are collected into an array.
+// A body holding several JSON values runs the expression on each, like jq does.
+func applyJq(ctx context.Context, jsonStr string, expression string) (string, error) {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return "", fmt.Errorf("jq expression '%s' is invalid: %w", expression, err)
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return "", fmt.Errorf("jq expression '%s' is invalid: %w", expression, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryShellTimeout)
+	defer cancel()
+
+	// Numbers are decoded as json.Number so large integers keep their precision
+	decoder := json.NewDecoder(strings.NewReader(jsonStr))
+	decoder.UseNumber()
+
+	var outputs []interface{}
+	for {
+		var input interface{}
+		if err := decoder.Decode(&input); err == io.EOF {
+			break
+		} else if err != nil {
+			return "", fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		iter := code.RunWithContext(ctx, input)
+		for {
+			value, ok := iter.Next()
+			if !ok {
+				break
+			}
+			if err, isErr := value.(error); isErr {
+				errMsg := err.Error()
+				if ctxErr := stoppedReason(ctx); ctxErr != "" {
+					errMsg = ctxErr
+				}
+				return "", fmt.Errorf("jq expression '%s' failed: %s", expression, errMsg)
+			}
+			outputs = append(outputs, value)
+		}
+	}
+
+	var result interface{}
+	switch len(outputs) {
+	case 0:
+		return "null", nil
+	case 1:
+		result = outputs[0]
+	default:
+		result = outputs
+	}
+
+	// Keep <, > and & readable like jq does
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return "", fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return strings.TrimSuffix(output.String(), "\n"), nil
+}
+
 // executeShellCommand executes a shell command with the body piped to stdin
-func executeShellCommand(body string, command string) (string, error) {
+// The command is killed when ctx is cancelled or QueryShellTimeout elapses
+func executeShellCommand(ctx context.Context, body string, command string) (string, error) {
 	// Execute with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), QueryShellTimeout)
+	ctx, cancel := context.WithTimeout(ctx, QueryShellTimeout)
 	defer cancel()
 
 	// Use sh -c to execute the command
 	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	// Don't wait on children of sh that still hold stdout once the command is killed
+	cmd.WaitDelay = time.Second
 
 	// Pipe body to stdin
 	cmd.Stdin = strings.NewReader(body)
@@ -120,18 +286,37 @@ func executeShellCommand(body string, command string) (string, error) {
 		if stderr.Len() > 0 {
 			errMsg = strings.TrimSpace(stderr.String())
 		}
+		if ctxErr := stoppedReason(ctx); ctxErr != "" {
+			errMsg = ctxErr
+		}
 		return "", fmt.Errorf("command '%s' failed: %s", command, errMsg)
 	}
 
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// stoppedReason describes why a command context ended early, or returns "" if it did not
+func stoppedReason(ctx context.Context) string {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return fmt.Sprintf("timed out after %s", QueryShellTimeout)
+	case context.Canceled:
+		return "cancelled"
+	}
+	return ""
+}
+
 // IsValidJMESPath checks if an expression is valid JMESPath syntax
 func IsValidJMESPath(expression string) bool {
 	_, err := jmespath.Compile(expression)
 	return err == nil
 }
 
+// IsJq checks if an expression is a jq expression (starts with jq:)
+func IsJq(expression string) bool {
+	return strings.HasPrefix(expression, JqPrefix)
+}
+
 // IsShellCommand checks if a query is a shell command (starts with $(...))
 func IsShellCommand(query string) bool {
 	return shellPattern.MatchString(query)
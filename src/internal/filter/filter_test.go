@@ -0,0 +1,137 @@
+package filter
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApply_Jq(t *testing.T) {
+	body := `{"items": [{"name": "a", "price": 150}, {"name": "b", "price": 50}]}`
+
+	tests := []struct {
+		name   string
+		filter string
+		query  string
+		want   string
+	}{
+		{"single output", "", "jq:.items[0].name", `"a"`},
+		{"stream becomes array", "", "jq:.items[] | .name", "[\n  \"a\",\n  \"b\"\n]"},
+		{"jq filter then JMESPath query", "jq:.items | map(select(.price > 100))", "[].name", "[\n  \"a\"\n]"},
+		{"object keys are sorted", "", `jq:{z: 1, a: 2}`, "{\n  \"a\": 2,\n  \"z\": 1\n}"},
+		{"no output", "", "jq:empty", "null"},
+		{"large integers keep their precision", "", "jq:9007199254740993", "9007199254740993"},
+		{"markup is not escaped", "", `jq:"<a & b>"`, `"<a & b>"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply(body, tt.filter, tt.query)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Apply(body, "", "jq:.items["); err == nil || !strings.Contains(err.Error(), "jq query") || !strings.Contains(err.Error(), "is invalid") {
+		t.Errorf("Expected a jq parse error, got %v", err)
+	}
+	if _, err := Apply(body, "", `jq:error("boom")`); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected the jq runtime error, got %v", err)
+	}
+	if _, err := Apply(body, "", "jq:.items[0].name | ascii_downcase | undefined_fn"); err == nil || !strings.Contains(err.Error(), "undefined_fn") {
+		t.Errorf("Expected an undefined function error, got %v", err)
+	}
+}
+
+func TestApply_JqStream(t *testing.T) {
+	// Each value of a stream (e.g. NDJSON) is an input, like with the jq binary
+	got, err := Apply("{\"id\": 1}\n{\"id\": 2}\n", "", "jq:.id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != "[\n  1,\n  2\n]" {
+		t.Errorf("Expected both ids, got %q", got)
+	}
+
+	// JMESPath stays the default
+	if got, err := Apply(`{"a": 1}`, "", "a"); err != nil || got != "1" {
+		t.Errorf("Expected the JMESPath result, got %q (%v)", got, err)
+	}
+}
+
+func TestSplitPipeline(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"items[].name", []string{"items[].name"}},
+		{"items[*] | [0]", []string{"items[*] | [0]"}},
+		{"a || b", []string{"a || b"}},
+		{"jq:.items[] | .name", []string{"jq:.items[] | .name"}},
+		{"data.items | $(jq 'length')", []string{"data.items", "$(jq 'length')"}},
+		{"data | jq:.items | length", []string{"data", "jq:.items | length"}},
+		{"$(sort | uniq) | length(@)", []string{"$(sort | uniq)", "length(@)"}},
+		{"items[?name == '|$(x)'] | [0]", []string{"items[?name == '|$(x)'] | [0]"}},
+	}
+
+	for _, tt := range tests {
+		got := SplitPipeline(tt.expr)
+		if strings.Join(got, "\x00") != strings.Join(tt.want, "\x00") {
+			t.Errorf("SplitPipeline(%q) = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestApply_Pipeline(t *testing.T) {
+	body := `{"data": {"items": ["b", "a", "b"]}}`
+
+	got, err := Apply(body, "", "data.items | $(tr -d ' \\n[]\"' | tr ',' '\\n' | sort -u | tr '\\n' ' ')")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != "a b" {
+		t.Errorf("Apply() = %q, want %q", got, "a b")
+	}
+
+	got, err = Apply(body, "data", `$(cat) | items[0]`)
+	if err != nil || got != `"b"` {
+		t.Errorf("Expected the shell output to feed the JMESPath stage, got %q (%v)", got, err)
+	}
+
+	if _, err := Apply(body, "", "data.items | $(exit 3)"); err == nil || !strings.Contains(err.Error(), "stage 2") {
+		t.Errorf("Expected a stage 2 error, got %v", err)
+	}
+}
+
+func TestApplyContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := ApplyContext(ctx, `{}`, "", "$(sleep 10)")
+	if err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Errorf("Expected a cancelled error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the shell stage to stop on cancel, took %s", elapsed)
+	}
+}
+
+func TestApplyContext_JqCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := ApplyContext(ctx, `{}`, "", "jq:last(repeat(1))")
+	if err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Errorf("Expected a cancelled error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the jq stage to stop on cancel, took %s", elapsed)
+	}
+}
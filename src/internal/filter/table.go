@@ -0,0 +1,147 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ValueColumn is the column used for array elements that are not objects
+const ValueColumn = "value"
+
+// Table is a JSON array of objects laid out as rows and columns
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// ToTable converts a JSON array into a table
+// Columns are the union of the element keys in order of appearance; nested objects
+// are flattened with dotted keys, nested arrays are kept as compact JSON, and missing keys are blank
+func ToTable(body string) (*Table, error) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal([]byte(strings.TrimSpace(body)), &elements); err != nil {
+		return nil, fmt.Errorf("response is not a JSON array")
+	}
+
+	table := &Table{}
+	columnIndex := make(map[string]int)
+	records := make([]map[string]string, 0, len(elements))
+
+	for _, element := range elements {
+		record := make(map[string]string)
+		var keys []string
+
+		if isJSONObject(element) {
+			if err := flattenObject(element, "", record, &keys); err != nil {
+				return nil, err
+			}
+		} else {
+			record[ValueColumn] = cellValue(element)
+			keys = append(keys, ValueColumn)
+		}
+
+		for _, key := range keys {
+			if _, ok := columnIndex[key]; !ok {
+				columnIndex[key] = len(table.Columns)
+				table.Columns = append(table.Columns, key)
+			}
+		}
+		records = append(records, record)
+	}
+
+	for _, record := range records {
+		row := make([]string, len(table.Columns))
+		for key, value := range record {
+			row[columnIndex[key]] = value
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table, nil
+}
+
+// Delimited renders the table with a header row, separated by sep (',' for CSV, '\t' for TSV)
+func (t *Table) Delimited(sep rune) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = sep
+
+	if len(t.Columns) > 0 {
+		if err := writer.Write(t.Columns); err != nil {
+			return "", fmt.Errorf("failed to write header row: %w", err)
+		}
+	}
+	if err := writer.WriteAll(t.Rows); err != nil {
+		return "", fmt.Errorf("failed to write rows: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// flattenObject records the leaves of a JSON object under dotted keys, keeping the key order
+func flattenObject(raw json.RawMessage, prefix string, record map[string]string, keys *[]string) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+
+	// Opening brace
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("invalid JSON object: %w", err)
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("invalid JSON object: %w", err)
+		}
+		key, _ := token.(string)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("invalid JSON value for %s: %w", key, err)
+		}
+
+		if isJSONObject(value) {
+			if err := flattenObject(value, key, record, keys); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, seen := record[key]; !seen {
+			*keys = append(*keys, key)
+		}
+		record[key] = cellValue(value)
+	}
+
+	return nil
+}
+
+// isJSONObject reports whether a raw JSON value is an object
+func isJSONObject(raw json.RawMessage) bool {
+	return strings.HasPrefix(strings.TrimSpace(string(raw)), "{")
+}
+
+// cellValue renders a JSON leaf as table text: strings unquoted, null blank, arrays as compact JSON
+func cellValue(raw json.RawMessage) string {
+	trimmed := strings.TrimSpace(string(raw))
+	switch {
+	case trimmed == "null":
+		return ""
+	case strings.HasPrefix(trimmed, `"`):
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+	case strings.HasPrefix(trimmed, "["):
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err == nil {
+			return compact.String()
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,62 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToTable(t *testing.T) {
+	body := `[
+		{"id": 1, "name": "Alice", "address": {"city": "Paris", "geo": {"lat": 48.8}}, "tags": ["a", "b"]},
+		{"id": 2, "name": "Bob, Jr.", "active": true, "address": null},
+		"loose"
+	]`
+
+	table, err := ToTable(body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantColumns := []string{"id", "name", "address.city", "address.geo.lat", "tags", "active", "address", "value"}
+	if !reflect.DeepEqual(table.Columns, wantColumns) {
+		t.Fatalf("Columns = %q, want %q", table.Columns, wantColumns)
+	}
+	wantRows := [][]string{
+		{"1", "Alice", "Paris", "48.8", `["a","b"]`, "", "", ""},
+		{"2", "Bob, Jr.", "", "", "", "true", "", ""},
+		{"", "", "", "", "", "", "", "loose"},
+	}
+	if !reflect.DeepEqual(table.Rows, wantRows) {
+		t.Errorf("Rows = %q, want %q", table.Rows, wantRows)
+	}
+
+	csv, err := table.Delimited(',')
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := "id,name,address.city,address.geo.lat,tags,active,address,value\n" +
+		"1,Alice,Paris,48.8,\"[\"\"a\"\",\"\"b\"\"]\",,,\n" +
+		"2,\"Bob, Jr.\",,,,true,,\n" +
+		",,,,,,,loose\n"
+	if csv != want {
+		t.Errorf("Delimited(',') = %q, want %q", csv, want)
+	}
+
+	tsv, _ := table.Delimited('\t')
+	if tsv[:len("id\tname\t")] != "id\tname\t" {
+		t.Errorf("Expected a tab separated header, got %q", tsv)
+	}
+}
+
+func TestToTable_NotAnArray(t *testing.T) {
+	for _, body := range []string{`{"items": []}`, "plain text", ""} {
+		if _, err := ToTable(body); err == nil {
+			t.Errorf("ToTable(%q): expected an error", body)
+		}
+	}
+
+	table, err := ToTable("[]")
+	if err != nil || len(table.Columns) != 0 || len(table.Rows) != 0 {
+		t.Errorf("Expected an empty table, got %+v (%v)", table, err)
+	}
+}
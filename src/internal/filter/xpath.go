@@ -0,0 +1,141 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// XPathPrefix marks an expression evaluated as XPath against an XML body (e.g. xpath://user/@id)
+const XPathPrefix = "xpath:"
+
+// IsXPath checks if an expression is an XPath expression (starts with xpath:)
+func IsXPath(expression string) bool {
+	return strings.HasPrefix(expression, XPathPrefix)
+}
+
+// applyXPath evaluates an XPath 1.0 expression against an XML string
+// Elements are rendered as XML and attributes or text as plain values, one result per line.
+// Expressions returning a number, string or boolean (e.g. count(//user)) give that value.
+func applyXPath(body string, expression string) (string, error) {
+	doc, err := xmlquery.Parse(strings.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("invalid XML: %w", err)
+	}
+	if doc.SelectElement("*") == nil {
+		return "", fmt.Errorf("invalid XML: no root element")
+	}
+
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return "", fmt.Errorf("XPath expression cannot be empty")
+	}
+	expr, err := xpath.Compile(expression)
+	if err != nil {
+		return "", fmt.Errorf("invalid XPath expression: %w", err)
+	}
+
+	var results []string
+	switch value := expr.Evaluate(xmlquery.CreateXPathNavigator(doc)).(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case *xpath.NodeIterator:
+		for value.MoveNext() {
+			if result, ok := renderXPathNode(value.Current().(*xmlquery.NodeNavigator)); ok {
+				results = append(results, result)
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		return "", fmt.Errorf("XPath expression '%s' matched no nodes", expression)
+	}
+	return strings.Join(results, "\n"), nil
+}
+
+// renderXPathNode renders a selected node, ok is false for whitespace-only text
+func renderXPathNode(nav *xmlquery.NodeNavigator) (string, bool) {
+	switch nav.NodeType() {
+	case xpath.ElementNode:
+		var sb strings.Builder
+		writeXMLNode(&sb, nav.Current(), "")
+		return strings.TrimRight(sb.String(), "\n"), true
+	case xpath.RootNode:
+		// The document is rendered as its root element
+		var sb strings.Builder
+		for child := nav.Current().FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == xmlquery.ElementNode {
+				writeXMLNode(&sb, child, "")
+			}
+		}
+		return strings.TrimRight(sb.String(), "\n"), true
+	case xpath.TextNode:
+		text := strings.TrimSpace(nav.Value())
+		return text, text != ""
+	default:
+		return nav.Value(), true
+	}
+}
+
+// qualifiedName returns a node name with its namespace prefix
+func qualifiedName(prefix, name string) string {
+	if prefix != "" {
+		return prefix + ":" + name
+	}
+	return name
+}
+
+// writeXMLNode serializes an element with two spaces of indentation per level
+// Elements containing only text stay on one line
+func writeXMLNode(sb *strings.Builder, node *xmlquery.Node, indent string) {
+	name := qualifiedName(node.Prefix, node.Data)
+	sb.WriteString(indent + "<" + name)
+	for _, attr := range node.Attr {
+		sb.WriteString(" " + qualifiedName(attr.Name.Space, attr.Name.Local) + `="` + escapeXML(attr.Value) + `"`)
+	}
+
+	hasElements := false
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == xmlquery.ElementNode {
+			hasElements = true
+			break
+		}
+	}
+
+	text := strings.TrimSpace(node.InnerText())
+	switch {
+	case !hasElements && text == "":
+		sb.WriteString("/>\n")
+	case !hasElements:
+		sb.WriteString(">" + escapeXML(text) + "</" + name + ">\n")
+	default:
+		sb.WriteString(">\n")
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			switch child.Type {
+			case xmlquery.ElementNode:
+				writeXMLNode(sb, child, indent+"  ")
+			case xmlquery.TextNode, xmlquery.CharDataNode:
+				if trimmed := strings.TrimSpace(child.Data); trimmed != "" {
+					sb.WriteString(indent + "  " + escapeXML(trimmed) + "\n")
+				}
+			}
+		}
+		sb.WriteString(indent + "</" + name + ">\n")
+	}
+}
+
+// escapeXML escapes text for use in element content or attribute values
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,81 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+)
+
+const usersXML = `<?xml version="1.0"?>
+<users xmlns:a="urn:a">
+  <user id="1" role="admin">
+    <name>Alice</name>
+    <a:email>alice@example.com</a:email>
+  </user>
+  <user id="2" role="member" op="a!=b">
+    <name>Bob</name>
+  </user>
+  <group><user id="3"><name>Carol</name></user></group>
+</users>`
+
+func TestApplyXPath(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"//user/@id", "1\n2\n3"},
+		{"/users/user/@id", "1\n2"},
+		{"users/user[2]/name/text()", "Bob"},
+		{"//user[@role='admin']/name", "<name>Alice</name>"},
+		{"//user[@role!='admin']/@id", "2"},
+		{"//user[name='Carol']/@id", "3"},
+		{"//user[last()]/@id", "2\n3"},
+		{"//user[a:email]/@id", "1"},
+		{"//user[contains(name,'o')]/@id", "2\n3"},
+		{"//a:email", "<a:email>alice@example.com</a:email>"},
+		{"//*[local-name()='email']/text()", "alice@example.com"},
+		{"//user[@op='a!=b']/@id", "2"},
+		{"//user[position() > 1]/@id", "2"},
+		{"//name[.='Bob']/ancestor::users/group/user/@id", "3"},
+		{"//user[@id='1']/following-sibling::user/name/text()", "Bob"},
+		{"string(//user[@id='3']/name)", "Carol"},
+		{"sum(//user/@id)", "6"},
+		{"boolean(//group)", "true"},
+		{"//group/*", "<user id=\"3\">\n  <name>Carol</name>\n</user>"},
+		{"//name[.='Bob']/../@role", "member"},
+		{"count(//user)", "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := Apply(usersXML, "", XPathPrefix+tt.expr)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyXPath_Errors(t *testing.T) {
+	tests := []struct {
+		body string
+		expr string
+		want string
+	}{
+		{`{"json": true}`, "//user", "invalid XML"},
+		{`<a><b></a>`, "//a", "invalid XML"},
+		{usersXML, "//nobody", "matched no nodes"},
+		{usersXML, "//user[@id='1'", "invalid XPath expression"},
+		{usersXML, "//@id/name", "matched no nodes"},
+		{usersXML, "//email", "matched no nodes"},
+	}
+
+	for _, tt := range tests {
+		_, err := Apply(tt.body, "", XPathPrefix+tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.expr, tt.want, err)
+		}
+	}
+}
@@ -32,6 +32,7 @@ func Save(requestFile string, req *types.HttpRequest, result *types.RequestResul
 		RequestSize:        result.RequestSize,
 		ResponseSize:       result.ResponseSize,
 		Error:              result.Error,
+		Redirects:          result.Redirects,
 	}
 
 	// Generate filename: {requestBaseName}_{timestamp}.json
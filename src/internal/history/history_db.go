@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -62,12 +63,17 @@ func (m *Manager) Save(requestFile string, profileName string, req *types.HttpRe
 		return fmt.Errorf("failed to marshal response headers: %w", err)
 	}
 
+	redirectsJSON, err := marshalRedirects(result.Redirects)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO history (
 			timestamp, request_file, request_name, method, url, headers, body,
 			response_status, response_status_text, response_headers, response_body,
-			duration_ms, request_size, response_size, error, profile_name
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			duration_ms, request_size, response_size, error, profile_name, redirects
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	// Format timestamp for SQLite in local time
@@ -90,6 +96,7 @@ func (m *Manager) Save(requestFile string, profileName string, req *types.HttpRe
 		result.ResponseSize,
 		result.Error,
 		profileName,
+		redirectsJSON,
 	)
 
 	if err != nil {
@@ -103,7 +110,7 @@ func (m *Manager) Load(profileName string) ([]types.HistoryEntry, error) {
 	query := `
 		SELECT id, timestamp, request_file, request_name, method, url, headers, body,
 		       response_status, response_status_text, response_headers, response_body,
-		       duration_ms, request_size, response_size, error, profile_name
+		       duration_ms, request_size, response_size, error, profile_name, note, tags, redirects
 		FROM history
 		WHERE profile_name = ?
 		ORDER BY timestamp DESC
@@ -126,7 +133,7 @@ func (m *Manager) LoadForFile(requestFile string) ([]types.HistoryEntry, error)
 	query := `
 		SELECT id, timestamp, request_file, request_name, method, url, headers, body,
 		       response_status, response_status_text, response_headers, response_body,
-		       duration_ms, request_size, response_size, error, profile_name
+		       duration_ms, request_size, response_size, error, profile_name, note, tags, redirects
 		FROM history
 		WHERE request_file LIKE ?
 		ORDER BY timestamp DESC
@@ -165,6 +172,9 @@ func (m *Manager) scanEntries(rows *sql.Rows) ([]types.HistoryEntry, error) {
 		var responseSize sql.NullInt64
 		var errorMsg sql.NullString
 		var profileName string
+		var note string
+		var tags string
+		var redirectsJSON string
 
 		err := rows.Scan(
 			&id,
@@ -184,6 +194,9 @@ func (m *Manager) scanEntries(rows *sql.Rows) ([]types.HistoryEntry, error) {
 			&responseSize,
 			&errorMsg,
 			&profileName,
+			&note,
+			&tags,
+			&redirectsJSON,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan history entry: %w", err)
@@ -201,6 +214,12 @@ func (m *Manager) scanEntries(rows *sql.Rows) ([]types.HistoryEntry, error) {
 			responseHeaders = make(map[string]string)
 		}
 
+		// Deserialize redirect hops (empty for entries without redirects)
+		var redirects []types.RedirectHop
+		if redirectsJSON != "" {
+			_ = json.Unmarshal([]byte(redirectsJSON), &redirects)
+		}
+
 		// Parse timestamp as local time
 		parsedTime, err := time.ParseInLocation("2006-01-02 15:04:05", timestamp, time.Local)
 		if err != nil {
@@ -213,6 +232,7 @@ func (m *Manager) scanEntries(rows *sql.Rows) ([]types.HistoryEntry, error) {
 		}
 
 		entry := types.HistoryEntry{
+			ID:                 id,
 			Timestamp:          parsedTime.Format(time.RFC3339),
 			RequestFile:        requestFile,
 			RequestName:        requestName.String,
@@ -228,6 +248,9 @@ func (m *Manager) scanEntries(rows *sql.Rows) ([]types.HistoryEntry, error) {
 			RequestSize:        int(requestSize.Int64),
 			ResponseSize:       int(responseSize.Int64),
 			Error:              errorMsg.String,
+			Note:               note,
+			Tags:               splitTags(tags),
+			Redirects:          redirects,
 		}
 
 		entries = append(entries, entry)
@@ -244,6 +267,44 @@ func (m *Manager) Clear() error {
 	return nil
 }
 
+// ClearUntagged deletes the profile's entries that have neither a note nor tags
+func (m *Manager) ClearUntagged(profileName string) error {
+	_, err := m.db.Exec("DELETE FROM history WHERE profile_name = ? AND note = '' AND tags = ''", profileName)
+	if err != nil {
+		return fmt.Errorf("failed to clear untagged history: %w", err)
+	}
+	return nil
+}
+
+// Annotate replaces the note and tags of an entry
+func (m *Manager) Annotate(id int64, note string, tags []string) error {
+	_, err := m.db.Exec("UPDATE history SET note = ?, tags = ? WHERE id = ?", note, strings.Join(tags, ","), id)
+	if err != nil {
+		return fmt.Errorf("failed to annotate history entry: %w", err)
+	}
+	return nil
+}
+
+// marshalRedirects serializes redirect hops for the redirects column ("" when there are none)
+func marshalRedirects(hops []types.RedirectHop) (string, error) {
+	if len(hops) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(hops)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal redirects: %w", err)
+	}
+	return string(data), nil
+}
+
+// splitTags parses the comma-separated tags column
+func splitTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	return strings.Split(tags, ",")
+}
+
 func (m *Manager) Delete(id int64) error {
 	_, err := m.db.Exec("DELETE FROM history WHERE id = ?", id)
 	if err != nil {
@@ -366,12 +427,17 @@ func (m *Manager) insertHistoryEntry(entry types.HistoryEntry) error {
 		return fmt.Errorf("failed to marshal response headers: %w", err)
 	}
 
+	redirectsJSON, err := marshalRedirects(entry.Redirects)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO history (
 			timestamp, request_file, request_name, method, url, headers, body,
 			response_status, response_status_text, response_headers, response_body,
-			duration_ms, request_size, response_size, error
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			duration_ms, request_size, response_size, error, redirects
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	// Parse RFC3339 timestamp and convert to SQLite format
@@ -397,6 +463,7 @@ func (m *Manager) insertHistoryEntry(entry types.HistoryEntry) error {
 		entry.RequestSize,
 		entry.ResponseSize,
 		entry.Error,
+		redirectsJSON,
 	)
 
 	if err != nil {
@@ -49,6 +49,8 @@ const (
 	ActionGoToTopPrepare   Action = "go_to_top_prepare"  // First 'g' in 'gg' sequence
 	ActionScrollUp         Action = "scroll_up"          // Scroll viewport up
 	ActionScrollDown       Action = "scroll_down"        // Scroll viewport down
+	ActionScrollLeft       Action = "scroll_left"        // Scroll viewport left
+	ActionScrollRight      Action = "scroll_right"       // Scroll viewport right
 
 	// Focus and panel switching
 	ActionSwitchFocus      Action = "switch_focus"       // Switch focus between panels
@@ -86,26 +88,50 @@ const (
 	ActionRenameFile       Action = "rename_file"        // Rename file
 	ActionCreateFile       Action = "create_file"        // Create new file
 	ActionRefreshFiles     Action = "refresh_files"      // Refresh file list
+	ActionSaveRequest      Action = "save_request"       // Save request back to its file (with confirm)
+	ActionEditRequest      Action = "edit_request"       // Edit the request inline and save it to its file
+	ActionRunFilteredFiles Action = "run_filtered_files" // Run every request of the category-filtered files
+	ActionNextRequest      Action = "next_request"       // Select the next request of the current file
+	ActionPrevRequest      Action = "prev_request"       // Select the previous request of the current file
+	ActionToggleWatch      Action = "toggle_watch"       // Re-run the request whenever its file is saved
+	ActionParentDirectory  Action = "parent_directory"   // Go up one folder in the tree view
+	ActionToggleTreeView   Action = "toggle_tree_view"   // Switch between the folder tree and the flat file list
 
 	// Response operations (Normal mode)
 	ActionSaveResponse     Action = "save_response"      // Save response to file
 	ActionCopyToClipboard  Action = "copy_to_clipboard"  // Copy response to clipboard
+	ActionCopyAsCurl       Action = "copy_as_curl"       // Copy request as a curl command
+	ActionOpenCopyAs       Action = "open_copy_as"       // Choose a format to copy the request or response as
 	ActionToggleBody       Action = "toggle_body"        // Toggle body visibility
 	ActionToggleHeaders    Action = "toggle_headers"     // Toggle headers visibility
 	ActionToggleFullscreen Action = "toggle_fullscreen"  // Toggle fullscreen mode
+	ActionToggleNotes      Action = "toggle_notes"       // Show the notes pane of the current file
+	ActionEditNotes        Action = "edit_notes"         // Edit the notes of the current file
 	ActionPinResponse      Action = "pin_response"       // Pin response for comparison
 	ActionShowDiff         Action = "show_diff"          // Show diff with pinned response
 	ActionFilterResponse   Action = "filter_response"    // Filter response with JMESPath
+	ActionSaveToVariable   Action = "save_to_variable"   // Save the (filtered) response to a session variable
+	ActionToggleJSONTree   Action = "toggle_json_tree"   // Toggle collapsible JSON tree view
+	ActionToggleTableView  Action = "toggle_table_view"  // Toggle table view for JSON arrays
+	ActionShowFullBody     Action = "show_full_body"     // Render a truncated body in full
+	ActionToggleHexView    Action = "toggle_hex_view"    // Toggle hex dump of the response body
+	ActionDownloadResponse Action = "download_response"  // Execute and stream the body to a file
+	ActionNextResponseTab  Action = "next_response_tab"  // Switch to the next response tab
+	ActionPrevResponseTab  Action = "prev_response_tab"  // Switch to the previous response tab
+	ActionCloseResponseTab Action = "close_response_tab" // Close the active response tab
 	ActionOpenErrorDetail  Action = "open_error_detail"  // Open error detail modal
 	ActionOpenBodyOverride Action = "open_body_override" // Open body override editor
 
 	// Modal launchers (Normal mode)
 	ActionOpenInspect       Action = "open_inspect"        // Open request inspector
 	ActionOpenVariables     Action = "open_variables"      // Open variable editor
+	ActionOpenVarSwitcher   Action = "open_var_switcher"   // Fuzzy find a variable or environment to switch
 	ActionOpenHeaders       Action = "open_headers"        // Open header editor
 	ActionOpenInteractive   Action = "open_interactive"    // Open interactive variables
 	ActionOpenProfiles      Action = "open_profiles"       // Open profile switcher
+	ActionCycleEnvironment  Action = "cycle_environment"   // Switch to the next environment of the profile
 	ActionOpenRecentFiles   Action = "open_recent_files"   // Open MRU list
+	ActionOpenFileFinder    Action = "open_file_finder"    // Fuzzy find a file in the workdir tree
 	ActionOpenHistory       Action = "open_history"        // Open history browser
 	ActionOpenAnalytics     Action = "open_analytics"      // Open analytics viewer
 	ActionOpenStressTest    Action = "open_stress_test"    // Open stress test config
@@ -117,10 +143,16 @@ const (
 	ActionOpenOAuth         Action = "open_oauth"          // Open OAuth config
 	ActionOpenOAuthDetail   Action = "open_oauth_detail"   // Open OAuth detail
 	ActionOpenConfigView    Action = "open_config_view"    // Open config viewer
+	ActionOpenCookies       Action = "open_cookies"        // Open cookie jar viewer
+	ActionOpenJWT           Action = "open_jwt"            // Decode the JWT of the Authorization header or a variable
 	ActionOpenDocumentation Action = "open_documentation"  // Open documentation
 	ActionOpenGoto          Action = "open_goto"           // Open goto line input
 	ActionOpenSearch        Action = "open_search"         // Open search input
 
+	// Macros (Normal mode)
+	ActionMacroRecord Action = "macro_record" // Start or stop recording a macro
+	ActionMacroReplay Action = "macro_replay" // Replay a recorded macro
+
 	// Variable editor actions
 	ActionVarAdd      Action = "var_add"      // Add variable
 	ActionVarEdit     Action = "var_edit"     // Edit variable
@@ -138,24 +170,37 @@ const (
 	ActionProfileCreate    Action = "profile_create"    // Create new profile
 	ActionProfileDuplicate Action = "profile_duplicate" // Duplicate profile
 	ActionProfileDelete    Action = "profile_delete"    // Delete profile
+	ActionProfileExport    Action = "profile_export"    // Export profile to a shareable file
+	ActionProfileImport    Action = "profile_import"    // Import profile from a shared file
 
 	// History actions
 	ActionHistoryExecute   Action = "history_execute"   // Execute from history
 	ActionHistoryRollback  Action = "history_rollback"  // Rollback history
+	ActionHistoryEdit      Action = "history_edit"      // Edit the request of an entry, then replay it
 	ActionHistoryPaginate  Action = "history_paginate"  // Paginate history
 	ActionHistoryClear     Action = "history_clear"     // Clear history
+	ActionHistoryDiff      Action = "history_diff"      // Mark entry for diff, or diff with the marked entry
+	ActionHistoryAnnotate  Action = "history_annotate"  // Edit the note and tags of an entry
+	ActionHistoryAnnotated Action = "history_annotated" // Show only entries with a note or tags
+	ActionHistoryPrune     Action = "history_prune"     // Clear entries without a note or tags
 
 	// Analytics actions
-	ActionAnalyticsPaginate Action = "analytics_paginate" // Paginate analytics
-	ActionAnalyticsClear    Action = "analytics_clear"    // Clear analytics
+	ActionAnalyticsPaginate   Action = "analytics_paginate"    // Paginate analytics
+	ActionAnalyticsClear      Action = "analytics_clear"       // Clear analytics
+	ActionAnalyticsExport     Action = "analytics_export"      // Export analytics to CSV
+	ActionAnalyticsExportJSON Action = "analytics_export_json" // Export analytics to JSON
+	ActionAnalyticsChart      Action = "analytics_chart"       // Toggle the time-series chart
+	ActionAnalyticsBucket     Action = "analytics_bucket"      // Switch chart bucket (hour/day)
+	ActionAnalyticsRange      Action = "analytics_range"       // Cycle chart date range
 
 	// Stress test actions
-	ActionStressTestStart  Action = "stress_test_start"  // Start stress test
-	ActionStressTestStop   Action = "stress_test_stop"   // Stop stress test
-	ActionStressTestSave   Action = "stress_test_save"   // Save stress test config
-	ActionStressTestLoad   Action = "stress_test_load"   // Load stress test config
-	ActionStressTestDelete Action = "stress_test_delete" // Delete stress test result
-	ActionStressTestExport Action = "stress_test_export" // Export stress test result
+	ActionStressTestStart   Action = "stress_test_start"   // Start stress test
+	ActionStressTestStop    Action = "stress_test_stop"    // Stop stress test
+	ActionStressTestSave    Action = "stress_test_save"    // Save stress test config
+	ActionStressTestLoad    Action = "stress_test_load"    // Load stress test config
+	ActionStressTestDelete  Action = "stress_test_delete"  // Delete stress test result
+	ActionStressTestExport  Action = "stress_test_export"  // Export stress test result
+	ActionStressTestCompare Action = "stress_test_compare" // Compare two stress test runs
 
 	// WebSocket actions
 	ActionWSConnect      Action = "ws_connect"       // Connect to WebSocket
@@ -207,14 +252,37 @@ func GetActionInfo(action Action) ActionInfo {
 		ActionGoToBottom:       {ActionGoToBottom, "Go to bottom", "Navigation"},
 		ActionExecute:          {ActionExecute, "Execute request", "File Operations"},
 		ActionOpenEditor:       {ActionOpenEditor, "Open in editor", "File Operations"},
+		ActionSaveRequest:      {ActionSaveRequest, "Save request to file", "File Operations"},
+		ActionEditRequest:      {ActionEditRequest, "Edit request inline", "File Operations"},
+		ActionRunFilteredFiles: {ActionRunFilteredFiles, "Run filtered files", "File Operations"},
+		ActionToggleWatch:      {ActionToggleWatch, "Toggle watch mode", "File Operations"},
+		ActionNextRequest:      {ActionNextRequest, "Next request in file", "File Operations"},
+		ActionPrevRequest:      {ActionPrevRequest, "Previous request in file", "File Operations"},
 		ActionSaveResponse:     {ActionSaveResponse, "Save response", "Response"},
 		ActionCopyToClipboard:  {ActionCopyToClipboard, "Copy to clipboard", "Response"},
+		ActionCopyAsCurl:       {ActionCopyAsCurl, "Copy as cURL", "Response"},
+		ActionOpenCopyAs:       {ActionOpenCopyAs, "Copy as...", "Response"},
+		ActionSaveToVariable:   {ActionSaveToVariable, "Save to session variable", "Response"},
 		ActionToggleBody:       {ActionToggleBody, "Toggle body", "Response"},
 		ActionToggleHeaders:    {ActionToggleHeaders, "Toggle headers", "Response"},
+		ActionToggleJSONTree:   {ActionToggleJSONTree, "Toggle JSON tree", "Response"},
+		ActionToggleTableView:  {ActionToggleTableView, "Toggle table view", "Response"},
+		ActionShowFullBody:     {ActionShowFullBody, "Show full body", "Response"},
+		ActionToggleHexView:    {ActionToggleHexView, "Toggle hex view", "Response"},
+		ActionDownloadResponse: {ActionDownloadResponse, "Download response to file", "Response"},
+		ActionNextResponseTab:  {ActionNextResponseTab, "Next response tab", "Response"},
+		ActionPrevResponseTab:  {ActionPrevResponseTab, "Previous response tab", "Response"},
+		ActionCloseResponseTab: {ActionCloseResponseTab, "Close response tab", "Response"},
 		ActionToggleFullscreen: {ActionToggleFullscreen, "Toggle fullscreen", "View"},
+		ActionToggleNotes:      {ActionToggleNotes, "Toggle notes pane", "View"},
+		ActionEditNotes:        {ActionEditNotes, "Edit file notes", "View"},
 		ActionOpenVariables:    {ActionOpenVariables, "Open variables", "Editors"},
+		ActionOpenVarSwitcher:  {ActionOpenVarSwitcher, "Quick variable switcher", "Editors"},
 		ActionOpenHeaders:      {ActionOpenHeaders, "Open headers", "Editors"},
+		ActionCycleEnvironment: {ActionCycleEnvironment, "Switch environment", "Profiles"},
 		ActionOpenHelp:         {ActionOpenHelp, "Open help", "Information"},
+		ActionMacroRecord:      {ActionMacroRecord, "Record macro", "Macros"},
+		ActionMacroReplay:      {ActionMacroReplay, "Replay macro", "Macros"},
 		// ... add more as needed
 	}
 
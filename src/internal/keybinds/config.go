@@ -62,33 +62,35 @@ func SaveConfig(config *Config, path string) error {
 	return os.WriteFile(path, data, 0644)
 }
 
+// sections maps each named config section to its context
+func (c *Config) sections() map[Context]*map[string]string {
+	return map[Context]*map[string]string{
+		ContextGlobal:        &c.Global,
+		ContextNormal:        &c.Normal,
+		ContextSearch:        &c.Search,
+		ContextGoto:          &c.Goto,
+		ContextVariableList:  &c.Variables,
+		ContextHeaderList:    &c.Headers,
+		ContextProfileList:   &c.Profiles,
+		ContextDocumentation: &c.Documentation,
+		ContextHistory:       &c.History,
+		ContextAnalytics:     &c.Analytics,
+		ContextStressTest:    &c.StressTest,
+		ContextHelp:          &c.Help,
+		ContextInspect:       &c.Inspect,
+		ContextWebSocket:     &c.WebSocket,
+		ContextModal:         &c.Modal,
+		ContextTextInput:     &c.TextInput,
+		ContextConfirm:       &c.Confirm,
+	}
+}
+
 // ApplyConfig applies user configuration to a registry
 // User bindings override default bindings
 func ApplyConfig(registry *Registry, config *Config) error {
-	// Map config sections to contexts
-	contextMappings := map[Context]map[string]string{
-		ContextGlobal:        config.Global,
-		ContextNormal:        config.Normal,
-		ContextSearch:        config.Search,
-		ContextGoto:          config.Goto,
-		ContextVariableList:  config.Variables,
-		ContextHeaderList:    config.Headers,
-		ContextProfileList:   config.Profiles,
-		ContextDocumentation: config.Documentation,
-		ContextHistory:       config.History,
-		ContextAnalytics:     config.Analytics,
-		ContextStressTest:    config.StressTest,
-		ContextHelp:          config.Help,
-		ContextInspect:       config.Inspect,
-		ContextWebSocket:     config.WebSocket,
-		ContextModal:         config.Modal,
-		ContextTextInput:     config.TextInput,
-		ContextConfirm:       config.Confirm,
-	}
-
 	// Apply each context's bindings
-	for context, bindings := range contextMappings {
-		for key, actionStr := range bindings {
+	for context, bindings := range config.sections() {
+		for key, actionStr := range *bindings {
 			action := Action(actionStr)
 			// Validate action exists (optional, could skip for flexibility)
 			registry.Register(context, key, action)
@@ -107,6 +109,46 @@ func ApplyConfig(registry *Registry, config *Config) error {
 	return nil
 }
 
+// Export returns every active binding (defaults and overrides) as a config
+// Contexts without a named section (e.g. viewer) are written under custom
+func (r *Registry) Export() *Config {
+	config := &Config{Version: "1.0"}
+	sections := config.sections()
+
+	for context, contextBindings := range r.bindings {
+		if len(contextBindings) == 0 {
+			continue
+		}
+		exported := make(map[string]string, len(contextBindings))
+		for key, action := range contextBindings {
+			exported[key] = string(action)
+		}
+
+		if section, ok := sections[context]; ok {
+			*section = exported
+			continue
+		}
+		if config.Custom == nil {
+			config.Custom = make(map[string]map[string]string)
+		}
+		config.Custom[string(context)] = exported
+	}
+
+	return config
+}
+
+// Count returns the number of bindings in the config
+func (c *Config) Count() int {
+	count := 0
+	for _, bindings := range c.sections() {
+		count += len(*bindings)
+	}
+	for _, bindings := range c.Custom {
+		count += len(bindings)
+	}
+	return count
+}
+
 // LoadOrDefault loads user config if it exists, otherwise returns default registry
 func LoadOrDefault(configPath string) (*Registry, error) {
 	// Start with defaults
@@ -129,6 +171,28 @@ func LoadOrDefault(configPath string) (*Registry, error) {
 	return registry, nil
 }
 
+// LoadLayered builds a registry from the defaults, the global config and an optional profile config
+// Precedence: profile overrides > global config > defaults
+// Reserved keys are restored on the merged result and reported as warnings
+func LoadLayered(globalPath, profilePath string) (*Registry, []ValidationError, error) {
+	registry, err := LoadOrDefault(globalPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if profilePath != "" {
+		config, err := LoadConfig(profilePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load profile keybinds: %w", err)
+		}
+		if err := ApplyConfig(registry, config); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply profile keybinds: %w", err)
+		}
+	}
+
+	return registry, NewValidator().EnforceReservedKeys(registry), nil
+}
+
 // ExportDefaults exports default keybindings as a config file
 // Useful for users to see what can be customized
 func ExportDefaults() *Config {
@@ -184,25 +248,34 @@ func CreateExampleConfig(path string) error {
 			"tab": "switch_focus",
 
 			// File operations
-			"q":     "quit",
-			"enter": "execute",
-			"x":     "open_editor",
-			"X":     "configure_editor",
-			"d":     "duplicate_file",
-			"D":     "delete_file",
-			"R":     "rename_file",
-			"F":     "create_file",
-			"r":     "refresh_files",
+			"q":      "quit",
+			"enter":  "execute",
+			"x":      "open_editor",
+			"X":      "configure_editor",
+			"d":      "duplicate_file",
+			"D":      "delete_file",
+			"R":      "rename_file",
+			"F":      "create_file",
+			"r":      "refresh_files",
+			"ctrl+s": "save_request",
+			"ctrl+e": "run_filtered_files",
 
 			// Response operations
-			"s": "save_response",
-			"c": "copy_to_clipboard",
-			"b": "toggle_body",
-			"B": "toggle_headers",
-			"f": "toggle_fullscreen",
-			"w": "pin_response",
-			"W": "show_diff",
-			"J": "filter_response",
+			"s":      "save_response",
+			"c":      "copy_to_clipboard",
+			"Y":      "copy_as_curl",
+			"V":      "save_to_variable",
+			"b":      "toggle_body",
+			"B":      "toggle_headers",
+			"f":      "toggle_fullscreen",
+			"w":      "pin_response",
+			"W":      "show_diff",
+			"J":      "filter_response",
+			"z":      "toggle_json_tree",
+			"Z":      "toggle_table_view",
+			"]":      "next_response_tab",
+			"[":      "prev_response_tab",
+			"ctrl+w": "close_response_tab",
 
 			// Modal launchers
 			"i": "open_inspect",
@@ -0,0 +1,107 @@
+package keybinds
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistry_ExportRoundTrip(t *testing.T) {
+	original := NewDefaultRegistry()
+	if err := ApplyConfig(original, &Config{
+		Normal: map[string]string{"gh": string(ActionOpenHistory), "e": string(ActionOpenEditor)},
+		Custom: map[string]map[string]string{"my_modal": {"z": string(ActionCloseModal)}},
+	}); err != nil {
+		t.Fatalf("ApplyConfig failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "keybinds.json")
+	if err := SaveConfig(original.Export(), path); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	// Reload on an empty registry so every binding has to come from the export
+	reloaded := NewRegistry()
+	if err := ApplyConfig(reloaded, config); err != nil {
+		t.Fatalf("ApplyConfig failed: %v", err)
+	}
+
+	if len(reloaded.bindings) != len(original.bindings) {
+		t.Errorf("Expected %d contexts, got %d", len(original.bindings), len(reloaded.bindings))
+	}
+	for context, contextBindings := range original.bindings {
+		if len(reloaded.bindings[context]) != len(contextBindings) {
+			t.Errorf("Context %s: expected %d bindings, got %d", context, len(contextBindings), len(reloaded.bindings[context]))
+		}
+		for key := range contextBindings {
+			want, wantOK := original.Match(context, key)
+			got, gotOK := reloaded.Match(context, key)
+			if got != want || gotOK != wantOK {
+				t.Errorf("Context %s key %q: got %q, want %q", context, key, got, want)
+			}
+		}
+	}
+
+	if config.Count() != original.Export().Count() {
+		t.Errorf("Expected %d exported bindings, got %d", original.Export().Count(), config.Count())
+	}
+}
+
+func TestRegistry_ExportSections(t *testing.T) {
+	config := NewDefaultRegistry().Export()
+
+	if config.Global["ctrl+c"] != string(ActionQuitForce) {
+		t.Errorf("Expected ctrl+c in the global section, got %v", config.Global)
+	}
+	if config.Normal["gg"] != string(ActionGoToTop) {
+		t.Errorf("Expected gg in the normal section, got %v", config.Normal["gg"])
+	}
+	if _, ok := config.Custom[string(ContextViewer)]; !ok {
+		t.Error("Expected contexts without a section to be exported under custom")
+	}
+}
+
+func TestLoadLayered_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	globalPath := filepath.Join(dir, "keybinds.json")
+	profilePath := filepath.Join(dir, "project-keybinds.json")
+
+	if err := SaveConfig(&Config{Normal: map[string]string{"x": string(ActionOpenHistory), "e": string(ActionOpenHelp)}}, globalPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveConfig(&Config{
+		Global: map[string]string{"ctrl+c": string(ActionQuit)},
+		Normal: map[string]string{"x": string(ActionOpenVariables)},
+	}, profilePath); err != nil {
+		t.Fatal(err)
+	}
+
+	registry, reserved, err := LoadLayered(globalPath, profilePath)
+	if err != nil {
+		t.Fatalf("LoadLayered failed: %v", err)
+	}
+
+	if action, _ := registry.Match(ContextNormal, "x"); action != ActionOpenVariables {
+		t.Errorf("Expected the profile override, got %q", action)
+	}
+	if action, _ := registry.Match(ContextNormal, "e"); action != ActionOpenHelp {
+		t.Errorf("Expected the global override, got %q", action)
+	}
+	if action, _ := registry.Match(ContextNormal, "G"); action != ActionGoToBottom {
+		t.Errorf("Expected the default binding, got %q", action)
+	}
+
+	if action, _ := registry.Match(ContextGlobal, "ctrl+c"); action != ActionQuitForce {
+		t.Errorf("Expected ctrl+c to stay quit_force, got %q", action)
+	}
+	if len(reserved) != 1 || reserved[0].Key != "ctrl+c" {
+		t.Errorf("Expected the reserved key to be reported, got %+v", reserved)
+	}
+
+	if _, _, err := LoadLayered(globalPath, filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing profile keybinds file")
+	}
+}
@@ -100,25 +100,49 @@ func registerNormalModeBindings(r *Registry) {
 	r.Register(ContextNormal, "R", ActionRenameFile)
 	r.Register(ContextNormal, "F", ActionCreateFile)
 	r.Register(ContextNormal, "r", ActionRefreshFiles)
+	r.Register(ContextNormal, "ctrl+s", ActionSaveRequest)
+	r.Register(ContextNormal, "u", ActionEditRequest)
+	r.Register(ContextNormal, "ctrl+e", ActionRunFilteredFiles)
+	r.Register(ContextNormal, "}", ActionNextRequest)
+	r.Register(ContextNormal, "{", ActionPrevRequest)
+	r.Register(ContextNormal, "L", ActionToggleWatch)
+	r.RegisterMultiple(ContextNormal, []string{"-", "backspace"}, ActionParentDirectory)
+	r.Register(ContextNormal, "ctrl+l", ActionToggleTreeView)
 
 	// Response operations
 	r.Register(ContextNormal, "s", ActionSaveResponse)
 	r.Register(ContextNormal, "c", ActionCopyToClipboard)
+	r.Register(ContextNormal, "Y", ActionCopyAsCurl)
+	r.Register(ContextNormal, "ctrl+y", ActionOpenCopyAs)
 	r.Register(ContextNormal, "b", ActionToggleBody)
 	r.Register(ContextNormal, "B", ActionToggleHeaders)
 	r.Register(ContextNormal, "f", ActionToggleFullscreen)
+	r.Register(ContextNormal, "ctrl+g", ActionToggleNotes)
+	r.Register(ContextNormal, "a", ActionEditNotes)
 	r.Register(ContextNormal, "w", ActionPinResponse)
 	r.Register(ContextNormal, "W", ActionShowDiff)
 	r.Register(ContextNormal, "J", ActionFilterResponse)
+	r.Register(ContextNormal, "V", ActionSaveToVariable)
+	r.Register(ContextNormal, "z", ActionToggleJSONTree)
+	r.Register(ContextNormal, "Z", ActionToggleTableView)
+	r.Register(ContextNormal, "U", ActionShowFullBody)
+	r.Register(ContextNormal, "ctrl+b", ActionToggleHexView)
+	r.Register(ContextNormal, "ctrl+o", ActionDownloadResponse)
+	r.Register(ContextNormal, "]", ActionNextResponseTab)
+	r.Register(ContextNormal, "[", ActionPrevResponseTab)
+	r.Register(ContextNormal, "ctrl+w", ActionCloseResponseTab)
 
 	// Modal launchers
 	r.Register(ContextNormal, "v", ActionOpenVariables)
+	r.Register(ContextNormal, "ctrl+k", ActionOpenVarSwitcher)
 	r.Register(ContextNormal, "h", ActionOpenHeaders)
 	r.Register(ContextNormal, "e", ActionOpenErrorDetail)
 	r.Register(ContextNormal, "E", ActionOpenBodyOverride)
 	r.Register(ContextNormal, "I", ActionShowStatusDetail)
 	r.Register(ContextNormal, "p", ActionOpenProfiles)
+	r.Register(ContextNormal, "ctrl+n", ActionCycleEnvironment)
 	r.Register(ContextNormal, "ctrl+p", ActionOpenRecentFiles)
+	r.Register(ContextNormal, "ctrl+f", ActionOpenFileFinder)
 	r.Register(ContextNormal, "H", ActionOpenHistory)
 	r.Register(ContextNormal, "A", ActionOpenAnalytics)
 	r.Register(ContextNormal, "S", ActionOpenStressTest)
@@ -130,10 +154,14 @@ func registerNormalModeBindings(r *Registry) {
 	r.Register(ContextNormal, "o", ActionOpenOAuth)
 	r.Register(ContextNormal, "O", ActionOpenOAuthDetail)
 	r.Register(ContextNormal, "C", ActionOpenConfigView)
+	r.Register(ContextNormal, "K", ActionOpenCookies)
+	r.Register(ContextNormal, "ctrl+t", ActionOpenJWT)
 	r.Register(ContextNormal, "m", ActionOpenDocumentation)
 	r.Register(ContextNormal, "n", ActionSearchNext)
 	r.Register(ContextNormal, "N", ActionSearchPrevious)
 	r.Register(ContextNormal, "ctrl+r", ActionRefresh)
+	r.Register(ContextNormal, "Q", ActionMacroRecord)
+	r.Register(ContextNormal, "@", ActionMacroReplay)
 	r.Register(ContextNormal, "P", ActionNoOp) // openProfilesInEditor - handled specially
 	r.Register(ContextNormal, "ctrl+x", ActionNoOp) // openSessionInEditor - handled specially
 }
@@ -217,6 +245,8 @@ func registerProfileBindings(r *Registry) {
 	r.Register(ContextProfileList, "n", ActionProfileCreate)
 	r.Register(ContextProfileList, "d", ActionProfileDuplicate)
 	r.Register(ContextProfileList, "D", ActionProfileDelete)
+	r.Register(ContextProfileList, "x", ActionProfileExport)
+	r.Register(ContextProfileList, "i", ActionProfileImport)
 
 	// Profile edit mode uses text input
 	r.Register(ContextProfileEdit, "esc", ActionTextCancel)
@@ -250,8 +280,13 @@ func registerHistoryBindings(r *Registry) {
 	r.Register(ContextHistory, "/", ActionOpenSearch)
 	r.Register(ContextHistory, "enter", ActionHistoryExecute)
 	r.Register(ContextHistory, "r", ActionHistoryRollback)
+	r.Register(ContextHistory, "e", ActionHistoryEdit)
 	r.Register(ContextHistory, "p", ActionHistoryPaginate)
 	r.Register(ContextHistory, "C", ActionHistoryClear)
+	r.Register(ContextHistory, "d", ActionHistoryDiff)
+	r.Register(ContextHistory, "a", ActionHistoryAnnotate)
+	r.Register(ContextHistory, "b", ActionHistoryAnnotated)
+	r.Register(ContextHistory, "U", ActionHistoryPrune)
 	r.Register(ContextHistory, "pgup", ActionPageUp)
 	r.Register(ContextHistory, "pgdown", ActionPageDown)
 	r.Register(ContextHistory, "ctrl+u", ActionHalfPageUp)
@@ -273,6 +308,13 @@ func registerAnalyticsBindings(r *Registry) {
 	r.Register(ContextAnalytics, "p", ActionAnalyticsPaginate)
 	r.Register(ContextAnalytics, "t", ActionOpenTagFilter)
 	r.Register(ContextAnalytics, "C", ActionAnalyticsClear)
+	r.Register(ContextAnalytics, "x", ActionAnalyticsExport)
+	r.Register(ContextAnalytics, "X", ActionAnalyticsExportJSON)
+	r.Register(ContextAnalytics, "c", ActionAnalyticsChart)
+	r.Register(ContextAnalytics, "b", ActionAnalyticsBucket)
+	r.Register(ContextAnalytics, "r", ActionAnalyticsRange)
+	r.RegisterMultiple(ContextAnalytics, []string{"left", "h"}, ActionScrollLeft)
+	r.RegisterMultiple(ContextAnalytics, []string{"right", "l"}, ActionScrollRight)
 	r.Register(ContextAnalytics, "pgup", ActionPageUp)
 	r.Register(ContextAnalytics, "pgdown", ActionPageDown)
 	r.Register(ContextAnalytics, "ctrl+u", ActionHalfPageUp)
@@ -301,6 +343,7 @@ func registerStressTestBindings(r *Registry) {
 	r.Register(ContextStressTest, "home", ActionTextMoveHome)
 	r.Register(ContextStressTest, "end", ActionTextMoveEnd)
 	r.Register(ContextStressTest, "d", ActionStressTestDelete)
+	r.Register(ContextStressTest, "c", ActionStressTestCompare)
 	r.Register(ContextStressTest, "l", ActionStressTestLoad)
 	r.Register(ContextStressTest, "r", ActionRefresh)
 }
@@ -2,9 +2,13 @@ package keybinds
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
+// leaderKeys start multi-key sequences (like the first 'g' in 'gg')
+var leaderKeys = map[string]bool{"g": true}
+
 // Binding represents a keybinding mapping
 type Binding struct {
 	Key     string
@@ -19,6 +23,9 @@ type Registry struct {
 
 	// multiKeyState tracks multi-key sequences (like 'gg' in vim)
 	multiKeyState map[Context]string
+
+	// lastPartial is the context of the most recent partial match
+	lastPartial Context
 }
 
 // NewRegistry creates a new keybinding registry
@@ -88,9 +95,10 @@ func (r *Registry) MatchMultiKey(context Context, key string) (Action, bool, boo
 	}
 
 	// Check if this key could start a sequence (currently only 'g' for 'gg')
-	if key == "g" {
+	if r.IsLeader(key) {
 		// Mark this as a potential multi-key start
 		r.multiKeyState[context] = key
+		r.lastPartial = context
 		return "", false, true // Partial match
 	}
 
@@ -104,6 +112,39 @@ func (r *Registry) ClearMultiKeyState(context Context) {
 	delete(r.multiKeyState, context)
 }
 
+// IsLeader reports whether key starts a multi-key sequence
+func (r *Registry) IsLeader(key string) bool {
+	return leaderKeys[key]
+}
+
+// PendingSequence returns the context and prefix of the multi-key sequence waiting for its next key
+func (r *Registry) PendingSequence() (Context, string, bool) {
+	prefix, ok := r.multiKeyState[r.lastPartial]
+	return r.lastPartial, prefix, ok
+}
+
+// Completions returns the bindings that complete a multi-key prefix, sorted by key
+// Context bindings take precedence over global bindings for the same key
+func (r *Registry) Completions(context Context, prefix string) []Binding {
+	found := make(map[string]Binding)
+	for _, ctx := range []Context{ContextGlobal, context} {
+		for key, action := range r.bindings[ctx] {
+			if len(key) > len(prefix) && strings.HasPrefix(key, prefix) {
+				found[key] = Binding{Key: key, Action: action, Context: ctx}
+			}
+		}
+	}
+
+	completions := make([]Binding, 0, len(found))
+	for _, binding := range found {
+		completions = append(completions, binding)
+	}
+	sort.Slice(completions, func(i, j int) bool {
+		return completions[i].Key < completions[j].Key
+	})
+	return completions
+}
+
 // GetBinding returns the key(s) bound to an action in a context
 func (r *Registry) GetBinding(context Context, action Action) []string {
 	var keys []string
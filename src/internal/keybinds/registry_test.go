@@ -0,0 +1,54 @@
+package keybinds
+
+import "testing"
+
+func TestRegistry_Completions(t *testing.T) {
+	r := NewRegistry()
+	r.Register(ContextGlobal, "gh", ActionOpenHelp)
+	r.Register(ContextNormal, "g", ActionGoToTopPrepare)
+	r.Register(ContextNormal, "gg", ActionGoToTop)
+	r.Register(ContextNormal, "gh", ActionOpenHistory)
+	r.Register(ContextNormal, "G", ActionGoToBottom)
+	r.Register(ContextHistory, "gv", ActionOpenVariables)
+
+	completions := r.Completions(ContextNormal, "g")
+	if len(completions) != 2 {
+		t.Fatalf("Expected 2 completions, got %+v", completions)
+	}
+	if completions[0].Key != "gg" || completions[0].Action != ActionGoToTop {
+		t.Errorf("Expected gg first, got %+v", completions[0])
+	}
+	if completions[1].Key != "gh" || completions[1].Action != ActionOpenHistory || completions[1].Context != ContextNormal {
+		t.Errorf("Expected context binding to override global gh, got %+v", completions[1])
+	}
+
+	if completions := r.Completions(ContextInspect, "g"); len(completions) != 1 || completions[0].Action != ActionOpenHelp {
+		t.Errorf("Expected only the global completion, got %+v", completions)
+	}
+	if completions := r.Completions(ContextNormal, "x"); len(completions) != 0 {
+		t.Errorf("Expected no completions, got %+v", completions)
+	}
+}
+
+func TestRegistry_PendingSequence(t *testing.T) {
+	r := NewDefaultRegistry()
+
+	if _, _, ok := r.PendingSequence(); ok {
+		t.Fatal("Expected no pending sequence")
+	}
+
+	if _, _, partial := r.MatchMultiKey(ContextHistory, "g"); !partial {
+		t.Fatal("Expected g to be a partial match")
+	}
+	context, prefix, ok := r.PendingSequence()
+	if !ok || context != ContextHistory || prefix != "g" {
+		t.Errorf("Expected pending g in history, got %q %q %v", context, prefix, ok)
+	}
+
+	if action, ok, _ := r.MatchMultiKey(ContextHistory, "g"); !ok || action != ActionGoToTop {
+		t.Errorf("Expected gg to go to top, got %q", action)
+	}
+	if _, _, ok := r.PendingSequence(); ok {
+		t.Error("Expected the sequence to be complete")
+	}
+}
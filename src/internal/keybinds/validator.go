@@ -182,6 +182,24 @@ func (v *Validator) checkReservedKeys(registry *Registry, result *ValidationResu
 	}
 }
 
+// EnforceReservedKeys restores the default action of rebound reserved keys
+// Returns the bindings that were overridden
+func (v *Validator) EnforceReservedKeys(registry *Registry) []ValidationError {
+	result := &ValidationResult{}
+	v.checkReservedKeys(registry, result)
+	if len(result.Warnings) == 0 {
+		return nil
+	}
+
+	defaults := NewDefaultRegistry()
+	for _, warning := range result.Warnings {
+		if action, ok := defaults.Match(warning.Context, warning.Key); ok {
+			registry.Register(warning.Context, warning.Key, action)
+		}
+	}
+	return result.Warnings
+}
+
 // checkMultiKeySequences checks for ambiguous multi-key sequences
 func (v *Validator) checkMultiKeySequences(registry *Registry, result *ValidationResult) {
 	for _, bindings := range registry.bindings {
@@ -250,6 +268,138 @@ func FindConflicts(config *Config) []string {
 	return conflicts
 }
 
+// KeySuggestion is a free key offered to resolve a conflicting binding
+type KeySuggestion struct {
+	Context Context
+	Key     string // The conflicting key
+	Suggest string // A free key in the same family
+	Reason  string // "case", "modifier", "letter" or "sequence"
+}
+
+// unreliableKeys are ctrl combinations most terminals cannot tell apart from other keys
+var unreliableKeys = map[string]bool{
+	"ctrl+h": true, // backspace
+	"ctrl+i": true, // tab
+	"ctrl+j": true, // enter
+	"ctrl+m": true, // enter
+}
+
+// SuggestKeys returns up to limit free keys in the same family as key in context
+// Case and modifier variants of the key come first, then other free keys with the same modifier
+// Returns nil when every candidate is taken
+func (v *Validator) SuggestKeys(registry *Registry, context Context, key string, limit int) []KeySuggestion {
+	modifier, base := splitModifier(key)
+
+	var candidates []KeySuggestion
+	add := func(candidate, reason string) {
+		candidates = append(candidates, KeySuggestion{Context: context, Key: key, Suggest: candidate, Reason: reason})
+	}
+
+	if modifier == "" && len(key) == 2 && registry.IsLeader(key[:1]) {
+		// Multi-key sequences stay behind the same leader
+		for c := 'a'; c <= 'z'; c++ {
+			add(key[:1]+string(c), "sequence")
+		}
+		return v.freeSuggestions(registry, context, key, candidates, limit)
+	}
+
+	if isLetter(base) && modifier == "" {
+		add(swapCase(base), "case")
+	}
+	for _, variant := range []string{"", "ctrl+", "alt+"} {
+		if variant != modifier && (variant != "" || modifier == "ctrl+" || modifier == "alt+") {
+			add(variant+strings.ToLower(base), "modifier")
+		}
+	}
+	if isLetter(base) {
+		for c := 'a'; c <= 'z'; c++ {
+			add(modifier+string(c), "letter")
+		}
+		if modifier == "" {
+			for c := 'A'; c <= 'Z'; c++ {
+				add(string(c), "letter")
+			}
+		}
+	}
+
+	return v.freeSuggestions(registry, context, key, candidates, limit)
+}
+
+// freeSuggestions keeps the first limit candidates that are free in context
+func (v *Validator) freeSuggestions(registry *Registry, context Context, key string, candidates []KeySuggestion, limit int) []KeySuggestion {
+	var suggestions []KeySuggestion
+	seen := map[string]bool{key: true}
+	for _, candidate := range candidates {
+		if len(suggestions) >= limit {
+			break
+		}
+		if seen[candidate.Suggest] || !v.isFree(registry, context, candidate.Suggest) {
+			continue
+		}
+		seen[candidate.Suggest] = true
+		suggestions = append(suggestions, candidate)
+	}
+	return suggestions
+}
+
+// SuggestFixes returns key suggestions for every conflict in result
+func (v *Validator) SuggestFixes(registry *Registry, result *ValidationResult, limit int) []KeySuggestion {
+	var suggestions []KeySuggestion
+	for _, err := range result.Errors {
+		if err.Type == "conflict" {
+			suggestions = append(suggestions, v.SuggestKeys(registry, err.Context, err.Key, limit)...)
+		}
+	}
+	return suggestions
+}
+
+// isFree reports whether key can be bound in context without clashing with another binding
+func (v *Validator) isFree(registry *Registry, context Context, key string) bool {
+	if v.reservedKeys[key] || unreliableKeys[key] || registry.IsLeader(key) || registry.HasBinding(context, key) {
+		return false
+	}
+
+	// A global key is shadowed by any context binding, so it must be free everywhere
+	if context == ContextGlobal {
+		for _, bindings := range registry.bindings {
+			if _, ok := bindings[key]; ok {
+				return false
+			}
+		}
+	}
+
+	// Keep multi-key sequences unambiguous
+	for bound := range registry.bindings[context] {
+		if len(bound) > 1 && !strings.Contains(bound, "+") && strings.HasPrefix(bound, key) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitModifier splits a key like "ctrl+r" into its modifier prefix and base key
+func splitModifier(key string) (string, string) {
+	for _, mod := range []string{"ctrl+", "alt+", "shift+", "super+"} {
+		if strings.HasPrefix(key, mod) && len(key) > len(mod) {
+			return mod, key[len(mod):]
+		}
+	}
+	return "", key
+}
+
+// isLetter reports whether key is a single ASCII letter
+func isLetter(key string) bool {
+	return len(key) == 1 && (key[0] >= 'a' && key[0] <= 'z' || key[0] >= 'A' && key[0] <= 'Z')
+}
+
+// swapCase returns a single letter in the opposite case
+func swapCase(letter string) string {
+	if upper := strings.ToUpper(letter); upper != letter {
+		return upper
+	}
+	return strings.ToLower(letter)
+}
+
 // ValidateKey checks if a key string is valid
 func ValidateKey(key string) error {
 	if key == "" {
@@ -724,3 +724,84 @@ func TestMultipleValidationIssues(t *testing.T) {
 		}
 	}
 }
+
+func TestSuggestKeys(t *testing.T) {
+	v := NewValidator()
+	r := NewRegistry()
+	r.Register(ContextGlobal, "ctrl+c", ActionQuitForce)
+	r.Register(ContextNormal, "x", ActionOpenHistory)
+	r.Register(ContextNormal, "X", ActionOpenHelp)
+	r.Register(ContextNormal, "ctrl+x", ActionOpenVariables)
+	r.Register(ContextNormal, "a", ActionQuit)
+	r.Register(ContextNormal, "g", ActionGoToTopPrepare)
+	r.Register(ContextNormal, "gg", ActionGoToTop)
+
+	suggestions := v.SuggestKeys(r, ContextNormal, "x", 3)
+	if len(suggestions) != 3 {
+		t.Fatalf("Expected 3 suggestions, got %+v", suggestions)
+	}
+	want := []KeySuggestion{
+		{Context: ContextNormal, Key: "x", Suggest: "alt+x", Reason: "modifier"},
+		{Context: ContextNormal, Key: "x", Suggest: "b", Reason: "letter"},
+		{Context: ContextNormal, Key: "x", Suggest: "c", Reason: "letter"},
+	}
+	for i := range want {
+		if suggestions[i] != want[i] {
+			t.Errorf("Suggestion %d: got %+v, want %+v", i, suggestions[i], want[i])
+		}
+	}
+
+	// Reserved keys, leaders and keys bound globally are never suggested
+	for _, s := range v.SuggestKeys(r, ContextNormal, "ctrl+x", 30) {
+		if s.Suggest == "ctrl+c" || s.Suggest == "ctrl+h" || s.Suggest == "x" {
+			t.Errorf("Unexpected suggestion %q", s.Suggest)
+		}
+	}
+	for _, s := range v.SuggestKeys(r, ContextNormal, "Q", 60) {
+		if s.Suggest == "g" {
+			t.Error("Expected the leader key not to be suggested")
+		}
+	}
+
+	if suggestions := v.SuggestKeys(r, ContextNormal, "gg", 1); len(suggestions) != 1 || suggestions[0].Suggest != "ga" || suggestions[0].Reason != "sequence" {
+		t.Errorf("Expected a sequence behind the same leader, got %+v", suggestions)
+	}
+}
+
+func TestSuggestKeys_NothingFree(t *testing.T) {
+	v := NewValidator()
+	r := NewRegistry()
+	for c := 'a'; c <= 'z'; c++ {
+		letter := string(c)
+		r.Register(ContextNormal, letter, ActionQuit)
+		r.Register(ContextNormal, strings.ToUpper(letter), ActionQuit)
+		r.Register(ContextNormal, "ctrl+"+letter, ActionQuit)
+		r.Register(ContextNormal, "alt+"+letter, ActionQuit)
+	}
+	r.Register(ContextNormal, "enter", ActionQuit)
+	r.Register(ContextGlobal, "ctrl+enter", ActionQuit)
+	r.Register(ContextNormal, "alt+enter", ActionQuit)
+
+	for _, key := range []string{"q", "ctrl+q", "alt+q", "enter"} {
+		if suggestions := v.SuggestKeys(r, ContextNormal, key, 5); suggestions != nil {
+			t.Errorf("Expected no suggestions for %q, got %+v", key, suggestions)
+		}
+	}
+}
+
+func TestSuggestFixes(t *testing.T) {
+	v := NewValidator()
+	r := NewRegistry()
+	r.Register(ContextNormal, "q", ActionQuit)
+
+	result := &ValidationResult{
+		Errors: []ValidationError{
+			{Type: "conflict", Context: ContextNormal, Key: "q", Message: "key bound 2 times"},
+			{Type: "invalid", Context: ContextNormal, Key: "w"},
+		},
+	}
+	suggestions := v.SuggestFixes(r, result, 2)
+	if len(suggestions) != 2 || suggestions[0].Suggest != "Q" || suggestions[0].Key != "q" {
+		t.Errorf("Expected suggestions for the conflict only, got %+v", suggestions)
+	}
+}
@@ -0,0 +1,49 @@
+// Package keychain stores and reads secrets in the OS keychain
+// (macOS Keychain, Windows Credential Manager, libsecret on Linux).
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Service is the keychain service used for secrets stored by restcli
+const Service = "restcli"
+
+// ReferencePrefix starts a {{keychain:service/account}} variable
+const ReferencePrefix = "keychain:"
+
+// ErrNotFound is returned when no secret exists for the service and account
+var ErrNotFound = errors.New("secret not found in the OS keychain")
+
+// ParseReference splits a "service/account" reference
+func ParseReference(ref string) (service, account string, err error) {
+	service, account, ok := strings.Cut(strings.TrimSpace(ref), "/")
+	if !ok || service == "" || account == "" {
+		return "", "", fmt.Errorf("invalid keychain reference %q (expected service/account)", ref)
+	}
+	return service, account, nil
+}
+
+// Reference returns the {{keychain:service/account}} placeholder for a secret
+func Reference(service, account string) string {
+	return "{{" + ReferencePrefix + service + "/" + account + "}}"
+}
+
+// Get reads the secret stored for service and account
+func Get(service, account string) (string, error) {
+	secret, err := get(service, account)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", fmt.Errorf("failed to read from the OS keychain: %w", err)
+	}
+	return secret, err
+}
+
+// Set stores the secret for service and account, replacing any existing value
+func Set(service, account, secret string) error {
+	if err := set(service, account, secret); err != nil {
+		return fmt.Errorf("failed to write to the OS keychain: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+//go:build !windows
+
+package keychain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// macOS uses the security CLI and Linux uses secret-tool (libsecret), both with
+// the same service/account attributes as other keyring libraries
+
+// securityItemNotFound is the exit code of security when no item matches
+const securityItemNotFound = 44
+
+func get(service, account string) (string, error) {
+	if runtime.GOOS == "darwin" {
+		out, err := run(nil, "security", "find-generic-password", "-s", service, "-a", account, "-w")
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound {
+			return "", ErrNotFound
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(out, "\n"), nil
+	}
+
+	out, err := run(nil, "secret-tool", "lookup", "service", service, "username", account)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) == 0 {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+func set(service, account, secret string) error {
+	if runtime.GOOS == "darwin" {
+		// Separate arguments keep names with quotes or spaces intact, security -i does not parse shell quoting
+		_, err := run(nil, "security", "add-generic-password", "-U", "-s", service, "-a", account, "-w", secret)
+		return err
+	}
+
+	label := fmt.Sprintf("%s: %s/%s", Service, service, account)
+	_, err := run(strings.NewReader(secret), "secret-tool", "store", "--label", label, "service", service, "username", account)
+	return err
+}
+
+// run executes a keychain command and includes its stderr in the error
+func run(stdin *strings.Reader, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%s is not installed", name)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitErr.Stderr = bytes.TrimSpace(stderr.Bytes())
+			if len(exitErr.Stderr) > 0 {
+				return "", &commandError{exitErr: exitErr}
+			}
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// commandError reports the stderr of a failed keychain command while keeping the exit code
+type commandError struct {
+	exitErr *exec.ExitError
+}
+
+func (e *commandError) Error() string { return string(e.exitErr.Stderr) }
+
+func (e *commandError) Unwrap() error { return e.exitErr }
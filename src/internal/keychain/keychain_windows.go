@@ -0,0 +1,86 @@
+package keychain
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+)
+
+// Windows stores generic credentials in the Credential Manager under "service:account"
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32      = syscall.NewLazyDLL("advapi32.dll")
+	procCredRead  = advapi32.NewProc("CredReadW")
+	procCredWrite = advapi32.NewProc("CredWriteW")
+	procCredFree  = advapi32.NewProc("CredFree")
+)
+
+// credential mirrors the Win32 CREDENTIALW structure
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+func get(service, account string) (string, error) {
+	target, err := syscall.UTF16PtrFromString(service + ":" + account)
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	ret, _, err := procCredRead.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if errors.Is(err, errorNotFound) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+func set(service, account, secret string) error {
+	target, err := syscall.UTF16PtrFromString(service + ":" + account)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+
+	ret, _, err := procCredWrite.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return err
+	}
+	return nil
+}
@@ -91,6 +91,123 @@ var AllMigrations = []Migration{
 			DROP INDEX IF EXISTS idx_analytics_profile_grouping;
 		`,
 	},
+	{
+		Version: 6,
+		Name:    "Add ttfb_ms column to analytics",
+		Up: `
+			-- Time to first byte, recorded separately from total duration (0 = unknown)
+			ALTER TABLE analytics ADD COLUMN ttfb_ms INTEGER NOT NULL DEFAULT 0;
+		`,
+		Down: `
+			-- SQLite does not support DROP COLUMN easily
+			-- Leaving column in place for backward compatibility
+		`,
+	},
+	{
+		Version: 7,
+		Name:    "Add target RPS columns to stress tests",
+		Up: `
+			-- Constant request-rate mode (0 = concurrency mode)
+			ALTER TABLE stress_test_configs ADD COLUMN target_rps INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE stress_test_runs ADD COLUMN target_rps INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE stress_test_runs ADD COLUMN achieved_rps REAL NOT NULL DEFAULT 0;
+		`,
+		Down: `
+			-- SQLite does not support DROP COLUMN easily
+			-- Leaving columns in place for backward compatibility
+		`,
+	},
+	{
+		Version: 8,
+		Name:    "Add think time columns to stress tests",
+		Up: `
+			-- Per-worker pause after each request (0/0 = none)
+			ALTER TABLE stress_test_configs ADD COLUMN think_time_min_ms INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE stress_test_configs ADD COLUMN think_time_max_ms INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE stress_test_runs ADD COLUMN think_time_min_ms INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE stress_test_runs ADD COLUMN think_time_max_ms INTEGER NOT NULL DEFAULT 0;
+		`,
+		Down: `
+			-- SQLite does not support DROP COLUMN easily
+			-- Leaving columns in place for backward compatibility
+		`,
+	},
+	{
+		Version: 9,
+		Name:    "Add data file columns to stress test configs",
+		Up: `
+			-- CSV data file for {{col}} variables
+			ALTER TABLE stress_test_configs ADD COLUMN data_file TEXT NOT NULL DEFAULT '';
+			ALTER TABLE stress_test_configs ADD COLUMN data_order TEXT NOT NULL DEFAULT '';
+		`,
+		Down: `
+			-- SQLite does not support DROP COLUMN easily
+			-- Leaving columns in place for backward compatibility
+		`,
+	},
+	{
+		Version: 10,
+		Name:    "Add weighted scenarios to stress tests",
+		Up: `
+			-- JSON list of {requestFile, weight}, empty = single request file
+			ALTER TABLE stress_test_configs ADD COLUMN scenarios TEXT NOT NULL DEFAULT '';
+			-- Scenario that produced each metric
+			ALTER TABLE stress_test_metrics ADD COLUMN scenario TEXT NOT NULL DEFAULT '';
+		`,
+		Down: `
+			-- SQLite does not support DROP COLUMN easily
+			-- Leaving columns in place for backward compatibility
+		`,
+	},
+	{
+		Version: 11,
+		Name:    "Add composite index for history loading",
+		Up: `
+			-- History is loaded per profile, newest first, then searched in memory (bodies included)
+			CREATE INDEX IF NOT EXISTS idx_history_profile_timestamp ON history(profile_name, timestamp DESC);
+		`,
+		Down: `
+			DROP INDEX IF EXISTS idx_history_profile_timestamp;
+		`,
+	},
+	{
+		Version: 12,
+		Name:    "Add note and tags columns to history",
+		Up: `
+			-- Free-text note and comma-separated tags attached from the history viewer
+			ALTER TABLE history ADD COLUMN note TEXT NOT NULL DEFAULT '';
+			ALTER TABLE history ADD COLUMN tags TEXT NOT NULL DEFAULT '';
+		`,
+		Down: `
+			-- SQLite does not support DROP COLUMN easily
+			-- Leaving columns in place for backward compatibility
+		`,
+	},
+	{
+		Version: 13,
+		Name:    "Add redirects column to history",
+		Up: `
+			-- Redirect hops followed before the final response, as JSON
+			ALTER TABLE history ADD COLUMN redirects TEXT NOT NULL DEFAULT '';
+		`,
+		Down: `
+			-- SQLite does not support DROP COLUMN easily
+			-- Leaving column in place for backward compatibility
+		`,
+	},
+	{
+		Version: 14,
+		Name:    "Add latency budget columns to analytics",
+		Up: `
+			-- maxDurationMs the request was held to (0 = none) and whether it was met
+			ALTER TABLE analytics ADD COLUMN budget_ms INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE analytics ADD COLUMN budget_met INTEGER NOT NULL DEFAULT 0;
+		`,
+		Down: `
+			-- SQLite does not support DROP COLUMN easily
+			-- Leaving columns in place for backward compatibility
+		`,
+	},
 }
 
 // InitSchema creates all tables required across all modules
@@ -3,6 +3,7 @@ package mock
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -57,11 +58,45 @@ func validateConfig(config *Config) error {
 		if route.PathType != "" && route.PathType != "exact" && route.PathType != "prefix" && route.PathType != "regex" {
 			return fmt.Errorf("route %d: pathType must be 'exact', 'prefix', or 'regex'", i)
 		}
+		if route.Repeat != "" && route.Repeat != "last" && route.Repeat != "cycle" {
+			return fmt.Errorf("route %d: repeat must be 'last' or 'cycle'", i)
+		}
+		if route.DelayMs != nil && (route.DelayMs.Min < 0 || route.DelayMs.Max < route.DelayMs.Min) {
+			return fmt.Errorf("route %d: delayMs must satisfy 0 <= min <= max", i)
+		}
+		if route.FailureRate < 0 || route.FailureRate > 1 {
+			return fmt.Errorf("route %d: failureRate must be between 0 and 1", i)
+		}
+		if route.FailureStatus != 0 && (route.FailureStatus < 100 || route.FailureStatus > 599) {
+			return fmt.Errorf("route %d: failureStatus must be a valid HTTP status code", i)
+		}
+		if ws := route.WebSocket; ws != nil {
+			if !strings.EqualFold(route.Method, http.MethodGet) {
+				return fmt.Errorf("route %d: websocket routes must use GET", i)
+			}
+			if ws.Loop && !hasMessageDelay(ws.Messages) {
+				return fmt.Errorf("route %d: websocket loop requires a message with a delay", i)
+			}
+		}
+	}
+
+	if config.Chaos < 0 || config.Chaos > 1 {
+		return fmt.Errorf("chaos must be between 0 and 1")
 	}
 
 	return nil
 }
 
+// hasMessageDelay reports whether any scripted message waits before being sent
+func hasMessageDelay(messages []WebSocketMessage) bool {
+	for _, msg := range messages {
+		if msg.Delay > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // SaveConfig saves a mock configuration to a file
 func SaveConfig(config *Config, path string) error {
 	var data []byte
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math/rand/v2"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
 // Server represents the mock HTTP server
@@ -22,8 +26,22 @@ type Server struct {
 	logsMutex  sync.RWMutex
 	workdir    string
 	notifyCh   chan struct{} // Channel to notify when new log arrives
+	calls      map[int]int   // Invocation count per route index
+	callsMutex sync.Mutex
+	onInject   func(RequestLog) // Called for every injected failure
+	wsConns    map[*websocket.Conn]struct{}
+	wsMutex    sync.Mutex
 }
 
+// Random sources for delays and failure injection, replaced in tests
+var (
+	randIntN    = rand.IntN
+	randFloat64 = rand.Float64
+)
+
+// ResetPath is the control endpoint that resets the route invocation counters
+const ResetPath = "/__mock/reset"
+
 // NewServer creates a new mock server
 func NewServer(config *Config, workdir string) *Server {
 	if config.Port == 0 {
@@ -38,6 +56,8 @@ func NewServer(config *Config, workdir string) *Server {
 		logs:     make([]RequestLog, 0),
 		workdir:  workdir,
 		notifyCh: make(chan struct{}, 100), // Buffered channel for notifications
+		calls:    make(map[int]int),
+		wsConns:  make(map[*websocket.Conn]struct{}),
 	}
 }
 
@@ -46,6 +66,7 @@ func (s *Server) Start() error {
 	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
 
 	mux := http.NewServeMux()
+	mux.HandleFunc(ResetPath, s.handleReset)
 	mux.HandleFunc("/", s.handleRequest)
 
 	s.httpServer = &http.Server{
@@ -71,6 +92,7 @@ func (s *Server) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	s.closeWebSockets()
 	return s.httpServer.Shutdown(ctx)
 }
 
@@ -84,55 +106,95 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 	requestBody := string(bodyBytes)
 
 	// Find matching route
-	route := s.findMatchingRoute(r.Method, r.URL.Path)
+	index, route := s.findMatchingRoute(r.Method, r.URL.Path)
+
+	if route != nil && route.WebSocket != nil {
+		s.handleWebSocket(w, r, route)
+		return
+	}
 
 	var status int
 	var responseBody string
 	var matchedRule string
+	var injected bool
 
 	if route == nil {
 		// No matching route - return 404
 		status = http.StatusNotFound
 		responseBody = fmt.Sprintf("Mock server: No route configured for %s %s", r.Method, r.URL.Path)
 		matchedRule = "none"
+	} else if s.shouldInjectFailure(route) {
+		// Injected failures keep the route latency but do not advance response sequences
+		sleepMs(routeDelay(route, route.Delay))
+
+		status = route.FailureStatus
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		responseBody = route.FailureBody
+		if responseBody == "" {
+			responseBody = fmt.Sprintf("Mock server: Injected failure (%d)", status)
+		}
+		matchedRule = routeLabel(route) + " (injected failure)"
+		injected = true
 	} else {
+		step, resp := s.nextResponse(index, route)
+
 		// Apply delay if configured
-		if route.Delay > 0 {
-			time.Sleep(time.Duration(route.Delay) * time.Millisecond)
-		}
+		sleepMs(routeDelay(route, resp.Delay))
 
 		// Set status
-		status = route.Status
+		status = resp.Status
 		if status == 0 {
 			status = http.StatusOK
 		}
 
 		// Set headers
-		for key, value := range route.Headers {
+		for key, value := range resp.Headers {
 			w.Header().Set(key, value)
 		}
 
 		// Get response body
-		if route.BodyFile != "" {
+		bodyFileFailed := false
+		if resp.BodyFile != "" {
 			// Load from file
-			filePath := route.BodyFile
+			filePath := resp.BodyFile
 			if !filepath.IsAbs(filePath) {
 				filePath = filepath.Join(s.workdir, filePath)
 			}
 			bodyBytes, err := os.ReadFile(filePath)
 			if err != nil {
+				bodyFileFailed = true
 				status = http.StatusInternalServerError
-				responseBody = fmt.Sprintf("Mock server: Failed to read body file %s: %v", route.BodyFile, err)
+				responseBody = fmt.Sprintf("Mock server: Failed to read body file %s: %v", resp.BodyFile, err)
 			} else {
 				responseBody = string(bodyBytes)
 			}
 		} else {
-			responseBody = route.Body
+			responseBody = resp.Body
+		}
+
+		if route.Template && !bodyFileFailed {
+			data := TemplateData{
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				PathParam: pathParams(route, r.URL.Path),
+				Query:     flattenHeaders(r.URL.Query()),
+				Header:    flattenHeaders(r.Header),
+				Body:      requestBody,
+			}
+			rendered, err := renderTemplate(responseBody, data)
+			if err != nil {
+				status = http.StatusInternalServerError
+				responseBody = fmt.Sprintf("Mock server: Failed to render response template: %v", err)
+			} else {
+				responseBody = rendered
+			}
 		}
 
-		matchedRule = route.Name
-		if matchedRule == "" {
-			matchedRule = fmt.Sprintf("%s %s", route.Method, route.Path)
+		matchedRule = routeLabel(route)
+		if len(route.Responses) > 0 {
+			matchedRule = fmt.Sprintf("%s #%d", matchedRule, step+1)
 		}
 	}
 
@@ -140,26 +202,77 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(status)
 	w.Write([]byte(responseBody))
 
-	duration := time.Since(start)
+	entry := RequestLog{
+		Timestamp:   start,
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		Headers:     flattenHeaders(r.Header),
+		Body:        requestBody,
+		MatchedRule: matchedRule,
+		Status:      status,
+		Duration:    time.Since(start),
+		Injected:    injected,
+	}
+
+	if injected && s.onInject != nil {
+		s.onInject(entry)
+	}
 
-	// Log request
+	s.logEntry(entry)
+}
+
+// logEntry records a request when logging is enabled
+func (s *Server) logEntry(entry RequestLog) {
 	if s.config.Logging {
-		s.logRequest(RequestLog{
-			Timestamp:   start,
-			Method:      r.Method,
-			Path:        r.URL.Path,
-			Headers:     flattenHeaders(r.Header),
-			Body:        requestBody,
-			MatchedRule: matchedRule,
-			Status:      status,
-			Duration:    duration,
-		})
+		s.logRequest(entry)
+	}
+}
+
+// OnInjectedFailure registers a callback invoked whenever a failure is injected
+func (s *Server) OnInjectedFailure(fn func(RequestLog)) {
+	s.onInject = fn
+}
+
+// routeLabel returns the route name, or its method and path when unnamed
+func routeLabel(route *Route) string {
+	if route.Name != "" {
+		return route.Name
+	}
+	return fmt.Sprintf("%s %s", route.Method, route.Path)
+}
+
+// routeDelay returns the delay to apply in milliseconds
+// An explicit delay wins over delayMs, which picks a random value when given a range
+func routeDelay(route *Route, delay int) int {
+	if delay > 0 || route.DelayMs == nil {
+		return delay
+	}
+	if route.DelayMs.Max > route.DelayMs.Min {
+		return route.DelayMs.Min + randIntN(route.DelayMs.Max-route.DelayMs.Min+1)
+	}
+	return route.DelayMs.Min
+}
+
+// sleepMs pauses for the given number of milliseconds
+func sleepMs(ms int) {
+	if ms > 0 {
+		time.Sleep(time.Duration(ms) * time.Millisecond)
 	}
 }
 
+// shouldInjectFailure rolls for a failure using the route failureRate or the global chaos rate, whichever is higher
+func (s *Server) shouldInjectFailure(route *Route) bool {
+	rate := route.FailureRate
+	if s.config.Chaos > rate {
+		rate = s.config.Chaos
+	}
+	return rate > 0 && randFloat64() < rate
+}
+
 // findMatchingRoute finds the first route that matches the method and path
-func (s *Server) findMatchingRoute(method, path string) *Route {
-	for _, route := range s.config.Routes {
+// Returns the route index along with the route, or -1 when nothing matches
+func (s *Server) findMatchingRoute(method, path string) (int, *Route) {
+	for i, route := range s.config.Routes {
 		if !strings.EqualFold(route.Method, method) {
 			continue
 		}
@@ -182,11 +295,132 @@ func (s *Server) findMatchingRoute(method, path string) *Route {
 		}
 
 		if matched {
-			return &route
+			return i, &route
 		}
 	}
 
-	return nil
+	return -1, nil
+}
+
+// pathParams returns the named groups captured by a regex route path
+func pathParams(route *Route, path string) map[string]string {
+	params := make(map[string]string)
+	if route.PathType != "regex" {
+		return params
+	}
+
+	re, err := regexp.Compile(route.Path)
+	if err != nil {
+		return params
+	}
+	match := re.FindStringSubmatch(path)
+	if match == nil {
+		return params
+	}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			params[name] = match[i]
+		}
+	}
+	return params
+}
+
+// renderTemplate executes a response body as a Go template against the request data
+func renderTemplate(body string, data TemplateData) (string, error) {
+	tmpl, err := template.New("response").Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// nextResponse returns the response for the next call to a route and its step in the sequence
+// Routes without a responses list always return their own status, headers and body
+func (s *Server) nextResponse(index int, route *Route) (int, Response) {
+	base := Response{
+		Status:   route.Status,
+		Headers:  route.Headers,
+		Body:     route.Body,
+		BodyFile: route.BodyFile,
+		Delay:    route.Delay,
+	}
+	if len(route.Responses) == 0 {
+		return 0, base
+	}
+
+	s.callsMutex.Lock()
+	call := s.calls[index]
+	s.calls[index] = call + 1
+	s.callsMutex.Unlock()
+
+	step := call
+	if step >= len(route.Responses) {
+		if route.Repeat == "cycle" {
+			step = call % len(route.Responses)
+		} else {
+			step = len(route.Responses) - 1
+		}
+	}
+
+	resp := route.Responses[step]
+	if resp.Status == 0 {
+		resp.Status = base.Status
+	}
+	if resp.Delay == 0 {
+		resp.Delay = base.Delay
+	}
+	headers := make(map[string]string, len(base.Headers)+len(resp.Headers))
+	for key, value := range base.Headers {
+		headers[key] = value
+	}
+	for key, value := range resp.Headers {
+		headers[key] = value
+	}
+	resp.Headers = headers
+
+	return step, resp
+}
+
+// ResetCounters resets the invocation counters so response sequences start over
+// With a route name, only that route is reset
+func (s *Server) ResetCounters(name string) int {
+	s.callsMutex.Lock()
+	defer s.callsMutex.Unlock()
+
+	if name == "" {
+		count := len(s.calls)
+		s.calls = make(map[int]int)
+		return count
+	}
+
+	count := 0
+	for i, route := range s.config.Routes {
+		if route.Name == name {
+			if _, ok := s.calls[i]; ok {
+				count++
+			}
+			delete(s.calls, i)
+		}
+	}
+	return count
+}
+
+// handleReset handles the control endpoint resetting the invocation counters
+func (s *Server) handleReset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Mock server: use POST to reset counters", http.StatusMethodNotAllowed)
+		return
+	}
+
+	count := s.ResetCounters(r.URL.Query().Get("route"))
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"reset": %d}`, count)
 }
 
 // logRequest adds a request to the log
@@ -238,8 +472,8 @@ func (s *Server) GetAddress() string {
 	return fmt.Sprintf("http://%s:%d", s.config.Host, s.config.Port)
 }
 
-// flattenHeaders converts http.Header to map[string]string (first value only)
-func flattenHeaders(headers http.Header) map[string]string {
+// flattenHeaders converts http.Header or url.Values to map[string]string (first value only)
+func flattenHeaders(headers map[string][]string) map[string]string {
 	result := make(map[string]string)
 	for key, values := range headers {
 		if len(values) > 0 {
@@ -0,0 +1,239 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func callRoute(t *testing.T, s *Server, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.handleRequest(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestServer_ResponseSequence(t *testing.T) {
+	s := NewServer(&Config{Routes: []Route{
+		{
+			Name:    "Poll job",
+			Method:  "GET",
+			Path:    "/jobs/1",
+			Status:  200,
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Responses: []Response{
+				{Status: 202, Body: `{"state": "pending"}`},
+				{Body: `{"state": "done"}`, Headers: map[string]string{"X-Step": "2"}},
+			},
+		},
+		{
+			Method:    "GET",
+			Path:      "/flaky",
+			Repeat:    "cycle",
+			Responses: []Response{{Status: 503}, {Status: 200}},
+		},
+	}}, t.TempDir())
+
+	for i, want := range []struct {
+		status int
+		body   string
+	}{
+		{202, `{"state": "pending"}`},
+		{200, `{"state": "done"}`},
+		{200, `{"state": "done"}`}, // stays on the last response
+	} {
+		rec := callRoute(t, s, "GET", "/jobs/1")
+		if rec.Code != want.status || rec.Body.String() != want.body {
+			t.Errorf("Call %d: got %d %q, want %d %q", i+1, rec.Code, rec.Body.String(), want.status, want.body)
+		}
+		if rec.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("Call %d: expected the route headers to apply", i+1)
+		}
+	}
+
+	for i, want := range []int{503, 200, 503, 200} {
+		if rec := callRoute(t, s, "GET", "/flaky"); rec.Code != want {
+			t.Errorf("Cycle call %d: got %d, want %d", i+1, rec.Code, want)
+		}
+	}
+
+	// Resetting one route leaves the others untouched
+	rec := httptest.NewRecorder()
+	s.handleReset(rec, httptest.NewRequest("POST", ResetPath+"?route=Poll+job", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != `{"reset": 1}` {
+		t.Errorf("Unexpected reset response: %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := callRoute(t, s, "GET", "/jobs/1"); rec.Code != 202 {
+		t.Errorf("Expected the sequence to start over, got %d", rec.Code)
+	}
+	if rec := callRoute(t, s, "GET", "/flaky"); rec.Code != 503 {
+		t.Errorf("Expected the cycle to continue, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	s.handleReset(rec, httptest.NewRequest("GET", ResetPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected GET on the reset endpoint to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestServer_TemplateResponse(t *testing.T) {
+	s := NewServer(&Config{Routes: []Route{
+		{
+			Method:   "POST",
+			Path:     `/users/(?P<id>[0-9]+)`,
+			PathType: "regex",
+			Template: true,
+			Body:     `{"id": "{{.PathParam.id}}", "q": "{{.Query.q}}", "agent": "{{index .Header "User-Agent"}}", "received": {{.Body}}}`,
+		},
+		{Method: "GET", Path: "/raw", Body: "{{.Body}}"},
+		{Method: "GET", Path: "/broken", Template: true, Body: "{{.Missing}}"},
+		{Method: "GET", Path: "/error", Template: true, Status: http.StatusInternalServerError, Body: `{"path": "{{.Path}}"}`},
+		{Method: "GET", Path: "/missing", Template: true, BodyFile: "missing-{{.Path}}.json"},
+	}}, t.TempDir())
+
+	req := httptest.NewRequest("POST", "/users/42?q=find", strings.NewReader(`{"name": "a"}`))
+	req.Header.Set("User-Agent", "restcli")
+	rec := httptest.NewRecorder()
+	s.handleRequest(rec, req)
+	want := `{"id": "42", "q": "find", "agent": "restcli", "received": {"name": "a"}}`
+	if rec.Code != http.StatusOK || rec.Body.String() != want {
+		t.Errorf("Unexpected templated response: %d %q", rec.Code, rec.Body.String())
+	}
+
+	if rec := callRoute(t, s, "GET", "/raw"); rec.Body.String() != "{{.Body}}" {
+		t.Errorf("Expected routes without template to stay raw, got %q", rec.Body.String())
+	}
+
+	rec = callRoute(t, s, "GET", "/broken")
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "Failed to render response template") {
+		t.Errorf("Expected a 500 for a failing template, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	// A configured 500 is still rendered
+	rec = callRoute(t, s, "GET", "/error")
+	if rec.Code != http.StatusInternalServerError || rec.Body.String() != `{"path": "/error"}` {
+		t.Errorf("Expected a rendered 500 response, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	// The read error is reported as-is, not rendered
+	rec = callRoute(t, s, "GET", "/missing")
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "Failed to read body file missing-{{.Path}}.json") {
+		t.Errorf("Expected the body file error, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestServer_FailureInjection(t *testing.T) {
+	roll := 0.5
+	originalFloat := randFloat64
+	randFloat64 = func() float64 { return roll }
+	t.Cleanup(func() { randFloat64 = originalFloat })
+
+	s := NewServer(&Config{Logging: true, Routes: []Route{
+		{
+			Name:          "Flaky",
+			Method:        "GET",
+			Path:          "/flaky",
+			Status:        200,
+			FailureRate:   0.6,
+			FailureStatus: 503,
+			FailureBody:   "unavailable",
+			Responses:     []Response{{Body: "first"}, {Body: "second"}},
+		},
+		{Method: "GET", Path: "/stable", Status: 200},
+	}}, t.TempDir())
+
+	var injected []RequestLog
+	s.OnInjectedFailure(func(entry RequestLog) { injected = append(injected, entry) })
+
+	rec := callRoute(t, s, "GET", "/flaky")
+	if rec.Code != 503 || rec.Body.String() != "unavailable" {
+		t.Errorf("Expected an injected failure, got %d %q", rec.Code, rec.Body.String())
+	}
+	if len(injected) != 1 || !s.GetLogs()[0].Injected {
+		t.Errorf("Expected the injected failure to be reported and logged")
+	}
+	if rec := callRoute(t, s, "GET", "/stable"); rec.Code != 200 {
+		t.Errorf("Expected routes without failureRate to succeed, got %d", rec.Code)
+	}
+
+	// Failures do not consume response sequence steps
+	roll = 0.9
+	if rec := callRoute(t, s, "GET", "/flaky"); rec.Body.String() != "first" {
+		t.Errorf("Expected the first response after a failure, got %q", rec.Body.String())
+	}
+
+	// The global chaos rate applies to every route
+	s.config.Chaos = 0.95
+	rec = callRoute(t, s, "GET", "/stable")
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "Injected failure") {
+		t.Errorf("Expected a chaos failure, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRouteDelay(t *testing.T) {
+	originalIntN := randIntN
+	randIntN = func(n int) int { return n - 1 }
+	t.Cleanup(func() { randIntN = originalIntN })
+
+	route := &Route{DelayMs: &DelayRange{Min: 100, Max: 300}}
+	if got := routeDelay(route, 0); got != 300 {
+		t.Errorf("Expected the top of the range, got %d", got)
+	}
+	if got := routeDelay(route, 50); got != 50 {
+		t.Errorf("Expected an explicit delay to win, got %d", got)
+	}
+	if got := routeDelay(&Route{DelayMs: &DelayRange{Min: 20, Max: 20}}, 0); got != 20 {
+		t.Errorf("Expected a fixed delay, got %d", got)
+	}
+}
+
+func TestDelayRange_Unmarshal(t *testing.T) {
+	var fromYAML struct {
+		Fixed  DelayRange `yaml:"fixed"`
+		Random DelayRange `yaml:"random"`
+	}
+	if err := yaml.Unmarshal([]byte("fixed: 200\nrandom: {min: 100, max: 500}\n"), &fromYAML); err != nil {
+		t.Fatal(err)
+	}
+	if fromYAML.Fixed != (DelayRange{200, 200}) || fromYAML.Random != (DelayRange{100, 500}) {
+		t.Errorf("Unexpected YAML delays: %+v", fromYAML)
+	}
+
+	var fromJSON []DelayRange
+	if err := json.Unmarshal([]byte(`[150, {"min": 1, "max": 2}]`), &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if fromJSON[0] != (DelayRange{150, 150}) || fromJSON[1] != (DelayRange{1, 2}) {
+		t.Errorf("Unexpected JSON delays: %+v", fromJSON)
+	}
+	if data, _ := json.Marshal(fromJSON); string(data) != `[150,{"min":1,"max":2}]` {
+		t.Errorf("Unexpected JSON output: %s", data)
+	}
+}
+
+func TestValidateConfig_Repeat(t *testing.T) {
+	config := &Config{Routes: []Route{{Method: "GET", Path: "/", Repeat: "forever"}}}
+	if err := validateConfig(config); err == nil {
+		t.Error("Expected an invalid repeat policy to be rejected")
+	}
+}
+
+func TestValidateConfig_Chaos(t *testing.T) {
+	for _, route := range []Route{
+		{Method: "GET", Path: "/", FailureRate: 1.5},
+		{Method: "GET", Path: "/", FailureStatus: 42},
+		{Method: "GET", Path: "/", DelayMs: &DelayRange{Min: 500, Max: 100}},
+	} {
+		if err := validateConfig(&Config{Routes: []Route{route}}); err == nil {
+			t.Errorf("Expected %+v to be rejected", route)
+		}
+	}
+	if err := validateConfig(&Config{Chaos: -0.1, Routes: []Route{{Method: "GET", Path: "/"}}}); err == nil {
+		t.Error("Expected a negative chaos rate to be rejected")
+	}
+}
@@ -1,27 +1,135 @@
 package mock
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 // Config represents the mock server configuration
 type Config struct {
-	Port    int     `json:"port" yaml:"port"`       // Server port (default: 8080)
-	Host    string  `json:"host" yaml:"host"`       // Server host (default: localhost)
-	Routes  []Route `json:"routes" yaml:"routes"`   // Route definitions
-	Logging bool    `json:"logging" yaml:"logging"` // Enable request logging (default: true)
+	Port    int     `json:"port" yaml:"port"`                       // Server port (default: 8080)
+	Host    string  `json:"host" yaml:"host"`                       // Server host (default: localhost)
+	Routes  []Route `json:"routes" yaml:"routes"`                   // Route definitions
+	Logging bool    `json:"logging" yaml:"logging"`                 // Enable request logging (default: true)
+	Chaos   float64 `json:"chaos,omitempty" yaml:"chaos,omitempty"` // Baseline failure rate applied to every route (0-1)
 }
 
 // Route represents a mock route configuration
 type Route struct {
-	Name        string            `json:"name,omitempty" yaml:"name,omitempty"`               // Route description
-	Method      string            `json:"method" yaml:"method"`                               // HTTP method (GET, POST, etc.)
-	Path        string            `json:"path" yaml:"path"`                                   // URL path pattern
-	PathType    string            `json:"pathType,omitempty" yaml:"pathType,omitempty"`       // exact, prefix, regex (default: exact)
-	Status      int               `json:"status" yaml:"status"`                               // HTTP status code
-	Headers     map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`         // Response headers
-	Body        string            `json:"body,omitempty" yaml:"body,omitempty"`               // Response body (string or file path)
-	BodyFile    string            `json:"bodyFile,omitempty" yaml:"bodyFile,omitempty"`       // Path to response body file
-	Delay       int               `json:"delay,omitempty" yaml:"delay,omitempty"`             // Response delay in milliseconds
-	Description string            `json:"description,omitempty" yaml:"description,omitempty"` // Route documentation
+	Name          string            `json:"name,omitempty" yaml:"name,omitempty"`                   // Route description
+	Method        string            `json:"method" yaml:"method"`                                   // HTTP method (GET, POST, etc.)
+	Path          string            `json:"path" yaml:"path"`                                       // URL path pattern
+	PathType      string            `json:"pathType,omitempty" yaml:"pathType,omitempty"`           // exact, prefix, regex (default: exact)
+	Status        int               `json:"status" yaml:"status"`                                   // HTTP status code
+	Headers       map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`             // Response headers
+	Body          string            `json:"body,omitempty" yaml:"body,omitempty"`                   // Response body (string or file path)
+	BodyFile      string            `json:"bodyFile,omitempty" yaml:"bodyFile,omitempty"`           // Path to response body file
+	Delay         int               `json:"delay,omitempty" yaml:"delay,omitempty"`                 // Response delay in milliseconds
+	Description   string            `json:"description,omitempty" yaml:"description,omitempty"`     // Route documentation
+	Responses     []Response        `json:"responses,omitempty" yaml:"responses,omitempty"`         // Ordered responses returned on successive calls
+	Repeat        string            `json:"repeat,omitempty" yaml:"repeat,omitempty"`               // last, cycle (default: last)
+	Template      bool              `json:"template,omitempty" yaml:"template,omitempty"`           // Render response bodies as Go templates
+	DelayMs       *DelayRange       `json:"delayMs,omitempty" yaml:"delayMs,omitempty"`             // Fixed or random response delay in milliseconds
+	FailureRate   float64           `json:"failureRate,omitempty" yaml:"failureRate,omitempty"`     // Probability of an injected failure (0-1)
+	FailureStatus int               `json:"failureStatus,omitempty" yaml:"failureStatus,omitempty"` // Status of injected failures (default: 500)
+	FailureBody   string            `json:"failureBody,omitempty" yaml:"failureBody,omitempty"`     // Body of injected failures
+	WebSocket     *WebSocketRoute   `json:"websocket,omitempty" yaml:"websocket,omitempty"`         // Upgrade matching requests to a WebSocket
+}
+
+// WebSocketRoute represents a scripted WebSocket endpoint
+type WebSocketRoute struct {
+	Subprotocols []string           `json:"subprotocols,omitempty" yaml:"subprotocols,omitempty"` // Supported subprotocols, in order of preference
+	Messages     []WebSocketMessage `json:"messages,omitempty" yaml:"messages,omitempty"`         // Messages sent after the connection opens
+	Loop         bool               `json:"loop,omitempty" yaml:"loop,omitempty"`                 // Restart the messages after the last one
+	Echo         bool               `json:"echo,omitempty" yaml:"echo,omitempty"`                 // Send received messages back to the client
+	Close        bool               `json:"close,omitempty" yaml:"close,omitempty"`               // Close the connection after the last message
+}
+
+// WebSocketMessage represents a scripted server message
+type WebSocketMessage struct {
+	Data   string `json:"data" yaml:"data"`                         // Message content
+	Binary bool   `json:"binary,omitempty" yaml:"binary,omitempty"` // Send as a binary frame instead of text
+	Delay  int    `json:"delay,omitempty" yaml:"delay,omitempty"`   // Wait before sending, in milliseconds
+}
+
+// DelayRange is a response delay in milliseconds
+// It is written as a number for a fixed delay or as {min, max} for a random one
+type DelayRange struct {
+	Min int `json:"min" yaml:"min"`
+	Max int `json:"max" yaml:"max"`
+}
+
+// UnmarshalJSON accepts a number or a {min, max} object
+func (d *DelayRange) UnmarshalJSON(data []byte) error {
+	var fixed int
+	if err := json.Unmarshal(data, &fixed); err == nil {
+		d.Min, d.Max = fixed, fixed
+		return nil
+	}
+
+	type rangeObject DelayRange
+	var obj rangeObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return errors.New("delayMs must be a number or an object with min and max")
+	}
+	*d = DelayRange(obj)
+	return nil
+}
+
+// MarshalJSON writes a fixed delay as a number
+func (d DelayRange) MarshalJSON() ([]byte, error) {
+	if d.Min == d.Max {
+		return json.Marshal(d.Min)
+	}
+	type rangeObject DelayRange
+	return json.Marshal(rangeObject(d))
+}
+
+// UnmarshalYAML accepts a number or a {min, max} mapping
+func (d *DelayRange) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var fixed int
+	if err := unmarshal(&fixed); err == nil {
+		d.Min, d.Max = fixed, fixed
+		return nil
+	}
+
+	type rangeObject DelayRange
+	var obj rangeObject
+	if err := unmarshal(&obj); err != nil {
+		return errors.New("delayMs must be a number or a mapping with min and max")
+	}
+	*d = DelayRange(obj)
+	return nil
+}
+
+// MarshalYAML writes a fixed delay as a number
+func (d DelayRange) MarshalYAML() (interface{}, error) {
+	if d.Min == d.Max {
+		return d.Min, nil
+	}
+	type rangeObject DelayRange
+	return rangeObject(d), nil
+}
+
+// Response represents one step of a route's response sequence
+// Unset status and delay fall back to the route's values
+type Response struct {
+	Status   int               `json:"status,omitempty" yaml:"status,omitempty"`     // HTTP status code
+	Headers  map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`   // Response headers, merged over the route headers
+	Body     string            `json:"body,omitempty" yaml:"body,omitempty"`         // Response body
+	BodyFile string            `json:"bodyFile,omitempty" yaml:"bodyFile,omitempty"` // Path to response body file
+	Delay    int               `json:"delay,omitempty" yaml:"delay,omitempty"`       // Response delay in milliseconds
+}
+
+// TemplateData is the request data available to templated response bodies
+type TemplateData struct {
+	Method    string            // Request method
+	Path      string            // Request path
+	PathParam map[string]string // Named groups captured by a regex route path
+	Query     map[string]string // Query parameters (first value only)
+	Header    map[string]string // Request headers (first value only)
+	Body      string            // Raw request body
 }
 
 // RequestLog represents a logged request
@@ -34,4 +142,6 @@ type RequestLog struct {
 	MatchedRule string            `json:"matchedRule"`
 	Status      int               `json:"status"`
 	Duration    time.Duration     `json:"duration"`
+	Injected    bool              `json:"injected,omitempty"` // Failure injected by failureRate or chaos
+	Event       string            `json:"event,omitempty"`    // WebSocket event: connect, disconnect
 }
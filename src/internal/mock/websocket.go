@@ -0,0 +1,175 @@
+package mock
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsConn serializes writes to a mock WebSocket connection
+// The script and the echo loop write from different goroutines
+type wsConn struct {
+	conn  *websocket.Conn
+	mutex sync.Mutex
+}
+
+// write sends a single frame
+func (c *wsConn) write(messageType int, data []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// trackConn registers an open WebSocket so Stop can close it
+func (s *Server) trackConn(conn *websocket.Conn) {
+	s.wsMutex.Lock()
+	defer s.wsMutex.Unlock()
+	s.wsConns[conn] = struct{}{}
+}
+
+// untrackConn forgets a closed WebSocket
+func (s *Server) untrackConn(conn *websocket.Conn) {
+	s.wsMutex.Lock()
+	defer s.wsMutex.Unlock()
+	delete(s.wsConns, conn)
+}
+
+// closeWebSockets closes every open WebSocket connection
+// Hijacked connections are not closed by http.Server.Shutdown
+func (s *Server) closeWebSockets() {
+	s.wsMutex.Lock()
+	defer s.wsMutex.Unlock()
+
+	for conn := range s.wsConns {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "mock server stopped"), time.Now().Add(time.Second))
+		conn.Close()
+	}
+	s.wsConns = make(map[*websocket.Conn]struct{})
+}
+
+// handleWebSocket upgrades the request and drives the route's scripted messages
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request, route *Route) {
+	start := time.Now()
+	ws := route.WebSocket
+
+	entry := RequestLog{
+		Timestamp:   start,
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		Headers:     flattenHeaders(r.Header),
+		MatchedRule: routeLabel(route),
+	}
+
+	upgrader := websocket.Upgrader{
+		Subprotocols: ws.Subprotocols,
+		CheckOrigin:  func(*http.Request) bool { return true },
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied with an error status
+		entry.Status = http.StatusBadRequest
+		entry.MatchedRule = fmt.Sprintf("%s (upgrade failed: %v)", routeLabel(route), err)
+		entry.Duration = time.Since(start)
+		s.logEntry(entry)
+		return
+	}
+	s.trackConn(conn)
+	defer s.untrackConn(conn)
+	defer conn.Close()
+
+	entry.Status = http.StatusSwitchingProtocols
+	entry.Event = "connect"
+	if protocol := conn.Subprotocol(); protocol != "" {
+		entry.MatchedRule = fmt.Sprintf("%s (subprotocol %s)", routeLabel(route), protocol)
+	}
+	entry.Duration = time.Since(start)
+	s.logEntry(entry)
+
+	c := &wsConn{conn: conn}
+	done := make(chan struct{})
+	scriptDone := make(chan struct{})
+	reason := "client closed"
+
+	// Read loop: echo messages back and detect the client closing
+	go func() {
+		defer close(done)
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if ws.Echo {
+				if err := c.write(messageType, data); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer close(scriptDone)
+		s.runWebSocketScript(c, ws, done)
+	}()
+
+	select {
+	case <-done:
+	case <-scriptDone:
+		if !ws.Close {
+			<-done
+			break
+		}
+
+		// WriteControl is safe to call concurrently with the other writers
+		reason = "script finished"
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+
+		// Wait for the client close frame, without hanging on clients that never answer
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+		}
+	}
+
+	s.logEntry(RequestLog{
+		Timestamp:   time.Now(),
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		MatchedRule: fmt.Sprintf("%s (%s)", routeLabel(route), reason),
+		Status:      http.StatusSwitchingProtocols,
+		Duration:    time.Since(start),
+		Event:       "disconnect",
+	})
+}
+
+// runWebSocketScript sends the scripted messages in order, restarting them when the route loops
+// It returns once the script is over or the connection is done
+func (s *Server) runWebSocketScript(c *wsConn, ws *WebSocketRoute, done <-chan struct{}) {
+	for {
+		for _, msg := range ws.Messages {
+			if msg.Delay > 0 {
+				timer := time.NewTimer(time.Duration(msg.Delay) * time.Millisecond)
+				select {
+				case <-done:
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+
+			messageType := websocket.TextMessage
+			if msg.Binary {
+				messageType = websocket.BinaryMessage
+			}
+			if err := c.write(messageType, []byte(msg.Data)); err != nil {
+				return
+			}
+		}
+
+		if !ws.Loop || len(ws.Messages) == 0 {
+			return
+		}
+	}
+}
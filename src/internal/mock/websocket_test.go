@@ -0,0 +1,122 @@
+package mock
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestServer_WebSocketScript(t *testing.T) {
+	s := NewServer(&Config{Logging: true, Routes: []Route{{
+		Name:   "Events",
+		Method: "GET",
+		Path:   "/ws",
+		WebSocket: &WebSocketRoute{
+			Subprotocols: []string{"graphql-ws", "json"},
+			Messages: []WebSocketMessage{
+				{Data: `{"type": "hello"}`},
+				{Data: `{"type": "tick"}`, Delay: 10},
+			},
+			Echo: true,
+		},
+	}}}, t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(s.handleRequest))
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"json", "graphql-ws"}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	// The server picks its own preferred subprotocol among the ones offered
+	if conn.Subprotocol() != "graphql-ws" {
+		t.Errorf("Expected the graphql-ws subprotocol, got %q", conn.Subprotocol())
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for _, want := range []string{`{"type": "hello"}`, `{"type": "tick"}`} {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("Failed to read scripted message: %v", err)
+		}
+		if string(data) != want {
+			t.Errorf("Expected %q, got %q", want, data)
+		}
+	}
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	if _, data, err := conn.ReadMessage(); err != nil || string(data) != "ping" {
+		t.Errorf("Expected the message to be echoed, got %q (%v)", data, err)
+	}
+
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	conn.Close()
+
+	// The disconnect is logged once the server notices the close
+	deadline := time.Now().Add(2 * time.Second)
+	for len(s.GetLogs()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	logs := s.GetLogs()
+	if len(logs) != 2 || logs[0].Event != "connect" || logs[1].Event != "disconnect" {
+		t.Fatalf("Expected connect and disconnect logs, got %+v", logs)
+	}
+	if !strings.Contains(logs[0].MatchedRule, "subprotocol graphql-ws") {
+		t.Errorf("Expected the subprotocol in the connect log, got %q", logs[0].MatchedRule)
+	}
+}
+
+func TestServer_WebSocketClose(t *testing.T) {
+	s := NewServer(&Config{Routes: []Route{{
+		Method:    "GET",
+		Path:      "/ws",
+		WebSocket: &WebSocketRoute{Messages: []WebSocketMessage{{Data: "bye"}}, Close: true},
+	}}}, t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(s.handleRequest))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, data, err := conn.ReadMessage(); err != nil || string(data) != "bye" {
+		t.Fatalf("Expected the scripted message, got %q (%v)", data, err)
+	}
+	if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("Expected a normal close after the script, got %v", err)
+	}
+
+	// Plain HTTP requests to a WebSocket route are rejected
+	resp, err := http.Get(server.URL + "/ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a non-upgrade request, got %d", resp.StatusCode)
+	}
+}
+
+func TestValidateConfig_WebSocket(t *testing.T) {
+	for _, route := range []Route{
+		{Method: "POST", Path: "/ws", WebSocket: &WebSocketRoute{}},
+		{Method: "GET", Path: "/ws", WebSocket: &WebSocketRoute{Loop: true, Messages: []WebSocketMessage{{Data: "x"}}}},
+	} {
+		if err := validateConfig(&Config{Routes: []Route{route}}); err == nil {
+			t.Errorf("Expected %+v to be rejected", route)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// deviceCodeGrantURN is the grant_type sent when polling the token endpoint (RFC 8628)
+	deviceCodeGrantURN = "urn:ietf:params:oauth:grant-type:device_code"
+	// DefaultDevicePollInterval is the polling interval in seconds when the server sends none
+	DefaultDevicePollInterval = 5
+	// deviceSlowDownIncrement is added to the interval on each slow_down response (seconds)
+	deviceSlowDownIncrement = 5
+)
+
+// pollIntervalUnit scales polling intervals (shortened in tests)
+var pollIntervalUnit = time.Second
+
+// DeviceAuthorization is the device authorization response (RFC 8628 section 3.2)
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURL         string `json:"verification_url,omitempty"` // Non-standard spelling used by some providers
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// RequestDeviceCode starts the device flow and returns the code the user enters on another device
+func RequestDeviceCode(config *Config) (*DeviceAuthorization, error) {
+	if config.DeviceAuthURL == "" {
+		return nil, fmt.Errorf("device authorization URL is required for the device_code grant")
+	}
+
+	data := url.Values{}
+	data.Set("client_id", config.ClientID)
+	if config.Scope != "" {
+		data.Set("scope", config.Scope)
+	}
+
+	status, body, err := postForm(context.Background(), config.DeviceAuthURL, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("device authorization failed with status %d: %s", status, string(body))
+	}
+
+	var auth DeviceAuthorization
+	if err := json.Unmarshal(body, &auth); err != nil {
+		return nil, fmt.Errorf("failed to parse device authorization response: %w", err)
+	}
+	if auth.VerificationURI == "" {
+		auth.VerificationURI = auth.VerificationURL
+	}
+	if auth.DeviceCode == "" || auth.UserCode == "" {
+		return nil, fmt.Errorf("device authorization response is missing device_code or user_code")
+	}
+
+	return &auth, nil
+}
+
+// PollDeviceToken polls the token endpoint until the user approves the device, the code
+// expires or ctx is cancelled. authorization_pending keeps polling and slow_down increases
+// the interval by 5 seconds, as required by RFC 8628 section 3.5.
+func PollDeviceToken(ctx context.Context, config *Config, auth *DeviceAuthorization) (*TokenResponse, error) {
+	interval := auth.Interval
+	if interval <= 0 {
+		interval = DefaultDevicePollInterval
+	}
+
+	expiresIn := time.Duration(auth.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = OAuthCallbackTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, expiresIn)
+	defer cancel()
+
+	data := url.Values{}
+	data.Set("grant_type", deviceCodeGrantURN)
+	data.Set("device_code", auth.DeviceCode)
+	data.Set("client_id", config.ClientID)
+	if config.ClientSecret != "" {
+		data.Set("client_secret", config.ClientSecret)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, deviceContextError(ctx)
+		case <-time.After(time.Duration(interval) * pollIntervalUnit):
+		}
+
+		status, body, err := postForm(ctx, config.TokenURL, data)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, deviceContextError(ctx)
+			}
+			return nil, fmt.Errorf("failed to poll token endpoint: %w", err)
+		}
+
+		// Some providers answer pending polls with 200 and an error field
+		var tokenErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.Unmarshal(body, &tokenErr)
+
+		switch tokenErr.Error {
+		case "":
+			if status != http.StatusOK {
+				return nil, fmt.Errorf("token request failed with status %d: %s", status, string(body))
+			}
+			var token TokenResponse
+			if err := json.Unmarshal(body, &token); err != nil {
+				return nil, fmt.Errorf("failed to parse token response: %w", err)
+			}
+			return &token, nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += deviceSlowDownIncrement
+		case "access_denied":
+			return nil, fmt.Errorf("authorization denied by user")
+		case "expired_token":
+			return nil, fmt.Errorf("device code expired before authorization")
+		default:
+			return nil, fmt.Errorf("token request failed: %s", strings.TrimSpace(tokenErr.Error+" "+tokenErr.Description))
+		}
+	}
+}
+
+// deviceContextError explains why polling stopped
+func deviceContextError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("device code expired before authorization")
+	}
+	return fmt.Errorf("device authorization cancelled: %w", ctx.Err())
+}
@@ -0,0 +1,88 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDeviceFlow(t *testing.T) {
+	pollIntervalUnit = time.Millisecond
+	defer func() { pollIntervalUnit = time.Second }()
+
+	var polls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("client_id") != "cli" {
+			t.Errorf("Unexpected client_id: %s", r.Form.Get("client_id"))
+		}
+		w.Write([]byte(`{"device_code":"dev-1","user_code":"ABCD-EFGH","verification_uri":"https://example.com/device","expires_in":600,"interval":1}`))
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != deviceCodeGrantURN || r.Form.Get("device_code") != "dev-1" {
+			t.Errorf("Unexpected poll: %v", r.Form)
+		}
+		switch atomic.AddInt32(&polls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"authorization_pending"}`))
+		case 2:
+			// Some providers answer with 200 and an error field
+			w.Write([]byte(`{"error":"slow_down"}`))
+		default:
+			w.Write([]byte(`{"access_token":"tok","token_type":"Bearer","expires_in":3600}`))
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := &Config{DeviceAuthURL: server.URL + "/device", TokenURL: server.URL + "/token", ClientID: "cli", GrantType: GrantDeviceCode}
+	auth, err := RequestDeviceCode(config)
+	if err != nil {
+		t.Fatalf("RequestDeviceCode failed: %v", err)
+	}
+	if auth.UserCode != "ABCD-EFGH" || auth.VerificationURI != "https://example.com/device" {
+		t.Errorf("Unexpected device authorization: %+v", auth)
+	}
+
+	token, err := PollDeviceToken(context.Background(), config, auth)
+	if err != nil {
+		t.Fatalf("PollDeviceToken failed: %v", err)
+	}
+	if token.AccessToken != "tok" || atomic.LoadInt32(&polls) != 3 {
+		t.Errorf("Expected token after 3 polls, got %+v after %d", token, polls)
+	}
+}
+
+func TestPollDeviceToken_Denied(t *testing.T) {
+	pollIntervalUnit = time.Millisecond
+	defer func() { pollIntervalUnit = time.Second }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"access_denied"}`))
+	}))
+	defer server.Close()
+
+	_, err := PollDeviceToken(context.Background(), &Config{TokenURL: server.URL}, &DeviceAuthorization{DeviceCode: "dev", Interval: 1})
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Expected access denied error, got %v", err)
+	}
+}
+
+func TestPollDeviceToken_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := PollDeviceToken(ctx, &Config{TokenURL: "http://127.0.0.1:1"}, &DeviceAuthorization{DeviceCode: "dev"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
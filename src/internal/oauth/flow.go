@@ -20,16 +20,25 @@ const (
 	TokenRequestTimeout = 30 * time.Second
 )
 
+// Supported OAuth grant types
+const (
+	GrantAuthorizationCode = "authorization_code" // Browser login with PKCE (default)
+	GrantClientCredentials = "client_credentials" // Machine-to-machine, no browser
+	GrantDeviceCode        = "device_code"        // RFC 8628 device flow for headless use
+)
+
 // Config holds OAuth configuration
 type Config struct {
-	AuthURL      string // Base auth URL (auto-build mode)
-	AuthEndpoint string // Complete auth URL (manual mode)
-	TokenURL     string
-	ClientID     string
-	ClientSecret string
-	RedirectURL  string
-	Scope        string
-	CallbackPort int
+	AuthURL       string // Base auth URL (auto-build mode)
+	AuthEndpoint  string // Complete auth URL (manual mode)
+	TokenURL      string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	Scope         string
+	CallbackPort  int
+	GrantType     string // authorization_code (default), client_credentials or device_code
+	DeviceAuthURL string // Device authorization endpoint (device_code grant)
 }
 
 // TokenResponse represents the OAuth token response
@@ -41,8 +50,16 @@ type TokenResponse struct {
 	Scope        string `json:"scope,omitempty"`
 }
 
-// StartFlow initiates the OAuth PKCE flow
+// StartFlow initiates the OAuth flow for the configured grant type
+// Authorization code uses PKCE with a browser; client credentials posts directly to the token URL
 func StartFlow(config *Config) (*TokenResponse, error) {
+	switch config.GrantType {
+	case GrantClientCredentials:
+		return requestClientCredentialsToken(config)
+	case GrantDeviceCode:
+		return nil, fmt.Errorf("the device_code grant needs user approval; use RequestDeviceCode and PollDeviceToken")
+	}
+
 	// Generate PKCE pair
 	pkce, err := GeneratePKCEPair()
 	if err != nil {
@@ -145,27 +162,70 @@ func exchangeCodeForToken(config *Config, code, verifier string) (*TokenResponse
 		data.Set("client_secret", config.ClientSecret)
 	}
 
-	req, err := http.NewRequest("POST", config.TokenURL, strings.NewReader(data.Encode()))
+	return requestToken(config, data)
+}
+
+// requestClientCredentialsToken requests a token with the client credentials grant
+func requestClientCredentialsToken(config *Config) (*TokenResponse, error) {
+	if config.ClientSecret == "" {
+		return nil, fmt.Errorf("client secret is required for the client_credentials grant")
+	}
+
+	data := url.Values{}
+	data.Set("grant_type", GrantClientCredentials)
+	data.Set("client_id", config.ClientID)
+	data.Set("client_secret", config.ClientSecret)
+	if config.Scope != "" {
+		data.Set("scope", config.Scope)
+	}
+
+	token, err := requestToken(config, data)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to request client credentials token: %w", err)
 	}
+	return token, nil
+}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+// RefreshToken exchanges a refresh token for a new access token
+func RefreshToken(config *Config, refreshToken string) (*TokenResponse, error) {
+	if refreshToken == "" {
+		return nil, fmt.Errorf("no refresh token available")
+	}
 
-	client := &http.Client{Timeout: TokenRequestTimeout}
-	resp, err := client.Do(req)
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", refreshToken)
+	data.Set("client_id", config.ClientID)
+
+	if config.ClientSecret != "" {
+		data.Set("client_secret", config.ClientSecret)
+	}
+	if config.Scope != "" {
+		data.Set("scope", config.Scope)
+	}
+
+	token, err := requestToken(config, data)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	// Some providers do not rotate refresh tokens; keep the previous one
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+
+	return token, nil
+}
+
+// requestToken posts form data to the token endpoint and parses the response
+func requestToken(config *Config, data url.Values) (*TokenResponse, error) {
+	status, body, err := postForm(context.Background(), config.TokenURL, data)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("token request failed with status %d: %s", status, string(body))
 	}
 
 	var token TokenResponse
@@ -176,6 +236,31 @@ func exchangeCodeForToken(config *Config, code, verifier string) (*TokenResponse
 	return &token, nil
 }
 
+// postForm posts url-encoded form data and returns the status code and body
+func postForm(ctx context.Context, endpoint string, data url.Values) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Timeout: TokenRequestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return resp.StatusCode, body, nil
+}
+
 // openBrowser opens the default browser with the given URL
 func openBrowser(url string) error {
 	var cmd *exec.Cmd
@@ -0,0 +1,88 @@
+package oauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStartFlow_ClientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Failed to parse form: %v", err)
+		}
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "svc" ||
+			r.Form.Get("client_secret") != "s3cret" || r.Form.Get("scope") != "read" {
+			t.Errorf("Unexpected token request: %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	token, err := StartFlow(&Config{
+		TokenURL:     server.URL,
+		ClientID:     "svc",
+		ClientSecret: "s3cret",
+		Scope:        "read",
+		GrantType:    GrantClientCredentials,
+	})
+	if err != nil {
+		t.Fatalf("StartFlow failed: %v", err)
+	}
+	if token.AccessToken != "abc" || token.ExpiresIn != 3600 {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+}
+
+func TestStartFlow_ClientCredentialsMissingSecret(t *testing.T) {
+	_, err := StartFlow(&Config{TokenURL: "http://127.0.0.1:1", ClientID: "svc", GrantType: GrantClientCredentials})
+	if err == nil || !strings.Contains(err.Error(), "client secret is required") {
+		t.Errorf("Expected missing client secret error, got %v", err)
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Failed to parse form: %v", err)
+		}
+		if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "old-refresh" ||
+			r.Form.Get("client_id") != "app" || r.Form.Get("client_secret") != "s3cret" {
+			t.Errorf("Unexpected refresh request: %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"fresh","token_type":"Bearer","expires_in":600}`))
+	}))
+	defer server.Close()
+
+	token, err := RefreshToken(&Config{TokenURL: server.URL, ClientID: "app", ClientSecret: "s3cret"}, "old-refresh")
+	if err != nil {
+		t.Fatalf("RefreshToken failed: %v", err)
+	}
+	if token.AccessToken != "fresh" || token.ExpiresIn != 600 {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+	// The provider did not rotate the refresh token
+	if token.RefreshToken != "old-refresh" {
+		t.Errorf("Expected the previous refresh token to be kept, got %q", token.RefreshToken)
+	}
+}
+
+func TestRefreshToken_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer server.Close()
+
+	_, err := RefreshToken(&Config{TokenURL: server.URL, ClientID: "app"}, "revoked")
+	if err == nil || !strings.Contains(err.Error(), "status 400") || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("Expected the rejected refresh with its body, got %v", err)
+	}
+
+	if _, err := RefreshToken(&Config{TokenURL: server.URL}, ""); err == nil {
+		t.Error("Expected an error without a refresh token")
+	}
+}
@@ -0,0 +1,234 @@
+package parser
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Dynamic variables are built-in {{$name}} functions evaluated on every resolution,
+// so each request (and each chain step) gets fresh values.
+// An argument may be a variable name, text with {{var}} placeholders, or one nested call:
+// {{$urlencode(query)}}, {{$base64({{user}}:{{pass}})}}, {{$urlencode($base64(token))}}
+
+// dynamicPattern matches {{$name}} and {{$name(args)}} where args may contain {{var}} placeholders
+// and one level of nested parentheses
+var dynamicPattern = regexp.MustCompile(`\{\{\s*\$([A-Za-z][\w.]*)\s*(?:\(((?:[^(){}]|\{\{[^{}]*\}\}|\((?:[^(){}]|\{\{[^{}]*\}\})*\))*)\))?\s*\}\}`)
+
+// nestedCallPattern matches a function call used as an argument: $name or $name(args)
+var nestedCallPattern = regexp.MustCompile(`^\$([A-Za-z][\w.]*)\s*(?:\(([^()]*)\))?$`)
+
+// argVariablePattern matches an argument that is a bare variable name
+var argVariablePattern = regexp.MustCompile(`^[A-Za-z_][\w.-]*$`)
+
+// randomStringAlphabet is the character set used by $randomString
+const randomStringAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// resolveDynamicVariables evaluates {{$function}} placeholders
+// Unknown functions and invalid arguments are reported as unresolved and replaced with an empty string
+func (vr *VariableResolver) resolveDynamicVariables(input string) string {
+	return dynamicPattern.ReplaceAllStringFunc(input, func(match string) string {
+		parts := dynamicPattern.FindStringSubmatch(match)
+		name := parts[1]
+
+		var args string
+		if inner := nestedCallPattern.FindStringSubmatch(strings.TrimSpace(parts[2])); inner != nil {
+			innerArgs := vr.resolveArgument(inner[2])
+			if vr.deferDynamic {
+				args = "$" + inner[1] + "(" + innerArgs + ")"
+			} else {
+				var err error
+				if args, err = evalDynamicVariable(inner[1], innerArgs); err != nil {
+					vr.unresolved = append(vr.unresolved, fmt.Sprintf("$%s (%v)", inner[1], err))
+					return ""
+				}
+			}
+		} else {
+			args = vr.resolveArgument(parts[2])
+		}
+
+		if vr.deferDynamic {
+			if strings.Contains(match, "(") {
+				return "{{$" + name + "(" + args + ")}}"
+			}
+			return "{{$" + name + "}}"
+		}
+
+		value, err := evalDynamicVariable(name, args)
+		if err != nil {
+			vr.unresolved = append(vr.unresolved, fmt.Sprintf("$%s (%v)", name, err))
+			return ""
+		}
+		return value
+	})
+}
+
+// resolveArgument resolves the {{var}} placeholders of a function argument
+// An argument that is exactly the name of a variable is replaced by its value
+func (vr *VariableResolver) resolveArgument(arg string) string {
+	arg = strings.TrimSpace(vr.resolveVariables(arg))
+	if argVariablePattern.MatchString(arg) {
+		if _, ok := vr.Lookup(arg); ok {
+			return vr.resolveVariables("{{" + arg + "}}")
+		}
+	}
+	return arg
+}
+
+// SetDeferDynamic keeps {{$function}} placeholders (with their arguments resolved) in the output
+// so they can be evaluated later, once per request, e.g. by the stress test executor
+func (vr *VariableResolver) SetDeferDynamic(deferDynamic bool) {
+	vr.deferDynamic = deferDynamic
+}
+
+// HasDynamicVariables reports whether a request uses {{$function}} placeholders
+func HasDynamicVariables(req *types.HttpRequest) bool {
+	values := []string{req.URL, req.Body}
+	for _, v := range req.Headers {
+		values = append(values, v)
+	}
+	if req.GraphQL != nil {
+		values = append(values, req.GraphQL.Query)
+		if data, err := json.Marshal(req.GraphQL.Variables); err == nil {
+			values = append(values, string(data))
+		}
+	}
+
+	for _, v := range values {
+		if dynamicPattern.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// evalDynamicVariable computes the value of a built-in function
+// Arguments are used as is, so values with surrounding whitespace keep it when encoded
+func evalDynamicVariable(name, args string) (string, error) {
+	if generator, ok := strings.CutPrefix(name, "faker."); ok {
+		return evalFaker(generator, args)
+	}
+
+	switch name {
+	case "uuid":
+		if args != "" {
+			return "", fmt.Errorf("takes no arguments")
+		}
+		return newUUID()
+
+	case "timestamp":
+		if args != "" {
+			return "", fmt.Errorf("takes no arguments")
+		}
+		return strconv.FormatInt(time.Now().Unix(), 10), nil
+
+	case "isoTimestamp":
+		if args != "" {
+			return "", fmt.Errorf("takes no arguments")
+		}
+		return time.Now().UTC().Format(time.RFC3339), nil
+
+	case "randomInt":
+		bounds := strings.Split(args, ",")
+		if len(bounds) != 2 {
+			return "", fmt.Errorf("expects (min,max)")
+		}
+		min, errMin := strconv.ParseInt(strings.TrimSpace(bounds[0]), 10, 64)
+		max, errMax := strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 64)
+		if errMin != nil || errMax != nil {
+			return "", fmt.Errorf("expects integer bounds")
+		}
+		if max < min {
+			return "", fmt.Errorf("max is less than min")
+		}
+		n, err := rand.Int(rand.Reader, new(big.Int).Add(big.NewInt(max-min), big.NewInt(1)))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random number: %w", err)
+		}
+		return strconv.FormatInt(min+n.Int64(), 10), nil
+
+	case "randomString":
+		length, err := strconv.Atoi(args)
+		if err != nil || length <= 0 {
+			return "", fmt.Errorf("expects a positive length")
+		}
+		return randomString(length)
+
+	case "base64":
+		return base64.StdEncoding.EncodeToString([]byte(args)), nil
+
+	case "urlencode":
+		return url.QueryEscape(args), nil
+
+	case "urlencodeComponent":
+		return encodeURIComponent(args), nil
+
+	case "jsonstring":
+		return jsonString(args)
+
+	default:
+		return "", fmt.Errorf("unknown function")
+	}
+}
+
+// newUUID returns a random RFC 4122 version 4 UUID
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate uuid: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // Variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// randomString returns a random alphanumeric string of the given length
+func randomString(length int) (string, error) {
+	max := big.NewInt(int64(len(randomStringAlphabet)))
+	var sb strings.Builder
+	sb.Grow(length)
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random string: %w", err)
+		}
+		sb.WriteByte(randomStringAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// encodeURIComponent percent-encodes every byte except the unreserved characters A-Z a-z 0-9 - _ . ! ~ * ' ( )
+func encodeURIComponent(value string) string {
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || strings.IndexByte("-_.!~*'()", c) >= 0 {
+			sb.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&sb, "%%%02X", c)
+	}
+	return sb.String()
+}
+
+// jsonString escapes a value for use inside a JSON string literal (without the surrounding quotes)
+func jsonString(value string) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", fmt.Errorf("failed to encode json string: %w", err)
+	}
+	encoded := strings.TrimSuffix(buf.String(), "\n")
+	return encoded[1 : len(encoded)-1], nil
+}
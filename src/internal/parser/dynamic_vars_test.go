@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"encoding/base64"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestResolve_DynamicVariables(t *testing.T) {
+	resolver := NewVariableResolver(nil, map[string]string{"user": "admin", "pass": "secret"}, nil, nil)
+
+	tests := []struct {
+		name  string
+		input string
+		check func(string) bool
+	}{
+		{"uuid", "{{$uuid}}", regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString},
+		{"timestamp", "{{$timestamp}}", func(s string) bool {
+			ts, err := strconv.ParseInt(s, 10, 64)
+			return err == nil && time.Since(time.Unix(ts, 0)) < time.Minute
+		}},
+		{"isoTimestamp", "{{ $isoTimestamp }}", func(s string) bool {
+			_, err := time.Parse(time.RFC3339, s)
+			return err == nil && strings.HasSuffix(s, "Z")
+		}},
+		{"randomInt", "{{$randomInt(1, 3)}}", func(s string) bool {
+			n, err := strconv.Atoi(s)
+			return err == nil && n >= 1 && n <= 3
+		}},
+		{"randomString", "{{$randomString(16)}}", regexp.MustCompile(`^[A-Za-z0-9]{16}$`).MatchString},
+		{"base64 literal", "{{$base64(hello)}}", func(s string) bool { return s == "aGVsbG8=" }},
+		{"base64 variables", "Basic {{$base64({{user}}:{{pass}})}}", func(s string) bool {
+			return s == "Basic "+base64.StdEncoding.EncodeToString([]byte("admin:secret"))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.Resolve(tt.input)
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			if !tt.check(got) {
+				t.Errorf("Unexpected value for %s: %q", tt.input, got)
+			}
+		})
+	}
+
+	if unresolved := resolver.GetUnresolvedVariables(); len(unresolved) != 0 {
+		t.Errorf("Expected no unresolved variables, got %v", unresolved)
+	}
+}
+
+func TestResolve_DynamicVariablesAreFresh(t *testing.T) {
+	resolver := NewVariableResolver(nil, nil, nil, nil)
+
+	first, _ := resolver.Resolve("{{$uuid}}")
+	second, _ := resolver.Resolve("{{$uuid}}")
+	if first == second {
+		t.Errorf("Expected a new uuid per resolution, got %q twice", first)
+	}
+}
+
+func TestResolve_InvalidDynamicVariables(t *testing.T) {
+	resolver := NewVariableResolver(nil, nil, nil, nil)
+
+	got, _ := resolver.Resolve("a{{$nope}}b{{$randomInt(5,1)}}c{{$randomString(x)}}d{{$uuid(1)}}e{{$randomInt(1}}f")
+	if got != "abcdef" {
+		t.Errorf("Expected invalid functions to be removed, got %q", got)
+	}
+
+	expected := []string{
+		"$nope (unknown function)",
+		"$randomInt (max is less than min)",
+		"$randomString (expects a positive length)",
+		"$uuid (takes no arguments)",
+		"$randomInt(1 (invalid function call)",
+	}
+	if unresolved := resolver.GetUnresolvedVariables(); !reflect.DeepEqual(unresolved, expected) {
+		t.Errorf("Expected unresolved %v, got %v", expected, unresolved)
+	}
+}
+
+func TestExtractVariableNames_SkipsDynamicVariables(t *testing.T) {
+	names := ExtractVariableNames("{{host}}/{{$uuid}}?auth={{$base64({{user}}:{{pass}})}}&n={{$randomInt(1,10)}}")
+	expected := []string{"host", "user", "pass"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+}
+
+func TestResolve_DynamicVariablesInValues(t *testing.T) {
+	email := "{{$faker.email}}"
+	loop := "{{$base64({{loop}})}}"
+	profileVars := map[string]types.VariableValue{
+		"email": {StringValue: &email},
+		"loop":  {StringValue: &loop},
+	}
+	resolver := NewVariableResolver(profileVars, nil, nil, nil)
+
+	got, _ := resolver.Resolve("{{email}}")
+	if !strings.Contains(got, "@") {
+		t.Errorf("Expected the function in the variable value to be evaluated, got %q", got)
+	}
+
+	// A self-referencing value is only expanded one level
+	if got, _ := resolver.Resolve("{{loop}}"); got == "" {
+		t.Error("Expected a value for the self-referencing variable")
+	}
+}
+
+func TestResolve_DeferDynamic(t *testing.T) {
+	resolver := NewVariableResolver(nil, map[string]string{"user": "admin"}, nil, nil)
+	resolver.SetDeferDynamic(true)
+
+	req := &types.HttpRequest{
+		Method: "POST",
+		URL:    "https://api.example.com/users",
+		Body:   `{"id": "{{$uuid}}", "auth": "{{$base64({{user}})}}"}`,
+	}
+	resolved, err := resolver.ResolveRequest(req)
+	if err != nil {
+		t.Fatalf("ResolveRequest failed: %v", err)
+	}
+
+	expected := `{"id": "{{$uuid}}", "auth": "{{$base64(admin)}}"}`
+	if resolved.Body != expected {
+		t.Errorf("Expected %q, got %q", expected, resolved.Body)
+	}
+	if !HasDynamicVariables(resolved) {
+		t.Error("Expected the deferred request to have dynamic variables")
+	}
+	if HasDynamicVariables(&types.HttpRequest{URL: "{{host}}/users"}) {
+		t.Error("Expected no dynamic variables in a plain request")
+	}
+}
+
+func TestResolve_EncodingFunctions(t *testing.T) {
+	sessionVars := map[string]string{
+		"baseUrl": "https://api.example.com",
+		"query":   "rock & roll/50% off",
+		"token":   "a b",
+		"quote":   `say "hi" <now>` + "\n",
+	}
+	resolver := NewVariableResolver(nil, sessionVars, nil, nil)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"{{baseUrl}}/search?q={{$urlencode(query)}}", "https://api.example.com/search?q=rock+%26+roll%2F50%25+off"},
+		{"{{ $urlencode( query ) }}", "rock+%26+roll%2F50%25+off"},
+		{"{{$urlencodeComponent(query)}}", "rock%20%26%20roll%2F50%25%20off"},
+		{"{{$urlencodeComponent({{token}}!)}}", "a%20b!"},
+		{`{"q": "{{$jsonstring(quote)}}"}`, `{"q": "say \"hi\" <now>\n"}`},
+		{"{{$urlencode(not a variable)}}", "not+a+variable"},
+		{"{{$urlencode($base64(token))}}", "YSBi"},
+		{"{{$urlencodeComponent( $base64( {{token}}? ) )}}", "YSBiPw%3D%3D"},
+	}
+
+	for _, tt := range tests {
+		got, err := resolver.Resolve(tt.input)
+		if err != nil {
+			t.Fatalf("Resolve(%q) failed: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	if unresolved := resolver.GetUnresolvedVariables(); len(unresolved) != 0 {
+		t.Errorf("Expected no unresolved variables, got %v", unresolved)
+	}
+}
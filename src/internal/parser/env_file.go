@@ -0,0 +1,496 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Env file formats recognized by LoadEnvFile
+const (
+	EnvFormatDotenv = "dotenv"
+	EnvFormatJSON   = "json"
+	EnvFormatYAML   = "yaml"
+	EnvFormatTOML   = "toml"
+)
+
+var (
+	// envKeyPattern matches dotenv keys (dots allow names produced by nested formats)
+	envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+	// tomlBareKeyPattern matches one part of a TOML key
+	tomlBareKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	// tomlTablePattern matches a TOML table header line
+	tomlTablePattern = regexp.MustCompile(`^\[\s*([^\[\]]+?)\s*\]$`)
+)
+
+// LoadEnvFile loads environment variables from a dotenv, JSON, YAML or TOML file
+// Nested objects and tables are flattened into dotted names (db.host), arrays are JSON-encoded
+func LoadEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open env file: %w", err)
+	}
+
+	format := DetectEnvFormat(path, data)
+	var envVars map[string]string
+	switch format {
+	case EnvFormatJSON:
+		envVars, err = parseJSONEnv(data)
+	case EnvFormatYAML:
+		envVars, err = parseYAMLEnv(data)
+	case EnvFormatTOML:
+		envVars, err = parseTOMLEnv(data)
+	default:
+		envVars, err = parseDotenv(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s env file %s: %w", format, path, err)
+	}
+
+	return envVars, nil
+}
+
+// DetectEnvFormat returns the format of an env file from its extension, or from its content
+// for other names (.env, .env.local): a JSON object, a TOML table header, a YAML "key: value"
+// first line, and dotenv otherwise
+func DetectEnvFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return EnvFormatJSON
+	case ".yaml", ".yml":
+		return EnvFormatYAML
+	case ".toml":
+		return EnvFormatTOML
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// A table header anywhere is TOML, dotenv and YAML have no such line
+		if tomlTablePattern.MatchString(line) {
+			return EnvFormatTOML
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return EnvFormatDotenv
+	}
+
+	first := lines[0]
+	if strings.HasPrefix(first, "{") {
+		return EnvFormatJSON
+	}
+	// The first separator decides: "KEY=value" is dotenv, "key: value" is YAML
+	eq := strings.Index(first, "=")
+	colon := strings.Index(first+" ", ": ") // A trailing colon opens a nested mapping
+	if colon > 0 && (eq < 0 || colon < eq) && !strings.HasPrefix(first, "export ") {
+		return EnvFormatYAML
+	}
+	return EnvFormatDotenv
+}
+
+// parseDotenv parses KEY=value lines with optional "export" prefix, quotes and comments
+func parseDotenv(data []byte) (map[string]string, error) {
+	envVars := make(map[string]string)
+
+	for i, rawLine := range strings.Split(string(data), "\n") {
+		lineNum := i + 1
+		line := strings.TrimSpace(rawLine)
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=value, got %q", lineNum, line)
+		}
+		key = strings.TrimSpace(key)
+		if !envKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNum, key)
+		}
+
+		value, err := parseDotenvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		envVars[key] = value
+	}
+
+	return envVars, nil
+}
+
+// parseDotenvValue unquotes a dotenv value
+// Double quotes support \n, \t, \" and \\ escapes, single quotes are literal,
+// unquoted values end at an inline " #" comment
+func parseDotenvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	quote := value[0]
+	if quote != '"' && quote != '\'' {
+		if idx := strings.Index(value, " #"); idx >= 0 {
+			value = value[:idx]
+		}
+		return strings.TrimSpace(value), nil
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		if c == quote {
+			rest := strings.TrimSpace(value[i+1:])
+			if rest != "" && !strings.HasPrefix(rest, "#") {
+				return "", fmt.Errorf("unexpected text after quoted value: %q", rest)
+			}
+			return b.String(), nil
+		}
+		if c == '\\' && quote == '"' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			default:
+				b.WriteByte(value[i])
+			}
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return "", fmt.Errorf("unterminated quoted value")
+}
+
+// parseJSONEnv parses a JSON object; syntax errors report the line of the offending byte
+func parseJSONEnv(data []byte) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // Keep numbers as written
+	var root any
+	if err := decoder.Decode(&root); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, fmt.Errorf("line %d: %w", lineAtOffset(data, syntaxErr.Offset), err)
+		}
+		return nil, err
+	}
+
+	object, ok := root.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an object at the top level")
+	}
+
+	envVars := make(map[string]string)
+	if err := flattenEnvValue(envVars, "", object); err != nil {
+		return nil, err
+	}
+	return envVars, nil
+}
+
+// parseYAMLEnv parses a YAML mapping, keeping scalars exactly as written
+func parseYAMLEnv(data []byte) (map[string]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err // yaml errors already name the line
+	}
+
+	envVars := make(map[string]string)
+	if len(doc.Content) == 0 {
+		return envVars, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping at the top level", root.Line)
+	}
+	if err := flattenYAMLNode(envVars, "", root); err != nil {
+		return nil, err
+	}
+	return envVars, nil
+}
+
+// flattenYAMLNode adds the entries of a mapping node under prefix
+func flattenYAMLNode(envVars map[string]string, prefix string, node *yaml.Node) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := joinEnvKey(prefix, node.Content[i].Value)
+		value := node.Content[i+1]
+		if value.Kind == yaml.AliasNode {
+			value = value.Alias
+		}
+
+		switch value.Kind {
+		case yaml.MappingNode:
+			if err := flattenYAMLNode(envVars, key, value); err != nil {
+				return err
+			}
+		case yaml.ScalarNode:
+			if value.Tag == "!!null" {
+				envVars[key] = ""
+			} else {
+				envVars[key] = value.Value
+			}
+		default:
+			var decoded any
+			if err := value.Decode(&decoded); err != nil {
+				return fmt.Errorf("line %d: %w", value.Line, err)
+			}
+			encoded, err := json.Marshal(decoded)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", value.Line, err)
+			}
+			envVars[key] = string(encoded)
+		}
+	}
+	return nil
+}
+
+// flattenEnvValue adds a decoded JSON or TOML value under prefix
+func flattenEnvValue(envVars map[string]string, prefix string, value any) error {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if err := flattenEnvValue(envVars, joinEnvKey(prefix, key), child); err != nil {
+				return err
+			}
+		}
+	case string:
+		envVars[prefix] = v
+	case nil:
+		envVars[prefix] = ""
+	case json.Number:
+		envVars[prefix] = v.String()
+	case bool:
+		envVars[prefix] = strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w", prefix, err)
+		}
+		envVars[prefix] = string(encoded)
+	}
+	return nil
+}
+
+// joinEnvKey joins a nested key to its parent with a dot
+func joinEnvKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// lineAtOffset returns the 1-based line of a byte offset
+func lineAtOffset(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// parseTOMLEnv parses the subset of TOML used for configuration values:
+// key/value pairs, [table] headers, dotted and quoted keys, strings, numbers,
+// booleans, dates and single-line arrays
+func parseTOMLEnv(data []byte) (map[string]string, error) {
+	envVars := make(map[string]string)
+	table := ""
+
+	for i, rawLine := range strings.Split(string(data), "\n") {
+		lineNum := i + 1
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") {
+			return nil, fmt.Errorf("line %d: arrays of tables are not supported", lineNum)
+		}
+		if strings.HasPrefix(line, "[") {
+			match := tomlTablePattern.FindStringSubmatch(stripTOMLComment(line))
+			if match == nil {
+				return nil, fmt.Errorf("line %d: invalid table header %q", lineNum, line)
+			}
+			name, err := parseTOMLKey(match[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			table = name
+			continue
+		}
+
+		rawKey, rawValue, found := cutTOMLKeyValue(line)
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key = value, got %q", lineNum, line)
+		}
+		key, err := parseTOMLKey(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		value, err := parseTOMLValue(stripTOMLComment(rawValue))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		if err := flattenEnvValue(envVars, joinEnvKey(table, key), value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+	}
+
+	return envVars, nil
+}
+
+// cutTOMLKeyValue splits a line at the first "=" outside a quoted key
+func cutTOMLKeyValue(line string) (key, value string, found bool) {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '=':
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// parseTOMLKey normalizes a bare, quoted or dotted key to its dotted form
+func parseTOMLKey(raw string) (string, error) {
+	var parts []string
+	for _, part := range splitTOMLOutsideQuotes(raw, '.') {
+		part = strings.TrimSpace(part)
+		switch {
+		case len(part) >= 2 && part[0] == '"' && part[len(part)-1] == '"':
+			unquoted, err := strconv.Unquote(part)
+			if err != nil {
+				return "", fmt.Errorf("invalid key %q", raw)
+			}
+			parts = append(parts, unquoted)
+		case len(part) >= 2 && part[0] == '\'' && part[len(part)-1] == '\'':
+			parts = append(parts, part[1:len(part)-1])
+		case tomlBareKeyPattern.MatchString(part):
+			parts = append(parts, part)
+		default:
+			return "", fmt.Errorf("invalid key %q", raw)
+		}
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// parseTOMLValue decodes a single-line TOML value into a string, bool, json.Number or []any
+// Dates and times are kept as written
+func parseTOMLValue(raw string) (any, error) {
+	switch {
+	case raw == "":
+		return nil, fmt.Errorf("missing value")
+	case strings.HasPrefix(raw, `"""`) || strings.HasPrefix(raw, "'''"):
+		return nil, fmt.Errorf("multi-line strings are not supported")
+	case strings.HasPrefix(raw, "{"):
+		return nil, fmt.Errorf("inline tables are not supported, use a [table] header")
+	case raw[0] == '"':
+		value, err := strconv.Unquote(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", raw)
+		}
+		return value, nil
+	case raw[0] == '\'':
+		if len(raw) < 2 || raw[len(raw)-1] != '\'' {
+			return nil, fmt.Errorf("unterminated string %s", raw)
+		}
+		return raw[1 : len(raw)-1], nil
+	case raw[0] == '[':
+		if raw[len(raw)-1] != ']' {
+			return nil, fmt.Errorf("arrays must be on one line")
+		}
+		items := []any{}
+		for _, item := range splitTOMLOutsideQuotes(raw[1:len(raw)-1], ',') {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue // Trailing comma
+			}
+			value, err := parseTOMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case raw == "true" || raw == "false":
+		return raw == "true", nil
+	}
+
+	// Numbers (underscores are digit separators), dates and times
+	number := strings.ReplaceAll(raw, "_", "")
+	if _, err := strconv.ParseFloat(number, 64); err == nil {
+		return json.Number(number), nil
+	}
+	if _, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return json.Number(raw), nil // Hex, octal and binary stay as written
+	}
+	if raw[0] >= '0' && raw[0] <= '9' && !strings.ContainsAny(raw, " \t") {
+		return raw, nil
+	}
+	return nil, fmt.Errorf("invalid value %q (strings must be quoted)", raw)
+}
+
+// stripTOMLComment removes a trailing # comment outside of strings
+func stripTOMLComment(value string) string {
+	var quote byte
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return strings.TrimSpace(value[:i])
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+// splitTOMLOutsideQuotes splits s at sep, ignoring separators inside strings
+func splitTOMLOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
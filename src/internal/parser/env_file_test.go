@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadEnvFile_Formats(t *testing.T) {
+	expected := map[string]string{
+		"API_KEY":  "secret 123",
+		"BASE_URL": "https://api.example.com",
+		"PORT":     "8080",
+		"DEBUG":    "true",
+		"db.host":  "localhost",
+		"TAGS":     `["a","b"]`,
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"dotenv", ".env", `# Local settings
+export API_KEY="secret 123"
+BASE_URL=https://api.example.com # inline comment
+
+PORT=8080
+DEBUG='true'
+db.host=localhost
+TAGS=["a","b"]
+`},
+		{"json", "env.json", `{
+  "API_KEY": "secret 123",
+  "BASE_URL": "https://api.example.com",
+  "PORT": 8080,
+  "DEBUG": true,
+  "db": {"host": "localhost"},
+  "TAGS": ["a", "b"]
+}`},
+		{"yaml", "env.yaml", `# Local settings
+API_KEY: secret 123
+BASE_URL: https://api.example.com
+PORT: 8080
+DEBUG: true
+db:
+  host: localhost
+TAGS: [a, b]
+`},
+		{"toml", "env.toml", `# Local settings
+API_KEY = "secret 123"
+BASE_URL = 'https://api.example.com'
+PORT = 8_080
+DEBUG = true # inline comment
+TAGS = ["a", "b",]
+
+[db]
+host = "localhost"
+`},
+		{"json by content", ".env.local", `{"API_KEY": "secret 123", "BASE_URL": "https://api.example.com", "PORT": 8080, "DEBUG": true, "db": {"host": "localhost"}, "TAGS": ["a", "b"]}`},
+		{"yaml by content", ".env.local", "API_KEY: secret 123\nBASE_URL: https://api.example.com\nPORT: 8080\nDEBUG: true\ndb:\n  host: localhost\nTAGS: [a, b]\n"},
+		{"toml by content", ".env.local", "API_KEY = \"secret 123\"\nBASE_URL = \"https://api.example.com\"\nPORT = 8080\nDEBUG = true\nTAGS = [\"a\", \"b\"]\n[db]\nhost = \"localhost\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envVars, err := LoadEnvFile(createTempFile(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("LoadEnvFile failed: %v", err)
+			}
+			if !reflect.DeepEqual(envVars, expected) {
+				t.Errorf("Expected %v, got %v", expected, envVars)
+			}
+		})
+	}
+}
+
+func TestLoadEnvFile_ErrorLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		wantLine string
+	}{
+		{"dotenv missing separator", ".env", "# comment\nAPI_KEY=abc\nBROKEN\n", "line 3"},
+		{"dotenv unterminated quote", ".env", "API_KEY=\"abc\n", "line 1"},
+		{"dotenv invalid name", ".env", "\n\nMY KEY=abc\n", "line 3"},
+		{"json syntax", "env.json", "{\n  \"a\": 1,\n  \"b\": \n}\n", "line 4"},
+		{"yaml syntax", "env.yaml", "a: 1\nb: [unclosed\n", "line"},
+		{"toml unquoted string", "env.toml", "a = 1\nb = hello world\n", "line 2"},
+		{"toml inline table", "env.toml", "a = { b = 1 }\n", "line 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadEnvFile(createTempFile(t, tt.file, tt.content))
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantLine) {
+				t.Errorf("Expected error to mention %q, got %v", tt.wantLine, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Faker generators produce realistic test data through {{$faker.name}} dynamic variables.
+// Values are random by default; SetFakerSeed makes the sequence reproducible (--seed).
+
+var (
+	fakerMu  sync.Mutex
+	fakerRng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+)
+
+// fakerGenerator produces one value from the shared generator and the call arguments
+type fakerGenerator func(rng *rand.Rand, args string) (string, error)
+
+// fakerGenerators maps generator names to their implementation
+var fakerGenerators = map[string]fakerGenerator{
+	"email":   fakeEmail,
+	"name":    fakeName,
+	"phone":   fakePhone,
+	"address": fakeAddress,
+	"lorem":   fakeLorem,
+}
+
+// fakerUsage documents generators that accept arguments
+var fakerUsage = map[string]string{
+	"lorem": "lorem(words)",
+}
+
+var (
+	fakerFirstNames = []string{"Alice", "Bruno", "Chloe", "Daniel", "Emma", "Felix", "Grace", "Hugo", "Iris", "Jonas", "Kara", "Liam", "Maya", "Noah", "Olivia", "Paul", "Rosa", "Samuel", "Tessa", "Victor"}
+	fakerLastNames  = []string{"Anderson", "Bernard", "Chen", "Dubois", "Evans", "Fischer", "Garcia", "Hansen", "Ivanova", "Jensen", "Kim", "Lopez", "Martin", "Nguyen", "Olsen", "Patel", "Rossi", "Silva", "Tanaka", "Weber"}
+	fakerDomains    = []string{"example.com", "example.org", "example.net", "test.dev"}
+	fakerStreets    = []string{"Maple Street", "Oak Avenue", "Pine Road", "Cedar Lane", "Elm Drive", "Lakeview Boulevard", "Hillcrest Way", "River Road"}
+	fakerCities     = []string{"Springfield", "Riverside", "Fairview", "Franklin", "Greenville", "Madison", "Georgetown", "Salem"}
+	fakerStates     = []string{"CA", "NY", "TX", "WA", "IL", "OR", "CO", "MA"}
+	fakerLoremWords = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim", "ad", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip"}
+)
+
+// SetFakerSeed makes faker generators return the same sequence of values for a given seed
+func SetFakerSeed(seed int64) {
+	fakerMu.Lock()
+	defer fakerMu.Unlock()
+	fakerRng = rand.New(rand.NewPCG(uint64(seed), uint64(seed)))
+}
+
+// FakerGenerators returns the available generators as {{$faker.*}} placeholders, sorted by name
+func FakerGenerators() []string {
+	names := make([]string, 0, len(fakerGenerators))
+	for name := range fakerGenerators {
+		if usage, ok := fakerUsage[name]; ok {
+			name = usage
+		}
+		names = append(names, "{{$faker."+name+"}}")
+	}
+	sort.Strings(names)
+	return names
+}
+
+// evalFaker runs the named generator
+func evalFaker(name, args string) (string, error) {
+	generator, ok := fakerGenerators[name]
+	if !ok {
+		return "", fmt.Errorf("unknown faker generator")
+	}
+
+	fakerMu.Lock()
+	defer fakerMu.Unlock()
+	return generator(fakerRng, args)
+}
+
+// pick returns a random element of values
+func pick(rng *rand.Rand, values []string) string {
+	return values[rng.IntN(len(values))]
+}
+
+// noFakerArgs rejects arguments for generators that take none
+func noFakerArgs(args string) error {
+	if args != "" {
+		return fmt.Errorf("takes no arguments")
+	}
+	return nil
+}
+
+func fakeName(rng *rand.Rand, args string) (string, error) {
+	if err := noFakerArgs(args); err != nil {
+		return "", err
+	}
+	return pick(rng, fakerFirstNames) + " " + pick(rng, fakerLastNames), nil
+}
+
+func fakeEmail(rng *rand.Rand, args string) (string, error) {
+	if err := noFakerArgs(args); err != nil {
+		return "", err
+	}
+	first := strings.ToLower(pick(rng, fakerFirstNames))
+	last := strings.ToLower(pick(rng, fakerLastNames))
+	return fmt.Sprintf("%s.%s%d@%s", first, last, rng.IntN(100), pick(rng, fakerDomains)), nil
+}
+
+func fakePhone(rng *rand.Rand, args string) (string, error) {
+	if err := noFakerArgs(args); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("+1-555-%03d-%04d", rng.IntN(1000), rng.IntN(10000)), nil
+}
+
+func fakeAddress(rng *rand.Rand, args string) (string, error) {
+	if err := noFakerArgs(args); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d %s, %s, %s %05d",
+		1+rng.IntN(9999), pick(rng, fakerStreets), pick(rng, fakerCities), pick(rng, fakerStates), rng.IntN(100000)), nil
+}
+
+// fakeLorem returns the given number of lorem ipsum words (10 by default)
+func fakeLorem(rng *rand.Rand, args string) (string, error) {
+	count := 10
+	if args != "" {
+		n, err := strconv.Atoi(args)
+		if err != nil || n <= 0 {
+			return "", fmt.Errorf("expects a positive word count")
+		}
+		count = n
+	}
+
+	words := make([]string, count)
+	for i := range words {
+		words[i] = pick(rng, fakerLoremWords)
+	}
+	return strings.Join(words, " "), nil
+}
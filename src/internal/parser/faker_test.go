@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestResolve_FakerGenerators(t *testing.T) {
+	resolver := NewVariableResolver(nil, nil, nil, nil)
+
+	tests := []struct {
+		input   string
+		pattern string
+	}{
+		{"{{$faker.email}}", `^[a-z]+\.[a-z]+\d+@[a-z]+\.[a-z]+$`},
+		{"{{$faker.name}}", `^[A-Z][a-z]+ [A-Z][a-z]+$`},
+		{"{{$faker.phone}}", `^\+1-555-\d{3}-\d{4}$`},
+		{"{{$faker.address}}", `^\d+ [A-Za-z ]+, [A-Za-z]+, [A-Z]{2} \d{5}$`},
+		{"{{$faker.lorem(20)}}", `^[a-z]+( [a-z]+){19}$`},
+		{"{{$faker.lorem}}", `^[a-z]+( [a-z]+){9}$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := resolver.Resolve(tt.input)
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			if !regexp.MustCompile(tt.pattern).MatchString(got) {
+				t.Errorf("Unexpected value for %s: %q", tt.input, got)
+			}
+		})
+	}
+
+	if unresolved := resolver.GetUnresolvedVariables(); len(unresolved) != 0 {
+		t.Errorf("Expected no unresolved variables, got %v", unresolved)
+	}
+}
+
+func TestSetFakerSeed_Reproducible(t *testing.T) {
+	input := "{{$faker.name}} {{$faker.email}} {{$faker.lorem(5)}}"
+	generate := func() string {
+		out, err := NewVariableResolver(nil, nil, nil, nil).Resolve(input)
+		if err != nil {
+			t.Fatalf("Resolve failed: %v", err)
+		}
+		return out
+	}
+
+	SetFakerSeed(42)
+	first := generate()
+	SetFakerSeed(42)
+	second := generate()
+	SetFakerSeed(7)
+	other := generate()
+
+	if first != second {
+		t.Errorf("Expected the same values for the same seed, got %q and %q", first, second)
+	}
+	if first == other {
+		t.Errorf("Expected different values for a different seed, got %q", first)
+	}
+}
+
+func TestResolve_UnknownFakerGenerator(t *testing.T) {
+	resolver := NewVariableResolver(nil, nil, nil, nil)
+
+	got, _ := resolver.Resolve("x{{$faker.nope}}y")
+	if got != "xy" {
+		t.Errorf("Expected the unknown generator to be removed, got %q", got)
+	}
+	unresolved := resolver.GetUnresolvedVariables()
+	if len(unresolved) != 1 || unresolved[0] != "$faker.nope (unknown faker generator)" {
+		t.Errorf("Expected an unknown generator warning, got %v", unresolved)
+	}
+}
+
+func TestFakerGenerators(t *testing.T) {
+	generators := strings.Join(FakerGenerators(), " ")
+	expected := "{{$faker.address}} {{$faker.email}} {{$faker.lorem(words)}} {{$faker.name}} {{$faker.phone}}"
+	if generators != expected {
+		t.Errorf("Expected %q, got %q", expected, generators)
+	}
+}
@@ -2,8 +2,10 @@ package parser
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/studiowebux/restcli/internal/types"
@@ -20,7 +22,9 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 	var requests []types.HttpRequest
 	var currentRequest *types.HttpRequest
 	var bodyLines []string
+	var variablesLines []string
 	inBody := false
+	inVariables := false
 
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
@@ -33,8 +37,8 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 		if strings.HasPrefix(line, "###") {
 			// Save previous request if exists
 			if currentRequest != nil {
-				if inBody && len(bodyLines) > 0 {
-					currentRequest.Body = strings.Join(bodyLines, "\n")
+				if err := finalizeRequest(currentRequest, bodyLines, variablesLines); err != nil {
+					return nil, err
 				}
 				requests = append(requests, *currentRequest)
 			}
@@ -46,7 +50,9 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 				DocumentationLines: []string{}, // Store raw documentation lines for lazy parsing
 			}
 			bodyLines = []string{}
+			variablesLines = nil
 			inBody = false
+			inVariables = false
 			continue
 		}
 
@@ -58,6 +64,10 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 				currentRequest.Protocol = strings.TrimSpace(strings.TrimPrefix(trimmed, "@protocol "))
 				continue
 			}
+			if strings.HasPrefix(trimmed, "@profile ") {
+				currentRequest.Profile = strings.TrimSpace(strings.TrimPrefix(trimmed, "@profile"))
+				continue
+			}
 			if strings.HasPrefix(trimmed, "@filter ") {
 				currentRequest.Filter = strings.TrimSpace(strings.TrimPrefix(trimmed, "@filter"))
 				continue
@@ -76,6 +86,96 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 				currentRequest.Streaming = value == "true"
 				continue
 			}
+			if strings.HasPrefix(trimmed, "@streamFormat ") {
+				currentRequest.StreamFormat = strings.TrimSpace(strings.TrimPrefix(trimmed, "@streamFormat"))
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@httpVersion ") {
+				currentRequest.HTTPVersion = strings.TrimSpace(strings.TrimPrefix(trimmed, "@httpVersion"))
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@requestCompression ") {
+				currentRequest.RequestCompression = strings.TrimSpace(strings.TrimPrefix(trimmed, "@requestCompression"))
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@retryCount ") {
+				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@retryCount"))
+				if count, err := strconv.Atoi(value); err == nil && count >= 0 {
+					currentRequest.RetryCount = count
+				}
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@retryBackoffMs ") {
+				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@retryBackoffMs"))
+				if backoff, err := strconv.Atoi(value); err == nil && backoff >= 0 {
+					currentRequest.RetryBackoffMs = backoff
+				}
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@retryOnStatus ") {
+				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@retryOnStatus"))
+				currentRequest.RetryOnStatus = ParseStatusCodes(value)
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@retryOnNetworkError ") {
+				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@retryOnNetworkError"))
+				currentRequest.RetryOnNetworkError = value == "true"
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@retryUnsafe ") {
+				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@retryUnsafe"))
+				currentRequest.RetryUnsafe = value == "true"
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@followRedirects ") {
+				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@followRedirects"))
+				follow := value == "true"
+				currentRequest.FollowRedirects = &follow
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@maxRedirects ") {
+				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@maxRedirects"))
+				if max, err := strconv.Atoi(value); err == nil && max > 0 {
+					currentRequest.MaxRedirects = max
+				}
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@resolve ") {
+				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@resolve"))
+				// Parse host:port address format (validated when the request runs)
+				parts := strings.Fields(value)
+				if len(parts) == 2 {
+					if currentRequest.Resolve == nil {
+						currentRequest.Resolve = make(map[string]string)
+					}
+					currentRequest.Resolve[parts[0]] = parts[1]
+				}
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@proxy ") {
+				currentRequest.ProxyURL = strings.TrimSpace(strings.TrimPrefix(trimmed, "@proxy"))
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@noProxy ") {
+				currentRequest.NoProxy = strings.TrimSpace(strings.TrimPrefix(trimmed, "@noProxy"))
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@operationName ") {
+				if currentRequest.GraphQL == nil {
+					currentRequest.GraphQL = &types.GraphQLRequest{}
+				}
+				currentRequest.GraphQL.OperationName = strings.TrimSpace(strings.TrimPrefix(trimmed, "@operationName"))
+				continue
+			}
+			if trimmed == "@variables" {
+				// Following body lines are the GraphQL variables JSON object
+				if currentRequest.GraphQL == nil {
+					currentRequest.GraphQL = &types.GraphQLRequest{}
+				}
+				inBody = true
+				inVariables = true
+				continue
+			}
 			if strings.HasPrefix(trimmed, "@confirmation ") {
 				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@confirmation"))
 				currentRequest.RequiresConfirmation = value == "true"
@@ -103,6 +203,42 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 					currentRequest.TLS.InsecureSkipVerify = value == "true"
 					continue
 				}
+				if strings.HasPrefix(trimmed, "@tls.pinnedCertSha256 ") {
+					value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@tls.pinnedCertSha256"))
+					currentRequest.TLS.PinnedCertSHA256 = append(currentRequest.TLS.PinnedCertSHA256, strings.Fields(strings.ReplaceAll(value, ",", " "))...)
+					continue
+				}
+			}
+			// Check for @sign.* annotations
+			if strings.HasPrefix(trimmed, "@sign.") {
+				if currentRequest.Signing == nil {
+					currentRequest.Signing = &types.SigningConfig{}
+				}
+				fields := map[string]*string{
+					"@sign.algorithm":    &currentRequest.Signing.Algorithm,
+					"@sign.accessKey":    &currentRequest.Signing.AccessKey,
+					"@sign.secretKey":    &currentRequest.Signing.SecretKey,
+					"@sign.sessionToken": &currentRequest.Signing.SessionToken,
+					"@sign.region":       &currentRequest.Signing.Region,
+					"@sign.service":      &currentRequest.Signing.Service,
+					"@sign.header":       &currentRequest.Signing.Header,
+				}
+				key, value, _ := strings.Cut(trimmed, " ")
+				if dest, ok := fields[key]; ok {
+					*dest = strings.TrimSpace(value)
+					continue
+				}
+			}
+			// Check for @protobuf annotation (descriptor set and message decoding the response)
+			if strings.HasPrefix(trimmed, "@protobuf ") {
+				fields := strings.Fields(strings.TrimPrefix(trimmed, "@protobuf"))
+				if len(fields) > 0 {
+					currentRequest.Protobuf = &types.ProtobufSchema{DescriptorFile: fields[0]}
+					if len(fields) > 1 {
+						currentRequest.Protobuf.Message = fields[1]
+					}
+				}
+				continue
 			}
 			// Check for validation annotations
 			if strings.HasPrefix(trimmed, "@expectedStatusCodes ") {
@@ -151,6 +287,14 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 				}
 				continue
 			}
+			if strings.HasPrefix(trimmed, "@maxDuration ") {
+				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@maxDuration"))
+				// Milliseconds, with an optional ms suffix
+				if ms, err := strconv.Atoi(strings.TrimSuffix(value, "ms")); err == nil && ms > 0 {
+					currentRequest.MaxDurationMs = ms
+				}
+				continue
+			}
 			// Check for chaining annotations
 			if strings.HasPrefix(trimmed, "@depends ") {
 				value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@depends"))
@@ -173,6 +317,29 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 				}
 				continue
 			}
+			if strings.HasPrefix(trimmed, "@forEach ") {
+				currentRequest.ForEach = strings.TrimSpace(strings.TrimPrefix(trimmed, "@forEach"))
+				continue
+			}
+			// Check for hook annotations: @before.<var> captures stdout into {{var}}
+			if strings.HasPrefix(trimmed, "@before ") || strings.HasPrefix(trimmed, "@before.") {
+				key, command, _ := strings.Cut(trimmed, " ")
+				if command = strings.TrimSpace(command); command != "" {
+					currentRequest.BeforeHooks = append(currentRequest.BeforeHooks, types.RequestHook{
+						Command:  command,
+						Variable: strings.TrimPrefix(strings.TrimPrefix(key, "@before"), "."),
+					})
+				}
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@after ") {
+				currentRequest.AfterHooks = append(currentRequest.AfterHooks, strings.TrimSpace(strings.TrimPrefix(trimmed, "@after")))
+				continue
+			}
+			if strings.HasPrefix(trimmed, "@condition ") {
+				currentRequest.Condition = strings.TrimSpace(strings.TrimPrefix(trimmed, "@condition"))
+				continue
+			}
 			currentRequest.DocumentationLines = append(currentRequest.DocumentationLines, line)
 			continue
 		}
@@ -182,7 +349,7 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 			parts := strings.Fields(line)
 			if len(parts) >= 2 {
 				method := strings.ToUpper(parts[0])
-				validMethods := []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"}
+				validMethods := []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "GRPC"}
 				for _, vm := range validMethods {
 					if method == vm {
 						currentRequest.Method = method
@@ -246,6 +413,10 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 		}
 
 		// Body content
+		if currentRequest != nil && inVariables {
+			variablesLines = append(variablesLines, line)
+			continue
+		}
 		if currentRequest != nil && inBody {
 			bodyLines = append(bodyLines, line)
 		}
@@ -253,8 +424,8 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 
 	// Save last request
 	if currentRequest != nil {
-		if inBody && len(bodyLines) > 0 {
-			currentRequest.Body = strings.Join(bodyLines, "\n")
+		if err := finalizeRequest(currentRequest, bodyLines, variablesLines); err != nil {
+			return nil, err
 		}
 		requests = append(requests, *currentRequest)
 	}
@@ -266,6 +437,60 @@ func ParseHTTPFile(filePath string) ([]types.HttpRequest, error) {
 	return requests, nil
 }
 
+// finalizeRequest sets the collected body on a request
+// GraphQL requests (@operationName or @variables) get the body as their query
+func finalizeRequest(req *types.HttpRequest, bodyLines, variablesLines []string) error {
+	body := strings.Join(bodyLines, "\n")
+
+	if req.GraphQL == nil {
+		req.Body = body
+		return nil
+	}
+
+	req.GraphQL.Query = strings.TrimSpace(body)
+	if variables := strings.TrimSpace(strings.Join(variablesLines, "\n")); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &req.GraphQL.Variables); err != nil {
+			return fmt.Errorf("invalid GraphQL variables in request %q: %w", req.Name, err)
+		}
+	}
+	return nil
+}
+
+// ParseGraphQLFile parses a .graphql file
+// It uses the .http syntax, but every request is a GraphQL operation
+func ParseGraphQLFile(filePath string) ([]types.HttpRequest, error) {
+	requests, err := ParseHTTPFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range requests {
+		req := &requests[i]
+		if req.GraphQL == nil {
+			req.GraphQL = &types.GraphQLRequest{Query: strings.TrimSpace(req.Body)}
+			req.Body = ""
+		}
+		req.Protocol = "graphql"
+	}
+
+	return requests, nil
+}
+
+// ParseGRPCFile parses a .grpc file (.http syntax) where every request is a unary gRPC call
+// The request line is "GRPC grpc://host:port/package.Service/Method" and the body is the JSON message
+func ParseGRPCFile(filePath string) ([]types.HttpRequest, error) {
+	requests, err := ParseHTTPFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range requests {
+		requests[i].Protocol = "grpc"
+	}
+
+	return requests, nil
+}
+
 // ParseDocumentationLines parses documentation from a slice of comment lines
 // This is used for lazy loading documentation
 func ParseDocumentationLines(lines []string) *types.Documentation {
@@ -0,0 +1,289 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Serialize writes a request in the canonical .http format read by ParseHTTPFile
+// Documentation comment lines are written back verbatim after the separator,
+// followed by one annotation per non-default field, the request line, sorted headers and the body
+func Serialize(req *types.HttpRequest) string {
+	var b strings.Builder
+
+	b.WriteString(strings.TrimSpace("### " + req.Name))
+	b.WriteString("\n")
+
+	for _, line := range req.DocumentationLines {
+		b.WriteString(line + "\n")
+	}
+	for _, annotation := range serializeAnnotations(req) {
+		b.WriteString("# " + annotation + "\n")
+	}
+
+	b.WriteString(req.Method + " " + req.URL + "\n")
+
+	for _, key := range sortedKeys(req.Headers) {
+		b.WriteString(key + ": " + req.Headers[key] + "\n")
+	}
+
+	body := req.Body
+	if req.GraphQL != nil {
+		body = req.GraphQL.Query
+	}
+	// Trailing blank lines would be read back as part of the body, so only one newline is kept
+	if body = strings.TrimRight(body, "\r\n"); body != "" {
+		b.WriteString("\n" + body + "\n")
+	}
+
+	if req.GraphQL != nil && len(req.GraphQL.Variables) > 0 {
+		// Variables are resolved as a JSON object, so marshalling cannot fail for parsed requests
+		if variables, err := json.MarshalIndent(req.GraphQL.Variables, "", "  "); err == nil {
+			b.WriteString("\n# @variables\n" + string(variables) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// SerializeHTTPFile writes requests as a .http file, separated by a blank line
+func SerializeHTTPFile(requests []types.HttpRequest) string {
+	parts := make([]string, len(requests))
+	for i := range requests {
+		parts[i] = Serialize(&requests[i])
+	}
+	return strings.Join(parts, "\n")
+}
+
+// serializeAnnotations returns the "@name value" annotations of the non-default request fields
+// in the order they are documented in the .http format
+func serializeAnnotations(req *types.HttpRequest) []string {
+	var annotations []string
+	add := func(name, value string) {
+		annotations = append(annotations, name+" "+value)
+	}
+
+	if req.Protocol != "" {
+		add("@protocol", req.Protocol)
+	}
+	if req.Profile != "" {
+		add("@profile", req.Profile)
+	}
+	if req.Filter != "" {
+		add("@filter", req.Filter)
+	}
+	if req.Query != "" {
+		add("@query", req.Query)
+	}
+	if req.ParseEscapes {
+		add("@parsing", "true")
+	}
+	if req.Streaming {
+		add("@streaming", "true")
+	}
+	if req.StreamFormat != "" {
+		add("@streamFormat", req.StreamFormat)
+	}
+	if req.HTTPVersion != "" {
+		add("@httpVersion", req.HTTPVersion)
+	}
+	if req.RequestCompression != "" {
+		add("@requestCompression", req.RequestCompression)
+	}
+
+	// Retry
+	if req.RetryCount != 0 {
+		add("@retryCount", strconv.Itoa(req.RetryCount))
+	}
+	if req.RetryBackoffMs != 0 {
+		add("@retryBackoffMs", strconv.Itoa(req.RetryBackoffMs))
+	}
+	if len(req.RetryOnStatus) > 0 {
+		add("@retryOnStatus", FormatStatusCodes(req.RetryOnStatus))
+	}
+	if req.RetryOnNetworkError {
+		add("@retryOnNetworkError", "true")
+	}
+	if req.RetryUnsafe {
+		add("@retryUnsafe", "true")
+	}
+
+	// Redirects
+	if req.FollowRedirects != nil {
+		add("@followRedirects", strconv.FormatBool(*req.FollowRedirects))
+	}
+	if req.MaxRedirects != 0 {
+		add("@maxRedirects", strconv.Itoa(req.MaxRedirects))
+	}
+
+	// Host mappings
+	for _, host := range sortedKeys(req.Resolve) {
+		add("@resolve", host+" "+req.Resolve[host])
+	}
+
+	// Outbound proxy
+	if req.ProxyURL != "" {
+		add("@proxy", req.ProxyURL)
+	}
+	if req.NoProxy != "" {
+		add("@noProxy", req.NoProxy)
+	}
+
+	if req.GraphQL != nil && req.GraphQL.OperationName != "" {
+		add("@operationName", req.GraphQL.OperationName)
+	}
+	if req.RequiresConfirmation {
+		add("@confirmation", "true")
+	}
+
+	// TLS
+	if req.TLS != nil {
+		if req.TLS.CertFile != "" {
+			add("@tls.certFile", req.TLS.CertFile)
+		}
+		if req.TLS.KeyFile != "" {
+			add("@tls.keyFile", req.TLS.KeyFile)
+		}
+		if req.TLS.CAFile != "" {
+			add("@tls.caFile", req.TLS.CAFile)
+		}
+		if req.TLS.InsecureSkipVerify {
+			add("@tls.insecureSkipVerify", "true")
+		}
+		for _, pin := range req.TLS.PinnedCertSHA256 {
+			add("@tls.pinnedCertSha256", pin)
+		}
+	}
+
+	// Signing
+	if req.Signing != nil {
+		for _, field := range []struct{ key, value string }{
+			{"@sign.algorithm", req.Signing.Algorithm},
+			{"@sign.accessKey", req.Signing.AccessKey},
+			{"@sign.secretKey", req.Signing.SecretKey},
+			{"@sign.sessionToken", req.Signing.SessionToken},
+			{"@sign.region", req.Signing.Region},
+			{"@sign.service", req.Signing.Service},
+			{"@sign.header", req.Signing.Header},
+		} {
+			if field.value != "" {
+				add(field.key, field.value)
+			}
+		}
+	}
+
+	// Protobuf response schema
+	if req.Protobuf != nil && req.Protobuf.DescriptorFile != "" {
+		add("@protobuf", strings.TrimSpace(req.Protobuf.DescriptorFile+" "+req.Protobuf.Message))
+	}
+
+	// Validation
+	if len(req.ExpectedStatusCodes) > 0 {
+		add("@expectedStatusCodes", FormatStatusCodes(req.ExpectedStatusCodes))
+	}
+	if req.ExpectedBodyExact != "" {
+		add("@expectedBodyExact", quoteAnnotationValue(req.ExpectedBodyExact))
+	}
+	if req.ExpectedBodyContains != "" {
+		add("@expectedBody", quoteAnnotationValue(req.ExpectedBodyContains))
+	}
+	if req.ExpectedBodyPattern != "" {
+		add("@expectedBodyPattern", quoteAnnotationValue(req.ExpectedBodyPattern))
+	}
+	for _, field := range sortedKeys(req.ExpectedBodyFields) {
+		add("@expectedBodyField", field+"="+req.ExpectedBodyFields[field])
+	}
+	if req.MaxDurationMs != 0 {
+		add("@maxDuration", strconv.Itoa(req.MaxDurationMs))
+	}
+
+	// Chaining
+	if len(req.DependsOn) > 0 {
+		add("@depends", strings.Join(req.DependsOn, " "))
+	}
+	for _, varName := range sortedKeys(req.Extract) {
+		add("@extract", varName+" "+req.Extract[varName])
+	}
+	if req.ForEach != "" {
+		add("@forEach", req.ForEach)
+	}
+	if req.Condition != "" {
+		add("@condition", req.Condition)
+	}
+	for _, hook := range req.BeforeHooks {
+		if hook.Variable != "" {
+			add("@before."+hook.Variable, hook.Command)
+		} else {
+			add("@before", hook.Command)
+		}
+	}
+	for _, command := range req.AfterHooks {
+		add("@after", command)
+	}
+
+	return annotations
+}
+
+// quoteAnnotationValue quotes a value whose surrounding whitespace or quotes would be stripped on parse
+func quoteAnnotationValue(value string) string {
+	if value != strings.TrimSpace(value) || (len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"') {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// FormatStatusCodes is the inverse of ParseStatusCodes
+// Complete ranges (all of 200-299) are written back as "2xx"
+func FormatStatusCodes(codes []int) string {
+	present := make(map[int]bool, len(codes))
+	for _, code := range codes {
+		present[code] = true
+	}
+
+	var parts []string
+	written := make(map[int]bool, len(codes))
+	for _, code := range codes {
+		if written[code] {
+			continue
+		}
+
+		rangeStart := code / 100 * 100
+		if code >= 100 && code < 600 && fullStatusRange(present, rangeStart) {
+			parts = append(parts, fmt.Sprintf("%dxx", code/100))
+			for i := rangeStart; i < rangeStart+100; i++ {
+				written[i] = true
+			}
+			continue
+		}
+
+		parts = append(parts, strconv.Itoa(code))
+		written[code] = true
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// fullStatusRange reports whether every code of the hundred starting at rangeStart is present
+func fullStatusRange(present map[int]bool, rangeStart int) bool {
+	for i := rangeStart; i < rangeStart+100; i++ {
+		if !present[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestSerialize_RoundTrip(t *testing.T) {
+	content := `### Create User
+# @description Creates a user
+# @tag users
+# @param name {string} required - Display name
+# @profile staging
+# @filter data.id
+# @retryCount 3
+# @retryOnStatus 429,5xx
+# @followRedirects false
+# @maxRedirects 3
+# @resolve api.example.com:443 10.0.0.5:8443
+# @proxy socks5://127.0.0.1:1080
+# @noProxy localhost,.internal
+# @tls.insecureSkipVerify true
+# @tls.pinnedCertSha256 sha256//r3ZhGmJxS3LKmHBqC8VXgCNrvqWQx6XQ3dDA0eG6u1k=
+# @sign.algorithm hmac-sha256
+# @sign.secretKey {{webhookSecret}}
+# @protobuf schemas/users.pb users.v1.User
+# @expectedStatusCodes 2xx
+# @expectedBody " created "
+# @expectedBodyField data.role=admin
+# @maxDuration 500
+# @depends login.http
+# @extract userId data.id
+# @before.token ./token.sh --scope users
+# @after tee last-user.json
+POST {{baseUrl}}/users
+Content-Type: application/json
+Authorization: Bearer {{token}}
+
+{
+  "name": "{{name}}"
+}
+
+### Get User
+GET {{baseUrl}}/users/{{userId}}
+`
+	original, err := ParseHTTPFile(createTempFile(t, "users.http", content))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	serialized := SerializeHTTPFile(original)
+	reparsed, err := ParseHTTPFile(createTempFile(t, "saved.http", serialized))
+	if err != nil {
+		t.Fatalf("Parse of serialized file failed: %v\n%s", err, serialized)
+	}
+
+	if len(reparsed) != len(original) {
+		t.Fatalf("Expected %d requests, got %d", len(original), len(reparsed))
+	}
+	for i := range original {
+		// Blank lines between requests belong to the body, only its content has to survive
+		original[i].Body = strings.TrimSpace(original[i].Body)
+		reparsed[i].Body = strings.TrimSpace(reparsed[i].Body)
+		if !reflect.DeepEqual(original[i], reparsed[i]) {
+			t.Errorf("Request %d changed after round trip:\noriginal: %+v\nreparsed: %+v\n%s", i, original[i], reparsed[i], serialized)
+		}
+	}
+
+	if !strings.Contains(serialized, "# @description Creates a user\n# @tag users\n") {
+		t.Errorf("Expected documentation lines to be kept verbatim:\n%s", serialized)
+	}
+	if !strings.Contains(serialized, "# @expectedStatusCodes 2xx\n") {
+		t.Errorf("Expected full status range to be written as 2xx:\n%s", serialized)
+	}
+	if !strings.Contains(serialized, "Authorization: Bearer {{token}}\nContent-Type: application/json\n") {
+		t.Errorf("Expected sorted headers:\n%s", serialized)
+	}
+}
+
+func TestSerialize_GraphQL(t *testing.T) {
+	req := &types.HttpRequest{
+		Name:   "Get User",
+		Method: "POST",
+		URL:    "{{baseUrl}}/graphql",
+		GraphQL: &types.GraphQLRequest{
+			Query:         "query GetUser($id: ID!) { user(id: $id) { name } }",
+			OperationName: "GetUser",
+			Variables:     map[string]interface{}{"id": "42"},
+		},
+	}
+
+	reparsed, err := ParseHTTPFile(createTempFile(t, "graphql.http", Serialize(req)))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(reparsed) != 1 || reparsed[0].GraphQL == nil {
+		t.Fatalf("Expected one GraphQL request, got %+v", reparsed)
+	}
+	if !reflect.DeepEqual(reparsed[0].GraphQL, req.GraphQL) {
+		t.Errorf("Expected GraphQL %+v, got %+v", req.GraphQL, reparsed[0].GraphQL)
+	}
+}
+
+func TestFormatStatusCodes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"200,201,204", "200,201,204"},
+		{"2xx", "2xx"},
+		{"200,4xx,503", "200,4xx,503"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatStatusCodes(ParseStatusCodes(tt.input)); got != tt.expected {
+			t.Errorf("FormatStatusCodes(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
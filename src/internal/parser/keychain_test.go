@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/keychain"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// stubKeychain replaces the keychain reader with a map of "service/account" secrets
+func stubKeychain(t *testing.T, secrets map[string]string) {
+	t.Helper()
+	original := keychainGet
+	keychainGet = func(service, account string) (string, error) {
+		if secret, ok := secrets[service+"/"+account]; ok {
+			return secret, nil
+		}
+		return "", keychain.ErrNotFound
+	}
+	t.Cleanup(func() { keychainGet = original })
+}
+
+func TestResolve_KeychainReference(t *testing.T) {
+	stubKeychain(t, map[string]string{"restcli/Dev/token": "s3cr3t"})
+
+	ref := "{{keychain:restcli/Dev/token}}"
+	profileVars := map[string]types.VariableValue{"token": {StringValue: &ref}}
+	resolver := NewVariableResolver(profileVars, nil, nil, nil)
+
+	for _, input := range []string{"Bearer {{keychain:restcli/Dev/token}}", "Bearer {{token}}"} {
+		got, err := resolver.Resolve(input)
+		if err != nil {
+			t.Fatalf("Resolve(%q) failed: %v", input, err)
+		}
+		if got != "Bearer s3cr3t" {
+			t.Errorf("Resolve(%q) = %q, want %q", input, got, "Bearer s3cr3t")
+		}
+	}
+}
+
+func TestResolve_KeychainErrors(t *testing.T) {
+	stubKeychain(t, nil)
+	resolver := NewVariableResolver(nil, nil, nil, nil)
+
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{"{{keychain:restcli/missing}}", "keychain:restcli/missing: secret not found in the OS keychain"},
+		{"{{keychain:no-account}}", "expected service/account"},
+	}
+
+	for _, tt := range tests {
+		got, err := resolver.Resolve(tt.input)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Resolve(%q) error = %v, want it to contain %q", tt.input, err, tt.wantErr)
+		}
+		if got != tt.input {
+			t.Errorf("Expected the placeholder to be kept, got %q", got)
+		}
+	}
+
+	if unresolved := resolver.GetUnresolvedVariables(); len(unresolved) != 0 {
+		t.Errorf("Expected keychain references not to be reported as unresolved, got %v", unresolved)
+	}
+	if names := ExtractVariableNames("{{keychain:restcli/token}} {{host}}"); len(names) != 1 || names[0] != "host" {
+		t.Errorf("Expected keychain references to be skipped, got %v", names)
+	}
+}
@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/secrets"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// stubPassphrase replaces the passphrase used to decrypt enc: variable values
+func stubPassphrase(t *testing.T, passphrase string) {
+	t.Helper()
+	original := secretsPassphrase
+	secretsPassphrase = func() string { return passphrase }
+	t.Cleanup(func() { secretsPassphrase = original })
+}
+
+func TestResolve_EncryptedVariable(t *testing.T) {
+	encrypted, err := secrets.Encrypt("s3cr3t", "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	profileVars := map[string]types.VariableValue{"token": {StringValue: &encrypted}}
+
+	stubPassphrase(t, "passphrase")
+	got, err := NewVariableResolver(profileVars, nil, nil, nil).Resolve("Bearer {{token}}")
+	if err != nil || got != "Bearer s3cr3t" {
+		t.Errorf("Resolve() = %q, %v", got, err)
+	}
+
+	stubPassphrase(t, "wrong")
+	got, err = NewVariableResolver(profileVars, nil, nil, nil).Resolve("Bearer {{token}}")
+	if err == nil || !strings.Contains(err.Error(), "token: wrong passphrase") {
+		t.Errorf("Expected a wrong passphrase error, got %v", err)
+	}
+	if got != "Bearer {{token}}" {
+		t.Errorf("Expected the placeholder to be kept instead of garbage, got %q", got)
+	}
+
+	stubPassphrase(t, "")
+	if _, err := NewVariableResolver(profileVars, nil, nil, nil).Resolve("{{token}}"); err == nil || !strings.Contains(err.Error(), "no passphrase set") {
+		t.Errorf("Expected a missing passphrase error, got %v", err)
+	}
+}
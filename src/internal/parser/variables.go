@@ -1,9 +1,9 @@
 package parser
 
 import (
-	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,6 +11,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/studiowebux/restcli/internal/keychain"
+	"github.com/studiowebux/restcli/internal/secrets"
 	"github.com/studiowebux/restcli/internal/types"
 )
 
@@ -25,17 +27,31 @@ var (
 
 	// Shell command pattern: $(command)
 	shellPattern = regexp.MustCompile(`\$\(([^)]+)\)`)
+
+	// Keychain secret pattern: {{keychain:service/account}}
+	keychainPattern = regexp.MustCompile(`\{\{\s*keychain:([^}]+)\}\}`)
+
+	// keychainGet reads secrets from the OS keychain (replaced in tests)
+	keychainGet = keychain.Get
+
+	// secretsPassphrase returns the passphrase for encrypted variable values (replaced in tests)
+	secretsPassphrase = secrets.Passphrase
 )
 
 // VariableResolver handles variable resolution for requests
 type VariableResolver struct {
 	// Variables are resolved in order: cliVars (highest) -> envVars -> session vars -> profile vars (lowest)
-	profileVars map[string]types.VariableValue
-	sessionVars map[string]string
-	cliVars     map[string]string // CLI vars from -e flag (highest priority)
-	envVars     map[string]string // Environment variables (accessed via {{env.VAR_NAME}})
-	unresolved  []string          // Track unresolved variable names
-	shellErrors []string          // Track shell command errors
+	profileVars  map[string]types.VariableValue
+	sessionVars  map[string]string
+	cliVars      map[string]string // CLI vars from -e flag (highest priority)
+	envVars      map[string]string // Environment variables (accessed via {{env.VAR_NAME}})
+	unresolved   []string          // Track unresolved variable names
+	shellErrors  []string          // Track shell command errors
+	secretErrors []string          // Track keychain lookup errors
+	cryptErrors  []string          // Track encrypted values that could not be decrypted
+	expanding    bool              // Set while evaluating functions inside a variable value
+	deferDynamic bool              // Keep {{$function}} placeholders for later evaluation
+	deferShell   bool              // Keep $(command) substitutions for later evaluation
 }
 
 // NewVariableResolver creates a new variable resolver
@@ -85,13 +101,23 @@ func (vr *VariableResolver) GetShellErrors() []string {
 
 // ExtractVariableNames extracts all unique variable names from a string
 // Returns variable names without the {{ }} brackets
+// Built-in {{$function}} placeholders are skipped, but variables used in their arguments are included
 func ExtractVariableNames(input string) []string {
+	var args []string
+	for _, match := range dynamicPattern.FindAllStringSubmatch(input, -1) {
+		args = append(args, match[2])
+	}
+	input = dynamicPattern.ReplaceAllString(input, " ") + " " + strings.Join(args, " ")
+
 	matches := varPattern.FindAllStringSubmatch(input, -1)
 	seen := make(map[string]bool)
 	var names []string
 	for _, match := range matches {
 		if len(match) > 1 {
 			name := strings.TrimSpace(match[1])
+			if strings.HasPrefix(name, "$") || strings.HasPrefix(name, keychain.ReferencePrefix) {
+				continue
+			}
 			if !seen[name] {
 				seen[name] = true
 				names = append(names, name)
@@ -128,6 +154,17 @@ func ExtractRequestVariables(req *types.HttpRequest) []string {
 	// Extract from body
 	addNames(ExtractVariableNames(req.Body))
 
+	// Extract from GraphQL query and variables
+	if req.GraphQL != nil {
+		addNames(ExtractVariableNames(req.GraphQL.Query))
+		addNames(ExtractVariableNames(req.GraphQL.OperationName))
+		if len(req.GraphQL.Variables) > 0 {
+			if data, err := json.Marshal(req.GraphQL.Variables); err == nil {
+				addNames(ExtractVariableNames(string(data)))
+			}
+		}
+	}
+
 	// Extract from TLS paths
 	if req.TLS != nil {
 		if req.TLS.CertFile != "" {
@@ -141,55 +178,14 @@ func ExtractRequestVariables(req *types.HttpRequest) []string {
 		}
 	}
 
-	return names
-}
-
-// LoadEnvFile loads environment variables from a .env file
-func LoadEnvFile(path string) (map[string]string, error) {
-	envVars := make(map[string]string)
-
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open env file: %w", err)
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	lineNum := 0
-	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
-
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		// Parse key=value
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			continue // Skip malformed lines
-		}
-
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		// Remove quotes if present
-		if len(value) >= 2 {
-			if (value[0] == '"' && value[len(value)-1] == '"') ||
-				(value[0] == '\'' && value[len(value)-1] == '\'') {
-				value = value[1 : len(value)-1]
-			}
+	// Extract from signing credentials
+	if req.Signing != nil {
+		for _, value := range []string{req.Signing.AccessKey, req.Signing.SecretKey, req.Signing.SessionToken, req.Signing.Region, req.Signing.Service} {
+			addNames(ExtractVariableNames(value))
 		}
-
-		envVars[key] = value
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed to read env file: %w", err)
 	}
 
-	return envVars, nil
+	return names
 }
 
 // LoadSystemEnv loads all system environment variables
@@ -216,6 +212,18 @@ func (vr *VariableResolver) ResolveRequest(req *types.HttpRequest) (*types.HttpR
 		Query:                req.Query,
 		ParseEscapes:         req.ParseEscapes,
 		Streaming:            req.Streaming,
+		StreamFormat:         req.StreamFormat,
+		HTTPVersion:          req.HTTPVersion,
+		RequestCompression:   req.RequestCompression,
+		RetryCount:           req.RetryCount,
+		RetryBackoffMs:       req.RetryBackoffMs,
+		RetryOnStatus:        req.RetryOnStatus,
+		RetryOnNetworkError:  req.RetryOnNetworkError,
+		RetryUnsafe:          req.RetryUnsafe,
+		FollowRedirects:      req.FollowRedirects,
+		MaxRedirects:         req.MaxRedirects,
+		Resolve:              req.Resolve,
+		NoProxy:              req.NoProxy,
 		RequiresConfirmation: req.RequiresConfirmation,
 		TLS:                  req.TLS,
 		ExpectedStatusCodes:  req.ExpectedStatusCodes,
@@ -223,8 +231,13 @@ func (vr *VariableResolver) ResolveRequest(req *types.HttpRequest) (*types.HttpR
 		ExpectedBodyContains: req.ExpectedBodyContains,
 		ExpectedBodyPattern:  req.ExpectedBodyPattern,
 		ExpectedBodyFields:   req.ExpectedBodyFields,
+		MaxDurationMs:        req.MaxDurationMs,
 		DependsOn:            req.DependsOn,
 		Extract:              req.Extract,
+		Condition:            req.Condition,
+		ForEach:              req.ForEach,
+		BeforeHooks:          req.BeforeHooks,
+		AfterHooks:           req.AfterHooks,
 	}
 
 	// Resolve URL
@@ -252,10 +265,29 @@ func (vr *VariableResolver) ResolveRequest(req *types.HttpRequest) (*types.HttpR
 		resolved.Body = body
 	}
 
+	// Resolve proxy URL (credentials often come from variables)
+	if req.ProxyURL != "" {
+		proxyURL, err := vr.Resolve(req.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve proxy URL: %w", err)
+		}
+		resolved.ProxyURL = proxyURL
+	}
+
+	// Resolve GraphQL query, operation name and variables
+	if req.GraphQL != nil {
+		graphQL, err := vr.resolveGraphQL(req.GraphQL)
+		if err != nil {
+			return nil, err
+		}
+		resolved.GraphQL = graphQL
+	}
+
 	// Resolve TLS paths
 	if req.TLS != nil {
 		resolvedTLS := &types.TLSConfig{
 			InsecureSkipVerify: req.TLS.InsecureSkipVerify,
+			PinnedCertSHA256:   req.TLS.PinnedCertSHA256,
 		}
 		if req.TLS.CertFile != "" {
 			certFile, err := vr.Resolve(req.TLS.CertFile)
@@ -281,12 +313,105 @@ func (vr *VariableResolver) ResolveRequest(req *types.HttpRequest) (*types.HttpR
 		resolved.TLS = resolvedTLS
 	}
 
+	// Resolve signing credentials
+	if req.Signing != nil {
+		signing, err := vr.ResolveSigning(req.Signing)
+		if err != nil {
+			return nil, err
+		}
+		resolved.Signing = signing
+	}
+
+	return resolved, nil
+}
+
+// ResolveSigning resolves variables in a signing configuration
+// Credentials usually come from {{keychain:...}} references or profile variables
+func (vr *VariableResolver) ResolveSigning(signing *types.SigningConfig) (*types.SigningConfig, error) {
+	resolved := &types.SigningConfig{}
+	fields := []struct {
+		name  string
+		value string
+		dest  *string
+	}{
+		{"algorithm", signing.Algorithm, &resolved.Algorithm},
+		{"accessKey", signing.AccessKey, &resolved.AccessKey},
+		{"secretKey", signing.SecretKey, &resolved.SecretKey},
+		{"sessionToken", signing.SessionToken, &resolved.SessionToken},
+		{"region", signing.Region, &resolved.Region},
+		{"service", signing.Service, &resolved.Service},
+		{"header", signing.Header, &resolved.Header},
+	}
+	for _, field := range fields {
+		value, err := vr.Resolve(field.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve signing %s: %w", field.name, err)
+		}
+		*field.dest = value
+	}
+	return resolved, nil
+}
+
+// resolveGraphQL resolves variables in a GraphQL operation
+// Variables are resolved inside string values, recursively through objects and arrays
+func (vr *VariableResolver) resolveGraphQL(gql *types.GraphQLRequest) (*types.GraphQLRequest, error) {
+	query, err := vr.Resolve(gql.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve GraphQL query: %w", err)
+	}
+	operationName, err := vr.Resolve(gql.OperationName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve GraphQL operation name: %w", err)
+	}
+
+	resolved := &types.GraphQLRequest{Query: query, OperationName: operationName}
+	if gql.Variables != nil {
+		variables, err := vr.resolveValue(gql.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve GraphQL variables: %w", err)
+		}
+		resolved.Variables = variables.(map[string]interface{})
+	}
 	return resolved, nil
 }
 
+// resolveValue resolves variables in strings nested in decoded JSON/YAML values
+// Returns a copy so the original request is never mutated
+func (vr *VariableResolver) resolveValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return vr.Resolve(v)
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolvedItem, err := vr.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = resolvedItem
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, item := range v {
+			resolvedItem, err := vr.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = resolvedItem
+		}
+		return resolved, nil
+	default:
+		return value, nil
+	}
+}
+
 // Resolve resolves variables and shell commands in a string
+// Keychain secrets that cannot be read are left as placeholders and returned as an error
 func (vr *VariableResolver) Resolve(input string) (string, error) {
 	var errors []error
+	secretErrors := len(vr.secretErrors)
+	cryptErrors := len(vr.cryptErrors)
 
 	// First pass: resolve shell commands
 	result, err := vr.resolveShellCommands(input)
@@ -303,6 +428,14 @@ func (vr *VariableResolver) Resolve(input string) (string, error) {
 		errors = append(errors, fmt.Errorf("second pass (from variables): %w", err))
 	}
 
+	if len(vr.cryptErrors) > cryptErrors {
+		return result, fmt.Errorf("failed to decrypt %s", strings.Join(vr.cryptErrors[cryptErrors:], "; "))
+	}
+
+	if len(vr.secretErrors) > secretErrors {
+		return result, fmt.Errorf("keychain lookup failed: %s", strings.Join(vr.secretErrors[secretErrors:], "; "))
+	}
+
 	// Return combined errors if any
 	if len(errors) > 0 {
 		var errMsg strings.Builder
@@ -317,36 +450,48 @@ func (vr *VariableResolver) Resolve(input string) (string, error) {
 	return result, nil
 }
 
-// resolveVariables resolves {{varName}} placeholders
+// resolveVariables resolves {{keychain:...}}, {{$function}} and {{varName}} placeholders
 func (vr *VariableResolver) resolveVariables(input string) string {
+	input = vr.resolveKeychainReferences(input)
+	input = vr.resolveDynamicVariables(input)
+
 	return varPattern.ReplaceAllStringFunc(input, func(match string) string {
 		// Extract variable name (remove {{ and }})
 		varName := strings.TrimSpace(match[2 : len(match)-2])
 
-		// Check for env.VAR_NAME syntax
-		if strings.HasPrefix(varName, "env.") {
-			envKey := varName[4:] // Remove "env." prefix
-			if value, ok := vr.envVars[envKey]; ok {
-				return value
-			}
-			// Track unresolved env variable
-			vr.unresolved = append(vr.unresolved, varName)
+		// Keychain errors are reported by Resolve
+		if strings.HasPrefix(varName, keychain.ReferencePrefix) {
 			return match
 		}
 
-		// Look up in CLI vars first (highest priority - from -e flag)
-		if value, ok := vr.cliVars[varName]; ok {
-			return value
+		if strings.HasPrefix(varName, "$") {
+			if vr.deferDynamic {
+				return match
+			}
+			// Malformed function calls are dropped like unknown functions
+			vr.unresolved = append(vr.unresolved, varName+" (invalid function call)")
+			return ""
 		}
 
-		// Then look up in session vars
-		if value, ok := vr.sessionVars[varName]; ok {
-			return value
-		}
+		if value, ok := vr.Lookup(varName); ok {
+			// Encrypted values are left as placeholders when they cannot be decrypted, never as ciphertext
+			if secrets.IsEncrypted(value) {
+				plaintext, err := secrets.Decrypt(value, secretsPassphrase())
+				if err != nil {
+					vr.cryptErrors = append(vr.cryptErrors, fmt.Sprintf("%s: %v", varName, err))
+					return match
+				}
+				value = plaintext
+			}
 
-		// Then look up in profile vars (lowest priority)
-		if value, ok := vr.profileVars[varName]; ok {
-			return value.GetValue()
+			// Evaluate secrets and functions stored in variable values (one level deep, so values cannot recurse)
+			if !vr.expanding {
+				vr.expanding = true
+				value = vr.resolveKeychainReferences(value)
+				value = vr.resolveDynamicVariables(value)
+				vr.expanding = false
+			}
+			return value
 		}
 
 		// Track unresolved variable
@@ -355,8 +500,57 @@ func (vr *VariableResolver) resolveVariables(input string) string {
 	})
 }
 
+// resolveKeychainReferences replaces {{keychain:service/account}} placeholders with secrets from the OS keychain
+func (vr *VariableResolver) resolveKeychainReferences(input string) string {
+	return keychainPattern.ReplaceAllStringFunc(input, func(match string) string {
+		ref := strings.TrimSpace(keychainPattern.FindStringSubmatch(match)[1])
+
+		service, account, err := keychain.ParseReference(ref)
+		if err == nil {
+			var secret string
+			if secret, err = keychainGet(service, account); err == nil {
+				return secret
+			}
+		}
+
+		vr.secretErrors = append(vr.secretErrors, fmt.Sprintf("%s%s: %v", keychain.ReferencePrefix, ref, err))
+		return match
+	})
+}
+
+// Lookup returns the value of a variable without resolving shell commands
+// env.VAR_NAME reads environment variables; other names follow CLI > session > profile priority
+func (vr *VariableResolver) Lookup(varName string) (string, bool) {
+	// Check for env.VAR_NAME syntax
+	if strings.HasPrefix(varName, "env.") {
+		value, ok := vr.envVars[varName[4:]] // Remove "env." prefix
+		return value, ok
+	}
+
+	// Look up in CLI vars first (highest priority - from -e flag)
+	if value, ok := vr.cliVars[varName]; ok {
+		return value, true
+	}
+
+	// Then look up in session vars
+	if value, ok := vr.sessionVars[varName]; ok {
+		return value, true
+	}
+
+	// Then look up in profile vars (lowest priority)
+	if value, ok := vr.profileVars[varName]; ok {
+		return value.GetValue(), true
+	}
+
+	return "", false
+}
+
 // resolveShellCommands executes shell commands in $(command) syntax
 func (vr *VariableResolver) resolveShellCommands(input string) (string, error) {
+	if vr.deferShell {
+		return input, nil
+	}
+
 	var cmdErrors []error
 	var failedCommands []string
 
@@ -402,6 +596,12 @@ func (vr *VariableResolver) resolveShellCommands(input string) (string, error) {
 	return result, nil
 }
 
+// SetDeferShell keeps $(command) substitutions in the output without running them
+// Used when the request is resolved again before it is sent, so each command runs once
+func (vr *VariableResolver) SetDeferShell(deferShell bool) {
+	vr.deferShell = deferShell
+}
+
 // AddSessionVariable adds or updates a session variable
 func (vr *VariableResolver) AddSessionVariable(name, value string) {
 	vr.sessionVars[name] = value
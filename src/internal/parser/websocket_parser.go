@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -86,6 +87,39 @@ func ParseWebSocketFile(filePath string) (*types.WebSocketRequest, error) {
 					continue
 				}
 
+				// @pingInterval / @pongTimeout keepalive annotations (seconds)
+				if strings.HasPrefix(trimmed, "@pingInterval ") {
+					value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@pingInterval"))
+					if seconds, err := strconv.Atoi(value); err == nil {
+						request.PingIntervalSec = seconds
+					}
+					continue
+				}
+				if strings.HasPrefix(trimmed, "@pongTimeout ") {
+					value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@pongTimeout"))
+					if seconds, err := strconv.Atoi(value); err == nil {
+						request.PongTimeoutSec = seconds
+					}
+					continue
+				}
+
+				// @on <pattern> => <reply> auto-reply rules
+				if strings.HasPrefix(trimmed, "@on ") {
+					rule, err := parseWebSocketRule(strings.TrimPrefix(trimmed, "@on "))
+					if err != nil {
+						return nil, fmt.Errorf("line %d: %w", lineNum, err)
+					}
+					request.Rules = append(request.Rules, rule)
+					continue
+				}
+				if strings.HasPrefix(trimmed, "@maxAutoReplies ") {
+					value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@maxAutoReplies"))
+					if max, err := strconv.Atoi(value); err == nil {
+						request.MaxAutoReplies = max
+					}
+					continue
+				}
+
 				// @tls annotations
 				if strings.HasPrefix(trimmed, "@tls.") {
 					if request.TLS == nil {
@@ -108,6 +142,11 @@ func ParseWebSocketFile(filePath string) (*types.WebSocketRequest, error) {
 						request.TLS.InsecureSkipVerify = value == "true"
 						continue
 					}
+					if strings.HasPrefix(trimmed, "@tls.pinnedCertSha256 ") {
+						value := strings.TrimSpace(strings.TrimPrefix(trimmed, "@tls.pinnedCertSha256"))
+						request.TLS.PinnedCertSHA256 = append(request.TLS.PinnedCertSHA256, strings.Fields(strings.ReplaceAll(value, ",", " "))...)
+						continue
+					}
 				}
 				continue
 			}
@@ -281,3 +320,16 @@ func ParseWebSocketFile(filePath string) (*types.WebSocketRequest, error) {
 
 	return request, nil
 }
+
+// parseWebSocketRule parses the "<pattern> => <reply>" of an @on annotation
+func parseWebSocketRule(value string) (types.WebSocketRule, error) {
+	pattern, reply, found := strings.Cut(value, " => ")
+	pattern = strings.TrimSpace(pattern)
+	if !found || pattern == "" {
+		return types.WebSocketRule{}, fmt.Errorf("invalid @on rule %q, expected <pattern> => <reply>", value)
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return types.WebSocketRule{}, fmt.Errorf("invalid @on pattern %q: %w", pattern, err)
+	}
+	return types.WebSocketRule{Pattern: pattern, Reply: strings.TrimSpace(reply)}, nil
+}
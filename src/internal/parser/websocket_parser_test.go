@@ -3,6 +3,7 @@ package parser
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -327,6 +328,64 @@ func TestParseWebSocketFile_TLSConfig(t *testing.T) {
 	}
 }
 
+func TestParseWebSocketFile_Keepalive(t *testing.T) {
+	content := `WEBSOCKET wss://stream.example.com
+# @pingInterval 20
+# @pongTimeout 5
+
+### Test
+> test
+`
+	tmpFile := createTempWSFile(t, content)
+	defer os.Remove(tmpFile)
+
+	result, err := ParseWebSocketFile(tmpFile)
+	if err != nil {
+		t.Fatalf("ParseWebSocketFile failed: %v", err)
+	}
+
+	if result.PingIntervalSec != 20 {
+		t.Errorf("Expected ping interval 20, got %d", result.PingIntervalSec)
+	}
+	if result.PongTimeoutSec != 5 {
+		t.Errorf("Expected pong timeout 5, got %d", result.PongTimeoutSec)
+	}
+}
+
+func TestParseWebSocketFile_Rules(t *testing.T) {
+	content := `WEBSOCKET ws://localhost:8080
+# @on ^challenge:(\w+)$ => response:$1
+# @on ping => pong
+# @maxAutoReplies 10
+
+### Test
+> hello
+`
+	tmpFile := createTempWSFile(t, content)
+	defer os.Remove(tmpFile)
+
+	result, err := ParseWebSocketFile(tmpFile)
+	if err != nil {
+		t.Fatalf("ParseWebSocketFile failed: %v", err)
+	}
+
+	if len(result.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(result.Rules))
+	}
+	if result.Rules[0].Pattern != `^challenge:(\w+)$` || result.Rules[0].Reply != "response:$1" {
+		t.Errorf("Unexpected first rule: %+v", result.Rules[0])
+	}
+	if result.MaxAutoReplies != 10 {
+		t.Errorf("Expected max auto-replies 10, got %d", result.MaxAutoReplies)
+	}
+
+	invalid := createTempWSFile(t, "WEBSOCKET ws://localhost:8080\n# @on ([ => x\n")
+	defer os.Remove(invalid)
+	if _, err := ParseWebSocketFile(invalid); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an invalid pattern error on line 2, got: %v", err)
+	}
+}
+
 func TestParseWebSocketFile_MissingURL(t *testing.T) {
 	content := `### Message
 > test
@@ -141,6 +141,14 @@ func DetectFormat(filePath string) (string, error) {
 		// WebSocket connection files
 		return "websocket", nil
 
+	case ".graphql":
+		// GraphQL operations in .http syntax
+		return "graphql", nil
+
+	case ".grpc":
+		// Unary gRPC calls in .http syntax
+		return "grpc", nil
+
 	case ".yaml", ".yml", ".json", ".jsonc":
 		// For YAML/JSON/JSONC files, check if it's OpenAPI
 		data, err := os.ReadFile(filePath)
@@ -207,6 +215,10 @@ func Parse(filePath string) ([]types.HttpRequest, error) {
 		return ParseYAMLFile(filePath)
 	case "http":
 		return ParseHTTPFile(filePath)
+	case "graphql":
+		return ParseGraphQLFile(filePath)
+	case "grpc":
+		return ParseGRPCFile(filePath)
 	default:
 		return nil, fmt.Errorf("unsupported file format: %s", format)
 	}
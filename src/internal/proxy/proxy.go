@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
@@ -23,6 +24,10 @@ var hopHeaders = map[string]bool{
 	"Upgrade":             true,
 }
 
+// viaToken is added to forwarded requests: seeing it on an incoming request means it looped back
+// (e.g. a profile proxy or HTTP_PROXY pointing at this proxy for a request to this proxy)
+const viaToken = "1.1 restcli-debug-proxy"
+
 // ProxyLog represents a captured HTTP transaction
 type ProxyLog struct {
 	ID          int
@@ -48,6 +53,7 @@ type Proxy struct {
 	stopChan  chan struct{}
 	maxLogs   int // Maximum number of logs to keep in memory
 	notifyCh  chan struct{} // Channel to notify when new log arrives
+	recordHosts []string // Hosts kept by WriteMockConfig (empty means all)
 }
 
 // NewProxy creates a new debug proxy
@@ -100,6 +106,11 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if isLooped(r.Header) {
+		http.Error(w, "Proxy loop detected: the request was already forwarded by this proxy", http.StatusLoopDetected)
+		return
+	}
+
 	// Build full URL for proxy request
 	targetURL := r.URL.String()
 	if r.URL.Scheme == "" {
@@ -159,8 +170,10 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 			proxyReq.Header.Add(name, value)
 		}
 	}
+	proxyReq.Header.Add("Via", viaToken)
 
 	// Execute request with transport that supports keep-alive
+	// Proxy is left unset: forwarding never goes through HTTP_PROXY, which may point back here
 	client := &http.Client{
 		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
@@ -216,6 +229,18 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 	w.Write(respBody)
 }
 
+// isLooped reports whether the request already went through this proxy
+func isLooped(header http.Header) bool {
+	for _, via := range header.Values("Via") {
+		for _, hop := range strings.Split(via, ",") {
+			if strings.TrimSpace(hop) == viaToken {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // getNextID returns the next log ID and increments the counter
 func (p *Proxy) getNextID() int {
 	p.logMutex.Lock()
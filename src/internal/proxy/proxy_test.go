@@ -0,0 +1,59 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestHandleProxy_DetectsLoop tests that a request forwarded back to the proxy is refused
+func TestHandleProxy_DetectsLoop(t *testing.T) {
+	p := NewProxy(0)
+	server := httptest.NewServer(http.HandlerFunc(p.handleProxy))
+	defer server.Close()
+
+	proxyURL, _ := url.Parse(server.URL)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	// The target is the proxy itself: the forwarded request comes back with the Via token
+	resp, err := client.Get(server.URL + "/loop")
+	if err != nil {
+		t.Fatalf("Expected a response, got: %v", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusLoopDetected {
+		t.Errorf("Expected %d, got %d", http.StatusLoopDetected, resp.StatusCode)
+	}
+}
+
+// TestHandleProxy_ForwardsWithVia tests that forwarded requests carry the Via token
+func TestHandleProxy_ForwardsWithVia(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Via")))
+	}))
+	defer target.Close()
+
+	p := NewProxy(0)
+	server := httptest.NewServer(http.HandlerFunc(p.handleProxy))
+	defer server.Close()
+
+	proxyURL, _ := url.Parse(server.URL)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+	resp, err := client.Get(target.URL)
+	if err != nil {
+		t.Fatalf("Expected a response, got: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK || string(body) != viaToken {
+		t.Errorf("Expected the Via token to reach the target, got %d %q", resp.StatusCode, body)
+	}
+	if p.GetLogCount() != 1 {
+		t.Errorf("Expected one captured request, got %d", p.GetLogCount())
+	}
+}
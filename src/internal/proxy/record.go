@@ -0,0 +1,157 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/studiowebux/restcli/internal/mock"
+)
+
+// unrecordedHeaders are response headers that do not make sense in a replayed response
+var unrecordedHeaders = map[string]bool{
+	"Content-Length":   true,
+	"Content-Encoding": true,
+	"Date":             true,
+}
+
+// SetRecordHosts restricts the recorded mock routes to the given hosts
+// An empty list records every host
+func (p *Proxy) SetRecordHosts(hosts []string) {
+	p.logMutex.Lock()
+	defer p.logMutex.Unlock()
+	p.recordHosts = hosts
+}
+
+// WriteMockConfig writes the captured traffic as a mock server config
+// It returns the number of routes written
+func (p *Proxy) WriteMockConfig(path string) (int, error) {
+	p.logMutex.RLock()
+	hosts := p.recordHosts
+	p.logMutex.RUnlock()
+
+	config := MockConfigFromLogs(p.GetLogs(), hosts)
+	if len(config.Routes) == 0 {
+		return 0, fmt.Errorf("no captured requests to record")
+	}
+
+	if err := mock.SaveConfig(config, path); err != nil {
+		return 0, err
+	}
+	return len(config.Routes), nil
+}
+
+// MockConfigFromLogs maps each observed method and path to its observed responses
+// Repeated requests with a different response become a response sequence, in capture order
+func MockConfigFromLogs(logs []*ProxyLog, hosts []string) *mock.Config {
+	config := &mock.Config{Port: 8080, Host: "localhost", Logging: true}
+	routeIndex := make(map[string]int)
+
+	for _, log := range logs {
+		// CONNECT tunnels and failed forwards have no response to replay
+		if log.Method == http.MethodConnect || log.RespHeaders == nil {
+			continue
+		}
+
+		target, err := url.Parse(log.URL)
+		if err != nil || !matchesHost(target, hosts) {
+			continue
+		}
+		path := target.Path
+		if path == "" {
+			path = "/"
+		}
+
+		response := recordedResponse(log)
+		key := log.Method + " " + path
+
+		index, ok := routeIndex[key]
+		if !ok {
+			routeIndex[key] = len(config.Routes)
+			config.Routes = append(config.Routes, mock.Route{
+				Name:      key,
+				Method:    log.Method,
+				Path:      path,
+				Responses: []mock.Response{response},
+			})
+			continue
+		}
+
+		// Identical consecutive responses are recorded once
+		route := &config.Routes[index]
+		if !sameResponse(route.Responses[len(route.Responses)-1], response) {
+			route.Responses = append(route.Responses, response)
+		}
+	}
+
+	// Routes that always answered the same way do not need a sequence
+	for i := range config.Routes {
+		route := &config.Routes[i]
+		if len(route.Responses) == 1 {
+			response := route.Responses[0]
+			route.Status, route.Headers, route.Body = response.Status, response.Headers, response.Body
+			route.Responses = nil
+		}
+	}
+
+	return config
+}
+
+// matchesHost reports whether the URL host is one of the recorded hosts (with or without port)
+func matchesHost(target *url.URL, hosts []string) bool {
+	if len(hosts) == 0 {
+		return true
+	}
+	for _, host := range hosts {
+		if strings.EqualFold(host, target.Host) || strings.EqualFold(host, target.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordedResponse converts a captured response into a mock response
+func recordedResponse(log *ProxyLog) mock.Response {
+	headers := make(map[string]string)
+	for name, values := range log.RespHeaders {
+		if hopHeaders[name] || unrecordedHeaders[name] || len(values) == 0 {
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	body := log.RespBody
+	if strings.EqualFold(log.RespHeaders.Get("Content-Encoding"), "gzip") {
+		if decoded, err := gunzip(body); err == nil {
+			body = decoded
+		}
+	}
+
+	// Binary bodies cannot be stored in the YAML config
+	response := mock.Response{Status: log.Status, Headers: headers}
+	if utf8.Valid(body) {
+		response.Body = string(body)
+	}
+	return response
+}
+
+// gunzip decompresses a gzip-encoded body
+func gunzip(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// sameResponse reports whether two recorded responses have the same status and body
+// Headers are ignored since they often carry per-response values (request IDs, cookies)
+func sameResponse(a, b mock.Response) bool {
+	return a.Status == b.Status && a.Body == b.Body
+}
@@ -0,0 +1,80 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/mock"
+)
+
+func TestMockConfigFromLogs(t *testing.T) {
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write([]byte(`{"items": []}`))
+	gz.Close()
+
+	jsonHeaders := http.Header{"Content-Type": {"application/json"}, "Date": {"today"}}
+	logs := []*ProxyLog{
+		{Method: "GET", URL: "http://api.example.com/jobs/1", Status: 202, RespHeaders: jsonHeaders, RespBody: []byte(`{"state": "pending"}`)},
+		{Method: "GET", URL: "http://api.example.com/jobs/1", Status: 202, RespHeaders: jsonHeaders, RespBody: []byte(`{"state": "pending"}`)},
+		{Method: "GET", URL: "http://api.example.com/jobs/1?verbose=1", Status: 200, RespHeaders: jsonHeaders, RespBody: []byte(`{"state": "done"}`)},
+		{Method: "GET", URL: "http://api.example.com:8080/items", Status: 200, RespHeaders: http.Header{"Content-Encoding": {"gzip"}}, RespBody: gzipped.Bytes()},
+		{Method: "GET", URL: "http://cdn.example.com/logo.png", Status: 200, RespHeaders: http.Header{}},
+		{Method: "CONNECT", URL: "https://api.example.com", Status: 200, RespHeaders: http.Header{}},
+		{Method: "POST", URL: "http://api.example.com/down", Status: http.StatusBadGateway},
+	}
+
+	config := MockConfigFromLogs(logs, []string{"API.example.com"})
+	if len(config.Routes) != 2 {
+		t.Fatalf("Expected 2 routes, got %+v", config.Routes)
+	}
+
+	jobs := config.Routes[0]
+	if jobs.Path != "/jobs/1" || len(jobs.Responses) != 2 {
+		t.Fatalf("Expected a two-step sequence for /jobs/1, got %+v", jobs)
+	}
+	if jobs.Responses[0].Status != 202 || jobs.Responses[1].Body != `{"state": "done"}` {
+		t.Errorf("Unexpected sequence: %+v", jobs.Responses)
+	}
+	if _, ok := jobs.Responses[0].Headers["Date"]; ok || jobs.Responses[0].Headers["Content-Type"] != "application/json" {
+		t.Errorf("Unexpected recorded headers: %v", jobs.Responses[0].Headers)
+	}
+
+	items := config.Routes[1]
+	if items.Responses != nil || items.Status != 200 || items.Body != `{"items": []}` {
+		t.Errorf("Expected a single decoded response for /items, got %+v", items)
+	}
+	if _, ok := items.Headers["Content-Encoding"]; ok {
+		t.Errorf("Expected Content-Encoding to be dropped, got %v", items.Headers)
+	}
+
+	if all := MockConfigFromLogs(logs, nil); len(all.Routes) != 3 {
+		t.Errorf("Expected every host without a filter, got %d routes", len(all.Routes))
+	}
+}
+
+func TestWriteMockConfig(t *testing.T) {
+	p := NewProxy(0)
+	path := filepath.Join(t.TempDir(), "recorded.mock.yaml")
+
+	if _, err := p.WriteMockConfig(path); err == nil {
+		t.Error("Expected an error without captured traffic")
+	}
+
+	p.addLog(&ProxyLog{Method: "GET", URL: "http://api.example.com/users", Status: 200, RespHeaders: http.Header{}, RespBody: []byte("[]")})
+	count, err := p.WriteMockConfig(path)
+	if err != nil || count != 1 {
+		t.Fatalf("Expected 1 route, got %d (%v)", count, err)
+	}
+
+	config, err := mock.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("Expected the recorded config to load: %v", err)
+	}
+	if config.Routes[0].Path != "/users" || config.Routes[0].Body != "[]" {
+		t.Errorf("Unexpected recorded route: %+v", config.Routes[0])
+	}
+}
@@ -0,0 +1,165 @@
+// Package secrets encrypts profile variable values at rest.
+//
+// An encrypted value is "enc:" followed by the base64 of a random salt, a GCM nonce and
+// the AES-256-GCM ciphertext. The key is derived from a passphrase with PBKDF2-SHA256, so
+// the profiles file can be committed without leaking the plaintext.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Prefix starts an encrypted variable value
+const Prefix = "enc:"
+
+// PassphraseEnv is the environment variable read when no passphrase was entered
+const PassphraseEnv = "RESTCLI_PASSPHRASE"
+
+const (
+	saltSize      = 16
+	keySize       = 32
+	kdfIterations = 600000
+)
+
+var (
+	// ErrWrongPassphrase is returned when a value cannot be decrypted with the passphrase
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted encrypted value")
+
+	// ErrNoPassphrase is returned when an encrypted value is used before a passphrase is set
+	ErrNoPassphrase = errors.New("no passphrase set (enter it in the TUI or set " + PassphraseEnv + ")")
+)
+
+var (
+	mu      sync.RWMutex
+	entered string                // Passphrase entered for this session
+	keys    = map[string][]byte{} // Derived keys by passphrase and salt, PBKDF2 is slow on purpose
+)
+
+// IsEncrypted returns true if a variable value is encrypted
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Unlock sets the passphrase used for the rest of the session
+func Unlock(passphrase string) {
+	mu.Lock()
+	defer mu.Unlock()
+	entered = passphrase
+}
+
+// Passphrase returns the session passphrase, or the PassphraseEnv value when none was entered
+func Passphrase() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if entered != "" {
+		return entered
+	}
+	return os.Getenv(PassphraseEnv)
+}
+
+// Encrypt encrypts a plaintext value with the passphrase
+func Encrypt(plaintext, passphrase string) (string, error) {
+	if passphrase == "" {
+		return "", ErrNoPassphrase
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	data := append(salt, nonce...)
+	data = gcm.Seal(data, nonce, []byte(plaintext), nil)
+	return Prefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Decrypt decrypts an encrypted value with the passphrase
+func Decrypt(value, passphrase string) (string, error) {
+	if !IsEncrypted(value) {
+		return "", fmt.Errorf("value is not encrypted (missing %s prefix)", Prefix)
+	}
+	if passphrase == "" {
+		return "", ErrNoPassphrase
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(strings.TrimPrefix(value, Prefix)))
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %w", err)
+	}
+	if len(data) < saltSize {
+		return "", fmt.Errorf("invalid encrypted value: too short")
+	}
+
+	gcm, err := newGCM(passphrase, data[:saltSize])
+	if err != nil {
+		return "", err
+	}
+	data = data[saltSize:]
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted value: too short")
+	}
+
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		// GCM authentication fails for a wrong key, so no garbage is ever returned
+		return "", ErrWrongPassphrase
+	}
+	return string(plaintext), nil
+}
+
+// newGCM returns the AES-GCM cipher for the passphrase and salt
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+// deriveKey derives the AES key from the passphrase and salt, caching it for the process
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	cacheKey := passphrase + "\x00" + string(salt)
+
+	mu.RLock()
+	key, ok := keys[cacheKey]
+	mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, kdfIterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	mu.Lock()
+	keys[cacheKey] = key
+	mu.Unlock()
+	return key, nil
+}
@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	encrypted, err := Encrypt("s3cr3t-token", "correct horse")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !IsEncrypted(encrypted) || strings.Contains(encrypted, "s3cr3t") {
+		t.Fatalf("Expected an enc: value without the plaintext, got %q", encrypted)
+	}
+
+	plaintext, err := Decrypt(encrypted, "correct horse")
+	if err != nil || plaintext != "s3cr3t-token" {
+		t.Errorf("Decrypt() = %q, %v", plaintext, err)
+	}
+
+	// Every encryption uses a fresh salt and nonce
+	again, _ := Encrypt("s3cr3t-token", "correct horse")
+	if again == encrypted {
+		t.Error("Expected two encryptions of the same value to differ")
+	}
+}
+
+func TestDecrypt_Errors(t *testing.T) {
+	encrypted, err := Encrypt("value", "right")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Decrypt(encrypted, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Expected ErrWrongPassphrase, got %v", err)
+	}
+	if _, err := Decrypt(encrypted, ""); !errors.Is(err, ErrNoPassphrase) {
+		t.Errorf("Expected ErrNoPassphrase, got %v", err)
+	}
+	if _, err := Decrypt("enc:not base64!", "right"); err == nil || errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Expected an invalid value error, got %v", err)
+	}
+	if _, err := Decrypt("plain", "right"); err == nil {
+		t.Error("Expected an error for a value without the enc: prefix")
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	t.Setenv(PassphraseEnv, "from-env")
+	Unlock("")
+	if got := Passphrase(); got != "from-env" {
+		t.Errorf("Expected the environment passphrase, got %q", got)
+	}
+
+	Unlock("entered")
+	t.Cleanup(func() { Unlock("") })
+	if got := Passphrase(); got != "entered" {
+		t.Errorf("Expected the entered passphrase to win, got %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Named environments
+//
+// A profile can define environments: named sets of variables layered over its own
+// variables during resolution, for the "same auth, different host" case. The selected
+// environment of each profile is kept in the session so it survives restarts.
+
+// EnvironmentNames returns the sorted environment names of a profile
+func EnvironmentNames(profile *types.Profile) []string {
+	names := make([]string, 0, len(profile.Environments))
+	for name := range profile.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveEnvironment returns the environment selected for a profile, empty when none
+// A selection whose environment was removed from the profile is ignored.
+func (m *Manager) ActiveEnvironment(profileName string) string {
+	name := m.session.Environments[profileName]
+	profile := m.GetProfile(profileName)
+	if name == "" || profile == nil {
+		return ""
+	}
+	if _, ok := profile.Environments[name]; !ok {
+		return ""
+	}
+	return name
+}
+
+// SetActiveEnvironment selects the environment of a profile, an empty name returns to its base variables
+func (m *Manager) SetActiveEnvironment(profileName, name string) error {
+	profile := m.GetProfile(profileName)
+	if profile == nil {
+		return fmt.Errorf("profile not found: %s", profileName)
+	}
+	if name != "" {
+		if _, ok := profile.Environments[name]; !ok {
+			return fmt.Errorf("environment '%s' not found in profile '%s'", name, profileName)
+		}
+	}
+
+	if name == "" {
+		delete(m.session.Environments, profileName)
+	} else {
+		if m.session.Environments == nil {
+			m.session.Environments = make(map[string]string)
+		}
+		m.session.Environments[profileName] = name
+	}
+	return m.SaveSession()
+}
+
+// ProfileVariables returns the variables of a profile with its active environment layered over them
+func (m *Manager) ProfileVariables(profile *types.Profile) map[string]types.VariableValue {
+	return profile.VariablesForEnvironment(m.ActiveEnvironment(profile.Name))
+}
@@ -0,0 +1,62 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/config"
+)
+
+func TestEnvironments_LayerVariables(t *testing.T) {
+	useProfilesFile(t, `[{
+		"name": "api",
+		"variables": {"baseUrl": "https://eu.example.com", "token": "shared"},
+		"environments": {
+			"us": {"baseUrl": "https://us.example.com"},
+			"ap": {"baseUrl": "https://ap.example.com", "region": "ap-southeast-1"}
+		}
+	}]`)
+	originalSession := config.SessionFile
+	config.SessionFile = filepath.Join(t.TempDir(), ".session.json")
+	t.Cleanup(func() { config.SessionFile = originalSession })
+
+	m := NewManager()
+	if err := m.LoadProfiles(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	profile := m.GetProfile("api")
+
+	if names := EnvironmentNames(profile); len(names) != 2 || names[0] != "ap" || names[1] != "us" {
+		t.Errorf("Expected sorted environment names [ap us], got %v", names)
+	}
+	if vars := m.ProfileVariables(profile); variableValue(vars, "baseUrl") != "https://eu.example.com" {
+		t.Errorf("Expected the base variables without an environment, got %v", variableValue(vars, "baseUrl"))
+	}
+
+	if err := m.SetActiveEnvironment("api", "ap"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	vars := m.ProfileVariables(profile)
+	if variableValue(vars, "baseUrl") != "https://ap.example.com" || variableValue(vars, "region") != "ap-southeast-1" || variableValue(vars, "token") != "shared" {
+		t.Errorf("Expected the ap environment over the base variables, got %v", vars)
+	}
+	if variableValue(profile.Variables, "baseUrl") != "https://eu.example.com" {
+		t.Error("Expected the profile variables to be left untouched")
+	}
+
+	// The selection is persisted in the session
+	reloaded := NewManager()
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if env := reloaded.ActiveEnvironment("api"); env != "ap" {
+		t.Errorf("Expected the ap environment after reload, got %q", env)
+	}
+
+	if err := m.SetActiveEnvironment("api", "mars"); err == nil {
+		t.Error("Expected an error for an unknown environment")
+	}
+	if err := m.SetActiveEnvironment("api", ""); err != nil || m.ActiveEnvironment("api") != "" {
+		t.Errorf("Expected the environment to be cleared, got %q (%v)", m.ActiveEnvironment("api"), err)
+	}
+}
@@ -0,0 +1,213 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Profile inheritance
+//
+// A profile naming a parent in Extends inherits the parent's headers, variables,
+// environments, TLS and OAuth settings; the child's own values win on conflicts.
+// The manager keeps the resolved profiles in memory so every reader sees the inherited
+// values, and strips the inherited values again when saving so the file only holds overrides.
+
+// resolveProfiles returns the profiles with their inherited values applied
+// bases holds, per child profile, the resolved parent it was merged with
+func resolveProfiles(profiles []types.Profile) (resolved []types.Profile, bases map[string]types.Profile, err error) {
+	byName := make(map[string]int, len(profiles))
+	for i, profile := range profiles {
+		byName[profile.Name] = i
+	}
+
+	resolved = make([]types.Profile, len(profiles))
+	bases = make(map[string]types.Profile)
+	done := make([]bool, len(profiles))
+
+	var resolve func(index int, chain []string) error
+	resolve = func(index int, chain []string) error {
+		if done[index] {
+			return nil
+		}
+		profile := profiles[index]
+		for _, name := range chain {
+			if name == profile.Name {
+				return fmt.Errorf("profile inheritance cycle: %s", strings.Join(append(chain, profile.Name), " -> "))
+			}
+		}
+
+		resolved[index] = cloneProfile(profile)
+		if profile.Extends != "" {
+			parentIndex, ok := byName[profile.Extends]
+			if !ok {
+				fmt.Fprintf(os.Stderr, "warning: profile '%s' extends unknown profile '%s'\n", profile.Name, profile.Extends)
+			} else {
+				if err := resolve(parentIndex, append(chain, profile.Name)); err != nil {
+					return err
+				}
+				base := cloneProfile(resolved[parentIndex])
+				mergeProfile(&resolved[index], base)
+				bases[profile.Name] = base
+			}
+		}
+		done[index] = true
+		return nil
+	}
+
+	for i := range profiles {
+		if err := resolve(i, nil); err != nil {
+			return nil, nil, err
+		}
+	}
+	return resolved, bases, nil
+}
+
+// InheritanceChain returns the names of the profiles a profile inherits from, nearest first
+func (m *Manager) InheritanceChain(name string) []string {
+	var chain []string
+	seen := map[string]bool{name: true}
+	for {
+		var parent string
+		for _, profile := range m.profiles {
+			if profile.Name == name {
+				parent = profile.Extends
+			}
+		}
+		if parent == "" || seen[parent] {
+			return chain
+		}
+		chain = append(chain, parent)
+		seen[parent] = true
+		name = parent
+	}
+}
+
+// mergeProfile fills the inheritable settings of child with the parent's values
+func mergeProfile(child *types.Profile, parent types.Profile) {
+	if len(parent.Headers) > 0 && child.Headers == nil {
+		child.Headers = make(map[string]string)
+	}
+	for key, value := range parent.Headers {
+		if _, ok := child.Headers[key]; !ok {
+			child.Headers[key] = value
+		}
+	}
+
+	if len(parent.Variables) > 0 && child.Variables == nil {
+		child.Variables = make(map[string]types.VariableValue)
+	}
+	for key, value := range parent.Variables {
+		if _, ok := child.Variables[key]; !ok {
+			child.Variables[key] = value
+		}
+	}
+
+	// Environments are inherited whole, a child environment of the same name replaces the parent's
+	if len(parent.Environments) > 0 && child.Environments == nil {
+		child.Environments = make(map[string]types.Environment)
+	}
+	for name, environment := range parent.Environments {
+		if _, ok := child.Environments[name]; !ok {
+			child.Environments[name] = environment
+		}
+	}
+
+	child.TLS = mergeStruct(child.TLS, parent.TLS)
+	child.OAuth = mergeStruct(child.OAuth, parent.OAuth)
+}
+
+// unmergeProfile removes the values a resolved profile inherited from base
+// Values present in raw (the profile as last loaded or saved) are kept even when equal to the parent
+func unmergeProfile(profile types.Profile, base types.Profile, raw types.Profile) types.Profile {
+	stored := cloneProfile(profile)
+
+	for key, value := range stored.Headers {
+		_, own := raw.Headers[key]
+		if inherited, ok := base.Headers[key]; ok && !own && inherited == value {
+			delete(stored.Headers, key)
+		}
+	}
+	for key, value := range stored.Variables {
+		_, own := raw.Variables[key]
+		if inherited, ok := base.Variables[key]; ok && !own && reflect.DeepEqual(inherited, value) {
+			delete(stored.Variables, key)
+		}
+	}
+
+	for name, environment := range stored.Environments {
+		_, own := raw.Environments[name]
+		if inherited, ok := base.Environments[name]; ok && !own && reflect.DeepEqual(inherited, environment) {
+			delete(stored.Environments, name)
+		}
+	}
+
+	stored.TLS = unmergeStruct(stored.TLS, base.TLS, raw.TLS)
+	stored.OAuth = unmergeStruct(stored.OAuth, base.OAuth, raw.OAuth)
+	return stored
+}
+
+// mergeStruct returns child with its zero fields taken from parent
+// A nil child inherits the whole parent struct
+func mergeStruct[T any](child, parent *T) *T {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		merged := *parent
+		return &merged
+	}
+
+	merged := *child
+	target := reflect.ValueOf(&merged).Elem()
+	source := reflect.ValueOf(parent).Elem()
+	for i := 0; i < target.NumField(); i++ {
+		if target.Field(i).IsZero() {
+			target.Field(i).Set(source.Field(i))
+		}
+	}
+	return &merged
+}
+
+// unmergeStruct returns value without the fields it inherited from parent
+// Fields set in raw are kept; nil is returned when nothing is left of the profile's own settings
+func unmergeStruct[T any](value, parent, raw *T) *T {
+	if value == nil || parent == nil {
+		return value
+	}
+	if raw == nil && reflect.DeepEqual(value, parent) {
+		return nil
+	}
+
+	stored := *value
+	target := reflect.ValueOf(&stored).Elem()
+	source := reflect.ValueOf(parent).Elem()
+	var own reflect.Value
+	if raw != nil {
+		own = reflect.ValueOf(raw).Elem()
+	}
+	for i := 0; i < target.NumField(); i++ {
+		setInRaw := own.IsValid() && !own.Field(i).IsZero()
+		if !setInRaw && reflect.DeepEqual(target.Field(i).Interface(), source.Field(i).Interface()) {
+			target.Field(i).Set(reflect.Zero(target.Field(i).Type()))
+		}
+	}
+	return &stored
+}
+
+// cloneProfile deep-copies a profile so resolved profiles never share maps or pointers
+func cloneProfile(profile types.Profile) types.Profile {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return profile
+	}
+	var clone types.Profile
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return profile
+	}
+	return clone
+}
@@ -0,0 +1,124 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// useProfilesFile points the profiles file at a temp file with the given content
+func useProfilesFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".profiles.json")
+	if err := os.WriteFile(path, []byte(content), config.FilePermissions); err != nil {
+		t.Fatal(err)
+	}
+	original := config.ProfilesFile
+	config.ProfilesFile = path
+	t.Cleanup(func() { config.ProfilesFile = original })
+	return path
+}
+
+func findProfile(t *testing.T, m *Manager, name string) *types.Profile {
+	t.Helper()
+	for i := range m.profiles {
+		if m.profiles[i].Name == name {
+			return &m.profiles[i]
+		}
+	}
+	t.Fatalf("profile %s not found", name)
+	return nil
+}
+
+func TestLoadProfiles_Extends(t *testing.T) {
+	useProfilesFile(t, `[
+		{"name": "base", "headers": {"Accept": "application/json", "X-Env": "base"}, "variables": {"baseUrl": "https://api.example.com", "region": "eu"},
+		 "environments": {"us": {"baseUrl": "https://us.example.com"}},
+		 "tls": {"caFile": "ca.pem"}, "oauth": {"enabled": true, "clientId": "shared", "scope": "read"}},
+		{"name": "staging", "extends": "base", "headers": {"X-Env": "staging"}, "variables": {"baseUrl": "https://staging.example.com"}, "oauth": {"scope": "read write"}},
+		{"name": "qa", "extends": "staging", "variables": {"region": "us"}}
+	]`)
+
+	m := NewManager()
+	if err := m.LoadProfiles(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	qa := findProfile(t, m, "qa")
+	if qa.Headers["Accept"] != "application/json" || qa.Headers["X-Env"] != "staging" {
+		t.Errorf("Unexpected inherited headers: %v", qa.Headers)
+	}
+	if got := qa.Variables["baseUrl"]; got.GetValue() != "https://staging.example.com" {
+		t.Errorf("Expected the staging baseUrl, got %q", got.GetValue())
+	}
+	if got := qa.Variables["region"]; got.GetValue() != "us" {
+		t.Errorf("Expected the child region to win, got %q", got.GetValue())
+	}
+	if got := qa.Environments["us"]["baseUrl"]; got.GetValue() != "https://us.example.com" {
+		t.Errorf("Expected the inherited us environment, got %q", got.GetValue())
+	}
+	if qa.TLS == nil || qa.TLS.CAFile != "ca.pem" {
+		t.Errorf("Expected the inherited TLS config, got %+v", qa.TLS)
+	}
+	if qa.OAuth == nil || qa.OAuth.ClientID != "shared" || qa.OAuth.Scope != "read write" || !qa.OAuth.Enabled {
+		t.Errorf("Expected the OAuth settings to be merged field by field, got %+v", qa.OAuth)
+	}
+
+	if chain := m.InheritanceChain("qa"); strings.Join(chain, ",") != "staging,base" {
+		t.Errorf("InheritanceChain(qa) = %v", chain)
+	}
+}
+
+func TestLoadProfiles_ExtendsCycle(t *testing.T) {
+	useProfilesFile(t, `[{"name": "a", "extends": "b"}, {"name": "b", "extends": "a"}]`)
+
+	err := NewManager().LoadProfiles()
+	if err == nil || !strings.Contains(err.Error(), "cycle: a -> b -> a") {
+		t.Errorf("Expected an inheritance cycle error, got %v", err)
+	}
+}
+
+func TestSaveProfiles_StoresOnlyOverrides(t *testing.T) {
+	path := useProfilesFile(t, `[
+		{"name": "base", "headers": {"Accept": "application/json"}, "variables": {"baseUrl": "https://api.example.com"}, "tls": {"caFile": "ca.pem"}},
+		{"name": "dev", "extends": "base", "headers": {"X-Debug": "1"}}
+	]`)
+
+	m := NewManager()
+	if err := m.LoadProfiles(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Edit the child through the resolved profile, and the parent
+	dev := findProfile(t, m, "dev")
+	token := "dev-token"
+	dev.Variables["token"] = types.VariableValue{StringValue: &token}
+	base := findProfile(t, m, "base")
+	base.Headers["Accept"] = "application/xml"
+
+	if err := m.SaveProfiles(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := string(data)
+	if strings.Count(saved, "baseUrl") != 1 || strings.Count(saved, "ca.pem") != 1 || !strings.Contains(saved, "dev-token") {
+		t.Errorf("Expected inherited values to be stored once, in the parent:\n%s", saved)
+	}
+
+	// The child picks up the parent's new value instead of keeping the old one
+	if got := dev.Headers["Accept"]; got != "application/xml" {
+		t.Errorf("Expected the updated parent header, got %q", got)
+	}
+
+	if err := m.DeleteProfile("base"); err == nil {
+		t.Error("Expected deleting an extended profile to fail")
+	}
+}
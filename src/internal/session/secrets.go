@@ -0,0 +1,26 @@
+package session
+
+import (
+	"github.com/studiowebux/restcli/internal/secrets"
+)
+
+// UnlockSecrets sets the passphrase for encrypted variable values for the rest of the session
+// The passphrase is checked against an encrypted value first, so a wrong one is rejected
+// instead of failing every request later
+func (m *Manager) UnlockSecrets(passphrase string) error {
+	for _, profile := range m.profiles {
+		for _, value := range profile.Variables {
+			if !secrets.IsEncrypted(value.GetValue()) {
+				continue
+			}
+			if _, err := secrets.Decrypt(value.GetValue(), passphrase); err != nil {
+				return err
+			}
+			secrets.Unlock(passphrase)
+			return nil
+		}
+	}
+
+	secrets.Unlock(passphrase)
+	return nil
+}
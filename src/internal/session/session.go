@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/studiowebux/restcli/internal/config"
 	"github.com/studiowebux/restcli/internal/types"
@@ -12,7 +13,10 @@ import (
 // Manager handles session and profile management
 type Manager struct {
 	session  *types.Session
-	profiles []types.Profile
+	profiles []types.Profile // Resolved profiles (inherited values applied)
+
+	stored map[string]types.Profile // Profiles as last loaded or saved, by name
+	bases  map[string]types.Profile // Resolved parent each extending profile was merged with, by name
 }
 
 // NewManager creates a new session manager
@@ -129,15 +133,54 @@ func (m *Manager) LoadProfiles() error {
 		}
 	}
 
-	m.profiles = profiles
+	resolved, bases, err := resolveProfiles(profiles)
+	if err != nil {
+		return err
+	}
+
+	m.profiles = resolved
+	m.setStored(profiles, bases)
 	return nil
 }
 
+// setStored records the profiles as written on disk and the parents they were resolved against
+func (m *Manager) setStored(profiles []types.Profile, bases map[string]types.Profile) {
+	m.stored = make(map[string]types.Profile, len(profiles))
+	for _, profile := range profiles {
+		m.stored[profile.Name] = profile
+	}
+	m.bases = bases
+
+	for i := range m.profiles {
+		if m.profiles[i].Headers == nil {
+			m.profiles[i].Headers = make(map[string]string)
+		}
+		if m.profiles[i].Variables == nil {
+			m.profiles[i].Variables = make(map[string]types.VariableValue)
+		}
+	}
+}
+
 // SaveProfiles saves the profiles to disk
+// Inherited values are left out so extending profiles only store their overrides
 func (m *Manager) SaveProfiles() error {
 	profilesPath := config.GetProfilesFilePath()
 
-	data, err := json.MarshalIndent(m.profiles, "", "  ")
+	stored := make([]types.Profile, len(m.profiles))
+	for i, profile := range m.profiles {
+		stored[i] = profile
+		if base, ok := m.bases[profile.Name]; ok {
+			stored[i] = unmergeProfile(profile, base, m.stored[profile.Name])
+		}
+	}
+
+	// Re-resolve first so a cycle is reported before anything is written
+	resolved, bases, err := resolveProfiles(stored)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(stored, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal profiles: %w", err)
 	}
@@ -146,6 +189,9 @@ func (m *Manager) SaveProfiles() error {
 		return fmt.Errorf("failed to write profiles file: %w", err)
 	}
 
+	// Update in place so pointers from GetActiveProfile stay valid
+	copy(m.profiles, resolved)
+	m.setStored(stored, bases)
 	return nil
 }
 
@@ -194,6 +240,31 @@ func (m *Manager) GetActiveProfile() *types.Profile {
 	}
 }
 
+// GetProfile returns the profile with the given name, or nil when it does not exist
+func (m *Manager) GetProfile(name string) *types.Profile {
+	for i := range m.profiles {
+		if m.profiles[i].Name == name {
+			return &m.profiles[i]
+		}
+	}
+	return nil
+}
+
+// ProfileForRequest returns the profile a request is executed with
+// A request naming a profile with @profile uses it instead of the active profile; when that
+// profile does not exist the active profile is returned along with a warning
+func (m *Manager) ProfileForRequest(request *types.HttpRequest) (*types.Profile, string) {
+	if request == nil || request.Profile == "" {
+		return m.GetActiveProfile(), ""
+	}
+	if profile := m.GetProfile(request.Profile); profile != nil {
+		return profile, ""
+	}
+
+	active := m.GetActiveProfile()
+	return active, fmt.Sprintf("profile '%s' not found, using '%s'", request.Profile, active.Name)
+}
+
 // SetActiveProfile sets the active profile by name
 func (m *Manager) SetActiveProfile(name string) error {
 	// Check if profile exists
@@ -239,6 +310,23 @@ func (m *Manager) UpdateProfile(name string, profile types.Profile) error {
 				profile.Name = name
 			}
 			m.profiles[i] = profile
+
+			// Keep extending profiles and the inheritance bookkeeping on the new name
+			if profile.Name != name {
+				for j := range m.profiles {
+					if m.profiles[j].Extends == name {
+						m.profiles[j].Extends = profile.Name
+					}
+				}
+				if base, ok := m.bases[name]; ok {
+					m.bases[profile.Name] = base
+					delete(m.bases, name)
+				}
+				if stored, ok := m.stored[name]; ok {
+					m.stored[profile.Name] = stored
+					delete(m.stored, name)
+				}
+			}
 			return m.SaveProfiles()
 		}
 	}
@@ -248,6 +336,12 @@ func (m *Manager) UpdateProfile(name string, profile types.Profile) error {
 
 // DeleteProfile deletes a profile by name
 func (m *Manager) DeleteProfile(name string) error {
+	for _, profile := range m.profiles {
+		if profile.Extends == name {
+			return fmt.Errorf("profile %s is extended by %s", name, profile.Name)
+		}
+	}
+
 	for i := range m.profiles {
 		if m.profiles[i].Name == name {
 			m.profiles = append(m.profiles[:i], m.profiles[i+1:]...)
@@ -328,3 +422,42 @@ func (m *Manager) GetRecentFiles() []string {
 	}
 	return m.session.RecentFiles
 }
+
+// GetMacro returns the keys recorded in a macro register
+func (m *Manager) GetMacro(register string) ([]string, bool) {
+	keys, ok := m.session.Macros[register]
+	return keys, ok && len(keys) > 0
+}
+
+// SetMacro stores the keys of a macro register, an empty macro clears it
+func (m *Manager) SetMacro(register string, keys []string) error {
+	if len(keys) == 0 {
+		delete(m.session.Macros, register)
+		return m.SaveSession()
+	}
+
+	if m.session.Macros == nil {
+		m.session.Macros = make(map[string][]string)
+	}
+	m.session.Macros[register] = keys
+	return m.SaveSession()
+}
+
+// GetNote returns the scratchpad notes of a request file
+func (m *Manager) GetNote(filePath string) string {
+	return m.session.Notes[filePath]
+}
+
+// SetNote stores the scratchpad notes of a request file, blank notes clear them
+func (m *Manager) SetNote(filePath, note string) error {
+	if strings.TrimSpace(note) == "" {
+		delete(m.session.Notes, filePath)
+		return m.SaveSession()
+	}
+
+	if m.session.Notes == nil {
+		m.session.Notes = make(map[string]string)
+	}
+	m.session.Notes[filePath] = note
+	return m.SaveSession()
+}
@@ -0,0 +1,32 @@
+package session
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestProfileForRequest(t *testing.T) {
+	useProfilesFile(t, `[{"name": "dev"}, {"name": "staging", "variables": {"baseUrl": "https://staging.example.com"}}]`)
+
+	m := NewManager()
+	if err := m.LoadProfiles(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	profile, warning := m.ProfileForRequest(&types.HttpRequest{})
+	if profile.Name != "dev" || warning != "" {
+		t.Errorf("Expected the active profile without a warning, got %s %q", profile.Name, warning)
+	}
+
+	profile, warning = m.ProfileForRequest(&types.HttpRequest{Profile: "staging"})
+	if profile.Name != "staging" || warning != "" {
+		t.Errorf("Expected the staging override, got %s %q", profile.Name, warning)
+	}
+
+	profile, warning = m.ProfileForRequest(&types.HttpRequest{Profile: "prod"})
+	if profile.Name != "dev" || !strings.Contains(warning, "'prod' not found") {
+		t.Errorf("Expected a fallback to dev with a warning, got %s %q", profile.Name, warning)
+	}
+}
@@ -0,0 +1,200 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/keychain"
+	"github.com/studiowebux/restcli/internal/secrets"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// ProfileExportVersion is the current version of the shared profile format
+const ProfileExportVersion = 1
+
+// ProfileExport is a single profile written to a standalone, shareable file
+type ProfileExport struct {
+	Version  int           `json:"version"`
+	Profile  types.Profile `json:"profile"`
+	Redacted []string      `json:"redacted,omitempty"` // Values left empty because they were secret, to be filled in by the importer
+}
+
+// ExportProfile writes a profile to a standalone file
+// Inherited values are included so the file does not depend on the parent profile.
+// Unless includeSecrets is set, encrypted values, keychain references and OAuth/signing
+// secrets are emptied and listed in Redacted.
+func (m *Manager) ExportProfile(name, path string, includeSecrets bool) (*ProfileExport, error) {
+	profile := m.GetProfile(name)
+	if profile == nil {
+		return nil, fmt.Errorf("profile not found: %s", name)
+	}
+
+	export := &ProfileExport{
+		Version: ProfileExportVersion,
+		Profile: cloneProfile(*profile),
+	}
+	export.Profile.Extends = ""
+	if !includeSecrets {
+		export.Redacted = redactProfile(&export.Profile)
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal profile: %w", err)
+	}
+	if err := os.WriteFile(path, data, config.FilePermissions); err != nil {
+		return nil, fmt.Errorf("failed to write profile file: %w", err)
+	}
+	return export, nil
+}
+
+// ImportProfile adds the profile stored in an exported file and returns its name
+// When a profile with the same name exists, merge overlays the imported headers and
+// variables on it (imported values win); otherwise the import is renamed.
+func (m *Manager) ImportProfile(path string, merge bool) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read profile file: %w", err)
+	}
+
+	export, err := parseProfileExport(data)
+	if err != nil {
+		return "", err
+	}
+	imported := export.Profile
+	if imported.Name == "" {
+		return "", fmt.Errorf("profile file has no profile name")
+	}
+	if imported.Headers == nil {
+		imported.Headers = make(map[string]string)
+	}
+	if imported.Variables == nil {
+		imported.Variables = make(map[string]types.VariableValue)
+	}
+	if imported.Extends != "" && !m.hasProfile(imported.Extends) {
+		fmt.Fprintf(os.Stderr, "warning: imported profile '%s' extends unknown profile '%s'\n", imported.Name, imported.Extends)
+	}
+
+	if !m.hasProfile(imported.Name) {
+		m.profiles = append(m.profiles, imported)
+		return imported.Name, m.SaveProfiles()
+	}
+
+	if !merge {
+		imported.Name = m.freeProfileName(imported.Name + "-imported")
+		m.profiles = append(m.profiles, imported)
+		return imported.Name, m.SaveProfiles()
+	}
+
+	for i := range m.profiles {
+		if m.profiles[i].Name != imported.Name {
+			continue
+		}
+		existing := &m.profiles[i]
+		for key, value := range imported.Headers {
+			existing.Headers[key] = value
+		}
+		for key, value := range imported.Variables {
+			// Keep the local secret rather than the empty placeholder of a redacted export
+			if current, ok := existing.Variables[key]; ok && isRedacted(export, "variables."+key) {
+				value = current
+			}
+			existing.Variables[key] = value
+		}
+		if imported.TLS != nil {
+			existing.TLS = imported.TLS
+		}
+		if imported.OAuth != nil {
+			existing.OAuth = mergeStruct(imported.OAuth, existing.OAuth)
+		}
+		if imported.Signing != nil {
+			existing.Signing = mergeStruct(imported.Signing, existing.Signing)
+		}
+		break
+	}
+	return imported.Name, m.SaveProfiles()
+}
+
+// parseProfileExport decodes an exported profile file and migrates older versions
+func parseProfileExport(data []byte) (*ProfileExport, error) {
+	var export ProfileExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse profile file: %w", err)
+	}
+
+	switch {
+	case export.Version == 0:
+		return nil, fmt.Errorf("not an exported profile (missing version)")
+	case export.Version > ProfileExportVersion:
+		return nil, fmt.Errorf("profile file version %d is newer than supported version %d", export.Version, ProfileExportVersion)
+	}
+	return &export, nil
+}
+
+// redactProfile empties the secret values of a profile and returns where they were
+func redactProfile(profile *types.Profile) []string {
+	var redacted []string
+	for name, value := range profile.Variables {
+		if value.IsMultiValue() || !isSecretValue(value.GetValue()) {
+			continue
+		}
+		value.SetValue("")
+		profile.Variables[name] = value
+		redacted = append(redacted, "variables."+name)
+	}
+	for name, value := range profile.Headers {
+		if isSecretValue(value) {
+			profile.Headers[name] = ""
+			redacted = append(redacted, "headers."+name)
+		}
+	}
+	if profile.OAuth != nil && profile.OAuth.ClientSecret != "" {
+		profile.OAuth.ClientSecret = ""
+		redacted = append(redacted, "oauth.clientSecret")
+	}
+	if profile.Signing != nil {
+		if profile.Signing.SecretKey != "" {
+			profile.Signing.SecretKey = ""
+			redacted = append(redacted, "signing.secretKey")
+		}
+		if profile.Signing.SessionToken != "" {
+			profile.Signing.SessionToken = ""
+			redacted = append(redacted, "signing.sessionToken")
+		}
+	}
+	sort.Strings(redacted)
+	return redacted
+}
+
+// isSecretValue returns true for encrypted values and keychain references
+func isSecretValue(value string) bool {
+	return secrets.IsEncrypted(value) || strings.Contains(value, "{{"+keychain.ReferencePrefix)
+}
+
+// isRedacted returns true if a field was emptied on export
+func isRedacted(export *ProfileExport, field string) bool {
+	for _, redacted := range export.Redacted {
+		if redacted == field {
+			return true
+		}
+	}
+	return false
+}
+
+// hasProfile returns true if a profile with the name exists
+func (m *Manager) hasProfile(name string) bool {
+	return m.GetProfile(name) != nil
+}
+
+// freeProfileName returns name, or name with the first free numeric suffix
+func (m *Manager) freeProfileName(name string) string {
+	candidate := name
+	for i := 2; m.hasProfile(candidate); i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
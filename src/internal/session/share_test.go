@@ -0,0 +1,167 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// variableValue returns the value of a variable, empty when missing
+func variableValue(variables map[string]types.VariableValue, name string) string {
+	value := variables[name]
+	return value.GetValue()
+}
+
+func TestExportProfile_RedactsSecrets(t *testing.T) {
+	useProfilesFile(t, `[
+		{"name": "base", "headers": {"Accept": "application/json"}, "variables": {"region": "eu"}},
+		{"name": "team", "extends": "base",
+		 "headers": {"X-Api-Key": "{{keychain:restcli/team}}"},
+		 "variables": {"baseUrl": "https://api.example.com", "token": "enc:abcdef", "password": "{{keychain:restcli/password}}"},
+		 "oauth": {"enabled": true, "clientId": "cli", "clientSecret": "s3cret"}}
+	]`)
+
+	m := NewManager()
+	if err := m.LoadProfiles(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "team.profile.json")
+	export, err := m.ExportProfile("team", path, false)
+	if err != nil {
+		t.Fatalf("ExportProfile failed: %v", err)
+	}
+
+	expected := []string{"headers.X-Api-Key", "oauth.clientSecret", "variables.password", "variables.token"}
+	if !reflect.DeepEqual(export.Redacted, expected) {
+		t.Errorf("Expected redacted %v, got %v", expected, export.Redacted)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"enc:abcdef", "s3cret", "keychain:"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %q to be redacted, got:\n%s", secret, data)
+		}
+	}
+
+	var written ProfileExport
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatal(err)
+	}
+	if written.Version != ProfileExportVersion {
+		t.Errorf("Expected version %d, got %d", ProfileExportVersion, written.Version)
+	}
+	if written.Profile.Extends != "" || variableValue(written.Profile.Variables, "region") != "eu" || written.Profile.Headers["Accept"] != "application/json" {
+		t.Errorf("Expected a standalone profile with inherited values, got %+v", written.Profile)
+	}
+	if variableValue(written.Profile.Variables, "baseUrl") != "https://api.example.com" {
+		t.Errorf("Expected plain variables to be kept, got %v", written.Profile.Variables)
+	}
+}
+
+func TestExportProfile_IncludeSecrets(t *testing.T) {
+	useProfilesFile(t, `[{"name": "team", "variables": {"token": "enc:abcdef"}}]`)
+
+	m := NewManager()
+	if err := m.LoadProfiles(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	export, err := m.ExportProfile("team", filepath.Join(t.TempDir(), "team.json"), true)
+	if err != nil {
+		t.Fatalf("ExportProfile failed: %v", err)
+	}
+	if len(export.Redacted) != 0 || variableValue(export.Profile.Variables, "token") != "enc:abcdef" {
+		t.Errorf("Expected secrets to be kept, got %+v", export)
+	}
+}
+
+func TestImportProfile_Collision(t *testing.T) {
+	exportPath := filepath.Join(t.TempDir(), "team.profile.json")
+	err := os.WriteFile(exportPath, []byte(`{
+		"version": 1,
+		"profile": {"name": "team", "headers": {"X-Team": "core"}, "variables": {"baseUrl": "https://shared.example.com", "token": ""}},
+		"redacted": ["variables.token"]
+	}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("new name", func(t *testing.T) {
+		useProfilesFile(t, `[{"name": "local"}]`)
+		m := NewManager()
+		if err := m.LoadProfiles(); err != nil {
+			t.Fatal(err)
+		}
+
+		name, err := m.ImportProfile(exportPath, false)
+		if err != nil || name != "team" {
+			t.Fatalf("Expected team to be imported, got %q, %v", name, err)
+		}
+		if findProfile(t, m, "team").Headers["X-Team"] != "core" {
+			t.Errorf("Expected imported headers")
+		}
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		useProfilesFile(t, `[{"name": "team"}, {"name": "team-imported"}]`)
+		m := NewManager()
+		if err := m.LoadProfiles(); err != nil {
+			t.Fatal(err)
+		}
+
+		name, err := m.ImportProfile(exportPath, false)
+		if err != nil || name != "team-imported-2" {
+			t.Fatalf("Expected team-imported-2, got %q, %v", name, err)
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		useProfilesFile(t, `[{"name": "team", "variables": {"baseUrl": "http://localhost", "token": "enc:local", "debug": "true"}}]`)
+		m := NewManager()
+		if err := m.LoadProfiles(); err != nil {
+			t.Fatal(err)
+		}
+
+		name, err := m.ImportProfile(exportPath, true)
+		if err != nil || name != "team" {
+			t.Fatalf("Expected a merge into team, got %q, %v", name, err)
+		}
+		team := findProfile(t, m, "team")
+		if variableValue(team.Variables, "baseUrl") != "https://shared.example.com" {
+			t.Errorf("Expected imported values to win, got %q", variableValue(team.Variables, "baseUrl"))
+		}
+		if variableValue(team.Variables, "token") != "enc:local" {
+			t.Errorf("Expected the local secret to survive a redacted import, got %q", variableValue(team.Variables, "token"))
+		}
+		if variableValue(team.Variables, "debug") != "true" || len(m.GetProfiles()) != 1 {
+			t.Errorf("Expected local-only values to be kept, got %v", team.Variables)
+		}
+	})
+}
+
+func TestImportProfile_RejectsUnknownVersion(t *testing.T) {
+	useProfilesFile(t, `[{"name": "local"}]`)
+	m := NewManager()
+	if err := m.LoadProfiles(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, content := range []string{`{"profile": {"name": "x"}}`, `{"version": 99, "profile": {"name": "x"}}`} {
+		path := filepath.Join(t.TempDir(), "profile.json")
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := m.ImportProfile(path, false); err == nil {
+			t.Errorf("Expected %s to be rejected", content)
+		}
+	}
+}
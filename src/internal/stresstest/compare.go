@@ -0,0 +1,104 @@
+package stresstest
+
+import "fmt"
+
+// RunSummary holds the aggregates of a run used for comparison
+// Counts are turned into rates so runs with different total requests compare fairly
+type RunSummary struct {
+	Run       *Run
+	AvgMs     float64
+	P50Ms     int64
+	P95Ms     int64
+	P99Ms     int64
+	ErrorRate float64 // Percentage of completed requests that failed (network or validation)
+	RPS       float64 // Completed requests per second
+}
+
+// RunComparison pairs a baseline run with the run compared against it
+type RunComparison struct {
+	Base  *RunSummary
+	Other *RunSummary
+}
+
+// MetricDelta is one compared metric
+type MetricDelta struct {
+	Name          string
+	Unit          string
+	Base          float64
+	Other         float64
+	LowerIsBetter bool
+}
+
+// Change returns the absolute change from base to other
+func (d MetricDelta) Change() float64 {
+	return d.Other - d.Base
+}
+
+// PercentChange returns the change relative to base in percent (0 when base is 0)
+func (d MetricDelta) PercentChange() float64 {
+	if d.Base == 0 {
+		return 0
+	}
+	return (d.Other - d.Base) / d.Base * 100
+}
+
+// Improved reports whether other is better than base
+func (d MetricDelta) Improved() bool {
+	if d.LowerIsBetter {
+		return d.Other < d.Base
+	}
+	return d.Other > d.Base
+}
+
+// Regressed reports whether other is worse than base
+func (d MetricDelta) Regressed() bool {
+	return d.Other != d.Base && !d.Improved()
+}
+
+// CompareRuns fetches the aggregates of two runs by ID
+func (m *Manager) CompareRuns(baseID, otherID int64) (*RunComparison, error) {
+	base, err := m.GetRun(baseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load run %d: %w", baseID, err)
+	}
+	other, err := m.GetRun(otherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load run %d: %w", otherID, err)
+	}
+	return &RunComparison{Base: SummarizeRun(base), Other: SummarizeRun(other)}, nil
+}
+
+// SummarizeRun computes the rate-based aggregates of a run
+func SummarizeRun(run *Run) *RunSummary {
+	summary := &RunSummary{
+		Run:   run,
+		AvgMs: run.AvgDurationMs,
+		P50Ms: run.P50DurationMs,
+		P95Ms: run.P95DurationMs,
+		P99Ms: run.P99DurationMs,
+		RPS:   run.AchievedRPS,
+	}
+	if run.TotalRequestsCompleted > 0 {
+		failed := run.TotalErrors + run.TotalValidationErrors
+		summary.ErrorRate = float64(failed) / float64(run.TotalRequestsCompleted) * 100
+	}
+	// Runs recorded before achieved RPS was stored fall back to their wall time
+	if summary.RPS == 0 && run.CompletedAt != nil {
+		if elapsed := run.CompletedAt.Sub(run.StartedAt).Seconds(); elapsed > 0 {
+			summary.RPS = float64(run.TotalRequestsCompleted) / elapsed
+		}
+	}
+	return summary
+}
+
+// Deltas returns the compared metrics in display order
+func (c *RunComparison) Deltas() []MetricDelta {
+	return []MetricDelta{
+		{Name: "Avg", Unit: "ms", Base: c.Base.AvgMs, Other: c.Other.AvgMs, LowerIsBetter: true},
+		{Name: "P50", Unit: "ms", Base: float64(c.Base.P50Ms), Other: float64(c.Other.P50Ms), LowerIsBetter: true},
+		{Name: "P95", Unit: "ms", Base: float64(c.Base.P95Ms), Other: float64(c.Other.P95Ms), LowerIsBetter: true},
+		{Name: "P99", Unit: "ms", Base: float64(c.Base.P99Ms), Other: float64(c.Other.P99Ms), LowerIsBetter: true},
+		{Name: "Error Rate", Unit: "%", Base: c.Base.ErrorRate, Other: c.Other.ErrorRate, LowerIsBetter: true},
+		{Name: "RPS", Unit: "req/s", Base: c.Base.RPS, Other: c.Other.RPS},
+	}
+}
@@ -0,0 +1,87 @@
+package stresstest
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestManager_CompareRuns(t *testing.T) {
+	manager := createTestManager(t)
+	defer manager.Close()
+
+	start := time.Now().Add(-time.Minute)
+	createRun := func(completed, errors int, avg float64, p95 int64, seconds int) *Run {
+		run := &Run{ConfigName: "api", RequestFile: "api.http", StartedAt: start, Status: "running"}
+		if err := manager.CreateRun(run); err != nil {
+			t.Fatalf("Failed to create run: %v", err)
+		}
+		completedAt := start.Add(time.Duration(seconds) * time.Second)
+		run.CompletedAt = &completedAt
+		run.Status = "completed"
+		run.TotalRequestsSent = completed
+		run.TotalRequestsCompleted = completed
+		run.TotalErrors = errors
+		run.AvgDurationMs = avg
+		run.P95DurationMs = p95
+		if err := manager.UpdateRun(run); err != nil {
+			t.Fatalf("Failed to update run: %v", err)
+		}
+		return run
+	}
+
+	// Different sizes: 100 requests with 10 errors vs 1000 requests with 20 errors
+	base := createRun(100, 10, 200, 400, 10)
+	other := createRun(1000, 20, 150, 300, 20)
+
+	comparison, err := manager.CompareRuns(base.ID, other.ID)
+	if err != nil {
+		t.Fatalf("CompareRuns failed: %v", err)
+	}
+
+	deltas := make(map[string]MetricDelta)
+	for _, d := range comparison.Deltas() {
+		deltas[d.Name] = d
+	}
+
+	errorRate := deltas["Error Rate"]
+	if errorRate.Base != 10 || errorRate.Other != 2 || !errorRate.Improved() {
+		t.Errorf("Expected error rate 10%% -> 2%% (improved), got: %+v", errorRate)
+	}
+
+	// RPS falls back to wall time for runs without an achieved RPS
+	rps := deltas["RPS"]
+	if rps.Base != 10 || rps.Other != 50 || !rps.Improved() {
+		t.Errorf("Expected RPS 10 -> 50 (improved), got: %+v", rps)
+	}
+
+	avg := deltas["Avg"]
+	if !avg.Improved() || avg.Regressed() || math.Abs(avg.PercentChange()+25) > 0.001 {
+		t.Errorf("Expected avg to improve by 25%%, got: %+v (%.1f%%)", avg, avg.PercentChange())
+	}
+
+	p95 := deltas["P95"]
+	if p95.Change() != -100 {
+		t.Errorf("Expected P95 change of -100ms, got: %.0f", p95.Change())
+	}
+
+	if _, err := manager.CompareRuns(base.ID, 9999); err == nil {
+		t.Error("Expected an error for an unknown run")
+	}
+}
+
+func TestMetricDelta_Regressed(t *testing.T) {
+	latency := MetricDelta{Base: 100, Other: 120, LowerIsBetter: true}
+	if latency.Improved() || !latency.Regressed() {
+		t.Errorf("Expected higher latency to be a regression")
+	}
+
+	unchanged := MetricDelta{Base: 5, Other: 5}
+	if unchanged.Improved() || unchanged.Regressed() {
+		t.Errorf("Expected an unchanged metric to be neither improved nor regressed")
+	}
+
+	if (MetricDelta{Base: 0, Other: 5}).PercentChange() != 0 {
+		t.Errorf("Expected no percent change from a zero base")
+	}
+}
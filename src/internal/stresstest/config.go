@@ -2,6 +2,8 @@ package stresstest
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"path/filepath"
 	"time"
 
 	"github.com/studiowebux/restcli/internal/types"
@@ -16,17 +18,23 @@ const (
 
 // Config represents a stress test configuration
 type Config struct {
-	ID                   int64
-	Name                 string
-	RequestFile          string
-	ProfileName          string
-	ConcurrentConns      int
-	TotalRequests        int
-	RampUpDurationSec    int
-	TestDurationSec      int
-	RequestTimeoutSec    int // Timeout for individual requests (default: 10s)
-	CreatedAt            time.Time
-	UpdatedAt            time.Time
+	ID                int64
+	Name              string
+	RequestFile       string
+	ProfileName       string
+	ConcurrentConns   int
+	TotalRequests     int
+	RampUpDurationSec int
+	TestDurationSec   int
+	RequestTimeoutSec int        // Timeout for individual requests (default: 10s)
+	TargetRPS         int        // Pace dispatch at this many requests per second (0 = as fast as the workers allow)
+	ThinkTimeMinMs    int        // Minimum pause a worker takes after each request
+	ThinkTimeMaxMs    int        // Maximum pause a worker takes after each request (0 = no think time)
+	DataFile          string     // CSV file whose columns fill {{col}} variables (relative to the request file)
+	DataOrder         string     // "sequential" (default) or "random"
+	Scenarios         []Scenario // Weighted request files, replaces RequestFile when set
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 // Run represents a stress test run record
@@ -49,6 +57,10 @@ type Run struct {
 	P50DurationMs          int64
 	P95DurationMs          int64
 	P99DurationMs          int64
+	TargetRPS              int     // Configured request rate (0 = concurrency mode)
+	AchievedRPS            float64 // Completed requests per second over the run
+	ThinkTimeMinMs         int     // Configured think time bounds (0/0 = none)
+	ThinkTimeMaxMs         int
 }
 
 // Metric represents a single request metric in a stress test
@@ -63,13 +75,16 @@ type Metric struct {
 	ResponseSize    int64
 	ErrorMessage    string
 	ValidationError string // Validation failure message (e.g., "unexpected status 404", "body validation failed")
+	Scenario        string // Request file of the scenario that produced this metric
 }
 
 // ExecutionConfig contains the runtime configuration for executing a stress test
 type ExecutionConfig struct {
-	Request         *types.HttpRequest
-	TLSConfig       *types.TLSConfig
-	Config          *Config
+	Request   *types.HttpRequest
+	TLSConfig *types.TLSConfig
+	Config    *Config
+	Data      *DataSet           // Rows for {{col}} variables (loaded from Config.DataFile when nil)
+	Scenarios []*ScenarioRequest // Weighted requests (Request is the only scenario when empty)
 }
 
 // Validate validates the stress test configuration
@@ -98,6 +113,30 @@ func (c *Config) Validate() error {
 	if c.TestDurationSec < 0 {
 		return fmt.Errorf("test duration cannot be negative")
 	}
+	if c.TargetRPS < 0 {
+		return fmt.Errorf("target RPS cannot be negative")
+	}
+	if c.TargetRPS > 0 && c.RampUpDurationSec > 0 {
+		// Both would decide when requests start
+		return fmt.Errorf("ramp-up duration cannot be combined with a target RPS (set one of them to 0)")
+	}
+	if c.ThinkTimeMinMs < 0 || c.ThinkTimeMaxMs < 0 {
+		return fmt.Errorf("think time cannot be negative")
+	}
+	if c.ThinkTimeMaxMs < c.ThinkTimeMinMs {
+		return fmt.Errorf("think time max (%dms) cannot be less than min (%dms)", c.ThinkTimeMaxMs, c.ThinkTimeMinMs)
+	}
+	if c.DataOrder != "" && c.DataOrder != DataOrderSequential && c.DataOrder != DataOrderRandom {
+		return fmt.Errorf("data order must be %q or %q", DataOrderSequential, DataOrderRandom)
+	}
+	for _, s := range c.Scenarios {
+		if s.RequestFile == "" {
+			return fmt.Errorf("scenario request file is required")
+		}
+		if s.Weight <= 0 {
+			return fmt.Errorf("scenario %s: weight must be greater than 0", s.RequestFile)
+		}
+	}
 	return nil
 }
 
@@ -122,6 +161,12 @@ func (c *Config) GetRequestTimeout() time.Duration {
 	return time.Duration(c.RequestTimeoutSec) * time.Second
 }
 
+// IsRateLimited returns true when requests are paced at TargetRPS
+// ConcurrentConns is then a cap on in-flight requests instead of the load driver
+func (c *Config) IsRateLimited() bool {
+	return c.TargetRPS > 0
+}
+
 // IsRunning returns true if the run is currently in progress
 func (r *Run) IsRunning() bool {
 	return r.Status == "running"
@@ -131,3 +176,45 @@ func (r *Run) IsRunning() bool {
 func (r *Run) IsCompleted() bool {
 	return r.Status == "completed" || r.Status == "cancelled" || r.Status == "failed"
 }
+
+// GetThinkTime returns a random pause between ThinkTimeMinMs and ThinkTimeMaxMs
+func (c *Config) GetThinkTime() time.Duration {
+	if c.ThinkTimeMaxMs <= 0 {
+		return 0
+	}
+	ms := c.ThinkTimeMinMs
+	if c.ThinkTimeMaxMs > c.ThinkTimeMinMs {
+		ms += rand.IntN(c.ThinkTimeMaxMs - c.ThinkTimeMinMs + 1)
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// GetDataFilePath returns the data file path, resolving relative paths against the request file directory
+func (c *Config) GetDataFilePath() string {
+	return c.relativeToRequestFile(c.DataFile)
+}
+
+// GetScenarioPath returns a scenario's request file path, resolving relative paths against the request file directory
+func (c *Config) GetScenarioPath(s Scenario) string {
+	return c.relativeToRequestFile(s.RequestFile)
+}
+
+func (c *Config) relativeToRequestFile(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(c.RequestFile), path)
+}
+
+// LoadData loads the configured data file, or returns nil when none is set
+func (c *Config) LoadData() (*DataSet, error) {
+	if c.DataFile == "" {
+		return nil, nil
+	}
+	data, err := LoadDataSet(c.GetDataFilePath())
+	if err != nil {
+		return nil, err
+	}
+	data.Random = c.DataOrder == DataOrderRandom
+	return data, nil
+}
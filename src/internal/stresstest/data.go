@@ -0,0 +1,129 @@
+package stresstest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/parser"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Data file row orders
+const (
+	DataOrderSequential = "sequential"
+	DataOrderRandom     = "random"
+)
+
+// DataSet holds the rows of a CSV data file used to parameterize requests
+// The header row names the variables, each following row provides their values
+type DataSet struct {
+	Columns []string
+	Rows    [][]string
+	Random  bool // Pick rows randomly instead of round-robin
+}
+
+// LoadDataSet reads a CSV data file
+func LoadDataSet(path string) (*DataSet, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open data file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data file %s: %w", path, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("data file %s is empty", path)
+	}
+	if len(records) == 1 {
+		return nil, fmt.Errorf("data file %s has a header but no rows", path)
+	}
+
+	columns := make([]string, len(records[0]))
+	for i, name := range records[0] {
+		columns[i] = strings.TrimSpace(name)
+		if columns[i] == "" {
+			return nil, fmt.Errorf("data file %s has an empty column name at position %d", path, i+1)
+		}
+	}
+
+	return &DataSet{
+		Columns: columns,
+		Rows:    records[1:],
+	}, nil
+}
+
+// Row returns the variables for the given request sequence number
+// Sequential order cycles through the rows when there are fewer rows than requests
+func (d *DataSet) Row(seq int) map[string]string {
+	index := seq % len(d.Rows)
+	if d.Random {
+		index = rand.IntN(len(d.Rows))
+	}
+
+	row := d.Rows[index]
+	vars := make(map[string]string, len(d.Columns))
+	for i, name := range d.Columns {
+		if i < len(row) {
+			vars[name] = row[i]
+		}
+	}
+	return vars
+}
+
+// CheckColumns returns an error when the request uses a variable that is not a column
+// Environment variables ({{env.NAME}}) are not expected to come from the data file
+func (d *DataSet) CheckColumns(req *types.HttpRequest) error {
+	known := make(map[string]bool, len(d.Columns))
+	for _, name := range d.Columns {
+		known[name] = true
+	}
+
+	var missing []string
+	for _, name := range parser.ExtractRequestVariables(req) {
+		if !known[name] && !strings.HasPrefix(name, "env.") {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("request variables missing from data file header: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Placeholders maps every column to its own {{column}} placeholder
+// Passing it to a resolver keeps the columns unresolved so each request can fill them from its row
+func (d *DataSet) Placeholders() map[string]string {
+	placeholders := make(map[string]string, len(d.Columns))
+	for _, name := range d.Columns {
+		placeholders[name] = "{{" + name + "}}"
+	}
+	return placeholders
+}
+
+// resolveRequest fills the request's column variables from the row for seq
+func (d *DataSet) resolveRequest(req *types.HttpRequest, seq int) (*types.HttpRequest, error) {
+	resolver := parser.NewVariableResolver(nil, nil, d.Row(seq), nil)
+	resolved, err := resolver.ResolveRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve data row: %w", err)
+	}
+	return resolved, nil
+}
+
+// resolveDynamicRequest evaluates the request's {{$function}} placeholders with fresh values
+func resolveDynamicRequest(req *types.HttpRequest) (*types.HttpRequest, error) {
+	resolver := parser.NewVariableResolver(nil, nil, nil, nil)
+	resolved, err := resolver.ResolveRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve dynamic variables: %w", err)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,120 @@
+package stresstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func writeDataFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "users.csv")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write data file: %v", err)
+	}
+	return path
+}
+
+func TestLoadDataSet(t *testing.T) {
+	data, err := LoadDataSet(writeDataFile(t, "id, name\n1,alice\n2,\"bob, jr\"\n"))
+	if err != nil {
+		t.Fatalf("LoadDataSet failed: %v", err)
+	}
+	if strings.Join(data.Columns, "|") != "id|name" {
+		t.Errorf("Unexpected columns: %v", data.Columns)
+	}
+	if len(data.Rows) != 2 {
+		t.Fatalf("Expected 2 rows, got: %d", len(data.Rows))
+	}
+
+	// Fewer rows than requests cycles back to the first row
+	for seq, expected := range []string{"alice", "bob, jr", "alice"} {
+		if got := data.Row(seq)["name"]; got != expected {
+			t.Errorf("Row(%d): expected name %q, got %q", seq, expected, got)
+		}
+	}
+
+	for _, content := range []string{"", "id,name\n", ",name\n1,alice\n"} {
+		if _, err := LoadDataSet(writeDataFile(t, content)); err == nil {
+			t.Errorf("Expected an error for data file %q", content)
+		}
+	}
+}
+
+func TestDataSet_CheckColumns(t *testing.T) {
+	data := &DataSet{Columns: []string{"id", "name"}, Rows: [][]string{{"1", "alice"}}}
+
+	req := &types.HttpRequest{
+		Method:  "POST",
+		URL:     "http://localhost/users/{{id}}",
+		Headers: map[string]string{"X-Home": "{{env.HOME}}"},
+		Body:    `{"name": "{{name}}"}`,
+	}
+	if err := data.CheckColumns(req); err != nil {
+		t.Errorf("Expected columns to match, got: %v", err)
+	}
+
+	req.Body = `{"name": "{{name}}", "email": "{{email}}"}`
+	err := data.CheckColumns(req)
+	if err == nil || !strings.Contains(err.Error(), "email") {
+		t.Errorf("Expected missing column error for email, got: %v", err)
+	}
+}
+
+func TestExecutor_DataFile(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	manager := createTestManager(t)
+	defer manager.Close()
+
+	dataFile := writeDataFile(t, "id\n1\n2\n3\n")
+	newConfig := func(url string) *ExecutionConfig {
+		return &ExecutionConfig{
+			Request: &types.HttpRequest{
+				Method: "GET",
+				URL:    url,
+			},
+			Config: &Config{
+				Name:            "test-data",
+				RequestFile:     "test.http",
+				ProfileName:     "default",
+				ConcurrentConns: 2,
+				TotalRequests:   6,
+				DataFile:        dataFile,
+			},
+		}
+	}
+
+	executor, err := NewExecutor(newConfig(server.URL+"/users/{{id}}"), manager)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	executor.Start()
+	executor.Wait()
+
+	sort.Strings(paths)
+	expected := "/users/1,/users/1,/users/2,/users/2,/users/3,/users/3"
+	if got := strings.Join(paths, ","); got != expected {
+		t.Errorf("Expected each row used twice (%s), got: %s", expected, got)
+	}
+
+	// A variable with no matching column is rejected before the run starts
+	if _, err := NewExecutor(newConfig(server.URL+"/users/{{uuid}}"), manager); err == nil {
+		t.Error("Expected an error for a variable missing from the data file header")
+	}
+}
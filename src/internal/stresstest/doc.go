@@ -6,6 +6,8 @@ Package stresstest provides load testing functionality for HTTP endpoints.
 The stresstest package implements a concurrent HTTP load testing system with:
   - Configurable worker pools
   - Request rate limiting and ramp-up
+  - CSV data files for per-request variables
+  - Weighted multi-request scenarios with per-scenario metrics
   - Real-time metrics collection
   - Response validation (status codes, body patterns)
   - Database persistence of results
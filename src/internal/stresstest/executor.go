@@ -5,19 +5,21 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"os"
-	"regexp"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/studiowebux/restcli/internal/assertion"
+	"github.com/studiowebux/restcli/internal/executor"
+	"github.com/studiowebux/restcli/internal/parser"
 	"github.com/studiowebux/restcli/internal/types"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -34,11 +36,13 @@ const (
 type RequestTask struct {
 	SequenceNum int
 	StartOffset time.Duration
+	Scenario    int // Index into the executor's scenarios
 }
 
 // RequestResult represents the result of a single request execution
 type RequestResult struct {
 	SequenceNum  int
+	Scenario     int
 	StatusCode   int
 	DurationMs   int64
 	ElapsedMs    int64
@@ -70,6 +74,10 @@ type Executor struct {
 	metricsBuf     []*Metric
 	bufferSize     int
 	httpClient     *http.Client // Shared HTTP client with connection pooling
+	scenarios      []*ScenarioRequest
+	dynamic        []bool          // Scenarios with {{$function}} placeholders, evaluated per request
+	picker         *scenarioPicker // Only used by the scheduler goroutine
+	timeline       timeline        // Per-second samples for live views (guarded by statsMu)
 }
 
 // NewExecutor creates a new stress test executor
@@ -78,15 +86,53 @@ func NewExecutor(config *ExecutionConfig, manager *Manager) (*Executor, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	// Without a weighted mix the request is the only scenario
+	scenarios := config.Scenarios
+	if len(scenarios) == 0 {
+		scenarios = []*ScenarioRequest{{Name: config.Config.RequestFile, Weight: 1, Request: config.Request}}
+	}
+	for _, s := range scenarios {
+		if s.Request == nil {
+			return nil, fmt.Errorf("scenario %s has no request", s.Name)
+		}
+		if s.Weight <= 0 {
+			return nil, fmt.Errorf("scenario %s: weight must be greater than 0", s.Name)
+		}
+	}
+
+	// Every variable left in the requests must be filled by a data file column
+	if config.Data == nil {
+		data, err := config.Config.LoadData()
+		if err != nil {
+			return nil, err
+		}
+		config.Data = data
+	}
+	if config.Data != nil {
+		for _, s := range scenarios {
+			if err := config.Data.CheckColumns(s.Request); err != nil {
+				return nil, fmt.Errorf("invalid data file for %s: %w", ScenarioName(s.Name), err)
+			}
+		}
+	}
+
+	dynamic := make([]bool, len(scenarios))
+	for i, s := range scenarios {
+		dynamic[i] = parser.HasDynamicVariables(s.Request)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Create run record
 	run := &Run{
-		ConfigName:  config.Config.Name,
-		RequestFile: config.Config.RequestFile,
-		ProfileName: config.Config.ProfileName,
-		StartedAt:   time.Now(),
-		Status:      "running",
+		ConfigName:     config.Config.Name,
+		RequestFile:    config.Config.RequestFile,
+		ProfileName:    config.Config.ProfileName,
+		StartedAt:      time.Now(),
+		Status:         "running",
+		TargetRPS:      config.Config.TargetRPS,
+		ThinkTimeMinMs: config.Config.ThinkTimeMinMs,
+		ThinkTimeMaxMs: config.Config.ThinkTimeMaxMs,
 	}
 	if config.Config.ID > 0 {
 		run.ConfigID = &config.Config.ID
@@ -121,6 +167,13 @@ func NewExecutor(config *ExecutionConfig, manager *Manager) (*Executor, error) {
 	// Pre-allocate buffer with calculated capacity to reduce reallocations
 	metricsBuffer := make([]*Metric, 0, bufferSize)
 
+	// In rate-limited mode a request is only dispatched when a worker is free,
+	// so a saturated pool shows up as a lower achieved RPS instead of a growing queue
+	requestBuffer := config.Config.ConcurrentConns * 2
+	if config.Config.IsRateLimited() {
+		requestBuffer = 0
+	}
+
 	return &Executor{
 		config:        config,
 		manager:       manager,
@@ -129,12 +182,15 @@ func NewExecutor(config *ExecutionConfig, manager *Manager) (*Executor, error) {
 		ctx:           ctx,
 		cancelFunc:    cancel,
 		workerReadyCh: make(chan struct{}, config.Config.ConcurrentConns),
-		requestChan:   make(chan *RequestTask, config.Config.ConcurrentConns*2),
+		requestChan:   make(chan *RequestTask, requestBuffer),
 		resultChan:    make(chan *RequestResult, config.Config.ConcurrentConns*2),
 		collectorDone: make(chan struct{}),
 		metricsBuf:    metricsBuffer,
 		bufferSize:    bufferSize,
 		httpClient:    httpClient,
+		scenarios:     scenarios,
+		dynamic:       dynamic,
+		picker:        newScenarioPicker(scenarios),
 	}, nil
 }
 
@@ -353,7 +409,17 @@ func (e *Executor) worker() {
 			// Track active worker
 			atomic.AddInt32(&e.activeWorkers, 1)
 			start := time.Now()
-			result, err := e.executeRequest(e.config.Request)
+			req := e.scenarios[task.Scenario].Request
+			var result *types.RequestResult
+			var err error
+			if e.config.Data != nil {
+				req, err = e.config.Data.resolveRequest(req, task.SequenceNum)
+			} else if e.dynamic[task.Scenario] {
+				req, err = resolveDynamicRequest(req)
+			}
+			if err == nil {
+				result, err = e.executeRequest(req)
+			}
 			duration := time.Since(start)
 			elapsed := time.Since(e.testStart)
 			atomic.AddInt32(&e.activeWorkers, -1)
@@ -361,6 +427,7 @@ func (e *Executor) worker() {
 			// Prepare result
 			requestResult := &RequestResult{
 				SequenceNum: task.SequenceNum,
+				Scenario:    task.Scenario,
 				DurationMs:  duration.Milliseconds(),
 				ElapsedMs:   elapsed.Milliseconds(),
 				Error:       err,
@@ -386,11 +453,21 @@ func (e *Executor) worker() {
 				return
 			case e.resultChan <- requestResult:
 			}
+
+			// Think time before picking up the next request
+			if thinkTime := e.config.Config.GetThinkTime(); thinkTime > 0 {
+				select {
+				case <-e.ctx.Done():
+					return
+				case <-time.After(thinkTime):
+				}
+			}
 		}
 	}
 }
 
 // scheduleRequests schedules requests with optional ramp-up
+// When TargetRPS is set, dispatch is paced by a rate limiter instead
 func (e *Executor) scheduleRequests() {
 	rampUpPerRequest := time.Duration(0)
 	totalRequests := e.config.Config.TotalRequests
@@ -400,7 +477,20 @@ func (e *Executor) scheduleRequests() {
 		rampUpPerRequest = rampUpDuration / time.Duration(totalRequests)
 	}
 
+	var limiter *rate.Limiter
+	if e.config.Config.IsRateLimited() {
+		limiter = rate.NewLimiter(rate.Limit(e.config.Config.TargetRPS), 1)
+	}
+
 	for i := 0; i < totalRequests; i++ {
+		if limiter != nil {
+			if err := limiter.Wait(e.ctx); err != nil {
+				// Context cancelled (duration reached or stopped)
+				close(e.requestChan)
+				return
+			}
+		}
+
 		select {
 		case <-e.ctx.Done():
 			close(e.requestChan)
@@ -408,6 +498,7 @@ func (e *Executor) scheduleRequests() {
 		case e.requestChan <- &RequestTask{
 			SequenceNum: i,
 			StartOffset: time.Duration(i) * rampUpPerRequest,
+			Scenario:    e.picker.next(),
 		}:
 			// Track that we actually sent/queued this request
 			e.statsMu.Lock()
@@ -419,72 +510,10 @@ func (e *Executor) scheduleRequests() {
 	close(e.requestChan)
 }
 
-// validateBody validates the response body against expected patterns
+// validateBody validates the response body against the scenario request's expected patterns
 // Returns empty string if validation passes, or error message if validation fails
-func (e *Executor) validateBody(body string) string {
-	req := e.config.Request
-
-	// Check ExpectedBodyExact (exact string match)
-	if req.ExpectedBodyExact != "" {
-		if body != req.ExpectedBodyExact {
-			return fmt.Sprintf("body does not match expected exact value (expected: %q, got: %q)", req.ExpectedBodyExact, body)
-		}
-	}
-
-	// Check ExpectedBodyContains (substring match)
-	if req.ExpectedBodyContains != "" {
-		if !strings.Contains(body, req.ExpectedBodyContains) {
-			return fmt.Sprintf("body does not contain expected substring: %s", req.ExpectedBodyContains)
-		}
-	}
-
-	// Check ExpectedBodyPattern (regex match)
-	if req.ExpectedBodyPattern != "" {
-		matched, err := regexp.MatchString(req.ExpectedBodyPattern, body)
-		if err != nil {
-			return fmt.Sprintf("invalid body pattern regex: %v", err)
-		}
-		if !matched {
-			return fmt.Sprintf("body does not match expected pattern: %s", req.ExpectedBodyPattern)
-		}
-	}
-
-	// Check ExpectedBodyFields (partial JSON field matching)
-	if len(req.ExpectedBodyFields) > 0 {
-		var bodyJSON map[string]interface{}
-		if err := json.Unmarshal([]byte(body), &bodyJSON); err != nil {
-			return fmt.Sprintf("failed to parse JSON body for field validation: %v", err)
-		}
-
-		for fieldName, expectedValue := range req.ExpectedBodyFields {
-			actualValue, exists := bodyJSON[fieldName]
-			if !exists {
-				return fmt.Sprintf("expected field '%s' not found in response", fieldName)
-			}
-
-			// Convert actual value to string for comparison
-			actualStr := fmt.Sprintf("%v", actualValue)
-
-			// Check if expected value is a regex pattern (starts and ends with /)
-			if strings.HasPrefix(expectedValue, "/") && strings.HasSuffix(expectedValue, "/") {
-				pattern := expectedValue[1 : len(expectedValue)-1]
-				matched, err := regexp.MatchString(pattern, actualStr)
-				if err != nil {
-					return fmt.Sprintf("invalid regex pattern for field '%s': %v", fieldName, err)
-				}
-				if !matched {
-					return fmt.Sprintf("field '%s' value '%s' does not match pattern '%s'", fieldName, actualStr, pattern)
-				}
-			} else {
-				// Literal value comparison
-				if actualStr != expectedValue {
-					return fmt.Sprintf("field '%s' expected '%s' but got '%s'", fieldName, expectedValue, actualStr)
-				}
-			}
-		}
-	}
-
-	return "" // All validations passed
+func (e *Executor) validateBody(req *types.HttpRequest, body string) string {
+	return assertion.CheckBody(req, body)
 }
 
 // collectResults collects and processes request results
@@ -492,6 +521,8 @@ func (e *Executor) collectResults() {
 	defer close(e.collectorDone) // Signal when collector finishes
 
 	for result := range e.resultChan {
+		scenario := e.scenarios[result.Scenario]
+
 		// Determine error types
 		isNetworkError := result.Error != nil || result.StatusCode == 0
 		isValidationError := false
@@ -500,11 +531,11 @@ func (e *Executor) collectResults() {
 		// Skip validation if network error occurred
 		if isNetworkError {
 			// No validation needed for network errors
-		} else if !e.config.Request.IsExpectedStatus(result.StatusCode) {
+		} else if !scenario.Request.IsExpectedStatus(result.StatusCode) {
 			// Status code validation failed
 			isValidationError = true
 			validationErrorMsg = fmt.Sprintf("unexpected status %d", result.StatusCode)
-		} else if bodyValidationErr := e.validateBody(result.Body); bodyValidationErr != "" {
+		} else if bodyValidationErr := e.validateBody(scenario.Request, result.Body); bodyValidationErr != "" {
 			// Body validation failed
 			isValidationError = true
 			validationErrorMsg = bodyValidationErr
@@ -514,6 +545,7 @@ func (e *Executor) collectResults() {
 		// Update statistics
 		e.statsMu.Lock()
 		e.stats.AddResult(result.DurationMs, isNetworkError, isValidationError)
+		e.timeline.add(result.ElapsedMs, result.DurationMs)
 		e.statsMu.Unlock()
 
 		// Buffer metric for batch insert
@@ -525,6 +557,7 @@ func (e *Executor) collectResults() {
 			DurationMs:   result.DurationMs,
 			RequestSize:  result.RequestSize,
 			ResponseSize: result.ResponseSize,
+			Scenario:     scenario.Name,
 		}
 		if result.Error != nil {
 			metric.ErrorMessage = result.Error.Error()
@@ -577,6 +610,11 @@ func (e *Executor) finalize(status string) {
 	e.run.P50DurationMs = e.stats.P50()
 	e.run.P95DurationMs = e.stats.P95()
 	e.run.P99DurationMs = e.stats.P99()
+	if !e.testStart.IsZero() {
+		if elapsed := now.Sub(e.testStart).Seconds(); elapsed > 0 {
+			e.run.AchievedRPS = float64(e.stats.CompletedRequests) / elapsed
+		}
+	}
 
 	err := e.manager.UpdateRun(e.run)
 	if err != nil {
@@ -641,6 +679,10 @@ func buildStressTestHTTPClient(config *Config, tlsConfig *types.TLSConfig) (*htt
 			tlsCfg.RootCAs = caCertPool
 		}
 
+		if err := executor.PinCertificate(tlsCfg, tlsConfig.PinnedCertSHA256); err != nil {
+			return nil, err
+		}
+
 		transport.TLSClientConfig = tlsCfg
 	}
 
@@ -676,6 +718,13 @@ func (e *Executor) executeRequest(req *types.HttpRequest) (*types.RequestResult,
 	for key, value := range req.Headers {
 		httpReq.Header.Set(key, value)
 	}
+	if err := executor.SignRequest(httpReq, req.Signing); err != nil {
+		return &types.RequestResult{
+			Error:       err.Error(),
+			Duration:    0,
+			RequestSize: requestSize,
+		}, nil
+	}
 
 	// Execute request with shared client
 	resp, err := e.httpClient.Do(httpReq)
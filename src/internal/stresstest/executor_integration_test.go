@@ -661,6 +661,179 @@ func TestExecutor_RampUp(t *testing.T) {
 	}
 }
 
+// TestExecutor_TargetRPS tests that a target RPS paces request dispatch
+func TestExecutor_TargetRPS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	manager := createTestManager(t)
+	defer manager.Close()
+	config := &ExecutionConfig{
+		Request: &types.HttpRequest{
+			Method: "GET",
+			URL:    server.URL,
+		},
+		Config: &Config{
+			Name:            "test-rps",
+			RequestFile:     "test.http",
+			ProfileName:     "default",
+			ConcurrentConns: 10,
+			TotalRequests:   20,
+			TargetRPS:       40,
+		},
+	}
+
+	executor, err := NewExecutor(config, manager)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+
+	start := time.Now()
+	executor.Start()
+	executor.Wait()
+	duration := time.Since(start)
+
+	// 20 requests at 40 RPS: the first is immediate, the last starts after 19 * 25ms = 475ms
+	if duration < 450*time.Millisecond {
+		t.Errorf("Expected pacing to take at least 450ms, took: %v", duration)
+	}
+
+	run, err := manager.GetRun(executor.GetRun().ID)
+	if err != nil {
+		t.Fatalf("Failed to load run: %v", err)
+	}
+	if run.TotalRequestsCompleted != 20 {
+		t.Errorf("Expected 20 completed, got: %d", run.TotalRequestsCompleted)
+	}
+	if run.TargetRPS != 40 {
+		t.Errorf("Expected target RPS 40, got: %d", run.TargetRPS)
+	}
+	if run.AchievedRPS <= 0 || run.AchievedRPS > 45 {
+		t.Errorf("Expected achieved RPS close to 40, got: %.1f", run.AchievedRPS)
+	}
+}
+
+// TestConfig_ValidateTargetRPS tests the target RPS validation rules
+func TestConfig_ValidateTargetRPS(t *testing.T) {
+	base := Config{Name: "rps", RequestFile: "test.http", ConcurrentConns: 5, TotalRequests: 10}
+
+	valid := base
+	valid.TargetRPS = 100
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected target RPS config to be valid, got: %v", err)
+	}
+
+	negative := base
+	negative.TargetRPS = -1
+	if err := negative.Validate(); err == nil {
+		t.Error("Expected an error for a negative target RPS")
+	}
+
+	withRampUp := base
+	withRampUp.TargetRPS = 100
+	withRampUp.RampUpDurationSec = 5
+	if err := withRampUp.Validate(); err == nil {
+		t.Error("Expected an error when combining target RPS with ramp-up")
+	}
+}
+
+// TestExecutor_ThinkTime tests that workers pause between requests and stop promptly when cancelled
+func TestExecutor_ThinkTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	manager := createTestManager(t)
+	defer manager.Close()
+
+	newConfig := func(total, minMs, maxMs int) *ExecutionConfig {
+		return &ExecutionConfig{
+			Request: &types.HttpRequest{
+				Method: "GET",
+				URL:    server.URL,
+			},
+			Config: &Config{
+				Name:            "test-think-time",
+				RequestFile:     "test.http",
+				ProfileName:     "default",
+				ConcurrentConns: 1,
+				TotalRequests:   total,
+				ThinkTimeMinMs:  minMs,
+				ThinkTimeMaxMs:  maxMs,
+			},
+		}
+	}
+
+	// One worker, 4 requests, 100ms pause after each
+	executor, err := NewExecutor(newConfig(4, 100, 100), manager)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	start := time.Now()
+	executor.Start()
+	executor.Wait()
+	if duration := time.Since(start); duration < 300*time.Millisecond {
+		t.Errorf("Expected think time to take at least 300ms, took: %v", duration)
+	}
+	if executor.GetStats().CompletedRequests != 4 {
+		t.Errorf("Expected 4 completed, got: %d", executor.GetStats().CompletedRequests)
+	}
+	run, err := manager.GetRun(executor.GetRun().ID)
+	if err != nil {
+		t.Fatalf("Failed to load run: %v", err)
+	}
+	if run.ThinkTimeMinMs != 100 || run.ThinkTimeMaxMs != 100 {
+		t.Errorf("Expected think time 100-100ms on run, got: %d-%dms", run.ThinkTimeMinMs, run.ThinkTimeMaxMs)
+	}
+
+	// A long think time must not delay cancellation
+	executor, err = NewExecutor(newConfig(10, 10000, 10000), manager)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	executor.Start()
+	time.Sleep(100 * time.Millisecond)
+	start = time.Now()
+	executor.Stop()
+	executor.Wait()
+	if duration := time.Since(start); duration > 2*time.Second {
+		t.Errorf("Expected stop to interrupt think time, took: %v", duration)
+	}
+}
+
+// TestConfig_ThinkTime tests think time validation and bounds
+func TestConfig_ThinkTime(t *testing.T) {
+	config := Config{Name: "think", RequestFile: "test.http", ConcurrentConns: 1, TotalRequests: 1, ThinkTimeMinMs: 500, ThinkTimeMaxMs: 100}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an error when think time max is less than min")
+	}
+
+	config.ThinkTimeMinMs = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an error for a negative think time")
+	}
+
+	config.ThinkTimeMinMs = 50
+	config.ThinkTimeMaxMs = 80
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Expected think time config to be valid, got: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		if d := config.GetThinkTime(); d < 50*time.Millisecond || d > 80*time.Millisecond {
+			t.Fatalf("Think time out of bounds: %v", d)
+		}
+	}
+
+	config.ThinkTimeMinMs = 0
+	config.ThinkTimeMaxMs = 0
+	if d := config.GetThinkTime(); d != 0 {
+		t.Errorf("Expected no think time, got: %v", d)
+	}
+}
+
 // TestExecutor_DurationBasedTest tests duration-based execution
 func TestExecutor_DurationBasedTest(t *testing.T) {
 	requestCount := int64(0)
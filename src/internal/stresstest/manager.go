@@ -2,6 +2,7 @@ package stresstest
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -42,13 +43,19 @@ func (m *Manager) SaveConfig(config *Config) error {
 		return err
 	}
 
+	scenarios, err := encodeScenarios(config.Scenarios)
+	if err != nil {
+		return err
+	}
+
 	if config.ID == 0 {
 		// Insert new config
 		result, err := m.db.Exec(`
 			INSERT INTO stress_test_configs
-			(name, request_file, profile_name, concurrent_connections, total_requests, ramp_up_duration_sec, test_duration_sec)
-			VALUES (?, ?, ?, ?, ?, ?, ?)
-		`, config.Name, config.RequestFile, config.ProfileName, config.ConcurrentConns, config.TotalRequests, config.RampUpDurationSec, config.TestDurationSec)
+			(name, request_file, profile_name, concurrent_connections, total_requests, ramp_up_duration_sec, test_duration_sec, target_rps, think_time_min_ms, think_time_max_ms, data_file, data_order, scenarios)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, config.Name, config.RequestFile, config.ProfileName, config.ConcurrentConns, config.TotalRequests, config.RampUpDurationSec, config.TestDurationSec, config.TargetRPS, config.ThinkTimeMinMs, config.ThinkTimeMaxMs,
+			config.DataFile, config.DataOrder, scenarios)
 		if err != nil {
 			return fmt.Errorf("failed to insert config: %w", err)
 		}
@@ -62,9 +69,12 @@ func (m *Manager) SaveConfig(config *Config) error {
 		_, err := m.db.Exec(`
 			UPDATE stress_test_configs
 			SET name = ?, request_file = ?, profile_name = ?, concurrent_connections = ?,
-			    total_requests = ?, ramp_up_duration_sec = ?, test_duration_sec = ?, updated_at = CURRENT_TIMESTAMP
+			    total_requests = ?, ramp_up_duration_sec = ?, test_duration_sec = ?, target_rps = ?,
+			    think_time_min_ms = ?, think_time_max_ms = ?, data_file = ?, data_order = ?, scenarios = ?,
+			    updated_at = CURRENT_TIMESTAMP
 			WHERE id = ?
-		`, config.Name, config.RequestFile, config.ProfileName, config.ConcurrentConns, config.TotalRequests, config.RampUpDurationSec, config.TestDurationSec, config.ID)
+		`, config.Name, config.RequestFile, config.ProfileName, config.ConcurrentConns, config.TotalRequests, config.RampUpDurationSec, config.TestDurationSec, config.TargetRPS,
+			config.ThinkTimeMinMs, config.ThinkTimeMaxMs, config.DataFile, config.DataOrder, scenarios, config.ID)
 		if err != nil {
 			return fmt.Errorf("failed to update config: %w", err)
 		}
@@ -75,32 +85,40 @@ func (m *Manager) SaveConfig(config *Config) error {
 // GetConfig retrieves a config by ID
 func (m *Manager) GetConfig(id int64) (*Config, error) {
 	config := &Config{}
+	var scenarios string
 	err := m.db.QueryRow(`
 		SELECT id, name, request_file, COALESCE(profile_name, ''), concurrent_connections, total_requests,
-		       ramp_up_duration_sec, test_duration_sec, created_at, updated_at
+		       ramp_up_duration_sec, test_duration_sec, target_rps, think_time_min_ms, think_time_max_ms, data_file, data_order, scenarios, created_at, updated_at
 		FROM stress_test_configs WHERE id = ?
 	`, id).Scan(&config.ID, &config.Name, &config.RequestFile, &config.ProfileName,
 		&config.ConcurrentConns, &config.TotalRequests, &config.RampUpDurationSec,
-		&config.TestDurationSec, &config.CreatedAt, &config.UpdatedAt)
+		&config.TestDurationSec, &config.TargetRPS, &config.ThinkTimeMinMs, &config.ThinkTimeMaxMs, &config.DataFile, &config.DataOrder, &scenarios, &config.CreatedAt, &config.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
+	if config.Scenarios, err = decodeScenarios(scenarios); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
 
 // GetConfigByName retrieves a config by name and profile
 func (m *Manager) GetConfigByName(name string, profileName string) (*Config, error) {
 	config := &Config{}
+	var scenarios string
 	err := m.db.QueryRow(`
 		SELECT id, name, request_file, COALESCE(profile_name, ''), concurrent_connections, total_requests,
-		       ramp_up_duration_sec, test_duration_sec, created_at, updated_at
+		       ramp_up_duration_sec, test_duration_sec, target_rps, think_time_min_ms, think_time_max_ms, data_file, data_order, scenarios, created_at, updated_at
 		FROM stress_test_configs WHERE name = ? AND (profile_name = ? OR profile_name IS NULL)
 	`, name, profileName).Scan(&config.ID, &config.Name, &config.RequestFile, &config.ProfileName,
 		&config.ConcurrentConns, &config.TotalRequests, &config.RampUpDurationSec,
-		&config.TestDurationSec, &config.CreatedAt, &config.UpdatedAt)
+		&config.TestDurationSec, &config.TargetRPS, &config.ThinkTimeMinMs, &config.ThinkTimeMaxMs, &config.DataFile, &config.DataOrder, &scenarios, &config.CreatedAt, &config.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
+	if config.Scenarios, err = decodeScenarios(scenarios); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
 
@@ -108,7 +126,7 @@ func (m *Manager) GetConfigByName(name string, profileName string) (*Config, err
 func (m *Manager) ListConfigs(profileName string) ([]*Config, error) {
 	rows, err := m.db.Query(`
 		SELECT id, name, request_file, COALESCE(profile_name, ''), concurrent_connections, total_requests,
-		       ramp_up_duration_sec, test_duration_sec, created_at, updated_at
+		       ramp_up_duration_sec, test_duration_sec, target_rps, think_time_min_ms, think_time_max_ms, data_file, data_order, scenarios, created_at, updated_at
 		FROM stress_test_configs
 		WHERE profile_name = ? OR (profile_name IS NULL AND ? = '')
 		ORDER BY updated_at DESC
@@ -121,12 +139,16 @@ func (m *Manager) ListConfigs(profileName string) ([]*Config, error) {
 	var configs []*Config
 	for rows.Next() {
 		config := &Config{}
+		var scenarios string
 		err := rows.Scan(&config.ID, &config.Name, &config.RequestFile, &config.ProfileName,
 			&config.ConcurrentConns, &config.TotalRequests, &config.RampUpDurationSec,
-			&config.TestDurationSec, &config.CreatedAt, &config.UpdatedAt)
+			&config.TestDurationSec, &config.TargetRPS, &config.ThinkTimeMinMs, &config.ThinkTimeMaxMs, &config.DataFile, &config.DataOrder, &scenarios, &config.CreatedAt, &config.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
+		if config.Scenarios, err = decodeScenarios(scenarios); err != nil {
+			return nil, err
+		}
 		configs = append(configs, config)
 	}
 	return configs, nil
@@ -142,9 +164,9 @@ func (m *Manager) DeleteConfig(id int64) error {
 func (m *Manager) CreateRun(run *Run) error {
 	result, err := m.db.Exec(`
 		INSERT INTO stress_test_runs
-		(config_id, config_name, request_file, profile_name, started_at, status)
-		VALUES (?, ?, ?, ?, ?, ?)
-	`, run.ConfigID, run.ConfigName, run.RequestFile, run.ProfileName, run.StartedAt, run.Status)
+		(config_id, config_name, request_file, profile_name, started_at, status, target_rps, think_time_min_ms, think_time_max_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, run.ConfigID, run.ConfigName, run.RequestFile, run.ProfileName, run.StartedAt, run.Status, run.TargetRPS, run.ThinkTimeMinMs, run.ThinkTimeMaxMs)
 	if err != nil {
 		return fmt.Errorf("failed to create run: %w", err)
 	}
@@ -162,11 +184,11 @@ func (m *Manager) UpdateRun(run *Run) error {
 		UPDATE stress_test_runs
 		SET completed_at = ?, status = ?, total_requests_sent = ?, total_requests_completed = ?,
 		    total_errors = ?, total_validation_errors = ?, avg_duration_ms = ?, min_duration_ms = ?, max_duration_ms = ?,
-		    p50_duration_ms = ?, p95_duration_ms = ?, p99_duration_ms = ?
+		    p50_duration_ms = ?, p95_duration_ms = ?, p99_duration_ms = ?, achieved_rps = ?
 		WHERE id = ?
 	`, run.CompletedAt, run.Status, run.TotalRequestsSent, run.TotalRequestsCompleted,
 		run.TotalErrors, run.TotalValidationErrors, run.AvgDurationMs, run.MinDurationMs, run.MaxDurationMs,
-		run.P50DurationMs, run.P95DurationMs, run.P99DurationMs, run.ID)
+		run.P50DurationMs, run.P95DurationMs, run.P99DurationMs, run.AchievedRPS, run.ID)
 	return err
 }
 
@@ -180,12 +202,14 @@ func (m *Manager) GetRun(id int64) (*Run, error) {
 		SELECT id, config_id, config_name, request_file, COALESCE(profile_name, ''), started_at, completed_at, status,
 		       total_requests_sent, total_requests_completed, total_errors, COALESCE(total_validation_errors, 0),
 		       COALESCE(avg_duration_ms, 0), COALESCE(min_duration_ms, 0), COALESCE(max_duration_ms, 0),
-		       COALESCE(p50_duration_ms, 0), COALESCE(p95_duration_ms, 0), COALESCE(p99_duration_ms, 0)
+		       COALESCE(p50_duration_ms, 0), COALESCE(p95_duration_ms, 0), COALESCE(p99_duration_ms, 0),
+		       target_rps, achieved_rps, think_time_min_ms, think_time_max_ms
 		FROM stress_test_runs WHERE id = ?
 	`, id).Scan(&run.ID, &configID, &run.ConfigName, &run.RequestFile, &run.ProfileName,
 		&run.StartedAt, &completedAt, &run.Status, &run.TotalRequestsSent,
 		&run.TotalRequestsCompleted, &run.TotalErrors, &run.TotalValidationErrors, &run.AvgDurationMs, &run.MinDurationMs,
-		&run.MaxDurationMs, &run.P50DurationMs, &run.P95DurationMs, &run.P99DurationMs)
+		&run.MaxDurationMs, &run.P50DurationMs, &run.P95DurationMs, &run.P99DurationMs,
+		&run.TargetRPS, &run.AchievedRPS, &run.ThinkTimeMinMs, &run.ThinkTimeMaxMs)
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +230,8 @@ func (m *Manager) ListRuns(profileName string, limit int) ([]*Run, error) {
 		SELECT id, config_id, config_name, request_file, COALESCE(profile_name, ''), started_at, completed_at, status,
 		       total_requests_sent, total_requests_completed, total_errors, COALESCE(total_validation_errors, 0),
 		       COALESCE(avg_duration_ms, 0), COALESCE(min_duration_ms, 0), COALESCE(max_duration_ms, 0),
-		       COALESCE(p50_duration_ms, 0), COALESCE(p95_duration_ms, 0), COALESCE(p99_duration_ms, 0)
+		       COALESCE(p50_duration_ms, 0), COALESCE(p95_duration_ms, 0), COALESCE(p99_duration_ms, 0),
+		       target_rps, achieved_rps, think_time_min_ms, think_time_max_ms
 		FROM stress_test_runs
 		WHERE profile_name = ? OR (profile_name IS NULL AND ? = '')
 		ORDER BY started_at DESC
@@ -230,7 +255,8 @@ func (m *Manager) ListRuns(profileName string, limit int) ([]*Run, error) {
 		err := rows.Scan(&run.ID, &configID, &run.ConfigName, &run.RequestFile, &run.ProfileName,
 			&run.StartedAt, &completedAt, &run.Status, &run.TotalRequestsSent,
 			&run.TotalRequestsCompleted, &run.TotalErrors, &run.TotalValidationErrors, &run.AvgDurationMs, &run.MinDurationMs,
-			&run.MaxDurationMs, &run.P50DurationMs, &run.P95DurationMs, &run.P99DurationMs)
+			&run.MaxDurationMs, &run.P50DurationMs, &run.P95DurationMs, &run.P99DurationMs,
+			&run.TargetRPS, &run.AchievedRPS, &run.ThinkTimeMinMs, &run.ThinkTimeMaxMs)
 		if err != nil {
 			return nil, err
 		}
@@ -257,10 +283,10 @@ func (m *Manager) DeleteRun(id int64) error {
 func (m *Manager) SaveMetric(metric *Metric) error {
 	result, err := m.db.Exec(`
 		INSERT INTO stress_test_metrics
-		(run_id, timestamp, elapsed_ms, status_code, duration_ms, request_size, response_size, error_message)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		(run_id, timestamp, elapsed_ms, status_code, duration_ms, request_size, response_size, error_message, scenario)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, metric.RunID, metric.Timestamp, metric.ElapsedMs, metric.StatusCode, metric.DurationMs,
-		metric.RequestSize, metric.ResponseSize, metric.ErrorMessage)
+		metric.RequestSize, metric.ResponseSize, metric.ErrorMessage, metric.Scenario)
 	if err != nil {
 		return fmt.Errorf("failed to save metric: %w", err)
 	}
@@ -286,8 +312,8 @@ func (m *Manager) SaveMetricsBatch(metrics []*Metric) error {
 
 	stmt, err := tx.Prepare(`
 		INSERT INTO stress_test_metrics
-		(run_id, timestamp, elapsed_ms, status_code, duration_ms, request_size, response_size, error_message, validation_error)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		(run_id, timestamp, elapsed_ms, status_code, duration_ms, request_size, response_size, error_message, validation_error, scenario)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -296,7 +322,7 @@ func (m *Manager) SaveMetricsBatch(metrics []*Metric) error {
 
 	for _, metric := range metrics {
 		_, err := stmt.Exec(metric.RunID, metric.Timestamp, metric.ElapsedMs, metric.StatusCode,
-			metric.DurationMs, metric.RequestSize, metric.ResponseSize, metric.ErrorMessage, metric.ValidationError)
+			metric.DurationMs, metric.RequestSize, metric.ResponseSize, metric.ErrorMessage, metric.ValidationError, metric.Scenario)
 		if err != nil {
 			return fmt.Errorf("failed to insert metric: %w", err)
 		}
@@ -309,7 +335,7 @@ func (m *Manager) SaveMetricsBatch(metrics []*Metric) error {
 func (m *Manager) GetMetrics(runID int64) ([]*Metric, error) {
 	rows, err := m.db.Query(`
 		SELECT id, run_id, timestamp, elapsed_ms, status_code, duration_ms, request_size, response_size,
-		       error_message, COALESCE(validation_error, '')
+		       error_message, COALESCE(validation_error, ''), scenario
 		FROM stress_test_metrics
 		WHERE run_id = ?
 		ORDER BY elapsed_ms
@@ -327,7 +353,7 @@ func (m *Manager) GetMetrics(runID int64) ([]*Metric, error) {
 
 		err := rows.Scan(&metric.ID, &metric.RunID, &metric.Timestamp, &metric.ElapsedMs,
 			&metric.StatusCode, &metric.DurationMs, &metric.RequestSize, &metric.ResponseSize,
-			&errorMsg, &validationErr)
+			&errorMsg, &validationErr, &metric.Scenario)
 		if err != nil {
 			return nil, err
 		}
@@ -343,3 +369,27 @@ func (m *Manager) GetMetrics(runID int64) ([]*Metric, error) {
 	}
 	return metrics, nil
 }
+
+// encodeScenarios serializes scenarios for storage (empty string when there are none)
+func encodeScenarios(scenarios []Scenario) (string, error) {
+	if len(scenarios) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(scenarios)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode scenarios: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeScenarios parses stored scenarios
+func decodeScenarios(data string) ([]Scenario, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var scenarios []Scenario
+	if err := json.Unmarshal([]byte(data), &scenarios); err != nil {
+		return nil, fmt.Errorf("failed to decode scenarios: %w", err)
+	}
+	return scenarios, nil
+}
@@ -0,0 +1,101 @@
+package stresstest
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// prometheusQuantiles are the latency percentiles exported for a run
+var prometheusQuantiles = []float64{50, 90, 95, 99}
+
+// WritePrometheus renders a run in the Prometheus text exposition format
+// Metrics are computed from the persisted stress_test_metrics rows, so past runs can be exported again
+func (m *Manager) WritePrometheus(w io.Writer, runID int64) error {
+	run, err := m.GetRun(runID)
+	if err != nil {
+		return fmt.Errorf("failed to load run %d: %w", runID, err)
+	}
+
+	rows, err := m.db.Query(`
+		SELECT status_code, duration_ms, COALESCE(error_message, ''), COALESCE(validation_error, '')
+		FROM stress_test_metrics
+		WHERE run_id = ?
+	`, runID)
+	if err != nil {
+		return fmt.Errorf("failed to load metrics for run %d: %w", runID, err)
+	}
+	defer rows.Close()
+
+	stats := NewStats()
+	statusCodes := make(map[int]int)
+	for rows.Next() {
+		var statusCode int
+		var durationMs int64
+		var errorMsg, validationErr string
+		if err := rows.Scan(&statusCode, &durationMs, &errorMsg, &validationErr); err != nil {
+			return fmt.Errorf("failed to scan metric: %w", err)
+		}
+		// Same classification as the executor
+		stats.AddResult(durationMs, errorMsg != "" || statusCode == 0, validationErr != "")
+		statusCodes[statusCode]++
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read metrics for run %d: %w", runID, err)
+	}
+
+	labels := fmt.Sprintf(`config=%s,profile=%s,run_id="%d"`,
+		prometheusLabelValue(run.ConfigName), prometheusLabelValue(run.ProfileName), run.ID)
+
+	var b strings.Builder
+	writeFamily := func(name, metricType, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+	}
+
+	writeFamily("restcli_stress_requests_total", "counter", "Requests completed during the stress test run.")
+	fmt.Fprintf(&b, "restcli_stress_requests_total{%s} %d\n", labels, stats.CompletedRequests)
+
+	writeFamily("restcli_stress_errors_total", "counter", "Requests that failed with a network error (timeout, connection failure).")
+	fmt.Fprintf(&b, "restcli_stress_errors_total{%s} %d\n", labels, stats.ErrorCount)
+
+	writeFamily("restcli_stress_validation_errors_total", "counter", "Requests that failed validation (unexpected status, body mismatch).")
+	fmt.Fprintf(&b, "restcli_stress_validation_errors_total{%s} %d\n", labels, stats.ValidationErrorCount)
+
+	writeFamily("restcli_stress_request_duration_seconds", "summary", "Request latency of the stress test run.")
+	for _, q := range prometheusQuantiles {
+		fmt.Fprintf(&b, "restcli_stress_request_duration_seconds{%s,quantile=\"%s\"} %s\n",
+			labels, strconv.FormatFloat(q/100, 'f', -1, 64), msToSeconds(stats.Percentile(q)))
+	}
+	fmt.Fprintf(&b, "restcli_stress_request_duration_seconds_sum{%s} %s\n", labels, msToSeconds(stats.TotalDurationMs))
+	fmt.Fprintf(&b, "restcli_stress_request_duration_seconds_count{%s} %d\n", labels, stats.CompletedRequests)
+
+	writeFamily("restcli_stress_responses_total", "counter", "Responses by HTTP status code (0 = no response).")
+	codes := make([]int, 0, len(statusCodes))
+	for code := range statusCodes {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		fmt.Fprintf(&b, "restcli_stress_responses_total{%s,code=\"%d\"} %d\n", labels, code, statusCodes[code])
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+	return nil
+}
+
+// prometheusLabelValue quotes a label value, escaping backslashes, quotes and newlines
+func prometheusLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return `"` + value + `"`
+}
+
+// msToSeconds formats a millisecond duration as seconds
+func msToSeconds(ms int64) string {
+	return strconv.FormatFloat(float64(ms)/1000, 'f', -1, 64)
+}
@@ -0,0 +1,64 @@
+package stresstest
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManager_WritePrometheus(t *testing.T) {
+	manager := createTestManager(t)
+	defer manager.Close()
+
+	run := &Run{ConfigName: `login "smoke"`, RequestFile: "login.http", ProfileName: "staging", StartedAt: time.Now(), Status: "completed"}
+	if err := manager.CreateRun(run); err != nil {
+		t.Fatalf("Failed to create run: %v", err)
+	}
+
+	now := time.Now()
+	metrics := []*Metric{
+		{RunID: run.ID, Timestamp: now, StatusCode: 200, DurationMs: 100},
+		{RunID: run.ID, Timestamp: now, StatusCode: 200, DurationMs: 200},
+		{RunID: run.ID, Timestamp: now, StatusCode: 404, DurationMs: 300, ValidationError: "unexpected status 404"},
+		{RunID: run.ID, Timestamp: now, StatusCode: 0, DurationMs: 400, ErrorMessage: "connection refused"},
+	}
+	if err := manager.SaveMetricsBatch(metrics); err != nil {
+		t.Fatalf("Failed to save metrics: %v", err)
+	}
+
+	var out strings.Builder
+	if err := manager.WritePrometheus(&out, run.ID); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+
+	labels := `config="login \"smoke\"",profile="staging",run_id="` + strconv.FormatInt(run.ID, 10) + `"`
+	expected := []string{
+		"# TYPE restcli_stress_requests_total counter",
+		"restcli_stress_requests_total{" + labels + "} 4",
+		"restcli_stress_errors_total{" + labels + "} 1",
+		"restcli_stress_validation_errors_total{" + labels + "} 1",
+		"# TYPE restcli_stress_request_duration_seconds summary",
+		"restcli_stress_request_duration_seconds{" + labels + `,quantile="0.5"} 0.25`,
+		"restcli_stress_request_duration_seconds_sum{" + labels + "} 1",
+		"restcli_stress_request_duration_seconds_count{" + labels + "} 4",
+		"restcli_stress_responses_total{" + labels + `,code="0"} 1`,
+		"restcli_stress_responses_total{" + labels + `,code="200"} 2`,
+		"restcli_stress_responses_total{" + labels + `,code="404"} 1`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestManager_WritePrometheus_UnknownRun(t *testing.T) {
+	manager := createTestManager(t)
+	defer manager.Close()
+
+	var out strings.Builder
+	if err := manager.WritePrometheus(&out, 42); err == nil {
+		t.Error("Expected an error for an unknown run")
+	}
+}
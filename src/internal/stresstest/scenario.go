@@ -0,0 +1,141 @@
+package stresstest
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Scenario is a request file and its share of the load in a weighted mix
+type Scenario struct {
+	RequestFile string `json:"requestFile"`
+	Weight      int    `json:"weight"`
+}
+
+// ScenarioRequest is a resolved scenario request ready for execution
+type ScenarioRequest struct {
+	Name    string // Identifier recorded on every metric of this scenario
+	Weight  int
+	Request *types.HttpRequest
+}
+
+// ScenarioStats aggregates the metrics of one scenario of a run
+type ScenarioStats struct {
+	Scenario string
+	*Stats
+}
+
+// ParseScenarios parses a "get.http:70, post.http:20" list
+// A missing weight defaults to 1
+func ParseScenarios(input string) ([]Scenario, error) {
+	var scenarios []Scenario
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		scenario := Scenario{RequestFile: part, Weight: 1}
+		// Split on the last colon so Windows drive letters stay in the path
+		if idx := strings.LastIndex(part, ":"); idx > 0 {
+			if weight, err := strconv.Atoi(strings.TrimSpace(part[idx+1:])); err == nil {
+				scenario.RequestFile = strings.TrimSpace(part[:idx])
+				scenario.Weight = weight
+			}
+		}
+		if scenario.Weight <= 0 {
+			return nil, fmt.Errorf("scenario %s: weight must be greater than 0", scenario.RequestFile)
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return scenarios, nil
+}
+
+// FormatScenarios renders scenarios in the format read by ParseScenarios
+func FormatScenarios(scenarios []Scenario) string {
+	parts := make([]string, len(scenarios))
+	for i, s := range scenarios {
+		parts[i] = fmt.Sprintf("%s:%d", s.RequestFile, s.Weight)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ScenarioName returns the short name shown for a scenario in results
+func ScenarioName(requestFile string) string {
+	return filepath.Base(requestFile)
+}
+
+// scenarioPicker distributes requests across scenarios by weight
+// Smooth weighted round-robin keeps the mix exact over every cycle of total weight
+// and interleaves scenarios instead of sending them in bursts
+type scenarioPicker struct {
+	weights []int
+	current []int
+	total   int
+}
+
+func newScenarioPicker(scenarios []*ScenarioRequest) *scenarioPicker {
+	p := &scenarioPicker{
+		weights: make([]int, len(scenarios)),
+		current: make([]int, len(scenarios)),
+	}
+	for i, s := range scenarios {
+		p.weights[i] = s.Weight
+		p.total += s.Weight
+	}
+	return p
+}
+
+// next returns the index of the scenario for the next request
+func (p *scenarioPicker) next() int {
+	best := 0
+	for i, weight := range p.weights {
+		p.current[i] += weight
+		if p.current[i] > p.current[best] {
+			best = i
+		}
+	}
+	p.current[best] -= p.total
+	return best
+}
+
+// GetScenarioStats returns per-scenario aggregates for a run, in order of first appearance
+func (m *Manager) GetScenarioStats(runID int64) ([]*ScenarioStats, error) {
+	rows, err := m.db.Query(`
+		SELECT scenario, status_code, duration_ms, COALESCE(error_message, ''), COALESCE(validation_error, '')
+		FROM stress_test_metrics
+		WHERE run_id = ?
+		ORDER BY id
+	`, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metrics for run %d: %w", runID, err)
+	}
+	defer rows.Close()
+
+	var result []*ScenarioStats
+	byName := make(map[string]*ScenarioStats)
+	for rows.Next() {
+		var scenario, errorMsg, validationErr string
+		var statusCode int
+		var durationMs int64
+		if err := rows.Scan(&scenario, &statusCode, &durationMs, &errorMsg, &validationErr); err != nil {
+			return nil, fmt.Errorf("failed to scan metric: %w", err)
+		}
+
+		stats, ok := byName[scenario]
+		if !ok {
+			stats = &ScenarioStats{Scenario: scenario, Stats: NewStats()}
+			byName[scenario] = stats
+			result = append(result, stats)
+		}
+		// Same classification as the executor
+		stats.AddResult(durationMs, errorMsg != "" || statusCode == 0, validationErr != "")
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics for run %d: %w", runID, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,140 @@
+package stresstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestParseScenarios(t *testing.T) {
+	scenarios, err := ParseScenarios("get.http:70, post.http:20,C:\\api\\delete.http:10, head.http")
+	if err != nil {
+		t.Fatalf("ParseScenarios failed: %v", err)
+	}
+	expected := []Scenario{
+		{RequestFile: "get.http", Weight: 70},
+		{RequestFile: "post.http", Weight: 20},
+		{RequestFile: "C:\\api\\delete.http", Weight: 10},
+		{RequestFile: "head.http", Weight: 1},
+	}
+	if len(scenarios) != len(expected) {
+		t.Fatalf("Expected %d scenarios, got: %v", len(expected), scenarios)
+	}
+	for i := range expected {
+		if scenarios[i] != expected[i] {
+			t.Errorf("Scenario %d: expected %+v, got %+v", i, expected[i], scenarios[i])
+		}
+	}
+
+	if formatted := FormatScenarios(scenarios[:2]); formatted != "get.http:70, post.http:20" {
+		t.Errorf("Unexpected format: %s", formatted)
+	}
+
+	if _, err := ParseScenarios("get.http:0"); err == nil {
+		t.Error("Expected an error for a zero weight")
+	}
+}
+
+func TestScenarioPicker(t *testing.T) {
+	picker := newScenarioPicker([]*ScenarioRequest{{Weight: 7}, {Weight: 2}, {Weight: 1}})
+
+	counts := make([]int, 3)
+	for i := 0; i < 100; i++ {
+		counts[picker.next()]++
+	}
+	if counts[0] != 70 || counts[1] != 20 || counts[2] != 10 {
+		t.Errorf("Expected a 70/20/10 split, got: %v", counts)
+	}
+}
+
+func TestExecutor_WeightedScenarios(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	manager := createTestManager(t)
+	defer manager.Close()
+
+	config := &ExecutionConfig{
+		Request: &types.HttpRequest{Method: "GET", URL: server.URL},
+		Config: &Config{
+			Name:            "test-scenarios",
+			RequestFile:     "get.http",
+			ProfileName:     "default",
+			ConcurrentConns: 4,
+			TotalRequests:   20,
+		},
+		Scenarios: []*ScenarioRequest{
+			{Name: "get.http", Weight: 7, Request: &types.HttpRequest{Method: "GET", URL: server.URL}},
+			{Name: "post.http", Weight: 2, Request: &types.HttpRequest{Method: "POST", URL: server.URL}},
+			{Name: "delete.http", Weight: 1, Request: &types.HttpRequest{Method: "DELETE", URL: server.URL}},
+		},
+	}
+
+	executor, err := NewExecutor(config, manager)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+	executor.Start()
+	executor.Wait()
+
+	stats, err := manager.GetScenarioStats(executor.GetRun().ID)
+	if err != nil {
+		t.Fatalf("GetScenarioStats failed: %v", err)
+	}
+	byName := make(map[string]*ScenarioStats)
+	for _, s := range stats {
+		byName[s.Scenario] = s
+	}
+
+	expected := map[string]int{"get.http": 14, "post.http": 4, "delete.http": 2}
+	for name, count := range expected {
+		s, ok := byName[name]
+		if !ok {
+			t.Errorf("Missing stats for scenario %s", name)
+			continue
+		}
+		if s.CompletedRequests != count {
+			t.Errorf("Scenario %s: expected %d requests, got %d", name, count, s.CompletedRequests)
+		}
+	}
+
+	// Only the DELETE scenario fails validation
+	if s := byName["delete.http"]; s != nil && s.ValidationErrorCount != 2 {
+		t.Errorf("Expected 2 validation errors for delete.http, got: %d", s.ValidationErrorCount)
+	}
+	if s := byName["get.http"]; s != nil && s.ValidationErrorCount != 0 {
+		t.Errorf("Expected no validation errors for get.http, got: %d", s.ValidationErrorCount)
+	}
+}
+
+func TestManager_ConfigScenarios(t *testing.T) {
+	manager := createTestManager(t)
+	defer manager.Close()
+
+	config := &Config{
+		Name:            "mix",
+		RequestFile:     "get.http",
+		ConcurrentConns: 1,
+		TotalRequests:   1,
+		Scenarios:       []Scenario{{RequestFile: "get.http", Weight: 3}, {RequestFile: "post.http", Weight: 1}},
+	}
+	if err := manager.SaveConfig(config); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	loaded, err := manager.GetConfig(config.ID)
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	if FormatScenarios(loaded.Scenarios) != "get.http:3, post.http:1" {
+		t.Errorf("Scenarios not persisted, got: %v", loaded.Scenarios)
+	}
+}
@@ -0,0 +1,61 @@
+package stresstest
+
+import "time"
+
+// maxPendingSamples bounds the samples kept when nobody drains the timeline
+const maxPendingSamples = 300
+
+// SecondSample summarizes the requests completed during one second of a run
+type SecondSample struct {
+	Second   int // Seconds since the test started
+	Requests int
+	P50Ms    int64
+	P95Ms    int64
+}
+
+// timeline buckets completed requests by the second they finished in
+type timeline struct {
+	second    int     // Second currently being filled
+	durations []int64 // Durations completed during that second
+	completed []SecondSample
+}
+
+// add records a request that completed elapsedMs after the test started
+// Late results for an already closed second are counted in the current one
+func (t *timeline) add(elapsedMs, durationMs int64) {
+	t.advance(int(elapsedMs / 1000))
+	t.durations = append(t.durations, durationMs)
+}
+
+// advance closes every second before sec, including seconds without requests
+func (t *timeline) advance(sec int) {
+	for t.second < sec {
+		sample := SecondSample{Second: t.second, Requests: len(t.durations)}
+		if len(t.durations) > 0 {
+			stats := &Stats{Durations: t.durations}
+			sample.P50Ms = stats.P50()
+			sample.P95Ms = stats.P95()
+		}
+		t.completed = append(t.completed, sample)
+		if len(t.completed) > maxPendingSamples {
+			t.completed = t.completed[len(t.completed)-maxPendingSamples:]
+		}
+		t.durations = t.durations[:0]
+		t.second++
+	}
+}
+
+// DrainTimeline returns the per-second samples completed since the last call
+// Only whole seconds are returned, so the current partial second is never reported twice
+func (e *Executor) DrainTimeline() []SecondSample {
+	if e.testStart.IsZero() {
+		return nil
+	}
+
+	e.statsMu.Lock()
+	defer e.statsMu.Unlock()
+	e.timeline.advance(int(time.Since(e.testStart) / time.Second))
+	samples := e.timeline.completed
+	e.timeline.completed = nil
+	return samples
+}
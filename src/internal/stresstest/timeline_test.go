@@ -0,0 +1,28 @@
+package stresstest
+
+import "testing"
+
+func TestTimeline(t *testing.T) {
+	var tl timeline
+
+	tl.add(100, 10)
+	tl.add(900, 30)
+	tl.add(2500, 50) // Second 1 had no requests
+
+	if len(tl.completed) != 2 {
+		t.Fatalf("Expected 2 closed seconds, got: %+v", tl.completed)
+	}
+	first, second := tl.completed[0], tl.completed[1]
+	if first.Second != 0 || first.Requests != 2 || first.P50Ms != 20 || first.P95Ms != 29 {
+		t.Errorf("Unexpected first second: %+v", first)
+	}
+	if second.Second != 1 || second.Requests != 0 || second.P95Ms != 0 {
+		t.Errorf("Expected an empty second 1, got: %+v", second)
+	}
+
+	// Second 2 is still open until time moves on
+	tl.advance(3)
+	if len(tl.completed) != 3 || tl.completed[2].Requests != 1 || tl.completed[2].P50Ms != 50 {
+		t.Errorf("Unexpected samples after advance: %+v", tl.completed)
+	}
+}
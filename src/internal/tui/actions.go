@@ -3,7 +3,9 @@ package tui
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -50,6 +52,7 @@ func (m *Model) executeRequest() tea.Cmd {
 			return errorMsg("No request selected")
 		}
 	}
+	m.executingRequest = request
 
 	// Check if request has dependencies - execute chain if needed
 	if chain.HasDependencies(request) {
@@ -60,7 +63,8 @@ func (m *Model) executeRequest() tea.Cmd {
 	// Clear it if we need to prompt for interactive vars or confirmation
 	m.loading = true
 
-	profile := m.sessionMgr.GetActiveProfile()
+	// A request declaring @profile runs with that profile instead of the active one
+	profile, _ := m.sessionMgr.ProfileForRequest(request)
 
 	// Check for interactive variables that need prompting (only if we haven't collected values yet)
 	if m.interactiveVarValues == nil {
@@ -75,6 +79,12 @@ func (m *Model) executeRequest() tea.Cmd {
 		}
 	}
 
+	// Encrypted variables need the passphrase once per session
+	if m.needsPassphrase() {
+		m.loading = false
+		return m.openPassphrasePrompt("")
+	}
+
 	// Check if request requires confirmation (and hasn't been confirmed yet)
 	if request.RequiresConfirmation && !m.confirmationGiven {
 		// Clear loading flag since we're not executing yet (waiting for confirmation)
@@ -97,6 +107,57 @@ func (m *Model) executeRequest() tea.Cmd {
 	// Update response view to show loading indicator
 	m.updateResponseView()
 
+	// The download target is one-time use, like the body override
+	downloadPath := m.downloadPath
+	m.downloadPath = ""
+
+	execution, err := m.resolveForExecution(request, profile, m.bodyOverride)
+	if err != nil {
+		m.loading = false      // Clear loading flag on error
+		m.updateResponseView() // Update view to remove loading indicator
+		return func() tea.Msg {
+			return errorMsg(fmt.Sprintf("Failed to resolve variables: %v", err))
+		}
+	}
+
+	// Clear body override after using it (one-time use)
+	m.bodyOverride = ""
+
+	resolvedRequest := execution.request
+
+	// A download writes the body to a file, streamed content types included
+	if downloadPath != "" {
+		m.statusMsg = fmt.Sprintf("Downloading %s to %s", resolvedRequest.Name, downloadPath)
+		return m.executeDownload(downloadPath, execution, profile)
+	}
+
+	// Check if this is a streaming request
+	if resolvedRequest.Streaming || resolvedRequest.IsNDJSONStream() {
+		m.statusMsg = fmt.Sprintf("Starting streaming request: %s", resolvedRequest.Name)
+		return m.executeStreamingRequest(execution, profile)
+	}
+
+	// Regular non-streaming execution
+	m.statusMsg = fmt.Sprintf("Executing request: %s", resolvedRequest.Name)
+	return m.executeRegularRequest(execution, profile)
+}
+
+// resolvedExecution is a request resolved for execution with the active profile
+type resolvedExecution struct {
+	request   *types.HttpRequest
+	tlsConfig *types.TLSConfig
+	warnings  []string // Unresolved variables
+	shellErrs []string
+	reresolve func() (*types.HttpRequest, error) // Resolves again against fresh session variables
+
+	// Runs the @before hooks and returns the request to send, resolved with their output
+	// Called from the command sending the request, so slow hooks can be cancelled with ESC
+	runBeforeHooks func(ctx context.Context) (*types.HttpRequest, error)
+}
+
+// resolveForExecution merges the profile headers into request and resolves its variables and TLS settings
+// A non-empty bodyOverride replaces the body, or the query of GraphQL requests
+func (m *Model) resolveForExecution(request *types.HttpRequest, profile *types.Profile, bodyOverride string) (*resolvedExecution, error) {
 	// Create a copy of the request to avoid mutation
 	requestCopy := *request
 	requestCopy.Headers = make(map[string]string)
@@ -108,67 +169,142 @@ func (m *Model) executeRequest() tea.Cmd {
 	for k, v := range request.Headers {
 		requestCopy.Headers[k] = v
 	}
+	// Profile signing applies unless the request has its own @sign.* block
+	if requestCopy.Signing == nil {
+		requestCopy.Signing = profile.Signing
+	}
 
 	// Apply body override if set (ephemeral, one-time)
-	if m.bodyOverride != "" {
-		requestCopy.Body = m.bodyOverride
+	// For GraphQL requests the override replaces the query
+	if bodyOverride != "" {
+		if requestCopy.GraphQL != nil {
+			graphQL := *requestCopy.GraphQL
+			graphQL.Query = bodyOverride
+			requestCopy.GraphQL = &graphQL
+		} else {
+			requestCopy.Body = bodyOverride
+		}
+	}
+
+	// @before hooks run with the request (see runBeforeHooks), their variables stay empty until then
+	var hookVars map[string]string
+	for _, hook := range request.BeforeHooks {
+		if hook.Variable != "" {
+			if hookVars == nil {
+				hookVars = make(map[string]string)
+			}
+			hookVars[hook.Variable] = ""
+		}
+	}
+
+	// Interactive variable values collected win over hook output
+	interactiveVars := m.interactiveVarValues
+	cliVars := func() map[string]string {
+		if len(hookVars) == 0 {
+			return interactiveVars
+		}
+		vars := make(map[string]string, len(hookVars)+len(interactiveVars))
+		for k, v := range hookVars {
+			vars[k] = v
+		}
+		for k, v := range interactiveVars {
+			vars[k] = v
+		}
+		return vars
 	}
 
 	// Resolve variables (load system env vars for {{env.VAR_NAME}} support)
-	// Include any interactive variable values collected
-	cliVars := m.interactiveVarValues
-	resolver := parser.NewVariableResolver(profile.Variables, m.sessionMgr.GetSession().Variables, cliVars, parser.LoadSystemEnv())
-	resolvedRequest, err := resolver.ResolveRequest(&requestCopy)
-	if err != nil {
-		m.loading = false      // Clear loading flag on error
-		m.updateResponseView() // Update view to remove loading indicator
-		return func() tea.Msg {
-			return errorMsg(fmt.Sprintf("Failed to resolve variables: %v", err))
+	// deferred leaves shell commands and {{$function}} placeholders unevaluated
+	resolve := func(deferred bool) (*types.HttpRequest, *parser.VariableResolver, error) {
+		resolver := parser.NewVariableResolver(m.sessionMgr.ProfileVariables(profile), m.sessionMgr.GetSession().Variables, cliVars(), parser.LoadSystemEnv())
+		resolver.SetDeferShell(deferred)
+		resolver.SetDeferDynamic(deferred)
+		resolved, err := resolver.ResolveRequest(&requestCopy)
+		if err != nil {
+			return nil, nil, err
 		}
+		// Add the OAuth bearer token unless the request sets its own Authorization header
+		m.injectOAuthToken(profile, resolved.Headers)
+		return resolved, resolver, nil
 	}
 
-	// Clear body override after using it (one-time use)
-	m.bodyOverride = ""
+	// With @before hooks the request sent is resolved again by runBeforeHooks,
+	// so shell commands and functions only run then, once
+	hasHooks := len(request.BeforeHooks) > 0
+	resolvedRequest, resolver, err := resolve(hasHooks)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get warnings for unresolved variables (short, for status bar)
-	warnings := resolver.GetUnresolvedVariables()
-	shellErrs := resolver.GetShellErrors()
+	execution := &resolvedExecution{
+		request:   resolvedRequest,
+		tlsConfig: executionTLSConfig(profile, resolver, resolvedRequest),
+		warnings:  resolver.GetUnresolvedVariables(),
+		shellErrs: resolver.GetShellErrors(),
+	}
 
-	// Merge TLS config: request-level overrides profile-level
-	// Resolve profile TLS config if present
-	var tlsConfig *types.TLSConfig
-	if profile.TLS != nil {
-		resolvedProfileTLS := &types.TLSConfig{
-			InsecureSkipVerify: profile.TLS.InsecureSkipVerify,
+	// Re-resolve against fresh session variables (e.g. a renewed OAuth token)
+	execution.reresolve = func() (*types.HttpRequest, error) {
+		retryRequest, _, err := resolve(false)
+		return retryRequest, err
+	}
+
+	// Run the @before hooks off the UI goroutine and resolve again with their output
+	execution.runBeforeHooks = func(ctx context.Context) (*types.HttpRequest, error) {
+		if !hasHooks {
+			return resolvedRequest, nil
 		}
-		if profile.TLS.CertFile != "" {
-			certFile, _ := resolver.Resolve(profile.TLS.CertFile)
-			resolvedProfileTLS.CertFile = certFile
+		vars, err := executor.RunBeforeHooks(ctx, request, profile)
+		if ctx.Err() != nil {
+			return nil, context.Canceled
 		}
-		if profile.TLS.KeyFile != "" {
-			keyFile, _ := resolver.Resolve(profile.TLS.KeyFile)
-			resolvedProfileTLS.KeyFile = keyFile
+		if err != nil {
+			return nil, err
 		}
-		if profile.TLS.CAFile != "" {
-			caFile, _ := resolver.Resolve(profile.TLS.CAFile)
-			resolvedProfileTLS.CAFile = caFile
+		hookVars = vars
+		hookedRequest, hookedResolver, err := resolve(false)
+		if err != nil {
+			return nil, err
 		}
-		tlsConfig = resolvedProfileTLS
+		// Replace what the deferred resolution could not know
+		execution.tlsConfig = executionTLSConfig(profile, hookedResolver, hookedRequest)
+		execution.warnings = hookedResolver.GetUnresolvedVariables()
+		execution.shellErrs = hookedResolver.GetShellErrors()
+		return hookedRequest, nil
 	}
+
+	return execution, nil
+}
+
+// executionTLSConfig merges the TLS config: request-level overrides profile-level
+func executionTLSConfig(profile *types.Profile, resolver *parser.VariableResolver, resolvedRequest *types.HttpRequest) *types.TLSConfig {
 	// Request-level TLS overrides profile-level (already resolved in resolvedRequest)
 	if resolvedRequest.TLS != nil {
-		tlsConfig = resolvedRequest.TLS
+		return resolvedRequest.TLS
 	}
-
-	// Check if this is a streaming request
-	if resolvedRequest.Streaming {
-		m.statusMsg = fmt.Sprintf("Starting streaming request: %s", resolvedRequest.Name)
-		return m.executeStreamingRequest(resolvedRequest, tlsConfig, warnings, shellErrs, profile)
+	if profile.TLS == nil {
+		return nil
 	}
 
-	// Regular non-streaming execution
-	m.statusMsg = fmt.Sprintf("Executing request: %s", resolvedRequest.Name)
-	return m.executeRegularRequest(resolvedRequest, tlsConfig, warnings, shellErrs, profile)
+	// Resolve profile TLS config
+	resolvedProfileTLS := &types.TLSConfig{
+		InsecureSkipVerify: profile.TLS.InsecureSkipVerify,
+		PinnedCertSHA256:   profile.TLS.PinnedCertSHA256,
+	}
+	if profile.TLS.CertFile != "" {
+		certFile, _ := resolver.Resolve(profile.TLS.CertFile)
+		resolvedProfileTLS.CertFile = certFile
+	}
+	if profile.TLS.KeyFile != "" {
+		keyFile, _ := resolver.Resolve(profile.TLS.KeyFile)
+		resolvedProfileTLS.KeyFile = keyFile
+	}
+	if profile.TLS.CAFile != "" {
+		caFile, _ := resolver.Resolve(profile.TLS.CAFile)
+		resolvedProfileTLS.CAFile = caFile
+	}
+	return resolvedProfileTLS
 }
 
 // executeWebSocket opens WebSocket modal and loads predefined messages
@@ -275,6 +411,13 @@ func (m *Model) connectWebSocket() tea.Cmd {
 		}
 	}
 
+	responder, err := executor.NewAutoResponder(wsReq.Rules, wsReq.MaxAutoReplies)
+	if err != nil {
+		return func() tea.Msg {
+			return errorMsg(fmt.Sprintf("Invalid WebSocket rules: %v", err))
+		}
+	}
+
 	// Set connecting status
 	m.wsConnectionStatus = "connecting"
 
@@ -312,7 +455,7 @@ func (m *Model) connectWebSocket() tea.Cmd {
 		// Create variable resolver for message resolution
 		session := m.sessionMgr.GetSession()
 		resolver := parser.NewVariableResolver(
-			profile.Variables,
+			m.sessionMgr.ProfileVariables(profile),
 			session.Variables,
 			nil, // No CLI vars for WebSocket
 			parser.LoadSystemEnv(),
@@ -333,9 +476,8 @@ func (m *Model) connectWebSocket() tea.Cmd {
 			}
 		}
 
-		// TODO(#TODO-001): OAuth auto-injection - fetch token if profile.OAuth.Enabled and inject as Authorization header
-		// For now, users can manually add "Authorization: Bearer <token>" in profile or .ws file headers
-		// See TODO.md for details and implementation plan
+		// Add the OAuth bearer token unless the profile or .ws file sets Authorization
+		m.injectOAuthToken(profile, mergedHeaders)
 
 		// Execute PERSISTENT WebSocket connection
 		err := executor.ExecuteWebSocketInteractive(
@@ -344,13 +486,15 @@ func (m *Model) connectWebSocket() tea.Cmd {
 			mergedHeaders,
 			wsReq.Subprotocols,
 			profile.TLS,
+			executor.KeepaliveFor(wsReq, profile),
+			responder,
 			resolver,
 			sendChan,
 			callback,
 		)
 
-		// Send completion message if error
-		if err != nil {
+		// Send completion message if error (a keepalive timeout was already reported as a lost connection)
+		if err != nil && !errors.Is(err, executor.ErrKeepaliveTimeout) {
 			msgChan <- types.ReceivedMessage{
 				Type:      "system",
 				Content:   fmt.Sprintf("Error: %v", err),
@@ -410,104 +554,35 @@ func (m *Model) exportWebSocketMessages() tea.Cmd {
 }
 
 // executeRegularRequest executes a standard (non-streaming) HTTP request with cancellation support
-func (m *Model) executeRegularRequest(resolvedRequest *types.HttpRequest, tlsConfig *types.TLSConfig, warnings, shellErrs []string, profile *types.Profile) tea.Cmd {
+// The @before hooks run first, in the command, so ESC cancels them too
+func (m *Model) executeRegularRequest(execution *resolvedExecution, profile *types.Profile) tea.Cmd {
 	// Create a cancellable context
 	ctx, cancel := context.WithCancel(context.Background())
 	m.requestState.SetCancel(cancel)
 
+	// Resolve the cookie jar before leaving the UI goroutine
+	jar := m.cookieJarForProfile(profile)
+
 	return func() tea.Msg {
-		// Create a channel for the result
-		type result struct {
-			data *types.RequestResult
-			err  error
+		resolvedRequest, err := execution.runBeforeHooks(ctx)
+		if errors.Is(err, context.Canceled) {
+			return errorMsg("Request cancelled by user")
 		}
-		resultChan := make(chan result, 1)
-
-		// Execute request in goroutine
-		go func() {
-			res, err := executor.ExecuteWithContext(ctx, resolvedRequest, tlsConfig, profile)
-			resultChan <- result{data: res, err: err}
-		}()
+		if err != nil {
+			return errorMsg(err.Error())
+		}
+		// Read after the hooks, which resolve the request again
+		tlsConfig, warnings, shellErrs := execution.tlsConfig, execution.warnings, execution.shellErrs
 
-		// Wait for either result or cancellation
-		select {
-		case <-ctx.Done():
-			// Request was cancelled
+		result, sentRequest, oauthNotice, err := m.sendRequest(ctx, resolvedRequest, tlsConfig, profile, jar, execution.reresolve)
+		resolvedRequest = sentRequest
+		if errors.Is(err, context.Canceled) {
 			return errorMsg("Request cancelled by user")
-		case res := <-resultChan:
-			// Request completed
-			if res.err != nil {
-				// Track network errors in analytics
-				shouldSaveAnalytics := false
-				if profile != nil && profile.AnalyticsEnabled != nil {
-					shouldSaveAnalytics = *profile.AnalyticsEnabled
-				}
-				if shouldSaveAnalytics && m.analyticsManager != nil {
-					currentFile := m.fileExplorer.GetCurrentFile()
-					if currentFile != nil {
-						filePath := currentFile.Path
-						normalizedPath := normalizePath(resolvedRequest.URL)
-
-						entry := analytics.Entry{
-							FilePath:       filePath,
-							NormalizedPath: normalizedPath,
-							Method:         resolvedRequest.Method,
-							StatusCode:     0, // 0 indicates network error (no HTTP response)
-							RequestSize:    int64(len(resolvedRequest.Body)),
-							ResponseSize:   0,
-							DurationMs:     0,
-							ErrorMessage:   res.err.Error(),
-							Timestamp:      time.Now(),
-							ProfileName:    profile.Name,
-						}
-
-						_ = m.analyticsManager.Save(entry)
-					}
-				}
-
-				return errorMsg(categorizeError(res.err))
-			}
-
-			result := res.data
-
-			// Apply filter and query
-			filterExpr := resolvedRequest.Filter
-			if filterExpr == "" {
-				filterExpr = profile.DefaultFilter
-			}
-			queryExpr := resolvedRequest.Query
-			if queryExpr == "" {
-				queryExpr = profile.DefaultQuery
-			}
-
-			if filterExpr != "" || queryExpr != "" {
-				filteredBody, err := filter.Apply(result.Body, filterExpr, queryExpr)
-				if err != nil {
-					_ = err
-				} else {
-					result.Body = filteredBody
-				}
-			}
-
-			// Parse escape sequences
-			if resolvedRequest.ParseEscapes {
-				result.Body = executor.ParseEscapeSequences(result.Body)
-			}
-
-			// Save to history
-			shouldSaveHistory := m.sessionMgr.IsHistoryEnabled()
-			if profile != nil && profile.HistoryEnabled != nil {
-				shouldSaveHistory = *profile.HistoryEnabled
-			}
-			if shouldSaveHistory && m.historyManager != nil {
-				currentFile := m.fileExplorer.GetCurrentFile()
-				if currentFile != nil {
-					filePath := currentFile.Path
-					_ = m.historyManager.Save(filePath, profile.Name, resolvedRequest, result)
-				}
-			}
+		}
 
-			// Save to analytics
+		// Request completed
+		if err != nil {
+			// Track network errors in analytics
 			shouldSaveAnalytics := false
 			if profile != nil && profile.AnalyticsEnabled != nil {
 				shouldSaveAnalytics = *profile.AnalyticsEnabled
@@ -522,42 +597,176 @@ func (m *Model) executeRegularRequest(resolvedRequest *types.HttpRequest, tlsCon
 						FilePath:       filePath,
 						NormalizedPath: normalizedPath,
 						Method:         resolvedRequest.Method,
-						StatusCode:     result.Status,
+						StatusCode:     0, // 0 indicates network error (no HTTP response)
 						RequestSize:    int64(len(resolvedRequest.Body)),
-						ResponseSize:   int64(len(result.Body)),
-						DurationMs:     result.Duration,
+						ResponseSize:   0,
+						DurationMs:     0,
+						ErrorMessage:   err.Error(),
 						Timestamp:      time.Now(),
 						ProfileName:    profile.Name,
 					}
 
-					_ = m.analyticsManager.Save(entry) // Ignore errors to not interrupt the flow
+					_ = m.analyticsManager.Save(entry)
 				}
 			}
 
-			// Auto-extract tokens
-			if result.Status >= 200 && result.Status < 300 {
-				if token, err := parser.ExtractJSONToken(result.Body, "access_token"); err == nil {
-					m.sessionMgr.SetSessionVariable("token", token)
-				}
-				if token, err := parser.ExtractJSONToken(result.Body, "token"); err == nil {
-					m.sessionMgr.SetSessionVariable("token", token)
+			return errorMsg(categorizeError(err))
+		}
+
+		rawResult := *result // @after hooks get the body before filter and query
+
+		// Apply filter and query
+		filterExpr := resolvedRequest.Filter
+		if filterExpr == "" {
+			filterExpr = profile.DefaultFilter
+		}
+		queryExpr := resolvedRequest.Query
+		if queryExpr == "" {
+			queryExpr = profile.DefaultQuery
+		}
+
+		if filterExpr != "" || queryExpr != "" {
+			filteredBody, err := filter.Apply(result.Body, filterExpr, queryExpr)
+			if err != nil {
+				_ = err
+			} else {
+				result.Body = filteredBody
+			}
+		}
+
+		// Parse escape sequences
+		if resolvedRequest.ParseEscapes {
+			result.Body = executor.ParseEscapeSequences(result.Body)
+		}
+
+		// A failing @after hook is reported with the shell errors, the response is still shown
+		if err := executor.RunAfterHooks(ctx, resolvedRequest, profile, &rawResult); err != nil {
+			shellErrs = append(shellErrs, err.Error())
+		}
+
+		// Save to history
+		shouldSaveHistory := m.sessionMgr.IsHistoryEnabled()
+		if profile != nil && profile.HistoryEnabled != nil {
+			shouldSaveHistory = *profile.HistoryEnabled
+		}
+		if shouldSaveHistory && m.historyManager != nil {
+			currentFile := m.fileExplorer.GetCurrentFile()
+			if currentFile != nil {
+				filePath := currentFile.Path
+				_ = m.historyManager.Save(filePath, profile.Name, resolvedRequest, result)
+			}
+		}
+
+		// Save to analytics
+		shouldSaveAnalytics := false
+		if profile != nil && profile.AnalyticsEnabled != nil {
+			shouldSaveAnalytics = *profile.AnalyticsEnabled
+		}
+		if shouldSaveAnalytics && m.analyticsManager != nil {
+			currentFile := m.fileExplorer.GetCurrentFile()
+			if currentFile != nil {
+				filePath := currentFile.Path
+				normalizedPath := normalizePath(resolvedRequest.URL)
+
+				entry := analytics.Entry{
+					FilePath:       filePath,
+					NormalizedPath: normalizedPath,
+					Method:         resolvedRequest.Method,
+					StatusCode:     result.Status,
+					RequestSize:    int64(result.RequestSize),
+					ResponseSize:   int64(len(result.Body)),
+					DurationMs:     result.Duration,
+					TTFBMs:         result.TTFBMs(),
+					BudgetMs:       result.MaxDurationMs,
+					BudgetMet:      result.MaxDurationMs > 0 && !result.OverBudget(),
+					Timestamp:      time.Now(),
+					ProfileName:    profile.Name,
 				}
+
+				_ = m.analyticsManager.Save(entry) // Ignore errors to not interrupt the flow
 			}
+		}
+
+		// Auto-extract tokens
+		if result.Status >= 200 && result.Status < 300 {
+			if token, err := parser.ExtractJSONToken(result.Body, "access_token"); err == nil {
+				m.sessionMgr.SetSessionVariable("token", token)
+			}
+			if token, err := parser.ExtractJSONToken(result.Body, "token"); err == nil {
+				m.sessionMgr.SetSessionVariable("token", token)
+			}
+		}
+
+		return requestExecutedMsg{result: result, warnings: warnings, shellErrors: shellErrs, oauthNotice: oauthNotice}
+	}
+}
 
-			return requestExecutedMsg{result: result, warnings: warnings, shellErrors: shellErrs}
+// sendRequest executes a resolved request, renewing the OAuth token before it expires or on 401
+// Returns the request as sent (re-resolved after a renewal) and the OAuth notice for the status bar
+// The error is context.Canceled when ctx is cancelled before the response arrives
+func (m *Model) sendRequest(ctx context.Context, resolvedRequest *types.HttpRequest, tlsConfig *types.TLSConfig, profile *types.Profile, jar http.CookieJar, reresolve func() (*types.HttpRequest, error)) (*types.RequestResult, *types.HttpRequest, string, error) {
+	// Create a channel for the result
+	type result struct {
+		data *types.RequestResult
+		err  error
+	}
+	resultChan := make(chan result, 1)
+
+	// Refresh the OAuth token before it expires instead of waiting for a 401
+	oauthNotice := ""
+	if m.oauthTokenNeedsRefresh(profile) {
+		notice, err := m.renewOAuthToken(profile)
+		if err != nil {
+			oauthNotice = err.Error()
+		} else if freshRequest, err := reresolve(); err != nil {
+			oauthNotice = fmt.Sprintf("%s, request not updated: %v", notice, err)
+		} else {
+			resolvedRequest = freshRequest
+			oauthNotice = notice
 		}
 	}
+
+	// Execute request in goroutine
+	go func() {
+		res, err := executor.ExecuteWithContext(ctx, resolvedRequest, tlsConfig, profile, jar, nil)
+		resultChan <- result{data: res, err: err}
+	}()
+
+	// Wait for either result or cancellation
+	select {
+	case <-ctx.Done():
+		// Request was cancelled
+		return nil, resolvedRequest, oauthNotice, context.Canceled
+	case res := <-resultChan:
+		// Renew the OAuth token on 401 and retry once
+		if res.err == nil && shouldRenewOAuthToken(profile, res.data.Status) {
+			notice, err := m.renewOAuthToken(profile)
+			if err != nil {
+				oauthNotice = err.Error()
+			} else if retryRequest, err := reresolve(); err != nil {
+				oauthNotice = fmt.Sprintf("%s, retry skipped: %v", notice, err)
+			} else {
+				resolvedRequest = retryRequest
+				retryRes, retryErr := executor.ExecuteWithContext(ctx, resolvedRequest, tlsConfig, profile, jar, nil)
+				res = result{data: retryRes, err: retryErr}
+				oauthNotice = notice + ", request retried"
+			}
+		}
+		return res.data, resolvedRequest, oauthNotice, res.err
+	}
 }
 
 // executeStreamingRequest starts a streaming request in a goroutine with real-time updates
-func (m *Model) executeStreamingRequest(resolvedRequest *types.HttpRequest, tlsConfig *types.TLSConfig, warnings, shellErrs []string, profile *types.Profile) tea.Cmd {
+func (m *Model) executeStreamingRequest(execution *resolvedExecution, profile *types.Profile) tea.Cmd {
 	// Create a channel for streaming chunks
 	m.streamChannel = make(chan streamChunkMsg, StreamMessageBuffer)
 	m.streamedBody = ""
+	m.streamNDJSON = execution.request.IsNDJSONStream()
 
 	// Create a cancellable context for the request
 	ctx, cancel := context.WithCancel(context.Background())
 	m.streamState.Start(cancel)
+	jar := m.cookieJarForProfile(profile)
 
 	// Start the request in a goroutine
 	go func() {
@@ -565,8 +774,14 @@ func (m *Model) executeStreamingRequest(resolvedRequest *types.HttpRequest, tlsC
 		defer cancel()
 		defer close(chunkChan)
 
+		resolvedRequest, err := execution.runBeforeHooks(ctx)
+		if err != nil {
+			chunkChan <- streamChunkMsg{chunk: []byte(fmt.Sprintf("Error: %v", err)), done: true}
+			return
+		}
+
 		// Execute with streaming callback - sends chunks as they arrive
-		_, err := executor.ExecuteWithStreaming(ctx, resolvedRequest, tlsConfig, profile, func(chunk []byte, done bool) {
+		_, err = executor.ExecuteWithStreaming(ctx, resolvedRequest, execution.tlsConfig, profile, jar, nil, func(chunk []byte, done bool) {
 			chunkChan <- streamChunkMsg{chunk: chunk, done: done}
 		})
 
@@ -652,8 +867,19 @@ func (m *Model) executeChain() tea.Cmd {
 	ctx, cancel := context.WithCancel(context.Background())
 	m.requestState.SetCancel(cancel)
 
+	// Share one cookie jar per profile across all chain steps (e.g. login then authenticated call)
+	// Jars are looked up here since steps declaring @profile may use another profile's jar
+	jars := make(map[string]http.CookieJar)
+	for _, node := range graph.Nodes {
+		stepProfile, _ := m.sessionMgr.ProfileForRequest(node.Request)
+		jars[stepProfile.Name] = m.cookieJarForProfile(stepProfile)
+	}
+
 	// Execute chain asynchronously
 	return func() tea.Msg {
+		var skipped []string
+		lastStatus := 0
+
 		// Execute each request in order
 		for i, filePath := range executionOrder {
 			// Check for cancellation before each request
@@ -684,95 +910,60 @@ func (m *Model) executeChain() tea.Cmd {
 
 			req := &requests[0]
 
-			// Resolve variables
-			resolver := parser.NewVariableResolver(profile.Variables, m.sessionMgr.GetSession().Variables, nil, parser.LoadSystemEnv())
-			resolvedRequest, err := resolver.ResolveRequest(req)
-			if err != nil {
-				return chainCompleteMsg{
-					success: false,
-					message: fmt.Sprintf("Failed to resolve variables in %s: %v", filepath.Base(filePath), err),
-				}
-			}
-
-			// Merge TLS config
-			var tlsConfig *types.TLSConfig
-			if profile.TLS != nil {
-				resolvedProfileTLS := &types.TLSConfig{
-					InsecureSkipVerify: profile.TLS.InsecureSkipVerify,
-				}
-				if profile.TLS.CertFile != "" {
-					certFile, _ := resolver.Resolve(profile.TLS.CertFile)
-					resolvedProfileTLS.CertFile = certFile
-				}
-				if profile.TLS.KeyFile != "" {
-					keyFile, _ := resolver.Resolve(profile.TLS.KeyFile)
-					resolvedProfileTLS.KeyFile = keyFile
-				}
-				if profile.TLS.CAFile != "" {
-					caFile, _ := resolver.Resolve(profile.TLS.CAFile)
-					resolvedProfileTLS.CAFile = caFile
-				}
-				tlsConfig = resolvedProfileTLS
-			}
-			if resolvedRequest.TLS != nil {
-				tlsConfig = resolvedRequest.TLS
-			}
+			// A step declaring @profile runs with that profile instead of the active one
+			stepProfile, _ := m.sessionMgr.ProfileForRequest(req)
+			jar := jars[stepProfile.Name]
 
-			// Execute request with cancellation support
-			result, err := executor.ExecuteWithContext(ctx, resolvedRequest, tlsConfig, profile)
-			if err != nil {
-				return chainCompleteMsg{
-					success: false,
-					message: fmt.Sprintf("Request %d/%d (%s) failed: %s", i+1, len(executionOrder), filepath.Base(filePath), categorizeError(err)),
+			// Refresh the OAuth token before it expires (long chains can outlive it)
+			if m.oauthTokenNeedsRefresh(stepProfile) {
+				if _, err := m.renewOAuthToken(stepProfile); err != nil {
+					return chainCompleteMsg{
+						success: false,
+						message: fmt.Sprintf("Request %d/%d (%s): %v", i+1, len(executionOrder), filepath.Base(filePath), err),
+					}
 				}
 			}
 
-			// Save to history
-			shouldSaveHistory := m.sessionMgr.IsHistoryEnabled()
-			if profile != nil && profile.HistoryEnabled != nil {
-				shouldSaveHistory = *profile.HistoryEnabled
-			}
-			if shouldSaveHistory && m.historyManager != nil {
-				_ = m.historyManager.Save(filePath, profile.Name, resolvedRequest, result)
-			}
-
-			// Track analytics if enabled
-			if profile.AnalyticsEnabled != nil && *profile.AnalyticsEnabled && m.analyticsManager != nil {
-				entry := analytics.Entry{
-					FilePath:       filePath,
-					NormalizedPath: resolvedRequest.URL,
-					Method:         resolvedRequest.Method,
-					StatusCode:     result.Status,
-					RequestSize:    int64(result.RequestSize),
-					ResponseSize:   int64(result.ResponseSize),
-					DurationMs:     result.Duration,
-					Timestamp:      time.Now(),
-					ProfileName:    profile.Name,
-				}
-				_ = m.analyticsManager.Save(entry)
-			}
+			// Resolve variables
+			resolver := parser.NewVariableResolver(m.sessionMgr.ProfileVariables(stepProfile), m.sessionMgr.GetSession().Variables, nil, parser.LoadSystemEnv())
 
-			// Extract variables if specified
-			if chain.HasExtractions(req) {
-				extracted, err := chain.ExtractVariables(req, result.Body)
+			// Skip (not fail) the step when its condition is false
+			if req.Condition != "" {
+				run, err := chain.EvaluateCondition(req.Condition, chainConditionLookup(resolver, lastStatus))
 				if err != nil {
 					return chainCompleteMsg{
 						success: false,
-						message: fmt.Sprintf("Failed to extract variables from %s: %v", filepath.Base(filePath), err),
+						message: fmt.Sprintf("Invalid condition in %s: %v", filepath.Base(filePath), err),
 					}
 				}
+				if !run {
+					skipped = append(skipped, filepath.Base(filePath))
+					continue
+				}
+			}
+
+			stepLabel := fmt.Sprintf("Request %d/%d (%s)", i+1, len(executionOrder), filepath.Base(filePath))
 
-				// Store extracted variables in session
-				for varName, varValue := range extracted {
-					m.sessionMgr.SetSessionVariable(varName, varValue)
+			// @forEach runs the step once per element of an extracted array
+			var result *types.RequestResult
+			if req.ForEach != "" {
+				result, err = m.executeForEachStep(ctx, filePath, req, resolver, stepProfile, jar, stepLabel)
+			} else {
+				result, err = m.executeChainStep(ctx, filePath, req, resolver, stepProfile, jar, stepLabel)
+			}
+			if err != nil {
+				return chainCompleteMsg{
+					success: false,
+					message: err.Error(),
 				}
 			}
 
+			lastStatus = result.Status
 			// If this is the last request, save the result
 			if i == len(executionOrder)-1 {
 				return chainCompleteMsg{
 					success:  true,
-					message:  fmt.Sprintf("Chain completed: %d requests executed", len(executionOrder)),
+					message:  chainSummary(len(executionOrder), skipped),
 					response: result,
 				}
 			}
@@ -780,11 +971,162 @@ func (m *Model) executeChain() tea.Cmd {
 
 		return chainCompleteMsg{
 			success: true,
-			message: fmt.Sprintf("Chain completed: %d requests executed", len(executionOrder)),
+			message: chainSummary(len(executionOrder), skipped),
 		}
 	}
 }
 
+// executeChainStep resolves and executes one chain request, then records history,
+// analytics and extracted variables
+func (m *Model) executeChainStep(ctx context.Context, filePath string, req *types.HttpRequest, resolver *parser.VariableResolver, profile *types.Profile, jar http.CookieJar, stepLabel string) (*types.RequestResult, error) {
+	if req.Signing == nil && profile.Signing != nil {
+		signed := *req
+		signed.Signing = profile.Signing
+		req = &signed
+	}
+	resolvedRequest, err := resolver.ResolveRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to resolve variables in %s: %v", filepath.Base(filePath), err)
+	}
+	m.injectOAuthToken(profile, resolvedRequest.Headers)
+
+	// Merge TLS config
+	var tlsConfig *types.TLSConfig
+	if profile.TLS != nil {
+		resolvedProfileTLS := &types.TLSConfig{
+			InsecureSkipVerify: profile.TLS.InsecureSkipVerify,
+			PinnedCertSHA256:   profile.TLS.PinnedCertSHA256,
+		}
+		if profile.TLS.CertFile != "" {
+			certFile, _ := resolver.Resolve(profile.TLS.CertFile)
+			resolvedProfileTLS.CertFile = certFile
+		}
+		if profile.TLS.KeyFile != "" {
+			keyFile, _ := resolver.Resolve(profile.TLS.KeyFile)
+			resolvedProfileTLS.KeyFile = keyFile
+		}
+		if profile.TLS.CAFile != "" {
+			caFile, _ := resolver.Resolve(profile.TLS.CAFile)
+			resolvedProfileTLS.CAFile = caFile
+		}
+		tlsConfig = resolvedProfileTLS
+	}
+	if resolvedRequest.TLS != nil {
+		tlsConfig = resolvedRequest.TLS
+	}
+
+	// Execute request with cancellation support
+	result, err := executor.ExecuteWithContext(ctx, resolvedRequest, tlsConfig, profile, jar, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %s", stepLabel, categorizeError(err))
+	}
+
+	// Save to history
+	shouldSaveHistory := m.sessionMgr.IsHistoryEnabled()
+	if profile != nil && profile.HistoryEnabled != nil {
+		shouldSaveHistory = *profile.HistoryEnabled
+	}
+	if shouldSaveHistory && m.historyManager != nil {
+		_ = m.historyManager.Save(filePath, profile.Name, resolvedRequest, result)
+	}
+
+	// Track analytics if enabled
+	if profile.AnalyticsEnabled != nil && *profile.AnalyticsEnabled && m.analyticsManager != nil {
+		entry := analytics.Entry{
+			FilePath:       filePath,
+			NormalizedPath: resolvedRequest.URL,
+			Method:         resolvedRequest.Method,
+			StatusCode:     result.Status,
+			RequestSize:    int64(result.RequestSize),
+			ResponseSize:   int64(result.ResponseSize),
+			DurationMs:     result.Duration,
+			TTFBMs:         result.TTFBMs(),
+			BudgetMs:       result.MaxDurationMs,
+			BudgetMet:      result.MaxDurationMs > 0 && !result.OverBudget(),
+			Timestamp:      time.Now(),
+			ProfileName:    profile.Name,
+		}
+		_ = m.analyticsManager.Save(entry)
+	}
+
+	// Extract variables if specified
+	if chain.HasExtractions(req) {
+		extracted, err := chain.ExtractVariables(req, result.Body)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to extract variables from %s: %v", filepath.Base(filePath), err)
+		}
+
+		// Store extracted variables in session
+		for varName, varValue := range extracted {
+			m.sessionMgr.SetSessionVariable(varName, varValue)
+		}
+	}
+
+	return result, nil
+}
+
+// executeForEachStep runs a chain step once per element of the array referenced by @forEach
+// The element is bound to {{item}} and its position to {{index}}; iterations are capped by
+// the profile's maxForEachIterations and stop when the chain is cancelled
+func (m *Model) executeForEachStep(ctx context.Context, filePath string, req *types.HttpRequest, resolver *parser.VariableResolver, profile *types.Profile, jar http.CookieJar, stepLabel string) (*types.RequestResult, error) {
+	varName := chain.ForEachVariable(req.ForEach)
+	value, ok := resolver.Lookup(varName)
+	if !ok {
+		return nil, fmt.Errorf("%s: forEach variable %s is not defined", stepLabel, varName)
+	}
+	items, err := chain.ForEachItems(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: forEach %s: %v", stepLabel, varName, err)
+	}
+
+	total := len(items)
+	if limit := profile.GetMaxForEachIterations(); len(items) > limit {
+		items = items[:limit]
+	}
+
+	iterations := make([]chain.ForEachIteration, 0, len(items))
+	for n, item := range items {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("Chain cancelled during %s after %d/%d items", stepLabel, n, len(items))
+		default:
+		}
+
+		// Loop variables have the highest priority (same level as CLI -e vars)
+		loopVars := map[string]string{"item": item, "index": strconv.Itoa(n)}
+		itemResolver := parser.NewVariableResolver(m.sessionMgr.ProfileVariables(profile), m.sessionMgr.GetSession().Variables, loopVars, parser.LoadSystemEnv())
+		result, err := m.executeChainStep(ctx, filePath, req, itemResolver, profile, jar, fmt.Sprintf("%s item %d/%d", stepLabel, n+1, len(items)))
+		if err != nil {
+			return nil, err
+		}
+		iterations = append(iterations, chain.ForEachIteration{Item: item, Result: result})
+	}
+
+	return chain.AggregateForEach(iterations, total), nil
+}
+
+// chainConditionLookup resolves variables for chain step conditions
+// {{status}} falls back to the HTTP status of the previous executed step
+func chainConditionLookup(resolver *parser.VariableResolver, lastStatus int) chain.LookupFunc {
+	return func(name string) (string, bool) {
+		if value, ok := resolver.Lookup(name); ok {
+			return value, true
+		}
+		if name == "status" && lastStatus != 0 {
+			return strconv.Itoa(lastStatus), true
+		}
+		return "", false
+	}
+}
+
+// chainSummary formats the chain completion message, listing skipped steps
+func chainSummary(total int, skipped []string) string {
+	if len(skipped) == 0 {
+		return fmt.Sprintf("Chain completed: %d requests executed", total)
+	}
+	return fmt.Sprintf("Chain completed: %d requests executed, %d skipped (%s)", total-len(skipped), len(skipped), strings.Join(skipped, ", "))
+}
+
 // openInEditor opens the current file in external editor
 func (m *Model) openInEditor() tea.Cmd {
 	currentFile := m.fileExplorer.GetCurrentFile()
@@ -899,77 +1241,7 @@ func (m *Model) saveResponse() tea.Cmd {
 			filename = fmt.Sprintf("%s_response_%s.json", baseName, timestamp)
 		}
 
-		// Create full response object with metadata
-		// Use filtered response if active, otherwise use original
-		var bodySource string
-		if m.filterActive && m.filteredResponse != "" {
-			bodySource = m.filteredResponse
-		} else {
-			bodySource = m.currentResponse.Body
-		}
-
-		// Try to parse body as JSON to avoid double-stringification
-		var bodyData interface{}
-		if err := json.Unmarshal([]byte(bodySource), &bodyData); err != nil {
-			// Body is not JSON (HTML, CBOR, etc) - keep as string
-			bodyData = bodySource
-		}
-		// else: Body is valid JSON - keep as parsed object
-
-		// Prepare request details with resolved variables
-		requestDetails := map[string]interface{}{}
-		if m.currentRequest != nil {
-			profile := m.sessionMgr.GetActiveProfile()
-			session := m.sessionMgr.GetSession()
-
-			// Create a copy of the request and merge headers
-			requestCopy := *m.currentRequest
-			requestCopy.Headers = make(map[string]string)
-			for k, v := range profile.Headers {
-				requestCopy.Headers[k] = v
-			}
-			for k, v := range m.currentRequest.Headers {
-				requestCopy.Headers[k] = v
-			}
-
-			// Resolve variables
-			resolver := parser.NewVariableResolver(profile.Variables, session.Variables, nil, parser.LoadSystemEnv())
-			resolvedRequest, err := resolver.ResolveRequest(&requestCopy)
-			if err == nil && resolvedRequest != nil {
-				// Use resolved values
-				requestDetails["method"] = resolvedRequest.Method
-				requestDetails["url"] = resolvedRequest.URL
-				requestDetails["headers"] = resolvedRequest.Headers
-				requestDetails["body"] = resolvedRequest.Body
-			} else {
-				// Fallback to unresolved values if resolution fails
-				requestDetails["method"] = requestCopy.Method
-				requestDetails["url"] = requestCopy.URL
-				requestDetails["headers"] = requestCopy.Headers
-				requestDetails["body"] = requestCopy.Body
-			}
-			// Note: profileVariables and sessionVariables removed - internal configs only
-		}
-
-		fullResponse := map[string]interface{}{
-			"request": requestDetails,
-			"response": map[string]interface{}{
-				"status":     m.currentResponse.Status,
-				"statusText": m.currentResponse.StatusText,
-				"headers":    m.currentResponse.Headers,
-				"body":       bodyData, // Already parsed if JSON, string if not
-			},
-			"duration":     m.currentResponse.Duration,
-			"requestSize":  m.currentResponse.RequestSize,
-			"responseSize": m.currentResponse.ResponseSize,
-		}
-
-		// Add filter note if a filter was applied
-		if m.filterActive && m.filteredResponse != "" {
-			fullResponse["filter"] = m.filterInput
-		}
-
-		data, err := json.MarshalIndent(fullResponse, "", "  ")
+		data, err := m.responseDocument()
 		if err != nil {
 			return errorMsg(fmt.Sprintf("Failed to marshal response: %v", err))
 		}
@@ -985,6 +1257,81 @@ func (m *Model) saveResponse() tea.Cmd {
 	}
 }
 
+// responseDocument builds the JSON document of the current request and response, as saved by saveResponse
+func (m *Model) responseDocument() ([]byte, error) {
+	// Create full response object with metadata
+	// Use filtered response if active, otherwise use original
+	var bodySource string
+	if m.filterActive && m.filteredResponse != "" {
+		bodySource = m.filteredResponse
+	} else {
+		bodySource = m.currentResponse.Body
+	}
+
+	// Try to parse body as JSON to avoid double-stringification
+	var bodyData interface{}
+	if err := json.Unmarshal([]byte(bodySource), &bodyData); err != nil {
+		// Body is not JSON (HTML, CBOR, etc) - keep as string
+		bodyData = bodySource
+	}
+	// else: Body is valid JSON - keep as parsed object
+
+	// Prepare request details with resolved variables
+	requestDetails := map[string]interface{}{}
+	if m.currentRequest != nil {
+		profile := m.sessionMgr.GetActiveProfile()
+		session := m.sessionMgr.GetSession()
+
+		// Create a copy of the request and merge headers
+		requestCopy := *m.currentRequest
+		requestCopy.Headers = make(map[string]string)
+		for k, v := range profile.Headers {
+			requestCopy.Headers[k] = v
+		}
+		for k, v := range m.currentRequest.Headers {
+			requestCopy.Headers[k] = v
+		}
+
+		// Resolve variables
+		resolver := parser.NewVariableResolver(m.sessionMgr.ProfileVariables(profile), session.Variables, nil, parser.LoadSystemEnv())
+		resolvedRequest, err := resolver.ResolveRequest(&requestCopy)
+		if err == nil && resolvedRequest != nil {
+			// Use resolved values
+			requestDetails["method"] = resolvedRequest.Method
+			requestDetails["url"] = resolvedRequest.URL
+			requestDetails["headers"] = resolvedRequest.Headers
+			requestDetails["body"] = resolvedRequest.Body
+		} else {
+			// Fallback to unresolved values if resolution fails
+			requestDetails["method"] = requestCopy.Method
+			requestDetails["url"] = requestCopy.URL
+			requestDetails["headers"] = requestCopy.Headers
+			requestDetails["body"] = requestCopy.Body
+		}
+		// Note: profileVariables and sessionVariables removed - internal configs only
+	}
+
+	fullResponse := map[string]interface{}{
+		"request": requestDetails,
+		"response": map[string]interface{}{
+			"status":     m.currentResponse.Status,
+			"statusText": m.currentResponse.StatusText,
+			"headers":    m.currentResponse.Headers,
+			"body":       bodyData, // Already parsed if JSON, string if not
+		},
+		"duration":     m.currentResponse.Duration,
+		"requestSize":  m.currentResponse.RequestSize,
+		"responseSize": m.currentResponse.ResponseSize,
+	}
+
+	// Add filter note if a filter was applied
+	if m.filterActive && m.filteredResponse != "" {
+		fullResponse["filter"] = m.filterInput
+	}
+
+	return json.MarshalIndent(fullResponse, "", "  ")
+}
+
 // copyToClipboard copies the FULL response body or error to clipboard
 func (m *Model) copyToClipboard() tea.Cmd {
 	return func() tea.Msg {
@@ -1023,6 +1370,74 @@ func (m *Model) copyToClipboard() tea.Cmd {
 	}
 }
 
+// copyAsCurl copies the current request, with variables resolved, as a curl command
+func (m *Model) copyAsCurl() tea.Cmd {
+	resolvedRequest, tlsConfig, unresolved, err := m.exportableRequest("cURL")
+	if err != nil {
+		return m.setErrorMessage(err.Error())
+	}
+
+	command := executor.ToCurl(resolvedRequest, tlsConfig)
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(command); err != nil {
+			return errorMsg(fmt.Sprintf("Failed to copy to clipboard: %v", err))
+		}
+		m.errorMsg = ""
+		if len(unresolved) > 0 {
+			return m.setStatusMessage(fmt.Sprintf("Request copied as cURL (unresolved: %s)", strings.Join(unresolved, ", ")))
+		}
+		return m.setStatusMessage("Request copied as cURL")
+	}
+}
+
+// exportableRequest resolves the current request as it would be executed, for the command exports
+// Returns the resolved request, the merged TLS config and the variables left unresolved
+func (m *Model) exportableRequest(format string) (*types.HttpRequest, *types.TLSConfig, []string, error) {
+	request := m.currentRequest
+	if request == nil {
+		return nil, nil, nil, fmt.Errorf("No request selected")
+	}
+	if request.IsGRPC() {
+		return nil, nil, nil, fmt.Errorf("gRPC requests cannot be exported as %s", format)
+	}
+
+	profile := m.sessionMgr.GetActiveProfile()
+
+	// Merge profile and request headers into a copy (same as executeRequest)
+	requestCopy := *request
+	requestCopy.Headers = make(map[string]string)
+	for k, v := range profile.Headers {
+		requestCopy.Headers[k] = v
+	}
+	for k, v := range request.Headers {
+		requestCopy.Headers[k] = v
+	}
+	if m.bodyOverride != "" && requestCopy.GraphQL == nil {
+		requestCopy.Body = m.bodyOverride
+	}
+
+	resolver := parser.NewVariableResolver(m.sessionMgr.ProfileVariables(profile), m.sessionMgr.GetSession().Variables, m.interactiveVarValues, parser.LoadSystemEnv())
+	resolvedRequest, err := resolver.ResolveRequest(&requestCopy)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Failed to resolve variables: %v", err)
+	}
+	m.injectOAuthToken(profile, resolvedRequest.Headers)
+
+	// Merge TLS config: request-level overrides profile-level
+	var tlsConfig *types.TLSConfig
+	if profile.TLS != nil {
+		tlsConfig = &types.TLSConfig{InsecureSkipVerify: profile.TLS.InsecureSkipVerify}
+		tlsConfig.CertFile, _ = resolver.Resolve(profile.TLS.CertFile)
+		tlsConfig.KeyFile, _ = resolver.Resolve(profile.TLS.KeyFile)
+		tlsConfig.CAFile, _ = resolver.Resolve(profile.TLS.CAFile)
+	}
+	if resolvedRequest.TLS != nil {
+		tlsConfig = resolvedRequest.TLS
+	}
+
+	return resolvedRequest, tlsConfig, resolver.GetUnresolvedVariables(), nil
+}
+
 // performSearch performs context-aware search (files or response based on focus)
 func (m *Model) performSearch() {
 	// Use searchInput from ModeSearch (not the currently active search)
@@ -1066,6 +1481,14 @@ func (m *Model) searchInFiles() {
 
 	// Use searchInput (from ModeSearch) for new searches
 	query := m.searchInput
+
+	// Hex digits search the bytes of the hex view
+	if m.hexView {
+		if pattern, ok := parseBytePattern(query); ok {
+			m.searchHexBytes(pattern)
+			return
+		}
+	}
 	pageSize := m.getFileListHeight()
 
 	matchCount, errMsg := m.fileExplorer.Search(query, pageSize)
@@ -1108,6 +1531,12 @@ func (m *Model) searchInResponse() {
 	// Use searchInput (from ModeSearch) for new searches
 	query := m.searchInput
 
+	// The hex view searches for hex digits as bytes
+	if pattern, ok := parseBytePattern(query); ok && m.showsHexDump() {
+		m.searchHexBytes(pattern)
+		return
+	}
+
 	// Auto-detect regex
 	useRegex := isRegexPattern(query)
 
@@ -1249,21 +1678,10 @@ func (m *Model) loadHistoryEntry(index int) tea.Cmd {
 		m.loadRequestsFromCurrentFile()
 	}
 
-	// Convert to RequestResult
-	m.currentResponse = &types.RequestResult{
-		Status:       entry.ResponseStatus,
-		StatusText:   entry.ResponseStatusText,
-		Headers:      entry.ResponseHeaders,
-		Body:         entry.ResponseBody,
-		Duration:     entry.Duration,
-		RequestSize:  entry.RequestSize,
-		ResponseSize: entry.ResponseSize,
-		Error:        entry.Error,
-		Timestamp:    entry.Timestamp,
-	}
+	response := historyEntryResult(entry)
 
 	// Convert to HttpRequest
-	m.currentRequest = &types.HttpRequest{
+	request := &types.HttpRequest{
 		Name:    entry.RequestName,
 		Method:  entry.Method,
 		URL:     entry.URL,
@@ -1271,6 +1689,9 @@ func (m *Model) loadHistoryEntry(index int) tea.Cmd {
 		Body:    entry.Body,
 	}
 
+	// Open in a new tab so the previous response stays available
+	m.openResponseTab(request, response)
+
 	// Update response view
 	m.updateResponseView()
 
@@ -1283,32 +1704,9 @@ func (m *Model) loadHistoryEntry(index int) tea.Cmd {
 }
 
 // filterHistoryEntries filters history entries based on search query
-// Searches in: URL, method, request name, status code, timestamp
+// Searches in: request name, method, URL, status, timestamp and bodies ("body:" for bodies only)
 func (m *Model) filterHistoryEntries() {
-	query := strings.ToLower(m.historyState.GetSearchQuery())
-
-	if query == "" {
-		m.historyState.SetEntries(m.historyState.GetAllEntries())
-		m.historyState.SetIndex(0)
-		m.updateHistoryView()
-		return
-	}
-
-	var filtered []types.HistoryEntry
-	for _, entry := range m.historyState.GetAllEntries() {
-		// Search in multiple fields
-		if strings.Contains(strings.ToLower(entry.URL), query) ||
-			strings.Contains(strings.ToLower(entry.Method), query) ||
-			strings.Contains(strings.ToLower(entry.RequestName), query) ||
-			strings.Contains(entry.ResponseStatusText, query) ||
-			strings.Contains(fmt.Sprintf("%d", entry.ResponseStatus), query) ||
-			strings.Contains(entry.Timestamp, query) {
-			filtered = append(filtered, entry)
-		}
-	}
-
-	m.historyState.SetEntries(filtered)
-	m.historyState.SetIndex(0)
+	m.historyState.Search(m.historyState.GetSearchQuery())
 	m.updateHistoryView()
 }
 
@@ -1320,17 +1718,8 @@ func (m *Model) replayHistoryEntry(index int) tea.Cmd {
 
 	entry := m.historyState.GetEntries()[index]
 
-	// Convert history entry to HttpRequest
-	request := &types.HttpRequest{
-		Name:    entry.RequestName,
-		Method:  entry.Method,
-		URL:     entry.URL,
-		Headers: entry.Headers,
-		Body:    entry.Body,
-	}
-
 	// Set as current request
-	m.currentRequest = request
+	m.currentRequest = historyRequest(entry)
 
 	// Close history modal
 	m.mode = ModeNormal
@@ -1340,7 +1729,7 @@ func (m *Model) replayHistoryEntry(index int) tea.Cmd {
 	return m.executeRequest()
 }
 
-// startOAuthFlow starts the OAuth PKCE flow
+// startOAuthFlow starts the OAuth flow for the profile's grant type
 func (m *Model) startOAuthFlow() tea.Cmd {
 	return func() tea.Msg {
 		profile := m.sessionMgr.GetActiveProfile()
@@ -1350,30 +1739,18 @@ func (m *Model) startOAuthFlow() tea.Cmd {
 			return errorMsg("OAuth is not configured. Press 'O' to configure.")
 		}
 
-		// Validate required fields - support both manual (authEndpoint) and auto-build (authUrl) modes
-		hasManualMode := profile.OAuth.AuthEndpoint != ""
-		hasAutoMode := profile.OAuth.AuthURL != ""
-
-		if !hasManualMode && !hasAutoMode {
-			return errorMsg("OAuth configuration incomplete. Either authEndpoint (complete URL) or authUrl (base URL) is required.")
-		}
-		if profile.OAuth.TokenURL == "" {
-			return errorMsg("OAuth configuration incomplete. Token URL is required.")
-		}
-		if profile.OAuth.ClientID == "" {
-			return errorMsg("OAuth configuration incomplete. Client ID is required.")
+		config, err := buildOAuthConfig(profile.OAuth)
+		if err != nil {
+			return errorMsg(err.Error())
 		}
 
-		// Prepare OAuth config
-		config := &oauth.Config{
-			AuthURL:      profile.OAuth.AuthURL,      // For auto-build mode
-			AuthEndpoint: profile.OAuth.AuthEndpoint, // For manual mode (complete URL)
-			TokenURL:     profile.OAuth.TokenURL,
-			ClientID:     profile.OAuth.ClientID,
-			ClientSecret: profile.OAuth.ClientSecret,
-			RedirectURL:  profile.OAuth.RedirectURI,
-			Scope:        profile.OAuth.Scope,
-			CallbackPort: profile.OAuth.WebhookPort,
+		// Device flow: show the user code first, polling starts once the modal is open
+		if config.GrantType == oauth.GrantDeviceCode {
+			auth, err := oauth.RequestDeviceCode(config)
+			if err != nil {
+				return errorMsg(fmt.Sprintf("OAuth device flow failed: %s", categorizeError(err)))
+			}
+			return oauthDeviceCodeMsg{auth: auth, config: config, oauthCfg: profile.OAuth}
 		}
 
 		// Start OAuth flow
@@ -1382,18 +1759,29 @@ func (m *Model) startOAuthFlow() tea.Cmd {
 			return errorMsg(fmt.Sprintf("OAuth flow failed: %s", categorizeError(err)))
 		}
 
-		// Store token in session variable
-		tokenKey := profile.OAuth.TokenStorageKey
-		if tokenKey == "" {
-			tokenKey = "token"
+		m.storeOAuthToken(profile.OAuth, token)
+
+		return oauthSuccessMsg{
+			accessToken:  token.AccessToken,
+			refreshToken: token.RefreshToken,
+			expiresIn:    token.ExpiresIn,
 		}
-		m.sessionMgr.SetSessionVariable(tokenKey, token.AccessToken)
+	}
+}
 
-		// Also store refresh token if available
-		if token.RefreshToken != "" {
-			m.sessionMgr.SetSessionVariable(tokenKey+"_refresh", token.RefreshToken)
+// pollOAuthDevice polls the token endpoint until the device is approved or polling is cancelled
+func (m *Model) pollOAuthDevice(ctx context.Context, msg oauthDeviceCodeMsg) tea.Cmd {
+	return func() tea.Msg {
+		token, err := oauth.PollDeviceToken(ctx, msg.config, msg.auth)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil // Cancelled with ESC, status already set
+			}
+			return errorMsg(fmt.Sprintf("OAuth device flow failed: %s", categorizeError(err)))
 		}
 
+		m.storeOAuthToken(msg.oauthCfg, token)
+
 		return oauthSuccessMsg{
 			accessToken:  token.AccessToken,
 			refreshToken: token.RefreshToken,
@@ -1402,6 +1790,149 @@ func (m *Model) startOAuthFlow() tea.Cmd {
 	}
 }
 
+// buildOAuthConfig validates the profile OAuth settings and converts them to an oauth.Config
+func buildOAuthConfig(oauthCfg *types.OAuthConfig) (*oauth.Config, error) {
+	switch oauthCfg.GrantType {
+	case "", oauth.GrantAuthorizationCode:
+		// Validate required fields - support both manual (authEndpoint) and auto-build (authUrl) modes
+		hasManualMode := oauthCfg.AuthEndpoint != ""
+		hasAutoMode := oauthCfg.AuthURL != ""
+
+		if !hasManualMode && !hasAutoMode {
+			return nil, fmt.Errorf("OAuth configuration incomplete. Either authEndpoint (complete URL) or authUrl (base URL) is required.")
+		}
+	case oauth.GrantClientCredentials:
+		// No browser involved, the token URL is called directly with the client secret
+		if oauthCfg.ClientSecret == "" {
+			return nil, fmt.Errorf("OAuth configuration incomplete. Client secret is required for the client_credentials grant.")
+		}
+	case oauth.GrantDeviceCode:
+		if oauthCfg.DeviceAuthURL == "" {
+			return nil, fmt.Errorf("OAuth configuration incomplete. Device authorization URL is required for the device_code grant.")
+		}
+	default:
+		return nil, fmt.Errorf("OAuth configuration invalid. Unsupported grant type %q (expected authorization_code, client_credentials or device_code).", oauthCfg.GrantType)
+	}
+
+	if oauthCfg.TokenURL == "" {
+		return nil, fmt.Errorf("OAuth configuration incomplete. Token URL is required.")
+	}
+	if oauthCfg.ClientID == "" {
+		return nil, fmt.Errorf("OAuth configuration incomplete. Client ID is required.")
+	}
+
+	return &oauth.Config{
+		AuthURL:       oauthCfg.AuthURL,      // For auto-build mode
+		AuthEndpoint:  oauthCfg.AuthEndpoint, // For manual mode (complete URL)
+		TokenURL:      oauthCfg.TokenURL,
+		ClientID:      oauthCfg.ClientID,
+		ClientSecret:  oauthCfg.ClientSecret,
+		RedirectURL:   oauthCfg.RedirectURI,
+		Scope:         oauthCfg.Scope,
+		CallbackPort:  oauthCfg.WebhookPort,
+		GrantType:     oauthCfg.GrantType,
+		DeviceAuthURL: oauthCfg.DeviceAuthURL,
+	}, nil
+}
+
+// oauthTokenKey returns the session variable name used to store the access token
+func oauthTokenKey(oauthCfg *types.OAuthConfig) string {
+	if oauthCfg.TokenStorageKey == "" {
+		return "token"
+	}
+	return oauthCfg.TokenStorageKey
+}
+
+// storeOAuthToken stores the access token (and refresh token and expiry if any) in session variables
+func (m *Model) storeOAuthToken(oauthCfg *types.OAuthConfig, token *oauth.TokenResponse) {
+	tokenKey := oauthTokenKey(oauthCfg)
+	m.sessionMgr.SetSessionVariable(tokenKey, token.AccessToken)
+
+	// Also store refresh token if available
+	if token.RefreshToken != "" {
+		m.sessionMgr.SetSessionVariable(tokenKey+"_refresh", token.RefreshToken)
+	}
+
+	// Expiry as RFC3339 so requests and conditions can inspect it
+	if token.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+		m.sessionMgr.SetSessionVariable(tokenKey+"_expires_at", expiresAt.Format(time.RFC3339))
+	}
+}
+
+// shouldRenewOAuthToken reports whether a 401 response should trigger an automatic token renewal
+func shouldRenewOAuthToken(profile *types.Profile, status int) bool {
+	if status != 401 || profile == nil || profile.OAuth == nil {
+		return false
+	}
+	return profile.OAuth.Enabled && profile.OAuth.AutoRefresh
+}
+
+// injectOAuthToken sets "Authorization: Bearer <token>" from the session token (profile TokenStorageKey)
+// when OAuth is enabled with autoInject and the headers have no Authorization header yet
+func (m *Model) injectOAuthToken(profile *types.Profile, headers map[string]string) {
+	if profile == nil || profile.OAuth == nil || !profile.OAuth.Enabled || !profile.OAuth.GetAutoInject() || headers == nil {
+		return
+	}
+	for name := range headers {
+		if strings.EqualFold(name, "Authorization") {
+			return
+		}
+	}
+	token := m.sessionMgr.GetSession().Variables[oauthTokenKey(profile.OAuth)]
+	if token == "" {
+		return
+	}
+	headers["Authorization"] = "Bearer " + token
+}
+
+// oauthTokenNeedsRefresh reports whether the stored OAuth token expires within the profile's refresh skew
+func (m *Model) oauthTokenNeedsRefresh(profile *types.Profile) bool {
+	if profile == nil || profile.OAuth == nil || !profile.OAuth.Enabled {
+		return false
+	}
+	expiresAt := m.sessionMgr.GetSession().Variables[oauthTokenKey(profile.OAuth)+"_expires_at"]
+	if expiresAt == "" {
+		return false // No token yet, or the provider did not send expires_in
+	}
+	expiry, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return false
+	}
+	return time.Until(expiry) <= profile.OAuth.GetRefreshSkew()
+}
+
+// renewOAuthToken refreshes the OAuth token, falling back to a full re-authorization
+// when no refresh token is stored or the refresh is rejected.
+// Returns a short description of what happened for the status bar.
+func (m *Model) renewOAuthToken(profile *types.Profile) (string, error) {
+	config, err := buildOAuthConfig(profile.OAuth)
+	if err != nil {
+		return "", err
+	}
+
+	refreshToken := m.sessionMgr.GetSession().Variables[oauthTokenKey(profile.OAuth)+"_refresh"]
+	if refreshToken != "" {
+		if token, err := oauth.RefreshToken(config, refreshToken); err == nil {
+			m.storeOAuthToken(profile.OAuth, token)
+			return "OAuth token refreshed", nil
+		}
+	}
+
+	// The device flow needs the user to approve again, which cannot happen mid-request
+	if config.GrantType == oauth.GrantDeviceCode {
+		return "", fmt.Errorf("OAuth token expired. Press o to authorize this device again")
+	}
+
+	token, err := oauth.StartFlow(config)
+	if err != nil {
+		return "", fmt.Errorf("OAuth re-authorization failed: %s", categorizeError(err))
+	}
+	m.storeOAuthToken(profile.OAuth, token)
+
+	return "OAuth re-authorized", nil
+}
+
 // openProfilesInEditor opens .profiles.json in external editor
 func (m *Model) openProfilesInEditor() tea.Cmd {
 	return m.openConfigFile(config.GetProfilesFilePath())
@@ -1440,6 +1971,7 @@ func (m *Model) loadRequestsFromCurrentFile() {
 	currentFile := m.fileExplorer.GetCurrentFile()
 	if currentFile == nil {
 		m.currentRequests = nil
+		m.currentFilePath = ""
 		m.currentRequest = nil
 		return
 	}
@@ -1447,6 +1979,7 @@ func (m *Model) loadRequestsFromCurrentFile() {
 	// Skip parsing for WebSocket files - they're executed directly
 	if currentFile.HTTPMethod == "WS" {
 		m.currentRequests = nil
+		m.currentFilePath = ""
 		m.currentRequest = nil
 		m.errorMsg = "" // Clear any errors
 		return
@@ -1459,8 +1992,17 @@ func (m *Model) loadRequestsFromCurrentFile() {
 		return
 	}
 
+	// Reloading the same file (refresh, watch mode) keeps the selected request
+	index := 0
+	if filePath == m.currentFilePath {
+		index = max(m.currentRequestIndex(), 0)
+	}
+
 	m.currentRequests = requests
-	if len(requests) > 0 {
+	m.currentFilePath = filePath
+	if index < len(requests) {
+		m.currentRequest = &requests[index]
+	} else if len(requests) > 0 {
 		m.currentRequest = &requests[0]
 	} else {
 		m.currentRequest = nil
@@ -1472,7 +2014,7 @@ func (m *Model) loadRequestsFromCurrentFile() {
 
 // getInteractiveVariables returns a list of interactive variables that are used in the current request
 func (m *Model) getInteractiveVariables() []string {
-	profile := m.sessionMgr.GetActiveProfile()
+	profile, _ := m.sessionMgr.ProfileForRequest(m.currentRequest)
 	if profile == nil || m.currentRequest == nil {
 		return nil
 	}
@@ -1486,7 +2028,7 @@ func (m *Model) getInteractiveVariables() []string {
 
 	// Only return interactive variables that are actually used in this request
 	var interactiveVars []string
-	for name, value := range profile.Variables {
+	for name, value := range m.sessionMgr.ProfileVariables(profile) {
 		if value.Interactive && requiredVarsMap[name] {
 			interactiveVars = append(interactiveVars, name)
 		}
@@ -1504,6 +2046,11 @@ func (m *Model) executeRequestWithInteractiveVars() tea.Cmd {
 // Removes query parameters and domain, keeping only the path
 // For analytics grouping purposes
 func normalizePath(rawURL string) string {
+	// Unix socket URLs keep only the HTTP path
+	if _, httpURL, ok := executor.SplitUnixSocketURL(rawURL); ok {
+		rawURL = httpURL
+	}
+
 	// Remove query parameters
 	if idx := strings.Index(rawURL, "?"); idx != -1 {
 		rawURL = rawURL[:idx]
@@ -1527,8 +2074,22 @@ func normalizePath(rawURL string) string {
 	return path
 }
 
-// renderHistoryClearConfirmation renders the confirmation modal for clearing all history
+// renderHistoryClearConfirmation renders the confirmation modal for clearing all or untagged history
 func (m *Model) renderHistoryClearConfirmation() string {
+	if m.historyClearUntagged {
+		count := 0
+		for _, entry := range m.historyState.GetAllEntries() {
+			if !entry.IsAnnotated() {
+				count++
+			}
+		}
+		content := "This will permanently delete the history entries of this profile\n"
+		content += "that have no note and no tags.\n\n"
+		content += fmt.Sprintf("Entries to delete: %d\n\n", count)
+		content += "Are you sure you want to continue?"
+		return m.renderModalWithFooter("Clear Untagged History", content, "[y]es [n]o/ESC", 60, 12)
+	}
+
 	count := len(m.historyState.GetEntries())
 	content := "WARNING\n\n"
 	content += "This will permanently delete ALL history entries.\n\n"
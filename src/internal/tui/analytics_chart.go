@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/analytics"
+)
+
+// analyticsChartRange is a date range selectable for the time-series chart
+type analyticsChartRange struct {
+	label string
+	span  time.Duration
+}
+
+var analyticsChartRanges = []analyticsChartRange{
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+	{"90d", 90 * 24 * time.Hour},
+}
+
+const defaultAnalyticsChartRange = 1 // 7d
+
+const (
+	chartLabelWidth     = 8 // Y axis labels ("12345ms ")
+	chartLatencyHeight  = 8
+	chartVolumeHeight   = 3
+	chartMinimumColumns = 10
+)
+
+// chartBlocks are the partial bar heights, in eighths
+var chartBlocks = []rune(" ▁▂▃▄▅▆▇█")
+
+// analyticsChartColumns returns how many buckets fit in a pane of the given width
+func analyticsChartColumns(width int) int {
+	return max(width-chartLabelWidth-1, chartMinimumColumns)
+}
+
+// chartWindow returns the visible slice of points, scrolled back by scroll buckets
+func chartWindow(points []analytics.TimeSeriesPoint, columns, scroll int) []analytics.TimeSeriesPoint {
+	end := len(points) - scroll
+	if end < min(columns, len(points)) {
+		end = min(columns, len(points))
+	}
+	return points[max(end-columns, 0):end]
+}
+
+// loadAnalyticsChart loads the time series of the selected endpoint
+func (m *Model) loadAnalyticsChart() error {
+	stat := m.analyticsState.GetCurrentStats()
+	if stat == nil || m.analyticsState.GetManager() == nil {
+		m.analyticsState.SetChartPoints(nil)
+		return nil
+	}
+
+	profileName := ""
+	if profile := m.sessionMgr.GetActiveProfile(); profile != nil {
+		profileName = profile.Name
+	}
+	query := analytics.TimeSeriesQuery{
+		ProfileName:    profileName,
+		NormalizedPath: stat.NormalizedPath,
+		Method:         stat.Method,
+		Bucket:         m.analyticsState.GetChartBucket(),
+		Since:          time.Now().Add(-m.analyticsState.GetChartRange().span),
+	}
+	if !m.analyticsState.GetGroupByPath() {
+		query.FilePath = stat.FilePath
+	}
+
+	points, err := m.analyticsState.GetManager().GetTimeSeries(query)
+	if err != nil {
+		m.analyticsState.SetChartPoints(nil)
+		return err
+	}
+	m.analyticsState.SetChartPoints(points)
+	return nil
+}
+
+// renderAnalyticsChart renders the average latency and request volume of points as ASCII charts
+func renderAnalyticsChart(points []analytics.TimeSeriesPoint, bucket analytics.Bucket, width, scroll int) string {
+	if len(points) == 0 {
+		return "No data in this range"
+	}
+
+	window := chartWindow(points, analyticsChartColumns(width), scroll)
+
+	maxLatency, maxCalls := 0.0, 0
+	totalCalls, totalErrors := 0, 0
+	for _, p := range window {
+		maxLatency = math.Max(maxLatency, p.AvgDurationMs)
+		maxCalls = max(maxCalls, p.Calls)
+		totalCalls += p.Calls
+		totalErrors += p.Errors
+	}
+
+	var b strings.Builder
+	b.WriteString(styleTitle.Render("Avg Latency") + "\n")
+	b.WriteString(renderLatencyChart(window, maxLatency))
+	b.WriteString(styleTitle.Render("Requests") + styleSubtle.Render(" (errors in red)") + "\n")
+	b.WriteString(renderVolumeChart(window, maxCalls))
+	b.WriteString(renderChartTimeAxis(window, bucket))
+
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Window:         %d %ss\n", len(window), bucket))
+	b.WriteString(fmt.Sprintf("Calls:          %d\n", totalCalls))
+	if totalCalls > 0 {
+		b.WriteString(fmt.Sprintf("Errors:         %d (%.1f%%)\n", totalErrors, float64(totalErrors)/float64(totalCalls)*100))
+	}
+	if scroll > 0 || len(window) < len(points) {
+		b.WriteString(styleSubtle.Render(fmt.Sprintf("Showing %d of %d %ss (h/l to scroll)", len(window), len(points), bucket)) + "\n")
+	}
+
+	return b.String()
+}
+
+// renderLatencyChart plots the average latency of each bucket, joining consecutive points
+func renderLatencyChart(window []analytics.TimeSeriesPoint, maxLatency float64) string {
+	grid := make([][]rune, chartLatencyHeight)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", len(window)))
+	}
+
+	prevRow := -1
+	for col, p := range window {
+		if p.Calls == 0 {
+			prevRow = -1
+			continue
+		}
+		row := 0
+		if maxLatency > 0 {
+			row = int(math.Round(p.AvgDurationMs / maxLatency * float64(chartLatencyHeight-1)))
+		}
+		// Rows count from the bottom; the grid is stored top first
+		if prevRow >= 0 {
+			for r := min(prevRow, row) + 1; r < max(prevRow, row); r++ {
+				grid[chartLatencyHeight-1-r][col] = '│'
+			}
+		}
+		grid[chartLatencyHeight-1-row][col] = '•'
+		prevRow = row
+	}
+
+	var b strings.Builder
+	for i, line := range grid {
+		label := ""
+		switch i {
+		case 0:
+			label = fmt.Sprintf("%.0fms", maxLatency)
+		case chartLatencyHeight - 1:
+			label = "0ms"
+		}
+		b.WriteString(fmt.Sprintf("%*s│", chartLabelWidth, label+" "))
+		b.WriteString(styleChartLatency.Render(string(line)) + "\n")
+	}
+	return b.String()
+}
+
+// renderVolumeChart draws one bar per bucket, red when the bucket has errors
+func renderVolumeChart(window []analytics.TimeSeriesPoint, maxCalls int) string {
+	levels := chartVolumeHeight * (len(chartBlocks) - 1)
+
+	var b strings.Builder
+	for i := 0; i < chartVolumeHeight; i++ {
+		label := ""
+		if i == 0 {
+			label = fmt.Sprintf("%d", maxCalls)
+		}
+		b.WriteString(fmt.Sprintf("%*s│", chartLabelWidth, label+" "))
+
+		// Row counted from the bottom
+		row := chartVolumeHeight - 1 - i
+		for _, p := range window {
+			level := 0
+			if maxCalls > 0 {
+				level = int(math.Ceil(float64(p.Calls) / float64(maxCalls) * float64(levels)))
+			}
+			fill := min(max(level-row*(len(chartBlocks)-1), 0), len(chartBlocks)-1)
+			block := string(chartBlocks[fill])
+			if p.Errors > 0 {
+				b.WriteString(styleChartErrors.Render(block))
+			} else {
+				b.WriteString(styleChartVolume.Render(block))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderChartTimeAxis draws the X axis with the first and last bucket of the window
+func renderChartTimeAxis(window []analytics.TimeSeriesPoint, bucket analytics.Bucket) string {
+	layout := "01-02 15h"
+	if bucket == analytics.BucketDay {
+		layout = "01-02"
+	}
+	first := window[0].Start.Format(layout)
+	last := window[len(window)-1].Start.Format(layout)
+
+	axis := strings.Repeat(" ", chartLabelWidth) + "└" + strings.Repeat("─", len(window)) + "\n"
+	labels := strings.Repeat(" ", chartLabelWidth+1) + first
+	if gap := len(window) - len(first) - len(last); gap > 0 {
+		labels += strings.Repeat(" ", gap) + last
+	}
+	return axis + labels + "\n"
+}
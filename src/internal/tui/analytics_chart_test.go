@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/analytics"
+)
+
+func TestChartWindow(t *testing.T) {
+	points := make([]analytics.TimeSeriesPoint, 50)
+	for i := range points {
+		points[i].Calls = i
+	}
+
+	window := chartWindow(points, 20, 0)
+	if len(window) != 20 || window[0].Calls != 30 || window[19].Calls != 49 {
+		t.Errorf("Expected the latest 20 buckets, got %d starting at %d", len(window), window[0].Calls)
+	}
+
+	window = chartWindow(points, 20, 10)
+	if window[0].Calls != 20 || window[19].Calls != 39 {
+		t.Errorf("Expected the window scrolled back by 10, got %d..%d", window[0].Calls, window[19].Calls)
+	}
+
+	window = chartWindow(points, 20, 45)
+	if len(window) != 20 || window[0].Calls != 0 {
+		t.Errorf("Expected over-scrolling to show the oldest full window, got %d starting at %d", len(window), window[0].Calls)
+	}
+
+	window = chartWindow(points[:5], 20, 0)
+	if len(window) != 5 {
+		t.Errorf("Expected short series to be shown whole, got %d", len(window))
+	}
+}
+
+func TestRenderLatencyChart(t *testing.T) {
+	window := []analytics.TimeSeriesPoint{
+		{Calls: 1, AvgDurationMs: 0},
+		{Calls: 0},
+		{Calls: 1, AvgDurationMs: 700},
+		{Calls: 1, AvgDurationMs: 0},
+	}
+
+	lines := strings.Split(strings.TrimSuffix(renderLatencyChart(window, 700), "\n"), "\n")
+	if len(lines) != chartLatencyHeight {
+		t.Fatalf("Expected %d rows, got %d", chartLatencyHeight, len(lines))
+	}
+	if !strings.Contains(lines[0], "700ms") || !strings.Contains(lines[len(lines)-1], "0ms") {
+		t.Errorf("Expected max and zero labels, got %q and %q", lines[0], lines[len(lines)-1])
+	}
+
+	column := func(col int) string {
+		var b strings.Builder
+		for _, line := range lines {
+			plot := []rune(line[strings.Index(line, "│")+len("│"):])
+			b.WriteRune(plot[col])
+		}
+		return b.String()
+	}
+	if got := column(1); strings.TrimSpace(got) != "" {
+		t.Errorf("Expected a gap for the bucket without calls, got %q", got)
+	}
+	if got := column(2); got != "•       " {
+		t.Errorf("Expected the peak at the top, got %q", got)
+	}
+	// The drop from the peak is joined by a vertical line
+	if got := column(3); got != " ││││││•" {
+		t.Errorf("Expected the drop to be joined, got %q", got)
+	}
+}
+
+func TestRenderAnalyticsChart(t *testing.T) {
+	if got := renderAnalyticsChart(nil, analytics.BucketHour, 80, 0); got != "No data in this range" {
+		t.Errorf("Unexpected output for an empty series: %q", got)
+	}
+
+	points := []analytics.TimeSeriesPoint{{Start: time.Date(2026, 1, 2, 10, 0, 0, 0, time.Local), Calls: 4, Errors: 1, AvgDurationMs: 120}}
+	if got := renderAnalyticsChart(points, analytics.BucketHour, 80, 0); !strings.Contains(got, "Errors:         1 (25.0%)") {
+		t.Errorf("Expected the error summary, got %q", got)
+	}
+}
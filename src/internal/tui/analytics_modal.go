@@ -2,12 +2,14 @@ package tui
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/studiowebux/restcli/internal/analytics"
+	"github.com/studiowebux/restcli/internal/types"
 )
 
 // renderAnalytics renders the analytics modal with telescope-style split view
@@ -37,10 +39,13 @@ func (m *Model) renderAnalytics() string {
 
 	// Build detail title with scroll indicator
 	detailTitle := "Details"
+	if m.analyticsState.GetChartVisible() {
+		detailTitle = "Trend"
+	}
 	scrollIndicator := m.getAnalyticsDetailScrollIndicator()
 	if scrollIndicator != "" {
 		// We'll render the title manually in the config to include the scroll indicator
-		detailTitle = "Details  " + scrollIndicator
+		detailTitle += "  " + scrollIndicator
 	}
 
 	// Build footer with instructions and scroll position
@@ -48,7 +53,11 @@ func (m *Model) renderAnalytics() string {
 	if m.analyticsState.GetGroupByPath() {
 		groupMode = "By Path"
 	}
-	footerText := fmt.Sprintf("TAB: Switch Focus | ↑/↓ j/k: Nav | Enter: Load | p: Preview | t: Toggle Group (%s) | C: Clear | ESC/q: Close", groupMode)
+	footerText := fmt.Sprintf("TAB: Switch Focus | ↑/↓ j/k: Nav | Enter: Load | p: Preview | t: Toggle Group (%s) | c: Chart | x/X: Export | C: Clear | ESC/q: Close", groupMode)
+	if m.analyticsState.GetChartVisible() {
+		footerText = fmt.Sprintf("TAB: Switch Focus | ↑/↓ j/k: Nav | t: Toggle Group (%s) | c: Stats | b: Bucket (%s) | r: Range (%s) | h/l: Scroll | ESC/q: Close",
+			groupMode, m.analyticsState.GetChartBucket(), m.analyticsState.GetChartRange().label)
+	}
 
 	// Add scroll indicator if there are stats
 	if len(m.analyticsState.GetStats()) > 0 {
@@ -113,6 +122,14 @@ func (m *Model) updateAnalyticsView() {
 
 	// Build content for left pane (analytics list)
 	var listContent strings.Builder
+
+	// Banner when endpoints fail more than the profile allows
+	bannerLines := 0
+	if alerts, threshold, window := m.analyticsState.GetAlertSummary(); alerts > 0 {
+		listContent.WriteString(styleError.Render(fmt.Sprintf("⚠ %d endpoint(s) above %.1f%% errors in the last %dm", alerts, threshold, window)) + "\n\n")
+		bannerLines = 2
+	}
+
 	if len(m.analyticsState.GetStats()) == 0 {
 		listContent.WriteString("No analytics data available.\n\nEnable analytics in your profile to start tracking:\n\"analyticsEnabled\": true")
 	} else {
@@ -139,9 +156,17 @@ func (m *Model) updateAnalyticsView() {
 				stat.AvgDurationMs,
 			)
 
+			// Flag endpoints above the error rate threshold
+			rate, alerting := m.analyticsState.GetAlert(stat.Method, stat.NormalizedPath)
+			if alerting {
+				line += fmt.Sprintf(" | Recent errors: %.1f%%", rate.Percent())
+			}
+
 			// Highlight selected
 			if i == m.analyticsState.GetIndex() {
 				line = styleSelected.Render("> " + line)
+			} else if alerting {
+				line = styleError.Render("! " + line)
 			} else {
 				line = "  " + line
 			}
@@ -157,7 +182,7 @@ func (m *Model) updateAnalyticsView() {
 	// Auto-scroll to keep selected item visible
 	if len(m.analyticsState.GetStats()) > 0 && m.analyticsState.GetIndex() >= 0 && m.analyticsState.GetIndex() < len(m.analyticsState.GetStats()) {
 		// Calculate the line position (0-indexed)
-		linePos := m.analyticsState.GetIndex()
+		linePos := m.analyticsState.GetIndex() + bannerLines
 
 		// Get viewport height
 		listView := m.analyticsState.GetListView()
@@ -177,6 +202,23 @@ func (m *Model) updateAnalyticsView() {
 	var detailContent strings.Builder
 	if len(m.analyticsState.GetStats()) == 0 || m.analyticsState.GetIndex() >= len(m.analyticsState.GetStats()) {
 		detailContent.WriteString("No analytics selected")
+	} else if m.analyticsState.GetChartVisible() {
+		stat := m.analyticsState.GetStats()[m.analyticsState.GetIndex()]
+		bucket := m.analyticsState.GetChartBucket()
+
+		detailContent.WriteString(styleTitle.Render(fmt.Sprintf("%s %s", stat.Method, stat.NormalizedPath)) + "\n")
+		detailContent.WriteString(styleSubtle.Render(fmt.Sprintf("Per %s, last %s", bucket, m.analyticsState.GetChartRange().label)) + "\n\n")
+
+		if err := m.loadAnalyticsChart(); err != nil {
+			detailContent.WriteString(styleError.Render(fmt.Sprintf("Failed to load time series: %v", err)))
+		} else {
+			detailContent.WriteString(renderAnalyticsChart(
+				m.analyticsState.GetChartPoints(),
+				bucket,
+				m.analyticsState.GetDetailView().Width,
+				m.analyticsState.GetChartScroll(),
+			))
+		}
 	} else {
 		stat := m.analyticsState.GetStats()[m.analyticsState.GetIndex()]
 
@@ -188,6 +230,13 @@ func (m *Model) updateAnalyticsView() {
 			detailContent.WriteString(styleSubtle.Render("File: ") + filepath.Base(stat.FilePath) + "\n\n")
 		}
 
+		// Recent error rate above the threshold
+		if rate, ok := m.analyticsState.GetAlert(stat.Method, stat.NormalizedPath); ok {
+			_, threshold, window := m.analyticsState.GetAlertSummary()
+			detailContent.WriteString(styleError.Render(fmt.Sprintf("⚠ %.1f%% errors in the last %dm (%d/%d calls, threshold %.1f%%)",
+				rate.Percent(), window, rate.Errors, rate.Calls, threshold)) + "\n\n")
+		}
+
 		// Summary stats
 		detailContent.WriteString(styleTitle.Render("Summary") + "\n")
 		detailContent.WriteString(fmt.Sprintf("Total Calls:    %d\n", stat.TotalCalls))
@@ -205,7 +254,17 @@ func (m *Model) updateAnalyticsView() {
 		detailContent.WriteString(styleTitle.Render("Timing") + "\n")
 		detailContent.WriteString(fmt.Sprintf("Average:        %.0fms\n", stat.AvgDurationMs))
 		detailContent.WriteString(fmt.Sprintf("Min:            %dms\n", stat.MinDurationMs))
-		detailContent.WriteString(fmt.Sprintf("Max:            %dms\n\n", stat.MaxDurationMs))
+		detailContent.WriteString(fmt.Sprintf("Max:            %dms\n", stat.MaxDurationMs))
+		detailContent.WriteString(fmt.Sprintf("Avg TTFB:       %.0fms\n", stat.AvgTTFBMs))
+		if stat.BudgetCalls > 0 {
+			sla := fmt.Sprintf("Within Budget:  %d/%d (%.1f%%)", stat.BudgetMetCount, stat.BudgetCalls,
+				float64(stat.BudgetMetCount)/float64(stat.BudgetCalls)*100)
+			if stat.BudgetMetCount < stat.BudgetCalls {
+				sla = styleWarning.Render(sla)
+			}
+			detailContent.WriteString(sla + "\n")
+		}
+		detailContent.WriteString("\n")
 
 		// Data transfer
 		detailContent.WriteString(styleTitle.Render("Data Transfer") + "\n")
@@ -326,11 +385,13 @@ func (m *Model) loadAnalytics() tea.Cmd {
 			return analyticsLoadedMsg{stats: []analytics.Stats{}}
 		}
 
-		// Get active profile name
-		profileName := ""
-		if profile := m.sessionMgr.GetActiveProfile(); profile != nil {
-			profileName = profile.Name
+		// Get active profile (name and error rate settings)
+		profile := m.sessionMgr.GetActiveProfile()
+		if profile == nil {
+			profile = &types.Profile{}
 		}
+		profileName := profile.Name
+		threshold, window := profile.GetErrorRateThreshold(), profile.GetErrorRateWindow()
 
 		var stats []analytics.Stats
 		var err error
@@ -345,7 +406,54 @@ func (m *Model) loadAnalytics() tea.Cmd {
 			return errorMsg(fmt.Sprintf("Failed to load analytics: %v", err))
 		}
 
-		return analyticsLoadedMsg{stats: stats}
+		since := time.Now().Add(-time.Duration(window) * time.Minute)
+		errorRates, err := m.analyticsState.GetManager().GetRecentErrorRates(profileName, since)
+		if err != nil {
+			return errorMsg(fmt.Sprintf("Failed to load analytics: %v", err))
+		}
+
+		return analyticsLoadedMsg{stats: stats, errorRates: errorRates, threshold: threshold, window: window}
+	}
+}
+
+// exportAnalytics writes the analytics entries of the active profile to a file
+// Rows follow the current grouping (per-file or by normalized path)
+func (m *Model) exportAnalytics(format string) tea.Cmd {
+	if m.analyticsManager == nil {
+		return m.setErrorMessage("Analytics are disabled")
+	}
+
+	profileName := ""
+	if profile := m.sessionMgr.GetActiveProfile(); profile != nil {
+		profileName = profile.Name
+	}
+	filter := analytics.ExportFilter{
+		ProfileName: &profileName,
+		GroupByPath: m.analyticsState.GetGroupByPath(),
+	}
+	manager := m.analyticsState.GetManager()
+
+	return func() tea.Msg {
+		timestamp := time.Now().Format("20060102-150405")
+		filename := fmt.Sprintf("analytics-%s.%s", timestamp, format)
+		// Written to the current directory, the status shows where that is
+		if abs, err := filepath.Abs(filename); err == nil {
+			filename = abs
+		}
+
+		file, err := os.Create(filename)
+		if err != nil {
+			return errorMsg(fmt.Sprintf("Export failed: %v", err))
+		}
+		count, err := manager.ExportFiltered(file, format, filter)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return errorMsg(fmt.Sprintf("Export failed: %v", err))
+		}
+
+		return m.setStatusMessage(fmt.Sprintf("Exported %d analytics entries to %s", count, filename))
 	}
 }
 
@@ -26,6 +26,18 @@ type AnalyticsState struct {
 	previewVisible bool   // Toggle for showing/hiding stats detail pane
 	groupByPath    bool   // Toggle between per-file and normalized-path grouping
 	focusedPane    string // "list" or "details" - which pane has focus in split view
+
+	// Time-series chart shown in the details pane
+	chartVisible bool
+	chartBucket  analytics.Bucket
+	chartRange   int // Index into analyticsChartRanges
+	chartScroll  int // Buckets scrolled back from the most recent one
+	chartPoints  []analytics.TimeSeriesPoint
+
+	// Endpoints whose recent error rate is above the profile threshold
+	alerts         map[string]analytics.ErrorRate // Keyed by method and normalized path
+	alertThreshold float64
+	alertWindow    int // Minutes
 }
 
 // NewAnalyticsState creates a new analytics state
@@ -39,6 +51,8 @@ func NewAnalyticsState(manager *analytics.Manager) *AnalyticsState {
 		previewVisible: true,
 		groupByPath:    false,
 		focusedPane:    "list",
+		chartBucket:    analytics.BucketHour,
+		chartRange:     defaultAnalyticsChartRange,
 	}
 }
 
@@ -204,3 +218,121 @@ func (s *AnalyticsState) ToggleFocus() {
 		s.focusedPane = "list"
 	}
 }
+
+// GetChartVisible returns whether the details pane shows the time-series chart
+func (s *AnalyticsState) GetChartVisible() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.chartVisible
+}
+
+// ToggleChart switches the details pane between stats and the time-series chart
+func (s *AnalyticsState) ToggleChart() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chartVisible = !s.chartVisible
+	s.chartScroll = 0
+}
+
+// GetChartBucket returns the chart bucket size
+func (s *AnalyticsState) GetChartBucket() analytics.Bucket {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.chartBucket
+}
+
+// ToggleChartBucket switches the chart between hourly and daily buckets
+func (s *AnalyticsState) ToggleChartBucket() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.chartBucket == analytics.BucketHour {
+		s.chartBucket = analytics.BucketDay
+	} else {
+		s.chartBucket = analytics.BucketHour
+	}
+	s.chartScroll = 0
+}
+
+// GetChartRange returns the chart date range
+func (s *AnalyticsState) GetChartRange() analyticsChartRange {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return analyticsChartRanges[s.chartRange]
+}
+
+// CycleChartRange switches to the next chart date range
+func (s *AnalyticsState) CycleChartRange() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chartRange = (s.chartRange + 1) % len(analyticsChartRanges)
+	s.chartScroll = 0
+}
+
+// GetChartScroll returns how many buckets the chart is scrolled back
+func (s *AnalyticsState) GetChartScroll() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.chartScroll
+}
+
+// ScrollChart moves the chart window by delta buckets (positive = older)
+// visible is the number of buckets the chart shows; the oldest window is the limit.
+func (s *AnalyticsState) ScrollChart(delta, visible int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chartScroll += delta
+	if s.chartScroll > len(s.chartPoints)-visible {
+		s.chartScroll = len(s.chartPoints) - visible
+	}
+	if s.chartScroll < 0 {
+		s.chartScroll = 0
+	}
+}
+
+// GetChartPoints returns the loaded time series
+func (s *AnalyticsState) GetChartPoints() []analytics.TimeSeriesPoint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.chartPoints
+}
+
+// SetChartPoints sets the loaded time series
+func (s *AnalyticsState) SetChartPoints(points []analytics.TimeSeriesPoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chartPoints = points
+}
+
+// alertKey identifies an endpoint in the alerts map
+func alertKey(method, normalizedPath string) string {
+	return method + " " + normalizedPath
+}
+
+// SetErrorRates keeps the endpoints whose error rate exceeds threshold over the last window minutes
+func (s *AnalyticsState) SetErrorRates(rates []analytics.ErrorRate, threshold float64, window int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = make(map[string]analytics.ErrorRate)
+	for _, rate := range rates {
+		if rate.Exceeds(threshold) {
+			s.alerts[alertKey(rate.Method, rate.NormalizedPath)] = rate
+		}
+	}
+	s.alertThreshold = threshold
+	s.alertWindow = window
+}
+
+// GetAlert returns the recent error rate of an endpoint when it is above the threshold
+func (s *AnalyticsState) GetAlert(method, normalizedPath string) (analytics.ErrorRate, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rate, ok := s.alerts[alertKey(method, normalizedPath)]
+	return rate, ok
+}
+
+// GetAlertSummary returns the number of alerting endpoints, the threshold and the window in minutes
+func (s *AnalyticsState) GetAlertSummary() (int, float64, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.alerts), s.alertThreshold, s.alertWindow
+}
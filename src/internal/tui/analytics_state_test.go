@@ -405,3 +405,75 @@ func TestAnalyticsState_ViewportImmutability(t *testing.T) {
 		t.Errorf("Expected Width 100, got %d", current.Width)
 	}
 }
+
+func TestAnalyticsState_ChartSettings(t *testing.T) {
+	state := NewAnalyticsState(nil)
+
+	if state.GetChartVisible() {
+		t.Error("Expected chart hidden by default")
+	}
+	if state.GetChartBucket() != analytics.BucketHour {
+		t.Errorf("Expected hourly buckets by default, got %s", state.GetChartBucket())
+	}
+	if state.GetChartRange().label != "7d" {
+		t.Errorf("Expected 7d range by default, got %s", state.GetChartRange().label)
+	}
+
+	state.ToggleChartBucket()
+	if state.GetChartBucket() != analytics.BucketDay {
+		t.Errorf("Expected daily buckets after toggle, got %s", state.GetChartBucket())
+	}
+
+	for range analyticsChartRanges {
+		state.CycleChartRange()
+	}
+	if state.GetChartRange().label != "7d" {
+		t.Errorf("Expected cycling through all ranges to wrap around, got %s", state.GetChartRange().label)
+	}
+}
+
+func TestAnalyticsState_ScrollChart(t *testing.T) {
+	state := NewAnalyticsState(nil)
+	state.SetChartPoints(make([]analytics.TimeSeriesPoint, 100))
+
+	state.ScrollChart(-5, 40)
+	if state.GetChartScroll() != 0 {
+		t.Errorf("Expected scroll clamped at the latest bucket, got %d", state.GetChartScroll())
+	}
+
+	state.ScrollChart(1000, 40)
+	if state.GetChartScroll() != 60 {
+		t.Errorf("Expected scroll clamped at the oldest window, got %d", state.GetChartScroll())
+	}
+
+	state.CycleChartRange()
+	if state.GetChartScroll() != 0 {
+		t.Errorf("Expected changing the range to reset the scroll, got %d", state.GetChartScroll())
+	}
+}
+
+func TestAnalyticsState_ErrorRateAlerts(t *testing.T) {
+	state := NewAnalyticsState(nil)
+	state.SetErrorRates([]analytics.ErrorRate{
+		{NormalizedPath: "/users/{id}", Method: "GET", Calls: 20, Errors: 5},
+		{NormalizedPath: "/auth", Method: "POST", Calls: 10, Errors: 1},
+		{NormalizedPath: "/health", Method: "GET", Calls: 10},
+	}, 10, 60)
+
+	count, threshold, window := state.GetAlertSummary()
+	if count != 1 || threshold != 10 || window != 60 {
+		t.Errorf("Expected 1 alert at 10%% over 60m, got %d at %.1f%% over %dm", count, threshold, window)
+	}
+
+	rate, ok := state.GetAlert("GET", "/users/{id}")
+	if !ok || rate.Percent() != 25 {
+		t.Errorf("Expected GET /users/{id} to alert at 25%%, got %v %.1f", ok, rate.Percent())
+	}
+	// Exactly at the threshold is not above it
+	if _, ok := state.GetAlert("POST", "/auth"); ok {
+		t.Error("Expected an error rate equal to the threshold not to alert")
+	}
+	if _, ok := state.GetAlert("POST", "/users/{id}"); ok {
+		t.Error("Expected alerts to be per method")
+	}
+}
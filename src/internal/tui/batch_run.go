@@ -0,0 +1,334 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/chain"
+	"github.com/studiowebux/restcli/internal/executor"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/parser"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Batch Run - Execute every request of the category-filtered files
+//
+// Requests run one at a time as a smoke test: each step resolves and sends a
+// request, then the Update loop queues the next one, so ESC cancels the request
+// in flight and stops the batch. A request passes when its status matches
+// @expectedStatusCodes (2xx by default). Results are collected for the summary
+// modal; the response panel and history are left untouched.
+
+// batchItem is one request queued by a batch run
+type batchItem struct {
+	fileName string
+	request  *types.HttpRequest // Nil when the file could not be parsed
+	problem  string             // Parse error or reason the request is skipped
+}
+
+// BatchResult is the outcome of one request of a batch run
+type BatchResult struct {
+	FileName   string
+	Request    string
+	Status     int // 0 when no response was received
+	DurationMs int64
+	Passed     bool
+	Skipped    bool
+	Message    string // Failure or skip reason
+}
+
+// batchRun tracks the progress of a running batch
+type batchRun struct {
+	items   []batchItem
+	index   int
+	results []BatchResult
+}
+
+// batchStepMsg is sent when one request of the batch has completed
+type batchStepMsg struct {
+	result    BatchResult
+	cancelled bool
+}
+
+// startBatchRun queues the requests of every file in the category-filtered file list
+func (m *Model) startBatchRun() tea.Cmd {
+	if m.loading {
+		return m.setErrorMessage("Request already in progress")
+	}
+	if len(m.fileExplorer.GetTagFilter()) == 0 {
+		return m.setErrorMessage("Filter files by category (t) to choose the files to run")
+	}
+
+	var items []batchItem
+	for _, file := range m.fileExplorer.GetFilteredFiles() {
+		items = append(items, batchItemsForFile(file)...)
+	}
+	if len(items) == 0 {
+		return m.setErrorMessage("No requests in the filtered files")
+	}
+
+	m.batch = &batchRun{items: items}
+	m.batchResults = nil
+	m.batchCancelled = false
+	m.loading = true
+	m.errorMsg = ""
+	return m.runBatchStep()
+}
+
+// batchItemsForFile parses a file into batch items, marking requests that cannot run unattended
+func batchItemsForFile(file types.FileInfo) []batchItem {
+	if file.HTTPMethod == "WS" {
+		return []batchItem{{fileName: file.Name, problem: "WebSocket files are not run in batches"}}
+	}
+
+	requests, err := parser.Parse(file.Path)
+	if err != nil {
+		return []batchItem{{fileName: file.Name, problem: fmt.Sprintf("failed to parse file: %v", err)}}
+	}
+
+	items := make([]batchItem, 0, len(requests))
+	for i := range requests {
+		req := &requests[i]
+		item := batchItem{fileName: file.Name, request: req}
+		switch {
+		case req.RequiresConfirmation:
+			item.problem = "requires confirmation"
+		case chain.HasDependencies(req):
+			item.problem = "has dependencies"
+		case req.Streaming || req.IsNDJSONStream():
+			item.problem = "streaming request"
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// runBatchStep resolves the next queued request and returns the command sending it
+// Skipped and unparsable items complete immediately without a network call
+func (m *Model) runBatchStep() tea.Cmd {
+	item := m.batch.items[m.batch.index]
+	result := BatchResult{FileName: item.fileName}
+
+	m.statusMsg = fmt.Sprintf("Batch %d/%d: %s", m.batch.index+1, len(m.batch.items), item.fileName)
+
+	if item.request == nil {
+		result.Message = item.problem
+		return func() tea.Msg { return batchStepMsg{result: result} }
+	}
+
+	result.Request = item.request.Name
+	if result.Request == "" {
+		result.Request = item.request.Method + " " + item.request.URL
+	}
+	if item.problem != "" {
+		result.Skipped = true
+		result.Message = item.problem
+		return func() tea.Msg { return batchStepMsg{result: result} }
+	}
+
+	profile := m.sessionMgr.GetActiveProfile()
+	execution, err := m.resolveForExecution(item.request, profile, "")
+	if err != nil {
+		result.Message = fmt.Sprintf("failed to resolve variables: %v", err)
+		return func() tea.Msg { return batchStepMsg{result: result} }
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.requestState.SetCancel(cancel)
+	jar := m.cookieJarForProfile(profile)
+	request := item.request
+
+	return func() tea.Msg {
+		resolvedRequest, err := execution.runBeforeHooks(ctx)
+		if errors.Is(err, context.Canceled) {
+			return batchStepMsg{cancelled: true}
+		}
+		if err != nil {
+			result.Message = err.Error()
+			return batchStepMsg{result: result}
+		}
+
+		res, _, _, err := m.sendRequest(ctx, resolvedRequest, execution.tlsConfig, profile, jar, execution.reresolve)
+		if errors.Is(err, context.Canceled) {
+			return batchStepMsg{cancelled: true}
+		}
+		if err != nil {
+			result.Message = categorizeError(err)
+			return batchStepMsg{result: result}
+		}
+
+		result.Status = res.Status
+		result.DurationMs = res.Duration
+		result.Passed = request.IsExpectedStatus(res.Status)
+		if !result.Passed {
+			result.Message = fmt.Sprintf("unexpected status %d", res.Status)
+		}
+		if err := executor.RunAfterHooks(ctx, resolvedRequest, profile, res); err != nil {
+			result.Passed = false
+			result.Message = err.Error()
+		}
+		return batchStepMsg{result: result}
+	}
+}
+
+// handleBatchStep records a completed step and starts the next one, or shows the summary
+func (m *Model) handleBatchStep(msg batchStepMsg) tea.Cmd {
+	if m.batch == nil {
+		return nil
+	}
+
+	// ESC clears loading before the cancelled step reports back
+	if msg.cancelled || !m.loading {
+		m.batchCancelled = true
+		m.finishBatchRun()
+		return nil
+	}
+
+	m.batch.results = append(m.batch.results, msg.result)
+	m.batch.index++
+	if m.batch.index < len(m.batch.items) {
+		return m.runBatchStep()
+	}
+
+	m.finishBatchRun()
+	return nil
+}
+
+// finishBatchRun stops the batch and opens the summary modal
+func (m *Model) finishBatchRun() {
+	m.loading = false
+	m.requestState.Clear()
+	m.batchResults = m.batch.results
+	m.batch = nil
+
+	passed, failed, skipped := countBatchResults(m.batchResults)
+	m.statusMsg = fmt.Sprintf("Batch: %d passed, %d failed, %d skipped", passed, failed, skipped)
+	if m.batchCancelled {
+		m.statusMsg += " (cancelled)"
+	}
+
+	m.mode = ModeBatchResults
+	m.modalView.SetYOffset(0)
+	m.updateResponseView() // Remove loading indicator
+}
+
+// countBatchResults returns the number of passed, failed and skipped requests
+func countBatchResults(results []BatchResult) (passed, failed, skipped int) {
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			skipped++
+		case result.Passed:
+			passed++
+		default:
+			failed++
+		}
+	}
+	return passed, failed, skipped
+}
+
+// handleBatchResultsKeys handles keys in the batch summary modal
+func (m *Model) handleBatchResultsKeys(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() == "enter" {
+		m.mode = ModeNormal
+		return nil
+	}
+
+	action, ok := m.keybinds.Match(keybinds.ContextModal, msg.String())
+	if !ok {
+		return nil
+	}
+
+	switch action {
+	case keybinds.ActionCloseModal:
+		m.mode = ModeNormal
+
+	case keybinds.ActionNavigateDown:
+		m.modalView.LineDown(1)
+
+	case keybinds.ActionNavigateUp:
+		m.modalView.LineUp(1)
+
+	case keybinds.ActionGoToTop:
+		m.modalView.GotoTop()
+
+	case keybinds.ActionGoToBottom:
+		m.modalView.GotoBottom()
+	}
+
+	return nil
+}
+
+// renderBatchResultsModal renders the pass/fail summary with the failures listed first
+func (m *Model) renderBatchResultsModal() string {
+	passed, failed, skipped := countBatchResults(m.batchResults)
+
+	var content strings.Builder
+	summary := fmt.Sprintf("%d passed, %d failed", passed, failed)
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", skipped)
+	}
+	if failed > 0 {
+		content.WriteString(styleError.Render(summary))
+	} else {
+		content.WriteString(styleSuccess.Render(summary))
+	}
+	if m.batchCancelled {
+		content.WriteString(styleWarning.Render(fmt.Sprintf("  (cancelled after %d requests)", len(m.batchResults))))
+	}
+	content.WriteString("\n")
+
+	writeSection := func(title string, include func(BatchResult) bool) {
+		wrote := false
+		for _, result := range m.batchResults {
+			if !include(result) {
+				continue
+			}
+			if !wrote {
+				content.WriteString("\n" + styleTitle.Render(title) + "\n")
+				wrote = true
+			}
+			content.WriteString(formatBatchResult(result) + "\n")
+		}
+	}
+	writeSection("Failed", func(r BatchResult) bool { return !r.Passed && !r.Skipped })
+	writeSection("Skipped", func(r BatchResult) bool { return r.Skipped })
+	writeSection("Passed", func(r BatchResult) bool { return r.Passed })
+
+	width := m.width - ModalWidthMargin
+	height := m.height - ModalOverheadMinimal
+	if width < 50 {
+		width = 50
+	}
+	if height < 10 {
+		height = 10
+	}
+
+	return m.renderModalWithFooter("Batch Run", content.String(), "j/k: scroll | g/G: top/bottom | ESC: close", width, height)
+}
+
+// formatBatchResult renders one result line: status, file and request, duration or reason
+func formatBatchResult(result BatchResult) string {
+	status := "---"
+	if result.Status > 0 {
+		status = fmt.Sprintf("%d", result.Status)
+	}
+
+	name := result.FileName
+	if result.Request != "" {
+		name += " > " + result.Request
+	}
+
+	line := fmt.Sprintf("  %s  %s", status, name)
+	switch {
+	case result.Skipped:
+		return styleSubtle.Render(line + " (" + result.Message + ")")
+	case result.Passed:
+		return styleSuccess.Render(line) + styleSubtle.Render(fmt.Sprintf(" %dms", result.DurationMs))
+	default:
+		return styleError.Render(line + ": " + result.Message)
+	}
+}
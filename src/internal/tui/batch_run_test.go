@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// setupBatchFiles writes .http files against server and loads them with their categories
+func setupBatchFiles(t *testing.T, m *Model, serverURL string) {
+	t.Helper()
+	dir := t.TempDir()
+	files := []struct {
+		name    string
+		content string
+		tags    []string
+	}{
+		{"health.http", "### Health\nGET " + serverURL + "/ok\n\n### Broken\nGET " + serverURL + "/fail\n", []string{"smoke"}},
+		{"delete.http", "### Delete\n# @confirmation true\nDELETE " + serverURL + "/ok\n", []string{"smoke"}},
+		{"other.http", "### Other\nGET " + serverURL + "/fail\n", []string{"admin"}},
+	}
+
+	var infos []types.FileInfo
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if err := os.WriteFile(path, []byte(file.content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", file.name, err)
+		}
+		infos = append(infos, types.FileInfo{Name: file.name, Path: path, Tags: file.tags})
+	}
+	m.fileExplorer.SetFiles(infos, infos)
+	m.fileExplorer.SetTagFilter([]string{"smoke"})
+}
+
+// runBatchSteps executes the batch commands until the batch stops queueing steps
+func runBatchSteps(t *testing.T, m *Model, cmd tea.Cmd) {
+	t.Helper()
+	for steps := 0; cmd != nil; steps++ {
+		if steps > 10 {
+			t.Fatal("Batch did not finish")
+		}
+		msg, ok := cmd().(batchStepMsg)
+		if !ok {
+			t.Fatalf("Expected batchStepMsg, got %T", msg)
+		}
+		cmd = m.handleBatchStep(msg)
+	}
+}
+
+func TestBatchRun_FilteredFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	m := CreateTestModel(t)
+	setupBatchFiles(t, m, server.URL)
+
+	runBatchSteps(t, m, m.startBatchRun())
+
+	AssertModelField(t, "mode", m.mode, ModeBatchResults)
+	AssertModelField(t, "loading", m.loading, false)
+	AssertModelField(t, "result count", len(m.batchResults), 3)
+
+	passed, failed, skipped := countBatchResults(m.batchResults)
+	AssertModelField(t, "passed", passed, 1)
+	AssertModelField(t, "failed", failed, 1)
+	AssertModelField(t, "skipped", skipped, 1)
+
+	AssertModelField(t, "failed request", m.batchResults[1].Request, "Broken")
+	AssertModelField(t, "failed status", m.batchResults[1].Status, http.StatusInternalServerError)
+	AssertModelField(t, "skip reason", m.batchResults[2].Message, "requires confirmation")
+	if m.currentResponse != nil || len(m.responseTabs) != 0 {
+		t.Error("Expected the batch to leave the response view untouched")
+	}
+}
+
+func TestBatchRun_RequiresTagFilter(t *testing.T) {
+	m := CreateTestModel(t)
+	setupBatchFiles(t, m, "http://127.0.0.1:0")
+	m.fileExplorer.SetTagFilter(nil)
+
+	m.startBatchRun()
+
+	AssertModelField(t, "loading", m.loading, false)
+	if m.batch != nil {
+		t.Error("Expected no batch without a category filter")
+	}
+}
+
+func TestBatchRun_EscapeCancels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	m := CreateTestModel(t)
+	setupBatchFiles(t, m, server.URL)
+
+	cmd := m.startBatchRun()
+	m.handleEscapeKey()
+	runBatchSteps(t, m, cmd)
+
+	AssertModelField(t, "mode", m.mode, ModeBatchResults)
+	AssertModelField(t, "cancelled", m.batchCancelled, true)
+	AssertModelField(t, "result count", len(m.batchResults), 0)
+}
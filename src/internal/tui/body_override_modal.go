@@ -1,7 +1,6 @@
 package tui
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -14,64 +13,60 @@ func (m *Model) handleBodyOverrideKeys(msg tea.KeyMsg) tea.Cmd {
 	// Handle special keys not in registry (multiline editor with custom behavior)
 	switch msg.String() {
 	case "ctrl+s", "ctrl+enter":
+		// Malformed bodies stay in the editor with the cursor on the error
+		if syntaxErr := validateBody(m.bodyOverrideInput, bodyFormatFor(m.bodyOverrideType)); syntaxErr != nil {
+			m.bodyOverrideCursor = lineColumnOffset(m.bodyOverrideInput, syntaxErr.Line, syntaxErr.Column)
+			m.errorMsg = "Fix the syntax error before saving"
+			return nil
+		}
+		m.errorMsg = ""
+
+		// Editing a history replay or the request inline: the body goes into the draft
+		if draft, mode := m.editDraft(); draft != nil {
+			if draft.GraphQL != nil {
+				draft.GraphQL.Query = m.bodyOverrideInput
+			} else {
+				draft.Body = m.bodyOverrideInput
+			}
+			m.mode = mode
+			m.statusMsg = "Body updated"
+			return nil
+		}
+
 		// Save and return to normal mode
 		m.bodyOverride = m.bodyOverrideInput
 		m.mode = ModeNormal
 		m.statusMsg = "Body override applied (will be used for next request)"
 		return nil
 
-	case "up":
-		// Move cursor to previous line
-		lines := strings.Split(m.bodyOverrideInput[:m.bodyOverrideCursor], "\n")
-		if len(lines) > 1 {
-			currentLinePos := len(lines[len(lines)-1])
-			prevLineStart := m.bodyOverrideCursor - currentLinePos - 1
-			if prevLineStart >= 0 {
-				prevLines := strings.Split(m.bodyOverrideInput[:prevLineStart], "\n")
-				if len(prevLines) > 0 {
-					prevLine := prevLines[len(prevLines)-1]
-					newPos := prevLineStart - len(prevLine)
-					if currentLinePos <= len(prevLine) {
-						newPos += currentLinePos
-					} else {
-						newPos += len(prevLine)
-					}
-					if newPos >= 0 && newPos <= len(m.bodyOverrideInput) {
-						m.bodyOverrideCursor = newPos
-					}
-				}
-			}
+	case "ctrl+f":
+		// Prettify JSON bodies
+		if bodyFormatFor(m.bodyOverrideType) != bodyFormatJSON {
+			m.errorMsg = "Formatting is only available for JSON bodies"
+			return nil
+		}
+		if syntaxErr := validateBody(m.bodyOverrideInput, bodyFormatJSON); syntaxErr != nil {
+			m.bodyOverrideCursor = lineColumnOffset(m.bodyOverrideInput, syntaxErr.Line, syntaxErr.Column)
+			m.errorMsg = "Fix the syntax error before formatting"
+			return nil
 		}
+		formatted, err := prettifyBody(m.bodyOverrideInput)
+		if err != nil {
+			// Placeholders outside strings parse as values but not for re-indenting
+			m.errorMsg = fmt.Sprintf("Cannot format: %v", err)
+			return nil
+		}
+		m.bodyOverrideInput = formatted
+		m.bodyOverrideCursor = 0
+		m.errorMsg = ""
 		return nil
 
-	case "down":
-		// Move cursor to next line
-		remaining := m.bodyOverrideInput[m.bodyOverrideCursor:]
-		if idx := strings.Index(remaining, "\n"); idx != -1 {
-			lines := strings.Split(m.bodyOverrideInput[:m.bodyOverrideCursor], "\n")
-			currentLinePos := 0
-			if len(lines) > 0 {
-				currentLinePos = len(lines[len(lines)-1])
-			}
-
-			nextLineStart := m.bodyOverrideCursor + idx + 1
-			nextLineEnd := len(m.bodyOverrideInput)
-			if nextIdx := strings.Index(m.bodyOverrideInput[nextLineStart:], "\n"); nextIdx != -1 {
-				nextLineEnd = nextLineStart + nextIdx
-			}
-
-			nextLineLen := nextLineEnd - nextLineStart
-			newPos := nextLineStart
-			if currentLinePos <= nextLineLen {
-				newPos += currentLinePos
-			} else {
-				newPos += nextLineLen
-			}
+	case "up":
+		m.bodyOverrideCursor = moveCursorLine(m.bodyOverrideInput, m.bodyOverrideCursor, -1)
+		return nil
 
-			if newPos <= len(m.bodyOverrideInput) {
-				m.bodyOverrideCursor = newPos
-			}
-		}
+	case "down":
+		m.bodyOverrideCursor = moveCursorLine(m.bodyOverrideInput, m.bodyOverrideCursor, 1)
 		return nil
 
 	case "enter":
@@ -87,6 +82,9 @@ func (m *Model) handleBodyOverrideKeys(msg tea.KeyMsg) tea.Cmd {
 		return nil
 	}
 
+	// Clear error when user starts typing
+	m.errorMsg = ""
+
 	// Use registry for text input actions
 	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
 	if !ok {
@@ -101,7 +99,7 @@ func (m *Model) handleBodyOverrideKeys(msg tea.KeyMsg) tea.Cmd {
 	switch action {
 	case keybinds.ActionTextCancel:
 		// Cancel - discard changes
-		m.mode = ModeNormal
+		_, m.mode = m.editDraft()
 		m.bodyOverrideInput = ""
 		m.bodyOverrideCursor = 0
 		m.statusMsg = "Body override cancelled"
@@ -172,14 +170,15 @@ func (m *Model) renderBodyOverrideModal() string {
 
 	content.WriteString("Edit Request Body (one-time override)\n\n")
 
-	// Show validation status for JSON
+	// Validate against the request's Content-Type
+	format := bodyFormatFor(m.bodyOverrideType)
+	syntaxErr := validateBody(m.bodyOverrideInput, format)
 	var validationMsg string
-	if m.bodyOverrideInput != "" {
-		var jsonData interface{}
-		if err := json.Unmarshal([]byte(m.bodyOverrideInput), &jsonData); err == nil {
-			validationMsg = "Valid JSON"
+	if format != "" && strings.TrimSpace(m.bodyOverrideInput) != "" {
+		if syntaxErr == nil {
+			validationMsg = styleSuccess.Render(fmt.Sprintf("Valid %s", strings.ToUpper(format)))
 		} else {
-			validationMsg = fmt.Sprintf("JSON Error: %s", err.Error())
+			validationMsg = styleError.Render(fmt.Sprintf("%s error at %s", strings.ToUpper(format), syntaxErr.Error()))
 		}
 	}
 
@@ -235,7 +234,22 @@ func (m *Model) renderBodyOverrideModal() string {
 			line = line[:displayWidth-3] + "..."
 		}
 
-		content.WriteString(fmt.Sprintf("%3d │ %s\n", i+1, line))
+		gutter := fmt.Sprintf("%3d │", i+1)
+		if syntaxErr != nil && i == syntaxErr.Line-1 {
+			gutter = styleError.Render(gutter)
+		}
+		content.WriteString(fmt.Sprintf("%s %s\n", gutter, line))
+
+		// Point at the error column, shifted by the cursor when it sits before it
+		if syntaxErr != nil && i == syntaxErr.Line-1 && syntaxErr.Column > 0 {
+			caret := syntaxErr.Column - 1
+			if i == cursorLine && cursorCol <= caret {
+				caret++
+			}
+			if caret < displayWidth {
+				content.WriteString(fmt.Sprintf("    │ %s%s\n", strings.Repeat(" ", caret), styleError.Render("^")))
+			}
+		}
 	}
 
 	if len(lines) > displayLines {
@@ -245,7 +259,40 @@ func (m *Model) renderBodyOverrideModal() string {
 	if validationMsg != "" {
 		content.WriteString("\n\n" + validationMsg)
 	}
+	if m.errorMsg != "" {
+		content.WriteString("\n" + styleError.Render(m.errorMsg))
+	}
 
 	footer := "[Ctrl+S/Ctrl+Enter] save • [ESC] cancel"
+	if format == bodyFormatJSON {
+		footer = "[Ctrl+S/Ctrl+Enter] save • [Ctrl+F] format • [ESC] cancel"
+	}
 	return m.renderModalWithFooter("Body Override", content.String(), footer, 80, 25)
 }
+
+// moveCursorLine moves a cursor in multi-line text to the line above (delta -1) or below (delta 1)
+// The column is kept when the target line is long enough, otherwise the cursor goes to its end.
+func moveCursorLine(text string, cursor, delta int) int {
+	lineStart := strings.LastIndex(text[:cursor], "\n") + 1
+	column := cursor - lineStart
+
+	var targetStart int
+	if delta < 0 {
+		if lineStart == 0 {
+			return cursor
+		}
+		targetStart = strings.LastIndex(text[:lineStart-1], "\n") + 1
+	} else {
+		next := strings.Index(text[cursor:], "\n")
+		if next == -1 {
+			return cursor
+		}
+		targetStart = cursor + next + 1
+	}
+
+	targetEnd := len(text)
+	if end := strings.Index(text[targetStart:], "\n"); end != -1 {
+		targetEnd = targetStart + end
+	}
+	return targetStart + min(column, targetEnd-targetStart)
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Body formats the override editor validates
+const (
+	bodyFormatJSON = "json"
+	bodyFormatYAML = "yaml"
+)
+
+// templateVarPattern matches {{name}} placeholders, resolved only when the request is sent
+var templateVarPattern = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+
+// yamlLinePattern extracts the line number from a yaml.v3 error message
+var yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// bodySyntaxError locates a syntax error in an edited body
+type bodySyntaxError struct {
+	Line    int // 1-based
+	Column  int // 1-based, 0 = unknown
+	Message string
+}
+
+func (e *bodySyntaxError) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// requestContentType returns the Content-Type header of a request, matched case-insensitively
+func requestContentType(headers map[string]string) string {
+	for name, value := range headers {
+		if strings.EqualFold(name, "Content-Type") {
+			return value
+		}
+	}
+	return ""
+}
+
+// bodyFormatFor returns the format to validate for a content type, empty when it is not checked
+func bodyFormatFor(contentType string) string {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return bodyFormatJSON
+	case strings.HasSuffix(mediaType, "/yaml") || strings.HasSuffix(mediaType, "/x-yaml") || strings.HasSuffix(mediaType, "+yaml"):
+		return bodyFormatYAML
+	}
+	return ""
+}
+
+// maskTemplateVars replaces {{name}} placeholders with a same-length JSON number
+// Offsets stay unchanged so errors point at the edited text.
+func maskTemplateVars(body string) string {
+	return templateVarPattern.ReplaceAllStringFunc(body, func(match string) string {
+		return "0" + strings.Repeat(" ", len(match)-1)
+	})
+}
+
+// validateBody checks body against format, returning nil when it parses or is not checked
+// An empty body is always valid.
+func validateBody(body, format string) *bodySyntaxError {
+	if strings.TrimSpace(body) == "" {
+		return nil
+	}
+	masked := maskTemplateVars(body)
+
+	switch format {
+	case bodyFormatJSON:
+		var data interface{}
+		err := json.Unmarshal([]byte(masked), &data)
+		if err == nil {
+			return nil
+		}
+		offset := int64(len(masked))
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			offset = syntaxErr.Offset
+		}
+		line, column := offsetPosition(body, int(offset))
+		return &bodySyntaxError{Line: line, Column: column, Message: strings.TrimPrefix(err.Error(), "json: ")}
+
+	case bodyFormatYAML:
+		var data interface{}
+		err := yaml.Unmarshal([]byte(masked), &data)
+		if err == nil {
+			return nil
+		}
+		message := strings.TrimPrefix(err.Error(), "yaml: ")
+		line := 1
+		if match := yamlLinePattern.FindStringSubmatch(message); match != nil {
+			line, _ = strconv.Atoi(match[1])
+			message = strings.TrimPrefix(message, match[0]+": ")
+		}
+		return &bodySyntaxError{Line: line, Message: message}
+	}
+	return nil
+}
+
+// offsetPosition converts a byte offset into a 1-based line and column
+// JSON syntax error offsets point just past the offending character.
+func offsetPosition(body string, offset int) (int, int) {
+	offset = max(0, min(offset, len(body)))
+	before := body[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := offset - strings.LastIndex(before, "\n")
+	if offset > 0 && before[len(before)-1] != '\n' {
+		column--
+	}
+	return line, max(column, 1)
+}
+
+// lineColumnOffset converts a 1-based line and column into a byte offset, clamped to the body
+func lineColumnOffset(body string, line, column int) int {
+	offset := 0
+	for i := 1; i < line; i++ {
+		next := strings.Index(body[offset:], "\n")
+		if next == -1 {
+			return len(body)
+		}
+		offset += next + 1
+	}
+	if column > 1 {
+		offset += column - 1
+	}
+	return min(offset, len(body))
+}
+
+// prettifyBody re-indents a JSON body with two spaces
+func prettifyBody(body string) (string, error) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(strings.TrimSpace(body)), "", "  "); err != nil {
+		return body, err
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBodyFormatFor(t *testing.T) {
+	tests := map[string]string{
+		"application/json":                bodyFormatJSON,
+		"application/json; charset=utf-8": bodyFormatJSON,
+		"application/problem+json":        bodyFormatJSON,
+		"application/yaml":                bodyFormatYAML,
+		"text/x-yaml":                     bodyFormatYAML,
+		"text/plain":                      "",
+		"":                                "",
+	}
+	for contentType, expected := range tests {
+		if got := bodyFormatFor(contentType); got != expected {
+			t.Errorf("bodyFormatFor(%q) = %q, expected %q", contentType, got, expected)
+		}
+	}
+}
+
+func TestValidateBody(t *testing.T) {
+	if err := validateBody(`{"id": 1}`, bodyFormatJSON); err != nil {
+		t.Errorf("Expected valid JSON, got: %v", err)
+	}
+	if err := validateBody(`{"id": {{userId}}, "name": "{{name}}"}`, bodyFormatJSON); err != nil {
+		t.Errorf("Expected placeholders to be accepted, got: %v", err)
+	}
+	if err := validateBody("not json", ""); err != nil {
+		t.Errorf("Expected unchecked formats to pass, got: %v", err)
+	}
+
+	err := validateBody("{\n  \"a\": 1,\n  \"b\": 2,\n}", bodyFormatJSON)
+	if err == nil {
+		t.Fatal("Expected a JSON syntax error")
+	}
+	if err.Line != 4 || err.Column != 1 {
+		t.Errorf("Expected the error at line 4, column 1, got line %d, column %d", err.Line, err.Column)
+	}
+
+	err = validateBody("name: a\n  bad: [", bodyFormatYAML)
+	if err == nil {
+		t.Fatal("Expected a YAML syntax error")
+	}
+	if err.Line != 2 {
+		t.Errorf("Expected the error at line 2, got %d", err.Line)
+	}
+}
+
+func TestBodyOverride_BlocksInvalidJSON(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	m.mode = ModeBodyOverride
+	m.bodyOverrideType = "application/json"
+	m.bodyOverrideInput = "{\n  \"a\": 1,\n}"
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+
+	AssertModelField(t, "mode", m.mode, ModeBodyOverride)
+	AssertModelField(t, "bodyOverride", m.bodyOverride, "")
+	AssertModelField(t, "cursor on the error", m.bodyOverrideCursor, strings.Index(m.bodyOverrideInput, "}"))
+	if !strings.Contains(m.renderBodyOverrideModal(), "JSON error at line 3") {
+		t.Error("Expected the error location in the editor")
+	}
+
+	// Fix the body, format it and save
+	m.bodyOverrideInput = `{"a":1}`
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	AssertModelField(t, "formatted body", m.bodyOverrideInput, "{\n  \"a\": 1\n}")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	AssertModelField(t, "mode", m.mode, ModeNormal)
+	AssertModelField(t, "bodyOverride", m.bodyOverride, "{\n  \"a\": 1\n}")
+}
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/executor"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// cookieJarForProfile returns the cookie jar for a profile, creating it on first use
+// Returns nil when cookies are disabled so the executor skips cookie handling entirely
+func (m *Model) cookieJarForProfile(profile *types.Profile) http.CookieJar {
+	if profile == nil || profile.CookiesEnabled == nil || !*profile.CookiesEnabled {
+		return nil
+	}
+
+	jar, exists := m.cookieJars[profile.Name]
+	if !exists {
+		newJar, err := executor.NewCookieJar()
+		if err != nil {
+			return nil
+		}
+		jar = newJar
+		m.cookieJars[profile.Name] = jar
+	}
+	return jar
+}
+
+// cookiesContent builds the cookie viewer content for the active profile
+func (m *Model) cookiesContent() string {
+	profile := m.sessionMgr.GetActiveProfile()
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("Profile: %s\n\n", profile.Name))
+
+	if profile.CookiesEnabled == nil || !*profile.CookiesEnabled {
+		content.WriteString(styleSubtle.Render("Cookies are disabled for this profile.\nSet \"cookiesEnabled\": true in .profiles.json to keep cookies across requests."))
+		return content.String()
+	}
+
+	var entries []executor.CookieEntry
+	if jar, exists := m.cookieJars[profile.Name]; exists {
+		entries = jar.Entries()
+	}
+
+	if len(entries) == 0 {
+		content.WriteString(styleSubtle.Render("No cookies stored"))
+	}
+
+	currentOrigin := ""
+	for _, entry := range entries {
+		if entry.Origin != currentOrigin {
+			if currentOrigin != "" {
+				content.WriteString("\n")
+			}
+			content.WriteString(styleTitle.Render(entry.Origin) + "\n")
+			currentOrigin = entry.Origin
+		}
+		content.WriteString(fmt.Sprintf("  %s = %s\n", entry.Name, entry.Value))
+	}
+
+	return content.String()
+}
+
+// renderCookiesModal renders the cookie jar viewer
+func (m *Model) renderCookiesModal() string {
+	width := m.width - ModalWidthMargin
+	height := m.height - ModalOverheadMinimal
+	if width < 50 {
+		width = 50
+	}
+	if height < 10 {
+		height = 10
+	}
+
+	return m.renderModalWithFooter("Cookies", m.cookiesContent(), "j/k: scroll | C: clear cookies | ESC: close", width, height)
+}
+
+// handleCookiesKeys handles keyboard input in the cookie viewer
+func (m *Model) handleCookiesKeys(msg tea.KeyMsg) tea.Cmd {
+	// Handle clear specially (not in registry)
+	if msg.String() == "C" {
+		profile := m.sessionMgr.GetActiveProfile()
+		if jar, exists := m.cookieJars[profile.Name]; exists {
+			jar.Clear()
+		}
+		m.statusMsg = fmt.Sprintf("Cookies cleared for profile: %s", profile.Name)
+		return nil
+	}
+
+	action, ok := m.keybinds.Match(keybinds.ContextModal, msg.String())
+	if !ok {
+		return nil
+	}
+
+	switch action {
+	case keybinds.ActionCloseModal:
+		m.mode = ModeNormal
+
+	case keybinds.ActionNavigateDown:
+		m.modalView.LineDown(1)
+
+	case keybinds.ActionNavigateUp:
+		m.modalView.LineUp(1)
+
+	case keybinds.ActionPageDown:
+		m.modalView.PageDown()
+
+	case keybinds.ActionPageUp:
+		m.modalView.PageUp()
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/executor"
+	"github.com/studiowebux/restcli/internal/keybinds"
+)
+
+// copyFormat is an entry of the "copy as" menu
+type copyFormat int
+
+const (
+	copyRawBody copyFormat = iota
+	copyPrettyJSON
+	copyCurl
+	copyHTTPie
+	copyHeaders
+	copyFullJSON
+)
+
+// copyFormats lists the menu entries in display order
+var copyFormats = []copyFormat{copyRawBody, copyPrettyJSON, copyCurl, copyHTTPie, copyHeaders, copyFullJSON}
+
+// String returns the name shown in the menu and in the confirmation message
+func (f copyFormat) String() string {
+	switch f {
+	case copyRawBody:
+		return "raw body"
+	case copyPrettyJSON:
+		return "pretty JSON"
+	case copyCurl:
+		return "cURL"
+	case copyHTTPie:
+		return "HTTPie"
+	case copyHeaders:
+		return "response headers"
+	case copyFullJSON:
+		return "request + response JSON"
+	}
+	return "unknown"
+}
+
+// copyAsContent formats the current request or response
+// Returns the text and, for the command formats, the variables left unresolved
+func (m *Model) copyAsContent(format copyFormat) (string, []string, error) {
+	switch format {
+	case copyCurl, copyHTTPie:
+		resolvedRequest, tlsConfig, unresolved, err := m.exportableRequest(format.String())
+		if err != nil {
+			return "", nil, err
+		}
+		if format == copyCurl {
+			return executor.ToCurl(resolvedRequest, tlsConfig), unresolved, nil
+		}
+		return executor.ToHTTPie(resolvedRequest, tlsConfig), unresolved, nil
+	}
+
+	if m.currentResponse == nil {
+		return "", nil, fmt.Errorf("No response to copy")
+	}
+	body := m.currentResponse.Body
+	if m.filterActive && m.filteredResponse != "" {
+		body = m.filteredResponse
+	}
+
+	switch format {
+	case copyRawBody:
+		return body, nil, nil
+
+	case copyPrettyJSON:
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, []byte(strings.TrimSpace(body)), "", "  "); err != nil {
+			return "", nil, fmt.Errorf("Response body is not JSON")
+		}
+		return pretty.String(), nil, nil
+
+	case copyHeaders:
+		if len(m.currentResponse.Headers) == 0 {
+			return "", nil, fmt.Errorf("Response has no headers")
+		}
+		names := make([]string, 0, len(m.currentResponse.Headers))
+		for name := range m.currentResponse.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var headers strings.Builder
+		for _, name := range names {
+			headers.WriteString(fmt.Sprintf("%s: %s\n", name, m.currentResponse.Headers[name]))
+		}
+		return headers.String(), nil, nil
+
+	case copyFullJSON:
+		data, err := m.responseDocument()
+		if err != nil {
+			return "", nil, fmt.Errorf("Failed to marshal response: %v", err)
+		}
+		return string(data), nil, nil
+	}
+	return "", nil, fmt.Errorf("Unknown format")
+}
+
+// copyAs formats the selection and writes it to the clipboard
+func (m *Model) copyAs(format copyFormat) tea.Cmd {
+	content, unresolved, err := m.copyAsContent(format)
+	if err != nil {
+		return m.setErrorMessage(err.Error())
+	}
+
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(content); err != nil {
+			return errorMsg(fmt.Sprintf("Failed to copy to clipboard: %v", err))
+		}
+		m.errorMsg = ""
+		if len(unresolved) > 0 {
+			return m.setStatusMessage(fmt.Sprintf("Copied as %s (unresolved: %s)", format, strings.Join(unresolved, ", ")))
+		}
+		return m.setStatusMessage(fmt.Sprintf("Copied as %s", format))
+	}
+}
+
+// handleCopyAsKeys handles keyboard input in the "copy as" menu
+func (m *Model) handleCopyAsKeys(msg tea.KeyMsg) tea.Cmd {
+	// Quick select and enter before registry (special keys)
+	switch msg.String() {
+	case "1", "2", "3", "4", "5", "6":
+		m.copyAsIndex = int(msg.String()[0] - '1')
+		fallthrough
+	case "enter":
+		m.mode = ModeNormal
+		return m.copyAs(copyFormats[m.copyAsIndex])
+	}
+
+	action, ok := m.keybinds.Match(keybinds.ContextModal, msg.String())
+	if !ok {
+		return nil
+	}
+
+	switch action {
+	case keybinds.ActionCloseModal:
+		m.mode = ModeNormal
+
+	case keybinds.ActionNavigateDown:
+		m.copyAsIndex = (m.copyAsIndex + 1) % len(copyFormats)
+
+	case keybinds.ActionNavigateUp:
+		m.copyAsIndex = (m.copyAsIndex - 1 + len(copyFormats)) % len(copyFormats)
+	}
+
+	return nil
+}
+
+// renderCopyAsModal renders the "copy as" menu
+func (m *Model) renderCopyAsModal() string {
+	var content strings.Builder
+	for i, format := range copyFormats {
+		line := fmt.Sprintf("%d. %s", i+1, format)
+		if i == m.copyAsIndex {
+			content.WriteString(styleSelected.Render(line) + "\n")
+		} else {
+			content.WriteString("  " + line + "\n")
+		}
+	}
+
+	footer := "[↑/↓ j/k] navigate [1-6] quick select [enter] copy [esc] close"
+	return m.renderModalWithFooter("Copy As", content.String(), footer, 70, 14)
+}
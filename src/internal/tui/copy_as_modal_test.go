@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestCopyAsContent(t *testing.T) {
+	m := CreateTestModel(t)
+	m.sessionMgr.GetSession().Variables["host"] = "api.example.com"
+	m.currentRequest = &types.HttpRequest{Method: "GET", URL: "https://{{host}}/users", Headers: map[string]string{"Accept": "application/json"}}
+	m.currentResponse = &types.RequestResult{
+		Status:  200,
+		Body:    `{"b":1,"a":[2]}`,
+		Headers: map[string]string{"X-Request-Id": "42", "Content-Type": "application/json"},
+	}
+
+	tests := []struct {
+		format copyFormat
+		want   string
+	}{
+		{copyRawBody, `{"b":1,"a":[2]}`},
+		{copyPrettyJSON, "{\n  \"b\": 1,\n  \"a\": [\n    2\n  ]\n}"},
+		{copyCurl, "curl -H 'Accept: application/json' https://api.example.com/users"},
+		{copyHTTPie, "http GET https://api.example.com/users Accept:application/json"},
+		{copyHeaders, "Content-Type: application/json\nX-Request-Id: 42\n"},
+	}
+	for _, tt := range tests {
+		got, _, err := m.copyAsContent(tt.format)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.format, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.format, tt.want, got)
+		}
+	}
+
+	full, _, err := m.copyAsContent(copyFullJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(full, `"url": "https://api.example.com/users"`) || !strings.Contains(full, `"X-Request-Id": "42"`) {
+		t.Errorf("Expected the resolved request and the response in:\n%s", full)
+	}
+}
+
+func TestCopyAsContent_Errors(t *testing.T) {
+	m := CreateTestModel(t)
+	if _, _, err := m.copyAsContent(copyRawBody); err == nil {
+		t.Error("Expected an error without a response")
+	}
+	if _, _, err := m.copyAsContent(copyHTTPie); err == nil {
+		t.Error("Expected an error without a request")
+	}
+
+	m.currentResponse = &types.RequestResult{Status: 200, Body: "<html></html>"}
+	if _, _, err := m.copyAsContent(copyPrettyJSON); err == nil || err.Error() != "Response body is not JSON" {
+		t.Errorf("Expected a not JSON error, got %v", err)
+	}
+
+	m.currentRequest = &types.HttpRequest{Method: "GET", URL: "https://{{missing}}/"}
+	_, unresolved, err := m.copyAsContent(copyHTTPie)
+	if err != nil || len(unresolved) != 1 || unresolved[0] != "missing" {
+		t.Errorf("Expected the unresolved variable to be reported, got %v %v", unresolved, err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/types"
 )
 
 // handleDiffKeys handles keyboard input in diff mode
@@ -23,6 +24,13 @@ func (m *Model) handleDiffKeys(msg tea.KeyMsg) tea.Cmd {
 		return nil
 	}
 
+	// Toggle between textual and semantic (JSON path) comparison
+	if msg.String() == "s" {
+		m.diffSemantic = !m.diffSemantic
+		m.updateDiffView()
+		return nil
+	}
+
 	// Use registry for all other keys including close/navigation
 	action, ok, partial := m.keybinds.MatchMultiKey(keybinds.ContextModal, msg.String())
 	if partial {
@@ -35,6 +43,13 @@ func (m *Model) handleDiffKeys(msg tea.KeyMsg) tea.Cmd {
 
 	switch action {
 	case keybinds.ActionCloseModal:
+		if m.historyDiff != nil {
+			// Back to the history list the comparison was opened from
+			m.historyDiff = nil
+			m.mode = ModeHistory
+			m.updateHistoryView()
+			return nil
+		}
 		m.mode = ModeNormal
 
 	case keybinds.ActionNavigateDown:
@@ -111,8 +126,33 @@ func (m *Model) handleDiffKeys(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+// diffSide is one of the two responses being compared
+type diffSide struct {
+	label    string // Pane title, e.g. PINNED
+	name     string // Request name shown in the header
+	response *types.RequestResult
+}
+
+// diffSides returns the responses to compare: two history entries, or the pinned and current responses
+func (m *Model) diffSides() (diffSide, diffSide) {
+	if m.historyDiff != nil {
+		return m.historyDiff[0], m.historyDiff[1]
+	}
+
+	pinned := diffSide{label: "PINNED", name: "Pinned", response: m.pinnedResponse}
+	if m.pinnedRequest != nil && m.pinnedRequest.Name != "" {
+		pinned.name = m.pinnedRequest.Name
+	}
+	current := diffSide{label: "CURRENT", name: "Current", response: m.currentResponse}
+	if m.currentRequest != nil && m.currentRequest.Name != "" {
+		current.name = m.currentRequest.Name
+	}
+	return pinned, current
+}
+
 // renderDiffSplitView renders the split pane view
 func (m *Model) renderDiffSplitView(modalWidth, modalHeight int) string {
+	pinned, current := m.diffSides()
 	// Calculate pane dimensions
 	paneWidth := (modalWidth - 3) / 2 // 50/50 split with separator
 	paneHeight := modalHeight - 6     // Account for metadata header and padding
@@ -127,7 +167,7 @@ func (m *Model) renderDiffSplitView(modalWidth, modalHeight int) string {
 		Width(paneWidth).
 		Height(paneHeight).
 		Padding(0, 1).
-		Render(styleTitle.Render("PINNED") + "\n" + m.diffLeftView.View())
+		Render(styleTitle.Render(pinned.label) + "\n" + m.diffLeftView.View())
 
 	// Create right pane (current)
 	rightPane := lipgloss.NewStyle().
@@ -136,7 +176,7 @@ func (m *Model) renderDiffSplitView(modalWidth, modalHeight int) string {
 		Width(paneWidth).
 		Height(paneHeight).
 		Padding(0, 1).
-		Render(styleTitle.Render("CURRENT") + "\n" + m.diffRightView.View())
+		Render(styleTitle.Render(current.label) + "\n" + m.diffRightView.View())
 
 	// Join panes horizontally
 	splitPanes := lipgloss.JoinHorizontal(
@@ -151,7 +191,7 @@ func (m *Model) renderDiffSplitView(modalWidth, modalHeight int) string {
 		metadata,
 		"\n",
 		splitPanes,
-		"\n"+styleSubtle.Render("Tab: Toggle View | ↑/↓ j/k: Scroll | gg/G: Top/Bottom | ESC/W: Close"),
+		"\n"+styleSubtle.Render("Tab: Toggle View | s: Text/Semantic | ↑/↓ j/k: Scroll | gg/G: Top/Bottom | ESC/W: Close"),
 	)
 }
 
@@ -169,7 +209,7 @@ func (m *Model) renderDiffModal() string {
 	} else {
 		// Unified diff view (default)
 		// Footer outside viewport
-		footer := styleSubtle.Render("Tab: Toggle View | ↑/↓ j/k: Scroll | gg/G: Top/Bottom | ESC/W: Close")
+		footer := styleSubtle.Render("Tab: Toggle View | s: Text/Semantic | ↑/↓ j/k: Scroll | gg/G: Top/Bottom | ESC/W: Close")
 
 		// Viewport with content (footer is separate)
 		diffView := lipgloss.NewStyle().
@@ -178,7 +218,7 @@ func (m *Model) renderDiffModal() string {
 			Width(modalWidth).
 			Height(modalHeight-2). // Reduce height to account for footer
 			Padding(1, 2).
-			Render(styleTitle.Render("Response Comparison") + "\n\n" + m.diffView.View())
+			Render(styleTitle.Render(m.diffTitle()) + "\n\n" + m.diffView.View())
 
 		// Combine viewport and footer
 		content = lipgloss.JoinVertical(
@@ -197,12 +237,21 @@ func (m *Model) renderDiffModal() string {
 	)
 }
 
+// diffTitle names the modal after the active comparison mode
+func (m *Model) diffTitle() string {
+	if m.diffSemantic {
+		return "Response Comparison (semantic)"
+	}
+	return "Response Comparison"
+}
+
 // updateDiffView generates the diff content
 func (m *Model) updateDiffView() {
 	// Initialize view mode if not set
 	if m.diffViewMode == "" {
 		m.diffViewMode = "unified"
 	}
+	pinned, current := m.diffSides()
 
 	if m.diffViewMode == "split" {
 		// Split view mode - populate left and right viewports
@@ -218,9 +267,14 @@ func (m *Model) updateDiffView() {
 		m.diffRightView.Height = paneHeight - ViewportPaddingVertical
 
 		// Generate diff-styled content with background highlighting
+		// Semantic mode sorts JSON keys first so reordered keys do not show up as changes
+		pinnedBody, currentBody := pinned.response.Body, current.response.Body
+		if m.diffSemantic {
+			pinnedBody, currentBody = normalizeJSONBody(pinnedBody), normalizeJSONBody(currentBody)
+		}
 		leftContent, rightContent := compareTextSplitView(
-			m.pinnedResponse.Body,
-			m.currentResponse.Body,
+			pinnedBody,
+			currentBody,
 			paneWidth-6,
 		)
 
@@ -240,24 +294,15 @@ func (m *Model) updateDiffView() {
 		var content strings.Builder
 
 		// Header
-		pinnedName := "Pinned"
-		if m.pinnedRequest != nil && m.pinnedRequest.Name != "" {
-			pinnedName = m.pinnedRequest.Name
-		}
-		currentName := "Current"
-		if m.currentRequest != nil && m.currentRequest.Name != "" {
-			currentName = m.currentRequest.Name
-		}
-
-		content.WriteString(styleSuccess.Render(fmt.Sprintf("PINNED: %s", pinnedName)))
+		content.WriteString(styleSuccess.Render(fmt.Sprintf("%s: %s", pinned.label, pinned.name)))
 		content.WriteString(" vs ")
-		content.WriteString(styleWarning.Render(fmt.Sprintf("CURRENT: %s", currentName)))
+		content.WriteString(styleWarning.Render(fmt.Sprintf("%s: %s", current.label, current.name)))
 		content.WriteString("\n\n")
 
 		// Status comparison
 		content.WriteString(styleTitle.Render("Status:") + "\n")
-		pinnedStatus := fmt.Sprintf("%d %s", m.pinnedResponse.Status, m.pinnedResponse.StatusText)
-		currentStatus := fmt.Sprintf("%d %s", m.currentResponse.Status, m.currentResponse.StatusText)
+		pinnedStatus := fmt.Sprintf("%d %s", pinned.response.Status, pinned.response.StatusText)
+		currentStatus := fmt.Sprintf("%d %s", current.response.Status, current.response.StatusText)
 
 		if pinnedStatus == currentStatus {
 			content.WriteString(fmt.Sprintf("  %s\n", pinnedStatus))
@@ -269,17 +314,17 @@ func (m *Model) updateDiffView() {
 
 		// Duration comparison
 		content.WriteString(styleTitle.Render("Duration:") + "\n")
-		if m.pinnedResponse.Duration == m.currentResponse.Duration {
-			content.WriteString(fmt.Sprintf("  %dms\n", m.pinnedResponse.Duration))
+		if pinned.response.Duration == current.response.Duration {
+			content.WriteString(fmt.Sprintf("  %dms\n", pinned.response.Duration))
 		} else {
-			content.WriteString(fmt.Sprintf("  - %s\n", styleError.Render(fmt.Sprintf("%dms", m.pinnedResponse.Duration))))
-			content.WriteString(fmt.Sprintf("  + %s\n", styleSuccess.Render(fmt.Sprintf("%dms", m.currentResponse.Duration))))
+			content.WriteString(fmt.Sprintf("  - %s\n", styleError.Render(fmt.Sprintf("%dms", pinned.response.Duration))))
+			content.WriteString(fmt.Sprintf("  + %s\n", styleSuccess.Render(fmt.Sprintf("%dms", current.response.Duration))))
 		}
 		content.WriteString("\n")
 
 		// Headers comparison
 		content.WriteString(styleTitle.Render("Headers:") + "\n")
-		headerDiff := compareHeaders(m.pinnedResponse.Headers, m.currentResponse.Headers)
+		headerDiff := compareHeaders(pinned.response.Headers, current.response.Headers)
 		if headerDiff == "" {
 			content.WriteString("  (no differences)\n")
 		} else {
@@ -289,8 +334,7 @@ func (m *Model) updateDiffView() {
 
 		// Body comparison
 		content.WriteString(styleTitle.Render("Response Body:") + "\n")
-		bodyDiff := compareTextLineByLine(m.pinnedResponse.Body, m.currentResponse.Body)
-		content.WriteString(bodyDiff)
+		content.WriteString(m.compareBodies())
 
 		// Footer is now outside viewport, don't add it to content
 
@@ -299,28 +343,53 @@ func (m *Model) updateDiffView() {
 	}
 }
 
+// compareBodies generates the unified body diff, by JSON path in semantic mode
+// Falls back to the line diff when either body is not JSON
+func (m *Model) compareBodies() string {
+	pinned, current := m.diffSides()
+	if !m.diffSemantic {
+		return compareTextLineByLine(pinned.response.Body, current.response.Body)
+	}
+
+	changes, ok := diffJSON(pinned.response.Body, current.response.Body)
+	if !ok {
+		return styleSubtle.Render("  (not JSON, showing line diff)") + "\n" +
+			compareTextLineByLine(pinned.response.Body, current.response.Body)
+	}
+	if len(changes) == 0 {
+		return "  (no differences)\n"
+	}
+
+	var result strings.Builder
+	for _, change := range changes {
+		style := styleWarning
+		switch change.Kind {
+		case "+":
+			style = styleSuccess
+		case "-":
+			style = styleError
+		}
+		for _, line := range strings.Split(wrapText(change.String(), 120), "\n") {
+			result.WriteString("  " + style.Render(line) + "\n")
+		}
+	}
+	return result.String()
+}
+
 // renderDiffMetadata generates a summary header for split view showing status, duration, and headers
 func (m *Model) renderDiffMetadata() string {
+	pinned, current := m.diffSides()
 	var content strings.Builder
 
 	// Header names
-	pinnedName := "Pinned"
-	if m.pinnedRequest != nil && m.pinnedRequest.Name != "" {
-		pinnedName = m.pinnedRequest.Name
-	}
-	currentName := "Current"
-	if m.currentRequest != nil && m.currentRequest.Name != "" {
-		currentName = m.currentRequest.Name
-	}
-
-	content.WriteString(styleSuccess.Render(fmt.Sprintf("PINNED: %s", pinnedName)))
+	content.WriteString(styleSuccess.Render(fmt.Sprintf("%s: %s", pinned.label, pinned.name)))
 	content.WriteString(" vs ")
-	content.WriteString(styleWarning.Render(fmt.Sprintf("CURRENT: %s", currentName)))
+	content.WriteString(styleWarning.Render(fmt.Sprintf("%s: %s", current.label, current.name)))
 	content.WriteString("\n\n")
 
 	// Status comparison
-	pinnedStatus := fmt.Sprintf("%d %s", m.pinnedResponse.Status, m.pinnedResponse.StatusText)
-	currentStatus := fmt.Sprintf("%d %s", m.currentResponse.Status, m.currentResponse.StatusText)
+	pinnedStatus := fmt.Sprintf("%d %s", pinned.response.Status, pinned.response.StatusText)
+	currentStatus := fmt.Sprintf("%d %s", current.response.Status, current.response.StatusText)
 
 	statusLabel := "Status: "
 	if pinnedStatus == currentStatus {
@@ -331,14 +400,14 @@ func (m *Model) renderDiffMetadata() string {
 
 	// Duration comparison
 	content.WriteString("  |  Duration: ")
-	if m.pinnedResponse.Duration == m.currentResponse.Duration {
-		content.WriteString(fmt.Sprintf("%dms", m.pinnedResponse.Duration))
+	if pinned.response.Duration == current.response.Duration {
+		content.WriteString(fmt.Sprintf("%dms", pinned.response.Duration))
 	} else {
-		content.WriteString(styleError.Render(fmt.Sprintf("%dms", m.pinnedResponse.Duration)) + " → " + styleSuccess.Render(fmt.Sprintf("%dms", m.currentResponse.Duration)))
+		content.WriteString(styleError.Render(fmt.Sprintf("%dms", pinned.response.Duration)) + " → " + styleSuccess.Render(fmt.Sprintf("%dms", current.response.Duration)))
 	}
 
 	// Header differences count
-	headerDiff := compareHeaders(m.pinnedResponse.Headers, m.currentResponse.Headers)
+	headerDiff := compareHeaders(pinned.response.Headers, current.response.Headers)
 	if headerDiff == "" {
 		content.WriteString("  |  Headers: ✓")
 	} else {
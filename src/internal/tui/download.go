@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/chain"
+	"github.com/studiowebux/restcli/internal/executor"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// defaultDownloadName is the suggested file name when the URL has no usable last segment
+const defaultDownloadName = "download.bin"
+
+// downloadTickMsg refreshes the progress of a running download
+type downloadTickMsg struct{}
+
+// downloadState tracks a running download, updated from the request goroutine
+type downloadState struct {
+	path    string
+	written atomic.Int64
+	total   atomic.Int64 // -1 = unknown
+}
+
+// progress renders the bytes written so far, with a percentage when the size is known
+func (d *downloadState) progress() string {
+	written, total := d.written.Load(), d.total.Load()
+	if total > 0 {
+		return fmt.Sprintf("Downloading to %s: %s / %s (%d%%)", d.path,
+			executor.FormatSize(int(written)), executor.FormatSize(int(total)), written*100/total)
+	}
+	return fmt.Sprintf("Downloading to %s: %s", d.path, executor.FormatSize(int(written)))
+}
+
+// suggestDownloadName returns the last path segment of a request URL, or defaultDownloadName
+func suggestDownloadName(rawURL string) string {
+	if strings.Contains(rawURL, "{{") {
+		// Unresolved variables make the segment meaningless
+		rawURL = rawURL[:strings.Index(rawURL, "{{")]
+	}
+	if parsed, err := url.Parse(rawURL); err == nil {
+		rawURL = parsed.Path
+	}
+	name := path.Base(rawURL)
+	if name == "" || name == "." || name == "/" {
+		return defaultDownloadName
+	}
+	return name
+}
+
+// openDownloadPrompt asks for the file that receives the body of the current request
+func (m *Model) openDownloadPrompt() tea.Cmd {
+	if m.currentRequest == nil {
+		return m.setErrorMessage("No request selected")
+	}
+	if m.loading {
+		return m.setErrorMessage("Request already in progress")
+	}
+	if chain.HasDependencies(m.currentRequest) {
+		return m.setErrorMessage("Requests with dependencies cannot be downloaded")
+	}
+	if m.currentRequest.IsGRPC() || m.currentRequest.IsGraphQL() {
+		return m.setErrorMessage("Only HTTP requests can be downloaded")
+	}
+
+	m.downloadInput = suggestDownloadName(m.currentRequest.URL)
+	m.downloadCursor = len(m.downloadInput)
+	m.errorMsg = ""
+	m.mode = ModeDownloadPrompt
+	return nil
+}
+
+// handleDownloadPromptKeys handles the download file prompt
+func (m *Model) handleDownloadPromptKeys(msg tea.KeyMsg) tea.Cmd {
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.mode = ModeNormal
+			m.errorMsg = ""
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			return m.startDownload()
+		}
+	}
+
+	// Clear error when user starts typing
+	m.errorMsg = ""
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.downloadInput, &m.downloadCursor, msg); shouldContinue {
+		return nil
+	}
+	if len(msg.String()) == 1 {
+		m.downloadInput = m.downloadInput[:m.downloadCursor] + msg.String() + m.downloadInput[m.downloadCursor:]
+		m.downloadCursor++
+	}
+	return nil
+}
+
+// startDownload executes the current request with its body written to the entered file
+func (m *Model) startDownload() tea.Cmd {
+	target := strings.TrimSpace(m.downloadInput)
+	if target == "" {
+		m.errorMsg = "File name cannot be empty"
+		return nil
+	}
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		m.errorMsg = fmt.Sprintf("%s is a directory", target)
+		return nil
+	}
+
+	m.mode = ModeNormal
+	m.downloadInput = ""
+	m.downloadPath = target
+	return m.executeRequest()
+}
+
+// executeDownload executes a resolved request, streaming its body to target instead of keeping it in memory
+// Only the metadata reaches the response view.
+func (m *Model) executeDownload(target string, execution *resolvedExecution, profile *types.Profile) tea.Cmd {
+	file, err := os.Create(target)
+	if err != nil {
+		m.loading = false
+		m.updateResponseView()
+		return func() tea.Msg {
+			return errorMsg(fmt.Sprintf("Failed to create %s: %v", target, err))
+		}
+	}
+
+	state := &downloadState{path: target}
+	state.total.Store(-1)
+	m.download = state
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.requestState.SetCancel(cancel)
+	ctx = executor.WithBodyWriter(ctx, file, func(written, total int64) {
+		state.written.Store(written)
+		state.total.Store(total)
+	})
+	jar := m.cookieJarForProfile(profile)
+
+	return tea.Batch(m.tickDownload(), func() tea.Msg {
+		resolvedRequest, err := execution.runBeforeHooks(ctx)
+		if err != nil {
+			// Nothing was written yet
+			file.Close()
+			os.Remove(target)
+			if errors.Is(err, context.Canceled) {
+				return errorMsg("Download cancelled by user")
+			}
+			return errorMsg(err.Error())
+		}
+		// Read after the hooks, which resolve the request again
+		warnings, shellErrs := execution.warnings, execution.shellErrs
+
+		result, sentRequest, oauthNotice, err := m.sendRequest(ctx, resolvedRequest, execution.tlsConfig, profile, jar, execution.reresolve)
+		if errors.Is(err, context.Canceled) {
+			// The executor may still be writing, leave the file to it
+			return errorMsg(fmt.Sprintf("Download cancelled by user, %s is incomplete", target))
+		}
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+		if err != nil {
+			return errorMsg(categorizeError(err))
+		}
+		result.DownloadPath = target
+
+		// A failing @after hook is reported with the shell errors, like regular requests
+		if err := executor.RunAfterHooks(ctx, sentRequest, profile, result); err != nil {
+			shellErrs = append(shellErrs, err.Error())
+		}
+
+		// Save to history (without the body, which is in the file)
+		shouldSaveHistory := m.sessionMgr.IsHistoryEnabled()
+		if profile != nil && profile.HistoryEnabled != nil {
+			shouldSaveHistory = *profile.HistoryEnabled
+		}
+		if shouldSaveHistory && m.historyManager != nil {
+			if currentFile := m.fileExplorer.GetCurrentFile(); currentFile != nil {
+				_ = m.historyManager.Save(currentFile.Path, profile.Name, sentRequest, result)
+			}
+		}
+
+		return requestExecutedMsg{result: result, warnings: warnings, shellErrors: shellErrs, oauthNotice: oauthNotice}
+	})
+}
+
+// tickDownload returns a command that will send downloadTickMsg after a short delay
+func (m *Model) tickDownload() tea.Cmd {
+	return tea.Tick(250*time.Millisecond, func(time.Time) tea.Msg {
+		return downloadTickMsg{}
+	})
+}
+
+// renderDownloadPromptModal renders the download file prompt
+func (m *Model) renderDownloadPromptModal() string {
+	inputWithCursor := m.downloadInput[:m.downloadCursor] + "█" + m.downloadInput[m.downloadCursor:]
+	content := fmt.Sprintf("File: %s", inputWithCursor)
+
+	if m.errorMsg != "" {
+		content += "\n\n" + styleError.Render(wrapText(m.errorMsg, 64))
+	}
+	content += "\n\n" + wrapText("The request is executed and its body is written to this file as it arrives, without being rendered. An existing file is overwritten. Enter to download, ESC to cancel", 64)
+
+	return m.renderModal("Download Response", content, 70, 14)
+}
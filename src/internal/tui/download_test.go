@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestSuggestDownloadName(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/files/report.pdf?v=2": "report.pdf",
+		"https://example.com/":                     defaultDownloadName,
+		"https://example.com":                      defaultDownloadName,
+		"{{baseUrl}}/export":                       defaultDownloadName,
+		"https://example.com/archive.zip/{{id}}":   "archive.zip",
+	}
+	for rawURL, expected := range tests {
+		if got := suggestDownloadName(rawURL); got != expected {
+			t.Errorf("suggestDownloadName(%q) = %q, expected %q", rawURL, got, expected)
+		}
+	}
+}
+
+func TestDownload_WritesBodyToFile(t *testing.T) {
+	body := strings.Repeat("0123456789", 10000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	m := CreateTestModel(t)
+	m.currentRequest = &types.HttpRequest{Name: "Export", Method: "GET", URL: server.URL + "/export.bin"}
+
+	m.openDownloadPrompt()
+	AssertModelField(t, "mode", m.mode, ModeDownloadPrompt)
+	AssertModelField(t, "suggested name", m.downloadInput, "export.bin")
+
+	target := filepath.Join(t.TempDir(), "export.bin")
+	m.downloadInput = target
+	m.downloadCursor = len(target)
+
+	batch, ok := m.handleDownloadPromptKeys(tea.KeyMsg{Type: tea.KeyEnter})().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected the download and its progress ticker")
+	}
+	if m.download == nil {
+		t.Fatal("Expected a running download")
+	}
+
+	var executed *requestExecutedMsg
+	for _, cmd := range batch {
+		if msg, ok := cmd().(requestExecutedMsg); ok {
+			executed = &msg
+		}
+	}
+	if executed == nil {
+		t.Fatal("Expected the request to complete")
+	}
+	m.Update(*executed)
+
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("Expected the downloaded file, got: %v", err)
+	}
+	if string(data) != body {
+		t.Errorf("Expected %d bytes in the file, got %d", len(body), len(data))
+	}
+	AssertModelField(t, "response body", m.currentResponse.Body, "")
+	AssertModelField(t, "download path", m.currentResponse.DownloadPath, target)
+	AssertModelField(t, "download path consumed", m.downloadPath, "")
+	if m.download != nil {
+		t.Error("Expected the download state to be cleared")
+	}
+	if !strings.Contains(m.responseContent, "Downloaded 97.66KB to "+target) {
+		t.Errorf("Expected the download summary in the response view, got:\n%s", m.responseContent)
+	}
+}
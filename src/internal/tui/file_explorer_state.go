@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
@@ -13,9 +15,13 @@ type FileExplorerState struct {
 	mu sync.RWMutex
 
 	// File lists
-	files    []types.FileInfo // Filtered/displayed file list
+	files    []types.FileInfo // Filtered/displayed file list (with folder entries in tree view)
 	allFiles []types.FileInfo // Unfiltered file list for tag filtering
 
+	// Tree view: one directory at a time, subdirectories listed as folder entries
+	treeView   bool
+	currentDir string // Directory shown in tree view, relative to the workdir ("" for the root)
+
 	// Navigation
 	fileIndex  int // Current selected file index
 	fileOffset int // Scroll offset for file list
@@ -41,6 +47,7 @@ func NewFileExplorerState() *FileExplorerState {
 		searchMatches: []int{},
 		searchIndex:   0,
 		tagFilter:     []string{},
+		treeView:      true,
 	}
 }
 
@@ -59,6 +66,9 @@ func (f *FileExplorerState) SetFiles(files, allFiles []types.FileInfo) {
 	defer f.mu.Unlock()
 	f.files = files
 	f.allFiles = allFiles
+	if f.treeView {
+		f.refreshViewLocked()
+	}
 
 	// Reset navigation if current index is out of bounds
 	if f.fileIndex >= len(f.files) {
@@ -67,6 +77,185 @@ func (f *FileExplorerState) SetFiles(files, allFiles []types.FileInfo) {
 	}
 }
 
+// GetFilteredFiles returns the files matching the tag filter across all directories, without folder entries
+func (f *FileExplorerState) GetFilteredFiles() []types.FileInfo {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.filteredFilesLocked()
+}
+
+// filteredFilesLocked returns the files matching the tag filter (must be called with lock held)
+func (f *FileExplorerState) filteredFilesLocked() []types.FileInfo {
+	if len(f.tagFilter) == 0 {
+		files := make([]types.FileInfo, len(f.allFiles))
+		copy(files, f.allFiles)
+		return files
+	}
+	var files []types.FileInfo
+	for _, file := range f.allFiles {
+		if hasAnyTag(file.Tags, f.tagFilter) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// refreshViewLocked rebuilds the displayed list of the tree view (must be called with lock held)
+// Falls back to the root when the current directory no longer holds any file.
+func (f *FileExplorerState) refreshViewLocked() {
+	filtered := f.filteredFilesLocked()
+	f.files = directoryEntries(filtered, f.currentDir)
+	if len(f.files) == 0 && f.currentDir != "" {
+		f.currentDir = ""
+		f.files = directoryEntries(filtered, "")
+	}
+	f.searchQuery = ""
+	f.searchMatches = nil
+	f.searchIndex = 0
+}
+
+// directoryEntries lists the subdirectories of dir holding files (as folder entries, first) and the files directly in dir
+func directoryEntries(files []types.FileInfo, dir string) []types.FileInfo {
+	prefix := ""
+	if dir != "" {
+		prefix = dir + string(filepath.Separator)
+	}
+
+	var dirs, entries []types.FileInfo
+	seen := make(map[string]bool)
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name, prefix) {
+			continue
+		}
+		rest := file.Name[len(prefix):]
+		sep := strings.IndexRune(rest, filepath.Separator)
+		if sep < 0 {
+			entries = append(entries, file)
+			continue
+		}
+		name := prefix + rest[:sep]
+		if !seen[name] {
+			seen[name] = true
+			dirs = append(dirs, types.FileInfo{
+				Name:  name,
+				Path:  strings.TrimSuffix(file.Path, rest[sep:]),
+				IsDir: true,
+			})
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i].Name < dirs[j].Name })
+	return append(dirs, entries...)
+}
+
+// IsTreeView reports whether the explorer shows one directory at a time
+func (f *FileExplorerState) IsTreeView() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.treeView
+}
+
+// SetTreeView switches between the tree view and the flat list of every file
+// The selected file stays selected when it is visible in the new view.
+func (f *FileExplorerState) SetTreeView(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var selected string
+	if f.fileIndex >= 0 && f.fileIndex < len(f.files) && !f.files[f.fileIndex].IsDir {
+		selected = f.files[f.fileIndex].Path
+	}
+
+	f.treeView = enabled
+	if enabled {
+		if selected != "" {
+			for _, file := range f.allFiles {
+				if file.Path == selected {
+					f.currentDir = parentDir(file.Name)
+				}
+			}
+		}
+		f.refreshViewLocked()
+	} else {
+		f.files = f.filteredFilesLocked()
+		f.searchQuery = ""
+		f.searchMatches = nil
+		f.searchIndex = 0
+	}
+
+	f.fileIndex = 0
+	f.fileOffset = 0
+	for i, file := range f.files {
+		if file.Path == selected {
+			f.fileIndex = i
+		}
+	}
+}
+
+// GetCurrentDir returns the directory shown in tree view, relative to the workdir ("" for the root)
+func (f *FileExplorerState) GetCurrentDir() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.currentDir
+}
+
+// EnterDirectory descends into the selected folder entry
+// Returns false when the selection is not a folder.
+func (f *FileExplorerState) EnterDirectory() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.treeView || f.fileIndex < 0 || f.fileIndex >= len(f.files) || !f.files[f.fileIndex].IsDir {
+		return false
+	}
+	f.currentDir = f.files[f.fileIndex].Name
+	f.refreshViewLocked()
+	f.fileIndex = 0
+	f.fileOffset = 0
+	return true
+}
+
+// ParentDirectory goes up one directory, selecting the folder that was left
+// Returns false at the root.
+func (f *FileExplorerState) ParentDirectory(pageSize int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.treeView || f.currentDir == "" {
+		return false
+	}
+	left := f.currentDir
+	f.currentDir = parentDir(left)
+	f.refreshViewLocked()
+
+	f.fileIndex = 0
+	for i, file := range f.files {
+		if file.IsDir && file.Name == left {
+			f.fileIndex = i
+		}
+	}
+	f.fileOffset = 0
+	f.adjustScrollOffsetLocked(pageSize)
+	return true
+}
+
+// parentDir returns the directory of a relative path, "" for the root
+func parentDir(name string) string {
+	dir := filepath.Dir(name)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// GetAllFiles returns a copy of the unfiltered file list
+func (f *FileExplorerState) GetAllFiles() []types.FileInfo {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	files := make([]types.FileInfo, len(f.allFiles))
+	copy(files, f.allFiles)
+	return files
+}
+
 // GetCurrentIndex returns the current file index
 func (f *FileExplorerState) GetCurrentIndex() int {
 	f.mu.RLock()
@@ -74,12 +263,12 @@ func (f *FileExplorerState) GetCurrentIndex() int {
 	return f.fileIndex
 }
 
-// GetCurrentFile returns the currently selected file (or nil if none)
+// GetCurrentFile returns the currently selected file (or nil if none or a folder is selected)
 func (f *FileExplorerState) GetCurrentFile() *types.FileInfo {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
-	if len(f.files) == 0 || f.fileIndex < 0 || f.fileIndex >= len(f.files) {
+	if len(f.files) == 0 || f.fileIndex < 0 || f.fileIndex >= len(f.files) || f.files[f.fileIndex].IsDir {
 		return nil
 	}
 
@@ -163,13 +352,32 @@ func (f *FileExplorerState) NavigateToFile(filePath string, pageSize int) bool {
 
 	// Find the file in the current list
 	for i, file := range f.files {
-		if file.Path == filePath {
+		if file.Path == filePath && !file.IsDir {
 			f.fileIndex = i
 			f.adjustScrollOffsetLocked(pageSize)
 			return true
 		}
 	}
 
+	// In tree view the file may be in another directory
+	if f.treeView {
+		for _, file := range f.filteredFilesLocked() {
+			if file.Path != filePath {
+				continue
+			}
+			f.currentDir = parentDir(file.Name)
+			f.refreshViewLocked()
+			for i, entry := range f.files {
+				if entry.Path == filePath && !entry.IsDir {
+					f.fileIndex = i
+					f.fileOffset = 0
+					f.adjustScrollOffsetLocked(pageSize)
+					return true
+				}
+			}
+		}
+	}
+
 	return false
 }
 
@@ -298,7 +506,9 @@ func (f *FileExplorerState) SetTagFilter(tags []string) {
 
 	f.tagFilter = tags
 
-	if len(tags) == 0 {
+	if f.treeView {
+		f.refreshViewLocked()
+	} else if len(tags) == 0 {
 		// No filter - show all files
 		f.files = f.allFiles
 	} else {
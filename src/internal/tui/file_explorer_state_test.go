@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"strings"
 	"sync"
 	"testing"
 
@@ -470,3 +471,87 @@ func TestFileExplorerState_NavigateToFile(t *testing.T) {
 	found = state.NavigateToFile("/test/a.http", 10)
 	AssertModelField(t, "empty list not found", found, false)
 }
+
+// treeFiles is a workdir with files at the root and in nested directories
+func treeFiles() []types.FileInfo {
+	return []types.FileInfo{
+		{Name: "health.http", Path: "/w/health.http"},
+		{Name: "orders/list.http", Path: "/w/orders/list.http", Tags: []string{"orders"}},
+		{Name: "users/admin/get_user.http", Path: "/w/users/admin/get_user.http"},
+		{Name: "users/create.http", Path: "/w/users/create.http", Tags: []string{"users"}},
+	}
+}
+
+func TestFileExplorerState_TreeView(t *testing.T) {
+	state := NewFileExplorerState()
+	files := treeFiles()
+	state.SetFiles(files, files)
+
+	names := func() []string {
+		var names []string
+		for _, file := range state.GetFiles() {
+			names = append(names, file.Name)
+		}
+		return names
+	}
+
+	// Folders first, then the files of the directory
+	AssertModelField(t, "root", strings.Join(names(), ","), "orders,users,health.http")
+	if state.GetCurrentFile() != nil {
+		t.Error("Expected no current file on a folder entry")
+	}
+
+	state.Navigate(1, 10)
+	AssertModelField(t, "enter users", state.EnterDirectory(), true)
+	AssertModelField(t, "current dir", state.GetCurrentDir(), "users")
+	AssertModelField(t, "users", strings.Join(names(), ","), "users/admin,users/create.http")
+	AssertModelField(t, "folder path", state.GetFiles()[0].Path, "/w/users/admin")
+
+	state.EnterDirectory()
+	AssertModelField(t, "admin", strings.Join(names(), ","), "users/admin/get_user.http")
+	AssertModelField(t, "enter a file", state.EnterDirectory(), false)
+
+	// Going up selects the folder that was left
+	AssertModelField(t, "up", state.ParentDirectory(10), true)
+	AssertModelField(t, "selected admin", state.GetFiles()[state.GetCurrentIndex()].Name, "users/admin")
+	state.ParentDirectory(10)
+	AssertModelField(t, "selected users", state.GetCurrentIndex(), 1)
+	AssertModelField(t, "up at root", state.ParentDirectory(10), false)
+
+	// Navigating to a file opens its directory
+	AssertModelField(t, "navigate", state.NavigateToFile("/w/users/admin/get_user.http", 10), true)
+	AssertModelField(t, "navigated dir", state.GetCurrentDir(), "users/admin")
+	AssertModelField(t, "current file", state.GetCurrentFile().Name, "users/admin/get_user.http")
+}
+
+func TestFileExplorerState_TreeViewTagFilter(t *testing.T) {
+	state := NewFileExplorerState()
+	files := treeFiles()
+	state.SetFiles(files, files)
+
+	// Only folders holding matching files are listed
+	state.SetTagFilter([]string{"users"})
+	if got := state.GetFiles(); len(got) != 1 || got[0].Name != "users" {
+		t.Errorf("Expected only the users folder, got %+v", got)
+	}
+	if got := state.GetFilteredFiles(); len(got) != 1 || got[0].Name != "users/create.http" {
+		t.Errorf("Expected the filtered files across folders, got %+v", got)
+	}
+}
+
+func TestFileExplorerState_FlatView(t *testing.T) {
+	state := NewFileExplorerState()
+	files := treeFiles()
+	state.SetFiles(files, files)
+	state.NavigateToFile("/w/users/create.http", 10)
+
+	// The selected file stays selected in the flat list
+	state.SetTreeView(false)
+	AssertModelField(t, "flat files", len(state.GetFiles()), 4)
+	AssertModelField(t, "selection kept", state.GetCurrentFile().Name, "users/create.http")
+	AssertModelField(t, "no folders in flat view", state.EnterDirectory(), false)
+
+	state.SetTreeView(true)
+	AssertModelField(t, "back in its directory", state.GetCurrentDir(), "users")
+	AssertModelField(t, "selection kept in tree", state.GetCurrentFile().Name, "users/create.http")
+}
@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// fileFinderMatches returns the files of the workdir whose relative path fuzzy-matches the query, best first
+// The whole tree is searched, ignoring the tag filter of the explorer.
+func (m *Model) fileFinderMatches() []types.FileInfo {
+	files := m.fileExplorer.GetAllFiles()
+	if m.fileFinderQuery == "" {
+		return files
+	}
+
+	type match struct {
+		file  types.FileInfo
+		score int
+	}
+	var matches []match
+	for _, file := range files {
+		if score, ok := fuzzyScore(m.fileFinderQuery, file.Name); ok {
+			matches = append(matches, match{file, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	result := make([]types.FileInfo, len(matches))
+	for i, match := range matches {
+		result[i] = match.file
+	}
+	return result
+}
+
+// firstPathSegment returns the first segment of a request URL path, e.g. "/users" for "{{baseUrl}}/users/{{id}}"
+func firstPathSegment(url string) string {
+	// Drop a leading variable holding the base URL, then the scheme and host
+	if strings.HasPrefix(url, "{{") {
+		if end := strings.Index(url, "}}"); end >= 0 {
+			url = url[end+2:]
+		}
+	}
+	if _, rest, found := strings.Cut(url, "://"); found {
+		url = ""
+		if slash := strings.Index(rest, "/"); slash >= 0 {
+			url = rest[slash:]
+		}
+	}
+
+	path := strings.TrimPrefix(url, "/")
+	if end := strings.IndexAny(path, "/?#"); end >= 0 {
+		path = path[:end]
+	}
+	return "/" + path
+}
+
+// openFileFinder opens the fuzzy file finder
+func (m *Model) openFileFinder() {
+	m.fileFinderQuery = ""
+	m.fileFinderCursor = 0
+	m.fileFinderIndex = 0
+	m.errorMsg = ""
+	m.mode = ModeFileFinder
+}
+
+// openFinderFile selects the highlighted file in the explorer and loads its requests
+func (m *Model) openFinderFile() tea.Cmd {
+	matches := m.fileFinderMatches()
+	if m.fileFinderIndex >= len(matches) {
+		return nil
+	}
+	file := matches[m.fileFinderIndex]
+
+	pageSize := m.getFileListHeight()
+	status := fmt.Sprintf("Opened: %s", file.Name)
+	if !m.fileExplorer.NavigateToFile(file.Path, pageSize) {
+		// Hidden by the tag filter: show every file again
+		m.fileExplorer.SetTagFilter(nil)
+		if !m.fileExplorer.NavigateToFile(file.Path, pageSize) {
+			m.errorMsg = fmt.Sprintf("File not in current directory: %s", filepath.Base(file.Path))
+			return nil
+		}
+		status += " (tag filter cleared)"
+	}
+	m.loadRequestsFromCurrentFile()
+
+	m.mode = ModeNormal
+	m.errorMsg = ""
+	return m.setStatusMessage(status)
+}
+
+// handleFileFinderKeys handles keyboard input in the file finder
+func (m *Model) handleFileFinderKeys(msg tea.KeyMsg) tea.Cmd {
+	// Navigation keys (not in registry, letters go to the query)
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if m.fileFinderIndex > 0 {
+			m.fileFinderIndex--
+		}
+		return nil
+	case "down", "ctrl+n":
+		if m.fileFinderIndex < len(m.fileFinderMatches())-1 {
+			m.fileFinderIndex++
+		}
+		return nil
+	}
+
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.mode = ModeNormal
+			m.errorMsg = ""
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			return m.openFinderFile()
+		}
+	}
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.fileFinderQuery, &m.fileFinderCursor, msg); shouldContinue {
+		m.fileFinderIndex = 0
+		return nil
+	}
+	if len(msg.String()) == 1 {
+		m.fileFinderQuery = m.fileFinderQuery[:m.fileFinderCursor] + msg.String() + m.fileFinderQuery[m.fileFinderCursor:]
+		m.fileFinderCursor++
+		m.fileFinderIndex = 0
+	}
+	return nil
+}
+
+// renderFileFinder renders the file finder
+func (m *Model) renderFileFinder() string {
+	width := m.width - ModalWidthMargin
+	if width > 120 {
+		width = 120
+	}
+	if width < 50 {
+		width = 50
+	}
+	height := m.height - ModalOverheadMinimal
+	if height < 12 {
+		height = 12
+	}
+
+	var content strings.Builder
+	content.WriteString("> " + m.fileFinderQuery[:m.fileFinderCursor] + "█" + m.fileFinderQuery[m.fileFinderCursor:] + "\n\n")
+
+	matches := m.fileFinderMatches()
+	if len(matches) == 0 {
+		content.WriteString(styleSubtle.Render("No matching file"))
+	}
+	nameWidth := width - 24
+	for i, file := range matches {
+		// Keep the end of long paths, where the file name is
+		name := file.Name
+		if runes := []rune(name); len(runes) > nameWidth {
+			name = "..." + string(runes[len(runes)-nameWidth+3:])
+		}
+		info := file.HTTPMethod
+		if file.URL != "" {
+			info += " " + firstPathSegment(file.URL)
+		}
+
+		if i == m.fileFinderIndex {
+			content.WriteString(styleSelected.Render(fmt.Sprintf("%-*s  %s", nameWidth, name, info)) + "\n")
+		} else {
+			content.WriteString(fmt.Sprintf("  %-*s  %s\n", nameWidth, name, styleSubtle.Render(info)))
+		}
+	}
+
+	if m.errorMsg != "" {
+		content.WriteString("\n" + styleError.Render(m.errorMsg))
+	}
+
+	title := fmt.Sprintf("Find File (%d/%d)", len(matches), len(m.fileExplorer.GetAllFiles()))
+	footer := "[type] filter [↑/↓] navigate [Enter] open [ESC] close"
+	return m.renderModalWithFooterAndScroll(title, content.String(), footer, width, height, m.fileFinderIndex+2)
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestFileFinder_OpensNestedFile(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+
+	dir := t.TempDir()
+	var infos []types.FileInfo
+	for _, name := range []string{"health.http", "orders/list.http", "users/admin/get_user.http", "users/create.http"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("### Request\nGET https://api.example.com/"+name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		infos = append(infos, types.FileInfo{Name: name, Path: path, HTTPMethod: "GET", Tags: []string{strings.Split(name, "/")[0]}})
+	}
+	m.fileExplorer.SetFiles(infos, infos)
+	// The file is hidden by the tag filter, the finder still finds it
+	m.fileExplorer.SetTagFilter([]string{"orders"})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	AssertModelField(t, "mode", m.mode, ModeFileFinder)
+	typeText(m, "usget")
+
+	matches := m.fileFinderMatches()
+	if len(matches) != 1 || matches[0].Name != "users/admin/get_user.http" {
+		t.Fatalf("Expected only the nested file to match, got %+v", matches)
+	}
+	if !strings.Contains(m.renderFileFinder(), "Find File (1/4)") {
+		t.Error("Expected the match count in the title")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "mode", m.mode, ModeNormal)
+	AssertModelField(t, "current file", m.fileExplorer.GetCurrentFile().Name, "users/admin/get_user.http")
+	AssertModelField(t, "status", m.statusMsg, "Opened: users/admin/get_user.http (tag filter cleared)")
+	if m.currentRequest == nil || !strings.HasSuffix(m.currentRequest.URL, "get_user.http") {
+		t.Errorf("Expected the requests of the file to be loaded, got %+v", m.currentRequest)
+	}
+}
+
+func TestFirstPathSegment(t *testing.T) {
+	for url, want := range map[string]string{
+		"{{baseUrl}}/users/{{id}}":           "/users",
+		"https://api.example.com/v1/orders":  "/v1",
+		"https://api.example.com":            "/",
+		"/health?verbose=true":               "/health",
+		"ws://localhost:8080/chat":           "/chat",
+		"{{host}}":                           "/",
+		"http://{{host}}:{{port}}/items#top": "/items",
+	} {
+		if got := firstPathSegment(url); got != want {
+			t.Errorf("%q: expected %q, got %q", url, want, got)
+		}
+	}
+}
@@ -107,7 +107,7 @@ func (m *Model) handleFilterKeys(msg tea.KeyMsg) tea.Cmd {
 		m.filterError = ""
 
 		// Apply the filter/query
-		result, err := filter.Apply(m.currentResponse.Body, "", m.filterInput)
+		result, err := filter.Apply(m.responseBodyText(), "", m.filterInput)
 		if err != nil {
 			// Keep modal open and show error
 			m.filterError = fmt.Sprintf("Failed to apply filter: %s", err.Error())
@@ -169,13 +169,16 @@ func (m *Model) handleFilterKeys(msg tea.KeyMsg) tea.Cmd {
 func (m *Model) renderFilterModal() string {
 	var content strings.Builder
 
-	content.WriteString("Filter Response with JMESPath\n\n")
+	content.WriteString("Filter Response with JMESPath, jq or XPath\n\n")
 	content.WriteString("Enter a JMESPath expression to filter/query the response.\n")
 	content.WriteString("Examples:\n")
 	content.WriteString("  items[?price > `100`]     - Filter items by condition\n")
 	content.WriteString("  [].name                    - Extract all names\n")
 	content.WriteString("  length(items)              - Count items\n")
-	content.WriteString("  $(jq '.items[]')           - Use shell command (jq)\n\n")
+	content.WriteString("  jq:.items[] | .name        - Use jq syntax\n")
+	content.WriteString("  xpath://user/@id           - Use XPath on XML responses\n")
+	content.WriteString("  $(sort | uniq)             - Use shell command\n")
+	content.WriteString("  items | $(jq 'length')     - Chain stages with |\n\n")
 
 	// Show input with cursor
 	inputWithCursor := m.filterInput[:m.filterCursor] + "█" + m.filterInput[m.filterCursor:]
@@ -191,8 +194,14 @@ func (m *Model) renderFilterModal() string {
 
 	// Validation feedback
 	if m.filterInput != "" && m.filterError == "" {
-		if filter.IsShellCommand(m.filterInput) {
+		if stages := filter.SplitPipeline(m.filterInput); len(stages) > 1 {
+			content.WriteString(fmt.Sprintf("\nPipeline with %d stages (checked on apply)", len(stages)))
+		} else if filter.IsShellCommand(m.filterInput) {
 			content.WriteString("\nShell command detected")
+		} else if filter.IsJq(m.filterInput) {
+			content.WriteString("\njq expression (checked on apply)")
+		} else if filter.IsXPath(m.filterInput) {
+			content.WriteString("\nXPath expression (checked on apply)")
 		} else if filter.IsValidJMESPath(m.filterInput) {
 			content.WriteString("\nValid JMESPath expression")
 		} else {
@@ -201,5 +210,5 @@ func (m *Model) renderFilterModal() string {
 	}
 
 	footer := "[Enter] apply • [Ctrl+S] save • [↑] history • [ESC] cancel"
-	return m.renderModalWithFooter("Response Filter", content.String(), footer, 75, 18)
+	return m.renderModalWithFooter("Response Filter", content.String(), footer, 75, 21)
 }
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/parser"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestExecuteForEachStep_IterationCap(t *testing.T) {
+	two, zero, negative := 2, 0, -1
+	tests := []struct {
+		name     string
+		max      *int
+		expected int32
+	}{
+		{"default", nil, 3},
+		{"capped", &two, 2},
+		{"zero uses the default", &zero, 3},
+		{"negative uses the default", &negative, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+			}))
+			defer server.Close()
+
+			m := CreateTestModel(t)
+			profile := &types.Profile{Name: "Default", MaxForEachIterations: tt.max}
+			resolver := parser.NewVariableResolver(nil, nil, map[string]string{"ids": `["a", "b", "c"]`}, nil)
+			req := &types.HttpRequest{Method: "GET", URL: server.URL + "/items/{{item}}", ForEach: "{{ids}}"}
+
+			if _, err := m.executeForEachStep(context.Background(), "items.http", req, resolver, profile, nil, "Step 1"); err != nil {
+				t.Fatalf("executeForEachStep failed: %v", err)
+			}
+			AssertModelField(t, "requests sent", hits.Load(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+)
+
+// fuzzyScore matches query against target as a case-insensitive subsequence
+// ok is false when a character of the query is missing from target. Higher scores rank first:
+// consecutive characters and characters at the start of a word earn a bonus, longer targets a small penalty.
+func fuzzyScore(query, target string) (score int, ok bool) {
+	queryRunes := []rune(strings.ToLower(query))
+	if len(queryRunes) == 0 {
+		return 0, true
+	}
+	targetRunes := []rune(target)
+
+	qi := 0
+	last := -2
+	for ti, r := range targetRunes {
+		if qi == len(queryRunes) {
+			break
+		}
+		if unicode.ToLower(r) != queryRunes[qi] {
+			continue
+		}
+
+		score++
+		if ti == last+1 {
+			score += 5
+		}
+		if ti == 0 || isWordStart(targetRunes[ti-1], r) {
+			score += 3
+		}
+		last = ti
+		qi++
+	}
+	if qi < len(queryRunes) {
+		return 0, false
+	}
+	return score*10 - len(targetRunes), true
+}
+
+// isWordStart reports whether r starts a word after prev (separator or camelCase boundary)
+func isWordStart(prev, r rune) bool {
+	if strings.ContainsRune("_-./: ", prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(r)
+}
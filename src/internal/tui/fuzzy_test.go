@@ -0,0 +1,36 @@
+package tui
+
+import "testing"
+
+func TestFuzzyScore(t *testing.T) {
+	for _, tt := range []struct {
+		query, target string
+		ok            bool
+	}{
+		{"", "anything", true},
+		{"uid", "userId", true},
+		{"UID", "userId", true},
+		{"usr", "userId", true},
+		{"idu", "userId", false},
+		{"userIds", "userId", false},
+	} {
+		if _, ok := fuzzyScore(tt.query, tt.target); ok != tt.ok {
+			t.Errorf("%q in %q: expected ok=%v", tt.query, tt.target, tt.ok)
+		}
+	}
+
+	// Consecutive and word-start matches rank first, then shorter targets
+	for _, tt := range []struct {
+		query, better, worse string
+	}{
+		{"user", "userId", "superuser"},
+		{"ui", "userId", "uuid"},
+		{"host", "host", "hostname"},
+	} {
+		a, _ := fuzzyScore(tt.query, tt.better)
+		b, _ := fuzzyScore(tt.query, tt.worse)
+		if a <= b {
+			t.Errorf("%q: expected %q (%d) to rank above %q (%d)", tt.query, tt.better, a, tt.worse, b)
+		}
+	}
+}
@@ -25,23 +25,50 @@ func getSortedHeaderNames(headers map[string]string) []string {
 	return names
 }
 
+// editedHeaders returns the headers the editor works on
+// While editing a history replay or a request inline these are the draft's, otherwise the active profile's.
+func (m *Model) editedHeaders() map[string]string {
+	if draft, _ := m.editDraft(); draft != nil {
+		return draft.Headers
+	}
+	profile := m.sessionMgr.GetActiveProfile()
+	if profile.Headers == nil {
+		profile.Headers = make(map[string]string)
+	}
+	return profile.Headers
+}
+
+// saveEditedHeaders persists profile headers (draft headers only live until the draft is sent or saved)
+func (m *Model) saveEditedHeaders() {
+	if draft, _ := m.editDraft(); draft == nil {
+		m.sessionMgr.SaveProfiles()
+	}
+}
+
 // renderHeaderEditor renders the header editor in its various modes
 func (m *Model) renderHeaderEditor() string {
-	profile := m.sessionMgr.GetActiveProfile()
+	headers := m.editedHeaders()
 
 	var content strings.Builder
 	var footer string
 
 	switch m.mode {
 	case ModeHeaderList:
-		content.WriteString("Profile Headers:\n")
+		switch _, mode := m.editDraft(); mode {
+		case ModeReplayEdit:
+			content.WriteString("Replay Headers:\n")
+		case ModeRequestEdit:
+			content.WriteString("Request Headers:\n")
+		default:
+			content.WriteString("Profile Headers:\n")
+		}
 
-		if len(profile.Headers) == 0 {
+		if len(headers) == 0 {
 			content.WriteString("  (none)\n")
 		} else {
-			sortedNames := getSortedHeaderNames(profile.Headers)
+			sortedNames := getSortedHeaderNames(headers)
 			for i, name := range sortedNames {
-				line := fmt.Sprintf("  %s: %s", name, truncate(profile.Headers[name], 50))
+				line := fmt.Sprintf("  %s: %s", name, truncate(headers[name], 50))
 				if i == m.headerEditIndex {
 					line = styleSelected.Render(line)
 				}
@@ -95,11 +122,11 @@ func (m *Model) renderHeaderEditor() string {
 
 // handleHeaderEditorKeys handles keyboard input in header editor modes
 func (m *Model) handleHeaderEditorKeys(msg tea.KeyMsg) tea.Cmd {
-	profile := m.sessionMgr.GetActiveProfile()
+	headers := m.editedHeaders()
 
 	switch m.mode {
 	case ModeHeaderList:
-		sortedNames := getSortedHeaderNames(profile.Headers)
+		sortedNames := getSortedHeaderNames(headers)
 
 		action, ok, partial := m.keybinds.MatchMultiKey(keybinds.ContextHeaderList, msg.String())
 		if partial {
@@ -113,7 +140,7 @@ func (m *Model) handleHeaderEditorKeys(msg tea.KeyMsg) tea.Cmd {
 
 		switch action {
 		case keybinds.ActionCloseModal:
-			m.mode = ModeNormal
+			_, m.mode = m.editDraft()
 
 		case keybinds.ActionNavigateUp:
 			if m.headerEditIndex > 0 {
@@ -167,7 +194,7 @@ func (m *Model) handleHeaderEditorKeys(msg tea.KeyMsg) tea.Cmd {
 				m.mode = ModeHeaderEdit
 				name := sortedNames[m.headerEditIndex]
 				m.headerEditName = name
-				m.headerEditValue = profile.Headers[name]
+				m.headerEditValue = headers[name]
 				m.headerEditCursor = 0
 			}
 
@@ -191,8 +218,8 @@ func (m *Model) handleHeaderEditorKeys(msg tea.KeyMsg) tea.Cmd {
 
 		switch action {
 		case keybinds.ActionConfirm:
-			delete(profile.Headers, m.headerEditName)
-			m.sessionMgr.SaveProfiles()
+			delete(headers, m.headerEditName)
+			m.saveEditedHeaders()
 			m.mode = ModeHeaderList
 			m.statusMsg = fmt.Sprintf("Deleted header: %s", m.headerEditName)
 
@@ -206,7 +233,7 @@ func (m *Model) handleHeaderEditorKeys(msg tea.KeyMsg) tea.Cmd {
 
 // handleHeaderInputKeys handles text input for add/edit header
 func (m *Model) handleHeaderInputKeys(msg tea.KeyMsg) tea.Cmd {
-	profile := m.sessionMgr.GetActiveProfile()
+	headers := m.editedHeaders()
 
 	// Handle tab specially (field switching)
 	if msg.String() == "tab" {
@@ -228,13 +255,9 @@ func (m *Model) handleHeaderInputKeys(msg tea.KeyMsg) tea.Cmd {
 			}
 
 			// Create or update header
-			if profile.Headers == nil {
-				profile.Headers = make(map[string]string)
-			}
-
-			profile.Headers[m.headerEditName] = m.headerEditValue
+			headers[m.headerEditName] = m.headerEditValue
 
-			m.sessionMgr.SaveProfiles()
+			m.saveEditedHeaders()
 			m.mode = ModeHeaderList
 			m.statusMsg = fmt.Sprintf("Saved header: %s", m.headerEditName)
 			return nil
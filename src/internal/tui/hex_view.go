@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// hexBytesPerLine is the width of the response hex dump, as in hexdump -C
+const hexBytesPerLine = 16
+
+// renderHexDump renders bytes as offset, hex bytes and ASCII gutter, 16 bytes per line
+func renderHexDump(data []byte) string {
+	return renderHexDumpLines(data, hexBytesPerLine)
+}
+
+// renderHexDumpLines renders a hex dump with perLine bytes per line
+// Lines wider than 8 bytes get an extra space in the middle of the hex column.
+func renderHexDumpLines(data []byte, perLine int) string {
+	var out strings.Builder
+	for offset := 0; offset < len(data); offset += perLine {
+		chunk := data[offset:min(offset+perLine, len(data))]
+
+		fmt.Fprintf(&out, "%08x  ", offset)
+		for i := 0; i < perLine; i++ {
+			if i < len(chunk) {
+				fmt.Fprintf(&out, "%02x ", chunk[i])
+			} else {
+				out.WriteString("   ")
+			}
+			if i == 7 && perLine > 8 {
+				out.WriteString(" ")
+			}
+		}
+
+		out.WriteString(" |")
+		for _, b := range chunk {
+			if b >= 0x20 && b <= 0x7e {
+				out.WriteByte(b)
+			} else {
+				out.WriteByte('.')
+			}
+		}
+		out.WriteString("|\n")
+	}
+	return out.String()
+}
+
+// hexDumpWidth returns the columns used by a line of renderHexDumpLines
+func hexDumpWidth(perLine int) int {
+	width := 8 + 2 + perLine*3 + 2 + perLine + 1
+	if perLine > 8 {
+		width++
+	}
+	return width
+}
+
+// hexBytesPerLineFor returns the widest line of 16, 8 or 4 bytes that fits in width
+func hexBytesPerLineFor(width int) int {
+	for _, perLine := range []int{16, 8} {
+		if hexDumpWidth(perLine) <= width {
+			return perLine
+		}
+	}
+	return 4
+}
+
+// parseBytePattern parses a search query as bytes, e.g. "de ad be ef", "0xdeadbeef" or "DE:AD"
+// Returns false when the query is not an even run of hex digits.
+func parseBytePattern(query string) ([]byte, bool) {
+	cleaned := strings.NewReplacer(" ", "", ":", "", "0x", "", "0X", "").Replace(strings.TrimSpace(query))
+	if len(cleaned) < 2 || len(cleaned)%2 != 0 {
+		return nil, false
+	}
+	pattern, err := hex.DecodeString(cleaned)
+	if err != nil {
+		return nil, false
+	}
+	return pattern, true
+}
+
+// hexMatchLines returns the dump lines holding an occurrence of pattern, in order
+// A match spanning two lines marks both.
+func hexMatchLines(data, pattern []byte, perLine int) []int {
+	var lines []int
+	last := -1
+	for start := 0; ; {
+		index := bytes.Index(data[start:], pattern)
+		if index == -1 {
+			return lines
+		}
+		offset := start + index
+		for line := offset / perLine; line <= (offset+len(pattern)-1)/perLine; line++ {
+			if line > last {
+				lines = append(lines, line)
+				last = line
+			}
+		}
+		start = offset + 1
+	}
+}
+
+// hexViewBody returns the bytes shown by the response hex view, capped like the text view
+func (m *Model) hexViewBody() ([]byte, bool) {
+	body := []byte(m.currentResponse.Body)
+	if limit := m.responseDisplayLimit(); limit > 0 && len(body) > limit {
+		return body[:limit], true
+	}
+	return body, false
+}
+
+// toggleHexView switches the response body between its usual rendering and a hex dump
+func (m *Model) toggleHexView() {
+	if m.currentResponse == nil || m.currentResponse.Body == "" {
+		m.statusMsg = "No response body to show as hex"
+		return
+	}
+
+	m.hexView = !m.hexView
+	if m.hexView {
+		m.jsonTreeState.SetActive(false)
+		m.tableViewState.SetActive(false)
+		m.statusMsg = "Hex view: / searches bytes (e.g. de ad be ef), Ctrl+B to close"
+	} else {
+		m.statusMsg = "Hex view closed"
+	}
+
+	m.responseSearchMatches = nil
+	m.cachedResponsePtr = nil // Hex and text views share the response cache
+	m.updateResponseView()
+}
+
+// searchHexBytes finds a byte pattern in the hex view, marking the dump lines that hold it
+func (m *Model) searchHexBytes(pattern []byte) {
+	data, _ := m.hexViewBody()
+	for _, line := range hexMatchLines(data, pattern, hexBytesPerLine) {
+		m.responseSearchMatches = append(m.responseSearchMatches, m.hexDumpStartLine+line)
+	}
+
+	if len(m.responseSearchMatches) == 0 {
+		m.errorMsg = "No matches found in response"
+		return
+	}
+
+	m.responseSearchIndex = 0
+	m.responseView.SetYOffset(m.centerLineInViewport(m.responseSearchMatches[0]))
+	m.statusMsg = fmt.Sprintf("[Response] Match 1 of %d (bytes)", len(m.responseSearchMatches))
+	m.updateResponseView() // Re-render with highlighting
+}
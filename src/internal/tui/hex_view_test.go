@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestRenderHexDump(t *testing.T) {
+	data := append([]byte("\x89PNG\r\n\x1a\n"), []byte("0123456789")...)
+	got := renderHexDump(data)
+
+	want := "00000000  89 50 4e 47 0d 0a 1a 0a  30 31 32 33 34 35 36 37  |.PNG....01234567|\n" +
+		"00000010  38 39                                             |89|\n"
+	if got != want {
+		t.Errorf("Unexpected hex dump:\n%s\nwant:\n%s", got, want)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		if strings.Index(line, "|") != 60 {
+			t.Errorf("Expected the ASCII gutter to stay aligned, got %q", line)
+		}
+	}
+}
+
+func TestHexBytesPerLineFor(t *testing.T) {
+	if got := hexBytesPerLineFor(100); got != 16 {
+		t.Errorf("Expected 16 bytes per line in a wide pane, got %d", got)
+	}
+	if got := hexBytesPerLineFor(50); got != 8 {
+		t.Errorf("Expected 8 bytes per line in a narrow pane, got %d", got)
+	}
+	if got := hexBytesPerLineFor(10); got != 4 {
+		t.Errorf("Expected 4 bytes per line at least, got %d", got)
+	}
+}
+
+func TestParseBytePattern(t *testing.T) {
+	for _, query := range []string{"de ad be ef", "0xdeadbeef", "DE:AD:BE:EF", "deadbeef"} {
+		pattern, ok := parseBytePattern(query)
+		if !ok || string(pattern) != "\xde\xad\xbe\xef" {
+			t.Errorf("Expected %q to parse as bytes, got %x (%v)", query, pattern, ok)
+		}
+	}
+	for _, query := range []string{"", "a", "abc", "hello", "dead b"} {
+		if _, ok := parseBytePattern(query); ok {
+			t.Errorf("Expected %q not to parse as bytes", query)
+		}
+	}
+}
+
+func TestHexMatchLines(t *testing.T) {
+	data := make([]byte, 48)
+	copy(data[2:], "\xde\xad")
+	copy(data[15:], "\xde\xad") // Spans lines 0 and 1
+	copy(data[40:], "\xde\xad")
+
+	got := hexMatchLines(data, []byte{0xde, 0xad}, 16)
+	if len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("Expected lines [0 1 2], got %v", got)
+	}
+	if got := hexMatchLines(data, []byte{0xbe, 0xef}, 16); len(got) != 0 {
+		t.Errorf("Expected no match, got %v", got)
+	}
+}
+
+func TestToggleHexView(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	m.responseView.Width, m.responseView.Height = 100, 30
+
+	body := "\x00\x01binary\xde\xad\xbe\xef\x00"
+	m.currentResponse = &types.RequestResult{Status: 200, StatusText: "200 OK", Body: body, ResponseSize: len(body)}
+	m.updateResponseView()
+	if !strings.Contains(m.responseContent, "Ctrl+B for the hex view") {
+		t.Fatal("Expected the binary indicator to point at the hex view")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	AssertModelField(t, "hexView", m.hexView, true)
+	if !strings.Contains(m.responseContent, "00 01 62 69 6e 61 72 79") || !strings.Contains(m.responseContent, "|..binary.....|") {
+		t.Errorf("Expected a hex dump of the body, got:\n%s", m.responseContent)
+	}
+
+	m.searchInput = "de ad be ef"
+	m.searchInResponse()
+	if len(m.responseSearchMatches) != 1 || !strings.HasSuffix(m.statusMsg, "(bytes)") {
+		t.Errorf("Expected one byte match, got %v (%q)", m.responseSearchMatches, m.statusMsg)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	AssertModelField(t, "hexView", m.hexView, false)
+	if strings.Contains(m.responseContent, "00000000") {
+		t.Error("Expected the hex dump to be gone")
+	}
+}
+
+func TestWebSocketHistory_HexView(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	m.wsMessages = []types.ReceivedMessage{
+		{Type: "binary", Content: "\x01\x02\xca\xfe", Direction: "received"},
+		{Type: "text", Content: "hello", Direction: "received"},
+	}
+
+	m.updateWebSocketHistoryView(60, 20)
+	if !strings.Contains(m.wsHistoryView.View(), "[Binary frame - 4 B, x for hex view]") {
+		t.Errorf("Expected a binary frame summary, got:\n%s", m.wsHistoryView.View())
+	}
+
+	m.handleWebSocketKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	AssertModelField(t, "wsHexView", m.wsHexView, true)
+
+	m.wsSearchQuery = "ca fe"
+	m.updateWebSocketHistoryView(60, 20)
+	view := m.wsHistoryView.View()
+	if !strings.Contains(view, "01 02 ca fe") || strings.Contains(view, "hello") {
+		t.Errorf("Expected only the binary frame, as hex, got:\n%s", view)
+	}
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// parseAnnotation splits annotation input into a note and tags
+// Words starting with # are tags ("#baseline #v2 known good" → note "known good", tags baseline, v2).
+func parseAnnotation(input string) (string, []string) {
+	var words, tags []string
+	seen := make(map[string]bool)
+	for _, word := range strings.Fields(input) {
+		tag, ok := strings.CutPrefix(word, "#")
+		if !ok || tag == "" {
+			words = append(words, word)
+			continue
+		}
+		// Commas separate tags in the database
+		for _, part := range strings.Split(tag, ",") {
+			if part != "" && !seen[part] {
+				seen[part] = true
+				tags = append(tags, part)
+			}
+		}
+	}
+	return strings.Join(words, " "), tags
+}
+
+// formatAnnotation is the inverse of parseAnnotation, used to pre-fill the input
+func formatAnnotation(entry types.HistoryEntry) string {
+	parts := make([]string, 0, len(entry.Tags)+1)
+	for _, tag := range entry.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	if entry.Note != "" {
+		parts = append(parts, entry.Note)
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatHistoryTags renders tags for the history list
+func formatHistoryTags(tags []string) string {
+	return "#" + strings.Join(tags, " #")
+}
+
+// startHistoryAnnotation opens the note and tags input for the selected entry
+func (m *Model) startHistoryAnnotation() tea.Cmd {
+	entry := m.historyState.GetCurrentEntry()
+	if entry == nil {
+		return nil
+	}
+	if entry.ID == 0 {
+		return m.setErrorMessage("Only entries stored in the history database can be annotated")
+	}
+
+	m.historyState.SetAnnotating(true)
+	m.inputValue = formatAnnotation(*entry)
+	m.inputCursor = len(m.inputValue)
+	m.statusMsg = "Annotate entry: #tags then a note (Enter to save, ESC to cancel)"
+	return nil
+}
+
+// handleHistoryAnnotateKeys handles the note and tags input of the history viewer
+func (m *Model) handleHistoryAnnotateKeys(msg tea.KeyMsg) tea.Cmd {
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.historyState.SetAnnotating(false)
+			m.inputValue = ""
+			m.inputCursor = 0
+			m.statusMsg = "Annotation cancelled"
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			m.historyState.SetAnnotating(false)
+			note, tags := parseAnnotation(m.inputValue)
+			m.inputValue = ""
+			m.inputCursor = 0
+			return m.saveHistoryAnnotation(note, tags)
+		}
+	}
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.inputValue, &m.inputCursor, msg); shouldContinue {
+		return nil
+	}
+
+	if len(msg.String()) == 1 {
+		m.inputValue = m.inputValue[:m.inputCursor] + msg.String() + m.inputValue[m.inputCursor:]
+		m.inputCursor++
+	}
+	return nil
+}
+
+// saveHistoryAnnotation stores the note and tags of the selected entry
+func (m *Model) saveHistoryAnnotation(note string, tags []string) tea.Cmd {
+	entry := m.historyState.GetCurrentEntry()
+	if entry == nil || m.historyManager == nil {
+		return nil
+	}
+
+	if err := m.historyManager.Annotate(entry.ID, note, tags); err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to annotate entry: %v", err))
+	}
+	m.historyState.UpdateAnnotation(entry.ID, note, tags)
+	m.updateHistoryView()
+
+	if note == "" && len(tags) == 0 {
+		return m.setStatusMessage("Annotation removed")
+	}
+	return m.setStatusMessage("Annotation saved")
+}
+
+// toggleHistoryAnnotatedOnly shows only entries with a note or tags, or all entries again
+func (m *Model) toggleHistoryAnnotatedOnly() {
+	m.historyState.ToggleAnnotatedOnly()
+	m.filterHistoryEntries()
+	if m.historyState.GetAnnotatedOnly() {
+		m.statusMsg = fmt.Sprintf("Showing %d annotated entries", len(m.historyState.GetEntries()))
+	} else {
+		m.statusMsg = "Showing all entries"
+	}
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestParseAnnotation(t *testing.T) {
+	tests := []struct {
+		input string
+		note  string
+		tags  []string
+	}{
+		{"#baseline #v2 known good", "known good", []string{"baseline", "v2"}},
+		{"before the #refactor", "before the", []string{"refactor"}},
+		{"#a,b #a", "", []string{"a", "b"}},
+		{"issue # 42", "issue # 42", nil},
+		{"", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			note, tags := parseAnnotation(tt.input)
+			if note != tt.note {
+				t.Errorf("Expected note %q, got %q", tt.note, note)
+			}
+			if !reflect.DeepEqual(tags, tt.tags) {
+				t.Errorf("Expected tags %v, got %v", tt.tags, tags)
+			}
+		})
+	}
+}
+
+func TestFormatAnnotation_RoundTrip(t *testing.T) {
+	entry := types.HistoryEntry{Note: "known good", Tags: []string{"baseline", "v2"}}
+	input := formatAnnotation(entry)
+	if input != "#baseline #v2 known good" {
+		t.Fatalf("Unexpected input %q", input)
+	}
+	note, tags := parseAnnotation(input)
+	if note != entry.Note || !reflect.DeepEqual(tags, entry.Tags) {
+		t.Errorf("Expected round trip, got %q %v", note, tags)
+	}
+}
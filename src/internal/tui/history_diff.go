@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// historyEntryResult converts a stored history entry back into a response
+func historyEntryResult(entry types.HistoryEntry) *types.RequestResult {
+	return &types.RequestResult{
+		Status:       entry.ResponseStatus,
+		StatusText:   entry.ResponseStatusText,
+		Headers:      entry.ResponseHeaders,
+		Body:         entry.ResponseBody,
+		Duration:     entry.Duration,
+		RequestSize:  entry.RequestSize,
+		ResponseSize: entry.ResponseSize,
+		Error:        entry.Error,
+		Timestamp:    entry.Timestamp,
+		Redirects:    entry.Redirects,
+	}
+}
+
+// markHistoryDiff marks the selected entry for diff
+// With an entry already marked, it opens the diff between the two instead
+func (m *Model) markHistoryDiff() tea.Cmd {
+	entries := m.historyState.GetEntries()
+	index := m.historyState.GetIndex()
+	if index < 0 || index >= len(entries) {
+		return nil
+	}
+
+	mark := m.historyState.GetDiffMark()
+	switch {
+	case mark < 0 || mark >= len(entries):
+		m.historyState.SetDiffMark(index)
+		m.statusMsg = "Marked for diff (select another entry and press d)"
+	case mark == index:
+		m.historyState.ClearDiffMark()
+		m.statusMsg = "Diff mark cleared"
+	default:
+		m.historyState.ClearDiffMark()
+		m.openHistoryDiff(entries[mark], entries[index])
+		return nil
+	}
+
+	m.updateHistoryView()
+	return nil
+}
+
+// openHistoryDiff compares two history entries in the diff viewer, older entry first
+func (m *Model) openHistoryDiff(a, b types.HistoryEntry) {
+	if a.Timestamp > b.Timestamp {
+		a, b = b, a
+	}
+
+	side := func(label string, entry types.HistoryEntry) diffSide {
+		name := entry.RequestName
+		if name == "" {
+			name = entry.Method + " " + entry.URL
+		}
+		return diffSide{label: label, name: fmt.Sprintf("%s (%s)", name, entry.Timestamp), response: historyEntryResult(entry)}
+	}
+
+	m.historyDiff = &[2]diffSide{side("OLDER", a), side("NEWER", b)}
+	m.mode = ModeDiff
+	m.updateDiffView()
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestHistoryDiff_MarkAndCompare(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	m.historyState.SetEntries([]types.HistoryEntry{
+		{Timestamp: "2026-01-02T10:00:00Z", RequestName: "Get user", Method: "GET", URL: "/users/1", ResponseStatus: 200, ResponseBody: `{"name": "b", "id": 1}`},
+		{Timestamp: "2026-01-01T10:00:00Z", RequestName: "Get user", Method: "GET", URL: "/users/1", ResponseStatus: 200, ResponseBody: `{"id": 1, "name": "a"}`},
+	})
+	m.mode = ModeHistory
+	d := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")}
+
+	m.Update(d)
+	AssertModelField(t, "diffMark", 0, m.historyState.GetDiffMark())
+	m.Update(d)
+	AssertModelField(t, "diffMark", -1, m.historyState.GetDiffMark())
+
+	m.Update(d)
+	m.historyState.Navigate(1)
+	m.Update(d)
+	AssertModelField(t, "mode", ModeDiff, m.mode)
+	if m.historyDiff == nil {
+		t.Fatal("Expected a history diff")
+	}
+	if older := m.historyDiff[0]; older.label != "OLDER" || older.response.Body != `{"id": 1, "name": "a"}` {
+		t.Errorf("Expected the older entry on the left, got %+v", older)
+	}
+
+	m.diffSemantic = true
+	if body := m.compareBodies(); !strings.Contains(body, `~ name: "a" -> "b"`) {
+		t.Errorf("Expected the semantic diff of the stored bodies, got %q", body)
+	}
+
+	// The live responses are left untouched
+	if m.pinnedResponse != nil || m.currentResponse != nil {
+		t.Error("Expected the history diff not to touch the pinned or current response")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	AssertModelField(t, "mode", ModeHistory, m.mode)
+	if m.historyDiff != nil {
+		t.Error("Expected closing the diff to clear the history comparison")
+	}
+}
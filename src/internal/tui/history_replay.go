@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// historyRequest reconstructs the request stored in a history entry
+// Headers are copied so edits never reach the entry itself.
+func historyRequest(entry types.HistoryEntry) *types.HttpRequest {
+	headers := make(map[string]string, len(entry.Headers))
+	for name, value := range entry.Headers {
+		headers[name] = value
+	}
+	return &types.HttpRequest{
+		Name:    entry.RequestName,
+		Method:  entry.Method,
+		URL:     entry.URL,
+		Headers: headers,
+		Body:    entry.Body,
+	}
+}
+
+// startReplayEdit opens the replay editor on the request of a history entry
+func (m *Model) startReplayEdit(index int) {
+	entries := m.historyState.GetEntries()
+	if index < 0 || index >= len(entries) {
+		return
+	}
+
+	m.replayDraft = historyRequest(entries[index])
+	m.replayURLCursor = len(m.replayDraft.URL)
+	m.headerEditIndex = 0
+	m.mode = ModeReplayEdit
+	m.statusMsg = "Edit the request, then press Enter to replay it"
+}
+
+// handleReplayEditKeys handles the replay editor
+// The URL is edited inline, the body and headers in their usual editors.
+func (m *Model) handleReplayEditKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+b":
+		m.bodyOverrideInput = m.replayDraft.Body
+		m.bodyOverrideType = requestContentType(m.replayDraft.Headers)
+		m.bodyOverrideCursor = 0
+		m.mode = ModeBodyOverride
+		m.statusMsg = "Editing replay body"
+		return nil
+
+	case "ctrl+t":
+		m.headerEditIndex = 0
+		m.mode = ModeHeaderList
+		m.modalView.SetYOffset(0)
+		return nil
+	}
+
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.replayDraft = nil
+			m.mode = ModeHistory
+			m.statusMsg = "Replay cancelled"
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			return m.sendReplayDraft()
+		}
+	}
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.replayDraft.URL, &m.replayURLCursor, msg); shouldContinue {
+		return nil
+	}
+
+	if len(msg.String()) == 1 {
+		m.replayDraft.URL = m.replayDraft.URL[:m.replayURLCursor] + msg.String() + m.replayDraft.URL[m.replayURLCursor:]
+		m.replayURLCursor++
+	}
+	return nil
+}
+
+// sendReplayDraft executes the edited request
+// It is recorded as a new history entry; the entry it came from is left untouched.
+func (m *Model) sendReplayDraft() tea.Cmd {
+	if strings.TrimSpace(m.replayDraft.URL) == "" {
+		m.errorMsg = "URL cannot be empty"
+		return nil
+	}
+
+	m.currentRequest = m.replayDraft
+	m.replayDraft = nil
+	// A pending one-time override would replace the edited body
+	m.bodyOverride = ""
+	m.mode = ModeNormal
+	return m.executeRequest()
+}
+
+// renderReplayEditModal renders the replay editor
+func (m *Model) renderReplayEditModal() string {
+	draft := m.replayDraft
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("Method:  %s\n", draft.Method))
+	content.WriteString(fmt.Sprintf("URL:     %s\n\n", addCursorAt(draft.URL, m.replayURLCursor)))
+	writeDraftDetails(&content, draft)
+
+	if m.errorMsg != "" {
+		content.WriteString("\n" + styleError.Render(m.errorMsg))
+	}
+
+	footer := "[Enter] replay [Ctrl+B] body [Ctrl+T] headers [ESC] back"
+	return m.renderModalWithFooter("Edit Replay", content.String(), footer, 80, 25)
+}
+
+// writeDraftDetails writes the headers and the first lines of the body of an edited request
+func writeDraftDetails(content *strings.Builder, draft *types.HttpRequest) {
+	content.WriteString(fmt.Sprintf("Headers (%d):\n", len(draft.Headers)))
+	if len(draft.Headers) == 0 {
+		content.WriteString("  (none)\n")
+	}
+	for _, name := range getSortedHeaderNames(draft.Headers) {
+		content.WriteString(fmt.Sprintf("  %s: %s\n", name, truncate(draft.Headers[name], 50)))
+	}
+
+	// GraphQL requests edit the query instead of the body
+	body, label := draft.Body, "Body"
+	if draft.GraphQL != nil {
+		body, label = draft.GraphQL.Query, "Query"
+	}
+	content.WriteString("\n" + label + ":\n")
+	if body == "" {
+		content.WriteString("  (empty)\n")
+	} else {
+		const previewLines = 5
+		lines := strings.Split(body, "\n")
+		for i, line := range lines {
+			if i == previewLines {
+				content.WriteString(styleSubtle.Render(fmt.Sprintf("  ... %d more lines", len(lines)-previewLines)) + "\n")
+				break
+			}
+			content.WriteString("  " + truncate(line, 60) + "\n")
+		}
+	}
+}
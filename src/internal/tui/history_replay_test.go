@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestReplayEdit_EditsCopyOfEntry(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	m.historyState.SetEntries([]types.HistoryEntry{
+		{Timestamp: "2026-01-01T10:00:00Z", RequestName: "Get user", Method: "GET", URL: "/users/1", Headers: map[string]string{"Accept": "application/json"}},
+	})
+	m.mode = ModeHistory
+	keys := func(s string) {
+		for _, r := range s {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	keys("e")
+	AssertModelField(t, "mode", ModeReplayEdit, m.mode)
+
+	// Change the path id
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	keys("2")
+
+	// Add a header through the header editor
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	AssertModelField(t, "mode", ModeHeaderList, m.mode)
+	keys("a")
+	keys("X-Trace")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	keys("1")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	AssertModelField(t, "mode", ModeReplayEdit, m.mode)
+
+	// Replace the body through the body editor
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	AssertModelField(t, "mode", ModeBodyOverride, m.mode)
+	keys("{}")
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	AssertModelField(t, "mode", ModeReplayEdit, m.mode)
+	AssertModelField(t, "bodyOverride", "", m.bodyOverride)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "mode", ModeNormal, m.mode)
+	if m.replayDraft != nil {
+		t.Error("Expected the draft to be cleared after sending")
+	}
+
+	req := m.currentRequest
+	if req.URL != "/users/2" || req.Headers["X-Trace"] != "1" || req.Body != "{}" {
+		t.Errorf("Expected the edited request, got %+v", req)
+	}
+
+	entry := m.historyState.GetEntries()[0]
+	if entry.URL != "/users/1" || len(entry.Headers) != 1 || entry.Body != "" {
+		t.Errorf("Expected the history entry to be left untouched, got %+v", entry)
+	}
+	if _, ok := m.sessionMgr.GetActiveProfile().Headers["X-Trace"]; ok {
+		t.Error("Expected replay headers not to be saved to the profile")
+	}
+}
+
+func TestReplayEdit_CancelReturnsToHistory(t *testing.T) {
+	m := CreateTestModel(t)
+	m.historyState.SetEntries([]types.HistoryEntry{
+		{Timestamp: "2026-01-01T10:00:00Z", Method: "GET", URL: "/users/1"},
+	})
+	m.mode = ModeHistory
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	AssertModelField(t, "mode", ModeHistory, m.mode)
+	if m.replayDraft != nil {
+		t.Error("Expected cancelling to discard the draft")
+	}
+}
@@ -2,6 +2,8 @@ package tui
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -25,6 +27,13 @@ type HistoryState struct {
 	focusedPane    string // "list" or "preview" - which pane has focus in split view
 	searchActive   bool   // True when search input is active
 	searchQuery    string // Search query for filtering history
+	diffMark       int    // Index of the entry marked for diff, -1 when none
+
+	// Search index: lowercased text of allEntries, built on the first search
+	searchIndex   []historySearchDoc
+	matchFields   []string // Field that matched the search, per entry (nil when not filtered)
+	annotatedOnly bool     // Only show entries with a note or tags
+	annotating    bool     // True while editing the note and tags of the selected entry
 
 	// Performance optimization: cache rendered content to avoid re-processing on every navigation
 	// Key format: "{timestamp}:{width}" → final rendered content (wrapped + highlighted)
@@ -42,6 +51,7 @@ func NewHistoryState() *HistoryState {
 		focusedPane:    "list",
 		searchActive:   false,
 		searchQuery:    "",
+		diffMark:       -1,
 		renderedCache:  make(map[string]string),
 	}
 }
@@ -60,8 +70,10 @@ func (s *HistoryState) SetEntries(entries []types.HistoryEntry) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.entries = entries
-	// Clear cache when entries change
+	s.matchFields = nil
+	// Clear cache and diff mark when entries change
 	s.renderedCache = make(map[string]string)
+	s.diffMark = -1
 }
 
 // GetAllEntries returns a copy of the allEntries slice
@@ -78,6 +90,7 @@ func (s *HistoryState) SetAllEntries(entries []types.HistoryEntry) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.allEntries = entries
+	s.searchIndex = nil
 }
 
 // GetIndex returns the current index
@@ -242,6 +255,27 @@ func (s *HistoryState) ToggleFocus() {
 	}
 }
 
+// GetDiffMark returns the index of the entry marked for diff, -1 when none
+func (s *HistoryState) GetDiffMark() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.diffMark
+}
+
+// SetDiffMark marks an entry for diff
+func (s *HistoryState) SetDiffMark(index int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.diffMark = index
+}
+
+// ClearDiffMark removes the diff mark
+func (s *HistoryState) ClearDiffMark() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.diffMark = -1
+}
+
 // GetRenderedCache returns cached rendered content for an entry by timestamp and width
 func (s *HistoryState) GetRenderedCache(timestamp string, width int) (string, bool) {
 	s.mu.RLock()
@@ -265,3 +299,144 @@ func (s *HistoryState) ClearRenderedCache() {
 	defer s.mu.Unlock()
 	s.renderedCache = make(map[string]string)
 }
+
+// historySearchFields names the searchable fields in match order, bodies last since they are the largest
+var historySearchFields = [...]string{"name", "method", "url", "status", "time", "tags", "note", "request body", "response body"}
+
+// historyBodyFields is the index of the first body field in historySearchFields
+const historyBodyFields = 7
+
+// historySearchDoc holds the lowercased searchable fields of one history entry
+type historySearchDoc [len(historySearchFields)]string
+
+// HistoryBodySearchPrefix scopes a history search to request and response bodies
+const HistoryBodySearchPrefix = "body:"
+
+// newHistorySearchDoc lowercases the searchable fields of an entry
+func newHistorySearchDoc(entry types.HistoryEntry) historySearchDoc {
+	return historySearchDoc{
+		strings.ToLower(entry.RequestName),
+		strings.ToLower(entry.Method),
+		strings.ToLower(entry.URL),
+		strconv.Itoa(entry.ResponseStatus) + " " + strings.ToLower(entry.ResponseStatusText),
+		entry.Timestamp,
+		strings.ToLower(strings.Join(entry.Tags, " ")),
+		strings.ToLower(entry.Note),
+		strings.ToLower(entry.Body),
+		strings.ToLower(entry.ResponseBody),
+	}
+}
+
+// Search filters all entries by query (case-insensitive) and shows the matches
+// Matches the name, method, URL, status, timestamp, tags, note and bodies; a "body:" prefix
+// only searches the request and response bodies. An empty query shows all entries.
+// With the annotated filter on, only entries with a note or tags are shown.
+func (s *HistoryState) Search(query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.renderedCache = make(map[string]string)
+	s.diffMark = -1
+	s.index = 0
+
+	query = strings.ToLower(query)
+	first := 0
+	if rest, ok := strings.CutPrefix(query, HistoryBodySearchPrefix); ok {
+		query = strings.TrimSpace(rest)
+		first = historyBodyFields
+	}
+	if query == "" {
+		s.entries = s.allEntries
+		if s.annotatedOnly {
+			s.entries = []types.HistoryEntry{}
+			for _, entry := range s.allEntries {
+				if entry.IsAnnotated() {
+					s.entries = append(s.entries, entry)
+				}
+			}
+		}
+		s.matchFields = nil
+		return
+	}
+
+	// Lowercasing large bodies on every keystroke is what makes searching slow
+	if s.searchIndex == nil {
+		s.searchIndex = make([]historySearchDoc, len(s.allEntries))
+		for i, entry := range s.allEntries {
+			s.searchIndex[i] = newHistorySearchDoc(entry)
+		}
+	}
+
+	entries := []types.HistoryEntry{}
+	var matchFields []string
+	for i, doc := range s.searchIndex {
+		if s.annotatedOnly && !s.allEntries[i].IsAnnotated() {
+			continue
+		}
+		for field := first; field < len(doc); field++ {
+			if strings.Contains(doc[field], query) {
+				entries = append(entries, s.allEntries[i])
+				matchFields = append(matchFields, historySearchFields[field])
+				break
+			}
+		}
+	}
+	s.entries = entries
+	s.matchFields = matchFields
+}
+
+// GetMatchField returns the field that matched the search for the entry at index
+// Empty when the entries are not filtered.
+func (s *HistoryState) GetMatchField(index int) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if index < 0 || index >= len(s.matchFields) {
+		return ""
+	}
+	return s.matchFields[index]
+}
+
+// GetAnnotatedOnly returns whether only annotated entries are shown
+func (s *HistoryState) GetAnnotatedOnly() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.annotatedOnly
+}
+
+// ToggleAnnotatedOnly toggles the annotated entries filter (applied by the next Search)
+func (s *HistoryState) ToggleAnnotatedOnly() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.annotatedOnly = !s.annotatedOnly
+}
+
+// GetAnnotating returns whether the note and tags of the selected entry are being edited
+func (s *HistoryState) GetAnnotating() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.annotating
+}
+
+// SetAnnotating sets the annotation editing state
+func (s *HistoryState) SetAnnotating(annotating bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.annotating = annotating
+}
+
+// UpdateAnnotation sets the note and tags of the entry with this database ID
+func (s *HistoryState) UpdateAnnotation(id int64, note string, tags []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entries := range [][]types.HistoryEntry{s.entries, s.allEntries} {
+		for i := range entries {
+			if entries[i].ID == id {
+				entries[i].Note = note
+				entries[i].Tags = tags
+			}
+		}
+	}
+	s.searchIndex = nil
+	s.renderedCache = make(map[string]string)
+}
@@ -229,6 +229,50 @@ func TestHistoryState_SearchOperations(t *testing.T) {
 	}
 }
 
+func TestHistoryState_Search(t *testing.T) {
+	state := NewHistoryState()
+	state.SetAllEntries([]types.HistoryEntry{
+		{Timestamp: "2026-01-01T10:00:00Z", Method: "GET", URL: "http://api/users", ResponseStatus: 200, ResponseBody: `{"name":"Alice"}`},
+		{Timestamp: "2026-01-01T11:00:00Z", Method: "POST", URL: "http://api/orders", ResponseStatus: 201, Body: `{"user":"alice"}`},
+		{Timestamp: "2026-01-01T12:00:00Z", Method: "GET", URL: "http://api/alice", ResponseStatus: 404, ResponseStatusText: "404 Not Found"},
+	})
+
+	tests := []struct {
+		query  string
+		urls   []string
+		fields []string
+	}{
+		{"ALICE", []string{"http://api/users", "http://api/orders", "http://api/alice"}, []string{"response body", "request body", "url"}},
+		{"body:alice", []string{"http://api/users", "http://api/orders"}, []string{"response body", "request body"}},
+		{"not found", []string{"http://api/alice"}, []string{"status"}},
+		{"body:users", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			state.Search(tt.query)
+			entries := state.GetEntries()
+			if len(entries) != len(tt.urls) {
+				t.Fatalf("Expected %d entries, got %d", len(tt.urls), len(entries))
+			}
+			for i, entry := range entries {
+				if entry.URL != tt.urls[i] {
+					t.Errorf("Entry %d: expected %s, got %s", i, tt.urls[i], entry.URL)
+				}
+				if field := state.GetMatchField(i); field != tt.fields[i] {
+					t.Errorf("Entry %d: expected match on %q, got %q", i, tt.fields[i], field)
+				}
+			}
+		})
+	}
+
+	// An empty query shows every entry without match fields
+	state.Search("")
+	if len(state.GetEntries()) != 3 || state.GetMatchField(0) != "" {
+		t.Error("Expected all entries unfiltered for an empty query")
+	}
+}
+
 func TestHistoryState_ConcurrentAccess(t *testing.T) {
 	state := NewHistoryState()
 
@@ -440,3 +484,54 @@ func TestHistoryState_ViewportImmutability(t *testing.T) {
 		t.Errorf("Expected Width 100, got %d", current.Width)
 	}
 }
+
+func TestHistoryState_DiffMark(t *testing.T) {
+	state := NewHistoryState()
+
+	if state.GetDiffMark() != -1 {
+		t.Errorf("Expected no diff mark by default, got %d", state.GetDiffMark())
+	}
+
+	state.SetDiffMark(2)
+	if state.GetDiffMark() != 2 {
+		t.Errorf("Expected diff mark 2, got %d", state.GetDiffMark())
+	}
+
+	// Indexes are stale once the entries change
+	state.SetEntries([]types.HistoryEntry{{Method: "GET"}})
+	if state.GetDiffMark() != -1 {
+		t.Errorf("Expected SetEntries to clear the diff mark, got %d", state.GetDiffMark())
+	}
+
+	state.SetDiffMark(0)
+	state.ClearDiffMark()
+	if state.GetDiffMark() != -1 {
+		t.Errorf("Expected diff mark cleared, got %d", state.GetDiffMark())
+	}
+}
+
+func TestHistoryState_AnnotatedOnly(t *testing.T) {
+	state := NewHistoryState()
+	state.SetAllEntries([]types.HistoryEntry{
+		{ID: 1, Timestamp: "2026-01-01T10:00:00Z", URL: "http://api/users"},
+		{ID: 2, Timestamp: "2026-01-01T11:00:00Z", URL: "http://api/orders", Tags: []string{"baseline"}},
+	})
+
+	state.ToggleAnnotatedOnly()
+	state.Search("")
+	if entries := state.GetEntries(); len(entries) != 1 || entries[0].ID != 2 {
+		t.Fatalf("Expected only the tagged entry, got %+v", entries)
+	}
+
+	state.UpdateAnnotation(1, "known good", nil)
+	state.Search("known")
+	if entries := state.GetEntries(); len(entries) != 1 || entries[0].ID != 1 || state.GetMatchField(0) != "note" {
+		t.Fatalf("Expected the note to be searchable, got %+v", entries)
+	}
+
+	state.ToggleAnnotatedOnly()
+	state.Search("")
+	if len(state.GetEntries()) != 2 {
+		t.Errorf("Expected all entries once the filter is off, got %d", len(state.GetEntries()))
+	}
+}
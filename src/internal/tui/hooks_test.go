@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// hooksTestModel returns a model whose active profile allows hooks, with request selected
+func hooksTestModel(t *testing.T, request *types.HttpRequest) *Model {
+	m := CreateTestModel(t)
+	originalProfilesFile := config.ProfilesFile
+	config.ProfilesFile = filepath.Join(t.TempDir(), ".profiles.json")
+	t.Cleanup(func() { config.ProfilesFile = originalProfilesFile })
+	m.sessionMgr.AddProfile(types.Profile{Name: "Default", AllowHooks: true})
+	m.currentRequest = request
+	return m
+}
+
+func TestBeforeHooks_RunInCommand(t *testing.T) {
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Token")
+	}))
+	defer server.Close()
+
+	marker := filepath.Join(t.TempDir(), "ran")
+	m := hooksTestModel(t, &types.HttpRequest{
+		Name:        "Hooked",
+		Method:      "GET",
+		URL:         server.URL,
+		Headers:     map[string]string{"X-Token": "{{token}}"},
+		BeforeHooks: []types.RequestHook{{Variable: "token", Command: "touch " + marker + " && echo abc123"}},
+	})
+
+	cmd := m.executeRequest()
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("Expected the hook not to run on the UI goroutine")
+	}
+
+	msg, ok := cmd().(requestExecutedMsg)
+	if !ok {
+		t.Fatal("Expected the request to complete")
+	}
+	if len(msg.warnings) > 0 {
+		t.Errorf("Expected no unresolved variables, got %v", msg.warnings)
+	}
+	AssertModelField(t, "hook output sent", token, "abc123")
+}
+
+func TestBeforeHooks_ErrorInResult(t *testing.T) {
+	m := hooksTestModel(t, &types.HttpRequest{
+		Name:        "Hooked",
+		Method:      "GET",
+		URL:         "http://127.0.0.1:1",
+		BeforeHooks: []types.RequestHook{{Command: "echo nope >&2; exit 3"}},
+	})
+
+	msg, ok := m.executeRequest()().(errorMsg)
+	if !ok {
+		t.Fatal("Expected the hook failure as an error message")
+	}
+	if !strings.Contains(string(msg), "@before hook") || !strings.Contains(string(msg), "nope") {
+		t.Errorf("Expected the hook error with its stderr, got %q", msg)
+	}
+}
+
+func TestBeforeHooks_CancelledWithRequest(t *testing.T) {
+	m := hooksTestModel(t, &types.HttpRequest{
+		Name:        "Hooked",
+		Method:      "GET",
+		URL:         "http://127.0.0.1:1",
+		BeforeHooks: []types.RequestHook{{Command: "sleep 10"}},
+	})
+
+	cmd := m.executeRequest()
+	done := make(chan interface{}, 1)
+	go func() { done <- cmd() }()
+	m.requestState.Cancel()
+
+	select {
+	case msg := <-done:
+		AssertModelField(t, "message", msg, interface{}(errorMsg("Request cancelled by user")))
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected ESC to stop the running hook")
+	}
+}
+
+func TestBeforeHooks_ShellCommandsRunOnce(t *testing.T) {
+	var token, count, id string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, count, id = r.Header.Get("X-Token"), r.Header.Get("X-Count"), r.Header.Get("X-Id")
+	}))
+	defer server.Close()
+
+	counter := filepath.Join(t.TempDir(), "runs")
+	m := hooksTestModel(t, &types.HttpRequest{
+		Name:   "Hooked",
+		Method: "GET",
+		URL:    server.URL,
+		Headers: map[string]string{
+			"X-Token": "{{token}}",
+			"X-Count": "$(echo run >> " + counter + " && wc -l < " + counter + ")",
+			"X-Id":    "{{$uuid}}",
+		},
+		BeforeHooks: []types.RequestHook{{Variable: "token", Command: "echo abc123"}},
+	})
+
+	if _, ok := m.executeRequest()().(requestExecutedMsg); !ok {
+		t.Fatal("Expected the request to complete")
+	}
+
+	runs, err := os.ReadFile(counter)
+	if err != nil {
+		t.Fatalf("Expected the shell command to run: %v", err)
+	}
+	AssertModelField(t, "shell command runs", strings.Count(string(runs), "run"), 1)
+	AssertModelField(t, "count sent", strings.TrimSpace(count), "1")
+	AssertModelField(t, "hook output sent", token, "abc123")
+	if len(id) != 36 {
+		t.Errorf("Expected a generated UUID, got %q", id)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/studiowebux/restcli/internal/analytics"
 	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/executor"
 	"github.com/studiowebux/restcli/internal/history"
 	"github.com/studiowebux/restcli/internal/jsonpath"
 	"github.com/studiowebux/restcli/internal/keybinds"
@@ -67,10 +68,17 @@ func New(mgr *session.Manager, version string) (Model, error) {
 		}
 	}
 
-	keybindRegistry, err := keybinds.LoadOrDefault(configPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: keybinds config error, using defaults: %v\n", err)
-		keybindRegistry = keybinds.NewDefaultRegistry()
+	// Layer the active profile's keybinds over the global config
+	keybindRegistry, keybindWarnings := loadKeybinds(configPath, mgr.GetActiveProfile())
+	for _, warning := range keybindWarnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+
+	// Apply the color theme (profile theme, then ~/.restcli/theme.json, then default)
+	theme, themeWarnings := loadTheme(mgr.GetActiveProfile())
+	applyTheme(theme)
+	for _, warning := range themeWarnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
 	}
 
 	// Initialize file explorer state
@@ -80,6 +88,12 @@ func New(mgr *session.Manager, version string) (Model, error) {
 	// Initialize documentation state
 	docState := NewDocumentationState()
 
+	// Initialize JSON tree state
+	jsonTreeState := NewJSONTreeState()
+
+	// Initialize table view state
+	tableViewState := NewTableViewState()
+
 	// Initialize history state
 	historyState := NewHistoryState()
 
@@ -114,6 +128,8 @@ func New(mgr *session.Manager, version string) (Model, error) {
 		analyticsState:    analyticsState,
 		stressTestState:   stressTestState,
 		docState:          docState,
+		jsonTreeState:     jsonTreeState,
+		tableViewState:    tableViewState,
 		profileEditState:  profileEditState,
 		mockServerState:   mockServerState,
 		proxyServerState:  proxyServerState,
@@ -124,6 +140,7 @@ func New(mgr *session.Manager, version string) (Model, error) {
 		focusedPanel:      "sidebar", // Start with sidebar focused
 		streamState:       &StreamState{},
 		requestState:      &RequestState{},
+		cookieJars:        make(map[string]*executor.CookieJar),
 		wsState:           &WebSocketState{},
 		responseView:      viewport.New(80, 20),
 		modalView:         viewport.New(80, 20), // For scrollable modals
@@ -209,11 +226,12 @@ func loadFiles(mgr *session.Manager) ([]types.FileInfo, error) {
 		}
 
 		ext := strings.ToLower(filepath.Ext(path))
-		if ext == ".http" || ext == ".yaml" || ext == ".yml" || ext == ".json" || ext == ".jsonc" || ext == ".ws" {
+		if ext == ".http" || ext == ".yaml" || ext == ".yml" || ext == ".json" || ext == ".jsonc" || ext == ".ws" || ext == ".graphql" || ext == ".grpc" {
 			relPath, _ := filepath.Rel(workdir, path)
 
 			// Parse file to get first HTTP method and tags
 			httpMethod := ""
+			url := ""
 			tags := []string{}
 
 			// WebSocket files are handled differently
@@ -222,15 +240,19 @@ func loadFiles(mgr *session.Manager) ([]types.FileInfo, error) {
 				httpMethod = "WS"
 
 				// Try to parse WebSocket file for tags
-				if wsReq, err := parser.ParseWebSocketFile(path); err == nil && wsReq.Documentation != nil {
-					for _, tag := range wsReq.Documentation.Tags {
-						tags = append(tags, tag)
+				if wsReq, err := parser.ParseWebSocketFile(path); err == nil {
+					url = wsReq.URL
+					if wsReq.Documentation != nil {
+						for _, tag := range wsReq.Documentation.Tags {
+							tags = append(tags, tag)
+						}
 					}
 				}
 			} else {
 				// Regular HTTP request files
 				if requests, err := parser.Parse(path); err == nil && len(requests) > 0 {
 					httpMethod = requests[0].Method
+					url = requests[0].URL
 
 					// Collect unique tags from all requests in file
 					tagSet := make(map[string]bool)
@@ -256,6 +278,7 @@ func loadFiles(mgr *session.Manager) ([]types.FileInfo, error) {
 				RequestCount: 0, // TODO(#TODO-002): Count requests in file - See TODO.md for details
 				ModifiedTime: info.ModTime(),
 				HTTPMethod:   httpMethod,
+				URL:          url,
 				Tags:         tags,
 			})
 		}
@@ -19,7 +19,7 @@ func (m *Model) initInteractiveVarPrompt() {
 
 	// Check if this is a multi-value variable
 	if profile != nil {
-		if varValue, exists := profile.Variables[currentVar]; exists && varValue.IsMultiValue() {
+		if varValue, exists := m.sessionMgr.ProfileVariables(profile)[currentVar]; exists && varValue.IsMultiValue() {
 			// Set up selection mode
 			m.interactiveVarMode = "select"
 			mv := varValue.MultiValue
@@ -239,7 +239,7 @@ func (m *Model) renderInteractiveVariablePrompt() string {
 	// Get variable info for title
 	var defaultValue string
 	if profile != nil {
-		if varValue, exists := profile.Variables[currentVar]; exists {
+		if varValue, exists := m.sessionMgr.ProfileVariables(profile)[currentVar]; exists {
 			defaultValue = varValue.GetValue()
 		}
 	}
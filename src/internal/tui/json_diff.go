@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// jsonChange is one difference between two JSON documents
+type jsonChange struct {
+	Kind string // "+" added, "-" removed, "~" changed
+	Path string // e.g. data.user.name or items[0]
+	Old  any
+	New  any
+}
+
+// String renders the change as "~ path: old -> new"
+func (c jsonChange) String() string {
+	switch c.Kind {
+	case "+":
+		return fmt.Sprintf("+ %s: %s", c.Path, formatJSONValue(c.New))
+	case "-":
+		return fmt.Sprintf("- %s: %s", c.Path, formatJSONValue(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, formatJSONValue(c.Old), formatJSONValue(c.New))
+	}
+}
+
+// parseJSONBody decodes a body, keeping numbers as written
+func parseJSONBody(body string) (any, bool) {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return nil, false
+	}
+	return value, true
+}
+
+// diffJSON compares two bodies as JSON, ignoring key order
+// Returns false when either body is not JSON
+func diffJSON(pinned, current string) ([]jsonChange, bool) {
+	pinnedValue, ok := parseJSONBody(pinned)
+	if !ok {
+		return nil, false
+	}
+	currentValue, ok := parseJSONBody(current)
+	if !ok {
+		return nil, false
+	}
+
+	var changes []jsonChange
+	collectJSONChanges("", pinnedValue, currentValue, &changes)
+	return changes, true
+}
+
+// collectJSONChanges walks both values and appends the differences under path
+func collectJSONChanges(path string, pinned, current any, changes *[]jsonChange) {
+	switch p := pinned.(type) {
+	case map[string]any:
+		if c, ok := current.(map[string]any); ok {
+			keys := make([]string, 0, len(p)+len(c))
+			for key := range p {
+				keys = append(keys, key)
+			}
+			for key := range c {
+				if _, ok := p[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				childPath := jsonChildPath(path, key)
+				pinnedChild, inPinned := p[key]
+				currentChild, inCurrent := c[key]
+				switch {
+				case !inCurrent:
+					*changes = append(*changes, jsonChange{Kind: "-", Path: childPath, Old: pinnedChild})
+				case !inPinned:
+					*changes = append(*changes, jsonChange{Kind: "+", Path: childPath, New: currentChild})
+				default:
+					collectJSONChanges(childPath, pinnedChild, currentChild, changes)
+				}
+			}
+			return
+		}
+
+	case []any:
+		if c, ok := current.([]any); ok {
+			for i := 0; i < max(len(p), len(c)); i++ {
+				childPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(c):
+					*changes = append(*changes, jsonChange{Kind: "-", Path: childPath, Old: p[i]})
+				case i >= len(p):
+					*changes = append(*changes, jsonChange{Kind: "+", Path: childPath, New: c[i]})
+				default:
+					collectJSONChanges(childPath, p[i], c[i], changes)
+				}
+			}
+			return
+		}
+	}
+
+	if formatJSONValue(pinned) != formatJSONValue(current) {
+		if path == "" {
+			path = "(root)"
+		}
+		*changes = append(*changes, jsonChange{Kind: "~", Path: path, Old: pinned, New: current})
+	}
+}
+
+// jsonIdentifier matches object keys that can be written as .key in a path
+var jsonIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$-]*$`)
+
+// jsonChildPath appends an object key to a path, quoting keys that are not identifiers
+func jsonChildPath(path, key string) string {
+	if !jsonIdentifier.MatchString(key) {
+		quoted, _ := json.Marshal(key)
+		return fmt.Sprintf("%s[%s]", path, quoted)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// formatJSONValue renders a value as compact JSON, objects with sorted keys
+func formatJSONValue(value any) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// normalizeJSONBody pretty-prints a JSON body with sorted keys so line diffs ignore key order
+// Returns the body unchanged when it is not JSON
+func normalizeJSONBody(body string) string {
+	value, ok := parseJSONBody(body)
+	if !ok {
+		return body
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return body
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestDiffJSON(t *testing.T) {
+	pinned := `{"data": {"user": {"name": "a", "age": 1}, "tags": ["x", "y"]}, "id": 1, "old": true}`
+	current := `{"id": 1, "data": {"tags": ["x"], "user": {"age": 1, "name": "b"}}, "new": null, "a.b": 2}`
+
+	changes, ok := diffJSON(pinned, current)
+	if !ok {
+		t.Fatal("Expected both bodies to parse as JSON")
+	}
+
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.String())
+	}
+	want := []string{
+		`+ ["a.b"]: 2`,
+		`- data.tags[1]: "y"`,
+		`~ data.user.name: "a" -> "b"`,
+		`+ new: null`,
+		`- old: true`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if changes, ok := diffJSON(`{"a":1,"b":[1,2]}`, `{"b":[1,2],"a":1}`); !ok || len(changes) != 0 {
+		t.Errorf("Expected reordered keys to be equal, got %v", changes)
+	}
+	if changes, _ := diffJSON(`{"a":1}`, `[1]`); len(changes) != 1 || changes[0].Path != "(root)" {
+		t.Errorf("Expected a type change at the root, got %v", changes)
+	}
+	if _, ok := diffJSON(`{"a":1}`, `not json`); ok {
+		t.Error("Expected non-JSON bodies to be rejected")
+	}
+}
+
+func TestDiffModal_SemanticToggle(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	m.pinnedResponse = &types.RequestResult{Status: 200, Body: `{"name": "a", "id": 1}`}
+	m.currentResponse = &types.RequestResult{Status: 200, Body: `{"id": 1, "name": "b"}`}
+	m.mode = ModeDiff
+	m.updateDiffView()
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	AssertModelField(t, "diffSemantic", true, m.diffSemantic)
+	if body := m.compareBodies(); !strings.Contains(body, `~ name: "a" -> "b"`) || strings.Contains(body, "id") {
+		t.Errorf("Expected only the changed path, got %q", body)
+	}
+
+	m.currentResponse.Body = "plain text"
+	if body := m.compareBodies(); !strings.Contains(body, "not JSON") {
+		t.Errorf("Expected the line diff fallback, got %q", body)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	AssertModelField(t, "diffSemantic", false, m.diffSemantic)
+}
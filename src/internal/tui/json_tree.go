@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/studiowebux/restcli/internal/keybinds"
+)
+
+// JSON Tree View - Collapsible response body
+//
+// Renders the (filtered) response body as a tree where objects and arrays can be
+// folded with enter/space. Containers below the root start collapsed and their
+// children are only materialized when first expanded, so opening a large
+// response only builds the top level.
+
+// maxJSONTreeDepth bounds recursion for pathologically nested documents
+const maxJSONTreeDepth = 100
+
+// toggleJSONTree switches the response body between the pretty-printed text and the tree view
+func (m *Model) toggleJSONTree() {
+	if m.focusedPanel != "response" {
+		m.statusMsg = "Focus the response panel (TAB) to open the JSON tree"
+		return
+	}
+	if m.currentResponse == nil || m.currentResponse.Body == "" {
+		m.statusMsg = "No response to show as tree"
+		return
+	}
+
+	if m.jsonTreeState.IsActive() {
+		m.jsonTreeState.SetActive(false)
+		m.statusMsg = "JSON tree closed"
+	} else {
+		if err := m.jsonTreeState.Load(m.responseBodySource()); err != nil {
+			m.statusMsg = "Response body is not JSON"
+			return
+		}
+		m.jsonTreeState.SetActive(true)
+		m.tableViewState.SetActive(false)
+		m.hexView = false
+		m.statusMsg = "JSON tree: enter/space to expand/collapse, z to close"
+	}
+
+	m.cachedResponsePtr = nil // Tree and text views share the response cache
+	m.updateResponseView()
+}
+
+// isJSONTreeFocused returns true when keys should drive the tree instead of the response viewport
+func (m *Model) isJSONTreeFocused() bool {
+	return m.focusedPanel == "response" && m.showBody &&
+		m.jsonTreeState.IsActive() && m.jsonTreeState.Root() != nil
+}
+
+// toggleSelectedJSONNode expands or collapses the selected object or array
+func (m *Model) toggleSelectedJSONNode() {
+	node := m.jsonTreeState.GetSelectedNode()
+	if node == nil || !node.isContainer() {
+		return
+	}
+	m.jsonTreeState.ToggleCollapsed(node.id)
+	m.updateResponseView()
+}
+
+// handleJSONTreeNavigation moves the tree selection for navigation actions
+// Returns true if the action was handled
+func (m *Model) handleJSONTreeNavigation(action keybinds.Action) bool {
+	count := m.jsonTreeState.GetItemCount()
+	pageSize := m.responseView.Height
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	switch action {
+	case keybinds.ActionNavigateUp:
+		m.jsonTreeState.Navigate(-1, count)
+	case keybinds.ActionNavigateDown:
+		m.jsonTreeState.Navigate(1, count)
+	case keybinds.ActionPageUp:
+		m.jsonTreeState.Navigate(-pageSize, count)
+	case keybinds.ActionPageDown:
+		m.jsonTreeState.Navigate(pageSize, count)
+	case keybinds.ActionHalfPageUp:
+		m.jsonTreeState.Navigate(-pageSize/2, count)
+	case keybinds.ActionHalfPageDown:
+		m.jsonTreeState.Navigate(pageSize/2, count)
+	case keybinds.ActionGoToTop:
+		m.jsonTreeState.SetSelectedIdx(0)
+	case keybinds.ActionGoToBottom:
+		m.jsonTreeState.SetSelectedIdx(max(count-1, 0))
+	default:
+		return false
+	}
+
+	m.updateResponseView()
+	return true
+}
+
+// responseBodySource returns the body shown in the response panel (filtered when a filter is active, decoded when binary)
+func (m *Model) responseBodySource() string {
+	if m.filterActive && m.filteredResponse != "" {
+		return m.filteredResponse
+	}
+	return m.responseBodyText()
+}
+
+// renderJSONTree writes the visible nodes of the loaded tree into content
+// Returns the line of the selected node within content, or -1
+func (m *Model) renderJSONTree(content *strings.Builder, width int) int {
+	selectedIdx := m.jsonTreeState.GetSelectedIdx()
+	selectedLine := -1
+	var visible []*jsonTreeNode
+
+	var render func(node *jsonTreeNode)
+	render = func(node *jsonTreeNode) {
+		if node.depth > maxJSONTreeDepth {
+			return
+		}
+
+		collapsed := m.jsonTreeState.GetCollapsed(node.id)
+		line := formatJSONTreeLine(node, collapsed, width)
+		if len(visible) == selectedIdx {
+			line = styleSelected.Render(line)
+			selectedLine = strings.Count(content.String(), "\n")
+		}
+		content.WriteString(line + "\n")
+		visible = append(visible, node)
+
+		if node.isContainer() && !collapsed {
+			for _, child := range m.jsonTreeState.Children(node) {
+				render(child)
+			}
+		}
+	}
+
+	if root := m.jsonTreeState.Root(); root != nil {
+		render(root)
+	}
+	m.jsonTreeState.SetVisible(visible)
+	return selectedLine
+}
+
+// formatJSONTreeLine renders one node: indentation, fold marker, key and value or summary
+func formatJSONTreeLine(node *jsonTreeNode, collapsed bool, width int) string {
+	indent := strings.Repeat("  ", node.depth)
+
+	marker := "  "
+	if node.isContainer() {
+		marker = "▼ "
+		if collapsed {
+			marker = "▶ "
+		}
+	}
+
+	label := ""
+	if node.key != "" {
+		label = styleTitle.Render(node.key) + ": "
+	}
+
+	var value string
+	switch v := node.value.(type) {
+	case map[string]any:
+		value = styleSubtle.Render(fmt.Sprintf("{%s}", pluralize(node.size(), "key", "keys")))
+	case []any:
+		value = styleSubtle.Render(fmt.Sprintf("[%s]", pluralize(node.size(), "item", "items")))
+	case string:
+		// Long scalars are cut to the panel width instead of wrapped so each node stays one line
+		available := width - len(indent) - len(marker) - len(node.key) - 2
+		value = styleSuccess.Render(truncateRunes(strconv.Quote(v), max(available, 10)))
+	case json.Number:
+		value = styleWarning.Render(v.String())
+	case bool:
+		value = styleWarning.Render(strconv.FormatBool(v))
+	case nil:
+		value = styleSubtle.Render("null")
+	}
+
+	return indent + marker + label + value
+}
+
+// pluralize formats a count with the singular or plural noun
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// truncateRunes truncates a string to maxLen runes, ending with "..." when cut
+func truncateRunes(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// jsonTreeNode is one value of a parsed JSON document shown in the response tree
+type jsonTreeNode struct {
+	id    int    // Collapse key, unique within the loaded document
+	key   string // Object key or array index, empty for the root
+	value any
+	depth int
+}
+
+// isContainer returns true for objects and arrays
+func (n *jsonTreeNode) isContainer() bool {
+	switch n.value.(type) {
+	case map[string]any, []any:
+		return true
+	}
+	return false
+}
+
+// size returns the number of keys or items of a container
+func (n *jsonTreeNode) size() int {
+	switch v := n.value.(type) {
+	case map[string]any:
+		return len(v)
+	case []any:
+		return len(v)
+	}
+	return 0
+}
+
+// JSONTreeState encapsulates the collapsible JSON tree view of the response panel
+// Collapse state, selection and item count reuse the documentation viewer's state,
+// keyed by node ID instead of documentation field hashes
+type JSONTreeState struct {
+	*DocumentationState
+
+	mu       sync.RWMutex
+	active   bool // Response body is shown as a tree
+	loaded   bool
+	source   string                  // Body the tree was built from
+	loadErr  error                   // Parse error of source
+	root     *jsonTreeNode           // Nil when source is not valid JSON
+	children map[int][]*jsonTreeNode // Materialized children per node ID
+	nextID   int
+	visible  []*jsonTreeNode // Nodes in display order, index matches the selection
+}
+
+// NewJSONTreeState creates a new JSON tree state
+func NewJSONTreeState() *JSONTreeState {
+	return &JSONTreeState{
+		DocumentationState: NewDocumentationState(),
+		children:           make(map[int][]*jsonTreeNode),
+	}
+}
+
+// IsActive returns whether the tree view is enabled
+func (s *JSONTreeState) IsActive() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// SetActive enables or disables the tree view
+func (s *JSONTreeState) SetActive(active bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = active
+}
+
+// Load parses source into a tree, keeping the current tree when source is unchanged
+// Returns an error when source is not valid JSON
+func (s *JSONTreeState) Load(source string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded && s.source == source {
+		return s.loadErr
+	}
+
+	s.loaded = true
+	s.source = source
+	s.loadErr = nil
+	s.root = nil
+	s.children = make(map[int][]*jsonTreeNode)
+	s.nextID = 0
+	s.visible = nil
+	s.DocumentationState.Reset()
+
+	// UseNumber keeps large integers and number formatting intact
+	decoder := json.NewDecoder(strings.NewReader(source))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		s.loadErr = fmt.Errorf("failed to parse JSON: %w", err)
+		return s.loadErr
+	}
+	if decoder.More() {
+		s.loadErr = fmt.Errorf("failed to parse JSON: unexpected data after top-level value")
+		return s.loadErr
+	}
+
+	s.root = s.newNode("", value, 0)
+	return nil
+}
+
+// newNode allocates a node ID; containers below the root start collapsed
+// Must be called with the lock held
+func (s *JSONTreeState) newNode(key string, value any, depth int) *jsonTreeNode {
+	node := &jsonTreeNode{id: s.nextID, key: key, value: value, depth: depth}
+	s.nextID++
+	if depth > 0 && node.isContainer() {
+		s.DocumentationState.SetCollapsed(node.id, true)
+	}
+	return node
+}
+
+// Root returns the root node, or nil when no valid JSON is loaded
+func (s *JSONTreeState) Root() *jsonTreeNode {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.root
+}
+
+// Children returns the children of a container, materializing them on first access
+// Object keys are sorted to match the pretty-printed body
+func (s *JSONTreeState) Children(node *jsonTreeNode) []*jsonTreeNode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if children, ok := s.children[node.id]; ok {
+		return children
+	}
+
+	var children []*jsonTreeNode
+	switch v := node.value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			children = append(children, s.newNode(key, v[key], node.depth+1))
+		}
+	case []any:
+		for i, item := range v {
+			children = append(children, s.newNode(fmt.Sprintf("[%d]", i), item, node.depth+1))
+		}
+	}
+	s.children[node.id] = children
+	return children
+}
+
+// SetVisible stores the nodes in display order after rendering
+func (s *JSONTreeState) SetVisible(nodes []*jsonTreeNode) {
+	s.mu.Lock()
+	s.visible = nodes
+	s.mu.Unlock()
+	s.SetItemCount(len(nodes))
+}
+
+// GetSelectedNode returns the node under the selection, or nil
+func (s *JSONTreeState) GetSelectedNode() *jsonTreeNode {
+	idx := s.GetSelectedIdx()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if idx < 0 || idx >= len(s.visible) {
+		return nil
+	}
+	return s.visible[idx]
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONTreeState_Load(t *testing.T) {
+	state := NewJSONTreeState()
+
+	if err := state.Load(`{"b": [1, 2, 3], "a": {"id": 12345678901234567890}}`); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	root := state.Root()
+	if root == nil || root.size() != 2 {
+		t.Fatalf("Expected root object with 2 keys, got: %+v", root)
+	}
+	if state.GetCollapsed(root.id) {
+		t.Error("Expected root to start expanded")
+	}
+
+	// Children are sorted by key and containers start collapsed
+	children := state.Children(root)
+	if len(children) != 2 || children[0].key != "a" || children[1].key != "b" {
+		t.Fatalf("Unexpected children: %+v", children)
+	}
+	for _, child := range children {
+		if !state.GetCollapsed(child.id) {
+			t.Errorf("Expected %s to start collapsed", child.key)
+		}
+	}
+
+	// Children are materialized once
+	if again := state.Children(root); again[0] != children[0] {
+		t.Error("Expected cached children on second access")
+	}
+
+	// Large integers are kept as written
+	id := state.Children(children[0])[0]
+	if line := formatJSONTreeLine(id, false, 80); !strings.Contains(line, "12345678901234567890") {
+		t.Errorf("Expected number to keep its digits, got: %q", line)
+	}
+
+	// Reloading the same source keeps collapse state
+	state.SetCollapsed(children[1].id, false)
+	if err := state.Load(`{"b": [1, 2, 3], "a": {"id": 12345678901234567890}}`); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if state.GetCollapsed(children[1].id) {
+		t.Error("Expected collapse state to survive reloading the same source")
+	}
+
+	// A new source resets the tree
+	if err := state.Load(`[1]`); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if state.Root().size() != 1 || state.GetSelectedIdx() != 0 {
+		t.Error("Expected tree to be rebuilt for a new source")
+	}
+
+	for _, source := range []string{"not json", `{"a": 1} {"b": 2}`} {
+		if err := state.Load(source); err == nil {
+			t.Errorf("Expected an error for %q", source)
+		}
+		if state.Root() != nil {
+			t.Errorf("Expected no root for %q", source)
+		}
+	}
+}
+
+func TestJSONTreeState_SelectedNode(t *testing.T) {
+	state := NewJSONTreeState()
+	if err := state.Load(`{"a": 1, "b": 2}`); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	root := state.Root()
+	state.SetVisible(append([]*jsonTreeNode{root}, state.Children(root)...))
+
+	if state.GetItemCount() != 3 {
+		t.Errorf("Expected 3 visible items, got %d", state.GetItemCount())
+	}
+	state.Navigate(2, state.GetItemCount())
+	if node := state.GetSelectedNode(); node == nil || node.key != "b" {
+		t.Errorf("Expected node b selected, got: %+v", node)
+	}
+	state.Navigate(5, state.GetItemCount())
+	if node := state.GetSelectedNode(); node == nil || node.key != "b" {
+		t.Errorf("Expected selection clamped to last node, got: %+v", node)
+	}
+}
+
+func TestFormatJSONTreeLine(t *testing.T) {
+	array := &jsonTreeNode{key: "items", value: []any{1.0, 2.0}, depth: 1}
+	if line := formatJSONTreeLine(array, true, 80); !strings.Contains(line, "▶") || !strings.Contains(line, "[2 items]") {
+		t.Errorf("Expected collapsed array summary, got: %q", line)
+	}
+	object := &jsonTreeNode{value: map[string]any{"a": nil}}
+	if line := formatJSONTreeLine(object, false, 80); !strings.Contains(line, "▼") || !strings.Contains(line, "{1 key}") {
+		t.Errorf("Expected expanded object summary, got: %q", line)
+	}
+	long := &jsonTreeNode{key: "s", value: strings.Repeat("é", 200)}
+	if line := formatJSONTreeLine(long, false, 40); !strings.Contains(line, "...") {
+		t.Errorf("Expected long string to be truncated, got: %q", line)
+	}
+}
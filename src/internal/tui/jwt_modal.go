@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/decoder"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/parser"
+)
+
+// jwtToken is a token found for the JWT viewer, with where it came from
+type jwtToken struct {
+	Source string // "Authorization header" or "variable <name>"
+	Value  string
+}
+
+// findJWTs collects the tokens of the current Authorization header and of the session variables
+// Any bearer value of the header is listed (so a malformed token shows its error),
+// variables only when they have the shape of a JWT.
+func (m *Model) findJWTs() []jwtToken {
+	var tokens []jwtToken
+	seen := make(map[string]bool)
+
+	if m.currentRequest != nil {
+		profile, _ := m.sessionMgr.ProfileForRequest(m.currentRequest)
+		headers := make(map[string]string)
+		for k, v := range profile.Headers {
+			headers[k] = v
+		}
+		for k, v := range m.currentRequest.Headers {
+			headers[k] = v
+		}
+		m.injectOAuthToken(profile, headers)
+
+		for name, value := range headers {
+			if !strings.EqualFold(name, "Authorization") {
+				continue
+			}
+			resolver := parser.NewVariableResolver(m.sessionMgr.ProfileVariables(profile), m.sessionMgr.GetSession().Variables, m.interactiveVarValues, parser.LoadSystemEnv())
+			if resolved, err := resolver.Resolve(value); err == nil {
+				value = resolved
+			}
+			scheme, token, found := strings.Cut(strings.TrimSpace(value), " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") {
+				if !decoder.LooksLikeJWT(value) {
+					continue // Basic or another scheme
+				}
+				token = value
+			}
+			token = strings.TrimSpace(token)
+			tokens = append(tokens, jwtToken{Source: "Authorization header", Value: token})
+			seen[token] = true
+		}
+	}
+
+	variables := m.sessionMgr.GetSession().Variables
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.TrimSpace(variables[name])
+		if seen[value] || !decoder.LooksLikeJWT(value) {
+			continue
+		}
+		tokens = append(tokens, jwtToken{Source: "variable " + name, Value: value})
+		seen[value] = true
+	}
+	return tokens
+}
+
+// openJWTViewer opens the JWT viewer, or reports that no token was found
+func (m *Model) openJWTViewer() {
+	m.jwtTokens = m.findJWTs()
+	if len(m.jwtTokens) == 0 {
+		m.statusMsg = "No JWT in the Authorization header or session variables"
+		return
+	}
+	m.jwtIndex = 0
+	m.mode = ModeJWT
+	m.modalView.SetYOffset(0)
+}
+
+// jwtContent builds the decoded view of the selected token
+func (m *Model) jwtContent(now time.Time) string {
+	if len(m.jwtTokens) == 0 {
+		return styleSubtle.Render("No token")
+	}
+	token := m.jwtTokens[m.jwtIndex]
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("Source: %s (%d/%d)\n\n", token.Source, m.jwtIndex+1, len(m.jwtTokens)))
+
+	jwt, err := decoder.DecodeJWT(token.Value)
+	if err != nil {
+		content.WriteString(styleError.Render("Malformed JWT: "+err.Error()) + "\n\n")
+		content.WriteString(styleSubtle.Render(wrapText(token.Value, m.width-ModalWidthMargin-6)))
+		return content.String()
+	}
+
+	status := jwt.ExpiryStatus(now)
+	switch {
+	case jwt.Expired(now):
+		status = styleError.Render(status)
+	case jwt.ExpiresAt != nil && jwt.ExpiresAt.Sub(now) < 5*time.Minute:
+		status = styleWarning.Render(status)
+	default:
+		status = styleSuccess.Render(status)
+	}
+	content.WriteString("Status: " + status + "\n")
+	for _, claim := range []struct {
+		label string
+		at    *time.Time
+	}{{"Issued at", jwt.IssuedAt}, {"Not before", jwt.NotBefore}, {"Expires at", jwt.ExpiresAt}} {
+		if claim.at != nil {
+			content.WriteString(fmt.Sprintf("%s: %s\n", claim.label, claim.at.Local().Format("2006-01-02 15:04:05 MST")))
+		}
+	}
+	content.WriteString(styleSubtle.Render(fmt.Sprintf("Signature: %d bytes, not verified", jwt.Signature)) + "\n\n")
+
+	content.WriteString(styleTitle.Render("Header") + "\n")
+	content.WriteString(jwt.Header + "\n\n")
+	content.WriteString(styleTitle.Render("Claims") + "\n")
+	content.WriteString(jwt.Claims + "\n")
+	return content.String()
+}
+
+// renderJWTModal renders the JWT viewer
+func (m *Model) renderJWTModal() string {
+	width := m.width - ModalWidthMargin
+	height := m.height - ModalOverheadMinimal
+	if width < 50 {
+		width = 50
+	}
+	if height < 10 {
+		height = 10
+	}
+
+	footer := "j/k: scroll | ESC: close"
+	if len(m.jwtTokens) > 1 {
+		footer = "tab: next token | " + footer
+	}
+	return m.renderModalWithFooter("JWT", m.jwtContent(time.Now()), footer, width, height)
+}
+
+// handleJWTKeys handles keyboard input in the JWT viewer
+func (m *Model) handleJWTKeys(msg tea.KeyMsg) tea.Cmd {
+	// Cycle tokens (not in registry)
+	switch msg.String() {
+	case "tab":
+		m.jwtIndex = (m.jwtIndex + 1) % len(m.jwtTokens)
+		m.modalView.SetYOffset(0)
+		return nil
+	case "shift+tab":
+		m.jwtIndex = (m.jwtIndex + len(m.jwtTokens) - 1) % len(m.jwtTokens)
+		m.modalView.SetYOffset(0)
+		return nil
+	}
+
+	action, ok := m.keybinds.Match(keybinds.ContextModal, msg.String())
+	if !ok {
+		return nil
+	}
+
+	switch action {
+	case keybinds.ActionCloseModal:
+		m.mode = ModeNormal
+
+	case keybinds.ActionNavigateDown:
+		m.modalView.LineDown(1)
+
+	case keybinds.ActionNavigateUp:
+		m.modalView.LineUp(1)
+
+	case keybinds.ActionPageDown:
+		m.modalView.PageDown()
+
+	case keybinds.ActionPageUp:
+		m.modalView.PageUp()
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// keybindsConfigPath returns the keybinds.json path (replaced in tests)
+var keybindsConfigPath = keybinds.GetDefaultConfigPath
+
+// exportKeybinds writes the active keybindings (defaults and overrides) to keybinds.json
+// The file then lists every binding, ready to be edited
+func (m *Model) exportKeybinds() tea.Cmd {
+	path, err := keybindsConfigPath()
+	if err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to locate keybinds.json: %v", err))
+	}
+
+	exported := m.keybinds.Export()
+	if err := keybinds.SaveConfig(exported, path); err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to export keybindings: %v", err))
+	}
+	return m.setStatusMessage(fmt.Sprintf("Exported %d keybindings to %s", exported.Count(), path))
+}
+
+// loadKeybinds builds the registry for a profile: profile keybinds > global keybinds.json > defaults
+// A broken profile file falls back to the global config, a broken global config to the defaults
+func loadKeybinds(globalPath string, profile *types.Profile) (*keybinds.Registry, []string) {
+	var warnings []string
+
+	profilePath := ""
+	if profile != nil && profile.Keybinds != "" {
+		path, err := config.ResolvePath(profile.Keybinds)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("profile keybinds ignored: %v", err))
+		} else {
+			profilePath = path
+		}
+	}
+
+	registry, reserved, err := keybinds.LoadLayered(globalPath, profilePath)
+	if err != nil && profilePath != "" {
+		warnings = append(warnings, fmt.Sprintf("%v, using global keybindings", err))
+		registry, reserved, err = keybinds.LoadLayered(globalPath, "")
+	}
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("keybinds config error, using defaults: %v", err))
+		registry, reserved = keybinds.NewDefaultRegistry(), nil
+	}
+
+	for _, binding := range reserved {
+		warnings = append(warnings, fmt.Sprintf("'%s' is reserved and cannot be rebound in %s", binding.Key, binding.Context))
+	}
+	return registry, warnings
+}
+
+// reloadKeybinds rebuilds the registry after the active profile changed
+func (m *Model) reloadKeybinds() tea.Cmd {
+	globalPath, err := keybindsConfigPath()
+	if err != nil {
+		globalPath = ""
+	}
+
+	registry, warnings := loadKeybinds(globalPath, m.sessionMgr.GetActiveProfile())
+	m.keybinds = registry
+	m.whichKey = nil
+	if len(warnings) > 0 {
+		return m.setErrorMessage(strings.Join(warnings, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestExportKeybinds_WritesActiveBindings(t *testing.T) {
+	m := CreateTestModel(t)
+	m.keybinds.Register(keybinds.ContextNormal, "gh", keybinds.ActionOpenHistory)
+
+	path := filepath.Join(t.TempDir(), "keybinds.json")
+	original := keybindsConfigPath
+	keybindsConfigPath = func() (string, error) { return path, nil }
+	defer func() { keybindsConfigPath = original }()
+
+	m.exportKeybinds()
+
+	if !strings.HasPrefix(m.statusMsg, "Exported ") {
+		t.Fatalf("Expected an export status, got %q (error %q)", m.statusMsg, m.errorMsg)
+	}
+	config, err := keybinds.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.Normal["gh"] != string(keybinds.ActionOpenHistory) || config.Normal["gg"] != string(keybinds.ActionGoToTop) {
+		t.Errorf("Expected defaults and overrides in the export, got %v", config.Normal)
+	}
+}
+
+func TestProfileSwitch_ReloadsKeybinds(t *testing.T) {
+	m := CreateTestModel(t)
+	dir := t.TempDir()
+
+	originalProfilesFile, originalSessionFile := config.ProfilesFile, config.SessionFile
+	config.ProfilesFile = filepath.Join(dir, ".profiles.json")
+	config.SessionFile = filepath.Join(dir, ".session.json")
+	original := keybindsConfigPath
+	keybindsConfigPath = func() (string, error) { return filepath.Join(dir, "keybinds.json"), nil }
+	t.Cleanup(func() {
+		config.ProfilesFile, config.SessionFile = originalProfilesFile, originalSessionFile
+		keybindsConfigPath = original
+	})
+
+	profilePath := filepath.Join(dir, "project-keybinds.json")
+	if err := keybinds.SaveConfig(&keybinds.Config{Normal: map[string]string{"x": string(keybinds.ActionOpenHistory)}}, profilePath); err != nil {
+		t.Fatal(err)
+	}
+	m.sessionMgr.AddProfile(types.Profile{Name: "Default"})
+	m.sessionMgr.AddProfile(types.Profile{Name: "Project", Keybinds: profilePath})
+
+	switchTo := func(name string) {
+		for i, profile := range m.sessionMgr.GetProfiles() {
+			if profile.Name == name {
+				m.profileIndex = i
+			}
+		}
+		m.mode = ModeProfileSwitch
+		m.handleProfileSwitchKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	switchTo("Project")
+	if action, _ := m.keybinds.Match(keybinds.ContextNormal, "x"); action != keybinds.ActionOpenHistory {
+		t.Errorf("Expected the profile keybinds after switching, got %q", action)
+	}
+
+	switchTo("Default")
+	if action, _ := m.keybinds.Match(keybinds.ContextNormal, "x"); action != keybinds.ActionOpenEditor {
+		t.Errorf("Expected the default keybinds after switching back, got %q", action)
+	}
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keychain"
+)
+
+// keychainSet writes secrets to the OS keychain (replaced in tests)
+var keychainSet = keychain.Set
+
+// variableStoredInKeychainMsg is sent once a variable's value has been written to the keychain
+type variableStoredInKeychainMsg struct {
+	profile   string
+	name      string
+	reference string
+}
+
+// storeVariableInKeychain writes a profile variable's value to the OS keychain
+// The profile value is replaced with the {{keychain:...}} reference once the write succeeds
+func (m *Model) storeVariableInKeychain(name string) tea.Cmd {
+	profile := m.sessionMgr.GetActiveProfile()
+	value, ok := profile.Variables[name]
+	if !ok {
+		return nil
+	}
+	if value.IsMultiValue() {
+		return m.setErrorMessage("Multi-value variables cannot be stored in the keychain")
+	}
+
+	secret := value.GetValue()
+	if strings.Contains(secret, "{{"+keychain.ReferencePrefix) {
+		return m.setErrorMessage(fmt.Sprintf("Variable '%s' is already stored in the keychain", name))
+	}
+
+	profileName := profile.Name
+	account := profileName + "/" + name
+	m.statusMsg = fmt.Sprintf("Storing '%s' in keychain...", name)
+
+	return func() tea.Msg {
+		if err := keychainSet(keychain.Service, account, secret); err != nil {
+			return errorMsg(fmt.Sprintf("Failed to store '%s' in keychain: %v", name, err))
+		}
+		return variableStoredInKeychainMsg{
+			profile:   profileName,
+			name:      name,
+			reference: keychain.Reference(keychain.Service, account),
+		}
+	}
+}
+
+// handleVariableStoredInKeychain replaces the plaintext profile value with its keychain reference
+func (m *Model) handleVariableStoredInKeychain(msg variableStoredInKeychainMsg) tea.Cmd {
+	profile := m.sessionMgr.GetActiveProfile()
+	if profile.Name != msg.profile {
+		return m.setErrorMessage(fmt.Sprintf("Profile changed: '%s' is in the keychain but %s still has its plaintext value", msg.name, msg.profile))
+	}
+
+	value := profile.Variables[msg.name]
+	value.SetValue(msg.reference)
+	profile.Variables[msg.name] = value
+	if err := m.sessionMgr.SaveProfiles(); err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to save profile: %v", err))
+	}
+
+	return m.setStatusMessage(fmt.Sprintf("Variable '%s' saved to keychain", msg.name))
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// setupKeychainProfile adds a profile (active as the only one) with a plaintext token and stubs the keychain writer
+func setupKeychainProfile(t *testing.T, m *Model, setErr error) map[string]string {
+	t.Helper()
+
+	originalProfilesFile := config.ProfilesFile
+	config.ProfilesFile = filepath.Join(t.TempDir(), ".profiles.json")
+	t.Cleanup(func() { config.ProfilesFile = originalProfilesFile })
+
+	stored := make(map[string]string)
+	originalSet := keychainSet
+	keychainSet = func(service, account, secret string) error {
+		if setErr != nil {
+			return setErr
+		}
+		stored[service+"/"+account] = secret
+		return nil
+	}
+	t.Cleanup(func() { keychainSet = originalSet })
+
+	token := "s3cr3t"
+	profile := types.Profile{Name: "Dev", Variables: map[string]types.VariableValue{"token": {StringValue: &token}}}
+	if err := m.sessionMgr.AddProfile(profile); err != nil {
+		t.Fatalf("Failed to add profile: %v", err)
+	}
+	return stored
+}
+
+func TestStoreVariableInKeychain(t *testing.T) {
+	m := CreateTestModel(t)
+	stored := setupKeychainProfile(t, m, nil)
+
+	msg, ok := m.storeVariableInKeychain("token")().(variableStoredInKeychainMsg)
+	if !ok {
+		t.Fatal("Expected variableStoredInKeychainMsg")
+	}
+	m.handleVariableStoredInKeychain(msg)
+
+	AssertModelField(t, "keychain secret", stored["restcli/Dev/token"], "s3cr3t")
+	value := m.sessionMgr.GetActiveProfile().Variables["token"]
+	AssertModelField(t, "profile value", value.GetValue(), "{{keychain:restcli/Dev/token}}")
+
+	// A reference is not stored a second time
+	m.storeVariableInKeychain("token")
+	if m.errorMsg == "" {
+		t.Error("Expected an error when the variable is already in the keychain")
+	}
+}
+
+func TestStoreVariableInKeychain_FailureKeepsValue(t *testing.T) {
+	m := CreateTestModel(t)
+	setupKeychainProfile(t, m, fmt.Errorf("keychain is locked"))
+
+	if _, ok := m.storeVariableInKeychain("token")().(errorMsg); !ok {
+		t.Fatal("Expected an errorMsg when the keychain write fails")
+	}
+
+	value := m.sessionMgr.GetActiveProfile().Variables["token"]
+	AssertModelField(t, "profile value", value.GetValue(), "s3cr3t")
+}
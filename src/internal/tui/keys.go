@@ -8,6 +8,7 @@ import (
 
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/analytics"
 	"github.com/studiowebux/restcli/internal/filter"
 	"github.com/studiowebux/restcli/internal/keybinds"
 	"github.com/studiowebux/restcli/internal/parser"
@@ -47,6 +48,8 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return m.handleHeaderEditorKeys(msg)
 	case ModeProfileSwitch, ModeProfileCreate, ModeProfileEdit, ModeProfileDuplicate, ModeProfileDeleteConfirm:
 		return m.handleProfileKeys(msg)
+	case ModeProfileExport, ModeProfileImport:
+		return m.handleProfileTransferKeys(msg)
 	case ModeDocumentation:
 		return m.handleDocumentationKeys(msg)
 	case ModeHistory:
@@ -83,12 +86,28 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return m.handleDeleteKeys(msg)
 	case ModeConfirmExecution:
 		return m.handleConfirmExecutionKeys(msg)
+	case ModeSaveRequestConfirm:
+		return m.handleSaveRequestConfirmKeys(msg)
+	case ModeBatchResults:
+		return m.handleBatchResultsKeys(msg)
 	case ModeShellErrors:
 		return m.handleShellErrorsKeys(msg)
 	case ModeErrorDetail:
 		return m.handleErrorDetailKeys(msg)
 	case ModeStatusDetail:
 		return m.handleStatusDetailKeys(msg)
+	case ModeCookies:
+		return m.handleCookiesKeys(msg)
+	case ModeJWT:
+		return m.handleJWTKeys(msg)
+	case ModeCopyAs:
+		return m.handleCopyAsKeys(msg)
+	case ModeVariableSwitcher:
+		return m.handleVariableSwitcherKeys(msg)
+	case ModeFileFinder:
+		return m.handleFileFinderKeys(msg)
+	case ModeOAuthDevice:
+		return m.handleOAuthDeviceKeys(msg)
 	case ModeCreateFile:
 		return m.handleCreateFileKeys(msg)
 	case ModeMRU:
@@ -97,6 +116,12 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return m.handleDiffKeys(msg)
 	case ModeBodyOverride:
 		return m.handleBodyOverrideKeys(msg)
+	case ModeReplayEdit:
+		return m.handleReplayEditKeys(msg)
+	case ModeRequestEdit:
+		return m.handleRequestEditKeys(msg)
+	case ModeNotesEdit:
+		return m.handleNotesEditKeys(msg)
 	case ModeJSONPathHistory:
 		return m.handleJSONPathHistoryKeys(msg)
 	case ModeTagFilter:
@@ -107,6 +132,14 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return m.handleProxyViewerKeys(msg)
 	case ModeProxyDetail:
 		return m.handleProxyDetailKeys(msg)
+	case ModeProxySave:
+		return m.handleProxySaveKeys(msg)
+	case ModeSaveToVariable:
+		return m.handleSaveToVariableKeys(msg)
+	case ModeDownloadPrompt:
+		return m.handleDownloadPromptKeys(msg)
+	case ModeSecretsPassphrase:
+		return m.handlePassphraseKeys(msg)
 	case ModeWebSocket:
 		return m.handleWebSocketKeys(msg)
 	}
@@ -292,6 +325,23 @@ func (m *Model) handleModalOpenAction(action keybinds.Action) tea.Cmd {
 		m.mode = ModeConfigView
 		return nil
 
+	case keybinds.ActionOpenCookies:
+		m.mode = ModeCookies
+		m.modalView.SetYOffset(0)
+		return nil
+
+	case keybinds.ActionOpenJWT:
+		m.openJWTViewer()
+		return nil
+
+	case keybinds.ActionOpenVarSwitcher:
+		m.openVariableSwitcher()
+		return nil
+
+	case keybinds.ActionOpenFileFinder:
+		m.openFileFinder()
+		return nil
+
 	default:
 		return nil
 	}
@@ -307,13 +357,20 @@ func (m *Model) handleComplexModalAction(action keybinds.Action) tea.Cmd {
 			// Initialize with current body resolved
 			profile := m.sessionMgr.GetActiveProfile()
 			requestCopy := *m.currentRequest
-			resolver := parser.NewVariableResolver(profile.Variables, m.sessionMgr.GetSession().Variables, m.interactiveVarValues, parser.LoadSystemEnv())
+			resolver := parser.NewVariableResolver(m.sessionMgr.ProfileVariables(profile), m.sessionMgr.GetSession().Variables, m.interactiveVarValues, parser.LoadSystemEnv())
 			resolvedRequest, err := resolver.ResolveRequest(&requestCopy)
 			if err == nil && resolvedRequest != nil {
 				m.bodyOverrideInput = resolvedRequest.Body
 			} else {
+				resolvedRequest = m.currentRequest
 				m.bodyOverrideInput = m.currentRequest.Body
 			}
+			m.bodyOverrideType = requestContentType(resolvedRequest.Headers)
+			// GraphQL requests edit the query instead of the body
+			if resolvedRequest.GraphQL != nil {
+				m.bodyOverrideInput = resolvedRequest.GraphQL.Query
+				m.bodyOverrideType = "" // A query is not JSON
+			}
 			m.bodyOverrideCursor = 0
 			m.mode = ModeBodyOverride
 			m.statusMsg = "Editing request body (one-time override)"
@@ -402,6 +459,12 @@ func (m *Model) handleExecuteAction() tea.Cmd {
 		return nil
 	}
 
+	// Enter on a folder of the tree view descends into it
+	if m.focusedPanel == "sidebar" && m.fileExplorer.EnterDirectory() {
+		m.loadRequestsFromCurrentFile()
+		return nil
+	}
+
 	// Check if current file is a WebSocket file
 	currentFile := m.fileExplorer.GetCurrentFile()
 	if currentFile != nil && currentFile.HTTPMethod == "WS" {
@@ -486,6 +549,17 @@ func (m *Model) handleResponseAction(action keybinds.Action) tea.Cmd {
 	case keybinds.ActionCopyToClipboard:
 		return m.copyToClipboard()
 
+	case keybinds.ActionCopyAsCurl:
+		return m.copyAsCurl()
+
+	case keybinds.ActionOpenCopyAs:
+		m.copyAsIndex = 0
+		m.mode = ModeCopyAs
+		return nil
+
+	case keybinds.ActionSaveToVariable:
+		return m.openSaveToVariable()
+
 	case keybinds.ActionPinResponse:
 		// Pin current response for comparison
 		if m.currentResponse == nil {
@@ -504,6 +578,7 @@ func (m *Model) handleResponseAction(action keybinds.Action) tea.Cmd {
 		if m.currentResponse == nil {
 			return m.setErrorMessage("No current response to compare")
 		}
+		m.historyDiff = nil
 		m.mode = ModeDiff
 		m.updateDiffView()
 		return nil
@@ -520,10 +595,13 @@ func (m *Model) handleResponseAction(action keybinds.Action) tea.Cmd {
 				m.updateResponseView()
 				m.statusMsg = "Filter cleared"
 			} else {
-				// Start inline filter editing
+				// Start inline filter editing, in XPath mode for XML responses
 				m.filterEditing = true
 				m.filterInput = ""
-				m.filterCursor = 0
+				if detectMarkup(m.currentResponse.Headers["Content-Type"], m.currentResponse.Body) == markupXML {
+					m.filterInput = filter.XPathPrefix
+				}
+				m.filterCursor = len(m.filterInput)
 				m.filterError = ""
 				m.statusMsg = ""
 				m.errorMsg = ""
@@ -583,12 +661,15 @@ func (m *Model) handleFileOperationAction(action keybinds.Action) tea.Cmd {
 		return m.duplicateFile()
 
 	case keybinds.ActionDeleteFile:
-		if len(m.fileExplorer.GetFiles()) > 0 {
+		if m.fileExplorer.GetCurrentFile() != nil {
 			m.mode = ModeDelete
 		}
 		return nil
 
 	case keybinds.ActionRenameFile:
+		if m.fileExplorer.GetCurrentFile() == nil {
+			return nil
+		}
 		m.mode = ModeRename
 		m.renameState.Reset()
 		return nil
@@ -605,6 +686,20 @@ func (m *Model) handleFileOperationAction(action keybinds.Action) tea.Cmd {
 		m.statusMsg = "Loading files..."
 		return m.refreshFiles()
 
+	case keybinds.ActionParentDirectory:
+		if m.fileExplorer.ParentDirectory(m.getFileListHeight()) {
+			m.loadRequestsFromCurrentFile()
+		}
+		return nil
+
+	case keybinds.ActionToggleTreeView:
+		m.fileExplorer.SetTreeView(!m.fileExplorer.IsTreeView())
+		m.loadRequestsFromCurrentFile()
+		if m.fileExplorer.IsTreeView() {
+			return m.setStatusMessage("File explorer: folder tree")
+		}
+		return m.setStatusMessage("File explorer: flat list")
+
 	default:
 		return nil
 	}
@@ -779,6 +874,10 @@ func (m *Model) handleEscapeKey() tea.Cmd {
 		}
 		m.loading = false
 		m.statusMsg = "Request cancelled by user"
+		// Show the active tab again instead of an empty panel
+		if m.currentResponse == nil && len(m.responseTabs) > 0 {
+			m.currentResponse = m.responseTabs[m.activeTab].Response
+		}
 		m.updateResponseView() // Remove loading indicator
 		return nil
 	}
@@ -829,6 +928,17 @@ func (m *Model) handleNormalKeys(msg tea.KeyMsg) tea.Cmd {
 		return m.handleEscapeKey()
 	}
 
+	// JSON tree folds with enter/space instead of executing the request
+	if m.isJSONTreeFocused() && (msg.String() == "enter" || msg.String() == " ") {
+		m.toggleSelectedJSONNode()
+		return nil
+	}
+
+	// Table view selects, sorts and hides columns instead of the normal bindings
+	if m.isTableViewFocused() && m.handleTableViewKeys(msg.String()) {
+		return nil
+	}
+
 	// Match key to action using keybinds registry
 	action, ok, partial := m.keybinds.MatchMultiKey(keybinds.ContextNormal, msg.String())
 	if partial {
@@ -855,7 +965,11 @@ func (m *Model) handleNormalKeys(msg tea.KeyMsg) tea.Cmd {
 		keybinds.ActionPageUp, keybinds.ActionPageDown,
 		keybinds.ActionHalfPageUp, keybinds.ActionHalfPageDown,
 		keybinds.ActionGoToTop, keybinds.ActionGoToBottom:
-		m.handleNavigationAction(action)
+		if m.isJSONTreeFocused() {
+			m.handleJSONTreeNavigation(action)
+		} else {
+			m.handleNavigationAction(action)
+		}
 
 	case keybinds.ActionOpenGoto:
 		m.mode = ModeGoto
@@ -876,22 +990,76 @@ func (m *Model) handleNormalKeys(msg tea.KeyMsg) tea.Cmd {
 
 	case keybinds.ActionDuplicateFile, keybinds.ActionDeleteFile,
 		keybinds.ActionRenameFile, keybinds.ActionCreateFile,
-		keybinds.ActionRefreshFiles:
+		keybinds.ActionRefreshFiles, keybinds.ActionParentDirectory,
+		keybinds.ActionToggleTreeView:
 		return m.handleFileOperationAction(action)
 
-	case keybinds.ActionSaveResponse, keybinds.ActionCopyToClipboard,
+	case keybinds.ActionSaveRequest:
+		return m.openSaveRequestConfirm()
+
+	case keybinds.ActionEditRequest:
+		return m.startRequestEdit()
+
+	case keybinds.ActionRunFilteredFiles:
+		return m.startBatchRun()
+
+	case keybinds.ActionNextRequest:
+		m.switchRequest(1)
+
+	case keybinds.ActionPrevRequest:
+		m.switchRequest(-1)
+
+	case keybinds.ActionToggleWatch:
+		return m.toggleWatch()
+
+	case keybinds.ActionSaveResponse, keybinds.ActionCopyToClipboard, keybinds.ActionCopyAsCurl, keybinds.ActionOpenCopyAs,
 		keybinds.ActionPinResponse, keybinds.ActionShowDiff,
-		keybinds.ActionFilterResponse:
+		keybinds.ActionFilterResponse, keybinds.ActionSaveToVariable:
 		return m.handleResponseAction(action)
 
 	case keybinds.ActionToggleBody, keybinds.ActionToggleHeaders, keybinds.ActionToggleFullscreen:
 		m.handleToggleAction(action)
 
+	case keybinds.ActionToggleNotes:
+		return m.toggleNotesPane()
+
+	case keybinds.ActionEditNotes:
+		return m.startNotesEdit()
+
+	case keybinds.ActionToggleJSONTree:
+		m.toggleJSONTree()
+
+	case keybinds.ActionToggleTableView:
+		m.toggleTableView()
+
+	case keybinds.ActionShowFullBody:
+		m.showFullBody()
+
+	case keybinds.ActionToggleHexView:
+		m.toggleHexView()
+
+	case keybinds.ActionDownloadResponse:
+		return m.openDownloadPrompt()
+
+	case keybinds.ActionCycleEnvironment:
+		return m.cycleEnvironment()
+
+	case keybinds.ActionNextResponseTab:
+		m.switchResponseTab(1)
+
+	case keybinds.ActionPrevResponseTab:
+		m.switchResponseTab(-1)
+
+	case keybinds.ActionCloseResponseTab:
+		m.closeResponseTab()
+
 	case keybinds.ActionOpenVariables, keybinds.ActionOpenHeaders,
 		keybinds.ActionOpenErrorDetail, keybinds.ActionShowStatusDetail,
 		keybinds.ActionOpenProfiles, keybinds.ActionOpenMockServer,
 		keybinds.ActionOpenOAuthDetail, keybinds.ActionOpenRecentFiles,
-		keybinds.ActionOpenConfigView:
+		keybinds.ActionOpenConfigView, keybinds.ActionOpenCookies,
+		keybinds.ActionOpenJWT, keybinds.ActionOpenVarSwitcher,
+		keybinds.ActionOpenFileFinder:
 		return m.handleModalOpenAction(action)
 
 	case keybinds.ActionOpenBodyOverride, keybinds.ActionOpenDocumentation,
@@ -906,6 +1074,9 @@ func (m *Model) handleNormalKeys(msg tea.KeyMsg) tea.Cmd {
 
 	case keybinds.ActionOpenTagFilter, keybinds.ActionClearTagFilter:
 		m.handleTagFilterAction(action)
+
+	case keybinds.ActionMacroRecord, keybinds.ActionMacroReplay:
+		return m.handleMacroAction(action)
 	}
 
 	return nil
@@ -1113,7 +1284,7 @@ func (m *Model) handleFilterInlineKeys(msg tea.KeyMsg) tea.Cmd {
 			}
 
 			// Apply the filter/query
-			result, err := filter.Apply(m.currentResponse.Body, "", m.filterInput)
+			result, err := filter.Apply(m.responseBodyText(), "", m.filterInput)
 			if err != nil {
 				m.filterError = fmt.Sprintf("Failed to apply filter: %s", err.Error())
 				return nil
@@ -1600,6 +1771,10 @@ func (m *Model) toggleFieldInTree(respIdx int, parentPath string, allFields []Do
 
 // handleHistoryKeys handles keys in history viewer mode
 func (m *Model) handleHistoryKeys(msg tea.KeyMsg) tea.Cmd {
+	if m.historyState.GetAnnotating() {
+		return m.handleHistoryAnnotateKeys(msg)
+	}
+
 	// If search is active, handle search input first
 	if m.historyState.GetSearchActive() {
 		switch msg.String() {
@@ -1708,6 +1883,11 @@ func (m *Model) handleHistoryKeys(msg tea.KeyMsg) tea.Cmd {
 			return m.replayHistoryEntry(m.historyState.GetIndex())
 		}
 
+	case keybinds.ActionHistoryEdit:
+		if len(m.historyState.GetEntries()) > 0 && m.historyState.GetIndex() < len(m.historyState.GetEntries()) {
+			m.startReplayEdit(m.historyState.GetIndex())
+		}
+
 	case keybinds.ActionHistoryPaginate:
 		m.historyState.TogglePreview() // m.historyState.GetPreviewVisible()
 		if m.historyState.GetPreviewVisible() {
@@ -1718,8 +1898,22 @@ func (m *Model) handleHistoryKeys(msg tea.KeyMsg) tea.Cmd {
 		m.updateHistoryView()
 
 	case keybinds.ActionHistoryClear:
+		m.historyClearUntagged = false
 		m.mode = ModeHistoryClearConfirm
 
+	case keybinds.ActionHistoryPrune:
+		m.historyClearUntagged = true
+		m.mode = ModeHistoryClearConfirm
+
+	case keybinds.ActionHistoryDiff:
+		return m.markHistoryDiff()
+
+	case keybinds.ActionHistoryAnnotate:
+		return m.startHistoryAnnotation()
+
+	case keybinds.ActionHistoryAnnotated:
+		m.toggleHistoryAnnotatedOnly()
+
 	case keybinds.ActionPageUp:
 		if m.historyState.GetFocusedPane() == "preview" && m.historyState.GetPreviewVisible() {
 			previewView := m.historyState.GetPreviewView()
@@ -1830,6 +2024,9 @@ func (m *Model) handleConfigViewKeys(msg tea.KeyMsg) tea.Cmd {
 		m.mode = ModeNormal
 		return nil
 	}
+	if msg.String() == "K" {
+		return m.exportKeybinds()
+	}
 
 	action, ok := m.keybinds.Match(keybinds.ContextModal, msg.String())
 	if !ok {
@@ -1898,6 +2095,18 @@ func (m *Model) handleHistoryClearConfirmKeys(msg tea.KeyMsg) tea.Cmd {
 		m.statusMsg = "Clear history cancelled"
 
 	case keybinds.ActionConfirm:
+		if m.historyManager != nil && m.historyClearUntagged {
+			m.mode = ModeHistory
+			profileName := ""
+			if profile := m.sessionMgr.GetActiveProfile(); profile != nil {
+				profileName = profile.Name
+			}
+			if err := m.historyManager.ClearUntagged(profileName); err != nil {
+				return m.setErrorMessage(fmt.Sprintf("Failed to clear history: %v", err))
+			}
+			m.statusMsg = "Untagged history cleared"
+			return m.loadHistory()
+		}
 		if m.historyManager != nil {
 			if err := m.historyManager.Clear(); err != nil {
 				m.mode = ModeHistory
@@ -2012,6 +2221,48 @@ func (m *Model) handleAnalyticsKeys(msg tea.KeyMsg) tea.Cmd {
 		m.mode = ModeAnalyticsClearConfirm
 		m.statusMsg = "Confirm clear all analytics"
 
+	case keybinds.ActionAnalyticsExport:
+		return m.exportAnalytics(analytics.ExportCSV)
+
+	case keybinds.ActionAnalyticsExportJSON:
+		return m.exportAnalytics(analytics.ExportJSON)
+
+	case keybinds.ActionAnalyticsChart:
+		m.analyticsState.ToggleChart()
+		if m.analyticsState.GetChartVisible() {
+			m.analyticsState.SetPreviewVisible(true)
+			m.statusMsg = "Showing latency and volume over time"
+		} else {
+			m.statusMsg = "Showing stats"
+		}
+		m.updateAnalyticsView()
+
+	case keybinds.ActionAnalyticsBucket:
+		if m.analyticsState.GetChartVisible() {
+			m.analyticsState.ToggleChartBucket()
+			m.statusMsg = fmt.Sprintf("Chart bucket: %s", m.analyticsState.GetChartBucket())
+			m.updateAnalyticsView()
+		}
+
+	case keybinds.ActionAnalyticsRange:
+		if m.analyticsState.GetChartVisible() {
+			m.analyticsState.CycleChartRange()
+			m.statusMsg = fmt.Sprintf("Chart range: last %s", m.analyticsState.GetChartRange().label)
+			m.updateAnalyticsView()
+		}
+
+	case keybinds.ActionScrollLeft, keybinds.ActionScrollRight:
+		if m.analyticsState.GetChartVisible() {
+			// Scroll a quarter of the visible buckets at a time
+			columns := analyticsChartColumns(m.analyticsState.GetDetailView().Width)
+			step := max(columns/4, 1)
+			if action == keybinds.ActionScrollRight {
+				step = -step
+			}
+			m.analyticsState.ScrollChart(step, columns)
+			m.updateAnalyticsView()
+		}
+
 	case keybinds.ActionPageUp:
 		if m.analyticsState.GetFocusedPane() == "details" {
 			if m.analyticsState.GetPreviewVisible() {
@@ -2288,7 +2539,7 @@ func (m *Model) handleStressTestConfigKeys(msg tea.KeyMsg) tea.Cmd {
 				return m.setErrorMessage(err.Error())
 			}
 			if m.stressTestState.GetConfigField() > 0 {
-				m.stressTestState.NavigateConfigFields(-1, 6) // 6 fields total
+				m.stressTestState.NavigateConfigFields(-1, 12) // 12 fields total
 				m.updateStressTestConfigInput()
 				if m.stressTestState.GetConfigField() == 1 {
 					m.loadStressTestFilePicker()
@@ -2311,8 +2562,8 @@ func (m *Model) handleStressTestConfigKeys(msg tea.KeyMsg) tea.Cmd {
 			if err := m.applyStressTestConfigInput(); err != nil {
 				return m.setErrorMessage(err.Error())
 			}
-			if m.stressTestState.GetConfigField() < 5 {
-				m.stressTestState.NavigateConfigFields(1, 6) // 6 fields total
+			if m.stressTestState.GetConfigField() < 11 {
+				m.stressTestState.NavigateConfigFields(1, 12) // 12 fields total
 				m.updateStressTestConfigInput()
 				if m.stressTestState.GetConfigField() == 1 {
 					m.loadStressTestFilePicker()
@@ -2446,6 +2697,7 @@ func (m *Model) handleStressTestResultsKeys(msg tea.KeyMsg) tea.Cmd {
 
 	switch action {
 	case keybinds.ActionCloseModal:
+		m.stressTestState.SetCompareRunID(0)
 		m.mode = ModeNormal
 
 	case keybinds.ActionSwitchPane:
@@ -2525,6 +2777,22 @@ func (m *Model) handleStressTestResultsKeys(msg tea.KeyMsg) tea.Cmd {
 			return m.loadStressTestRuns()
 		}
 
+	case keybinds.ActionStressTestCompare:
+		if len(m.stressTestState.GetRuns()) > 0 && m.stressTestState.GetRunIndex() < len(m.stressTestState.GetRuns()) {
+			run := m.stressTestState.GetRuns()[m.stressTestState.GetRunIndex()]
+			if baseID := m.stressTestState.GetCompareRunID(); baseID != 0 {
+				m.stressTestState.SetCompareRunID(0)
+				m.statusMsg = "Comparison cleared"
+			} else {
+				m.stressTestState.SetCompareRunID(run.ID)
+				m.statusMsg = fmt.Sprintf("Run #%d is the baseline - select another run to compare (c: clear)", run.ID)
+			}
+			m.updateStressTestListView()
+			detailView := m.stressTestState.GetDetailView()
+			detailView.GotoTop()
+			m.stressTestState.SetDetailView(detailView)
+		}
+
 	case keybinds.ActionStressTestLoad:
 		return m.loadStressTestConfigs()
 
@@ -2570,7 +2838,7 @@ func (m *Model) handleTagFilterKeys(msg tea.KeyMsg) tea.Cmd {
 				m.fileExplorer.SetTagFilter([]string{m.inputValue})
 				m.loadRequestsFromCurrentFile()
 				m.mode = ModeNormal
-				files := m.fileExplorer.GetFiles()
+				files := m.fileExplorer.GetFilteredFiles()
 				m.statusMsg = fmt.Sprintf("Filtered by category: %s (%d files)", m.inputValue, len(files))
 			}
 			m.inputValue = ""
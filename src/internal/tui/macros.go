@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+)
+
+// macroMaxReplay caps the keys replayed by one macro, nested macros included
+const macroMaxReplay = 1000
+
+// macroState tracks vim-style macro recording and replay
+type macroState struct {
+	pending   keybinds.Action // Record or replay action waiting for its register key
+	recording string          // Register being recorded, empty when idle
+	buffer    []tea.KeyMsg    // Keys recorded so far
+	replaying []string        // Registers being replayed, innermost last
+	replayed  int             // Keys replayed since the outermost replay started
+	aborted   bool            // Set when a replay hits the guard and must stop
+	last      string          // Last replayed register, for @@
+}
+
+// keyTypes maps key names back to their tea key type for replay
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for keyType := tea.KeyType(-128); keyType < 128; keyType++ {
+		if name := keyType.String(); name != "" && keyType != tea.KeyRunes {
+			types[name] = keyType
+		}
+	}
+	return types
+}()
+
+// parseKeyMsg turns a recorded key string back into a key message
+func parseKeyMsg(key string) tea.KeyMsg {
+	alt := false
+	if strings.HasPrefix(key, "alt+") && len(key) > len("alt+") {
+		alt = true
+		key = strings.TrimPrefix(key, "alt+")
+	}
+	if keyType, ok := keyTypes[key]; ok {
+		return tea.KeyMsg{Type: keyType, Alt: alt}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key), Alt: alt}
+}
+
+// isMacroRegister reports whether key names a macro register (a-z, 0-9)
+func isMacroRegister(key string) bool {
+	return len(key) == 1 && (key[0] >= 'a' && key[0] <= 'z' || key[0] >= '0' && key[0] <= '9')
+}
+
+// handleMacroAction starts or stops recording, or waits for the register to replay
+func (m *Model) handleMacroAction(action keybinds.Action) tea.Cmd {
+	if action == keybinds.ActionMacroRecord && m.macro.recording != "" {
+		return m.stopMacroRecording()
+	}
+
+	m.macro.pending = action
+	if action == keybinds.ActionMacroRecord {
+		m.statusMsg = "Record macro: press a register (a-z, 0-9)"
+	} else {
+		m.statusMsg = "Replay macro: press a register (a-z, 0-9, @ for last)"
+	}
+	return nil
+}
+
+// handleMacroRegister consumes the register key after a record or replay action
+// Returns false when no register key was expected
+func (m *Model) handleMacroRegister(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.macro.pending == "" {
+		return false, nil
+	}
+	action := m.macro.pending
+	m.macro.pending = ""
+
+	register := msg.String()
+	if register == "esc" {
+		m.statusMsg = ""
+		return true, nil
+	}
+
+	if action == keybinds.ActionMacroRecord {
+		if !isMacroRegister(register) {
+			return true, m.setErrorMessage(fmt.Sprintf("Invalid macro register: %s", register))
+		}
+		m.macro.recording = register
+		m.macro.buffer = nil
+		m.statusMsg = fmt.Sprintf("Recording @%s (press Q to stop)", register)
+		return true, nil
+	}
+
+	// Keep "@<register>" in a macro being recorded, not the keys it expands to
+	m.recordMacroKey(msg)
+	return true, m.replayMacro(register)
+}
+
+// recordMacroKey appends a handled key to the macro being recorded
+func (m *Model) recordMacroKey(msg tea.KeyMsg) {
+	if m.macro.recording == "" || len(m.macro.replaying) > 0 {
+		return
+	}
+	m.macro.buffer = append(m.macro.buffer, msg)
+}
+
+// stopMacroRecording saves the recorded keys to the session
+func (m *Model) stopMacroRecording() tea.Cmd {
+	register := m.macro.recording
+	keys := make([]string, len(m.macro.buffer))
+	for i, msg := range m.macro.buffer {
+		keys[i] = msg.String()
+	}
+	m.macro.recording = ""
+	m.macro.buffer = nil
+
+	if err := m.sessionMgr.SetMacro(register, keys); err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to save macro: %v", err))
+	}
+	return m.setStatusMessage(fmt.Sprintf("Recorded @%s (%d keys)", register, len(keys)))
+}
+
+// replayMacro feeds the keys of a register back into the key handler
+// Replay stops when a macro invokes itself or more than macroMaxReplay keys are replayed
+func (m *Model) replayMacro(register string) tea.Cmd {
+	if register == "@" {
+		register = m.macro.last
+		if register == "" {
+			return m.setErrorMessage("No macro replayed yet")
+		}
+	}
+	if !isMacroRegister(register) {
+		return m.setErrorMessage(fmt.Sprintf("Invalid macro register: %s", register))
+	}
+
+	keys, ok := m.sessionMgr.GetMacro(register)
+	if !ok {
+		return m.setErrorMessage(fmt.Sprintf("Macro @%s is empty", register))
+	}
+	if slices.Contains(m.macro.replaying, register) {
+		m.macro.aborted = true
+		return m.setErrorMessage(fmt.Sprintf("Macro @%s invokes itself, replay stopped", register))
+	}
+
+	if len(m.macro.replaying) == 0 {
+		m.macro.replayed = 0
+		m.macro.aborted = false
+	}
+	m.macro.last = register
+	m.macro.replaying = append(m.macro.replaying, register)
+	defer func() { m.macro.replaying = m.macro.replaying[:len(m.macro.replaying)-1] }()
+
+	var cmds []tea.Cmd
+	for _, key := range keys {
+		if m.macro.aborted {
+			break
+		}
+		if m.macro.replayed >= macroMaxReplay {
+			m.macro.aborted = true
+			cmds = append(cmds, m.setErrorMessage(fmt.Sprintf("Macro replay stopped after %d keys", macroMaxReplay)))
+			break
+		}
+		m.macro.replayed++
+
+		msg := parseKeyMsg(key)
+		if handled, cmd := m.handleMacroRegister(msg); handled {
+			cmds = append(cmds, cmd)
+			continue
+		}
+		cmds = append(cmds, m.handleKeyPress(msg))
+	}
+	return tea.Batch(cmds...)
+}
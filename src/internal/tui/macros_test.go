@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/session"
+)
+
+// useTempSession points the session file at a temporary directory
+func useTempSession(t *testing.T, m *Model) {
+	t.Helper()
+	original := config.SessionFile
+	config.SessionFile = filepath.Join(t.TempDir(), ".session.json")
+	t.Cleanup(func() { config.SessionFile = original })
+	if err := m.sessionMgr.LoadSession(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// pressKeys sends each key through Update as if typed
+func pressKeys(m *Model, keys ...string) {
+	for _, key := range keys {
+		m.Update(parseKeyMsg(key))
+	}
+}
+
+func TestMacro_RecordAndReplay(t *testing.T) {
+	m := CreateTestModel(t)
+	useTempSession(t, m)
+	showBody := m.showBody
+
+	pressKeys(m, "Q", "a", "b", "Q")
+	if m.macro.recording != "" {
+		t.Fatal("Expected recording to stop")
+	}
+	keys, ok := m.sessionMgr.GetMacro("a")
+	if !ok || !slices.Equal(keys, []string{"b"}) {
+		t.Fatalf("Expected only the keys between the record keys, got %v", keys)
+	}
+	AssertModelField(t, "showBody", !showBody, m.showBody)
+
+	pressKeys(m, "@", "a")
+	AssertModelField(t, "showBody", showBody, m.showBody)
+	pressKeys(m, "@", "@")
+	AssertModelField(t, "showBody", !showBody, m.showBody)
+
+	// Macros survive a restart
+	reloaded := session.NewManager()
+	if err := reloaded.LoadSession(); err != nil {
+		t.Fatal(err)
+	}
+	if keys, ok := reloaded.GetMacro("a"); !ok || !slices.Equal(keys, []string{"b"}) {
+		t.Errorf("Expected the macro to be persisted, got %v", keys)
+	}
+}
+
+func TestMacro_RecursiveReplayStops(t *testing.T) {
+	m := CreateTestModel(t)
+	useTempSession(t, m)
+	showBody := m.showBody
+
+	m.sessionMgr.SetMacro("a", []string{"@", "b"})
+	m.sessionMgr.SetMacro("b", []string{"@", "a", "b"})
+
+	pressKeys(m, "@", "a")
+	if !strings.Contains(m.errorMsg, "invokes itself") {
+		t.Errorf("Expected a recursion error, got %q", m.errorMsg)
+	}
+	AssertModelField(t, "showBody", showBody, m.showBody)
+	if len(m.macro.replaying) != 0 || m.macro.pending != "" {
+		t.Errorf("Expected the replay state to be reset, got %+v", m.macro)
+	}
+}
+
+func TestMacro_ReplayLengthCapped(t *testing.T) {
+	m := CreateTestModel(t)
+	useTempSession(t, m)
+	showBody := m.showBody
+
+	keys := make([]string, macroMaxReplay+1)
+	for i := range keys {
+		keys[i] = "b"
+	}
+	m.sessionMgr.SetMacro("a", keys)
+
+	pressKeys(m, "@", "a")
+	if !strings.Contains(m.errorMsg, "stopped after") {
+		t.Errorf("Expected the replay cap error, got %q", m.errorMsg)
+	}
+	// An even number of toggles leaves the body as it was
+	AssertModelField(t, "showBody", showBody, m.showBody)
+}
+
+func TestParseKeyMsg_RoundTrip(t *testing.T) {
+	for _, key := range []string{"a", "G", "enter", "ctrl+s", "alt+x", " ", "esc", "up"} {
+		if got := parseKeyMsg(key).String(); got != key {
+			t.Errorf("parseKeyMsg(%q).String() = %q", key, got)
+		}
+	}
+	if msg := parseKeyMsg("enter"); msg.Type != tea.KeyEnter {
+		t.Errorf("Expected enter to parse as KeyEnter, got %v", msg.Type)
+	}
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// Markup languages recognized in response bodies
+const (
+	markupXML  = "xml"
+	markupHTML = "html"
+)
+
+// htmlVoidElements never have children or a closing tag
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// detectMarkup returns the markup language of a body, or "" when it is neither XML nor HTML
+// XML is recognized by content type or by an XML declaration, HTML only by content type
+func detectMarkup(contentType, body string) string {
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "xml"):
+		return markupXML
+	case strings.Contains(contentType, "html"):
+		return markupHTML
+	case strings.HasPrefix(strings.TrimSpace(body), "<?xml"):
+		return markupXML
+	}
+	return ""
+}
+
+// indentMarkup re-indents an XML or HTML document with two spaces per level
+// Elements containing only text stay on one line; HTML is parsed leniently
+func indentMarkup(body, language string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(body))
+	isHTML := language == markupHTML
+	if isHTML {
+		decoder.Strict = false
+		decoder.Entity = xml.HTMLEntity
+	}
+
+	var buf bytes.Buffer
+	depth := 0
+	openTag := false // Last write was a start tag, so text or its end tag stay on the same line
+	newline := func() {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(strings.Repeat("  ", depth))
+	}
+
+	for {
+		// RawToken keeps namespace prefixes as written instead of resolving them to URLs
+		tok, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			newline()
+			buf.WriteString("<" + markupName(t.Name))
+			for _, attr := range t.Attr {
+				buf.WriteString(" " + markupName(attr.Name) + `="`)
+				xml.EscapeText(&buf, []byte(attr.Value))
+				buf.WriteString(`"`)
+			}
+			buf.WriteString(">")
+			if isHTML && htmlVoidElements[strings.ToLower(t.Name.Local)] {
+				openTag = false
+				continue
+			}
+			depth++
+			openTag = true
+		case xml.EndElement:
+			if isHTML && htmlVoidElements[strings.ToLower(t.Name.Local)] {
+				continue
+			}
+			if depth > 0 {
+				depth--
+			}
+			if !openTag {
+				newline()
+			}
+			buf.WriteString("</" + markupName(t.Name) + ">")
+			openTag = false
+		case xml.CharData:
+			text := bytes.TrimSpace(t)
+			if len(text) == 0 {
+				continue
+			}
+			if !openTag {
+				newline()
+			}
+			xml.EscapeText(&buf, text)
+		case xml.Comment:
+			newline()
+			buf.WriteString("<!--" + string(t) + "-->")
+			openTag = false
+		case xml.ProcInst:
+			newline()
+			buf.WriteString("<?" + t.Target + " " + string(t.Inst) + "?>")
+			openTag = false
+		case xml.Directive:
+			newline()
+			buf.WriteString("<!" + string(t) + ">")
+			openTag = false
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// markupName renders an element or attribute name with its prefix, if any
+func markupName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
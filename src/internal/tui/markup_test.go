@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestDetectMarkup(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		want        string
+	}{
+		{"application/xml", "<a/>", markupXML},
+		{"application/soap+xml; charset=utf-8", "<a/>", markupXML},
+		{"text/html; charset=utf-8", "<html></html>", markupHTML},
+		{"text/plain", `<?xml version="1.0"?><a/>`, markupXML},
+		{"text/plain", "<html></html>", ""},
+		{"", "plain text", ""},
+	}
+
+	for _, tt := range tests {
+		if got := detectMarkup(tt.contentType, tt.body); got != tt.want {
+			t.Errorf("detectMarkup(%q, %q) = %q, want %q", tt.contentType, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestIndentMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		language string
+		want     string
+	}{
+		{
+			name:     "nested xml",
+			body:     `<?xml version="1.0"?><soap:Envelope xmlns:soap="urn:x"><soap:Body><id a="1 &amp; 2">42</id><empty></empty></soap:Body></soap:Envelope>`,
+			language: markupXML,
+			want: "<?xml version=\"1.0\"?>\n" +
+				"<soap:Envelope xmlns:soap=\"urn:x\">\n" +
+				"  <soap:Body>\n" +
+				"    <id a=\"1 &amp; 2\">42</id>\n" +
+				"    <empty></empty>\n" +
+				"  </soap:Body>\n" +
+				"</soap:Envelope>",
+		},
+		{
+			name:     "html void elements",
+			body:     "<!DOCTYPE html><html><body><p>a&nbsp;b<br>c</p><img src=x></body></html>",
+			language: markupHTML,
+			want: "<!DOCTYPE html>\n" +
+				"<html>\n" +
+				"  <body>\n" +
+				"    <p>a b\n" +
+				"      <br>\n" +
+				"      c\n" +
+				"    </p>\n" +
+				"    <img src=\"x\">\n" +
+				"  </body>\n" +
+				"</html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := indentMarkup(tt.body, tt.language)
+			if err != nil {
+				t.Fatalf("indentMarkup failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("indentMarkup() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := indentMarkup("<a><b</a>", markupXML); err == nil {
+		t.Error("Expected an error for malformed XML")
+	}
+}
+
+func TestHighlightContent(t *testing.T) {
+	if got := highlightContent("plain", "text/plain", nil); got != "plain" {
+		t.Errorf("Expected unsupported content to be unchanged, got: %q", got)
+	}
+	if got := highlightContent("<a>1</a>", "application/xml", nil); got == "<a>1</a>" {
+		t.Error("Expected XML to be highlighted")
+	}
+}
+
+func TestFilterResponse_XPathForXML(t *testing.T) {
+	m := CreateTestModel(t)
+	m.currentResponse = &types.RequestResult{
+		Status:  200,
+		Headers: map[string]string{"Content-Type": "application/xml"},
+		Body:    `<users><user id="1"/><user id="2"/></users>`,
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")})
+	AssertModelField(t, "filterEditing", true, m.filterEditing)
+	AssertModelField(t, "filterInput", "xpath:", m.filterInput)
+
+	for _, r := range "//user/@id" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "filteredResponse", "1\n2", m.filteredResponse)
+
+	// JSON responses keep the JMESPath default
+	m.filterActive = false
+	m.currentResponse = &types.RequestResult{Status: 200, Body: `{"a": 1}`}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")})
+	AssertModelField(t, "filterInput", "", m.filterInput)
+}
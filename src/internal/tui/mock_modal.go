@@ -46,6 +46,11 @@ func (m *Model) renderMockServer() string {
 					statusStyle = styleWarning
 				}
 
+				statusText := fmt.Sprintf("%d", log.Status)
+				if log.Event != "" {
+					statusText = "ws " + log.Event
+				}
+
 				timestamp := log.Timestamp.Format("15:04:05")
 				// Format method with padding for alignment
 				method := fmt.Sprintf("%-6s", log.Method)
@@ -53,10 +58,12 @@ func (m *Model) renderMockServer() string {
 					timestamp,
 					method,
 					log.Path,
-					statusStyle.Render(fmt.Sprintf("%d", log.Status)),
+					statusStyle.Render(statusText),
 					log.Duration.Milliseconds()))
 
-				if log.MatchedRule != "none" && log.MatchedRule != "" {
+				if log.Injected {
+					content.WriteString(fmt.Sprintf("  → %s\n", styleWarning.Render(log.MatchedRule)))
+				} else if log.MatchedRule != "none" && log.MatchedRule != "" {
 					content.WriteString(fmt.Sprintf("  → %s\n", styleSubtle.Render(log.MatchedRule)))
 				}
 			}
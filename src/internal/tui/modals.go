@@ -90,14 +90,16 @@ func (m *Model) renderHistory() string {
 
 	// Build footer with instructions and scroll position
 	var footerText string
-	if m.historyState.GetSearchActive() {
+	if m.historyState.GetAnnotating() {
+		footerText = fmt.Sprintf("Annotate (#tags note): %s█%s", m.inputValue[:m.inputCursor], m.inputValue[m.inputCursor:])
+	} else if m.historyState.GetSearchActive() {
 		// Show search input when active
 		footerText = fmt.Sprintf("Search: %s█", m.historyState.GetSearchQuery())
 		if len(m.historyState.GetEntries()) > 0 {
 			footerText += fmt.Sprintf(" [%d results]", len(m.historyState.GetEntries()))
 		}
 	} else {
-		footerText = "TAB: Switch Focus | /: Search | ↑/↓ j/k: Navigate | Enter: Load | r: Replay | p: Toggle Preview | C: Clear All | ESC/H/q: Close"
+		footerText = "TAB: Switch Focus | /: Search | ↑/↓ j/k: Navigate | Enter: Load | r: Replay | e: Edit & Replay | d: Diff | a: Annotate | b: Annotated Only | p: Toggle Preview | C: Clear All | U: Clear Untagged | ESC/H/q: Close"
 
 		// Add scroll indicator if there are entries
 		if len(m.historyState.GetEntries()) > 0 {
@@ -109,6 +111,11 @@ func (m *Model) renderHistory() string {
 		}
 	}
 
+	historyTitle := "History"
+	if m.historyState.GetAnnotatedOnly() {
+		historyTitle = "History (annotated)"
+	}
+
 	// Determine border colors based on focus
 	listBorderColor := colorGray
 	previewBorderColor := colorGray
@@ -134,7 +141,7 @@ func (m *Model) renderHistory() string {
 		ModalWidth:       modalWidth,
 		ModalHeight:      modalHeight,
 		IsSplitView:      m.historyState.GetPreviewVisible(),
-		LeftTitle:        "History",
+		LeftTitle:        historyTitle,
 		LeftContent:      m.modalView.View(),
 		LeftBorderColor:  listBorderColor,
 		LeftIsFocused:    leftIsFocused,
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -9,9 +10,11 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/studiowebux/restcli/internal/analytics"
+	"github.com/studiowebux/restcli/internal/executor"
 	"github.com/studiowebux/restcli/internal/history"
 	"github.com/studiowebux/restcli/internal/jsonpath"
 	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/oauth"
 	"github.com/studiowebux/restcli/internal/session"
 	"github.com/studiowebux/restcli/internal/types"
 )
@@ -63,6 +66,8 @@ const (
 	ModeMRU
 	ModeDiff
 	ModeConfirmExecution
+	ModeSaveRequestConfirm
+	ModeBatchResults
 	ModeErrorDetail
 	ModeStatusDetail
 	ModeBodyOverride
@@ -72,6 +77,21 @@ const (
 	ModeProxyViewer
 	ModeProxyDetail
 	ModeWebSocket
+	ModeCookies
+	ModeOAuthDevice
+	ModeProxySave
+	ModeSaveToVariable
+	ModeSecretsPassphrase
+	ModeProfileExport
+	ModeProfileImport
+	ModeReplayEdit
+	ModeDownloadPrompt
+	ModeJWT
+	ModeCopyAs
+	ModeVariableSwitcher
+	ModeFileFinder
+	ModeRequestEdit
+	ModeNotesEdit
 )
 
 // Model represents the TUI state
@@ -101,11 +121,23 @@ type Model struct {
 	searchCursor          int    // Cursor position in search input
 
 	// Request/Response
-	currentRequests []types.HttpRequest
-	currentRequest  *types.HttpRequest
-	currentResponse *types.RequestResult
-	responseView    viewport.Model
-	responseContent string // Full formatted response content for searching
+	currentRequests  []types.HttpRequest
+	currentFilePath  string // File the current requests were parsed from
+	currentRequest   *types.HttpRequest
+	currentResponse  *types.RequestResult // Response of the active tab (nil while a request is loading)
+	responseView     viewport.Model
+	responseContent  string               // Full formatted response content for searching
+	fullBodyResponse *types.RequestResult // Response whose body is rendered past the display cap
+
+	// Response tabs (one per executed response, currentResponse mirrors the active one)
+	responseTabs     []*ResponseTab
+	activeTab        int
+	executingRequest *types.HttpRequest // Request of the pending response, tied to the tab opened for it
+
+	// Batch run of the category-filtered files
+	batch          *batchRun // Nil when no batch is running
+	batchResults   []BatchResult
+	batchCancelled bool
 
 	// Response content cache tracking
 	cachedResponsePtr      *types.RequestResult // Pointer to response that was cached
@@ -114,6 +146,7 @@ type Model struct {
 	cachedSearchActive     bool                 // Search highlight state when cached
 	cachedShowHeaders      bool                 // Headers visibility when cached
 	cachedShowBody         bool                 // Body visibility when cached
+	cachedHexView          bool                 // Hex view state when cached
 	cachedHighlightedBody  string               // Pre-highlighted body to avoid re-rendering
 	cachedSearchMatchCount int                  // Number of matches used for cached highlighting
 
@@ -123,11 +156,36 @@ type Model struct {
 	// Streaming state
 	streamState   *StreamState        // Thread-safe streaming state management
 	streamedBody  string              // Accumulated streamed response body
+	streamNDJSON  bool                // Pretty-print each streamed chunk as an NDJSON line
 	streamChannel chan streamChunkMsg // Channel for receiving stream chunks
 
 	// Request cancellation (for regular non-streaming requests)
 	requestState *RequestState // Thread-safe request cancellation
 
+	// Cookie jars keyed by profile name (never shared across profiles)
+	cookieJars map[string]*executor.CookieJar
+
+	// JWT viewer (tokens of the Authorization header and session variables)
+	jwtTokens []jwtToken
+	jwtIndex  int
+
+	// "Copy as" menu selection
+	copyAsIndex int
+
+	// Variable switcher (fuzzy find over environments and variables)
+	varSwitcherQuery    string
+	varSwitcherCursor   int
+	varSwitcherIndex    int
+	varSwitcherOption   int    // Option picked on a multi-value row
+	varSwitcherEditing  string // Variable whose value is being edited, empty while listing
+	varSwitcherValue    string
+	varSwitcherValuePos int
+
+	// File finder (fuzzy find over the files of the workdir tree)
+	fileFinderQuery  string
+	fileFinderCursor int
+	fileFinderIndex  int
+
 	// UI state
 	width         int
 	height        int
@@ -164,14 +222,42 @@ type Model struct {
 	profileCursor  int
 	profileNamePos int // Cursor position in profile name
 
+	// Profile export/import prompt
+	profileTransferInput  string // Path of the shared profile file
+	profileTransferCursor int    // Cursor position in input
+	profileTransferOption bool   // Export: include secrets, import: merge on name collision
+
 	// Profile edit state (encapsulates all profile editing UI state)
 	profileEditState *ProfileEditState
 
 	// Documentation viewer state (encapsulates all documentation UI state)
 	docState *DocumentationState
 
+	// JSON tree state (collapsible response body view)
+	jsonTreeState *JSONTreeState
+
+	// Table view state (JSON array bodies as columns)
+	tableViewState *TableViewState
+
+	// Hex view (response body as a hex dump)
+	hexView          bool
+	hexDumpStartLine int // Line of responseContent where the dump starts, for byte search
+
+	// Binary body decoded to JSON for display (MessagePack, CBOR, protobuf)
+	decodedResponsePtr *types.RequestResult // Response the decoded fields belong to
+	decodedBody        string               // Decoded JSON, empty when the body was not decoded
+	decodeErr          error                // Why a body with a decoder could not be decoded
+
 	// History state (encapsulates all history UI state)
-	historyState *HistoryState
+	historyState         *HistoryState
+	historyClearUntagged bool               // The clear confirmation keeps entries with a note or tags
+	replayDraft          *types.HttpRequest // History request being edited before replay, nil otherwise
+	replayURLCursor      int
+
+	// Inline request editor
+	requestDraft      *types.HttpRequest // Copy of the current request being edited, nil otherwise
+	requestEditField  int                // Focused field: requestEditMethod or requestEditURL
+	requestEditCursor int                // Cursor position in the focused field
 
 	// Analytics state (encapsulates all analytics UI state)
 	analyticsState *AnalyticsState
@@ -185,6 +271,26 @@ type Model struct {
 	// Proxy server state (encapsulates all proxy server state)
 	proxyServerState *ProxyServerState
 
+	// Captured proxy request export
+	proxySaveInput     string // Filename for the captured request
+	proxySaveCursor    int    // Cursor position in input
+	proxyImportHeaders bool   // Keep sensitive header values instead of placeholders
+
+	// Response value saved to a session variable
+	saveVarInput  string // Session variable name
+	saveVarCursor int    // Cursor position in input
+
+	// Response body downloaded to a file
+	downloadInput  string         // File name being entered
+	downloadCursor int            // Cursor position in input
+	downloadPath   string         // File for the next execution, one-time use
+	download       *downloadState // Running download, nil when none
+
+	// Passphrase prompt for encrypted variable values
+	passphraseInput      string // Entered passphrase (never rendered)
+	passphraseCursor     int    // Cursor position in input
+	passphraseEncryptVar string // Variable to encrypt once unlocked, empty to execute the request
+
 	// Rename state (encapsulates file rename input state)
 	renameState *RenameState
 
@@ -192,6 +298,10 @@ type Model struct {
 	oauthField  int
 	oauthCursor int
 
+	// OAuth device flow state
+	oauthDevice       *oauth.DeviceAuthorization // Pending device authorization (user code shown in modal)
+	oauthDeviceCancel context.CancelFunc         // Stops token polling
+
 	// Input states
 	inputValue  string
 	inputCursor int
@@ -213,6 +323,12 @@ type Model struct {
 	shellErrors      []string
 	shellErrorScroll int
 
+	// Notes pane state (scratchpad per request file, stored in the session)
+	showNotes   bool
+	notesPath   string // File whose notes are being edited
+	notesInput  string
+	notesCursor int
+
 	// Create file state
 	createFileInput  string // Filename/path input
 	createFileType   int    // Selected file type (0=http, 1=json, 2=yaml, 3=jsonc)
@@ -221,6 +337,16 @@ type Model struct {
 	// MRU state
 	mruIndex int // Selected index in MRU list
 
+	// Which-key popup state
+	whichKey    *whichKeyPopup // Completions shown while a multi-key sequence is pending
+	whichKeySeq int            // Incremented per leader key press to ignore stale timeouts
+
+	// Macro recording and replay state
+	macro macroState
+
+	// Watch mode: re-run the selected request when its file is saved
+	watch watchState
+
 	// Diff state
 	pinnedResponse *types.RequestResult // Response pinned for comparison
 	pinnedRequest  *types.HttpRequest   // Request info for pinned response
@@ -228,6 +354,8 @@ type Model struct {
 	diffViewMode   string               // "unified" or "split"
 	diffLeftView   viewport.Model       // Left pane viewport (pinned) for split mode
 	diffRightView  viewport.Model       // Right pane viewport (current) for split mode
+	diffSemantic   bool                 // Compare JSON bodies by path instead of line by line
+	historyDiff    *[2]diffSide         // History entries being compared, nil for pinned vs current
 
 	// Interactive variable prompt state
 	interactiveVarNames     []string          // Queue of variables to prompt for
@@ -248,6 +376,7 @@ type Model struct {
 	bodyOverrideInput  string // Edited body content
 	bodyOverrideCursor int    // Cursor position (linear, not line-based)
 	bodyOverride       string // Applied body override (cleared after send)
+	bodyOverrideType   string // Content-Type of the edited body, selects the validation
 
 	// Filter state
 	filterInput      string // JMESPath filter/query expression
@@ -284,6 +413,7 @@ type Model struct {
 	wsShowClearConfirm     bool                       // True when showing clear history confirmation dialog
 	wsSearchMode           bool                       // True when in search mode
 	wsSearchQuery          string                     // Current search query
+	wsHexView              bool                       // Show binary frames as hex dumps
 	wsStatusMsg            string                     // WebSocket-specific status message for footer
 	wsComposerMode         bool                       // True when in custom message composer mode
 	wsComposerMessage      string                     // Custom message being composed
@@ -317,6 +447,7 @@ func (m *Model) Cleanup() {
 			fmt.Fprintf(os.Stderr, "error closing bookmark database: %v\n", err)
 		}
 	}
+	m.stopWatch()
 }
 
 // Update handles messages and updates the model
@@ -325,13 +456,25 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		cmd = m.handleKeyPress(msg)
+		if m.dismissWhichKey(msg) {
+			break
+		}
+		if handled, macroCmd := m.handleMacroRegister(msg); handled {
+			cmd = macroCmd
+			break
+		}
+		pendingContext, _, wasPending := m.keybinds.PendingSequence()
+		cmd = tea.Batch(m.handleKeyPress(msg), m.scheduleWhichKey(msg, pendingContext, wasPending))
+		m.recordMacroKey(msg)
 
-	// Mouse events - capture to prevent terminal scrolling, but don't use them for navigation
+	case whichKeyTimeoutMsg:
+		m.showWhichKey(msg)
+
+	// Mouse events - always captured to prevent terminal scrolling
 	case tea.MouseMsg:
-		// Explicitly handle and discard mouse scroll to prevent terminal buffer scrolling
-		// This keeps the app "on top" when scrolling with the mouse
-		// All navigation remains keyboard-only as intended
+		// Discarded unless the profile enables the mouse, which keeps the app "on top"
+		// when scrolling; navigation stays keyboard-only by default
+		m.handleMouse(msg)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -344,12 +487,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Reload current file's requests to reflect any changes
 		m.loadRequestsFromCurrentFile()
 
+	case watchChangedMsg:
+		cmd = m.handleWatchChanged(msg)
+
 	case chainCompleteMsg:
 		m.loading = false
 		m.requestState.Clear() // Clear cancel function
 		if msg.success {
 			if msg.response != nil {
-				m.currentResponse = msg.response
+				m.openResponseTab(m.executingRequest, msg.response)
 			}
 			m.errorMsg = ""
 			m.fullErrorMsg = ""
@@ -367,24 +513,39 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case batchStepMsg:
+		cmd = m.handleBatchStep(msg)
+
 	case requestExecutedMsg:
 		m.loading = false      // Clear loading flag
 		m.requestState.Clear() // Clear cancel function
-		m.currentResponse = msg.result
+		m.download = nil
+		if msg.result != nil {
+			m.openResponseTab(m.executingRequest, msg.result)
+		} else {
+			m.currentResponse = nil
+		}
 		// Clear any previous errors since request completed successfully
 		m.errorMsg = ""
 		m.fullErrorMsg = ""
+		statusText := "Request completed"
 		if len(msg.warnings) > 0 {
-			statusText := fmt.Sprintf("Request completed (unresolved: %s)", strings.Join(msg.warnings, ", "))
-			m.fullStatusMsg = statusText
-			if len(statusText) > 100 {
-				m.statusMsg = statusText[:97] + "..."
-			} else {
-				m.statusMsg = statusText
-			}
+			statusText += fmt.Sprintf(" (unresolved: %s)", strings.Join(msg.warnings, ", "))
+		}
+		if msg.result != nil && len(msg.result.GraphQLErrors) > 0 {
+			statusText += fmt.Sprintf(" (GraphQL errors: %d)", len(msg.result.GraphQLErrors))
+		}
+		if msg.result != nil && msg.result.Attempts > 1 {
+			statusText += fmt.Sprintf(" (retried %dx)", msg.result.Attempts-1)
+		}
+		if msg.oauthNotice != "" {
+			statusText += fmt.Sprintf(" (%s)", msg.oauthNotice)
+		}
+		m.fullStatusMsg = statusText
+		if len(statusText) > 100 {
+			m.statusMsg = statusText[:97] + "..."
 		} else {
-			m.statusMsg = "Request completed"
-			m.fullStatusMsg = "Request completed"
+			m.statusMsg = statusText
 		}
 		m.updateResponseView()
 		// Auto-switch focus to response panel so user can immediately scroll
@@ -400,12 +561,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case streamChunkMsg:
-		// Accumulate streaming chunks
-		m.streamedBody += string(msg.chunk)
+		// Accumulate streaming chunks (NDJSON chunks are complete lines, pretty-printed)
+		if m.streamNDJSON && len(msg.chunk) > 0 {
+			m.streamedBody += formatNDJSONLine(msg.chunk)
+		} else {
+			m.streamedBody += string(msg.chunk)
+		}
 
 		// Update the display with current streamed content
 		if m.currentResponse == nil {
-			m.currentResponse = &types.RequestResult{}
+			m.openResponseTab(m.executingRequest, &types.RequestResult{})
 		}
 		m.currentResponse.Body = m.streamedBody
 		m.cachedResponsePtr = nil // Invalidate cache since body changed in place
@@ -463,6 +628,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 						m.wsPendingMessageIndex = -1 // Clear pending message
 					}
+				} else if strings.HasPrefix(msg.message.Content, "Connection lost") {
+					m.wsState.Stop()
+					m.wsConnectionStatus = "connection lost"
+					m.errorMsg = "WebSocket " + strings.ToLower(msg.message.Content)
 				} else if strings.Contains(msg.message.Content, "Disconnected") || strings.Contains(msg.message.Content, "Error") {
 					m.wsState.Stop()
 					m.wsConnectionStatus = "disconnected"
@@ -490,7 +659,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMsg = "WebSocket connection closed"
 		}
 
+	case oauthDeviceCodeMsg:
+		ctx, cancel := context.WithCancel(context.Background())
+		m.oauthDevice = msg.auth
+		m.oauthDeviceCancel = cancel
+		m.mode = ModeOAuthDevice
+		return m, m.pollOAuthDevice(ctx, msg)
+
 	case oauthSuccessMsg:
+		m.closeOAuthDevice()
 		m.statusMsg = fmt.Sprintf("OAuth successful! Token stored (expires in %d seconds)", msg.expiresIn)
 
 	case historyLoadedMsg:
@@ -499,6 +676,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.historyState.SetIndex(0)
 		m.historyState.SetSearchQuery("") // Reset search on load
 		m.historyState.SetSearchActive(false)
+		if m.historyState.GetAnnotatedOnly() {
+			m.historyState.Search("")
+		}
 		if len(msg.entries) > 0 {
 			m.statusMsg = fmt.Sprintf("Loaded %d history entries", len(msg.entries))
 		}
@@ -506,6 +686,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case analyticsLoadedMsg:
 		m.analyticsState.SetStats(msg.stats)
+		m.analyticsState.SetErrorRates(msg.errorRates, msg.threshold, msg.window)
 		m.analyticsState.SetIndex(0)
 		if len(msg.stats) > 0 {
 			m.statusMsg = fmt.Sprintf("Loaded %d analytics entries", len(msg.stats))
@@ -542,7 +723,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case stressTestProgressMsg:
-		// Continue polling for progress
+		// Collect per-second samples for the sparklines, then continue polling
+		if executor := m.stressTestState.GetExecutor(); executor != nil {
+			m.stressTestState.AppendTimeline(executor.DrainTimeline()...)
+		}
 		return m, m.pollStressTestProgress()
 
 	case stressTestCompletedMsg:
@@ -583,6 +767,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mode = ModeVariablePromptInteractive
 		m.initInteractiveVarPrompt()
 
+	case variableStoredInKeychainMsg:
+		cmd = m.handleVariableStoredInKeychain(msg)
+
 	case mockServerStartedMsg:
 		m.mockServerState.Start(msg.server, msg.configPath)
 		m.statusMsg = fmt.Sprintf("Mock server started at %s", msg.address)
@@ -593,6 +780,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mockServerState.Stop()
 		m.statusMsg = "Mock server stopped"
 
+	case downloadTickMsg:
+		// Refresh the progress until the download completes
+		if m.download != nil && m.loading {
+			m.updateResponseView()
+			cmd = m.tickDownload()
+		} else {
+			m.download = nil
+		}
+
 	case mockServerTickMsg:
 		// Refresh mock server view if in that mode and server is running
 		if m.mode == ModeMockServer && m.mockServerState.IsRunning() {
@@ -637,6 +833,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case errorMsg:
 		m.loading = false // Clear loading flag on error
+		m.closeOAuthDevice()
 		fullMsg := string(msg)
 		m.fullErrorMsg = fullMsg
 		// Truncate for footer display (max 100 chars)
@@ -682,6 +879,15 @@ func (m Model) View() string {
 		return "Initializing..."
 	}
 
+	view := m.renderMode()
+	if m.whichKey != nil {
+		view = m.overlayWhichKey(view)
+	}
+	return view
+}
+
+// renderMode renders the view for the current mode
+func (m Model) renderMode() string {
 	switch m.mode {
 	case ModeHelp:
 		return m.renderHelp()
@@ -693,6 +899,8 @@ func (m Model) View() string {
 		return m.renderHeaderEditor()
 	case ModeProfileSwitch, ModeProfileCreate, ModeProfileEdit, ModeProfileDuplicate, ModeProfileDeleteConfirm:
 		return m.renderProfileModal()
+	case ModeProfileExport, ModeProfileImport:
+		return m.renderProfileTransferModal()
 	case ModeDocumentation:
 		return m.renderDocumentation()
 	case ModeHistory:
@@ -727,10 +935,18 @@ func (m Model) View() string {
 		return m.renderDeleteModal()
 	case ModeConfirmExecution:
 		return m.renderConfirmExecutionModal()
+	case ModeSaveRequestConfirm:
+		return m.renderSaveRequestModal()
+	case ModeBatchResults:
+		return m.renderBatchResultsModal()
 	case ModeErrorDetail:
 		return m.renderErrorDetailModal()
 	case ModeStatusDetail:
 		return m.renderStatusDetailModal()
+	case ModeCookies:
+		return m.renderCookiesModal()
+	case ModeOAuthDevice:
+		return m.renderOAuthDevice()
 	case ModeShellErrors:
 		return m.renderShellErrorsModal()
 	case ModeCreateFile:
@@ -741,12 +957,32 @@ func (m Model) View() string {
 		return m.renderProxyModal()
 	case ModeProxyDetail:
 		return m.renderProxyDetailModal()
+	case ModeProxySave:
+		return m.renderProxySaveModal()
+	case ModeSaveToVariable:
+		return m.renderSaveToVariableModal()
+	case ModeDownloadPrompt:
+		return m.renderDownloadPromptModal()
+	case ModeJWT:
+		return m.renderJWTModal()
+	case ModeCopyAs:
+		return m.renderCopyAsModal()
+	case ModeVariableSwitcher:
+		return m.renderVariableSwitcher()
+	case ModeFileFinder:
+		return m.renderFileFinder()
+	case ModeSecretsPassphrase:
+		return m.renderPassphraseModal()
 	case ModeMRU:
 		return m.renderMRUModal()
 	case ModeDiff:
 		return m.renderDiffModal()
 	case ModeBodyOverride:
 		return m.renderBodyOverrideModal()
+	case ModeReplayEdit:
+		return m.renderReplayEditModal()
+	case ModeRequestEdit:
+		return m.renderRequestEditModal()
 	case ModeJSONPathHistory:
 		return m.renderJSONPathHistoryModal()
 	case ModeWebSocket:
@@ -765,6 +1001,13 @@ type requestExecutedMsg struct {
 	result      *types.RequestResult
 	warnings    []string // Unresolved variables
 	shellErrors []string // Shell command errors
+	oauthNotice string   // Set when the OAuth token was renewed after a 401
+}
+
+type oauthDeviceCodeMsg struct {
+	auth     *oauth.DeviceAuthorization
+	config   *oauth.Config
+	oauthCfg *types.OAuthConfig
 }
 
 type oauthSuccessMsg struct {
@@ -778,7 +1021,10 @@ type historyLoadedMsg struct {
 }
 
 type analyticsLoadedMsg struct {
-	stats []analytics.Stats
+	stats      []analytics.Stats
+	errorRates []analytics.ErrorRate
+	threshold  float64 // Error rate in percent above which an endpoint is flagged
+	window     int     // Error rate window in minutes
 }
 
 type promptInteractiveVarsMsg struct {
@@ -2,6 +2,9 @@ package tui
 
 import (
 	"testing"
+	"time"
+
+	"github.com/studiowebux/restcli/internal/types"
 )
 
 func TestNew_InitializesStateCorrectly(t *testing.T) {
@@ -127,3 +130,42 @@ func TestModel_VersionSet(t *testing.T) {
 
 	AssertModelField(t, "version", m.version, "test-version")
 }
+
+func TestModel_OAuthTokenNeedsRefresh(t *testing.T) {
+	m := CreateTestModel(t)
+	skew := 120
+	profile := &types.Profile{Name: "test", OAuth: &types.OAuthConfig{Enabled: true, RefreshSkew: &skew}}
+	vars := m.sessionMgr.GetSession().Variables
+
+	AssertModelField(t, "no expiry stored", m.oauthTokenNeedsRefresh(profile), false)
+
+	vars["token_expires_at"] = time.Now().Add(time.Minute).Format(time.RFC3339)
+	AssertModelField(t, "expires within skew", m.oauthTokenNeedsRefresh(profile), true)
+
+	vars["token_expires_at"] = time.Now().Add(time.Hour).Format(time.RFC3339)
+	AssertModelField(t, "expires after skew", m.oauthTokenNeedsRefresh(profile), false)
+
+	profile.OAuth.Enabled = false
+	vars["token_expires_at"] = time.Now().Format(time.RFC3339)
+	AssertModelField(t, "oauth disabled", m.oauthTokenNeedsRefresh(profile), false)
+}
+
+func TestModel_InjectOAuthToken(t *testing.T) {
+	m := CreateTestModel(t)
+	profile := &types.Profile{Name: "test", OAuth: &types.OAuthConfig{Enabled: true, TokenStorageKey: "api_token"}}
+	m.sessionMgr.GetSession().Variables["api_token"] = "abc"
+
+	headers := map[string]string{}
+	m.injectOAuthToken(profile, headers)
+	AssertModelField(t, "injected header", headers["Authorization"], "Bearer abc")
+
+	headers = map[string]string{"authorization": "Basic xyz"}
+	m.injectOAuthToken(profile, headers)
+	AssertModelField(t, "existing header kept", len(headers), 1)
+
+	disabled := false
+	profile.OAuth.AutoInject = &disabled
+	headers = map[string]string{}
+	m.injectOAuthToken(profile, headers)
+	AssertModelField(t, "autoInject disabled", len(headers), 0)
+}
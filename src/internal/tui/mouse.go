@@ -0,0 +1,82 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Mouse Support - Opt-in scrolling and selection with the mouse
+//
+// The program always captures mouse events so wheel scrolling does not scroll the
+// terminal buffer behind the alternate screen. Without the profile's mouseEnabled
+// the events are dropped and the TUI stays keyboard-only.
+
+// Screen rows of the main view: the panels' top border is row 0 and their title row 1
+const (
+	mouseTabBarRow   = 2 // Response tab bar, under the response title
+	mouseFileListRow = 3 // First file of the sidebar, after the title and a blank line
+)
+
+// mouseScrollLines is how far one wheel step scrolls the response
+const mouseScrollLines = 3
+
+// handleMouse scrolls the focused panel with the wheel and selects files and response tabs with a click
+func (m *Model) handleMouse(msg tea.MouseMsg) {
+	if !m.sessionMgr.GetActiveProfile().MouseEnabled || m.mode != ModeNormal {
+		return
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scrollFocusedPanel(-1)
+	case tea.MouseButtonWheelDown:
+		m.scrollFocusedPanel(1)
+	case tea.MouseButtonLeft:
+		if msg.Action == tea.MouseActionPress {
+			m.handleMouseClick(msg.X, msg.Y)
+		}
+	}
+}
+
+// scrollFocusedPanel moves the file selection or scrolls the response one wheel step
+func (m *Model) scrollFocusedPanel(direction int) {
+	if m.focusedPanel != "response" && !m.fullscreen {
+		m.navigateFiles(direction)
+		return
+	}
+	if !m.showBody || m.currentResponse == nil {
+		return
+	}
+	if direction < 0 {
+		m.responseView.ScrollUp(mouseScrollLines)
+	} else {
+		m.responseView.ScrollDown(mouseScrollLines)
+	}
+}
+
+// handleMouseClick focuses the panel under the pointer and selects the file or response tab clicked
+func (m *Model) handleMouseClick(x, y int) {
+	if m.fullscreen {
+		return
+	}
+
+	// The sidebar box spans its width plus two border columns
+	sidebarEnd := m.sidebarWidth() + 2
+	if x < sidebarEnd {
+		m.focusedPanel = "sidebar"
+		index := m.fileExplorer.GetScrollOffset() + y - mouseFileListRow
+		if y < mouseFileListRow || y-mouseFileListRow >= m.getFileListHeight() || index >= len(m.fileExplorer.GetFiles()) {
+			return
+		}
+		m.navigateFiles(index - m.fileExplorer.GetCurrentIndex())
+		return
+	}
+
+	m.focusedPanel = "response"
+	if y != mouseTabBarRow || m.loading {
+		return
+	}
+	// The response content starts after the left border and one column of padding
+	if tab := m.responseTabAt(x - sidebarEnd - 2); tab >= 0 {
+		m.activateResponseTab(tab)
+	}
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// mouseTestModel returns a model with three files and the mouse enabled or not
+func mouseTestModel(t *testing.T, enabled bool) *Model {
+	m := CreateTestModel(t)
+	m.width, m.height = 160, 40
+	m.updateViewport()
+	originalProfilesFile := config.ProfilesFile
+	config.ProfilesFile = filepath.Join(t.TempDir(), ".profiles.json")
+	t.Cleanup(func() { config.ProfilesFile = originalProfilesFile })
+	m.sessionMgr.AddProfile(types.Profile{Name: "Default", MouseEnabled: enabled})
+	files := []types.FileInfo{
+		{Name: "health.http", Path: "/w/health.http"},
+		{Name: "orders.http", Path: "/w/orders.http"},
+		{Name: "users.http", Path: "/w/users.http"},
+	}
+	m.fileExplorer.SetFiles(files, files)
+	return m
+}
+
+func TestMouse_DisabledByDefault(t *testing.T) {
+	m := mouseTestModel(t, false)
+	m.Update(tea.MouseMsg{X: 5, Y: mouseFileListRow + 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	m.Update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	AssertModelField(t, "current index", m.fileExplorer.GetCurrentIndex(), 0)
+}
+
+func TestMouse_ClickSelectsFile(t *testing.T) {
+	m := mouseTestModel(t, true)
+
+	// The row clicked is the row the file is rendered on
+	lines := strings.Split(stripANSI(m.renderMain()), "\n")
+	if !strings.Contains(lines[mouseFileListRow+2], "users.http") {
+		t.Fatalf("Expected users.http on row %d, got %q", mouseFileListRow+2, lines[mouseFileListRow+2])
+	}
+
+	m.focusedPanel = "response"
+	m.Update(tea.MouseMsg{X: 5, Y: mouseFileListRow + 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	AssertModelField(t, "current file", m.fileExplorer.GetCurrentFile().Name, "users.http")
+	AssertModelField(t, "focusedPanel", m.focusedPanel, "sidebar")
+
+	// Below the last file: nothing changes
+	m.Update(tea.MouseMsg{X: 5, Y: mouseFileListRow + 5, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	AssertModelField(t, "current index", m.fileExplorer.GetCurrentIndex(), 2)
+
+	// The wheel moves the selection of the focused sidebar
+	m.Update(tea.MouseMsg{Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress})
+	AssertModelField(t, "current index after wheel", m.fileExplorer.GetCurrentIndex(), 1)
+}
+
+func TestMouse_ClickSelectsResponseTab(t *testing.T) {
+	m := mouseTestModel(t, true)
+	first := &types.RequestResult{Status: 200, Body: "1"}
+	second := &types.RequestResult{Status: 200, Body: "2"}
+	m.openResponseTab(&types.HttpRequest{Name: "first", Method: "GET", URL: "http://localhost/1"}, first)
+	m.openResponseTab(&types.HttpRequest{Name: "second", Method: "GET", URL: "http://localhost/2"}, second)
+
+	// Click on the label of the first tab, where it is rendered
+	line := stripANSI(strings.Split(m.renderMain(), "\n")[mouseTabBarRow])
+	x := strings.Index(line, " 1 first ")
+	if x < 0 {
+		t.Fatalf("Expected the tab bar on row %d, got %q", mouseTabBarRow, line)
+	}
+	m.Update(tea.MouseMsg{X: x + 2, Y: mouseTabBarRow, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	AssertModelField(t, "activeTab", m.activeTab, 0)
+	AssertModelField(t, "currentResponse", m.currentResponse, first)
+	AssertModelField(t, "focusedPanel", m.focusedPanel, "response")
+}
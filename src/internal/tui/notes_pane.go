@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/studiowebux/restcli/internal/keybinds"
+)
+
+// Notes Pane - A scratchpad per request file, shown next to the response
+//
+// Notes are stored in the session file keyed by the path of the request file,
+// so they survive restarts without changing the file itself. They have nothing
+// to do with the documentation viewer, which is parsed from the file's comments.
+
+// notesPaneWidth returns the width of the notes pane, 0 when it is hidden
+func (m Model) notesPaneWidth() int {
+	if !m.showNotes || m.fullscreen {
+		return 0
+	}
+	return max(28, m.width*25/100)
+}
+
+// notesFilePath returns the file whose notes are shown: the one being edited, else the selected one
+func (m Model) notesFilePath() string {
+	if m.mode == ModeNotesEdit {
+		return m.notesPath
+	}
+	if file := m.fileExplorer.GetCurrentFile(); file != nil {
+		return file.Path
+	}
+	return ""
+}
+
+// toggleNotesPane shows or hides the notes pane
+func (m *Model) toggleNotesPane() tea.Cmd {
+	m.showNotes = !m.showNotes
+	// The response panel gives up or takes back the width of the pane
+	m.updateViewport()
+	m.updateResponseView()
+	if m.showNotes {
+		return m.setStatusMessage("Notes pane shown (a to edit)")
+	}
+	return m.setStatusMessage("Notes pane hidden")
+}
+
+// startNotesEdit opens the notes of the selected file for editing, showing the pane if needed
+func (m *Model) startNotesEdit() tea.Cmd {
+	file := m.fileExplorer.GetCurrentFile()
+	if file == nil {
+		return m.setErrorMessage("No file selected")
+	}
+
+	if !m.showNotes {
+		m.showNotes = true
+		m.updateViewport()
+		m.updateResponseView()
+	}
+	m.notesPath = file.Path
+	m.notesInput = m.sessionMgr.GetNote(file.Path)
+	m.notesCursor = len(m.notesInput)
+	m.errorMsg = ""
+	m.mode = ModeNotesEdit
+	m.statusMsg = "Editing notes (Ctrl+S to save, ESC to cancel)"
+	return nil
+}
+
+// saveNotes stores the edited notes in the session
+func (m *Model) saveNotes() tea.Cmd {
+	if err := m.sessionMgr.SetNote(m.notesPath, m.notesInput); err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to save notes: %v", err))
+	}
+
+	m.mode = ModeNormal
+	m.notesInput = ""
+	m.notesCursor = 0
+	return m.setStatusMessage(fmt.Sprintf("Notes saved for %s", filepath.Base(m.notesPath)))
+}
+
+// handleNotesEditKeys handles the multi-line notes editor
+func (m *Model) handleNotesEditKeys(msg tea.KeyMsg) tea.Cmd {
+	// Special keys not in registry (multi-line editor)
+	switch msg.String() {
+	case "ctrl+s":
+		return m.saveNotes()
+
+	case "up":
+		m.notesCursor = moveCursorLine(m.notesInput, m.notesCursor, -1)
+		return nil
+
+	case "down":
+		m.notesCursor = moveCursorLine(m.notesInput, m.notesCursor, 1)
+		return nil
+
+	case "enter":
+		m.notesInput = m.notesInput[:m.notesCursor] + "\n" + m.notesInput[m.notesCursor:]
+		m.notesCursor++
+		return nil
+
+	case "tab":
+		m.notesInput = m.notesInput[:m.notesCursor] + "  " + m.notesInput[m.notesCursor:]
+		m.notesCursor += 2
+		return nil
+	}
+
+	if action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String()); ok && action == keybinds.ActionTextCancel {
+		m.mode = ModeNormal
+		m.notesInput = ""
+		m.notesCursor = 0
+		m.statusMsg = "Notes unchanged"
+		return nil
+	}
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.notesInput, &m.notesCursor, msg); shouldContinue {
+		return nil
+	}
+	if len(msg.String()) == 1 {
+		m.notesInput = m.notesInput[:m.notesCursor] + msg.String() + m.notesInput[m.notesCursor:]
+		m.notesCursor++
+	}
+	return nil
+}
+
+// renderNotesPane renders the notes of the current file in the side panel
+func (m Model) renderNotesPane(width, height int) string {
+	editing := m.mode == ModeNotesEdit
+	titleStyle := styleTitleUnfocused
+	if editing {
+		titleStyle = styleTitleFocused
+	}
+	lines := []string{titleStyle.Render("Notes")}
+
+	path := m.notesFilePath()
+	if path == "" {
+		lines = append(lines, "", styleSubtle.Render("No file selected"))
+		return lipgloss.NewStyle().Width(width).Height(height).Padding(0, 1).Render(strings.Join(lines, "\n"))
+	}
+	lines = append(lines, styleSubtle.Render(truncate(filepath.Base(path), max(width-2, 4))), "")
+
+	// Title, file name, blank line and the footer hint take 5 lines
+	available := max(height-5, 1)
+	text := m.sessionMgr.GetNote(path)
+	if editing {
+		text = addCursorAt(m.notesInput, m.notesCursor)
+	}
+
+	if text == "" {
+		lines = append(lines, styleSubtle.Render("No notes for this file"))
+	} else {
+		textLines := strings.Split(wrapText(text, width-2), "\n")
+		start := 0
+		if editing {
+			// Keep the cursor in view
+			for i, line := range textLines {
+				if strings.Contains(line, "█") {
+					start = max(i-available+1, 0)
+					break
+				}
+			}
+		}
+		end := min(start+available, len(textLines))
+		lines = append(lines, textLines[start:end]...)
+		if !editing && end < len(textLines) {
+			lines[len(lines)-1] = styleSubtle.Render(fmt.Sprintf("... %d more lines", len(textLines)-end+1))
+		}
+	}
+
+	hint := "[a] edit [Ctrl+G] hide"
+	if editing {
+		hint = "[Ctrl+S] save [ESC] cancel"
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines[:max(height-1, 0)], styleSubtle.Render(hint))
+
+	return lipgloss.NewStyle().Width(width).Height(height).Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestNotesPane_EditAndPersist(t *testing.T) {
+	m := CreateTestModel(t)
+	useTempSession(t, m)
+	m.width, m.height = 160, 40
+	files := []types.FileInfo{{Name: "users.http", Path: "/w/users.http"}, {Name: "orders.http", Path: "/w/orders.http"}}
+	m.fileExplorer.SetFiles(files, files)
+
+	// Editing shows the pane
+	typeText(m, "a")
+	AssertModelField(t, "mode", m.mode, ModeNotesEdit)
+	AssertModelField(t, "showNotes", m.showNotes, true)
+	typeText(m, "needs")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	typeText(m, "X-Tenant")
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	AssertModelField(t, "mode", m.mode, ModeNormal)
+
+	// Notes survive a restart
+	if err := m.sessionMgr.LoadSession(); err != nil {
+		t.Fatal(err)
+	}
+	AssertModelField(t, "note", m.sessionMgr.GetNote("/w/users.http"), "needs\nX-Tenant")
+	if pane := m.renderNotesPane(40, 20); !strings.Contains(pane, "X-Tenant") {
+		t.Errorf("Expected the notes in the pane, got:\n%s", pane)
+	}
+	if view := m.renderMain(); !strings.Contains(view, "X-Tenant") {
+		t.Error("Expected the notes pane in the main view")
+	}
+
+	// Notes belong to their file
+	m.fileExplorer.Navigate(1, 10)
+	if pane := m.renderNotesPane(40, 20); !strings.Contains(pane, "No notes for this file") {
+		t.Errorf("Expected no notes for another file, got:\n%s", pane)
+	}
+
+	// Cancelling keeps the saved notes, blank notes clear them
+	m.fileExplorer.Navigate(-1, 10)
+	typeText(m, "a")
+	typeText(m, "!")
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	AssertModelField(t, "note after cancel", m.sessionMgr.GetNote("/w/users.http"), "needs\nX-Tenant")
+
+	m.startNotesEdit()
+	m.notesInput = "  \n"
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if _, ok := m.sessionMgr.GetSession().Notes["/w/users.http"]; ok {
+		t.Error("Expected blank notes to be removed")
+	}
+
+	m.toggleNotesPane()
+	AssertModelField(t, "showNotes", m.showNotes, false)
+	AssertModelField(t, "notesPaneWidth", m.notesPaneWidth(), 0)
+}
+
+func TestMoveCursorLine(t *testing.T) {
+	text := "first line\nab\nthird line"
+	tests := []struct {
+		cursor, delta, want int
+	}{
+		{5, -1, 5},   // First line: unchanged
+		{5, 1, 13},   // Column past the end of "ab"
+		{12, 1, 15},  // Column kept
+		{15, -1, 12}, // Back up
+		{20, 1, 20},  // Last line: unchanged
+		{11, -1, 0},  // Start of a line
+	}
+	for _, tt := range tests {
+		if got := moveCursorLine(text, tt.cursor, tt.delta); got != tt.want {
+			t.Errorf("moveCursorLine(%d, %d): expected %d, got %d", tt.cursor, tt.delta, tt.want, got)
+		}
+	}
+}
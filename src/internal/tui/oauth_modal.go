@@ -14,7 +14,9 @@ import (
 // OAuth field indices
 const (
 	oauthFieldEnabled = iota
+	oauthFieldGrantType
 	oauthFieldAuthURL
+	oauthFieldDeviceAuthURL
 	oauthFieldTokenURL
 	oauthFieldClientID
 	oauthFieldClientSecret
@@ -23,6 +25,7 @@ const (
 	oauthFieldResponseType
 	oauthFieldPort
 	oauthFieldTokenKey
+	oauthFieldAutoRefresh
 	oauthFieldCount
 )
 
@@ -51,7 +54,9 @@ func (m *Model) renderOAuthConfig() string {
 		value string
 	}{
 		{"Enabled", fmt.Sprintf("%v", oauth.Enabled)},
+		{"Grant Type", oauthGrantTypeLabel(oauth.GrantType)},
 		{"Auth URL", oauth.AuthURL},
+		{"Device Auth URL", oauth.DeviceAuthURL},
 		{"Token URL", oauth.TokenURL},
 		{"Client ID", oauth.ClientID},
 		{"Client Secret", strings.Repeat("*", len(oauth.ClientSecret))},
@@ -60,6 +65,7 @@ func (m *Model) renderOAuthConfig() string {
 		{"Response Type", oauth.ResponseType},
 		{"Webhook Port", fmt.Sprintf("%d", oauth.WebhookPort)},
 		{"Token Storage Key", oauth.TokenStorageKey},
+		{"Auto Refresh", fmt.Sprintf("%v", oauth.AutoRefresh)},
 	}
 
 	for i, field := range fields {
@@ -70,7 +76,7 @@ func (m *Model) renderOAuthConfig() string {
 		content.WriteString(line + "\n")
 	}
 
-	content.WriteString("\n↑/↓ navigate, [e]dit field, [t]oggle enabled, [s]ave, ESC cancel")
+	content.WriteString("\n↑/↓ navigate, [e]dit field, [t]oggle enabled/auto refresh, [s]ave, ESC cancel")
 
 	return m.renderModal("OAuth Configuration", content.String(), 70, 25)
 }
@@ -88,7 +94,9 @@ func (m *Model) renderOAuthEdit() string {
 	// Get field label
 	fieldLabels := []string{
 		"Enabled",
+		"Grant Type",
 		"Auth URL",
+		"Device Auth URL",
 		"Token URL",
 		"Client ID",
 		"Client Secret",
@@ -97,6 +105,7 @@ func (m *Model) renderOAuthEdit() string {
 		"Response Type",
 		"Webhook Port",
 		"Token Storage Key",
+		"Auto Refresh",
 	}
 
 	if m.oauthField >= 0 && m.oauthField < len(fieldLabels) {
@@ -131,8 +140,11 @@ func (m *Model) handleOAuthKeys(msg tea.KeyMsg) tea.Cmd {
 	// Handle special keys not in registry
 	switch msg.String() {
 	case "t":
-		if m.oauthField == oauthFieldEnabled {
+		switch m.oauthField {
+		case oauthFieldEnabled:
 			profile.OAuth.Enabled = !profile.OAuth.Enabled
+		case oauthFieldAutoRefresh:
+			profile.OAuth.AutoRefresh = !profile.OAuth.AutoRefresh
 		}
 		return nil
 
@@ -266,8 +278,12 @@ func (m *Model) getOAuthFieldValue(oauth *types.OAuthConfig, fieldIndex int) str
 	switch fieldIndex {
 	case oauthFieldEnabled:
 		return fmt.Sprintf("%v", oauth.Enabled)
+	case oauthFieldGrantType:
+		return oauth.GrantType
 	case oauthFieldAuthURL:
 		return oauth.AuthURL
+	case oauthFieldDeviceAuthURL:
+		return oauth.DeviceAuthURL
 	case oauthFieldTokenURL:
 		return oauth.TokenURL
 	case oauthFieldClientID:
@@ -284,6 +300,8 @@ func (m *Model) getOAuthFieldValue(oauth *types.OAuthConfig, fieldIndex int) str
 		return fmt.Sprintf("%d", oauth.WebhookPort)
 	case oauthFieldTokenKey:
 		return oauth.TokenStorageKey
+	case oauthFieldAutoRefresh:
+		return fmt.Sprintf("%v", oauth.AutoRefresh)
 	default:
 		return ""
 	}
@@ -292,8 +310,12 @@ func (m *Model) getOAuthFieldValue(oauth *types.OAuthConfig, fieldIndex int) str
 // setOAuthFieldValue sets the value of an OAuth field from a string
 func (m *Model) setOAuthFieldValue(oauth *types.OAuthConfig, fieldIndex int, value string) {
 	switch fieldIndex {
+	case oauthFieldGrantType:
+		oauth.GrantType = strings.TrimSpace(value)
 	case oauthFieldAuthURL:
 		oauth.AuthURL = value
+	case oauthFieldDeviceAuthURL:
+		oauth.DeviceAuthURL = value
 	case oauthFieldTokenURL:
 		oauth.TokenURL = value
 	case oauthFieldClientID:
@@ -314,3 +336,55 @@ func (m *Model) setOAuthFieldValue(oauth *types.OAuthConfig, fieldIndex int, val
 		oauth.TokenStorageKey = value
 	}
 }
+
+// oauthGrantTypeLabel returns the grant type shown in the editor (empty means authorization code)
+func oauthGrantTypeLabel(grantType string) string {
+	if grantType == "" {
+		return "authorization_code"
+	}
+	return grantType
+}
+
+// renderOAuthDevice renders the device flow modal with the code to enter on another device
+func (m *Model) renderOAuthDevice() string {
+	auth := m.oauthDevice
+	if auth == nil {
+		return m.renderModal("OAuth Device Login", "No device authorization in progress", 70, 10)
+	}
+
+	var content strings.Builder
+	content.WriteString("Open this URL on any device and enter the code:\n\n")
+	content.WriteString(fmt.Sprintf("  URL:  %s\n", auth.VerificationURI))
+	content.WriteString(fmt.Sprintf("  Code: %s\n", styleSelected.Render(auth.UserCode)))
+	if auth.VerificationURIComplete != "" {
+		content.WriteString(fmt.Sprintf("\nOr open directly:\n  %s\n", auth.VerificationURIComplete))
+	}
+	content.WriteString("\nWaiting for approval...")
+	if auth.ExpiresIn > 0 {
+		content.WriteString(fmt.Sprintf(" (code expires in %d minutes)", (auth.ExpiresIn+59)/60))
+	}
+
+	return m.renderModalWithFooter("OAuth Device Login", content.String(), "ESC: cancel", 70, 16)
+}
+
+// handleOAuthDeviceKeys handles keyboard input while waiting for device approval
+func (m *Model) handleOAuthDeviceKeys(msg tea.KeyMsg) tea.Cmd {
+	action, ok := m.keybinds.Match(keybinds.ContextModal, msg.String())
+	if ok && action == keybinds.ActionCloseModal {
+		m.closeOAuthDevice()
+		m.statusMsg = "OAuth device authorization cancelled"
+	}
+	return nil
+}
+
+// closeOAuthDevice stops device polling and closes its modal
+func (m *Model) closeOAuthDevice() {
+	if m.oauthDeviceCancel != nil {
+		m.oauthDeviceCancel()
+		m.oauthDeviceCancel = nil
+	}
+	m.oauthDevice = nil
+	if m.mode == ModeOAuthDevice {
+		m.mode = ModeNormal
+	}
+}
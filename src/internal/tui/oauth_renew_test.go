@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// newTokenServer issues "new-token" for the grants in accepted and rejects the others
+func newTokenServer(t *testing.T, accepted ...string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var grants []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		grant := r.Form.Get("grant_type")
+		grants = append(grants, grant)
+		for _, g := range accepted {
+			if g == grant {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"access_token":"new-token","token_type":"Bearer","expires_in":3600}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &grants
+}
+
+// oauthTestModel returns a model whose active profile uses the client_credentials grant against tokenURL
+// The session holds an old access token and a refresh token
+func oauthTestModel(t *testing.T, tokenURL string) (*Model, *types.Profile) {
+	m := CreateTestModel(t)
+	useTempSession(t, m)
+	originalProfilesFile := config.ProfilesFile
+	config.ProfilesFile = filepath.Join(t.TempDir(), ".profiles.json")
+	t.Cleanup(func() { config.ProfilesFile = originalProfilesFile })
+
+	m.sessionMgr.AddProfile(types.Profile{Name: "Default", OAuth: &types.OAuthConfig{
+		Enabled:      true,
+		GrantType:    "client_credentials",
+		TokenURL:     tokenURL,
+		ClientID:     "svc",
+		ClientSecret: "s3cret",
+		AutoRefresh:  true,
+	}})
+	m.sessionMgr.SetSessionVariable("token", "old-token")
+	m.sessionMgr.SetSessionVariable("token_refresh", "old-refresh")
+	return m, m.sessionMgr.GetActiveProfile()
+}
+
+func TestRenewOAuthToken_Refresh(t *testing.T) {
+	tokenServer, grants := newTokenServer(t, "refresh_token")
+	m, profile := oauthTestModel(t, tokenServer.URL)
+
+	notice, err := m.renewOAuthToken(profile)
+	if err != nil {
+		t.Fatalf("renewOAuthToken failed: %v", err)
+	}
+	AssertModelField(t, "notice", notice, "OAuth token refreshed")
+	AssertModelField(t, "grants", strings.Join(*grants, ","), "refresh_token")
+	AssertModelField(t, "stored token", m.sessionMgr.GetSession().Variables["token"], "new-token")
+	AssertModelField(t, "refresh token kept", m.sessionMgr.GetSession().Variables["token_refresh"], "old-refresh")
+}
+
+func TestRenewOAuthToken_FallsBackToFullFlow(t *testing.T) {
+	tokenServer, grants := newTokenServer(t, "client_credentials")
+	m, profile := oauthTestModel(t, tokenServer.URL)
+
+	notice, err := m.renewOAuthToken(profile)
+	if err != nil {
+		t.Fatalf("renewOAuthToken failed: %v", err)
+	}
+	AssertModelField(t, "notice", notice, "OAuth re-authorized")
+	AssertModelField(t, "grants", strings.Join(*grants, ","), "refresh_token,client_credentials")
+	AssertModelField(t, "stored token", m.sessionMgr.GetSession().Variables["token"], "new-token")
+}
+
+func TestExecuteRequest_RetriesOnceOn401(t *testing.T) {
+	tests := []struct {
+		name       string
+		validToken string // Token the API accepts
+		wantStatus int
+		wantNotice string
+	}{
+		{"renewed token accepted", "new-token", 200, "OAuth token refreshed, request retried"},
+		{"still unauthorized", "never", 401, "OAuth token refreshed, request retried"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				if r.Header.Get("Authorization") != "Bearer "+tt.validToken {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+			}))
+			defer api.Close()
+
+			tokenServer, _ := newTokenServer(t, "refresh_token")
+			m, _ := oauthTestModel(t, tokenServer.URL)
+			m.currentRequest = &types.HttpRequest{Name: "Me", Method: "GET", URL: api.URL + "/me"}
+
+			msg, ok := m.executeRequest()().(requestExecutedMsg)
+			if !ok {
+				t.Fatal("Expected the request to complete")
+			}
+			AssertModelField(t, "status", msg.result.Status, tt.wantStatus)
+			AssertModelField(t, "notice", msg.oauthNotice, tt.wantNotice)
+			AssertModelField(t, "requests sent", hits.Load(), int32(2))
+		})
+	}
+}
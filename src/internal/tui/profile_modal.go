@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/session"
 	"github.com/studiowebux/restcli/internal/types"
 )
 
@@ -74,8 +75,8 @@ func (m *Model) handleProfileSwitchKeys(msg tea.KeyMsg) tea.Cmd {
 			m.mode = ModeNormal
 			m.statusMsg = fmt.Sprintf("Switched to profile: %s", selectedProfile.Name)
 
-			// Reload files from new profile's workdir
-			return m.refreshFiles()
+			// Reload files from new profile's workdir and apply its keybinds
+			return tea.Batch(m.reloadKeybinds(), m.reloadTheme(), m.refreshFiles())
 		}
 
 	case keybinds.ActionProfileDuplicate:
@@ -92,6 +93,14 @@ func (m *Model) handleProfileSwitchKeys(msg tea.KeyMsg) tea.Cmd {
 			m.errorMsg = ""
 		}
 
+	case keybinds.ActionProfileExport:
+		if m.profileIndex < len(profiles) {
+			return m.openProfileExport(profiles[m.profileIndex].Name)
+		}
+
+	case keybinds.ActionProfileImport:
+		return m.openProfileImport()
+
 	case keybinds.ActionProfileCreate:
 		m.mode = ModeProfileCreate
 		m.profileName = ""
@@ -102,6 +111,39 @@ func (m *Model) handleProfileSwitchKeys(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+// cycleEnvironment switches the active profile to its next environment, wrapping back to the base variables
+func (m *Model) cycleEnvironment() tea.Cmd {
+	profile := m.sessionMgr.GetActiveProfile()
+	names := session.EnvironmentNames(profile)
+	if len(names) == 0 {
+		return m.setErrorMessage(fmt.Sprintf("Profile %s has no environments", profile.Name))
+	}
+
+	// The base variables come first, then each environment in order
+	choices := append([]string{""}, names...)
+	current := m.sessionMgr.ActiveEnvironment(profile.Name)
+	next := choices[0]
+	for i, name := range choices {
+		if name == current {
+			next = choices[(i+1)%len(choices)]
+			break
+		}
+	}
+	return m.switchEnvironment(next)
+}
+
+// switchEnvironment selects an environment of the active profile, an empty name returns to its base variables
+func (m *Model) switchEnvironment(next string) tea.Cmd {
+	profile := m.sessionMgr.GetActiveProfile()
+	if err := m.sessionMgr.SetActiveEnvironment(profile.Name, next); err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to switch environment: %v", err))
+	}
+	if next == "" {
+		return m.setStatusMessage(fmt.Sprintf("Switched to the base variables of %s", profile.Name))
+	}
+	return m.setStatusMessage(fmt.Sprintf("Switched to environment: %s:%s", profile.Name, next))
+}
+
 // handleProfileCreateKeys handles profile creation
 func (m *Model) handleProfileCreateKeys(msg tea.KeyMsg) tea.Cmd {
 	action, ok := m.keybinds.Match(keybinds.ContextProfileEdit, msg.String())
@@ -135,8 +177,8 @@ func (m *Model) handleProfileCreateKeys(msg tea.KeyMsg) tea.Cmd {
 			m.mode = ModeNormal
 			m.statusMsg = fmt.Sprintf("Created and switched to profile: %s", m.profileName)
 
-			// Reload files
-			return m.refreshFiles()
+			// Reload files and keybinds
+			return tea.Batch(m.reloadKeybinds(), m.reloadTheme(), m.refreshFiles())
 		}
 	}
 
@@ -182,6 +224,7 @@ func (m *Model) handleProfileDuplicateKeys(msg tea.KeyMsg) tea.Cmd {
 				Name:             m.profileName,
 				Workdir:          sourceProfile.Workdir,
 				Editor:           sourceProfile.Editor,
+				Keybinds:         sourceProfile.Keybinds,
 				Output:           sourceProfile.Output,
 				HistoryEnabled:   sourceProfile.HistoryEnabled,
 				AnalyticsEnabled: sourceProfile.AnalyticsEnabled,
@@ -215,8 +258,8 @@ func (m *Model) handleProfileDuplicateKeys(msg tea.KeyMsg) tea.Cmd {
 			m.mode = ModeNormal
 			m.statusMsg = fmt.Sprintf("Duplicated profile '%s' as '%s'", sourceProfile.Name, m.profileName)
 
-			// Reload files
-			return m.refreshFiles()
+			// Reload files and keybinds
+			return tea.Batch(m.reloadKeybinds(), m.reloadTheme(), m.refreshFiles())
 		}
 	}
 
@@ -464,7 +507,7 @@ func (m *Model) renderProfileModal() string {
 			}
 			content.WriteString(line + "\n")
 		}
-		footer := "↑/↓ select [Enter] switch [e]dit [d]uplicate [D]elete | [n]ew [ESC] cancel"
+		footer := "↑/↓ select [Enter] switch [e]dit [d]uplicate [D]elete e[x]port | [n]ew [i]mport [ESC] cancel"
 		// Use auto-scroll to keep selected profile visible
 		return m.renderModalWithFooterAndScroll("Switch Profile", content.String(), footer, 70, 15, m.profileIndex)
 
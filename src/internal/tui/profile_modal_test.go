@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestCycleEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	originalProfilesFile, originalSessionFile := config.ProfilesFile, config.SessionFile
+	config.ProfilesFile = filepath.Join(dir, ".profiles.json")
+	config.SessionFile = filepath.Join(dir, ".session.json")
+	t.Cleanup(func() {
+		config.ProfilesFile, config.SessionFile = originalProfilesFile, originalSessionFile
+	})
+
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+
+	base, us, ap := "https://eu.example.com", "https://us.example.com", "https://ap.example.com"
+	profile := types.Profile{
+		Name:      "api",
+		Variables: map[string]types.VariableValue{"baseUrl": {StringValue: &base}},
+		Environments: map[string]types.Environment{
+			"us": {"baseUrl": {StringValue: &us}},
+			"ap": {"baseUrl": {StringValue: &ap}},
+		},
+	}
+	if err := m.sessionMgr.AddProfile(profile); err != nil {
+		t.Fatalf("Failed to add profile: %v", err)
+	}
+
+	baseURL := func() string {
+		value := m.sessionMgr.ProfileVariables(m.sessionMgr.GetActiveProfile())["baseUrl"]
+		return value.GetValue()
+	}
+
+	// Environments are visited in name order, then back to the base variables
+	for _, expected := range []string{"ap", "us", ""} {
+		m.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+		AssertModelField(t, "active environment", m.sessionMgr.ActiveEnvironment("api"), expected)
+	}
+	AssertModelField(t, "base url", baseURL(), base)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+	AssertModelField(t, "base url", baseURL(), ap)
+	if !strings.Contains(m.renderStatusBar(), "Profile: api:ap") {
+		t.Errorf("Expected profile:env in the status bar, got:\n%s", m.renderStatusBar())
+	}
+}
+
+func TestCycleEnvironment_NoEnvironments(t *testing.T) {
+	m := CreateTestModel(t)
+
+	m.cycleEnvironment()
+	if !strings.Contains(m.errorMsg, "has no environments") {
+		t.Errorf("Expected an error without environments, got %q", m.errorMsg)
+	}
+}
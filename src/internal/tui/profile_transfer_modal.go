@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+)
+
+// openProfileExport prompts for the file to export a profile to
+func (m *Model) openProfileExport(name string) tea.Cmd {
+	m.profileTransferInput = name + ".profile.json"
+	m.profileTransferCursor = len(m.profileTransferInput)
+	m.profileTransferOption = false
+	m.errorMsg = ""
+	m.mode = ModeProfileExport
+	return nil
+}
+
+// openProfileImport prompts for the shared profile file to import
+func (m *Model) openProfileImport() tea.Cmd {
+	m.profileTransferInput = ""
+	m.profileTransferCursor = 0
+	m.profileTransferOption = false
+	m.errorMsg = ""
+	m.mode = ModeProfileImport
+	return nil
+}
+
+// handleProfileTransferKeys handles the export/import path prompt
+// Tab toggles including secrets (export) or merging on a name collision (import)
+func (m *Model) handleProfileTransferKeys(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() == "tab" {
+		m.profileTransferOption = !m.profileTransferOption
+		return nil
+	}
+
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.mode = ModeProfileSwitch
+			m.errorMsg = ""
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			if m.mode == ModeProfileExport {
+				return m.exportProfile()
+			}
+			return m.importProfile()
+		}
+	}
+
+	// Clear error when user starts typing
+	m.errorMsg = ""
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.profileTransferInput, &m.profileTransferCursor, msg); shouldContinue {
+		return nil
+	}
+	if len(msg.String()) == 1 {
+		m.profileTransferInput = m.profileTransferInput[:m.profileTransferCursor] + msg.String() + m.profileTransferInput[m.profileTransferCursor:]
+		m.profileTransferCursor++
+	}
+	return nil
+}
+
+// exportProfile writes the selected profile to the entered path
+func (m *Model) exportProfile() tea.Cmd {
+	path := strings.TrimSpace(m.profileTransferInput)
+	if path == "" {
+		m.errorMsg = "Path cannot be empty"
+		return nil
+	}
+
+	profiles := m.sessionMgr.GetProfiles()
+	if m.profileIndex >= len(profiles) {
+		m.errorMsg = "Invalid profile selection"
+		return nil
+	}
+	name := profiles[m.profileIndex].Name
+
+	export, err := m.sessionMgr.ExportProfile(name, path, m.profileTransferOption)
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Failed to export profile: %v", err)
+		return nil
+	}
+
+	m.mode = ModeProfileSwitch
+	status := fmt.Sprintf("Exported profile '%s' to %s", name, path)
+	if len(export.Redacted) > 0 {
+		status += fmt.Sprintf(" (%d secret values redacted)", len(export.Redacted))
+	}
+	return m.setStatusMessage(status)
+}
+
+// importProfile adds the profile from the entered file
+func (m *Model) importProfile() tea.Cmd {
+	path := strings.TrimSpace(m.profileTransferInput)
+	if path == "" {
+		m.errorMsg = "Path cannot be empty"
+		return nil
+	}
+
+	name, err := m.sessionMgr.ImportProfile(path, m.profileTransferOption)
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Failed to import profile: %v", err)
+		return nil
+	}
+
+	for i, profile := range m.sessionMgr.GetProfiles() {
+		if profile.Name == name {
+			m.profileIndex = i
+		}
+	}
+	m.mode = ModeProfileSwitch
+	return m.setStatusMessage(fmt.Sprintf("Imported profile '%s'", name))
+}
+
+// renderProfileTransferModal renders the export/import path prompt
+func (m *Model) renderProfileTransferModal() string {
+	inputWithCursor := m.profileTransferInput[:m.profileTransferCursor] + "█" + m.profileTransferInput[m.profileTransferCursor:]
+	content := "File: " + inputWithCursor + "\n\n"
+
+	title := "Import Profile"
+	hint := "Imports a profile exported with e[x]port. Merge overlays it on the profile with the same name (local secrets are kept), otherwise it is added under a new name."
+	if m.mode == ModeProfileExport {
+		title = "Export Profile"
+		secretsValue := "redacted"
+		if m.profileTransferOption {
+			secretsValue = "included"
+		}
+		content += "Secrets: " + styleSelected.Render(secretsValue)
+		hint = "Encrypted values, keychain references and OAuth/signing secrets are left empty unless included."
+	} else {
+		collision := "rename"
+		if m.profileTransferOption {
+			collision = "merge"
+		}
+		content += "On name collision: " + styleSelected.Render(collision)
+	}
+
+	if m.errorMsg != "" {
+		content += "\n\n" + styleError.Render(wrapText(m.errorMsg, 64))
+	}
+	content += "\n\n" + wrapText(hint, 64)
+
+	footer := "[TAB] toggle [Enter] confirm [ESC] cancel"
+	return m.renderModalWithFooter(title, content, footer, 70, 16)
+}
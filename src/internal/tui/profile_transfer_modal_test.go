@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestProfileTransfer_ExportThenImport(t *testing.T) {
+	m := CreateTestModel(t)
+	dir := t.TempDir()
+
+	originalProfilesFile, originalSessionFile := config.ProfilesFile, config.SessionFile
+	config.ProfilesFile = filepath.Join(dir, ".profiles.json")
+	config.SessionFile = filepath.Join(dir, ".session.json")
+	t.Cleanup(func() { config.ProfilesFile, config.SessionFile = originalProfilesFile, originalSessionFile })
+
+	token := "enc:abcdef"
+	profile := types.Profile{Name: "Team", Variables: map[string]types.VariableValue{"token": {StringValue: &token}}}
+	if err := m.sessionMgr.AddProfile(profile); err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range m.sessionMgr.GetProfiles() {
+		if p.Name == "Team" {
+			m.profileIndex = i
+		}
+	}
+
+	m.mode = ModeProfileSwitch
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	AssertModelField(t, "mode", ModeProfileExport, m.mode)
+	AssertModelField(t, "profileTransferInput", "Team.profile.json", m.profileTransferInput)
+
+	path := filepath.Join(dir, "team.json")
+	m.profileTransferInput = path
+	m.profileTransferCursor = len(path)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "mode", ModeProfileSwitch, m.mode)
+	if !strings.Contains(m.fullStatusMsg, "1 secret values redacted") {
+		t.Errorf("Expected the redaction in the status, got %q (error %q)", m.fullStatusMsg, m.errorMsg)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	AssertModelField(t, "mode", ModeProfileImport, m.mode)
+	typeText(m, path)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "mode", ModeProfileSwitch, m.mode)
+
+	profiles := m.sessionMgr.GetProfiles()
+	if imported := profiles[m.profileIndex]; imported.Name != "Team-imported" {
+		t.Errorf("Expected the import to be renamed and selected, got %q", imported.Name)
+	}
+}
@@ -30,7 +30,11 @@ func (m Model) renderProxyDetailModal() string {
 	log := m.proxyServerState.GetLogs()[m.proxyServerState.GetSelectedIndex()]
 
 	// Fixed footer for keybinds
-	footer := styleSubtle.Render("↑/↓ scroll | Ctrl+d/u half page | g/G top/bottom | ESC close")
+	importHeaders := "off"
+	if m.proxyImportHeaders {
+		importHeaders = "on"
+	}
+	footer := styleSubtle.Render(fmt.Sprintf("↑/↓ scroll | Ctrl+d/u half page | g/G top/bottom | s save as .http | i import headers (%s) | ESC close", importHeaders))
 
 	modalWidth := m.width - ModalWidthMargin
 	modalHeight := m.height - ModalHeightMargin
@@ -108,11 +112,11 @@ func (m *Model) buildProxyDetailContent() string {
 	content.WriteString(styleTitle.Render("Response") + "\n")
 	var statusStyle lipgloss.Style
 	if log.Status >= 200 && log.Status < 300 {
-		statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+		statusStyle = styleSuccess
 	} else if log.Status >= 300 && log.Status < 400 {
-		statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+		statusStyle = styleWarning
 	} else if log.Status >= 400 {
-		statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+		statusStyle = styleError
 	}
 	content.WriteString(statusStyle.Render(fmt.Sprintf("%d %s", log.Status, log.StatusText)) + "\n\n")
 
@@ -159,6 +163,19 @@ func (m *Model) buildProxyDetailContent() string {
 
 // handleProxyDetailKeys handles key events in proxy detail mode
 func (m *Model) handleProxyDetailKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "s":
+		return m.openProxySave()
+	case "i":
+		m.proxyImportHeaders = !m.proxyImportHeaders
+		if m.proxyImportHeaders {
+			m.statusMsg = "Sensitive headers will be saved as captured"
+		} else {
+			m.statusMsg = "Sensitive headers will be replaced with variables"
+		}
+		return nil
+	}
+
 	// Use registry for all navigation and close
 	action, ok := m.keybinds.Match(keybinds.ContextModal, msg.String())
 	if !ok {
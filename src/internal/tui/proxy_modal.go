@@ -98,35 +98,22 @@ func (m Model) renderProxyModal() string {
 	for i := 0; i < len(m.proxyServerState.GetLogs()); i++ {
 		log := m.proxyServerState.GetLogs()[i]
 
-		// Format method with color
-		var methodStyle lipgloss.Style
-		switch log.Method {
-		case "GET":
-			methodStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
-		case "POST":
-			methodStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
-		case "PUT":
-			methodStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
-		case "DELETE":
-			methodStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
-		default:
-			methodStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-		}
+		// Format method and status with the theme colors
+		methodStyle := getMethodStyle(log.Method)
 
-		// Format status with color
 		var statusStyle lipgloss.Style
 		if log.Status >= 200 && log.Status < 300 {
-			statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+			statusStyle = styleSuccess
 		} else if log.Status >= 300 && log.Status < 400 {
-			statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+			statusStyle = styleWarning
 		} else if log.Status >= 400 {
-			statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+			statusStyle = styleError
 		}
 
 		// Highlight selected
 		lineStyle := lipgloss.NewStyle()
 		if i == m.proxyServerState.GetSelectedIndex() {
-			lineStyle = lineStyle.Background(lipgloss.Color("237"))
+			lineStyle = lineStyle.Background(colorSelectedBg)
 		}
 
 		// Format: #ID METHOD URL → STATUS SIZE DURATION
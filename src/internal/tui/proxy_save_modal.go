@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/converter"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/proxy"
+)
+
+// selectedProxyLog returns the captured request shown in the detail view
+func (m *Model) selectedProxyLog() *proxy.ProxyLog {
+	logs := m.proxyServerState.GetLogs()
+	index := m.proxyServerState.GetSelectedIndex()
+	if index < 0 || index >= len(logs) {
+		return nil
+	}
+	return logs[index]
+}
+
+// capturedRequest converts a proxy log into the converter input
+func capturedRequest(log *proxy.ProxyLog) converter.CapturedRequest {
+	return converter.CapturedRequest{
+		Method:  log.Method,
+		URL:     log.URL,
+		Headers: log.ReqHeaders,
+		Body:    log.ReqBody,
+	}
+}
+
+// openProxySave prompts for the filename of the selected captured request
+func (m *Model) openProxySave() tea.Cmd {
+	log := m.selectedProxyLog()
+	if log == nil {
+		return m.setErrorMessage("No request selected")
+	}
+
+	m.proxySaveInput = converter.SuggestCapturedFilename(capturedRequest(log))
+	m.proxySaveCursor = len(m.proxySaveInput)
+	m.errorMsg = ""
+	m.mode = ModeProxySave
+	return nil
+}
+
+// handleProxySaveKeys handles the filename prompt for a captured request
+func (m *Model) handleProxySaveKeys(msg tea.KeyMsg) tea.Cmd {
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.mode = ModeProxyDetail
+			m.errorMsg = ""
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			return m.saveProxyRequest()
+		}
+	}
+
+	// Clear error when user starts typing
+	m.errorMsg = ""
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.proxySaveInput, &m.proxySaveCursor, msg); shouldContinue {
+		return nil
+	}
+	if len(msg.String()) == 1 {
+		m.proxySaveInput = m.proxySaveInput[:m.proxySaveCursor] + msg.String() + m.proxySaveInput[m.proxySaveCursor:]
+		m.proxySaveCursor++
+	}
+	return nil
+}
+
+// saveProxyRequest writes the selected captured request to a .http file in the workdir
+func (m *Model) saveProxyRequest() tea.Cmd {
+	log := m.selectedProxyLog()
+	if log == nil {
+		m.errorMsg = "No request selected"
+		return nil
+	}
+
+	filename := strings.TrimSpace(m.proxySaveInput)
+	if filename == "" {
+		m.errorMsg = "Filename cannot be empty"
+		return nil
+	}
+	if !strings.HasSuffix(filename, ".http") {
+		filename += ".http"
+	}
+
+	workdir, err := config.GetWorkingDirectory(m.sessionMgr.GetActiveProfile().Workdir)
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Failed to resolve workdir: %v", err)
+		return nil
+	}
+	fullPath := filename
+	if !filepath.IsAbs(fullPath) {
+		fullPath = filepath.Join(workdir, filename)
+	}
+
+	if _, err := os.Stat(fullPath); err == nil {
+		m.errorMsg = fmt.Sprintf("File '%s' already exists", filename)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), config.DirPermissions); err != nil {
+		m.errorMsg = fmt.Sprintf("Failed to create directory: %v", err)
+		return nil
+	}
+
+	content := converter.CapturedToHttp(capturedRequest(log), m.proxyImportHeaders)
+	if err := os.WriteFile(fullPath, []byte(content), config.FilePermissions); err != nil {
+		m.errorMsg = fmt.Sprintf("Failed to write file: %v", err)
+		return nil
+	}
+
+	m.mode = ModeProxyDetail
+	m.proxySaveInput = ""
+	return tea.Batch(m.setStatusMessage(fmt.Sprintf("Saved request to %s", filename)), m.refreshFiles())
+}
+
+// renderProxySaveModal renders the filename prompt for a captured request
+func (m *Model) renderProxySaveModal() string {
+	headers := "replaced with {{variables}}"
+	if m.proxyImportHeaders {
+		headers = "saved as captured"
+	}
+
+	inputWithCursor := m.proxySaveInput[:m.proxySaveCursor] + "█" + m.proxySaveInput[m.proxySaveCursor:]
+	content := fmt.Sprintf("Filename: %s\n\nSensitive headers: %s", inputWithCursor, headers)
+
+	if m.errorMsg != "" {
+		content += "\n\n" + styleError.Render(wrapText(m.errorMsg, 64))
+	}
+	content += "\n\n" + wrapText("Relative paths are saved in the profile workdir. Enter to save, ESC to cancel", 64)
+
+	return m.renderModal("Save Captured Request", content, 70, 14)
+}
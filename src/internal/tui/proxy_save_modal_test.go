@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/proxy"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestProxySave_WritesHttpFile(t *testing.T) {
+	m := CreateTestModel(t)
+	dir := t.TempDir()
+
+	originalProfilesFile, originalSessionFile := config.ProfilesFile, config.SessionFile
+	config.ProfilesFile = filepath.Join(dir, ".profiles.json")
+	config.SessionFile = filepath.Join(dir, ".session.json")
+	t.Cleanup(func() { config.ProfilesFile, config.SessionFile = originalProfilesFile, originalSessionFile })
+
+	workdir := filepath.Join(dir, "requests")
+	if err := m.sessionMgr.AddProfile(types.Profile{Name: "Capture", Workdir: workdir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.sessionMgr.SetActiveProfile("Capture"); err != nil {
+		t.Fatal(err)
+	}
+
+	m.proxyServerState.SetLogs([]*proxy.ProxyLog{{
+		ID:     1,
+		Method: "POST",
+		URL:    "https://api.example.com/users",
+		ReqHeaders: http.Header{
+			"Authorization":    {"Bearer secret-token"},
+			"Cookie":           {"session=abc"},
+			"Content-Type":     {"application/json"},
+			"Proxy-Connection": {"keep-alive"},
+		},
+		ReqBody: []byte(`{"name":"a"}`),
+	}})
+	m.proxyServerState.SetSelectedIndex(0)
+	m.mode = ModeProxyDetail
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	AssertModelField(t, "mode", ModeProxySave, m.mode)
+	AssertModelField(t, "proxySaveInput", "post-users.http", m.proxySaveInput)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "mode", ModeProxyDetail, m.mode)
+
+	data, err := os.ReadFile(filepath.Join(workdir, "post-users.http"))
+	if err != nil {
+		t.Fatalf("Expected the request file to be written: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{"POST https://api.example.com/users\n", "Authorization: Bearer {{token}}\n", "Cookie: {{cookie}}\n", "Content-Type: application/json\n", `{"name":"a"}`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q in:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "secret-token") || strings.Contains(content, "Proxy-Connection") {
+		t.Errorf("Expected credentials and proxy headers to be left out:\n%s", content)
+	}
+
+	// Saving again does not overwrite the file
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.errorMsg, "already exists") {
+		t.Errorf("Expected an already exists error, got %q", m.errorMsg)
+	}
+
+	// With import headers on, the captured values are kept
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m.proxySaveInput, m.proxySaveCursor = "captured/with-headers", len("captured/with-headers")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	data, err = os.ReadFile(filepath.Join(workdir, "captured", "with-headers.http"))
+	if err != nil {
+		t.Fatalf("Expected the request file to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "Authorization: Bearer secret-token") {
+		t.Errorf("Expected the captured Authorization header:\n%s", data)
+	}
+}
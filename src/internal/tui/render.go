@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/alecthomas/chroma/v2"
@@ -17,78 +19,136 @@ import (
 	"github.com/studiowebux/restcli/internal/config"
 	"github.com/studiowebux/restcli/internal/executor"
 	"github.com/studiowebux/restcli/internal/parser"
+	"github.com/studiowebux/restcli/internal/session"
 	"github.com/studiowebux/restcli/internal/types"
 )
 
-// Color definitions using terminal's native color palette with adaptive brightness
-// Light mode uses regular colors (0-7), dark mode uses bright colors (8-15) for better contrast
+// Colors of the active theme (see theme.go)
+// The default theme uses the terminal's native palette with adaptive brightness:
+// light mode uses regular colors (0-7), dark mode uses bright colors (8-15) for better contrast
 var (
-	colorGreen  = lipgloss.AdaptiveColor{Light: "2", Dark: "10"} // Green / Bright green
-	colorRed    = lipgloss.AdaptiveColor{Light: "1", Dark: "9"}  // Red / Bright red
-	colorYellow = lipgloss.AdaptiveColor{Light: "3", Dark: "11"} // Yellow / Bright yellow
-	colorBlue   = lipgloss.AdaptiveColor{Light: "4", Dark: "12"} // Blue / Bright blue
-	colorGray   = lipgloss.AdaptiveColor{Light: "8", Dark: "8"}  // Bright black (gray) for both
-	colorCyan   = lipgloss.AdaptiveColor{Light: "6", Dark: "14"} // Cyan / Bright cyan
+	colorGreen           lipgloss.AdaptiveColor // Success
+	colorRed             lipgloss.AdaptiveColor // Error
+	colorYellow          lipgloss.AdaptiveColor // Warning
+	colorBlue            lipgloss.AdaptiveColor // Info
+	colorGray            lipgloss.AdaptiveColor // Muted
+	colorCyan            lipgloss.AdaptiveColor // Accent
+	colorSelectedFg      lipgloss.AdaptiveColor
+	colorSelectedBg      lipgloss.AdaptiveColor
+	colorHighlightFg     lipgloss.AdaptiveColor
+	colorHighlightBg     lipgloss.AdaptiveColor
+	colorContrastOnColor = lipgloss.AdaptiveColor{Light: "15", Dark: "0"} // White / Black text on colored backgrounds
 )
 
-// Style definitions
+// Style definitions, built from the theme colors by buildStyles
 var (
+	styleTitle           lipgloss.Style
+	styleTitleFocused    lipgloss.Style
+	styleTitleUnfocused  lipgloss.Style
+	styleSelected        lipgloss.Style
+	styleSuccess         lipgloss.Style
+	styleError           lipgloss.Style
+	styleWarning         lipgloss.Style
+	styleSearchMatch     lipgloss.Style
+	styleSubtle          lipgloss.Style
+	styleSearchHighlight lipgloss.Style
+
+	// Diff background styles for split view highlighting
+	styleDiffRemoved lipgloss.Style
+	styleDiffAdded   lipgloss.Style
+	styleDiffNeutral lipgloss.Style
+
+	// HTTP method styles
+	styleMethodGET     lipgloss.Style
+	styleMethodPOST    lipgloss.Style
+	styleMethodPUT     lipgloss.Style
+	styleMethodPATCH   lipgloss.Style
+	styleMethodDELETE  lipgloss.Style
+	styleMethodHEAD    lipgloss.Style
+	styleMethodOPTIONS lipgloss.Style
+	styleMethodWS      lipgloss.Style
+
+	// Analytics trend chart styles
+	styleChartLatency lipgloss.Style
+	styleChartVolume  lipgloss.Style
+	styleChartErrors  lipgloss.Style
+)
+
+func init() {
+	applyTheme(builtinThemes[DefaultThemeName])
+}
+
+// applyTheme sets the theme colors and rebuilds the styles from them
+func applyTheme(t Theme) {
+	colorGreen = t.Success
+	colorRed = t.Error
+	colorYellow = t.Warning
+	colorBlue = t.Info
+	colorGray = t.Muted
+	colorCyan = t.Accent
+	colorSelectedFg = t.SelectedForeground
+	colorSelectedBg = t.SelectedBackground
+	colorHighlightFg = t.HighlightForeground
+	colorHighlightBg = t.HighlightBackground
+
 	styleTitle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(colorCyan)
+		Bold(true).
+		Foreground(colorCyan)
 
 	styleTitleFocused = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(colorCyan)
+		Bold(true).
+		Foreground(colorCyan)
 
 	styleTitleUnfocused = lipgloss.NewStyle().
-				Foreground(colorGray)
+		Foreground(colorGray)
 
 	styleSelected = lipgloss.NewStyle().
-			Background(lipgloss.AdaptiveColor{Light: "7", Dark: "8"}). // Bright white / Bright black
-			Foreground(lipgloss.AdaptiveColor{Light: "0", Dark: "15"}) // Black / White
+		Background(colorSelectedBg).
+		Foreground(colorSelectedFg)
 
 	styleSuccess = lipgloss.NewStyle().
-			Foreground(colorGreen)
+		Foreground(colorGreen)
 
 	styleError = lipgloss.NewStyle().
-			Foreground(colorRed)
+		Foreground(colorRed)
 
 	styleWarning = lipgloss.NewStyle().
-			Foreground(colorYellow)
+		Foreground(colorYellow)
 
 	styleSearchMatch = lipgloss.NewStyle().
-				Foreground(colorYellow)
+		Foreground(colorYellow)
 
 	styleSubtle = lipgloss.NewStyle().
-			Foreground(colorGray)
+		Foreground(colorGray)
 
 	styleSearchHighlight = lipgloss.NewStyle().
-				Background(lipgloss.AdaptiveColor{Light: "5", Dark: "13"}). // Magenta / Bright magenta background
-				Foreground(lipgloss.AdaptiveColor{Light: "0", Dark: "0"})   // Black text for both modes
+		Background(colorHighlightBg).
+		Foreground(colorHighlightFg)
 
-	// Diff background styles for split view highlighting
 	styleDiffRemoved = lipgloss.NewStyle().
-				Background(lipgloss.AdaptiveColor{Light: "1", Dark: "9"}). // Red / Bright red
-				Foreground(lipgloss.AdaptiveColor{Light: "15", Dark: "0"}) // White / Black
+		Background(colorRed).
+		Foreground(colorContrastOnColor)
 
 	styleDiffAdded = lipgloss.NewStyle().
-			Background(lipgloss.AdaptiveColor{Light: "2", Dark: "10"}). // Green / Bright green
-			Foreground(lipgloss.AdaptiveColor{Light: "15", Dark: "0"})  // White / Black
+		Background(colorGreen).
+		Foreground(colorContrastOnColor)
 
 	styleDiffNeutral = lipgloss.NewStyle().
-				Foreground(colorGray)
-
-	// HTTP method styles
-	styleMethodGET     = lipgloss.NewStyle().Foreground(colorBlue)
-	styleMethodPOST    = lipgloss.NewStyle().Foreground(colorGreen)
-	styleMethodPUT     = lipgloss.NewStyle().Foreground(colorYellow)
-	styleMethodPATCH   = lipgloss.NewStyle().Foreground(colorYellow)
-	styleMethodDELETE  = lipgloss.NewStyle().Foreground(colorRed)
-	styleMethodHEAD    = lipgloss.NewStyle().Foreground(colorGray)
-	styleMethodOPTIONS = lipgloss.NewStyle().Foreground(colorGray)
-	styleMethodWS      = lipgloss.NewStyle().Foreground(colorCyan)
-)
+		Foreground(colorGray)
+
+	styleMethodGET = lipgloss.NewStyle().Foreground(t.MethodGET)
+	styleMethodPOST = lipgloss.NewStyle().Foreground(t.MethodPOST)
+	styleMethodPUT = lipgloss.NewStyle().Foreground(t.MethodPUT)
+	styleMethodPATCH = lipgloss.NewStyle().Foreground(t.MethodPATCH)
+	styleMethodDELETE = lipgloss.NewStyle().Foreground(t.MethodDELETE)
+	styleMethodHEAD = lipgloss.NewStyle().Foreground(t.MethodHEAD)
+	styleMethodOPTIONS = lipgloss.NewStyle().Foreground(t.MethodOPTIONS)
+	styleMethodWS = lipgloss.NewStyle().Foreground(t.MethodWS)
+
+	styleChartLatency = lipgloss.NewStyle().Foreground(colorCyan)
+	styleChartVolume = lipgloss.NewStyle().Foreground(colorBlue)
+	styleChartErrors = lipgloss.NewStyle().Foreground(colorRed)
+}
 
 // getMethodStyle returns the appropriate style for an HTTP method or protocol
 func getMethodStyle(method string) lipgloss.Style {
@@ -115,11 +175,24 @@ func getMethodStyle(method string) lipgloss.Style {
 }
 
 // highlightJSON applies syntax highlighting to JSON content
-// Uses configured syntax themes from the profile, or sensible defaults
 func highlightJSON(jsonStr string, profile *types.Profile) string {
-	lexer := lexers.Get("json")
+	return highlightContent(jsonStr, "application/json", profile)
+}
+
+// highlightContent applies syntax highlighting using the lexer matching the content type
+// JSON, XML and HTML are highlighted, anything else is returned unchanged
+// Uses configured syntax themes from the profile, or sensible defaults
+func highlightContent(body, contentType string, profile *types.Profile) string {
+	lexerName := detectMarkup(contentType, body)
+	if strings.Contains(strings.ToLower(contentType), "json") {
+		lexerName = "json"
+	}
+	if lexerName == "" {
+		return body
+	}
+	lexer := lexers.Get(lexerName)
 	if lexer == nil {
-		return jsonStr
+		return body
 	}
 	lexer = chroma.Coalesce(lexer)
 
@@ -150,18 +223,18 @@ func highlightJSON(jsonStr string, profile *types.Profile) string {
 	// Use terminal256 formatter for wide terminal support
 	formatter := formatters.Get("terminal256")
 	if formatter == nil {
-		return jsonStr
+		return body
 	}
 
-	iterator, err := lexer.Tokenise(nil, jsonStr)
+	iterator, err := lexer.Tokenise(nil, body)
 	if err != nil {
-		return jsonStr
+		return body
 	}
 
 	var buf bytes.Buffer
 	err = formatter.Format(&buf, style, iterator)
 	if err != nil {
-		return jsonStr
+		return body
 	}
 
 	return buf.String()
@@ -195,12 +268,12 @@ func (m Model) renderMain() string {
 	}
 
 	// Normal mode - show sidebar and response
-	// Calculate dimensions - make sidebar wider (40% of width or min 40 chars)
-	sidebarWidth := max(40, m.width*40/100)
-	if m.width < 100 {
-		sidebarWidth = m.width / 2
-	}
+	sidebarWidth := m.sidebarWidth()
 	responseWidth := m.width - sidebarWidth - ViewportPaddingHorizontal // Account for borders
+	notesWidth := m.notesPaneWidth()
+	if notesWidth > 0 {
+		responseWidth -= notesWidth + MinimalBorderMargin // The pane has its own border
+	}
 
 	// Render components with borders
 	sidebar := m.renderSidebar(sidebarWidth-MinimalBorderMargin, m.height-MainViewHeightOffset) // -5 = -1 (status) -2 (borders) -2 (top visibility)
@@ -233,12 +306,27 @@ func (m Model) renderMain() string {
 		AlignVertical(lipgloss.Top).
 		Render(response)
 
-	// Combine sidebar and response
+	// Combine sidebar and response, with the notes pane on the right when shown
 	mainView := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		sidebarBox,
 		responseBox,
 	)
+	if notesWidth > 0 {
+		notesBorderColor := colorGray
+		if m.mode == ModeNotesEdit {
+			notesBorderColor = colorCyan
+		}
+		notesBox := lipgloss.NewStyle().
+			Border(lipgloss.ThickBorder()).
+			BorderForeground(notesBorderColor).
+			Width(notesWidth).
+			Height(m.height - ModalHeightMargin).
+			Padding(0).
+			AlignVertical(lipgloss.Top).
+			Render(m.renderNotesPane(notesWidth-MinimalBorderMargin, m.height-MainViewHeightOffset))
+		mainView = lipgloss.JoinHorizontal(lipgloss.Top, mainView, notesBox)
+	}
 
 	// Status bar
 	statusBar := m.renderStatusBar()
@@ -251,6 +339,15 @@ func (m Model) renderMain() string {
 	)
 }
 
+// sidebarWidth returns the width of the file sidebar in split view, without its border
+// 40% of the width with at least 40 columns, or half the width on narrow screens.
+func (m Model) sidebarWidth() int {
+	if m.width < 100 {
+		return m.width / 2
+	}
+	return max(40, m.width*40/100)
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -299,6 +396,10 @@ func (m Model) renderSidebar(width, height int) string {
 	if len(tagFilter) > 0 {
 		title = fmt.Sprintf("Files (%s)", strings.Join(tagFilter, ","))
 	}
+	// Breadcrumbs of the tree view, e.g. "Files / users / admin"
+	if dir := m.fileExplorer.GetCurrentDir(); dir != "" && m.fileExplorer.IsTreeView() {
+		title += " / " + strings.Join(strings.Split(dir, string(filepath.Separator)), " / ")
+	}
 	lines = append(lines, titleStyle.Render(title))
 	lines = append(lines, "")
 
@@ -321,6 +422,15 @@ func (m Model) renderSidebar(width, height int) string {
 		// Hex number
 		hexNum := fmt.Sprintf("%x", i)
 
+		if file.IsDir {
+			line := fmt.Sprintf("%s %s", hexNum, styleTitle.Render(filepath.Base(file.Name)+"/"))
+			if i == m.fileExplorer.GetCurrentIndex() {
+				line = styleSelected.Render(fmt.Sprintf("%s %s/", hexNum, filepath.Base(file.Name)))
+			}
+			lines = append(lines, line)
+			continue
+		}
+
 		// HTTP method with color (if available)
 		methodPrefix := ""
 		methodLen := 0
@@ -364,6 +474,9 @@ func (m Model) renderSidebar(width, height int) string {
 			maxNameLen = 10
 		}
 		name := file.Name
+		if m.fileExplorer.IsTreeView() {
+			name = filepath.Base(name) // The directory is in the breadcrumbs
+		}
 		if len(name) > maxNameLen {
 			name = name[:maxNameLen-3] + "..."
 		}
@@ -392,7 +505,8 @@ func (m Model) renderSidebar(width, height int) string {
 
 	// Footer - show position
 	if len(files) > 0 {
-		lines = append(lines, "")
+		// Requests of the current file take the blank line above the position
+		lines = append(lines, m.renderRequestTabBar(width-2))
 		fileIndex := m.fileExplorer.GetCurrentIndex()
 		footer := fmt.Sprintf("[%d/%d]", fileIndex+1, len(files))
 		lines = append(lines, styleSubtle.Render(footer))
@@ -464,11 +578,14 @@ func (m Model) renderResponse(width, height int) string {
 		title = titleWithScroll
 	}
 
+	// Tab bar takes the line below the title, so the viewport height is the same with or without tabs
+	tabBar := m.renderResponseTabBar(width - 2)
+
 	if m.currentResponse == nil {
 		// If loading, show viewport content (which has the loading indicator)
 		if m.loading {
 			var content strings.Builder
-			content.WriteString(title + "\n\n")
+			content.WriteString(title + "\n" + tabBar + "\n")
 			viewportContent := m.responseView.View()
 			// Add viewport content
 			content.WriteString(viewportContent)
@@ -482,7 +599,7 @@ func (m Model) renderResponse(width, height int) string {
 		}
 		// Otherwise show empty state message
 		noResponse := styleSubtle.Render("No response yet\n\nPress Enter to execute request\n\nPress 'b' to toggle body visibility")
-		contentStr := title + "\n\n" + noResponse
+		contentStr := title + "\n" + tabBar + "\n" + noResponse
 
 		// Apply style with height constraint
 		style := lipgloss.NewStyle().
@@ -496,7 +613,7 @@ func (m Model) renderResponse(width, height int) string {
 	if m.showBody {
 		// Return viewport content with title
 		var content strings.Builder
-		content.WriteString(title + "\n\n")
+		content.WriteString(title + "\n" + tabBar + "\n")
 		viewportContent := m.responseView.View()
 		content.WriteString(viewportContent)
 
@@ -511,7 +628,7 @@ func (m Model) renderResponse(width, height int) string {
 	// Otherwise show just status and headers (no scrolling needed)
 	var lines []string
 	lines = append(lines, title)
-	lines = append(lines, "")
+	lines = append(lines, tabBar)
 
 	// Status line
 	statusStyle := styleSuccess
@@ -525,17 +642,38 @@ func (m Model) renderResponse(width, height int) string {
 		statusStyle.Render(fmt.Sprintf("%d", m.currentResponse.Status)),
 		m.currentResponse.StatusText)
 	lines = append(lines, statusLine)
+	if graphQLErrors := formatGraphQLErrors(m.currentResponse.GraphQLErrors); graphQLErrors != "" {
+		lines = append(lines, graphQLErrors)
+	}
 
 	// Timing info
 	timingParts := []string{
 		fmt.Sprintf("Duration: %s", executor.FormatDuration(m.currentResponse.Duration)),
 		fmt.Sprintf("Size: %s", executor.FormatSize(m.currentResponse.ResponseSize)),
 	}
+	if m.currentResponse.CompressedSize > 0 {
+		timingParts = append(timingParts, fmt.Sprintf("Compressed: %s (%s)",
+			executor.FormatSize(m.currentResponse.CompressedSize),
+			m.currentResponse.Headers["Content-Encoding"]))
+	}
+	if m.currentResponse.Protocol != "" {
+		timingParts = append(timingParts, fmt.Sprintf("Protocol: %s", m.currentResponse.Protocol))
+	}
 	if m.currentResponse.Timestamp != "" {
 		timingParts = append(timingParts, fmt.Sprintf("Time: %s", m.currentResponse.Timestamp))
 	}
 	timing := strings.Join(timingParts, " | ")
 	lines = append(lines, styleSubtle.Render(timing))
+	if warning := m.latencyBudgetLine(); warning != "" {
+		lines = append(lines, warning)
+	}
+	if m.currentResponse.Timings != nil {
+		lines = append(lines, styleSubtle.Render(executor.FormatTimings(m.currentResponse.Timings, m.currentResponse.Duration)))
+	}
+	if summary := m.tlsSummaryLine(); summary != "" {
+		lines = append(lines, summary)
+	}
+	lines = append(lines, m.redirectChainLines(width-2)...)
 	lines = append(lines, "")
 
 	// Headers (if enabled)
@@ -584,8 +722,24 @@ func isWarningMessage(msg string) bool {
 func (m Model) renderStatusBar() string {
 	profile := m.sessionMgr.GetActiveProfile()
 
-	// Left side - profile
+	// Left side - profile:environment, and the one the current request declares with @profile
 	left := fmt.Sprintf("Profile: %s", profile.Name)
+	if env := m.sessionMgr.ActiveEnvironment(profile.Name); env != "" {
+		left += ":" + env
+	}
+	if m.currentRequest != nil && m.currentRequest.Profile != "" && m.currentRequest.Profile != profile.Name {
+		if _, warning := m.sessionMgr.ProfileForRequest(m.currentRequest); warning != "" {
+			left += " " + styleWarning.Render("(@profile "+m.currentRequest.Profile+" not found)")
+		} else {
+			left += " " + styleWarning.Render("(request: "+m.currentRequest.Profile+")")
+		}
+	}
+	if m.macro.recording != "" {
+		left += " " + styleWarning.Render("recording @"+m.macro.recording)
+	}
+	if m.watch.watcher != nil {
+		left += " " + styleWarning.Render("watching "+filepath.Base(m.watch.path))
+	}
 
 	// Right side - messages or input
 	right := ""
@@ -673,11 +827,11 @@ func (m *Model) updateViewport() {
 		responseWidth = m.width - MinimalBorderMargin // Just account for borders
 	} else {
 		// In split view, account for sidebar
-		sidebarWidth := max(40, m.width*40/100)
-		if m.width < 100 {
-			sidebarWidth = m.width / 2
-		}
+		sidebarWidth := m.sidebarWidth()
 		responseWidth = m.width - sidebarWidth - ViewportPaddingHorizontal // Account for borders
+		if notesWidth := m.notesPaneWidth(); notesWidth > 0 {
+			responseWidth -= notesWidth + MinimalBorderMargin
+		}
 	}
 
 	// Viewport width = renderResponse width - content padding
@@ -718,6 +872,9 @@ func (m *Model) updateResponseView() {
 			Align(lipgloss.Center).
 			Render(">>> EXECUTING REQUEST <<<")
 		content.WriteString(loadingBar + "\n\n")
+		if m.download != nil {
+			content.WriteString(styleSubtle.Render(m.download.progress()) + "\n\n")
+		}
 	}
 
 	// Handle case where no response exists yet
@@ -740,7 +897,9 @@ func (m *Model) updateResponseView() {
 		m.cachedFilterActive == m.filterActive &&
 		m.cachedSearchActive == m.searchInResponseCtx &&
 		m.cachedShowHeaders == m.showHeaders &&
-		m.cachedShowBody == m.showBody
+		m.cachedShowBody == m.showBody &&
+		m.cachedHexView == m.hexView &&
+		!m.jsonTreeState.IsActive() // Tree selection changes on every key, always re-render
 
 	if cacheValid && !m.loading {
 		// Use cached content
@@ -763,9 +922,11 @@ func (m *Model) updateResponseView() {
 		return
 	}
 
+	treeSelectedLine := -1 // Line of the selected JSON tree node, used to keep it visible
+
 	// Request section with resolved values
 	if m.currentRequest != nil {
-		profile := m.sessionMgr.GetActiveProfile()
+		profile, _ := m.sessionMgr.ProfileForRequest(m.currentRequest)
 		session := m.sessionMgr.GetSession()
 
 		// Create a copy of the request and merge headers
@@ -779,7 +940,7 @@ func (m *Model) updateResponseView() {
 		}
 
 		// Resolve variables for display (include interactive variables if collected)
-		resolver := parser.NewVariableResolver(profile.Variables, session.Variables, m.interactiveVarValues, parser.LoadSystemEnv())
+		resolver := parser.NewVariableResolver(m.sessionMgr.ProfileVariables(profile), session.Variables, m.interactiveVarValues, parser.LoadSystemEnv())
 		resolvedRequest, err := resolver.ResolveRequest(&requestCopy)
 
 		content.WriteString(styleTitle.Render("Request") + "\n")
@@ -844,17 +1005,47 @@ func (m *Model) updateResponseView() {
 	content.WriteString(fmt.Sprintf("%s - %s\n",
 		statusStyle.Render(fmt.Sprintf("%d", m.currentResponse.Status)),
 		m.currentResponse.StatusText))
+	if graphQLErrors := formatGraphQLErrors(m.currentResponse.GraphQLErrors); graphQLErrors != "" {
+		content.WriteString(graphQLErrors + "\n")
+	}
+	// A 3xx here was not followed, its target is what the user wants to see
+	location := m.currentResponse.Headers["Location"]
+	isRedirect := m.currentResponse.Status >= 300 && m.currentResponse.Status < 400 && location != ""
+	if isRedirect {
+		content.WriteString(styleWarning.Render("Location: "+location) + styleSubtle.Render(" (redirect not followed)") + "\n")
+	}
 
 	// Timing info
 	timingParts := []string{
 		fmt.Sprintf("Duration: %s", executor.FormatDuration(m.currentResponse.Duration)),
 		fmt.Sprintf("Size: %s", executor.FormatSize(m.currentResponse.ResponseSize)),
 	}
+	if m.currentResponse.CompressedSize > 0 {
+		timingParts = append(timingParts, fmt.Sprintf("Compressed: %s (%s)",
+			executor.FormatSize(m.currentResponse.CompressedSize),
+			m.currentResponse.Headers["Content-Encoding"]))
+	}
+	if m.currentResponse.Protocol != "" {
+		timingParts = append(timingParts, fmt.Sprintf("Protocol: %s", m.currentResponse.Protocol))
+	}
 	if m.currentResponse.Timestamp != "" {
 		timingParts = append(timingParts, fmt.Sprintf("Time: %s", m.currentResponse.Timestamp))
 	}
 	content.WriteString(styleSubtle.Render(strings.Join(timingParts, " | ")))
 	content.WriteString("\n")
+	if warning := m.latencyBudgetLine(); warning != "" {
+		content.WriteString(warning + "\n")
+	}
+	if m.currentResponse.Timings != nil {
+		content.WriteString(styleSubtle.Render(executor.FormatTimings(m.currentResponse.Timings, m.currentResponse.Duration)))
+		content.WriteString("\n")
+	}
+	if summary := m.tlsSummaryLine(); summary != "" {
+		content.WriteString(summary + "\n")
+	}
+	for _, line := range m.redirectChainLines(m.responseView.Width) {
+		content.WriteString(line + "\n")
+	}
 
 	// Response Headers (toggle with Shift+B, with wrapping)
 	if m.showHeaders && len(m.currentResponse.Headers) > 0 {
@@ -869,7 +1060,9 @@ func (m *Model) updateResponseView() {
 			wrappedLines := wrapText(unwrappedLine, wrapWidth-2) // Reserve 2 chars for indent
 			// Add indent to each line
 			for _, line := range strings.Split(wrappedLines, "\n") {
-				if line != "" {
+				if line != "" && isRedirect && key == "Location" {
+					content.WriteString("  " + styleWarning.Render(line) + "\n")
+				} else if line != "" {
 					content.WriteString("  " + line + "\n")
 				}
 			}
@@ -878,28 +1071,37 @@ func (m *Model) updateResponseView() {
 	content.WriteString("\n")
 
 	// Body
+	// Downloaded bodies stay on disk, only the metadata is shown
+	if m.currentResponse.DownloadPath != "" {
+		content.WriteString(styleTitle.Render("Body") + "\n")
+		content.WriteString(styleSuccess.Render(fmt.Sprintf("Downloaded %s to %s",
+			executor.FormatSize(m.currentResponse.ResponseSize), m.currentResponse.DownloadPath)) + "\n")
+	}
+
 	if m.currentResponse.Body != "" {
 		// Show filter indicator if active
 		if m.filterActive && m.filteredResponse != "" {
 			content.WriteString(styleTitle.Render(fmt.Sprintf("Body (Filtered: %s)", m.filterInput)) + "\n")
+		} else if mediaType := m.decodedMediaType(); mediaType != "" && !m.hexView {
+			content.WriteString(styleTitle.Render(fmt.Sprintf("Body (decoded from %s)", mediaType)) + "\n")
 		} else {
 			content.WriteString(styleTitle.Render("Body") + "\n")
 		}
 
 		// Use filtered response if active, otherwise use original
-		var bodySource string
-		if m.filterActive && m.filteredResponse != "" {
-			bodySource = m.filteredResponse
-		} else {
-			bodySource = m.currentResponse.Body
+		bodySource := m.responseBodySource()
+
+		// A body its decoder could not read is shown as received, with the reason
+		if m.decodeErr != nil && !m.showsHexDump() {
+			content.WriteString(styleWarning.Render(fmt.Sprintf("Could not decode body: %v", m.decodeErr)) + "\n")
 		}
 
 		// Check if content is binary
-		if isBinaryContent(bodySource) {
+		if !m.showsHexDump() && isBinaryContent(bodySource) {
 			// Show binary content indicator instead of garbage
 			content.WriteString(styleSubtle.Render(fmt.Sprintf(
 				"[Binary content - %s - %d bytes]\n\nResponse contains binary data that cannot be displayed as text.\n"+
-					"Content-Type: %s",
+					"Content-Type: %s\n\nPress Ctrl+B for the hex view",
 				executor.FormatSize(len(bodySource)),
 				len(bodySource),
 				m.currentResponse.Headers["Content-Type"],
@@ -909,39 +1111,43 @@ func (m *Model) updateResponseView() {
 			return
 		}
 
-		// Try to pretty-print and highlight JSON
-		var bodyText string
-		var isJSON bool
-		var jsonData interface{}
-		if err := json.Unmarshal([]byte(bodySource), &jsonData); err == nil {
-			if prettyJSON, err := json.MarshalIndent(jsonData, "", "  "); err == nil {
-				bodyText = string(prettyJSON)
-				isJSON = true
-			} else {
-				// Fallback to raw body if formatting fails
-				bodyText = bodySource
+		// The hex dump shows the received bytes, the filter does not apply
+		if m.showsHexDump() {
+			if !m.hexView {
+				content.WriteString(styleSubtle.Render("No protobuf schema, showing the bytes (add # @protobuf <descriptor-set> <message>)") + "\n")
+			}
+			data, truncated := m.hexViewBody()
+			if truncated {
+				content.WriteString(truncationBanner(len(data), len(m.currentResponse.Body)) + "\n")
+			}
+			m.hexDumpStartLine = strings.Count(content.String(), "\n")
+			content.WriteString(renderHexDump(data))
+			if truncated {
+				content.WriteString(truncationBanner(len(data), len(m.currentResponse.Body)) + "\n")
 			}
+		} else if m.jsonTreeState.IsActive() && m.jsonTreeState.Load(bodySource) == nil {
+			// The collapsible tree replaces the pretty-printed body when enabled and the body is JSON
+			treeSelectedLine = m.renderJSONTree(&content, m.responseView.Width)
+		} else if rendered, ok := m.renderResponseTable(bodySource); ok {
+			content.WriteString(rendered)
+			content.WriteString("\n")
 		} else {
-			// Not JSON, show raw body
-			bodyText = bodySource
-		}
-
-		// Wrap text to viewport width to prevent truncation
-		wrapWidth := m.responseView.Width
-		if wrapWidth < 40 {
-			wrapWidth = 40 // Minimum reasonable width
+			// Huge bodies freeze the view while wrapping and highlighting, render only the first bytes
+			displayBody, truncated := truncateForDisplay(bodySource, m.responseDisplayLimit())
+			if truncated {
+				content.WriteString(truncationBanner(len(displayBody), len(bodySource)) + "\n")
+			}
+			content.WriteString(m.formatResponseBody(displayBody))
+			content.WriteString("\n")
+			if truncated {
+				content.WriteString(truncationBanner(len(displayBody), len(bodySource)) + "\n")
+			}
 		}
-		wrappedBody := wrapText(bodyText, wrapWidth)
-
-		// Apply syntax highlighting after wrapping (for JSON only)
-		if isJSON {
-			profile := m.sessionMgr.GetActiveProfile()
-			wrappedBody = highlightJSON(wrappedBody, profile)
+		if m.currentResponse.Truncated {
+			content.WriteString(styleSubtle.Render(fmt.Sprintf("Body is a preview: %s of %s received",
+				executor.FormatSize(len(m.currentResponse.Body)), executor.FormatSize(m.currentResponse.ResponseSize))) + "\n")
 		}
 
-		content.WriteString(wrappedBody)
-		content.WriteString("\n")
-
 		// Show hint to clear filter
 		if m.filterActive {
 			content.WriteString("\n")
@@ -972,6 +1178,7 @@ func (m *Model) updateResponseView() {
 	m.cachedSearchActive = m.searchInResponseCtx
 	m.cachedShowHeaders = m.showHeaders
 	m.cachedShowBody = m.showBody
+	m.cachedHexView = m.hexView
 
 	// Apply search highlighting if we're searching in response
 	if m.searchInResponseCtx && len(m.responseSearchMatches) > 0 {
@@ -985,6 +1192,57 @@ func (m *Model) updateResponseView() {
 	}
 
 	m.responseView.SetContent(contentStr)
+
+	// Scroll just enough to keep the selected tree node visible
+	if treeSelectedLine >= 0 && m.responseView.Height > 0 {
+		if treeSelectedLine < m.responseView.YOffset {
+			m.responseView.SetYOffset(treeSelectedLine)
+		} else if treeSelectedLine >= m.responseView.YOffset+m.responseView.Height {
+			m.responseView.SetYOffset(treeSelectedLine - m.responseView.Height + 1)
+		}
+	}
+}
+
+// formatResponseBody pretty-prints, wraps and highlights a response body for the response panel
+func (m *Model) formatResponseBody(bodySource string) string {
+	// Try to pretty-print and highlight JSON, then XML/HTML
+	var bodyText string
+	var highlightType string
+	var jsonData interface{}
+	if err := json.Unmarshal([]byte(bodySource), &jsonData); err == nil {
+		if prettyJSON, err := json.MarshalIndent(jsonData, "", "  "); err == nil {
+			bodyText = string(prettyJSON)
+			highlightType = "application/json"
+		} else {
+			// Fallback to raw body if formatting fails
+			bodyText = bodySource
+		}
+	} else if language := detectMarkup(m.currentResponse.Headers["Content-Type"], bodySource); language != "" {
+		// Malformed markup is still highlighted, just not re-indented
+		bodyText = bodySource
+		if indented, err := indentMarkup(bodySource, language); err == nil {
+			bodyText = indented
+		}
+		highlightType = "text/" + language
+	} else {
+		// Not JSON or markup, show raw body
+		bodyText = bodySource
+	}
+
+	// Wrap text to viewport width to prevent truncation
+	wrapWidth := m.responseView.Width
+	if wrapWidth < 40 {
+		wrapWidth = 40 // Minimum reasonable width
+	}
+	wrappedBody := wrapText(bodyText, wrapWidth)
+
+	// Apply syntax highlighting after wrapping (for JSON and markup only)
+	if highlightType != "" {
+		profile := m.sessionMgr.GetActiveProfile()
+		wrappedBody = highlightContent(wrappedBody, highlightType, profile)
+	}
+
+	return wrappedBody
 }
 
 // highlightSearchMatches highlights lines that match the current search
@@ -1025,6 +1283,10 @@ NAVIGATION (Keyboard Only)
   Home/End       Jump to first/last
   :              Goto hex line
   Ctrl+P         Recent files (MRU)
+  Ctrl+F         Find file (fuzzy, whole workdir tree)
+  Enter          Open folder (sidebar)
+  -, Backspace   Parent folder (sidebar)
+  Ctrl+L         Toggle folder tree / flat list
 
 SEARCH
   /              Search files or response (context-aware)
@@ -1049,21 +1311,49 @@ FILE OPERATIONS
   F            Create new file
   R            Rename file
   r            Refresh file list
+  Ctrl+S       Save request to its file (with confirmation)
+  u            Edit request inline (method, URL, headers, body)
+  }/{          Next/previous request in the file
   t            Filter by category
   T            Clear category filter
+  Ctrl+E       Run all requests of the filtered files
 
 RESPONSE
   s            Save response to file
   c            Copy full response to clipboard
+  Y            Copy request as cURL command
+  Ctrl+Y       Copy as... (body, pretty JSON, cURL, HTTPie, headers, full JSON)
+  V            Save response (or filtered result) to a session variable
   b            Toggle body visibility
   B            Toggle headers visibility (request + response)
-  E            Edit request body (one-time override)
+  E            Edit request body (one-time override, Ctrl+F formats JSON)
   f            Toggle fullscreen (ESC to exit)
+  Ctrl+G       Toggle notes pane (per-file scratchpad)
+  a            Edit notes of the current file (Ctrl+S save)
   w            Pin response for comparison
   W            Show diff (compare pinned vs current)
   J            Filter response with JMESPath (toggle on/off)
+  z            Toggle collapsible JSON tree (response focused)
+  Z            Toggle table view for JSON arrays
+  U            Show full body (when truncated for display)
+  Ctrl+O       Download response body to a file (not rendered)
+  Ctrl+B       Toggle hex view of the response body
+  ]/[          Next/previous response tab
+  Ctrl+W       Close response tab
   ↑/↓, j/k     Scroll response (when body shown)
 
+JSON TREE (when z pressed)
+  ↑/↓, j/k     Select node
+  Enter/Space  Expand/collapse object or array
+  z            Back to pretty-printed body
+
+TABLE VIEW (when Z pressed, response focused)
+  ←/→          Select column
+  Enter        Sort by column (ascending, descending, off)
+  Space        Hide column
+  a            Show all columns
+  Z            Back to pretty-printed body
+
 INLINE FILTER EDITOR (when J pressed)
   Type         Enter JMESPath expression
   Enter        Apply filter
@@ -1073,10 +1363,14 @@ INLINE FILTER EDITOR (when J pressed)
 
 CONFIGURATION
   v            Variable editor
+  Ctrl+K       Quick variable switcher (fuzzy find, edit one value)
   h            Header editor
   p            Switch profile
+  Ctrl+N       Switch to the next environment of the profile
   n            Create new profile (when no search active)
   C            View current configuration
+  K            View cookie jar (C to clear)
+  Ctrl+T       Decode the JWT of the Authorization header or a variable
   P            Edit .profiles.json
   Ctrl+X       View session config
 
@@ -1093,6 +1387,10 @@ TOOLS
   A            Analytics viewer
   S            Stress test results
 
+MACROS
+  Q<reg>       Record a macro into register a-z or 0-9 (Q again to stop)
+  @<reg>       Replay a macro (@@ replays the last one)
+
 MOCK SERVER (when in modal)
   s            Start/stop server
   c            Clear logs
@@ -1114,6 +1412,7 @@ ANALYTICS (when in viewer)
   Enter        Load request file
   p            Toggle preview pane
   t            Toggle grouping
+  x/X          Export to CSV/JSON
   C            Clear all analytics
   Esc, q       Close viewer
 
@@ -1129,6 +1428,8 @@ STRESS TESTING (when in results)
   Esc, q       Close viewer
 
 VARIABLE EDITOR (multi-value)
+  K            Store value in the OS keychain
+  E            Encrypt value in place (enc:, passphrase)
   m            Manage options for multi-value variable
   s            Set active option
   a            Add option
@@ -1137,6 +1438,21 @@ VARIABLE EDITOR (multi-value)
   l            Set alias for option (e.g., 'u1', 'dev')
   L            Delete aliases from option
 
+DYNAMIC VARIABLES (fresh value on every request)
+  {{$uuid}}                 Random UUID v4
+  {{$timestamp}}            Unix timestamp in seconds
+  {{$isoTimestamp}}         UTC time in ISO 8601 format
+  {{$randomInt(1,100)}}     Random integer, bounds inclusive
+  {{$randomString(16)}}     Random alphanumeric string
+  {{$base64(value)}}        Base64 of value
+  {{$urlencode(var)}}       Query-string encoding (spaces as +)
+  {{$urlencodeComponent(v)}} Percent-encoding like encodeURIComponent
+  {{$jsonstring(var)}}      Escape for use inside a JSON string
+  Arguments: variable name, text with {{var}}, or one nested $call()
+  {{$faker.name}}           Fake person name (also email, phone, address)
+  {{$faker.lorem(20)}}      Lorem ipsum words (--seed for reproducible data)
+  Unknown functions are removed and reported as unresolved
+
 DOCUMENTATION, HISTORY & ANALYTICS
   m            View documentation
   H            View history
@@ -1159,6 +1475,7 @@ HISTORY VIEWER (when in history modal)
 ANALYTICS VIEWER (when in analytics modal)
   p            Toggle preview pane
   t            Toggle grouping (per-file ↔ by path)
+  x            Export entries to CSV (X for JSON)
   C            Clear all analytics (with confirmation)
 
 STRESS TESTING
@@ -1228,7 +1545,7 @@ CLI MODE
   restcli <file>           Execute without profile (prompts for vars)
   restcli <file> -p <name> Execute with profile (no prompts)
   restcli <file> -e k=v    Provide variable (won't be prompted)
-  --env-file <path>        Load environment variables from file`
+  --env-file <path>        Load environment variables (.env, JSON, YAML, TOML)`
 
 	// Apply search filter if active
 	if m.helpSearchQuery != "" {
@@ -1304,6 +1621,16 @@ func (m Model) renderConfigView() string {
 	content.WriteString(styleTitle.Render("ACTIVE PROFILE"))
 	content.WriteString("\n\n")
 	content.WriteString(wrapValue("Name:     ", profile.Name, modalWidth-4))
+	if chain := m.sessionMgr.InheritanceChain(profile.Name); len(chain) > 0 {
+		content.WriteString(wrapValue("Extends:  ", "inherits from: "+strings.Join(chain, " → "), modalWidth-4))
+	}
+	if names := session.EnvironmentNames(profile); len(names) > 0 {
+		env := m.sessionMgr.ActiveEnvironment(profile.Name)
+		if env == "" {
+			env = "(base)"
+		}
+		content.WriteString(wrapValue("Env:      ", env+" of "+strings.Join(names, ", "), modalWidth-4))
+	}
 
 	// Working directory
 	workdir, err := config.GetWorkingDirectory(profile.Workdir)
@@ -1326,6 +1653,13 @@ func (m Model) renderConfigView() string {
 	}
 	content.WriteString(wrapValue("Output:   ", output, modalWidth-4))
 
+	// Effective outbound proxy (requests may still override it with @proxy)
+	content.WriteString(wrapValue("Proxy:    ", executor.ResolveOutboundProxy(nil, profile).Describe(), modalWidth-4))
+	if server := m.proxyServerState.GetServer(); m.proxyServerState.IsRunning() && server != nil {
+		debugProxy := fmt.Sprintf("running on http://localhost:%d (set proxyUrl to capture requests)", server.Port)
+		content.WriteString(wrapValue("Debug:    ", debugProxy, modalWidth-4))
+	}
+
 	// Session info
 	content.WriteString("\n")
 	content.WriteString(styleTitle.Render("SESSION"))
@@ -1366,8 +1700,11 @@ func (m Model) renderConfigView() string {
 	content.WriteString(wrapValue("Config:   ", config.ConfigDir, modalWidth-4))
 	content.WriteString(wrapValue("Session:  ", config.GetSessionFilePath(), modalWidth-4))
 	content.WriteString(wrapValue("Profiles: ", config.GetProfilesFilePath(), modalWidth-4))
+	if keybindsPath, err := keybindsConfigPath(); err == nil {
+		content.WriteString(wrapValue("Keybinds: ", keybindsPath, modalWidth-4))
+	}
 
-	footer := "[ESC/C/q] close"
+	footer := "[K] export keybinds  [ESC/C/q] close"
 
 	return m.renderModalWithFooter("Current Configuration", content.String(), footer, 70, 25)
 }
@@ -1550,7 +1887,7 @@ func (m *Model) updateInspectView() {
 	// Ensure documentation is parsed before accessing categories
 	m.currentRequest.EnsureDocumentationParsed(parser.ParseDocumentationLines)
 
-	profile := m.sessionMgr.GetActiveProfile()
+	profile, profileWarning := m.sessionMgr.ProfileForRequest(m.currentRequest)
 
 	// Create a copy of the request to avoid mutation
 	requestCopy := *m.currentRequest
@@ -1565,12 +1902,20 @@ func (m *Model) updateInspectView() {
 	}
 
 	// Resolve variables for preview
-	resolver := parser.NewVariableResolver(profile.Variables, m.sessionMgr.GetSession().Variables, nil, parser.LoadSystemEnv())
+	resolver := parser.NewVariableResolver(m.sessionMgr.ProfileVariables(profile), m.sessionMgr.GetSession().Variables, nil, parser.LoadSystemEnv())
 	resolvedRequest, err := resolver.ResolveRequest(&requestCopy)
 
 	var content strings.Builder
 	content.WriteString("Request Preview\n\n")
 
+	if m.currentRequest.Profile != "" {
+		if profileWarning != "" {
+			content.WriteString(styleWarning.Render("Profile: "+profileWarning) + "\n\n")
+		} else {
+			content.WriteString("Profile: " + profile.Name + " (@profile)\n\n")
+		}
+	}
+
 	if err != nil {
 		content.WriteString(styleError.Render(fmt.Sprintf("Error resolving variables: %v\n\n", err)))
 	}
@@ -1587,6 +1932,33 @@ func (m *Model) updateInspectView() {
 		wrappedMethod := wrapText(methodLine, wrapWidth)
 		content.WriteString(wrappedMethod + "\n\n")
 
+		// Show unix socket target (the request is sent to localhost over the socket)
+		if socketPath, httpURL, ok := executor.SplitUnixSocketURL(resolvedRequest.URL); ok {
+			content.WriteString("Unix Socket:\n")
+			content.WriteString("  Socket: " + socketPath + "\n")
+			content.WriteString("  Request: " + httpURL + "\n")
+			if _, err := os.Stat(socketPath); err != nil {
+				content.WriteString("  " + styleWarning.Render("Socket not found") + "\n")
+			}
+			content.WriteString("\n")
+		}
+
+		// Show gRPC target (headers are sent as metadata)
+		if resolvedRequest.IsGRPC() {
+			content.WriteString("gRPC:\n")
+			if target, err := executor.ParseGRPCURL(resolvedRequest.URL); err == nil {
+				transport := "TLS"
+				if target.Plaintext {
+					transport = "plaintext"
+				}
+				content.WriteString("  Target: " + target.Address + " (" + transport + ")\n")
+				content.WriteString("  Method: " + target.FullMethod() + "\n")
+			} else {
+				content.WriteString("  " + styleWarning.Render(err.Error()) + "\n")
+			}
+			content.WriteString("\n")
+		}
+
 		if len(resolvedRequest.Headers) > 0 {
 			content.WriteString("Headers:\n")
 			// Get sorted header names for consistent display
@@ -1620,6 +1992,14 @@ func (m *Model) updateInspectView() {
 
 		if resolvedRequest.Body != "" {
 			content.WriteString("Body:\n")
+			if resolvedRequest.RequestCompression != "" {
+				method := strings.ToUpper(resolvedRequest.Method)
+				if method == "GET" || method == "HEAD" {
+					content.WriteString("  " + styleWarning.Render(fmt.Sprintf("Compression: %s ignored for %s requests", resolvedRequest.RequestCompression, method)) + "\n")
+				} else {
+					content.WriteString("  " + styleSubtle.Render(fmt.Sprintf("Compression: %s (Content-Encoding set, sent compressed)", resolvedRequest.RequestCompression)) + "\n")
+				}
+			}
 			// Wrap body lines
 			bodyLines := strings.Split(resolvedRequest.Body, "\n")
 			for _, line := range bodyLines {
@@ -1633,6 +2013,28 @@ func (m *Model) updateInspectView() {
 			content.WriteString("\n")
 		}
 
+		// Show GraphQL operation if present
+		if resolvedRequest.GraphQL != nil {
+			content.WriteString("GraphQL:\n")
+			if resolvedRequest.GraphQL.OperationName != "" {
+				content.WriteString("  Operation: " + resolvedRequest.GraphQL.OperationName + "\n")
+			}
+			for _, line := range strings.Split(resolvedRequest.GraphQL.Query, "\n") {
+				wrappedLine := wrapText(line, wrapWidth-2)
+				for _, wl := range strings.Split(wrappedLine, "\n") {
+					if wl != "" {
+						content.WriteString("  " + wl + "\n")
+					}
+				}
+			}
+			if len(resolvedRequest.GraphQL.Variables) > 0 {
+				content.WriteString("  Variables:\n")
+				variablesJSON, _ := json.MarshalIndent(resolvedRequest.GraphQL.Variables, "    ", "  ")
+				content.WriteString("    " + string(variablesJSON) + "\n")
+			}
+			content.WriteString("\n")
+		}
+
 		// Show filter if present
 		if resolvedRequest.Filter != "" {
 			content.WriteString("Filter:\n")
@@ -1657,6 +2059,44 @@ func (m *Model) updateInspectView() {
 			content.WriteString("\n")
 		}
 
+		// Show HTTP protocol version (requested and last negotiated)
+		httpVersion := resolvedRequest.HTTPVersion
+		if httpVersion == "" {
+			httpVersion = profile.GetHTTPVersion()
+		}
+		content.WriteString("HTTP Version:\n")
+		content.WriteString("  Requested: " + httpVersion + "\n")
+		if m.currentResponse != nil && m.currentResponse.Protocol != "" {
+			content.WriteString("  Last response: " + m.currentResponse.Protocol + "\n")
+		}
+		content.WriteString("\n")
+
+		// Show phase timings of the last response
+		if m.currentResponse != nil && m.currentResponse.Timings != nil {
+			content.WriteString("Timing:\n")
+			content.WriteString("  " + executor.FormatTimings(m.currentResponse.Timings, m.currentResponse.Duration) + "\n\n")
+		}
+
+		// Show the redirects followed by the last response
+		if m.currentResponse != nil && len(m.currentResponse.Redirects) > 0 {
+			content.WriteString("Redirects:\n")
+			for _, hop := range m.currentResponse.Redirects {
+				for _, line := range strings.Split(wrapText(fmt.Sprintf("%d %s -> %s", hop.Status, hop.URL, hop.Location), wrapWidth-2), "\n") {
+					content.WriteString("  " + line + "\n")
+				}
+			}
+			content.WriteString(fmt.Sprintf("  %d (final)\n\n", m.currentResponse.Status))
+		}
+
+		// Show the TLS connection and certificate chain of the last response
+		if m.currentResponse != nil && m.currentResponse.TLS != nil {
+			content.WriteString("TLS Info:\n")
+			for _, line := range tlsInfoLines(m.currentResponse.TLS, wrapWidth-2) {
+				content.WriteString("  " + line + "\n")
+			}
+			content.WriteString("\n")
+		}
+
 		// Show TLS configuration if present
 		if resolvedRequest.TLS != nil {
 			content.WriteString("TLS Configuration:\n")
@@ -1708,15 +2148,24 @@ func (m *Model) updateInspectView() {
 								// Check if this file has extractions
 								if requests, err := parser.Parse(filePath); err == nil && len(requests) > 0 {
 									req := &requests[0]
+									step := fmt.Sprintf("    %d. %s", i+1, baseName)
 									if len(req.Extract) > 0 {
 										extractVars := make([]string, 0, len(req.Extract))
 										for varName := range req.Extract {
 											extractVars = append(extractVars, varName)
 										}
-										content.WriteString(fmt.Sprintf("    %d. %s → extracts: %s\n", i+1, baseName, strings.Join(extractVars, ", ")))
-									} else {
-										content.WriteString(fmt.Sprintf("    %d. %s\n", i+1, baseName))
+										step += " → extracts: " + strings.Join(extractVars, ", ")
+									}
+									if req.Condition != "" {
+										step += " (if " + req.Condition + ")"
+									}
+									if req.ForEach != "" {
+										step += " (for each " + req.ForEach + ")"
 									}
+									if req.Profile != "" {
+										step += " [profile " + req.Profile + "]"
+									}
+									content.WriteString(step + "\n")
 								} else {
 									content.WriteString(fmt.Sprintf("    %d. %s\n", i+1, baseName))
 								}
@@ -1747,7 +2196,8 @@ func (m *Model) updateInspectView() {
 			resolvedRequest.ExpectedBodyExact != "" ||
 			resolvedRequest.ExpectedBodyContains != "" ||
 			resolvedRequest.ExpectedBodyPattern != "" ||
-			len(resolvedRequest.ExpectedBodyFields) > 0
+			len(resolvedRequest.ExpectedBodyFields) > 0 ||
+			resolvedRequest.MaxDurationMs > 0 || profile.MaxDurationMs > 0
 
 		if hasValidation {
 			content.WriteString("Validation (Stress Testing):\n")
@@ -1815,6 +2265,13 @@ func (m *Model) updateInspectView() {
 				}
 			}
 
+			// Latency budget (request overrides the profile default)
+			if resolvedRequest.MaxDurationMs > 0 {
+				content.WriteString(fmt.Sprintf("  Max Duration: %dms\n", resolvedRequest.MaxDurationMs))
+			} else if profile.MaxDurationMs > 0 {
+				content.WriteString(fmt.Sprintf("  Max Duration: %dms (profile)\n", profile.MaxDurationMs))
+			}
+
 			content.WriteString("\n")
 		}
 	}
@@ -1876,6 +2333,19 @@ func (m *Model) updateHistoryView() {
 				entry.URL,
 				statusStyle.Render(fmt.Sprintf("%d", entry.ResponseStatus)))
 
+			if len(entry.Tags) > 0 {
+				line += " " + styleWarning.Render(formatHistoryTags(entry.Tags))
+			}
+
+			// Show which field the search matched (e.g. a body, which is not in the line)
+			if field := m.historyState.GetMatchField(i); field != "" {
+				line += styleSubtle.Render(" · " + field)
+			}
+
+			if i == m.historyState.GetDiffMark() {
+				line = styleWarning.Render("[diff] ") + line
+			}
+
 			// Highlight selected entry
 			if i == m.historyState.GetIndex() {
 				line = styleSelected.Render(line)
@@ -1895,7 +2365,17 @@ func (m *Model) updateHistoryView() {
 			previewContent.WriteString(fmt.Sprintf("%s %s\n", entry.Method, entry.URL))
 			previewContent.WriteString(fmt.Sprintf("Status: %d %s\n", entry.ResponseStatus, entry.ResponseStatusText))
 			previewContent.WriteString(fmt.Sprintf("Size: %d bytes\n", entry.ResponseSize))
-			previewContent.WriteString(fmt.Sprintf("Time: %s\n\n", entry.Timestamp[:19]))
+			previewContent.WriteString(fmt.Sprintf("Time: %s\n", entry.Timestamp[:19]))
+			if len(entry.Redirects) > 0 {
+				previewContent.WriteString(fmt.Sprintf("Redirects: %s\n", executor.FormatRedirectChain(entry.Redirects, entry.ResponseStatus, entry.Duration)))
+			}
+			if len(entry.Tags) > 0 {
+				previewContent.WriteString(fmt.Sprintf("Tags: %s\n", formatHistoryTags(entry.Tags)))
+			}
+			if entry.Note != "" {
+				previewContent.WriteString(fmt.Sprintf("Note: %s\n", entry.Note))
+			}
+			previewContent.WriteString("\n")
 
 			// Determine viewport width for wrapping
 			wrapWidth := m.historyState.GetPreviewView().Width
@@ -1989,6 +2469,114 @@ func (m *Model) updateHistoryView() {
 	}
 }
 
+// formatGraphQLErrors renders GraphQL-level errors separately from the HTTP status
+// Returns an empty string when there are none
+func formatGraphQLErrors(errors []string) string {
+	if len(errors) == 0 {
+		return ""
+	}
+	lines := []string{styleError.Render(fmt.Sprintf("GraphQL errors (%d):", len(errors)))}
+	for _, message := range errors {
+		lines = append(lines, styleError.Render("  - "+message))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatNDJSONLine pretty-prints one NDJSON line as it arrives from a stream
+// Lines that are not valid JSON are returned unchanged
+func formatNDJSONLine(line []byte) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, bytes.TrimSpace(line), "", "  "); err != nil {
+		return string(line)
+	}
+	indented.WriteByte('\n')
+	return indented.String()
+}
+
+// latencyBudgetLine renders a warning when the current response took longer than its maxDurationMs budget
+func (m Model) latencyBudgetLine() string {
+	if !m.currentResponse.OverBudget() {
+		return ""
+	}
+	return styleWarning.Render(fmt.Sprintf("⚠ Slow: %s, over the %s budget (maxDurationMs)",
+		executor.FormatDuration(m.currentResponse.Duration), executor.FormatDuration(m.currentResponse.MaxDurationMs)))
+}
+
+// tlsSummaryLine renders the negotiated TLS version and leaf certificate expiry of the current response
+// The line is highlighted when a certificate of the chain expires soon.
+func (m Model) tlsSummaryLine() string {
+	info := m.currentResponse.TLS
+	if info == nil {
+		return ""
+	}
+	parts := []string{"TLS: " + info.Version, info.CipherSuite}
+	warn := false
+	for i, cert := range info.Certificates {
+		text, soon := executor.CertificateExpiry(cert, time.Now())
+		if i == 0 {
+			parts = append(parts, "certificate "+text)
+		} else if soon {
+			parts = append(parts, "chain certificate "+text)
+		}
+		warn = warn || soon
+	}
+	line := strings.Join(parts, " | ")
+	if warn {
+		return styleWarning.Render(line)
+	}
+	return styleSubtle.Render(line)
+}
+
+// tlsInfoLines renders the TLS connection and each certificate of the chain, leaf first
+func tlsInfoLines(info *types.TLSInfo, width int) []string {
+	lines := []string{"Version: " + info.Version, "Cipher: " + info.CipherSuite}
+	if info.ALPN != "" {
+		lines = append(lines, "ALPN: "+info.ALPN)
+	}
+	if info.ServerName != "" {
+		lines = append(lines, "Server name: "+info.ServerName)
+	}
+	for i, cert := range info.Certificates {
+		lines = append(lines, fmt.Sprintf("Certificate %d:", i))
+		fields := []string{"Subject: " + cert.Subject, "Issuer: " + cert.Issuer}
+		if sans := append(append([]string{}, cert.DNSNames...), cert.IPAddresses...); len(sans) > 0 {
+			fields = append(fields, "SANs: "+strings.Join(sans, ", "))
+		}
+		fields = append(fields, "Valid from: "+cert.NotBefore.UTC().Format("2006-01-02 15:04 MST"))
+		for _, field := range fields {
+			for _, line := range strings.Split(wrapText(field, width-2), "\n") {
+				lines = append(lines, "  "+line)
+			}
+		}
+		if text, soon := executor.CertificateExpiry(cert, time.Now()); soon {
+			lines = append(lines, "  "+styleWarning.Render("Validity: "+text))
+		} else {
+			lines = append(lines, "  Validity: "+text)
+		}
+		lines = append(lines, styleSubtle.Render("  SHA-256: "+cert.SHA256), styleSubtle.Render("  Pin: sha256//"+cert.SPKISHA256))
+	}
+	return lines
+}
+
+// redirectChainLines renders the redirects followed by the current response, one hop per line
+func (m Model) redirectChainLines(width int) []string {
+	hops := m.currentResponse.Redirects
+	if len(hops) == 0 {
+		return nil
+	}
+	if width < 40 {
+		width = 40
+	}
+	chain := executor.FormatRedirectChain(hops, m.currentResponse.Status, m.currentResponse.Duration)
+	lines := []string{styleWarning.Render(fmt.Sprintf("Redirect chain (%d hops): %s", len(hops), chain))}
+	for _, hop := range hops {
+		for _, line := range strings.Split(wrapText(fmt.Sprintf("%d %s -> %s", hop.Status, hop.URL, hop.Location), width-2), "\n") {
+			lines = append(lines, styleSubtle.Render("  "+line))
+		}
+	}
+	return lines
+}
+
 // wrapText wraps long lines to fit within the specified width
 // Preserves indentation and breaks at word boundaries when possible
 func wrapText(text string, width int) string {
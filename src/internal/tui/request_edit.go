@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Inline Request Edit - Change the current request without leaving the TUI
+//
+// The method and URL are edited in place, the headers and body in their usual
+// editors. The edits go to a copy of the request; Enter writes it back to its
+// file like Ctrl+S does and reloads the file. The external editor (x) stays
+// the way to make bigger changes.
+
+// Fields of the inline request editor edited in place
+const (
+	requestEditMethod = iota
+	requestEditURL
+)
+
+// editDraft returns the request edited in the replay or inline request editor and the mode of that editor
+// The header and body editors work on the draft when there is one, and return to its editor.
+func (m *Model) editDraft() (*types.HttpRequest, Mode) {
+	switch {
+	case m.replayDraft != nil:
+		return m.replayDraft, ModeReplayEdit
+	case m.requestDraft != nil:
+		return m.requestDraft, ModeRequestEdit
+	}
+	return nil, ModeNormal
+}
+
+// startRequestEdit opens the inline editor on a copy of the current request
+func (m *Model) startRequestEdit() tea.Cmd {
+	if _, err := m.savableRequestFile(); err != nil {
+		return m.setErrorMessage(err.Error())
+	}
+
+	draft := *m.currentRequest
+	draft.Headers = make(map[string]string, len(m.currentRequest.Headers))
+	for name, value := range m.currentRequest.Headers {
+		draft.Headers[name] = value
+	}
+	if draft.GraphQL != nil {
+		graphQL := *draft.GraphQL
+		draft.GraphQL = &graphQL
+	}
+
+	m.requestDraft = &draft
+	m.requestEditField = requestEditURL
+	m.requestEditCursor = len(draft.URL)
+	m.headerEditIndex = 0
+	m.errorMsg = ""
+	m.mode = ModeRequestEdit
+	m.statusMsg = "Edit the request, then press Enter to save it to its file"
+	return nil
+}
+
+// requestEditInput returns the field of the draft being edited in place
+func (m *Model) requestEditInput() *string {
+	if m.requestEditField == requestEditMethod {
+		return &m.requestDraft.Method
+	}
+	return &m.requestDraft.URL
+}
+
+// handleRequestEditKeys handles the inline request editor
+func (m *Model) handleRequestEditKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "tab", "shift+tab":
+		// Two fields: both keys switch to the other one
+		m.requestEditField = 1 - m.requestEditField
+		m.requestEditCursor = len(*m.requestEditInput())
+		return nil
+
+	case "ctrl+b":
+		m.bodyOverrideInput = m.requestDraft.Body
+		m.bodyOverrideType = requestContentType(m.requestDraft.Headers)
+		if m.requestDraft.GraphQL != nil {
+			m.bodyOverrideInput = m.requestDraft.GraphQL.Query
+			m.bodyOverrideType = "" // A query is not JSON
+		}
+		m.bodyOverrideCursor = 0
+		m.mode = ModeBodyOverride
+		m.statusMsg = "Editing request body"
+		return nil
+
+	case "ctrl+t":
+		m.headerEditIndex = 0
+		m.mode = ModeHeaderList
+		m.modalView.SetYOffset(0)
+		return nil
+	}
+
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.requestDraft = nil
+			m.errorMsg = ""
+			m.mode = ModeNormal
+			m.statusMsg = "Edit cancelled"
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			return m.saveRequestDraft()
+		}
+	}
+
+	input := m.requestEditInput()
+	if _, shouldContinue := handleTextInputWithCursor(input, &m.requestEditCursor, msg); shouldContinue {
+		return nil
+	}
+
+	if len(msg.String()) == 1 {
+		*input = (*input)[:m.requestEditCursor] + msg.String() + (*input)[m.requestEditCursor:]
+		m.requestEditCursor++
+	}
+	return nil
+}
+
+// saveRequestDraft writes the edited request to its file and reloads the file
+func (m *Model) saveRequestDraft() tea.Cmd {
+	draft := m.requestDraft
+	draft.Method = strings.ToUpper(strings.TrimSpace(draft.Method))
+	draft.URL = strings.TrimSpace(draft.URL)
+	if draft.Method == "" {
+		m.errorMsg = "Method cannot be empty"
+		return nil
+	}
+	if draft.URL == "" {
+		m.errorMsg = "URL cannot be empty"
+		return nil
+	}
+
+	currentFile, err := m.savableRequestFile()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return nil
+	}
+	if err := saveRequestToFile(currentFile.Path, m.currentRequestIndex(), *draft); err != nil {
+		m.errorMsg = fmt.Sprintf("Failed to save request: %v", err)
+		return nil
+	}
+
+	m.requestDraft = nil
+	m.errorMsg = ""
+	m.mode = ModeNormal
+	// Same file: the reloaded request stays selected
+	m.loadRequestsFromCurrentFile()
+	m.updateResponseView()
+	return m.setStatusMessage(fmt.Sprintf("Request saved to %s", currentFile.Name))
+}
+
+// renderRequestEditModal renders the inline request editor
+func (m *Model) renderRequestEditModal() string {
+	draft := m.requestDraft
+	var content strings.Builder
+
+	method, url := draft.Method, draft.URL
+	if m.requestEditField == requestEditMethod {
+		method = addCursorAt(method, m.requestEditCursor)
+	} else {
+		url = addCursorAt(url, m.requestEditCursor)
+	}
+	content.WriteString(fmt.Sprintf("Method:  %s\n", method))
+	content.WriteString(fmt.Sprintf("URL:     %s\n\n", url))
+	writeDraftDetails(&content, draft)
+
+	if m.errorMsg != "" {
+		content.WriteString("\n" + styleError.Render(m.errorMsg))
+	}
+
+	footer := "[Enter] save to file [TAB] method/URL [Ctrl+B] body [Ctrl+T] headers [ESC] cancel"
+	return m.renderModalWithFooter("Edit Request", content.String(), footer, 90, 25)
+}
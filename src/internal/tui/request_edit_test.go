@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestRequestEdit_SavesToFile(t *testing.T) {
+	content := `### List Users
+GET http://localhost/users
+
+### Get User
+GET http://localhost/users/1
+Accept: application/json
+`
+	path := filepath.Join(t.TempDir(), "users.http")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	files := []types.FileInfo{{Name: "users.http", Path: path, HTTPMethod: "GET"}}
+	m.fileExplorer.SetFiles(files, files)
+	m.loadRequestsFromCurrentFile()
+	m.switchRequest(1)
+
+	typeText(m, "u")
+	AssertModelField(t, "mode", m.mode, ModeRequestEdit)
+
+	// Change the path id, then the method
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText(m, "2")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	for range "GET" {
+		m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	typeText(m, "put")
+
+	// Add a header and a body through their editors
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	typeText(m, "a")
+	typeText(m, "Content-Type")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "application/json")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	AssertModelField(t, "mode", m.mode, ModeRequestEdit)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	typeText(m, `{"name":"ada"}`)
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	AssertModelField(t, "mode", m.mode, ModeRequestEdit)
+	AssertModelField(t, "bodyOverride", m.bodyOverride, "")
+
+	// The current request is untouched until the draft is saved
+	AssertModelField(t, "URL before save", m.currentRequest.URL, "http://localhost/users/1")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "mode", m.mode, ModeNormal)
+	if m.requestDraft != nil {
+		t.Error("Expected the draft to be cleared after saving")
+	}
+
+	// The file is re-parsed and the edited request stays selected
+	req := m.currentRequest
+	AssertModelField(t, "request index", m.currentRequestIndex(), 1)
+	if req.Method != "PUT" || req.URL != "http://localhost/users/2" || req.Headers["Content-Type"] != "application/json" || strings.TrimSpace(req.Body) != `{"name":"ada"}` {
+		t.Errorf("Expected the edited request, got %+v", req)
+	}
+	AssertModelField(t, "first request", m.currentRequests[0].URL, "http://localhost/users")
+	if _, ok := m.sessionMgr.GetActiveProfile().Headers["Content-Type"]; ok {
+		t.Error("Expected request headers not to be saved to the profile")
+	}
+}
+
+func TestRequestEdit_Cancel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "health.http")
+	if err := os.WriteFile(path, []byte("### Health\nGET http://localhost/health\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	m := CreateTestModel(t)
+	files := []types.FileInfo{{Name: "health.http", Path: path, HTTPMethod: "GET"}}
+	m.fileExplorer.SetFiles(files, files)
+	m.loadRequestsFromCurrentFile()
+
+	typeText(m, "u")
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	AssertModelField(t, "mode", m.mode, ModeNormal)
+	AssertModelField(t, "URL", m.currentRequest.URL, "http://localhost/health")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertModelField(t, "file", string(data), "### Health\nGET http://localhost/health\n")
+}
+
+func TestRequestEdit_NoRequest(t *testing.T) {
+	m := CreateTestModel(t)
+	m.startRequestEdit()
+	AssertModelField(t, "mode", m.mode, ModeNormal)
+	AssertModelField(t, "errorMsg", m.errorMsg, "No request loaded (select a file first)")
+}
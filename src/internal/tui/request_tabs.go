@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// switchRequest makes the request delta positions away in the current file current, wrapping around
+// The inspect and execute paths work on currentRequest, so this is all it takes to run another request of the file.
+func (m *Model) switchRequest(delta int) {
+	if len(m.currentRequests) < 2 {
+		m.statusMsg = "File has a single request"
+		return
+	}
+	if m.loading {
+		m.statusMsg = "Request in progress"
+		return
+	}
+
+	count := len(m.currentRequests)
+	// A response tab of another file may hold the current request: start from the first one
+	index := max(m.currentRequestIndex(), 0)
+	index = ((index+delta)%count + count) % count
+	m.currentRequest = &m.currentRequests[index]
+	// The override was written for the previous request
+	m.bodyOverride = ""
+	m.statusMsg = fmt.Sprintf("Request %d/%d: %s", index+1, count, requestLabel(m.currentRequest))
+}
+
+// renderRequestTabBar renders one line with a label per request of the current file
+// Returns "" for files with a single request so the sidebar looks unchanged.
+func (m Model) renderRequestTabBar(width int) string {
+	if len(m.currentRequests) < 2 {
+		return ""
+	}
+
+	active := m.currentRequestIndex()
+	parts := make([]string, len(m.currentRequests))
+	for i := range m.currentRequests {
+		label := fmt.Sprintf(" %d %s ", i+1, truncateRunes(requestLabel(&m.currentRequests[i]), 16))
+		if i == active {
+			parts[i] = styleSelected.Render(label)
+		} else {
+			parts[i] = styleSubtle.Render(label)
+		}
+	}
+
+	// Drop tabs from the left until the active one fits on the line
+	start := 0
+	for start < active && lipgloss.Width(strings.Join(parts[start:active+1], " ")) > width {
+		start++
+	}
+	bar := strings.Join(parts[start:], " ")
+	if start > 0 {
+		bar = styleSubtle.Render("<") + bar
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(bar)
+}
+
+// requestLabel names a request after its name, or its method and URL
+func requestLabel(request *types.HttpRequest) string {
+	if request.Name != "" {
+		return request.Name
+	}
+	return request.Method + " " + request.URL
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestSwitchRequest(t *testing.T) {
+	content := `### List Users
+GET http://localhost/users
+
+### Get User
+GET http://localhost/users/1
+
+###
+DELETE http://localhost/users/1
+`
+	path := filepath.Join(t.TempDir(), "users.http")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	m := CreateTestModel(t)
+	files := []types.FileInfo{{Name: "users.http", Path: path, HTTPMethod: "GET"}}
+	m.fileExplorer.SetFiles(files, files)
+	m.loadRequestsFromCurrentFile()
+
+	// The first request is selected by default
+	AssertModelField(t, "len(currentRequests)", len(m.currentRequests), 3)
+	AssertModelField(t, "default request", m.currentRequestIndex(), 0)
+
+	m.bodyOverride = "{}"
+	m.switchRequest(1)
+	AssertModelField(t, "next request", m.currentRequest.Name, "Get User")
+	AssertModelField(t, "bodyOverride", m.bodyOverride, "")
+	AssertModelField(t, "statusMsg", m.statusMsg, "Request 2/3: Get User")
+
+	// Wraps around, unnamed requests are labelled with their method and URL
+	m.switchRequest(-2)
+	AssertModelField(t, "wrapped request", m.currentRequestIndex(), 2)
+	AssertModelField(t, "statusMsg", m.statusMsg, "Request 3/3: DELETE http://localhost/users/1")
+	if bar := m.renderRequestTabBar(80); bar == "" {
+		t.Error("Expected a request tab bar for a file with three requests")
+	}
+
+	// Reloading the same file keeps the selection
+	m.loadRequestsFromCurrentFile()
+	AssertModelField(t, "request after reload", m.currentRequestIndex(), 2)
+}
+
+func TestSwitchRequest_SingleRequest(t *testing.T) {
+	m := CreateTestModel(t)
+	m.currentRequests = []types.HttpRequest{{Method: "GET", URL: "http://localhost"}}
+	m.currentRequest = &m.currentRequests[0]
+
+	m.switchRequest(1)
+	AssertModelField(t, "statusMsg", m.statusMsg, "File has a single request")
+	AssertModelField(t, "tab bar", m.renderRequestTabBar(80), "")
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"errors"
+	"mime"
+
+	"github.com/studiowebux/restcli/internal/decoder"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// decodeResponseBody decodes a MessagePack, CBOR or protobuf body into JSON, once per response
+// The decoded JSON is only displayed; saving and copying keep the received bytes.
+func (m *Model) decodeResponseBody() {
+	if m.decodedResponsePtr == m.currentResponse {
+		return
+	}
+	m.decodedResponsePtr = m.currentResponse
+	m.decodedBody, m.decodeErr = "", nil
+	if m.currentResponse == nil || m.currentResponse.Body == "" {
+		return
+	}
+
+	var schema *types.ProtobufSchema
+	if m.currentRequest != nil {
+		schema = m.currentRequest.Protobuf
+	}
+	decoded, ok, err := decoder.Decode(m.currentResponse.Headers["Content-Type"], []byte(m.currentResponse.Body), schema)
+	if !ok {
+		return
+	}
+	if err != nil {
+		m.decodeErr = err
+		return
+	}
+	m.decodedBody = string(decoded)
+}
+
+// responseBodyText returns the response body as JSON when it was decoded, the received body otherwise
+func (m *Model) responseBodyText() string {
+	m.decodeResponseBody()
+	if m.decodedBody != "" {
+		return m.decodedBody
+	}
+	return m.currentResponse.Body
+}
+
+// decodedMediaType returns the media type the body was decoded from, empty when it was not
+func (m *Model) decodedMediaType() string {
+	m.decodeResponseBody()
+	if m.decodedBody == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(m.currentResponse.Headers["Content-Type"])
+	if err != nil {
+		return m.currentResponse.Headers["Content-Type"]
+	}
+	return mediaType
+}
+
+// showsHexDump reports whether the body is rendered as a hex dump
+// Protobuf bodies without a schema fall back to it, their field names are unknown.
+func (m *Model) showsHexDump() bool {
+	m.decodeResponseBody()
+	return m.hexView || errors.Is(m.decodeErr, decoder.ErrNoSchema)
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestResponseView_DecodesMsgpack(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	m.responseView.Width, m.responseView.Height = 100, 30
+
+	// {"id": 7}
+	body := "\x81\xa2id\x07"
+	m.currentResponse = &types.RequestResult{Status: 200, StatusText: "200 OK", Body: body, ResponseSize: len(body),
+		Headers: map[string]string{"Content-Type": "application/msgpack"}}
+	m.updateResponseView()
+
+	if !strings.Contains(m.responseContent, "decoded from application/msgpack") || !strings.Contains(stripANSI(m.responseContent), `"id": 7`) {
+		t.Errorf("Expected the decoded body, got:\n%s", m.responseContent)
+	}
+	if m.currentResponse.Body != body {
+		t.Error("Expected the received bytes to be kept")
+	}
+
+	// The hex view still shows the received bytes
+	m.toggleHexView()
+	if !strings.Contains(m.responseContent, "81 a2 69 64 07") {
+		t.Errorf("Expected the raw bytes in the hex view, got:\n%s", m.responseContent)
+	}
+}
+
+func TestResponseView_ProtobufWithoutSchemaShowsHex(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	m.responseView.Width, m.responseView.Height = 100, 30
+
+	body := "\x08\x96\x01"
+	m.currentResponse = &types.RequestResult{Status: 200, StatusText: "200 OK", Body: body, ResponseSize: len(body),
+		Headers: map[string]string{"Content-Type": "application/x-protobuf"}}
+	m.updateResponseView()
+
+	if !strings.Contains(m.responseContent, "No protobuf schema") || !strings.Contains(m.responseContent, "08 96 01") {
+		t.Errorf("Expected the hex fallback, got:\n%s", m.responseContent)
+	}
+}
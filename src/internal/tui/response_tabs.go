@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// maxResponseTabs bounds the responses kept open; the oldest tab is dropped first
+const maxResponseTabs = 9
+
+// ResponseTab is a response kept open in the response panel with the request that produced it
+type ResponseTab struct {
+	Request  *types.HttpRequest
+	Response *types.RequestResult
+}
+
+// openResponseTab adds a response as a new tab and makes it the active one
+func (m *Model) openResponseTab(request *types.HttpRequest, response *types.RequestResult) {
+	if request == nil {
+		request = m.currentRequest
+	}
+	m.responseTabs = append(m.responseTabs, &ResponseTab{Request: request, Response: response})
+	if len(m.responseTabs) > maxResponseTabs {
+		m.responseTabs = m.responseTabs[len(m.responseTabs)-maxResponseTabs:]
+	}
+	m.activeTab = len(m.responseTabs) - 1
+	m.currentResponse = response
+	if request != nil {
+		m.currentRequest = request
+	}
+}
+
+// switchResponseTab activates the tab delta positions away, wrapping around
+func (m *Model) switchResponseTab(delta int) {
+	if len(m.responseTabs) == 0 {
+		m.statusMsg = "No response tabs"
+		return
+	}
+	if m.loading {
+		m.statusMsg = "Request in progress"
+		return
+	}
+
+	count := len(m.responseTabs)
+	m.activateResponseTab(((m.activeTab+delta)%count + count) % count)
+	tab := m.responseTabs[m.activeTab]
+	m.statusMsg = fmt.Sprintf("Tab %d/%d: %s", m.activeTab+1, count, responseTabLabel(tab))
+}
+
+// closeResponseTab closes the active tab and activates its left neighbour
+func (m *Model) closeResponseTab() {
+	if len(m.responseTabs) == 0 {
+		m.statusMsg = "No response tabs"
+		return
+	}
+	if m.loading {
+		m.statusMsg = "Request in progress"
+		return
+	}
+
+	closed := m.responseTabs[m.activeTab]
+	m.responseTabs = append(m.responseTabs[:m.activeTab], m.responseTabs[m.activeTab+1:]...)
+	m.statusMsg = "Closed tab: " + responseTabLabel(closed)
+
+	if len(m.responseTabs) == 0 {
+		m.activeTab = 0
+		m.currentResponse = nil
+		m.resetResponseViewState()
+		m.updateResponseView()
+		return
+	}
+	m.activateResponseTab(max(m.activeTab-1, 0))
+}
+
+// activateResponseTab shows the tab at index with its request
+// A filter or search applies to the response it was run on, so both are cleared
+func (m *Model) activateResponseTab(index int) {
+	tab := m.responseTabs[index]
+	m.activeTab = index
+	m.currentResponse = tab.Response
+	if tab.Request != nil {
+		m.currentRequest = tab.Request
+	}
+	m.resetResponseViewState()
+	m.updateResponseView()
+	m.responseView.GotoTop()
+}
+
+// resetResponseViewState clears the filter and search bound to the previously shown response
+func (m *Model) resetResponseViewState() {
+	m.filterActive = false
+	m.filteredResponse = ""
+	m.filterInput = ""
+	m.filterError = ""
+	m.responseSearchMatches = nil
+	m.responseSearchIndex = 0
+	m.searchInResponseCtx = false
+	m.cachedHighlightedBody = ""
+	m.cachedSearchMatchCount = 0
+}
+
+// renderResponseTabBar renders one line with a label per tab, or "" when there are no tabs
+// Returns "" for a single tab so the panel looks unchanged until a second response is kept
+func (m Model) renderResponseTabBar(width int) string {
+	if len(m.responseTabs) < 2 {
+		return ""
+	}
+
+	parts := make([]string, len(m.responseTabs))
+	for i, tab := range m.responseTabs {
+		label := m.responseTabTitle(i)
+
+		switch {
+		case i == m.activeTab:
+			parts[i] = styleSelected.Render(label)
+		case tab.Response != nil && (tab.Response.Error != "" || tab.Response.Status >= 400):
+			parts[i] = styleError.Render(label)
+		default:
+			parts[i] = styleSubtle.Render(label)
+		}
+	}
+
+	bar := strings.Join(parts, " ")
+	// Keep the bar on one line so the viewport height is unchanged
+	return lipgloss.NewStyle().MaxWidth(width).Render(bar)
+}
+
+// responseTabTitle returns the text of the tab at index in the tab bar
+func (m Model) responseTabTitle(index int) string {
+	tab := m.responseTabs[index]
+	pinMarker := ""
+	if m.pinnedResponse != nil && tab.Response == m.pinnedResponse {
+		pinMarker = "*" // Pinned for diff
+	}
+	return fmt.Sprintf(" %d %s%s ", index+1, truncateRunes(responseTabLabel(tab), 20), pinMarker)
+}
+
+// responseTabAt returns the tab under column x of the tab bar, or -1
+func (m Model) responseTabAt(x int) int {
+	if len(m.responseTabs) < 2 {
+		return -1
+	}
+	start := 0
+	for i := range m.responseTabs {
+		end := start + lipgloss.Width(m.responseTabTitle(i))
+		if x >= start && x < end {
+			return i
+		}
+		start = end + 1 // Space between tabs
+	}
+	return -1
+}
+
+// responseTabLabel names a tab after its request, or its status when the request is unknown
+func responseTabLabel(tab *ResponseTab) string {
+	if tab.Request != nil {
+		return requestLabel(tab.Request)
+	}
+	if tab.Response != nil {
+		return fmt.Sprintf("%d", tab.Response.Status)
+	}
+	return "response"
+}
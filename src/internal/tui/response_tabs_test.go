@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestResponseTabs_OpenSwitchClose(t *testing.T) {
+	m := CreateTestModel(t)
+
+	first := &types.HttpRequest{Name: "first", Method: "GET", URL: "http://localhost/1"}
+	second := &types.HttpRequest{Name: "second", Method: "GET", URL: "http://localhost/2"}
+	firstResp := &types.RequestResult{Status: 200, Body: "1"}
+	secondResp := &types.RequestResult{Status: 404, Body: "2"}
+
+	m.openResponseTab(first, firstResp)
+	m.openResponseTab(second, secondResp)
+	AssertModelField(t, "len(responseTabs)", len(m.responseTabs), 2)
+	AssertModelField(t, "activeTab", m.activeTab, 1)
+	AssertModelField(t, "currentResponse", m.currentResponse, secondResp)
+
+	// Switching wraps around and restores the tab's request
+	m.filterActive = true
+	m.filteredResponse = "stale"
+	m.switchResponseTab(1)
+	AssertModelField(t, "activeTab", m.activeTab, 0)
+	AssertModelField(t, "currentResponse", m.currentResponse, firstResp)
+	AssertModelField(t, "currentRequest", m.currentRequest, first)
+	AssertModelField(t, "filterActive", m.filterActive, false)
+
+	m.switchResponseTab(-1)
+	AssertModelField(t, "activeTab", m.activeTab, 1)
+
+	// Any tab can be pinned for diff
+	m.pinnedResponse = m.currentResponse
+	if bar := m.renderResponseTabBar(80); bar == "" {
+		t.Error("Expected a tab bar with two tabs open")
+	}
+
+	m.closeResponseTab()
+	AssertModelField(t, "len(responseTabs)", len(m.responseTabs), 1)
+	AssertModelField(t, "currentResponse", m.currentResponse, firstResp)
+	AssertModelField(t, "tab bar", m.renderResponseTabBar(80), "")
+
+	m.closeResponseTab()
+	AssertModelField(t, "len(responseTabs)", len(m.responseTabs), 0)
+	if m.currentResponse != nil {
+		t.Error("Expected no response after closing the last tab")
+	}
+}
+
+func TestResponseTabs_DropsOldest(t *testing.T) {
+	m := CreateTestModel(t)
+
+	var responses []*types.RequestResult
+	for i := 0; i < maxResponseTabs+2; i++ {
+		resp := &types.RequestResult{Status: 200}
+		responses = append(responses, resp)
+		m.openResponseTab(&types.HttpRequest{Method: "GET", URL: "http://localhost"}, resp)
+	}
+
+	AssertModelField(t, "len(responseTabs)", len(m.responseTabs), maxResponseTabs)
+	AssertModelField(t, "first tab", m.responseTabs[0].Response, responses[2])
+	AssertModelField(t, "activeTab", m.activeTab, maxResponseTabs-1)
+}
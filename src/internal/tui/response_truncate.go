@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/studiowebux/restcli/internal/executor"
+)
+
+// defaultResponseDisplayBytes is the display cap without an active profile (1MB)
+const defaultResponseDisplayBytes = 1024 * 1024
+
+// responseDisplayLimit returns how many body bytes the response view renders (0 = no limit)
+func (m *Model) responseDisplayLimit() int {
+	if m.currentResponse != nil && m.fullBodyResponse == m.currentResponse {
+		return 0
+	}
+	if profile := m.sessionMgr.GetActiveProfile(); profile != nil {
+		return profile.GetMaxResponseDisplayBytes()
+	}
+	return defaultResponseDisplayBytes
+}
+
+// truncateForDisplay cuts body to at most limit bytes without splitting a UTF-8 character
+// Returns the body unchanged when it fits or limit is 0.
+func truncateForDisplay(body string, limit int) (string, bool) {
+	if limit <= 0 || len(body) <= limit {
+		return body, false
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return body[:cut], true
+}
+
+// truncationBanner tells how much of the body is shown and how to get the rest
+func truncationBanner(shown, total int) string {
+	return styleWarning.Render(fmt.Sprintf("[truncated — showing %s of %s — press U to view full, s to save]",
+		executor.FormatSize(shown), executor.FormatSize(total)))
+}
+
+// showFullBody renders the whole body of the current response, ignoring the display cap
+func (m *Model) showFullBody() {
+	if m.currentResponse == nil || m.currentResponse.Body == "" {
+		m.statusMsg = "No response body"
+		return
+	}
+	limit := m.responseDisplayLimit()
+	if limit <= 0 || len(m.responseBodySource()) <= limit {
+		m.statusMsg = "Response body is already shown in full"
+		return
+	}
+
+	m.fullBodyResponse = m.currentResponse
+	m.cachedResponsePtr = nil // Force a re-render without the cap
+	m.updateResponseView()
+	m.statusMsg = fmt.Sprintf("Showing the full body (%s)", executor.FormatSize(len(m.responseBodySource())))
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestTruncateForDisplay(t *testing.T) {
+	if body, truncated := truncateForDisplay("short", 10); body != "short" || truncated {
+		t.Errorf("Expected a short body unchanged, got %q (truncated %v)", body, truncated)
+	}
+	if body, truncated := truncateForDisplay("long body", 0); body != "long body" || truncated {
+		t.Errorf("Expected no limit with 0, got %q (truncated %v)", body, truncated)
+	}
+
+	// "é" is two bytes; cutting at 2 would split it
+	body, truncated := truncateForDisplay("aéb", 2)
+	if body != "a" || !truncated {
+		t.Errorf("Expected the cut before the multi-byte character, got %q (truncated %v)", body, truncated)
+	}
+}
+
+func TestResponseView_TruncatesLargeBody(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+	m.responseView.Width, m.responseView.Height = 100, 30
+	originalProfilesFile := config.ProfilesFile
+	config.ProfilesFile = filepath.Join(t.TempDir(), ".profiles.json")
+	t.Cleanup(func() { config.ProfilesFile = originalProfilesFile })
+	limit := 64
+	m.sessionMgr.AddProfile(types.Profile{Name: "Default", MaxResponseDisplayBytes: &limit})
+
+	body := strings.Repeat("line of text\n", 50) + "LAST LINE"
+	m.currentResponse = &types.RequestResult{Status: 200, StatusText: "200 OK", Body: body, ResponseSize: len(body)}
+	m.updateResponseView()
+
+	if !strings.Contains(m.responseContent, "[truncated") {
+		t.Fatal("Expected a truncation banner")
+	}
+	if strings.Contains(m.responseContent, "LAST LINE") {
+		t.Error("Expected the end of the body to be cut")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+
+	if strings.Contains(m.responseContent, "[truncated") || !strings.Contains(m.responseContent, "LAST LINE") {
+		t.Error("Expected the full body after pressing U")
+	}
+
+	// A new response is capped again
+	m.currentResponse = &types.RequestResult{Status: 200, StatusText: "200 OK", Body: body, ResponseSize: len(body)}
+	m.updateResponseView()
+	if !strings.Contains(m.responseContent, "[truncated") {
+		t.Error("Expected the next response to be truncated again")
+	}
+}
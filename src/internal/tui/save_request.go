@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/parser"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Save Request - Write the current request back to its source file
+//
+// The file is re-parsed before writing so documentation comment lines come from
+// disk (the inspector clears them once parsed) and the other requests of the file
+// are written back as they are. Only .http-syntax files can be saved.
+
+// savableFormats are the file formats written with parser.SerializeHTTPFile
+var savableFormats = map[string]bool{"http": true, "graphql": true, "grpc": true}
+
+// openSaveRequestConfirm asks for confirmation before overwriting the current file
+func (m *Model) openSaveRequestConfirm() tea.Cmd {
+	if _, err := m.savableRequestFile(); err != nil {
+		return m.setErrorMessage(err.Error())
+	}
+
+	m.mode = ModeSaveRequestConfirm
+	return nil
+}
+
+// savableRequestFile returns the selected file when the current request can be written back to it
+func (m *Model) savableRequestFile() (*types.FileInfo, error) {
+	if m.currentRequest == nil {
+		return nil, fmt.Errorf("No request loaded (select a file first)")
+	}
+	currentFile := m.fileExplorer.GetCurrentFile()
+	if currentFile == nil {
+		return nil, fmt.Errorf("No file selected")
+	}
+	if m.currentRequestIndex() < 0 {
+		return nil, fmt.Errorf("Current request does not belong to the selected file")
+	}
+	format, err := parser.DetectFormat(currentFile.Path)
+	if err != nil || !savableFormats[format] {
+		return nil, fmt.Errorf("Only .http, .graphql and .grpc files can be saved")
+	}
+	return currentFile, nil
+}
+
+// saveCurrentRequest writes the current request with its pending body override to its file
+func (m *Model) saveCurrentRequest() tea.Cmd {
+	m.mode = ModeNormal
+
+	currentFile := m.fileExplorer.GetCurrentFile()
+	index := m.currentRequestIndex()
+	if currentFile == nil || index < 0 {
+		return m.setErrorMessage("No request to save")
+	}
+
+	request := *m.currentRequest
+	if m.bodyOverride != "" {
+		if request.GraphQL != nil {
+			graphQL := *request.GraphQL
+			graphQL.Query = m.bodyOverride
+			request.GraphQL = &graphQL
+		} else {
+			request.Body = m.bodyOverride
+		}
+	}
+
+	if err := saveRequestToFile(currentFile.Path, index, request); err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to save request: %v", err))
+	}
+
+	// The override is now part of the file
+	m.bodyOverride = ""
+	m.loadRequestsFromCurrentFile()
+	if index < len(m.currentRequests) {
+		m.currentRequest = &m.currentRequests[index]
+	}
+	m.statusMsg = fmt.Sprintf("Request saved to %s", currentFile.Name)
+	return nil
+}
+
+// currentRequestIndex returns the position of the current request in the loaded file, or -1
+// A response tab can make a request of another file current
+func (m *Model) currentRequestIndex() int {
+	for i := range m.currentRequests {
+		if &m.currentRequests[i] == m.currentRequest {
+			return i
+		}
+	}
+	return -1
+}
+
+// saveRequestToFile replaces the request at index in filePath and rewrites the file
+// Text before the first request separator is kept as is
+func saveRequestToFile(filePath string, index int, request types.HttpRequest) error {
+	format, err := parser.DetectFormat(filePath)
+	if err != nil {
+		return err
+	}
+	if !savableFormats[format] {
+		return fmt.Errorf("unsupported file format: %s", format)
+	}
+
+	requests, err := parser.Parse(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+	if index < 0 || index >= len(requests) {
+		return fmt.Errorf("request %d not found, the file changed on disk (refresh with r)", index+1)
+	}
+
+	request.DocumentationLines = requests[index].DocumentationLines
+	requests[index] = request
+
+	// .graphql and .grpc files imply the protocol of every request
+	if format != "http" {
+		for i := range requests {
+			if requests[i].Protocol == format {
+				requests[i].Protocol = ""
+			}
+		}
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	content := fileHeader(string(data)) + parser.SerializeHTTPFile(requests)
+	if err := os.WriteFile(filePath, []byte(content), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// fileHeader returns the lines before the first request separator, which the parser ignores
+func fileHeader(content string) string {
+	if strings.HasPrefix(content, "###") {
+		return ""
+	}
+	if idx := strings.Index(content, "\n###"); idx >= 0 {
+		return content[:idx+1]
+	}
+	return ""
+}
+
+// handleSaveRequestConfirmKeys handles keys in save request confirmation mode
+func (m *Model) handleSaveRequestConfirmKeys(msg tea.KeyMsg) tea.Cmd {
+	action, ok := m.keybinds.Match(keybinds.ContextConfirm, msg.String())
+	if !ok {
+		return nil
+	}
+
+	switch action {
+	case keybinds.ActionCancel:
+		m.mode = ModeNormal
+		m.statusMsg = "Save cancelled"
+
+	case keybinds.ActionConfirm:
+		return m.saveCurrentRequest()
+	}
+
+	return nil
+}
+
+// renderSaveRequestModal renders the overwrite confirmation
+func (m *Model) renderSaveRequestModal() string {
+	currentFile := m.fileExplorer.GetCurrentFile()
+	if m.currentRequest == nil || currentFile == nil {
+		return m.renderModal("Save Request", "No request selected\n\nPress ESC to close", 50, 10)
+	}
+
+	requestName := m.currentRequest.Name
+	if requestName == "" {
+		requestName = fmt.Sprintf("%s %s", m.currentRequest.Method, m.currentRequest.URL)
+	}
+
+	content := fmt.Sprintf("Overwrite %s with:\n\n  %s\n\nThe file is rewritten in the canonical format.", currentFile.Name, requestName)
+	if m.bodyOverride != "" {
+		content += "\nThe pending body override is saved as the body."
+	}
+	footer := "[y]es [n]o"
+
+	return m.renderModalWithFooter("Save Request", content, footer, 65, 14)
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/parser"
+)
+
+func TestSaveRequestToFile(t *testing.T) {
+	content := `# Shared notes about this file
+
+### List Users
+# @description Lists all users
+GET {{baseUrl}}/users
+
+### Create User
+# Keep this comment
+POST {{baseUrl}}/users
+Content-Type: application/json
+
+{"name": "old"}
+`
+	path := filepath.Join(t.TempDir(), "users.http")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	requests, err := parser.Parse(path)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	// The inspector clears documentation lines once they are parsed
+	request := requests[1]
+	request.DocumentationLines = nil
+	request.Body = `{"name": "new"}`
+	request.Headers["X-Trace"] = "1"
+
+	if err := saveRequestToFile(path, 1, request); err != nil {
+		t.Fatalf("saveRequestToFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	saved := string(data)
+	for _, want := range []string{
+		"# Shared notes about this file\n",
+		"# @description Lists all users\n",
+		"# Keep this comment\n",
+		"X-Trace: 1\n",
+		`{"name": "new"}`,
+	} {
+		if !strings.Contains(saved, want) {
+			t.Errorf("Expected saved file to contain %q:\n%s", want, saved)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	AssertModelField(t, "file mode", info.Mode().Perm(), os.FileMode(0600))
+
+	reparsed, err := parser.Parse(path)
+	if err != nil {
+		t.Fatalf("Parse of saved file failed: %v", err)
+	}
+	AssertModelField(t, "request count", len(reparsed), 2)
+	AssertModelField(t, "saved body", strings.TrimSpace(reparsed[1].Body), `{"name": "new"}`)
+}
+
+func TestSaveRequestToFile_UnsupportedFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.yaml")
+	if err := os.WriteFile(path, []byte("name: List\nmethod: GET\nurl: http://x\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	requests, err := parser.Parse(path)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if err := saveRequestToFile(path, 0, requests[0]); err == nil {
+		t.Error("Expected an error for YAML files")
+	}
+}
+
+func TestFileHeader(t *testing.T) {
+	AssertModelField(t, "no header", fileHeader("### A\nGET /\n"), "")
+	AssertModelField(t, "header", fileHeader("# notes\n\n### A\nGET /\n"), "# notes\n\n")
+	AssertModelField(t, "no requests", fileHeader("# notes\n"), "")
+}
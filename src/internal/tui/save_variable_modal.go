@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+)
+
+// variableValue converts a response value into a session variable value
+// JSON strings are unquoted, objects and arrays are stored as compact JSON, and other text is kept as-is
+func variableValue(body string) (string, error) {
+	trimmed := strings.TrimSpace(body)
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(trimmed), &data); err != nil {
+		// Shell query output and non-JSON bodies are stored verbatim
+		return trimmed, nil
+	}
+
+	switch v := data.(type) {
+	case nil:
+		return "", fmt.Errorf("value is null")
+	case string:
+		return v, nil
+	case map[string]interface{}, []interface{}:
+		var compact strings.Builder
+		encoder := json.NewEncoder(&compact)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(v); err != nil {
+			return "", fmt.Errorf("failed to serialize value: %w", err)
+		}
+		return strings.TrimSuffix(compact.String(), "\n"), nil
+	default:
+		// Numbers and booleans keep their JSON text
+		return trimmed, nil
+	}
+}
+
+// openSaveToVariable prompts for the session variable that receives the response value
+func (m *Model) openSaveToVariable() tea.Cmd {
+	if m.currentResponse == nil || strings.TrimSpace(m.responseBodySource()) == "" {
+		return m.setErrorMessage("No response to save")
+	}
+
+	m.saveVarInput = ""
+	m.saveVarCursor = 0
+	m.errorMsg = ""
+	m.mode = ModeSaveToVariable
+	return nil
+}
+
+// handleSaveToVariableKeys handles the variable name prompt
+func (m *Model) handleSaveToVariableKeys(msg tea.KeyMsg) tea.Cmd {
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.mode = ModeNormal
+			m.errorMsg = ""
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			return m.saveResponseToVariable()
+		}
+	}
+
+	// Clear error when user starts typing
+	m.errorMsg = ""
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.saveVarInput, &m.saveVarCursor, msg); shouldContinue {
+		return nil
+	}
+	if len(msg.String()) == 1 {
+		m.saveVarInput = m.saveVarInput[:m.saveVarCursor] + msg.String() + m.saveVarInput[m.saveVarCursor:]
+		m.saveVarCursor++
+	}
+	return nil
+}
+
+// saveResponseToVariable stores the response value in the named session variable
+func (m *Model) saveResponseToVariable() tea.Cmd {
+	name := strings.TrimSpace(m.saveVarInput)
+	if name == "" {
+		m.errorMsg = "Variable name cannot be empty"
+		return nil
+	}
+	if strings.ContainsAny(name, " \t{}") {
+		m.errorMsg = "Variable name cannot contain spaces or braces"
+		return nil
+	}
+
+	value, err := variableValue(m.responseBodySource())
+	if err != nil {
+		m.errorMsg = fmt.Sprintf("Cannot save response: %v", err)
+		return nil
+	}
+
+	if err := m.sessionMgr.SetSessionVariable(name, value); err != nil {
+		m.errorMsg = fmt.Sprintf("Failed to save session variable: %v", err)
+		return nil
+	}
+
+	m.mode = ModeNormal
+	m.saveVarInput = ""
+	return m.setStatusMessage(fmt.Sprintf("Saved {{%s}} = %s", name, truncateRunes(strings.Join(strings.Fields(value), " "), 40)))
+}
+
+// renderSaveToVariableModal renders the variable name prompt
+func (m *Model) renderSaveToVariableModal() string {
+	source := "response body"
+	if m.filterActive && m.filteredResponse != "" {
+		source = "filtered result"
+	}
+
+	inputWithCursor := m.saveVarInput[:m.saveVarCursor] + "█" + m.saveVarInput[m.saveVarCursor:]
+	content := fmt.Sprintf("Variable: %s\n\nValue: %s", inputWithCursor, source)
+
+	if m.errorMsg != "" {
+		content += "\n\n" + styleError.Render(wrapText(m.errorMsg, 64))
+	}
+	content += "\n\n" + wrapText("The value is stored in the session and available as {{name}} in later requests. Enter to save, ESC to cancel", 64)
+
+	return m.renderModal("Save to Session Variable", content, 70, 14)
+}
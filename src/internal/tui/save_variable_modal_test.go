@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestSaveToVariable_StoresFilteredResult(t *testing.T) {
+	m := CreateTestModel(t)
+	dir := t.TempDir()
+
+	originalSessionFile := config.SessionFile
+	config.SessionFile = filepath.Join(dir, ".session.json")
+	t.Cleanup(func() { config.SessionFile = originalSessionFile })
+
+	m.currentResponse = &types.RequestResult{Status: 200, Body: `{"user": {"id": "u-1", "tags": ["a", "<b>"]}}`}
+	m.filteredResponse = "{\n  \"id\": \"u-1\",\n  \"tags\": [\"a\", \"<b>\"]\n}"
+	m.filterActive = true
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	AssertModelField(t, "mode", ModeSaveToVariable, m.mode)
+
+	// An empty name is rejected
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "errorMsg", "Variable name cannot be empty", m.errorMsg)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "mode", ModeNormal, m.mode)
+
+	value, ok := m.sessionMgr.GetSessionVariable("us")
+	if !ok || value != `{"id":"u-1","tags":["a","<b>"]}` {
+		t.Errorf("Expected the compact JSON of the filtered result, got %q (%v)", value, ok)
+	}
+	if m.statusMsg == "" {
+		t.Error("Expected a confirmation in the status bar")
+	}
+}
+
+func TestVariableValue(t *testing.T) {
+	tests := []struct {
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{`"u-1"`, "u-1", false},
+		{"42", "42", false},
+		{"true", "true", false},
+		{"[\n  1,\n  2\n]", "[1,2]", false},
+		{"plain text\n", "plain text", false},
+		{"null", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := variableValue(tt.body)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("variableValue(%q) = %q, %v; want %q", tt.body, got, err, tt.want)
+		}
+	}
+}
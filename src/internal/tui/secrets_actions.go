@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/keychain"
+	"github.com/studiowebux/restcli/internal/parser"
+	"github.com/studiowebux/restcli/internal/secrets"
+)
+
+// needsPassphrase returns true when the current request uses an encrypted variable and no passphrase is set
+func (m *Model) needsPassphrase() bool {
+	profile, _ := m.sessionMgr.ProfileForRequest(m.currentRequest)
+	if profile == nil || m.currentRequest == nil || secrets.Passphrase() != "" {
+		return false
+	}
+
+	for _, name := range parser.ExtractRequestVariables(m.currentRequest) {
+		if value, ok := profile.Variables[name]; ok && secrets.IsEncrypted(value.GetValue()) {
+			return true
+		}
+	}
+	return false
+}
+
+// openPassphrasePrompt asks for the passphrase of encrypted variables
+// encryptVar is the variable to encrypt once unlocked; when empty the current request is executed
+func (m *Model) openPassphrasePrompt(encryptVar string) tea.Cmd {
+	m.passphraseInput = ""
+	m.passphraseCursor = 0
+	m.passphraseEncryptVar = encryptVar
+	m.errorMsg = ""
+	m.mode = ModeSecretsPassphrase
+	return nil
+}
+
+// handlePassphraseKeys handles the passphrase prompt
+func (m *Model) handlePassphraseKeys(msg tea.KeyMsg) tea.Cmd {
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.passphraseInput = ""
+			m.errorMsg = ""
+			m.mode = m.passphraseReturnMode()
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			return m.submitPassphrase()
+		}
+	}
+
+	// Clear error when user starts typing
+	m.errorMsg = ""
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.passphraseInput, &m.passphraseCursor, msg); shouldContinue {
+		return nil
+	}
+	if len(msg.String()) == 1 {
+		m.passphraseInput = m.passphraseInput[:m.passphraseCursor] + msg.String() + m.passphraseInput[m.passphraseCursor:]
+		m.passphraseCursor++
+	}
+	return nil
+}
+
+// passphraseReturnMode returns the mode the passphrase prompt was opened from
+func (m *Model) passphraseReturnMode() Mode {
+	if m.passphraseEncryptVar != "" {
+		return ModeVariableList
+	}
+	return ModeNormal
+}
+
+// submitPassphrase unlocks the encrypted variables and resumes what needed them
+func (m *Model) submitPassphrase() tea.Cmd {
+	passphrase := m.passphraseInput
+	if passphrase == "" {
+		m.errorMsg = "Passphrase cannot be empty"
+		return nil
+	}
+
+	if err := m.sessionMgr.UnlockSecrets(passphrase); err != nil {
+		m.passphraseInput = ""
+		m.passphraseCursor = 0
+		if errors.Is(err, secrets.ErrWrongPassphrase) {
+			m.errorMsg = "Wrong passphrase"
+		} else {
+			m.errorMsg = fmt.Sprintf("Failed to unlock secrets: %v", err)
+		}
+		return nil
+	}
+
+	m.passphraseInput = ""
+	m.mode = m.passphraseReturnMode()
+	if name := m.passphraseEncryptVar; name != "" {
+		m.passphraseEncryptVar = ""
+		return m.encryptVariable(name)
+	}
+	return m.executeRequest()
+}
+
+// encryptVariable replaces a profile variable's plaintext value with its enc: form
+// Only the encrypted value is saved; the plaintext never reaches the profiles file again
+func (m *Model) encryptVariable(name string) tea.Cmd {
+	profile := m.sessionMgr.GetActiveProfile()
+	value, ok := profile.Variables[name]
+	if !ok {
+		return nil
+	}
+	if value.IsMultiValue() {
+		return m.setErrorMessage("Multi-value variables cannot be encrypted")
+	}
+
+	plaintext := value.GetValue()
+	if secrets.IsEncrypted(plaintext) {
+		return m.setErrorMessage(fmt.Sprintf("Variable '%s' is already encrypted", name))
+	}
+	if strings.Contains(plaintext, "{{"+keychain.ReferencePrefix) {
+		return m.setErrorMessage(fmt.Sprintf("Variable '%s' is stored in the keychain", name))
+	}
+
+	passphrase := secrets.Passphrase()
+	if passphrase == "" {
+		return m.openPassphrasePrompt(name)
+	}
+
+	encrypted, err := secrets.Encrypt(plaintext, passphrase)
+	if err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to encrypt '%s': %v", name, err))
+	}
+
+	value.SetValue(encrypted)
+	profile.Variables[name] = value
+	if err := m.sessionMgr.SaveProfiles(); err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to save profile: %v", err))
+	}
+
+	return m.setStatusMessage(fmt.Sprintf("Variable '%s' encrypted", name))
+}
+
+// renderPassphraseModal renders the passphrase prompt with the input masked
+func (m *Model) renderPassphraseModal() string {
+	masked := strings.Repeat("•", m.passphraseCursor) + "█" + strings.Repeat("•", len(m.passphraseInput)-m.passphraseCursor)
+	content := "Passphrase: " + masked
+
+	if m.errorMsg != "" {
+		content += "\n\n" + styleError.Render(wrapText(m.errorMsg, 64))
+	}
+
+	hint := "The profile has encrypted variables (enc:). The passphrase is kept in memory for this session only."
+	if m.passphraseEncryptVar != "" {
+		hint = fmt.Sprintf("Enter the passphrase to encrypt '%s' with. It is kept in memory for this session only.", m.passphraseEncryptVar)
+	}
+	content += "\n\n" + wrapText(hint+" Enter to unlock, ESC to cancel", 64)
+
+	return m.renderModal("Unlock Secrets", content, 70, 12)
+}
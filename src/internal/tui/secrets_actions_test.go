@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/secrets"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// typeText sends each character of text as a key press
+func typeText(m *Model, text string) {
+	for _, r := range text {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestEncryptVariable_PromptsForPassphrase(t *testing.T) {
+	t.Setenv(secrets.PassphraseEnv, "")
+	secrets.Unlock("")
+	t.Cleanup(func() { secrets.Unlock("") })
+
+	m := CreateTestModel(t)
+	profilesFile := filepath.Join(t.TempDir(), ".profiles.json")
+	originalProfilesFile := config.ProfilesFile
+	config.ProfilesFile = profilesFile
+	t.Cleanup(func() { config.ProfilesFile = originalProfilesFile })
+
+	token := "s3cr3t"
+	profile := types.Profile{Name: "Dev", Variables: map[string]types.VariableValue{"token": {StringValue: &token}}}
+	if err := m.sessionMgr.AddProfile(profile); err != nil {
+		t.Fatalf("Failed to add profile: %v", err)
+	}
+
+	m.mode = ModeVariableList
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	AssertModelField(t, "mode", ModeSecretsPassphrase, m.mode)
+
+	typeText(m, "pass")
+	if strings.Contains(m.renderPassphraseModal(), "pass") {
+		t.Error("Expected the passphrase to be masked")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "mode", ModeVariableList, m.mode)
+
+	value := m.sessionMgr.GetActiveProfile().Variables["token"]
+	if !secrets.IsEncrypted(value.GetValue()) {
+		t.Fatalf("Expected the value to be encrypted, got %q", value.GetValue())
+	}
+	data, err := os.ReadFile(profilesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "s3cr3t") {
+		t.Error("Expected the plaintext to never be written to the profiles file")
+	}
+
+	// A later unlock with the wrong passphrase is rejected
+	secrets.Unlock("")
+	m.openPassphrasePrompt("")
+	typeText(m, "nope")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "mode", ModeSecretsPassphrase, m.mode)
+	AssertModelField(t, "errorMsg", "Wrong passphrase", m.errorMsg)
+	if secrets.Passphrase() != "" {
+		t.Error("Expected a wrong passphrase not to unlock the session")
+	}
+}
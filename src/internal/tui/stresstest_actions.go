@@ -24,53 +24,36 @@ func (m *Model) startStressTest() tea.Cmd {
 		m.stressTestState.GetConfigEdit().ProfileName = profile.Name
 	}
 
-	// Load requests from the configured file
-	requests, err := parser.Parse(m.stressTestState.GetConfigEdit().RequestFile)
+	// Load the CSV data file, if any
+	data, err := m.stressTestState.GetConfigEdit().LoadData()
 	if err != nil {
 		return func() tea.Msg {
-			return errorMsg(fmt.Sprintf("Failed to parse request file: %v", err))
+			return errorMsg(fmt.Sprintf("Failed to load data file: %v", err))
 		}
 	}
 
-	if len(requests) == 0 {
+	// Load and resolve the request from the configured file
+	requestCopy, err := m.prepareStressTestRequest(m.stressTestState.GetConfigEdit().RequestFile, profile, data)
+	if err != nil {
 		return func() tea.Msg {
-			return errorMsg("No requests found in file")
-		}
-	}
-
-	// Always use the first request in the file
-	selectedRequest := &requests[0]
-
-	// Make a copy of the request and resolve variables
-	requestCopy := *selectedRequest
-
-	// Merge profile headers into request
-	if profile != nil && profile.Headers != nil {
-		if requestCopy.Headers == nil {
-			requestCopy.Headers = make(map[string]string)
-		}
-		for key, value := range profile.Headers {
-			if _, exists := requestCopy.Headers[key]; !exists {
-				requestCopy.Headers[key] = value
-			}
+			return errorMsg(fmt.Sprintf("Failed to load request: %v", err))
 		}
 	}
 
-	// Resolve variables in the request
-	if profile != nil {
-		resolver := parser.NewVariableResolver(
-			profile.Variables,
-			m.sessionMgr.GetSession().Variables,
-			nil, // No CLI vars for stress test
-			parser.LoadSystemEnv(),
-		)
-		resolvedRequest, err := resolver.ResolveRequest(&requestCopy)
+	// Load every scenario of a weighted mix the same way
+	var scenarios []*stresstest.ScenarioRequest
+	for _, scenario := range m.stressTestState.GetConfigEdit().Scenarios {
+		req, err := m.prepareStressTestRequest(m.stressTestState.GetConfigEdit().GetScenarioPath(scenario), profile, data)
 		if err != nil {
 			return func() tea.Msg {
-				return errorMsg(fmt.Sprintf("Failed to resolve variables: %v", err))
+				return errorMsg(fmt.Sprintf("Failed to load scenario %s: %v", scenario.RequestFile, err))
 			}
 		}
-		requestCopy = *resolvedRequest
+		scenarios = append(scenarios, &stresstest.ScenarioRequest{
+			Name:    scenario.RequestFile,
+			Weight:  scenario.Weight,
+			Request: req,
+		})
 	}
 
 	// Get TLS config from profile
@@ -98,15 +81,20 @@ func (m *Model) startStressTest() tea.Cmd {
 			if requestCopy.TLS.InsecureSkipVerify {
 				merged.InsecureSkipVerify = requestCopy.TLS.InsecureSkipVerify
 			}
+			if len(requestCopy.TLS.PinnedCertSHA256) > 0 {
+				merged.PinnedCertSHA256 = requestCopy.TLS.PinnedCertSHA256
+			}
 			tlsConfig = &merged
 		}
 	}
 
 	// Create execution config
 	execConfig := &stresstest.ExecutionConfig{
-		Request:   &requestCopy,
+		Request:   requestCopy,
 		TLSConfig: tlsConfig,
 		Config:    m.stressTestState.GetConfigEdit(),
+		Data:      data,
+		Scenarios: scenarios,
 	}
 
 	// Create executor
@@ -119,7 +107,8 @@ func (m *Model) startStressTest() tea.Cmd {
 
 	// Store executor and request info for display
 	m.stressTestState.SetExecutor(executor)
-	m.stressTestState.SetActiveRequest(&requestCopy)
+	m.stressTestState.SetActiveRequest(requestCopy)
+	m.stressTestState.ResetTimeline()
 	m.stressTestState.GetExecutor().Start()
 
 	// Switch to progress mode
@@ -130,6 +119,61 @@ func (m *Model) startStressTest() tea.Cmd {
 	return m.pollStressTestProgress()
 }
 
+// prepareStressTestRequest loads the first request of a file, merges profile headers and resolves variables
+// Data file columns and {{$function}} placeholders are kept and filled per request by the executor
+func (m *Model) prepareStressTestRequest(path string, profile *types.Profile, data *stresstest.DataSet) (*types.HttpRequest, error) {
+	requests, err := parser.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request file: %w", err)
+	}
+
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("no requests found in file")
+	}
+
+	// Always use the first request in the file, as a copy
+	requestCopy := requests[0]
+
+	// Profile signing applies unless the request has its own @sign.* block
+	if profile != nil && requestCopy.Signing == nil {
+		requestCopy.Signing = profile.Signing
+	}
+
+	// Merge profile headers into request
+	if profile != nil && profile.Headers != nil {
+		if requestCopy.Headers == nil {
+			requestCopy.Headers = make(map[string]string)
+		}
+		for key, value := range profile.Headers {
+			if _, exists := requestCopy.Headers[key]; !exists {
+				requestCopy.Headers[key] = value
+			}
+		}
+	}
+
+	// Resolve variables in the request
+	if profile != nil {
+		var columnVars map[string]string
+		if data != nil {
+			columnVars = data.Placeholders()
+		}
+		resolver := parser.NewVariableResolver(
+			m.sessionMgr.ProfileVariables(profile),
+			m.sessionMgr.GetSession().Variables,
+			columnVars, // No CLI vars for stress test, columns take precedence
+			parser.LoadSystemEnv(),
+		)
+		resolver.SetDeferDynamic(true) // Evaluated per request by the executor
+		resolvedRequest, err := resolver.ResolveRequest(&requestCopy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve variables: %w", err)
+		}
+		return resolvedRequest, nil
+	}
+
+	return &requestCopy, nil
+}
+
 // pollStressTestProgress polls the stress test executor for progress updates
 func (m *Model) pollStressTestProgress() tea.Cmd {
 	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
@@ -157,15 +201,16 @@ func (m *Model) loadStressTestFilePicker() {
 	}
 
 	// Get files from workdir
-	files := m.fileExplorer.GetFiles() // Use already loaded files from main view
+	files := m.fileExplorer.GetFilteredFiles() // Use already loaded files from main view
 
 	// Filter by supported extensions
 	supportedExts := map[string]bool{
-		".http":  true,
-		".yaml":  true,
-		".yml":   true,
-		".json":  true,
-		".jsonc": true,
+		".http":    true,
+		".yaml":    true,
+		".yml":     true,
+		".json":    true,
+		".jsonc":   true,
+		".graphql": true,
 	}
 
 	filtered := []types.FileInfo{}
@@ -51,6 +51,12 @@ func (m *Model) renderStressTestConfig() string {
 		{"Total Requests:", fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().TotalRequests), "Total number of requests to send"},
 		{"Ramp-Up Duration (sec):", fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().RampUpDurationSec), "Time to gradually increase load (0=no ramp)"},
 		{"Test Duration (sec):", fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().TestDurationSec), "Max test duration (0=unlimited)"},
+		{"Target RPS:", fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().TargetRPS), "Requests per second (0=max throughput, connections become a cap)"},
+		{"Think Time Min (ms):", fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().ThinkTimeMinMs), "Minimum pause per worker after each request"},
+		{"Think Time Max (ms):", fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().ThinkTimeMaxMs), "Maximum pause per worker after each request (0=no think time)"},
+		{"Data File (CSV):", m.stressTestState.GetConfigEdit().DataFile, "CSV whose header columns fill {{col}} variables (empty=none)"},
+		{"Data Order:", m.stressTestState.GetConfigEdit().DataOrder, "Row order: sequential (default) or random"},
+		{"Scenarios:", stresstest.FormatScenarios(m.stressTestState.GetConfigEdit().Scenarios), "Weighted mix, e.g. get.http:70, post.http:30 (empty=Request File only)"},
 	}
 
 	for i, field := range fields {
@@ -233,6 +239,18 @@ func (m *Model) updateStressTestConfigInput() {
 		m.stressTestState.SetConfigInput(fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().RampUpDurationSec))
 	case 5:
 		m.stressTestState.SetConfigInput(fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().TestDurationSec))
+	case 6:
+		m.stressTestState.SetConfigInput(fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().TargetRPS))
+	case 7:
+		m.stressTestState.SetConfigInput(fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().ThinkTimeMinMs))
+	case 8:
+		m.stressTestState.SetConfigInput(fmt.Sprintf("%d", m.stressTestState.GetConfigEdit().ThinkTimeMaxMs))
+	case 9:
+		m.stressTestState.SetConfigInput(m.stressTestState.GetConfigEdit().DataFile)
+	case 10:
+		m.stressTestState.SetConfigInput(m.stressTestState.GetConfigEdit().DataOrder)
+	case 11:
+		m.stressTestState.SetConfigInput(stresstest.FormatScenarios(m.stressTestState.GetConfigEdit().Scenarios))
 	}
 	m.stressTestState.SetConfigCursor(len(m.stressTestState.GetConfigInput()))
 }
@@ -274,6 +292,38 @@ func (m *Model) applyStressTestConfigInput() error {
 		} else {
 			return fmt.Errorf("test duration must be 0 or greater")
 		}
+	case 6: // Target RPS
+		if val, err := strconv.Atoi(value); err == nil && val >= 0 {
+			m.stressTestState.GetConfigEdit().TargetRPS = val
+		} else {
+			return fmt.Errorf("target RPS must be 0 or greater")
+		}
+	case 7: // Think Time Min
+		if val, err := strconv.Atoi(value); err == nil && val >= 0 {
+			m.stressTestState.GetConfigEdit().ThinkTimeMinMs = val
+		} else {
+			return fmt.Errorf("think time min must be 0 or greater")
+		}
+	case 8: // Think Time Max
+		if val, err := strconv.Atoi(value); err == nil && val >= 0 {
+			m.stressTestState.GetConfigEdit().ThinkTimeMaxMs = val
+		} else {
+			return fmt.Errorf("think time max must be 0 or greater")
+		}
+	case 9: // Data File
+		m.stressTestState.GetConfigEdit().DataFile = strings.TrimSpace(value)
+	case 10: // Data Order
+		order := strings.ToLower(strings.TrimSpace(value))
+		if order != "" && order != stresstest.DataOrderSequential && order != stresstest.DataOrderRandom {
+			return fmt.Errorf("data order must be sequential or random")
+		}
+		m.stressTestState.GetConfigEdit().DataOrder = order
+	case 11: // Scenarios
+		scenarios, err := stresstest.ParseScenarios(value)
+		if err != nil {
+			return err
+		}
+		m.stressTestState.GetConfigEdit().Scenarios = scenarios
 	}
 
 	return nil
@@ -298,6 +348,12 @@ func (m *Model) renderStressTestLoadConfig() string {
 
 		for i, config := range m.stressTestState.GetConfigs() {
 			line := fmt.Sprintf("%s | %d conns | %d reqs", config.Name, config.ConcurrentConns, config.TotalRequests)
+			if config.TargetRPS > 0 {
+				line += fmt.Sprintf(" | %d rps", config.TargetRPS)
+			}
+			if len(config.Scenarios) > 0 {
+				line += fmt.Sprintf(" | %d scenarios", len(config.Scenarios))
+			}
 
 			if i == m.stressTestState.GetConfigIndex() {
 				content.WriteString(styleSelected.Render("> " + line))
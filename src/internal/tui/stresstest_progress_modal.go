@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/studiowebux/restcli/internal/stresstest"
 )
 
 // renderStressTestProgress renders the stress test progress modal
@@ -130,6 +131,12 @@ func (m *Model) renderStressTestProgress() string {
 
 	content.WriteString(fmt.Sprintf("\nRequests/sec: %.2f\n", rps))
 
+	// Live sparklines of the last seconds
+	if timeline := m.stressTestState.GetTimeline(); len(timeline) > 0 {
+		content.WriteString("\n" + styleTitleFocused.Render(fmt.Sprintf("Last %ds", len(timeline))) + "\n")
+		content.WriteString(renderStressTestSparklines(timeline))
+	}
+
 	// Instructions
 	content.WriteString("\n")
 	footer := "ESC/q: Cancel test"
@@ -188,3 +195,51 @@ func formatDuration(d time.Duration) string {
 	seconds := int(d.Seconds()) % 60
 	return fmt.Sprintf("%dm %ds", minutes, seconds)
 }
+
+// sparkBlocks are the levels of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as block characters, scale being the value of the highest block
+func sparkline(values []float64, scale float64) string {
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if scale > 0 {
+			level = int(v / scale * float64(len(sparkBlocks)-1))
+		}
+		if level < 0 {
+			level = 0
+		} else if level >= len(sparkBlocks) {
+			level = len(sparkBlocks) - 1
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// renderStressTestSparklines renders P95, P50 and throughput sparklines with their latest values
+// P50 and P95 share a scale so the gap between them stays visible
+func renderStressTestSparklines(timeline []stresstest.SecondSample) string {
+	p50 := make([]float64, len(timeline))
+	p95 := make([]float64, len(timeline))
+	rps := make([]float64, len(timeline))
+	var maxLatency, maxRPS float64
+	for i, sample := range timeline {
+		p50[i] = float64(sample.P50Ms)
+		p95[i] = float64(sample.P95Ms)
+		rps[i] = float64(sample.Requests)
+		if p95[i] > maxLatency {
+			maxLatency = p95[i]
+		}
+		if rps[i] > maxRPS {
+			maxRPS = rps[i]
+		}
+	}
+
+	last := timeline[len(timeline)-1]
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("P95 %s %dms\n", sparkline(p95, maxLatency), last.P95Ms))
+	b.WriteString(fmt.Sprintf("P50 %s %dms\n", sparkline(p50, maxLatency), last.P50Ms))
+	b.WriteString(fmt.Sprintf("RPS %s %d/s\n", sparkline(rps, maxRPS), last.Requests))
+	return b.String()
+}
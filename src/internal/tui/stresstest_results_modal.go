@@ -60,7 +60,7 @@ func (m *Model) renderStressTestResults() string {
 		RightContent:     m.stressTestState.GetDetailView().View(),
 		RightBorderColor: detailBorderColor,
 		RightIsFocused:   rightIsFocused,
-		Footer:           "n: New | r: Re-run | l: Load Config | TAB: Switch Focus | ↑/↓ j/k: Navigate | g/G: Top/Bottom | d: Delete | ESC/q: Close",
+		Footer:           "n: New | r: Re-run | c: Compare | l: Load Config | TAB: Switch Focus | ↑/↓ j/k: Navigate | g/G: Top/Bottom | d: Delete | ESC/q: Close",
 		LeftWidthRatio:   SplitViewEqual,
 	}
 
@@ -98,6 +98,9 @@ func (m *Model) updateStressTestListView() {
 			if run.AvgDurationMs > 0 {
 				line += fmt.Sprintf(" | %.0fms avg", run.AvgDurationMs)
 			}
+			if run.ID == m.stressTestState.GetCompareRunID() {
+				line += " [baseline]"
+			}
 
 			// Highlight selected
 			if i == m.stressTestState.GetRunIndex() {
@@ -139,6 +142,8 @@ func (m *Model) updateStressTestDetailView() {
 
 	if len(m.stressTestState.GetRuns()) == 0 || m.stressTestState.GetRunIndex() >= len(m.stressTestState.GetRuns()) {
 		detailContent.WriteString("No test run selected")
+	} else if baseID := m.stressTestState.GetCompareRunID(); baseID != 0 && baseID != m.stressTestState.GetRuns()[m.stressTestState.GetRunIndex()].ID {
+		m.renderStressTestComparison(&detailContent, baseID, m.stressTestState.GetRuns()[m.stressTestState.GetRunIndex()].ID)
 	} else {
 		run := m.stressTestState.GetRuns()[m.stressTestState.GetRunIndex()]
 
@@ -172,6 +177,16 @@ func (m *Model) updateStressTestDetailView() {
 			successRate := float64(successCount) / float64(run.TotalRequestsCompleted) * 100
 			detailContent.WriteString(fmt.Sprintf("Success Rate: %.1f%%\n", successRate))
 		}
+		if run.TargetRPS > 0 {
+			detailContent.WriteString(fmt.Sprintf("Target RPS:   %d\n", run.TargetRPS))
+		}
+		if run.AchievedRPS > 0 {
+			detailContent.WriteString(fmt.Sprintf("Achieved RPS: %.1f\n", run.AchievedRPS))
+		}
+		if run.ThinkTimeMaxMs > 0 {
+			detailContent.WriteString(fmt.Sprintf("Think Time:   %d-%dms per worker\n", run.ThinkTimeMinMs, run.ThinkTimeMaxMs))
+			detailContent.WriteString(styleSubtle.Render("  ↳ Workers pause between requests, so throughput is lower than the server allows") + "\n")
+		}
 		detailContent.WriteString("\n")
 
 		// Latency stats
@@ -182,6 +197,20 @@ func (m *Model) updateStressTestDetailView() {
 		detailContent.WriteString(fmt.Sprintf("P50:        %dms\n", run.P50DurationMs))
 		detailContent.WriteString(fmt.Sprintf("P95:        %dms\n", run.P95DurationMs))
 		detailContent.WriteString(fmt.Sprintf("P99:        %dms\n", run.P99DurationMs))
+
+		// Per-scenario breakdown of weighted runs
+		if scenarios, err := m.stressTestState.GetScenarioStats(run.ID); err != nil {
+			detailContent.WriteString("\n" + styleError.Render(fmt.Sprintf("Failed to load scenarios: %v", err)) + "\n")
+		} else if len(scenarios) > 1 {
+			detailContent.WriteString("\n" + styleTitle.Render("Scenarios") + "\n")
+			for _, s := range scenarios {
+				detailContent.WriteString(fmt.Sprintf("%s\n", stresstest.ScenarioName(s.Scenario)))
+				detailContent.WriteString(fmt.Sprintf("  Requests: %d | Errors: %.1f%% | Val Errors: %.1f%%\n",
+					s.CompletedRequests, s.ErrorRate(), s.ValidationErrorRate()))
+				detailContent.WriteString(fmt.Sprintf("  Avg: %.0fms | P50: %dms | P95: %dms | P99: %dms\n",
+					s.AvgDurationMs(), s.P50(), s.P95(), s.P99()))
+			}
+		}
 	}
 
 	detailView := m.stressTestState.GetDetailView()
@@ -189,6 +218,55 @@ func (m *Model) updateStressTestDetailView() {
 	m.stressTestState.SetDetailView(detailView)
 }
 
+// renderStressTestComparison writes the summary metrics of two runs with their deltas
+// Green arrows mark improvements over the baseline, red arrows regressions
+func (m *Model) renderStressTestComparison(content *strings.Builder, baseID, otherID int64) {
+	comparison, err := m.stressTestState.GetManager().CompareRuns(baseID, otherID)
+	if err != nil {
+		content.WriteString(styleError.Render(fmt.Sprintf("Failed to compare runs: %v", err)))
+		return
+	}
+
+	content.WriteString(styleTitle.Render(fmt.Sprintf("Run #%d vs Run #%d", baseID, otherID)) + "\n\n")
+	content.WriteString(styleSubtle.Render("Baseline: ") + fmt.Sprintf("%s (%d reqs)\n", comparison.Base.Run.ConfigName, comparison.Base.Run.TotalRequestsCompleted))
+	content.WriteString(styleSubtle.Render("Compared: ") + fmt.Sprintf("%s (%d reqs)\n\n", comparison.Other.Run.ConfigName, comparison.Other.Run.TotalRequestsCompleted))
+
+	content.WriteString(fmt.Sprintf("%-11s %12s %12s  %s\n", "Metric", fmt.Sprintf("#%d", baseID), fmt.Sprintf("#%d", otherID), "Change"))
+	for _, d := range comparison.Deltas() {
+		change := "  ="
+		if d.Change() != 0 {
+			arrow := "↑"
+			if d.Change() < 0 {
+				arrow = "↓"
+			}
+			change = fmt.Sprintf("%s %+.1f%s", arrow, d.Change(), d.Unit)
+			if d.Base != 0 {
+				change += fmt.Sprintf(" (%+.1f%%)", d.PercentChange())
+			}
+			if d.Improved() {
+				change = styleSuccess.Render(change)
+			} else {
+				change = styleError.Render(change)
+			}
+		}
+		content.WriteString(fmt.Sprintf("%-11s %12s %12s  %s\n", d.Name, formatCompareValue(d.Base, d.Unit), formatCompareValue(d.Other, d.Unit), change))
+	}
+
+	content.WriteString("\n" + styleSubtle.Render("Error rate and RPS are rates, so runs of different sizes compare fairly") + "\n")
+}
+
+// formatCompareValue formats a compared metric with its unit
+func formatCompareValue(value float64, unit string) string {
+	switch unit {
+	case "ms":
+		return fmt.Sprintf("%.0fms", value)
+	case "%":
+		return fmt.Sprintf("%.1f%%", value)
+	default:
+		return fmt.Sprintf("%.1f", value)
+	}
+}
+
 // loadStressTestRuns loads stress test runs from the database
 func (m *Model) loadStressTestRuns() tea.Cmd {
 	return func() tea.Msg {
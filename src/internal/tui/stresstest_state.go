@@ -8,6 +8,9 @@ import (
 	"github.com/studiowebux/restcli/internal/types"
 )
 
+// stressTimelineSize is how many seconds of live samples the progress sparklines show
+const stressTimelineSize = 60
+
 // StressTestState manages stress test UI state with thread safety
 type StressTestState struct {
 	mu sync.RWMutex
@@ -25,6 +28,12 @@ type StressTestState struct {
 	runs        []*stresstest.Run
 	runIndex    int
 
+	// Per-scenario breakdown cache, keyed by run ID (runs are immutable once listed)
+	scenarioStats map[int64][]*stresstest.ScenarioStats
+
+	// Baseline run the selected run is compared against (0 = not comparing)
+	compareRunID int64
+
 	// Viewports for split view
 	listView   viewport.Model
 	detailView viewport.Model
@@ -44,6 +53,11 @@ type StressTestState struct {
 
 	// Execution state
 	stopping bool
+
+	// Ring buffer of the last stressTimelineSize per-second samples of the running test
+	timeline      [stressTimelineSize]stresstest.SecondSample
+	timelineStart int
+	timelineLen   int
 }
 
 // NewStressTestState creates a new stress test state
@@ -168,6 +182,28 @@ func (s *StressTestState) SetRuns(runs []*stresstest.Run) {
 	if s.runIndex >= len(runs) {
 		s.runIndex = 0
 	}
+	s.scenarioStats = nil
+}
+
+// GetScenarioStats returns the per-scenario breakdown of a run, loading it on first use
+func (s *StressTestState) GetScenarioStats(runID int64) ([]*stresstest.ScenarioStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stats, ok := s.scenarioStats[runID]; ok {
+		return stats, nil
+	}
+	if s.manager == nil {
+		return nil, nil
+	}
+	stats, err := s.manager.GetScenarioStats(runID)
+	if err != nil {
+		return nil, err
+	}
+	if s.scenarioStats == nil {
+		s.scenarioStats = make(map[int64][]*stresstest.ScenarioStats)
+	}
+	s.scenarioStats[runID] = stats
+	return stats, nil
 }
 
 // GetRuns returns the list of runs
@@ -459,3 +495,51 @@ func (s *StressTestState) ClearConfigEdit() {
 	s.filePickerFiles = nil
 	s.filePickerIndex = 0
 }
+
+// AppendTimeline adds per-second samples, dropping the oldest once the buffer is full
+func (s *StressTestState) AppendTimeline(samples ...stresstest.SecondSample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sample := range samples {
+		if s.timelineLen < stressTimelineSize {
+			s.timeline[(s.timelineStart+s.timelineLen)%stressTimelineSize] = sample
+			s.timelineLen++
+		} else {
+			s.timeline[s.timelineStart] = sample
+			s.timelineStart = (s.timelineStart + 1) % stressTimelineSize
+		}
+	}
+}
+
+// GetTimeline returns the buffered samples, oldest first
+func (s *StressTestState) GetTimeline() []stresstest.SecondSample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	samples := make([]stresstest.SecondSample, s.timelineLen)
+	for i := range samples {
+		samples[i] = s.timeline[(s.timelineStart+i)%stressTimelineSize]
+	}
+	return samples
+}
+
+// ResetTimeline clears the buffered samples
+func (s *StressTestState) ResetTimeline() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timelineStart = 0
+	s.timelineLen = 0
+}
+
+// SetCompareRunID sets the baseline run for comparison (0 stops comparing)
+func (s *StressTestState) SetCompareRunID(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.compareRunID = id
+}
+
+// GetCompareRunID returns the baseline run for comparison
+func (s *StressTestState) GetCompareRunID() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.compareRunID
+}
@@ -353,6 +353,31 @@ func TestStressTestState_IndexBoundsAfterSetRuns(t *testing.T) {
 	AssertModelField(t, "run index after shrinking", state.GetRunIndex(), 0)
 }
 
+func TestStressTestState_Timeline(t *testing.T) {
+	state := NewStressTestState(nil)
+
+	if len(state.GetTimeline()) != 0 {
+		t.Fatal("Expected empty timeline initially")
+	}
+
+	// Overfill the ring buffer: only the last stressTimelineSize samples remain, oldest first
+	for i := 0; i < stressTimelineSize+5; i++ {
+		state.AppendTimeline(stresstest.SecondSample{Second: i, Requests: i})
+	}
+	timeline := state.GetTimeline()
+	AssertModelField(t, "timeline length", len(timeline), stressTimelineSize)
+	AssertModelField(t, "oldest second", timeline[0].Second, 5)
+	AssertModelField(t, "newest second", timeline[len(timeline)-1].Second, stressTimelineSize+4)
+
+	state.ResetTimeline()
+	AssertModelField(t, "timeline length after reset", len(state.GetTimeline()), 0)
+}
+
+func TestSparkline(t *testing.T) {
+	AssertModelField(t, "sparkline", sparkline([]float64{0, 50, 100}, 100), "▁▄█")
+	AssertModelField(t, "flat sparkline", sparkline([]float64{0, 0}, 0), "▁▁")
+}
+
 func BenchmarkStressTestState_Navigate(b *testing.B) {
 	state := NewStressTestState(nil)
 
@@ -0,0 +1,211 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/studiowebux/restcli/internal/filter"
+)
+
+// Table View - JSON arrays as aligned columns
+//
+// Renders a (filtered) response body that is a JSON array as a table, one row per
+// element. Columns come from filter.ToTable, so the TUI shows the same layout as
+// the CLI csv/tsv output formats. With the response panel focused, left/right
+// select a column, enter cycles its sort and space hides it.
+
+const (
+	// maxTableRows bounds the rows rendered for large arrays
+	maxTableRows = 500
+	// maxTableCellWidth bounds the width of a single cell before it is truncated
+	maxTableCellWidth = 40
+)
+
+// toggleTableView switches the response body between the pretty-printed text and the table view
+func (m *Model) toggleTableView() {
+	if m.currentResponse == nil || m.currentResponse.Body == "" {
+		m.statusMsg = "No response to show as table"
+		return
+	}
+
+	if m.tableViewState.IsActive() {
+		m.tableViewState.SetActive(false)
+		m.statusMsg = "Table view closed"
+	} else {
+		if _, err := filter.ToTable(m.responseBodySource()); err != nil {
+			m.statusMsg = "Response body is not a JSON array"
+			return
+		}
+		m.tableViewState.Reset()
+		m.tableViewState.SetActive(true)
+		m.jsonTreeState.SetActive(false)
+		m.hexView = false
+		m.statusMsg = "Table view: ←/→ column, enter sort, space hide, a show all, Z to close"
+	}
+
+	m.cachedResponsePtr = nil // Table and text views share the response cache
+	m.updateResponseView()
+}
+
+// isTableViewFocused returns true when keys should drive the table instead of the response viewport
+func (m *Model) isTableViewFocused() bool {
+	return m.focusedPanel == "response" && m.showBody &&
+		m.tableViewState.IsActive() && m.currentResponse != nil
+}
+
+// handleTableViewKeys handles column selection, sorting and hiding in the table view
+// Returns true if the key was handled
+func (m *Model) handleTableViewKeys(key string) bool {
+	data, err := filter.ToTable(m.responseBodySource())
+	if err != nil {
+		return false
+	}
+	columns := m.visibleTableColumns(data)
+	selected := m.tableViewState.GetSelected(len(columns))
+
+	switch key {
+	case "left":
+		m.tableViewState.MoveSelection(-1, len(columns))
+	case "right":
+		m.tableViewState.MoveSelection(1, len(columns))
+	case "enter":
+		if len(columns) == 0 {
+			return true
+		}
+		m.tableViewState.CycleSort(data.Columns[columns[selected]])
+	case " ":
+		if len(columns) <= 1 {
+			m.statusMsg = "Cannot hide the last column"
+			return true
+		}
+		m.tableViewState.Hide(data.Columns[columns[selected]])
+	case "a":
+		m.tableViewState.ShowAll()
+	default:
+		return false
+	}
+
+	m.cachedResponsePtr = nil
+	m.updateResponseView()
+	return true
+}
+
+// visibleTableColumns returns the indexes of the columns that are not hidden
+func (m *Model) visibleTableColumns(data *filter.Table) []int {
+	var columns []int
+	for i, column := range data.Columns {
+		if !m.tableViewState.IsHidden(column) {
+			columns = append(columns, i)
+		}
+	}
+	return columns
+}
+
+// sortTableRows sorts rows by a column, numerically when both cells are numbers
+// Blank cells sort last in both directions
+func sortTableRows(rows [][]string, column int, desc bool) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i][column], rows[j][column]
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+		if desc {
+			a, b = b, a
+		}
+		if x, errX := strconv.ParseFloat(a, 64); errX == nil {
+			if y, errY := strconv.ParseFloat(b, 64); errY == nil {
+				return x < y
+			}
+		}
+		return a < b
+	})
+}
+
+// renderResponseTable renders a JSON array body as a table that fits the response panel
+// Returns false when the table view is off
+func (m *Model) renderResponseTable(body string) (string, bool) {
+	if !m.tableViewState.IsActive() {
+		return "", false
+	}
+	data, err := filter.ToTable(body)
+	if err != nil || len(data.Columns) == 0 {
+		// A filter can turn the body into something that is not a list of rows
+		return styleSubtle.Render("Not a JSON array of objects, showing the body (Z to close the table view)") +
+			"\n\n" + m.formatResponseBody(body), true
+	}
+
+	rows := make([][]string, len(data.Rows))
+	copy(rows, data.Rows)
+	sortColumn, sortDesc := m.tableViewState.GetSort()
+	for i, column := range data.Columns {
+		if column == sortColumn {
+			sortTableRows(rows, i, sortDesc)
+		}
+	}
+	if len(rows) > maxTableRows {
+		rows = rows[:maxTableRows]
+	}
+
+	columns := m.visibleTableColumns(data)
+	selected := m.tableViewState.GetSelected(len(columns))
+
+	headers := make([]string, len(columns))
+	for i, index := range columns {
+		headers[i] = data.Columns[index]
+		if headers[i] == sortColumn {
+			if sortDesc {
+				headers[i] += " ▼"
+			} else {
+				headers[i] += " ▲"
+			}
+		}
+	}
+
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]string, len(columns))
+		for j, index := range columns {
+			cells[i][j] = truncateRunes(strings.Join(strings.Fields(row[index]), " "), maxTableCellWidth)
+		}
+	}
+
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+	headerStyle := styleTitle.Padding(0, 1)
+	selectedHeaderStyle := styleSelected.Bold(true).Padding(0, 1)
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(styleSubtle).
+		Headers(headers...).
+		Rows(cells...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row != table.HeaderRow {
+				return cellStyle
+			}
+			if col == selected {
+				return selectedHeaderStyle
+			}
+			return headerStyle
+		})
+
+	// Only shrink the columns when the natural layout is wider than the panel
+	rendered := t.Render()
+	if width := m.responseView.Width; width > 0 && lipgloss.Width(rendered) > width {
+		rendered = t.Width(width).Render()
+	}
+
+	var notes []string
+	if len(data.Rows) > len(rows) {
+		notes = append(notes, fmt.Sprintf("Showing the first %d of %d rows", len(rows), len(data.Rows)))
+	}
+	if hidden := len(data.Columns) - len(columns); hidden > 0 {
+		notes = append(notes, fmt.Sprintf("%d hidden column(s), a to show all", hidden))
+	}
+	if len(notes) > 0 {
+		rendered += "\n" + styleSubtle.Render(strings.Join(notes, " • "))
+	}
+	return rendered, true
+}
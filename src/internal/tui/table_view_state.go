@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"sync"
+)
+
+// TableViewState encapsulates the table view of the response panel
+// Hidden columns and the sort column are tracked by name so they survive a new response
+// with the same shape while the view stays open
+type TableViewState struct {
+	mu sync.RWMutex
+
+	active     bool            // Response body is shown as a table
+	selected   int             // Selected column among the visible columns
+	hidden     map[string]bool // Hidden column names
+	sortColumn string          // Column the rows are sorted by, empty for response order
+	sortDesc   bool
+}
+
+// NewTableViewState creates a new table view state
+func NewTableViewState() *TableViewState {
+	return &TableViewState{
+		hidden: make(map[string]bool),
+	}
+}
+
+// IsActive returns true when the response body is shown as a table
+func (s *TableViewState) IsActive() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// SetActive shows or hides the table view
+func (s *TableViewState) SetActive(active bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = active
+}
+
+// GetSelected returns the selected column, clamped to the visible column count
+func (s *TableViewState) GetSelected(count int) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clampIndex(s.selected, count)
+}
+
+// MoveSelection moves the selected column by delta within the visible column count
+func (s *TableViewState) MoveSelection(delta, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.selected = clampIndex(s.selected+delta, count)
+}
+
+// IsHidden returns true if the column is hidden
+func (s *TableViewState) IsHidden(column string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hidden[column]
+}
+
+// Hide hides a column
+func (s *TableViewState) Hide(column string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hidden[column] = true
+	if s.sortColumn == column {
+		s.sortColumn = ""
+		s.sortDesc = false
+	}
+}
+
+// HiddenCount returns the number of hidden columns
+func (s *TableViewState) HiddenCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.hidden)
+}
+
+// ShowAll shows every hidden column
+func (s *TableViewState) ShowAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hidden = make(map[string]bool)
+}
+
+// GetSort returns the sort column and direction
+func (s *TableViewState) GetSort() (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sortColumn, s.sortDesc
+}
+
+// CycleSort sorts by column ascending, then descending, then back to response order
+func (s *TableViewState) CycleSort(column string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.sortColumn != column:
+		s.sortColumn, s.sortDesc = column, false
+	case !s.sortDesc:
+		s.sortDesc = true
+	default:
+		s.sortColumn, s.sortDesc = "", false
+	}
+}
+
+// Reset clears the selection, hidden columns and sort
+func (s *TableViewState) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.selected = 0
+	s.hidden = make(map[string]bool)
+	s.sortColumn = ""
+	s.sortDesc = false
+}
+
+// clampIndex keeps index within [0, count)
+func clampIndex(index, count int) int {
+	if index >= count {
+		index = count - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	return index
+}
@@ -0,0 +1,51 @@
+package tui
+
+import "testing"
+
+func TestTableViewState_CycleSort(t *testing.T) {
+	state := NewTableViewState()
+
+	state.CycleSort("id")
+	if column, desc := state.GetSort(); column != "id" || desc {
+		t.Errorf("Expected ascending id, got %q desc=%v", column, desc)
+	}
+	state.CycleSort("id")
+	if column, desc := state.GetSort(); column != "id" || !desc {
+		t.Errorf("Expected descending id, got %q desc=%v", column, desc)
+	}
+	state.CycleSort("id")
+	if column, _ := state.GetSort(); column != "" {
+		t.Errorf("Expected the response order, got %q", column)
+	}
+
+	// Hiding the sort column clears the sort
+	state.CycleSort("name")
+	state.Hide("name")
+	if column, _ := state.GetSort(); column != "" || !state.IsHidden("name") {
+		t.Errorf("Expected name to be hidden and unsorted, got sort %q", column)
+	}
+}
+
+func TestTableViewState_Selection(t *testing.T) {
+	state := NewTableViewState()
+
+	state.MoveSelection(-1, 3)
+	if got := state.GetSelected(3); got != 0 {
+		t.Errorf("Expected 0, got %d", got)
+	}
+	state.MoveSelection(5, 3)
+	if got := state.GetSelected(3); got != 2 {
+		t.Errorf("Expected 2, got %d", got)
+	}
+
+	// Fewer visible columns clamp the selection
+	if got := state.GetSelected(1); got != 0 {
+		t.Errorf("Expected 0, got %d", got)
+	}
+
+	state.Hide("a")
+	state.Reset()
+	if state.HiddenCount() != 0 || state.GetSelected(3) != 0 {
+		t.Error("Expected Reset to clear hidden columns and selection")
+	}
+}
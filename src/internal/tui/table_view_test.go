@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestToggleTableView(t *testing.T) {
+	m := CreateTestModel(t)
+	m.responseView.Width = 80
+	m.currentResponse = &types.RequestResult{Status: 200, Body: `[{"id": 1, "user": {"name": "Alice"}}, {"id": 2, "extra": "x"}]`}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	AssertModelField(t, "tableViewState.IsActive()", true, m.tableViewState.IsActive())
+
+	m.updateResponseView()
+	for _, want := range []string{"user.name", "extra", "Alice"} {
+		if !strings.Contains(m.responseContent, want) {
+			t.Errorf("Expected %q in the table view:\n%s", want, m.responseContent)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	AssertModelField(t, "tableViewState.IsActive()", false, m.tableViewState.IsActive())
+
+	// Objects cannot be shown as a table
+	m.currentResponse = &types.RequestResult{Status: 200, Body: `{"id": 1}`}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	AssertModelField(t, "tableViewState.IsActive()", false, m.tableViewState.IsActive())
+	AssertModelField(t, "statusMsg", "Response body is not a JSON array", m.statusMsg)
+}
+
+func TestTableView_SortAndHideColumns(t *testing.T) {
+	m := CreateTestModel(t)
+	m.responseView.Width = 80
+	m.focusedPanel = "response"
+	m.currentResponse = &types.RequestResult{Status: 200, Body: `[{"name": "b", "age": 9}, {"name": "a", "age": 10}, {"name": "c"}]`}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+
+	// Sort by the second column: numerically, blanks last
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	column, desc := m.tableViewState.GetSort()
+	if column != "age" || desc {
+		t.Fatalf("Expected an ascending sort on age, got %q desc=%v", column, desc)
+	}
+	if strings.Index(m.responseContent, " b ") > strings.Index(m.responseContent, " a ") {
+		t.Errorf("Expected 9 to sort before 10:\n%s", m.responseContent)
+	}
+	if !strings.Contains(m.responseContent, "age ▲") {
+		t.Errorf("Expected a sort indicator:\n%s", m.responseContent)
+	}
+
+	// Hide the selected column, then show it again
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if !m.tableViewState.IsHidden("age") || strings.Contains(m.responseContent, "age") {
+		t.Errorf("Expected age to be hidden:\n%s", m.responseContent)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	AssertModelField(t, "statusMsg", "Cannot hide the last column", m.statusMsg)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	AssertModelField(t, "HiddenCount()", 0, m.tableViewState.HiddenCount())
+}
+
+func TestSortTableRows(t *testing.T) {
+	rows := [][]string{{"10"}, {""}, {"9"}, {"b"}, {"a"}}
+
+	sortTableRows(rows, 0, false)
+	if want := [][]string{{"9"}, {"10"}, {"a"}, {"b"}, {""}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("ascending = %q, want %q", rows, want)
+	}
+
+	sortTableRows(rows, 0, true)
+	if want := [][]string{{"b"}, {"a"}, {"10"}, {"9"}, {""}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("descending = %q, want %q", rows, want)
+	}
+}
@@ -0,0 +1,321 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// Theme defines the colors of the TUI
+// Each color has a light and a dark variant, picked from the terminal background.
+type Theme struct {
+	Accent              lipgloss.AdaptiveColor // Titles, focused borders
+	Success             lipgloss.AdaptiveColor
+	Error               lipgloss.AdaptiveColor
+	Warning             lipgloss.AdaptiveColor
+	Info                lipgloss.AdaptiveColor
+	Muted               lipgloss.AdaptiveColor // Hints, unfocused borders
+	SelectedForeground  lipgloss.AdaptiveColor
+	SelectedBackground  lipgloss.AdaptiveColor
+	HighlightForeground lipgloss.AdaptiveColor // Search matches
+	HighlightBackground lipgloss.AdaptiveColor
+	MethodGET           lipgloss.AdaptiveColor
+	MethodPOST          lipgloss.AdaptiveColor
+	MethodPUT           lipgloss.AdaptiveColor
+	MethodPATCH         lipgloss.AdaptiveColor
+	MethodDELETE        lipgloss.AdaptiveColor
+	MethodHEAD          lipgloss.AdaptiveColor
+	MethodOPTIONS       lipgloss.AdaptiveColor
+	MethodWS            lipgloss.AdaptiveColor
+}
+
+// colors maps the theme.json color names to the theme fields
+func (t *Theme) colors() map[string]*lipgloss.AdaptiveColor {
+	return map[string]*lipgloss.AdaptiveColor{
+		"accent":              &t.Accent,
+		"success":             &t.Success,
+		"error":               &t.Error,
+		"warning":             &t.Warning,
+		"info":                &t.Info,
+		"muted":               &t.Muted,
+		"selectedForeground":  &t.SelectedForeground,
+		"selectedBackground":  &t.SelectedBackground,
+		"highlightForeground": &t.HighlightForeground,
+		"highlightBackground": &t.HighlightBackground,
+		"methodGet":           &t.MethodGET,
+		"methodPost":          &t.MethodPOST,
+		"methodPut":           &t.MethodPUT,
+		"methodPatch":         &t.MethodPATCH,
+		"methodDelete":        &t.MethodDELETE,
+		"methodHead":          &t.MethodHEAD,
+		"methodOptions":       &t.MethodOPTIONS,
+		"methodWs":            &t.MethodWS,
+	}
+}
+
+// adaptive returns a color with a light and a dark variant
+func adaptive(light, dark string) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: light, Dark: dark}
+}
+
+// fixed returns a color used on light and dark backgrounds alike
+func fixed(color string) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: color, Dark: color}
+}
+
+// DefaultThemeName is the theme used when none is configured
+const DefaultThemeName = "default"
+
+// builtinThemes are the themes selectable by name
+// The default uses the terminal's own palette: regular colors (0-7) on light backgrounds,
+// bright colors (8-15) on dark ones.
+var builtinThemes = map[string]Theme{
+	DefaultThemeName: {
+		Accent:              adaptive("6", "14"),
+		Success:             adaptive("2", "10"),
+		Error:               adaptive("1", "9"),
+		Warning:             adaptive("3", "11"),
+		Info:                adaptive("4", "12"),
+		Muted:               fixed("8"),
+		SelectedForeground:  adaptive("0", "15"),
+		SelectedBackground:  adaptive("7", "8"),
+		HighlightForeground: fixed("0"),
+		HighlightBackground: adaptive("5", "13"),
+		MethodGET:           adaptive("4", "12"),
+		MethodPOST:          adaptive("2", "10"),
+		MethodPUT:           adaptive("3", "11"),
+		MethodPATCH:         adaptive("3", "11"),
+		MethodDELETE:        adaptive("1", "9"),
+		MethodHEAD:          fixed("8"),
+		MethodOPTIONS:       fixed("8"),
+		MethodWS:            adaptive("6", "14"),
+	},
+	"dark": {
+		Accent:              fixed("14"),
+		Success:             fixed("10"),
+		Error:               fixed("9"),
+		Warning:             fixed("11"),
+		Info:                fixed("12"),
+		Muted:               fixed("245"),
+		SelectedForeground:  fixed("15"),
+		SelectedBackground:  fixed("238"),
+		HighlightForeground: fixed("0"),
+		HighlightBackground: fixed("13"),
+		MethodGET:           fixed("12"),
+		MethodPOST:          fixed("10"),
+		MethodPUT:           fixed("11"),
+		MethodPATCH:         fixed("11"),
+		MethodDELETE:        fixed("9"),
+		MethodHEAD:          fixed("245"),
+		MethodOPTIONS:       fixed("245"),
+		MethodWS:            fixed("14"),
+	},
+	"light": {
+		Accent:              fixed("6"),
+		Success:             fixed("2"),
+		Error:               fixed("1"),
+		Warning:             fixed("130"),
+		Info:                fixed("4"),
+		Muted:               fixed("242"),
+		SelectedForeground:  fixed("0"),
+		SelectedBackground:  fixed("252"),
+		HighlightForeground: fixed("15"),
+		HighlightBackground: fixed("5"),
+		MethodGET:           fixed("4"),
+		MethodPOST:          fixed("2"),
+		MethodPUT:           fixed("130"),
+		MethodPATCH:         fixed("130"),
+		MethodDELETE:        fixed("1"),
+		MethodHEAD:          fixed("242"),
+		MethodOPTIONS:       fixed("242"),
+		MethodWS:            fixed("6"),
+	},
+	"high-contrast": {
+		Accent:              adaptive("#005f87", "#00ffff"),
+		Success:             adaptive("#005f00", "#00ff00"),
+		Error:               adaptive("#af0000", "#ff5f5f"),
+		Warning:             adaptive("#875f00", "#ffff00"),
+		Info:                adaptive("#0000af", "#5fafff"),
+		Muted:               adaptive("#444444", "#d0d0d0"),
+		SelectedForeground:  adaptive("#ffffff", "#000000"),
+		SelectedBackground:  adaptive("#000000", "#ffffff"),
+		HighlightForeground: fixed("#000000"),
+		HighlightBackground: fixed("#ffff00"),
+		MethodGET:           adaptive("#0000af", "#5fafff"),
+		MethodPOST:          adaptive("#005f00", "#00ff00"),
+		MethodPUT:           adaptive("#875f00", "#ffff00"),
+		MethodPATCH:         adaptive("#875f00", "#ffff00"),
+		MethodDELETE:        adaptive("#af0000", "#ff5f5f"),
+		MethodHEAD:          adaptive("#444444", "#d0d0d0"),
+		MethodOPTIONS:       adaptive("#444444", "#d0d0d0"),
+		MethodWS:            adaptive("#005f87", "#00ffff"),
+	},
+}
+
+// ThemeNames returns the names of the built-in themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ThemeConfig is the format of theme.json
+// Colors override the base theme; each is an ANSI color (0-255), a hex color (#rrggbb),
+// or an object with "light" and "dark" variants.
+type ThemeConfig struct {
+	Base   string                     `json:"base,omitempty"` // Built-in theme to start from (default: default)
+	Colors map[string]json.RawMessage `json:"colors,omitempty"`
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validColor reports whether s is an ANSI color (0-255) or a hex color
+func validColor(s string) bool {
+	if hexColorPattern.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// parseThemeColor reads a color of theme.json: "9", "#ff0000" or {"light": "1", "dark": "9"}
+func parseThemeColor(raw json.RawMessage) (lipgloss.AdaptiveColor, error) {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		if !validColor(single) {
+			return lipgloss.AdaptiveColor{}, fmt.Errorf("invalid color %q", single)
+		}
+		return fixed(single), nil
+	}
+
+	var variants struct {
+		Light string `json:"light"`
+		Dark  string `json:"dark"`
+	}
+	if err := json.Unmarshal(raw, &variants); err != nil {
+		return lipgloss.AdaptiveColor{}, fmt.Errorf("expected a color or {\"light\", \"dark\"}")
+	}
+	for _, color := range []string{variants.Light, variants.Dark} {
+		if !validColor(color) {
+			return lipgloss.AdaptiveColor{}, fmt.Errorf("invalid color %q", color)
+		}
+	}
+	return adaptive(variants.Light, variants.Dark), nil
+}
+
+// buildTheme applies the overrides of cfg to its base theme
+// Unknown names and invalid colors are skipped and reported as warnings.
+func buildTheme(cfg ThemeConfig) (Theme, []string) {
+	var warnings []string
+
+	base := cfg.Base
+	if base == "" {
+		base = DefaultThemeName
+	}
+	theme, ok := builtinThemes[base]
+	if !ok {
+		warnings = append(warnings, fmt.Sprintf("unknown base theme '%s', using %s", base, DefaultThemeName))
+		theme = builtinThemes[DefaultThemeName]
+	}
+
+	fields := theme.colors()
+	names := make([]string, 0, len(cfg.Colors))
+	for name := range cfg.Colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown theme color '%s'", name))
+			continue
+		}
+		color, err := parseThemeColor(cfg.Colors[name])
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("theme color '%s': %v, using the default", name, err))
+			continue
+		}
+		*field = color
+	}
+	return theme, warnings
+}
+
+// loadThemeFile reads a theme.json file
+func loadThemeFile(path string) (Theme, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, nil, err
+	}
+	var cfg ThemeConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Theme{}, nil, fmt.Errorf("invalid theme file %s: %w", path, err)
+	}
+	theme, warnings := buildTheme(cfg)
+	return theme, warnings, nil
+}
+
+// themeConfigPath returns the global theme.json path (replaced in tests)
+var themeConfigPath = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".restcli", "theme.json"), nil
+}
+
+// loadTheme picks the theme for a profile: profile theme > global theme.json > default
+// The profile names a built-in theme or a theme file. A broken theme falls back to the next one.
+func loadTheme(profile *types.Profile) (Theme, []string) {
+	var warnings []string
+
+	if profile != nil && profile.Theme != "" {
+		if theme, ok := builtinThemes[profile.Theme]; ok {
+			return theme, nil
+		}
+		path, err := config.ResolvePath(profile.Theme)
+		if err == nil {
+			theme, fileWarnings, loadErr := loadThemeFile(path)
+			if loadErr == nil {
+				return theme, fileWarnings
+			}
+			err = loadErr
+		}
+		warnings = append(warnings, fmt.Sprintf("profile theme ignored (not one of %s or a readable file): %v",
+			strings.Join(ThemeNames(), ", "), err))
+	}
+
+	if path, err := themeConfigPath(); err == nil {
+		if _, statErr := os.Stat(path); statErr == nil {
+			theme, fileWarnings, err := loadThemeFile(path)
+			if err == nil {
+				return theme, append(warnings, fileWarnings...)
+			}
+			warnings = append(warnings, fmt.Sprintf("%v, using the default theme", err))
+		}
+	}
+
+	return builtinThemes[DefaultThemeName], warnings
+}
+
+// reloadTheme applies the theme of the active profile after it changed
+func (m *Model) reloadTheme() tea.Cmd {
+	theme, warnings := loadTheme(m.sessionMgr.GetActiveProfile())
+	applyTheme(theme)
+	if len(warnings) > 0 {
+		return m.setErrorMessage(strings.Join(warnings, "; "))
+	}
+	return nil
+}
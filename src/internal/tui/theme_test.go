@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func writeThemeFile(t *testing.T, path string, cfg ThemeConfig) {
+	t.Helper()
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildTheme_OverridesBase(t *testing.T) {
+	theme, warnings := buildTheme(ThemeConfig{
+		Base: "dark",
+		Colors: map[string]json.RawMessage{
+			"error":  json.RawMessage(`"#ff0000"`),
+			"accent": json.RawMessage(`{"light": "6", "dark": "51"}`),
+		},
+	})
+
+	if len(warnings) != 0 {
+		t.Fatalf("Expected no warnings, got %v", warnings)
+	}
+	if theme.Error != fixed("#ff0000") {
+		t.Errorf("Expected the error color override, got %v", theme.Error)
+	}
+	if theme.Accent != adaptive("6", "51") {
+		t.Errorf("Expected the adaptive accent override, got %v", theme.Accent)
+	}
+	if theme.Success != builtinThemes["dark"].Success {
+		t.Errorf("Expected colors without override to come from the base theme, got %v", theme.Success)
+	}
+}
+
+func TestBuildTheme_InvalidEntriesFallBack(t *testing.T) {
+	theme, warnings := buildTheme(ThemeConfig{
+		Base: "solarized",
+		Colors: map[string]json.RawMessage{
+			"error":    json.RawMessage(`"red"`),
+			"success":  json.RawMessage(`"256"`),
+			"warning":  json.RawMessage(`{"light": "3", "dark": "#12345"}`),
+			"sparkles": json.RawMessage(`"5"`),
+		},
+	})
+
+	if len(warnings) != 5 {
+		t.Fatalf("Expected 5 warnings (base, 3 colors, unknown name), got %v", warnings)
+	}
+	defaults := builtinThemes[DefaultThemeName]
+	if theme.Error != defaults.Error || theme.Success != defaults.Success || theme.Warning != defaults.Warning {
+		t.Errorf("Expected invalid colors to keep the default theme values, got %+v", theme)
+	}
+}
+
+func TestValidColor(t *testing.T) {
+	for _, color := range []string{"0", "9", "255", "#fff", "#00FF7f"} {
+		if !validColor(color) {
+			t.Errorf("Expected %q to be valid", color)
+		}
+	}
+	for _, color := range []string{"", "-1", "256", "red", "#ffff", "ff0000", "#gggggg"} {
+		if validColor(color) {
+			t.Errorf("Expected %q to be invalid", color)
+		}
+	}
+}
+
+func TestLoadTheme_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	globalPath := filepath.Join(dir, "theme.json")
+	original := themeConfigPath
+	themeConfigPath = func() (string, error) { return globalPath, nil }
+	t.Cleanup(func() { themeConfigPath = original })
+
+	// No configuration: default theme
+	theme, warnings := loadTheme(&types.Profile{Name: "Default"})
+	if theme != builtinThemes[DefaultThemeName] || len(warnings) != 0 {
+		t.Fatalf("Expected the default theme, got %+v (%v)", theme, warnings)
+	}
+
+	// Global theme.json
+	writeThemeFile(t, globalPath, ThemeConfig{Base: "light"})
+	if theme, _ := loadTheme(&types.Profile{Name: "Default"}); theme != builtinThemes["light"] {
+		t.Errorf("Expected the global theme.json, got %+v", theme)
+	}
+
+	// Profile theme by name wins over the global file
+	if theme, _ := loadTheme(&types.Profile{Name: "Default", Theme: "high-contrast"}); theme != builtinThemes["high-contrast"] {
+		t.Errorf("Expected the profile's built-in theme, got %+v", theme)
+	}
+
+	// Profile theme file
+	profilePath := filepath.Join(dir, "project-theme.json")
+	writeThemeFile(t, profilePath, ThemeConfig{Base: "dark", Colors: map[string]json.RawMessage{"accent": json.RawMessage(`"208"`)}})
+	theme, _ = loadTheme(&types.Profile{Name: "Project", Theme: profilePath})
+	if theme.Accent != fixed("208") || theme.Error != builtinThemes["dark"].Error {
+		t.Errorf("Expected the profile theme file, got %+v", theme)
+	}
+
+	// Unknown profile theme falls back to the global file with a warning
+	theme, warnings = loadTheme(&types.Profile{Name: "Broken", Theme: filepath.Join(dir, "missing.json")})
+	if theme != builtinThemes["light"] {
+		t.Errorf("Expected the global theme as fallback, got %+v", theme)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "profile theme ignored") {
+		t.Errorf("Expected a warning about the profile theme, got %v", warnings)
+	}
+}
+
+func TestApplyTheme_RebuildsStyles(t *testing.T) {
+	t.Cleanup(func() { applyTheme(builtinThemes[DefaultThemeName]) })
+
+	theme := builtinThemes["high-contrast"]
+	applyTheme(theme)
+
+	if styleError.GetForeground() != theme.Error {
+		t.Errorf("Expected styleError to use the theme error color, got %v", styleError.GetForeground())
+	}
+	if styleSelected.GetBackground() != theme.SelectedBackground {
+		t.Errorf("Expected styleSelected to use the theme selection color, got %v", styleSelected.GetBackground())
+	}
+	if getMethodStyle("DELETE").GetForeground() != theme.MethodDELETE {
+		t.Errorf("Expected the DELETE method style to use the theme color, got %v", getMethodStyle("DELETE").GetForeground())
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/parser"
+	"github.com/studiowebux/restcli/internal/secrets"
 	"github.com/studiowebux/restcli/internal/types"
 )
 
@@ -50,6 +52,9 @@ func (m *Model) renderVariableEditor() string {
 				if v.value.Interactive {
 					line += " [interactive]"
 				}
+				if secrets.IsEncrypted(v.value.GetValue()) {
+					line += " [encrypted]"
+				}
 				if i == m.varEditIndex {
 					line = styleSelected.Render(line)
 				}
@@ -57,7 +62,7 @@ func (m *Model) renderVariableEditor() string {
 			}
 		}
 
-		footer = "[a]dd [e]dit [d]elete [o]ptions [m]anage [i]nteractive [ESC]"
+		footer = "[a]dd [e]dit [d]elete [o]ptions [m]anage [i]nteractive [K]eychain [E]ncrypt [ESC]"
 
 	case ModeVariableAdd:
 		content.WriteString("Add Variable\n\n")
@@ -72,6 +77,7 @@ func (m *Model) renderVariableEditor() string {
 		}
 		content.WriteString("Name:  " + nameField + "\n")
 		content.WriteString("Value: " + valueField + "\n")
+		content.WriteString("\n" + generatorHint() + "\n")
 		footer = "[TAB] switch fields [Enter] save [ESC] cancel"
 
 	case ModeVariableEdit:
@@ -87,6 +93,7 @@ func (m *Model) renderVariableEditor() string {
 		}
 		content.WriteString("Name:  " + nameField + "\n")
 		content.WriteString("Value: " + valueField + "\n")
+		content.WriteString("\n" + generatorHint() + "\n")
 		footer = "[TAB] switch fields [Enter] save [ESC] cancel"
 
 	case ModeVariableDelete:
@@ -194,6 +201,11 @@ func (m *Model) renderVariableEditor() string {
 	return m.renderModalWithFooterAndScroll("Variables", content.String(), footer, 70, 25, selectedLine)
 }
 
+// generatorHint lists the faker generators that can be used in a variable value
+func generatorHint() string {
+	return styleSubtle.Render(wrapText("Generators: "+strings.Join(parser.FakerGenerators(), " "), 60))
+}
+
 // getSortedVariableNames returns sorted variable names
 func getSortedVariableNames(vars map[string]types.VariableValue) []string {
 	names := make([]string, 0, len(vars))
@@ -243,6 +255,16 @@ func (m *Model) handleVariableEditorKeys(msg tea.KeyMsg) tea.Cmd {
 				}
 			}
 			return nil
+		case "K":
+			if len(sortedNames) > 0 && m.varEditIndex < len(sortedNames) {
+				return m.storeVariableInKeychain(sortedNames[m.varEditIndex])
+			}
+			return nil
+		case "E":
+			if len(sortedNames) > 0 && m.varEditIndex < len(sortedNames) {
+				return m.encryptVariable(sortedNames[m.varEditIndex])
+			}
+			return nil
 		}
 
 		action, ok, partial := m.keybinds.MatchMultiKey(keybinds.ContextVariableList, msg.String())
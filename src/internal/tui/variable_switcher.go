@@ -0,0 +1,352 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/keybinds"
+	"github.com/studiowebux/restcli/internal/secrets"
+	"github.com/studiowebux/restcli/internal/session"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// switcherEntry is a row of the variable switcher: an environment of the active profile or a variable
+type switcherEntry struct {
+	Environment bool                // Row switches environment (Name is empty for the base variables)
+	Name        string              // Variable or environment name
+	Value       types.VariableValue // Current value of a variable
+	Source      string              // "session", the environment overriding the variable, or empty for the profile
+}
+
+// label is the text matched against the query
+func (e switcherEntry) label() string {
+	if e.Environment {
+		if e.Name == "" {
+			return "env base"
+		}
+		return "env " + e.Name
+	}
+	return e.Name
+}
+
+// switcherEntries lists the environments and variables of the active profile, filtered and ranked by the query
+// Session variables take precedence over profile variables during resolution, so they win here too.
+func (m *Model) switcherEntries() []switcherEntry {
+	profile := m.sessionMgr.GetActiveProfile()
+	activeEnv := m.sessionMgr.ActiveEnvironment(profile.Name)
+
+	var entries []switcherEntry
+	if names := session.EnvironmentNames(profile); len(names) > 0 {
+		for _, name := range append([]string{""}, names...) {
+			entries = append(entries, switcherEntry{Environment: true, Name: name})
+		}
+	}
+
+	variables := make(map[string]switcherEntry)
+	for name, value := range m.sessionMgr.ProfileVariables(profile) {
+		entry := switcherEntry{Name: name, Value: value}
+		if _, overridden := profile.Environments[activeEnv][name]; overridden {
+			entry.Source = activeEnv
+		}
+		variables[name] = entry
+	}
+	for name, value := range m.sessionMgr.GetSession().Variables {
+		entry := switcherEntry{Name: name, Source: "session"}
+		entry.Value.SetValue(value)
+		variables[name] = entry
+	}
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entries = append(entries, variables[name])
+	}
+
+	if m.varSwitcherQuery == "" {
+		return entries
+	}
+	scores := make(map[int]int)
+	var matches []switcherEntry
+	for _, entry := range entries {
+		if score, ok := fuzzyScore(m.varSwitcherQuery, entry.label()); ok {
+			scores[len(matches)] = score
+			matches = append(matches, entry)
+		}
+	}
+	order := make([]int, len(matches))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+	ranked := make([]switcherEntry, len(matches))
+	for i, idx := range order {
+		ranked[i] = matches[idx]
+	}
+	return ranked
+}
+
+// openVariableSwitcher opens the fuzzy variable switcher
+func (m *Model) openVariableSwitcher() {
+	m.varSwitcherQuery = ""
+	m.varSwitcherCursor = 0
+	m.varSwitcherEditing = ""
+	m.errorMsg = ""
+	m.selectSwitcherEntry(0)
+	m.mode = ModeVariableSwitcher
+}
+
+// selectSwitcherEntry moves the selection, starting multi-value rows on their active option
+func (m *Model) selectSwitcherEntry(index int) {
+	entries := m.switcherEntries()
+	if index >= len(entries) {
+		index = len(entries) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	m.varSwitcherIndex = index
+	m.varSwitcherOption = 0
+	if index < len(entries) && entries[index].Value.IsMultiValue() {
+		m.varSwitcherOption = entries[index].Value.MultiValue.Active
+	}
+}
+
+// applySwitcherEntry switches to the selected environment, sets the picked option or starts editing the value
+func (m *Model) applySwitcherEntry() tea.Cmd {
+	entries := m.switcherEntries()
+	if m.varSwitcherIndex >= len(entries) {
+		return nil
+	}
+	entry := entries[m.varSwitcherIndex]
+
+	switch {
+	case entry.Environment:
+		m.mode = ModeNormal
+		return m.switchEnvironment(entry.Name)
+
+	case entry.Value.IsMultiValue():
+		option := m.varSwitcherOption
+		if option < 0 || option >= len(entry.Value.MultiValue.Options) {
+			return nil
+		}
+		m.updateProfileVariable(entry.Name, func(value *types.VariableValue) {
+			value.MultiValue.Active = option
+		})
+		m.mode = ModeNormal
+		return m.setStatusMessage(fmt.Sprintf("Set %s = %s", entry.Name, entry.Value.MultiValue.Options[option]))
+	}
+
+	m.varSwitcherEditing = entry.Name
+	m.varSwitcherValue = entry.Value.GetValue()
+	m.varSwitcherValuePos = len(m.varSwitcherValue)
+	return nil
+}
+
+// saveSwitcherValue stores the edited value where the variable is defined and closes the switcher
+func (m *Model) saveSwitcherValue() tea.Cmd {
+	name := m.varSwitcherEditing
+	value := m.varSwitcherValue
+
+	if _, isSession := m.sessionMgr.GetSession().Variables[name]; isSession {
+		if err := m.sessionMgr.SetSessionVariable(name, value); err != nil {
+			m.errorMsg = fmt.Sprintf("Failed to save session variable: %v", err)
+			return nil
+		}
+	} else {
+		m.updateProfileVariable(name, func(v *types.VariableValue) {
+			v.SetValue(value)
+		})
+	}
+
+	m.mode = ModeNormal
+	m.varSwitcherEditing = ""
+	return m.setStatusMessage(fmt.Sprintf("Set %s = %s", name, truncateRunes(value, 40)))
+}
+
+// updateProfileVariable changes a variable of the active profile where it is defined:
+// in the active environment when it overrides the variable, otherwise in the profile itself
+func (m *Model) updateProfileVariable(name string, update func(*types.VariableValue)) {
+	profile := m.sessionMgr.GetActiveProfile()
+	if env := m.sessionMgr.ActiveEnvironment(profile.Name); env != "" {
+		if value, ok := profile.Environments[env][name]; ok {
+			update(&value)
+			profile.Environments[env][name] = value
+			m.sessionMgr.SaveProfiles()
+			return
+		}
+	}
+
+	if profile.Variables == nil {
+		profile.Variables = make(map[string]types.VariableValue)
+	}
+	value := profile.Variables[name]
+	update(&value)
+	profile.Variables[name] = value
+	m.sessionMgr.SaveProfiles()
+}
+
+// handleVariableSwitcherKeys handles keyboard input in the variable switcher
+func (m *Model) handleVariableSwitcherKeys(msg tea.KeyMsg) tea.Cmd {
+	if m.varSwitcherEditing != "" {
+		return m.handleVariableSwitcherEditKeys(msg)
+	}
+
+	// Navigation keys (not in registry, letters go to the query)
+	switch msg.String() {
+	case "up", "ctrl+p":
+		m.selectSwitcherEntry(m.varSwitcherIndex - 1)
+		return nil
+	case "down", "ctrl+n":
+		m.selectSwitcherEntry(m.varSwitcherIndex + 1)
+		return nil
+	case "tab", "shift+tab":
+		// Pick an option of a multi-value variable
+		entries := m.switcherEntries()
+		if m.varSwitcherIndex < len(entries) && entries[m.varSwitcherIndex].Value.IsMultiValue() {
+			count := len(entries[m.varSwitcherIndex].Value.MultiValue.Options)
+			if count == 0 {
+				return nil
+			}
+			step := 1
+			if msg.String() == "shift+tab" {
+				step = count - 1
+			}
+			m.varSwitcherOption = (m.varSwitcherOption + step) % count
+		}
+		return nil
+	}
+
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			m.mode = ModeNormal
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			return m.applySwitcherEntry()
+		}
+	}
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.varSwitcherQuery, &m.varSwitcherCursor, msg); shouldContinue {
+		m.selectSwitcherEntry(0)
+		return nil
+	}
+	if len(msg.String()) == 1 {
+		m.varSwitcherQuery = m.varSwitcherQuery[:m.varSwitcherCursor] + msg.String() + m.varSwitcherQuery[m.varSwitcherCursor:]
+		m.varSwitcherCursor++
+		m.selectSwitcherEntry(0)
+	}
+	return nil
+}
+
+// handleVariableSwitcherEditKeys handles the value input of the variable switcher
+func (m *Model) handleVariableSwitcherEditKeys(msg tea.KeyMsg) tea.Cmd {
+	action, ok := m.keybinds.Match(keybinds.ContextTextInput, msg.String())
+	if ok {
+		switch action {
+		case keybinds.ActionTextCancel:
+			// Back to the list
+			m.varSwitcherEditing = ""
+			m.errorMsg = ""
+			return nil
+
+		case keybinds.ActionTextSubmit:
+			return m.saveSwitcherValue()
+		}
+	}
+
+	if _, shouldContinue := handleTextInputWithCursor(&m.varSwitcherValue, &m.varSwitcherValuePos, msg); shouldContinue {
+		return nil
+	}
+	if len(msg.String()) == 1 {
+		m.varSwitcherValue = m.varSwitcherValue[:m.varSwitcherValuePos] + msg.String() + m.varSwitcherValue[m.varSwitcherValuePos:]
+		m.varSwitcherValuePos++
+	}
+	return nil
+}
+
+// renderVariableSwitcher renders the variable switcher
+func (m *Model) renderVariableSwitcher() string {
+	width := m.width - ModalWidthMargin
+	if width > 100 {
+		width = 100
+	}
+	if width < 50 {
+		width = 50
+	}
+	height := m.height - ModalOverheadMinimal
+	if height < 12 {
+		height = 12
+	}
+
+	var content strings.Builder
+	if m.varSwitcherEditing != "" {
+		valueField := m.varSwitcherValue[:m.varSwitcherValuePos] + "█" + m.varSwitcherValue[m.varSwitcherValuePos:]
+		content.WriteString(fmt.Sprintf("%s = %s\n", m.varSwitcherEditing, valueField))
+		if m.errorMsg != "" {
+			content.WriteString("\n" + styleError.Render(m.errorMsg) + "\n")
+		}
+		return m.renderModalWithFooter("Edit Variable", content.String(), "[Enter] save [ESC] back", width, 8)
+	}
+
+	content.WriteString("> " + m.varSwitcherQuery[:m.varSwitcherCursor] + "█" + m.varSwitcherQuery[m.varSwitcherCursor:] + "\n\n")
+
+	profile := m.sessionMgr.GetActiveProfile()
+	activeEnv := m.sessionMgr.ActiveEnvironment(profile.Name)
+	entries := m.switcherEntries()
+	if len(entries) == 0 {
+		content.WriteString(styleSubtle.Render("No matching variable"))
+	}
+	for i, entry := range entries {
+		selected := i == m.varSwitcherIndex
+		var line string
+		switch {
+		case entry.Environment:
+			name := entry.Name
+			if name == "" {
+				name = "(base)"
+			}
+			line = "env: " + name
+			if entry.Name == activeEnv {
+				line += " [active]"
+			}
+
+		case entry.Value.IsMultiValue():
+			options := make([]string, len(entry.Value.MultiValue.Options))
+			for j, option := range entry.Value.MultiValue.Options {
+				option = truncate(option, 20)
+				switch {
+				case selected && j == m.varSwitcherOption:
+					option = "[" + option + "]"
+				case !selected && j == entry.Value.MultiValue.Active:
+					option = "*" + option
+				}
+				options[j] = option
+			}
+			line = entry.Name + ": " + strings.Join(options, " | ")
+
+		default:
+			line = fmt.Sprintf("%s = %s", entry.Name, truncate(entry.Value.GetValue(), 50))
+			if secrets.IsEncrypted(entry.Value.GetValue()) {
+				line += " [encrypted]"
+			}
+		}
+		if entry.Source != "" {
+			line += " [" + entry.Source + "]"
+		}
+
+		if selected {
+			content.WriteString(styleSelected.Render(line) + "\n")
+		} else {
+			content.WriteString("  " + line + "\n")
+		}
+	}
+
+	footer := "[type] filter [↑/↓] navigate [tab] pick option [Enter] select/edit [ESC] close"
+	return m.renderModalWithFooterAndScroll("Variables", content.String(), footer, width, height, m.varSwitcherIndex+2)
+}
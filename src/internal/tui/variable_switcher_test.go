@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/config"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+// newSwitcherTestModel creates a model whose active profile has a plain, a multi-value and an environment-overridden variable
+func newSwitcherTestModel(t *testing.T) *Model {
+	t.Helper()
+	dir := t.TempDir()
+	originalProfilesFile, originalSessionFile := config.ProfilesFile, config.SessionFile
+	config.ProfilesFile = filepath.Join(dir, ".profiles.json")
+	config.SessionFile = filepath.Join(dir, ".session.json")
+	t.Cleanup(func() {
+		config.ProfilesFile, config.SessionFile = originalProfilesFile, originalSessionFile
+	})
+
+	m := CreateTestModel(t)
+	m.width, m.height = 120, 40
+
+	userID, base, us := "42", "https://eu.example.com", "https://us.example.com"
+	profile := types.Profile{
+		Name: "api",
+		Variables: map[string]types.VariableValue{
+			"userId":  {StringValue: &userID},
+			"baseUrl": {StringValue: &base},
+			"tenant":  {MultiValue: &types.MultiValueVariable{Options: []string{"acme", "globex", "initech"}}},
+		},
+		Environments: map[string]types.Environment{"us": {"baseUrl": {StringValue: &us}}},
+	}
+	if err := m.sessionMgr.AddProfile(profile); err != nil {
+		t.Fatalf("Failed to add profile: %v", err)
+	}
+	return m
+}
+
+func profileValue(m *Model, name string) string {
+	value := m.sessionMgr.ProfileVariables(m.sessionMgr.GetActiveProfile())[name]
+	return value.GetValue()
+}
+
+func TestVariableSwitcher_EditValue(t *testing.T) {
+	m := newSwitcherTestModel(t)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	AssertModelField(t, "mode", m.mode, ModeVariableSwitcher)
+
+	typeText(m, "uid")
+	entries := m.switcherEntries()
+	if len(entries) == 0 || entries[0].Name != "userId" {
+		t.Fatalf("Expected userId to rank first, got %+v", entries)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "editing", m.varSwitcherEditing, "userId")
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText(m, "3")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	AssertModelField(t, "mode", m.mode, ModeNormal)
+	AssertModelField(t, "userId", profileValue(m, "userId"), "43")
+}
+
+func TestVariableSwitcher_PickOption(t *testing.T) {
+	m := newSwitcherTestModel(t)
+	m.openVariableSwitcher()
+
+	typeText(m, "tenant")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	AssertModelField(t, "mode", m.mode, ModeNormal)
+	AssertModelField(t, "tenant", profileValue(m, "tenant"), "initech")
+}
+
+func TestVariableSwitcher_Environments(t *testing.T) {
+	m := newSwitcherTestModel(t)
+	m.openVariableSwitcher()
+
+	typeText(m, "env us")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "active environment", m.sessionMgr.ActiveEnvironment("api"), "us")
+
+	// Editing an overridden variable changes the environment, not the base value
+	m.openVariableSwitcher()
+	typeText(m, "baseurl")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.varSwitcherValue = "https://us2.example.com"
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	AssertModelField(t, "baseUrl", profileValue(m, "baseUrl"), "https://us2.example.com")
+	baseValue := m.sessionMgr.GetActiveProfile().Variables["baseUrl"]
+	AssertModelField(t, "base baseUrl", baseValue.GetValue(), "https://eu.example.com")
+}
+
+func TestVariableSwitcher_SessionVariableWins(t *testing.T) {
+	m := newSwitcherTestModel(t)
+	m.sessionMgr.GetSession().Variables["userId"] = "7"
+	m.openVariableSwitcher()
+
+	typeText(m, "userid")
+	entries := m.switcherEntries()
+	if len(entries) != 1 || entries[0].Source != "session" || entries[0].Value.GetValue() != "7" {
+		t.Fatalf("Expected the session value, got %+v", entries)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.varSwitcherValue = "8"
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	AssertModelField(t, "session userId", m.sessionMgr.GetSession().Variables["userId"], "8")
+	AssertModelField(t, "profile userId", profileValue(m, "userId"), "42")
+}
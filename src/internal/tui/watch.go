@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/watcher"
+)
+
+// watchState tracks the file re-executed when it or one of its dependencies is saved
+type watchState struct {
+	watcher *watcher.Watcher // Active watcher, nil when watch mode is off
+	path    string           // Request file being watched
+}
+
+// watchChangedMsg is sent when a watched file changed on disk
+type watchChangedMsg struct {
+	watcher *watcher.Watcher
+	path    string
+}
+
+// toggleWatch turns watch mode on for the selected file, or off
+func (m *Model) toggleWatch() tea.Cmd {
+	if m.watch.watcher != nil {
+		m.stopWatch()
+		return m.setStatusMessage("Watch mode off")
+	}
+
+	file := m.fileExplorer.GetCurrentFile()
+	if file == nil {
+		return m.setErrorMessage("No file selected")
+	}
+
+	profile := m.sessionMgr.GetActiveProfile()
+	w, err := watcher.New(watcher.Files(file.Path, profile.Workdir), watcher.DefaultDebounce)
+	if err != nil {
+		return m.setErrorMessage(fmt.Sprintf("Failed to watch file: %v", err))
+	}
+
+	m.watch = watchState{watcher: w, path: file.Path}
+	status := m.setStatusMessage(fmt.Sprintf("Watching %s: re-runs on save (L to stop)", filepath.Base(file.Path)))
+	return tea.Batch(status, waitForWatchChange(w))
+}
+
+// stopWatch closes the active watcher
+func (m *Model) stopWatch() {
+	if m.watch.watcher != nil {
+		m.watch.watcher.Close()
+	}
+	m.watch = watchState{}
+}
+
+// waitForWatchChange waits for the next change reported by the watcher
+func waitForWatchChange(w *watcher.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		path, ok := <-w.Changes()
+		if !ok {
+			return nil
+		}
+		return watchChangedMsg{watcher: w, path: path}
+	}
+}
+
+// handleWatchChanged re-executes the watched request after a save
+// The change is skipped (but watching continues) while another file is selected, a modal is
+// open or a request is running.
+func (m *Model) handleWatchChanged(msg watchChangedMsg) tea.Cmd {
+	// Stale message from a watcher that was turned off
+	if msg.watcher != m.watch.watcher {
+		return nil
+	}
+	wait := waitForWatchChange(msg.watcher)
+
+	file := m.fileExplorer.GetCurrentFile()
+	if file == nil || file.Path != m.watch.path || m.mode != ModeNormal || m.loading {
+		return tea.Batch(m.setStatusMessage(fmt.Sprintf("%s changed (not re-run)", filepath.Base(msg.path))), wait)
+	}
+
+	// Pick up dependencies added or removed by the edit
+	profile := m.sessionMgr.GetActiveProfile()
+	if err := msg.watcher.SetFiles(watcher.Files(m.watch.path, profile.Workdir)); err != nil {
+		return tea.Batch(m.setErrorMessage(fmt.Sprintf("Failed to watch file: %v", err)), wait)
+	}
+
+	m.loadRequestsFromCurrentFile()
+	return tea.Batch(m.handleExecuteAction(), wait)
+}
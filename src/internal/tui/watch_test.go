@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/studiowebux/restcli/internal/types"
+)
+
+func TestWatch_ReRunsSavedRequest(t *testing.T) {
+	m := CreateTestModel(t)
+	path := filepath.Join(t.TempDir(), "api.http")
+	if err := os.WriteFile(path, []byte("### API\nGET http://localhost/v1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	infos := []types.FileInfo{{Name: "api.http", Path: path}}
+	m.fileExplorer.SetFiles(infos, infos)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if m.watch.watcher == nil {
+		t.Fatalf("Expected watch mode to be on (error %q)", m.errorMsg)
+	}
+	defer m.stopWatch()
+
+	if err := os.WriteFile(path, []byte("### API\nGET http://localhost/v2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m.Update(watchChangedMsg{watcher: m.watch.watcher, path: path})
+	if !m.loading {
+		t.Error("Expected the saved request to be executed")
+	}
+	if m.currentRequest == nil || !strings.HasSuffix(m.currentRequest.URL, "/v2") {
+		t.Errorf("Expected the request to be reloaded from disk, got %+v", m.currentRequest)
+	}
+}
+
+func TestWatch_ToggleOff(t *testing.T) {
+	m := CreateTestModel(t)
+	path := filepath.Join(t.TempDir(), "api.http")
+	if err := os.WriteFile(path, []byte("### API\nGET http://localhost\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	infos := []types.FileInfo{{Name: "api.http", Path: path}}
+	m.fileExplorer.SetFiles(infos, infos)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	stale := m.watch.watcher
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if m.watch.watcher != nil {
+		t.Fatal("Expected watch mode to be off")
+	}
+
+	// A change reported by the closed watcher is ignored
+	m.Update(watchChangedMsg{watcher: stale, path: path})
+	if m.loading {
+		t.Error("Expected no execution after watch mode was turned off")
+	}
+}
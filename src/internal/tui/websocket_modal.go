@@ -193,9 +193,9 @@ func (m *Model) renderWebSocketModal() string {
 		}
 
 		if m.wsFocusedPane == "menu" {
-			footer = statusStyle.Render(fmt.Sprintf(" j/k: Select | Enter: Send | /: Search | c: Copy | C: Clear | e: Export | %s | Tab: Switch | q: Close ", connectionAction))
+			footer = statusStyle.Render(fmt.Sprintf(" j/k: Select | Enter: Send | /: Search | x: Hex | c: Copy | C: Clear | e: Export | %s | Tab: Switch | q: Close ", connectionAction))
 		} else {
-			footer = statusStyle.Render(fmt.Sprintf(" j/k: Scroll | /: Search | c: Copy | C: Clear | e: Export | %s | Tab: Switch | q: Close ", connectionAction))
+			footer = statusStyle.Render(fmt.Sprintf(" j/k: Scroll | /: Search | x: Hex | c: Copy | C: Clear | e: Export | %s | Tab: Switch | q: Close ", connectionAction))
 		}
 	}
 
@@ -300,8 +300,12 @@ func (m *Model) updateWebSocketHistoryView(width, height int) {
 	var filteredMessages []types.ReceivedMessage
 	if m.wsSearchQuery != "" {
 		query := strings.ToLower(m.wsSearchQuery)
+		// In the hex view, hex digits also match the bytes of binary frames
+		pattern, bytePattern := parseBytePattern(m.wsSearchQuery)
+		bytePattern = bytePattern && m.wsHexView
 		for _, msg := range m.wsMessages {
-			if strings.Contains(strings.ToLower(msg.Content), query) ||
+			if (bytePattern && isBinaryFrame(msg) && strings.Contains(msg.Content, string(pattern))) ||
+				strings.Contains(strings.ToLower(msg.Content), query) ||
 				strings.Contains(strings.ToLower(msg.Direction), query) ||
 				strings.Contains(strings.ToLower(msg.Type), query) {
 				filteredMessages = append(filteredMessages, msg)
@@ -366,8 +370,15 @@ func (m *Model) updateWebSocketHistoryView(width, height int) {
 		contentStyle := lipgloss.NewStyle().
 			Width(maxWidth)
 
-		// Wrap the content
-		wrappedContent := contentStyle.Render(content)
+		// Binary frames are a hex dump or a size summary, never raw bytes
+		var wrappedContent string
+		if isBinaryFrame(msg) && m.wsHexView {
+			wrappedContent = strings.TrimSuffix(renderHexDumpLines([]byte(content), hexBytesPerLineFor(maxWidth)), "\n")
+		} else if isBinaryFrame(msg) {
+			wrappedContent = contentStyle.Render(fmt.Sprintf("[Binary frame - %s, x for hex view]", formatBytes(int64(len(content)))))
+		} else {
+			wrappedContent = contentStyle.Render(content)
+		}
 
 		// Split wrapped content into lines
 		contentLines := strings.Split(wrappedContent, "\n")
@@ -617,6 +628,17 @@ func (m *Model) handleWebSocketKeys(msg tea.KeyMsg) tea.Cmd {
 		}
 		return nil
 
+	case "x":
+		// Toggle the hex dump of binary frames
+		m.wsLastKey = ""
+		m.wsHexView = !m.wsHexView
+		modalWidth := m.width - ModalWidthMargin
+		modalHeight := m.height - ModalHeightMargin
+		paneHeight := modalHeight - 3
+		historyWidth := (modalWidth * 6) / 10
+		m.updateWebSocketHistoryView(historyWidth-4, paneHeight-2)
+		return nil
+
 	case "e":
 		// Export message history to file
 		m.wsLastKey = ""
@@ -811,3 +833,8 @@ func (m *Model) handleWebSocketKeys(msg tea.KeyMsg) tea.Cmd {
 	m.gPressed = false
 	return nil
 }
+
+// isBinaryFrame reports whether a WebSocket message holds binary data
+func isBinaryFrame(msg types.ReceivedMessage) bool {
+	return msg.Direction != "system" && (msg.Type == "binary" || isBinaryContent(msg.Content))
+}
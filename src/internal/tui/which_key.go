@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/studiowebux/restcli/internal/keybinds"
+)
+
+// whichKeyDelay is how long a leader key waits before its completions pop up
+// Fast sequences like 'gg' complete before the popup appears
+const whichKeyDelay = 500 * time.Millisecond
+
+// whichKeyPopup lists the bindings that complete a pending multi-key sequence
+type whichKeyPopup struct {
+	context     keybinds.Context
+	prefix      string
+	completions []keybinds.Binding
+}
+
+// whichKeyTimeoutMsg is sent once the which-key delay has elapsed after a leader key
+type whichKeyTimeoutMsg struct {
+	seq     int
+	context keybinds.Context
+	prefix  string
+}
+
+// scheduleWhichKey starts the popup timer when msg started a new multi-key sequence
+// wasPending and pendingContext describe the registry state before the key was handled
+func (m *Model) scheduleWhichKey(msg tea.KeyMsg, pendingContext keybinds.Context, wasPending bool) tea.Cmd {
+	context, prefix, ok := m.keybinds.PendingSequence()
+	if !ok || prefix != msg.String() || (wasPending && context == pendingContext) {
+		return nil
+	}
+
+	m.whichKeySeq++
+	seq := m.whichKeySeq
+	return tea.Tick(whichKeyDelay, func(time.Time) tea.Msg {
+		return whichKeyTimeoutMsg{seq: seq, context: context, prefix: prefix}
+	})
+}
+
+// showWhichKey opens the popup if the sequence that started the timer is still pending
+func (m *Model) showWhichKey(msg whichKeyTimeoutMsg) {
+	if msg.seq != m.whichKeySeq {
+		return
+	}
+	context, prefix, ok := m.keybinds.PendingSequence()
+	if !ok || context != msg.context || prefix != msg.prefix {
+		return
+	}
+
+	completions := m.keybinds.Completions(context, prefix)
+	if len(completions) == 0 {
+		return
+	}
+	m.whichKey = &whichKeyPopup{context: context, prefix: prefix, completions: completions}
+}
+
+// dismissWhichKey closes the popup on any key press
+// ESC also cancels the pending sequence and is consumed; other keys are handled as usual
+func (m *Model) dismissWhichKey(msg tea.KeyMsg) bool {
+	if m.whichKey == nil {
+		return false
+	}
+	popup := m.whichKey
+	m.whichKey = nil
+
+	if msg.String() == "esc" {
+		m.keybinds.ClearMultiKeyState(popup.context)
+		m.gPressed = false
+		return true
+	}
+	return false
+}
+
+// renderWhichKey renders the completion list box
+func (m Model) renderWhichKey() string {
+	keyWidth := 0
+	for _, binding := range m.whichKey.completions {
+		keyWidth = max(keyWidth, len(binding.Key))
+	}
+
+	var content strings.Builder
+	content.WriteString(styleTitle.Render(m.whichKey.prefix+"…") + "\n")
+	for _, binding := range m.whichKey.completions {
+		key := fmt.Sprintf("%-*s", keyWidth, binding.Key)
+		content.WriteString("\n" + styleWarning.Render(key) + "  " + keybinds.GetActionInfo(binding.Action).Description)
+	}
+	content.WriteString("\n\n" + styleSubtle.Render("ESC to cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBlue).
+		Padding(0, 1).
+		Render(content.String())
+}
+
+// overlayWhichKey draws the popup in the bottom right corner of view, above the footer line
+func (m Model) overlayWhichKey(view string) string {
+	lines := strings.Split(view, "\n")
+	box := strings.Split(m.renderWhichKey(), "\n")
+
+	end := len(lines) - 1
+	if end < len(box) {
+		return strings.Join(box, "\n")
+	}
+	start := end - len(box)
+	for i, line := range box {
+		lines[start+i] = lipgloss.PlaceHorizontal(m.width, lipgloss.Right, line)
+	}
+	return strings.Join(lines, "\n")
+}
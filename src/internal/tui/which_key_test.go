@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWhichKey_ShowsCompletionsAfterLeader(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 100, 30
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	context, prefix, ok := m.keybinds.PendingSequence()
+	if !ok || prefix != "g" {
+		t.Fatal("Expected g to start a pending sequence")
+	}
+
+	// A timeout from an earlier leader press is ignored
+	m.Update(whichKeyTimeoutMsg{seq: m.whichKeySeq - 1, context: context, prefix: prefix})
+	if m.whichKey != nil {
+		t.Fatal("Expected a stale timeout to be ignored")
+	}
+
+	m.Update(whichKeyTimeoutMsg{seq: m.whichKeySeq, context: context, prefix: prefix})
+	if m.whichKey == nil {
+		t.Fatal("Expected the which-key popup to be shown")
+	}
+	if view := m.View(); !strings.Contains(view, "gg") || !strings.Contains(view, "Go to top") {
+		t.Errorf("Expected the gg completion in the popup:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.whichKey != nil {
+		t.Error("Expected ESC to dismiss the popup")
+	}
+	if _, _, ok := m.keybinds.PendingSequence(); ok {
+		t.Error("Expected ESC to cancel the pending sequence")
+	}
+	AssertModelField(t, "mode", ModeNormal, m.mode)
+}
+
+func TestWhichKey_CompletedSequenceSkipsPopup(t *testing.T) {
+	m := CreateTestModel(t)
+	m.width, m.height = 100, 30
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	seq := m.whichKeySeq
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+
+	m.Update(whichKeyTimeoutMsg{seq: seq, context: "normal", prefix: "g"})
+	if m.whichKey != nil {
+		t.Error("Expected no popup once the sequence completed")
+	}
+}
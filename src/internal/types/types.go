@@ -1,11 +1,15 @@
 package types
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // HttpRequest represents an HTTP request definition from .http files
 type HttpRequest struct {
 	Name                string                 `json:"name,omitempty" yaml:"name,omitempty"`
-	Protocol            string                 `json:"protocol,omitempty" yaml:"protocol,omitempty"` // Protocol type: http, graphql (defaults to http)
+	Protocol            string                 `json:"protocol,omitempty" yaml:"protocol,omitempty"` // Protocol type: http, graphql, grpc (defaults to http)
+	Profile             string                 `json:"profile,omitempty" yaml:"profile,omitempty"`   // Profile to execute this request with instead of the active one
 	Method              string                 `json:"method" yaml:"method"`
 	URL                 string                 `json:"url" yaml:"url"`
 	Headers             map[string]string      `json:"headers,omitempty" yaml:"headers,omitempty"`
@@ -14,8 +18,14 @@ type HttpRequest struct {
 	Query               string                 `json:"query,omitempty" yaml:"query,omitempty"`   // JMESPath query or $(bash command)
 	ParseEscapes        bool                   `json:"parseEscapes,omitempty" yaml:"parseEscapes,omitempty"` // Parse escape sequences in response body
 	Streaming           bool                   `json:"streaming,omitempty" yaml:"streaming,omitempty"` // Enable real-time streaming display (for SSE, infinite streams)
+	StreamFormat        string                 `json:"streamFormat,omitempty" yaml:"streamFormat,omitempty"` // Stream framing: sse, ndjson, raw (empty = chunks as received)
+	HTTPVersion         string                 `json:"httpVersion,omitempty" yaml:"httpVersion,omitempty"` // HTTP protocol version: auto, http1, http2, h2c (defaults to profile setting)
+	RequestCompression  string                 `json:"requestCompression,omitempty" yaml:"requestCompression,omitempty"` // Compress request body: gzip, deflate, br (empty = none)
+	GraphQL             *GraphQLRequest        `json:"graphql,omitempty" yaml:"graphql,omitempty"` // GraphQL operation (implies protocol graphql)
 	RequiresConfirmation bool                  `json:"requiresConfirmation,omitempty" yaml:"requiresConfirmation,omitempty"` // Require user confirmation before execution
 	TLS                  *TLSConfig             `json:"tls,omitempty" yaml:"tls,omitempty"`       // TLS/mTLS configuration
+	Signing              *SigningConfig         `json:"signing,omitempty" yaml:"signing,omitempty"` // Request signing (overrides the profile)
+	Protobuf             *ProtobufSchema        `json:"protobuf,omitempty" yaml:"protobuf,omitempty"` // Schema decoding a protobuf response for display
 	Documentation        *Documentation         `json:"documentation,omitempty" yaml:"documentation,omitempty"`
 	DocumentationLines   []string               `json:"-" yaml:"-"` // Raw documentation comment lines for lazy loading
 	documentationParsed  bool                   `json:"-" yaml:"-"` // Whether documentation has been parsed (unexported for internal use)
@@ -26,10 +36,64 @@ type HttpRequest struct {
 	ExpectedBodyContains string            `json:"expectedBodyContains,omitempty" yaml:"expectedBodyContains,omitempty"` // Substring that body must contain
 	ExpectedBodyPattern  string            `json:"expectedBodyPattern,omitempty" yaml:"expectedBodyPattern,omitempty"`   // Regex pattern body must match
 	ExpectedBodyFields   map[string]string `json:"expectedBodyFields,omitempty" yaml:"expectedBodyFields,omitempty"`     // JSON field:value or field:pattern map for partial matching
+	MaxDurationMs        int               `json:"maxDurationMs,omitempty" yaml:"maxDurationMs,omitempty"`               // Latency budget in milliseconds (0 = profile default)
+
+	// Retry fields (zero values fall back to the profile defaults)
+	RetryCount          int   `json:"retryCount,omitempty" yaml:"retryCount,omitempty"`                   // Max retries after the first attempt
+	RetryBackoffMs      int   `json:"retryBackoffMs,omitempty" yaml:"retryBackoffMs,omitempty"`           // Base delay for exponential backoff in milliseconds
+	RetryOnStatus       []int `json:"retryOnStatus,omitempty" yaml:"retryOnStatus,omitempty"`             // Status codes that trigger a retry (default: 502, 503, 504)
+	RetryOnNetworkError bool  `json:"retryOnNetworkError,omitempty" yaml:"retryOnNetworkError,omitempty"` // Retry on connection/transport errors
+	RetryUnsafe         bool  `json:"retryUnsafe,omitempty" yaml:"retryUnsafe,omitempty"`                 // Allow retries for non-idempotent methods (POST, PATCH)
+
+	// Redirect fields (unset values fall back to the profile defaults)
+	FollowRedirects *bool `json:"followRedirects,omitempty" yaml:"followRedirects,omitempty"` // Follow 3xx responses (false = return the 3xx as-is)
+	MaxRedirects    int   `json:"maxRedirects,omitempty" yaml:"maxRedirects,omitempty"`       // Redirects followed before failing
+
+	// Host mappings: host:port -> ip:port dialed instead (merged over the profile mappings)
+	Resolve map[string]string `json:"resolve,omitempty" yaml:"resolve,omitempty"`
+
+	// Outbound proxy (empty falls back to the profile, then the environment)
+	ProxyURL string `json:"proxyUrl,omitempty" yaml:"proxyUrl,omitempty"` // http, https, socks5 or socks5h URL, or "direct"
+	NoProxy  string `json:"noProxy,omitempty" yaml:"noProxy,omitempty"`   // Comma-separated hosts, domains and CIDRs reached directly
+
+	// Shell hooks, only run when the profile sets allowHooks
+	BeforeHooks []RequestHook `json:"beforeHooks,omitempty" yaml:"beforeHooks,omitempty"` // Run before variables are resolved
+	AfterHooks  []string      `json:"afterHooks,omitempty" yaml:"afterHooks,omitempty"`   // Run after the response, with the body on stdin
 
 	// Request chaining fields
 	DependsOn []string                `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"` // List of file paths this request depends on
 	Extract   map[string]string       `json:"extract,omitempty" yaml:"extract,omitempty"`     // Map of varName -> JMESPath for extracting values from response
+	Condition string                  `json:"condition,omitempty" yaml:"condition,omitempty"` // Skip this chain step unless the condition holds (e.g. {{role}} == admin)
+	ForEach   string                  `json:"forEach,omitempty" yaml:"forEach,omitempty"`     // Run this chain step once per element of an extracted array (e.g. {{ids}})
+}
+
+// RequestHook is a shell command run before a request (see Profile.AllowHooks)
+type RequestHook struct {
+	Command  string `json:"command" yaml:"command"`
+	Variable string `json:"variable,omitempty" yaml:"variable,omitempty"` // Receives the trimmed stdout (empty = discarded)
+}
+
+// GraphQLRequest is a GraphQL operation sent as the standard JSON POST body
+type GraphQLRequest struct {
+	Query         string                 `json:"query" yaml:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty" yaml:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty" yaml:"operationName,omitempty"`
+}
+
+// IsGraphQL reports whether the request is sent as a GraphQL operation
+func (r *HttpRequest) IsGraphQL() bool {
+	return r.Protocol == "graphql" || r.GraphQL != nil
+}
+
+// IsGRPC reports whether the request is a gRPC call (grpc:// or grpcs:// URL)
+func (r *HttpRequest) IsGRPC() bool {
+	url := strings.ToLower(r.URL)
+	return r.Protocol == "grpc" || strings.HasPrefix(url, "grpc://") || strings.HasPrefix(url, "grpcs://")
+}
+
+// IsNDJSONStream reports whether the response should be streamed as newline-delimited JSON
+func (r *HttpRequest) IsNDJSONStream() bool {
+	return strings.EqualFold(strings.TrimSpace(r.StreamFormat), "ndjson")
 }
 
 // EnsureDocumentationParsed parses documentation lines if not already parsed
@@ -100,22 +164,29 @@ type ResponseField struct {
 
 // Session represents ephemeral session state
 type Session struct {
-	Variables      map[string]string `json:"variables,omitempty"`
-	ActiveProfile  string            `json:"activeProfile,omitempty"`
-	HistoryEnabled *bool             `json:"historyEnabled,omitempty"`
-	RecentFiles    []string          `json:"recentFiles,omitempty"` // Most recently used files (MRU)
+	Variables      map[string]string   `json:"variables,omitempty"`
+	ActiveProfile  string              `json:"activeProfile,omitempty"`
+	HistoryEnabled *bool               `json:"historyEnabled,omitempty"`
+	RecentFiles    []string            `json:"recentFiles,omitempty"`  // Most recently used files (MRU)
+	Macros         map[string][]string `json:"macros,omitempty"`       // Recorded key macros by register
+	Environments   map[string]string   `json:"environments,omitempty"` // Active environment by profile name
+	Notes          map[string]string   `json:"notes,omitempty"`        // Scratchpad notes by request file path
 }
 
 // Profile represents a header/variable profile
 type Profile struct {
 	Name          string                    `json:"name"`
+	Extends       string                    `json:"extends,omitempty"`       // Parent profile whose headers, variables, environments, TLS and OAuth are inherited
 	Headers       map[string]string         `json:"headers,omitempty"`
 	Variables     map[string]VariableValue  `json:"variables,omitempty"`
+	Environments  map[string]Environment    `json:"environments,omitempty"`  // Named variable overrides layered over Variables (e.g. per region)
 	Workdir       string                    `json:"workdir,omitempty"`
 	OAuth         *OAuthConfig              `json:"oauth,omitempty"`
 	TLS           *TLSConfig                `json:"tls,omitempty"`           // TLS/mTLS configuration
+	Signing       *SigningConfig            `json:"signing,omitempty"`       // Request signing (AWS SigV4, HMAC)
 	Editor        string                    `json:"editor,omitempty"`
-	Output        string                    `json:"output,omitempty"`        // json, yaml, text
+	Keybinds      string                    `json:"keybinds,omitempty"`      // keybinds.json layered over the global keybindings
+	Output        string                    `json:"output,omitempty"`        // json, yaml, text, csv, tsv
 	DefaultFilter    string `json:"defaultFilter,omitempty"`    // Global JMESPath filter for all responses
 	DefaultQuery     string `json:"defaultQuery,omitempty"`     // Global JMESPath query for all responses
 	HistoryEnabled   *bool  `json:"historyEnabled,omitempty"`   // Override global history setting (nil = use global)
@@ -123,9 +194,53 @@ type Profile struct {
 	MessageTimeout   *int   `json:"messageTimeout,omitempty"`   // Auto-clear footer messages after N seconds (nil = permanent/no auto-clear)
 	RequestTimeout   *int   `json:"requestTimeout,omitempty"`   // HTTP request timeout in seconds (nil = 30s default)
 	MaxResponseSize  *int64 `json:"maxResponseSize,omitempty"`  // Max response body size in bytes (nil = 100MB default)
+	MaxResponseDisplayBytes *int `json:"maxResponseDisplayBytes,omitempty"` // Bytes of the body rendered in the TUI (nil = 1MB default, 0 = no limit)
 	SyntaxThemeLight string `json:"syntaxThemeLight,omitempty"` // Chroma syntax theme for light backgrounds (default: github)
 	SyntaxThemeDark  string `json:"syntaxThemeDark,omitempty"`  // Chroma syntax theme for dark backgrounds (default: monokai)
+	Theme            string `json:"theme,omitempty"`            // TUI color theme: default, dark, light, high-contrast, or a theme file path
 	ProxyPort        *int   `json:"proxyPort,omitempty"`        // Debug proxy port (nil = 8888 default)
+	HTTPVersion      string `json:"httpVersion,omitempty"`      // Default HTTP protocol version: auto, http1, http2, h2c (default: auto)
+	AutoDecompress   *bool  `json:"autoDecompress,omitempty"`   // Decompress gzip/deflate/br responses (nil = true default)
+	CookiesEnabled   *bool  `json:"cookiesEnabled,omitempty"`   // Keep a cookie jar across requests and chains (default: false)
+	MaxForEachIterations *int `json:"maxForEachIterations,omitempty"` // Cap on @forEach iterations per chain step (nil or <= 0 = 100 default)
+	RetryCount          int   `json:"retryCount,omitempty"`          // Default max retries after the first attempt (default: 0, no retries)
+	RetryBackoffMs      int   `json:"retryBackoffMs,omitempty"`      // Default base backoff delay in milliseconds (default: 200)
+	RetryOnStatus       []int `json:"retryOnStatus,omitempty"`       // Default status codes that trigger a retry (default: 502, 503, 504)
+	RetryOnNetworkError bool  `json:"retryOnNetworkError,omitempty"` // Retry on connection/transport errors by default
+	RetryUnsafe         bool  `json:"retryUnsafe,omitempty"`         // Allow retries for non-idempotent methods by default
+	ErrorRateThreshold *float64 `json:"errorRateThreshold,omitempty"` // Error rate in percent above which analytics flags an endpoint (nil = 10)
+	ErrorRateWindow    *int     `json:"errorRateWindow,omitempty"`    // Rolling window of the error rate in minutes (nil = 60)
+	AllowHooks         bool     `json:"allowHooks,omitempty"`         // Run the @before/@after shell hooks of requests (default: false)
+	HookTimeout        *int     `json:"hookTimeout,omitempty"`        // Timeout of each hook in seconds (nil = 30s default)
+	PingIntervalSec    *int     `json:"pingIntervalSec,omitempty"`    // WebSocket keepalive ping interval in seconds (nil = no pings)
+	PongTimeoutSec     *int     `json:"pongTimeoutSec,omitempty"`     // Seconds to wait for a WebSocket pong before disconnecting (nil = ping interval)
+	FollowRedirects    *bool    `json:"followRedirects,omitempty"`    // Follow 3xx responses (nil = true default)
+	MaxRedirects       *int     `json:"maxRedirects,omitempty"`       // Redirects followed before failing (nil = 10 default)
+	Resolve            map[string]string `json:"resolve,omitempty"`   // Host mappings: host:port -> ip:port dialed instead, like curl --resolve
+	ProxyURL           string   `json:"proxyUrl,omitempty"`           // Outbound proxy: http, https, socks5 or socks5h URL (empty = HTTP_PROXY/HTTPS_PROXY)
+	NoProxy            string   `json:"noProxy,omitempty"`            // Comma-separated hosts, domains and CIDRs reached without the proxy
+	MaxDurationMs      int      `json:"maxDurationMs,omitempty"`      // Default latency budget of requests in milliseconds (0 = none)
+	MouseEnabled       bool     `json:"mouseEnabled,omitempty"`       // Scroll and click with the mouse in the TUI (default: false, keyboard only)
+}
+
+// Environment is a named set of variable overrides within a profile
+type Environment map[string]VariableValue
+
+// VariablesForEnvironment returns the profile variables with the named environment layered over them
+// An empty or unknown name returns Variables unchanged.
+func (p *Profile) VariablesForEnvironment(name string) map[string]VariableValue {
+	overrides, ok := p.Environments[name]
+	if !ok || len(overrides) == 0 {
+		return p.Variables
+	}
+	merged := make(map[string]VariableValue, len(p.Variables)+len(overrides))
+	for key, value := range p.Variables {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
 }
 
 // GetRequestTimeout returns the configured timeout or default (30 seconds)
@@ -144,6 +259,23 @@ func (p *Profile) GetMaxResponseSize() int64 {
 	return 100 * 1024 * 1024 // Default 100MB
 }
 
+// GetMaxResponseDisplayBytes returns how many bytes of a response body the TUI renders or default (1MB)
+// 0 renders the full body
+func (p *Profile) GetMaxResponseDisplayBytes() int {
+	if p.MaxResponseDisplayBytes != nil {
+		return *p.MaxResponseDisplayBytes
+	}
+	return 1024 * 1024 // Default 1MB
+}
+
+// GetHookTimeout returns the configured hook timeout in seconds or default (30 seconds)
+func (p *Profile) GetHookTimeout() int {
+	if p.HookTimeout != nil {
+		return *p.HookTimeout
+	}
+	return 30 // Default 30 seconds
+}
+
 // GetProxyPort returns the configured proxy port or default (8888)
 func (p *Profile) GetProxyPort() int {
 	if p.ProxyPort != nil {
@@ -152,6 +284,47 @@ func (p *Profile) GetProxyPort() int {
 	return 8888 // Default port
 }
 
+// GetHTTPVersion returns the configured HTTP protocol version or default (auto)
+func (p *Profile) GetHTTPVersion() string {
+	if p.HTTPVersion != "" {
+		return p.HTTPVersion
+	}
+	return "auto"
+}
+
+// GetAutoDecompress returns whether responses are decompressed automatically (default true)
+func (p *Profile) GetAutoDecompress() bool {
+	if p.AutoDecompress != nil {
+		return *p.AutoDecompress
+	}
+	return true
+}
+
+// GetMaxForEachIterations returns the configured @forEach iteration cap or default (100)
+// Zero or negative values fall back to the default
+func (p *Profile) GetMaxForEachIterations() int {
+	if p.MaxForEachIterations != nil && *p.MaxForEachIterations > 0 {
+		return *p.MaxForEachIterations
+	}
+	return 100 // Default 100 iterations
+}
+
+// GetErrorRateThreshold returns the configured error rate threshold in percent or default (10)
+func (p *Profile) GetErrorRateThreshold() float64 {
+	if p.ErrorRateThreshold != nil {
+		return *p.ErrorRateThreshold
+	}
+	return 10 // Default 10%
+}
+
+// GetErrorRateWindow returns the configured error rate window in minutes or default (60)
+func (p *Profile) GetErrorRateWindow() int {
+	if p.ErrorRateWindow != nil {
+		return *p.ErrorRateWindow
+	}
+	return 60 // Default 1 hour
+}
+
 // VariableValue can be a simple string or a multi-value variable
 type VariableValue struct {
 	// Simple string value
@@ -176,6 +349,12 @@ type MultiValueVariable struct {
 type OAuthConfig struct {
 	Enabled bool `json:"enabled"`
 
+	// Grant type: authorization_code (default, browser + PKCE), client_credentials or device_code
+	GrantType string `json:"grantType,omitempty"`
+
+	// Device authorization endpoint (device_code grant)
+	DeviceAuthURL string `json:"deviceAuthUrl,omitempty"`
+
 	// Manual mode - complete auth URL
 	AuthEndpoint string `json:"authEndpoint,omitempty"`
 
@@ -189,6 +368,25 @@ type OAuthConfig struct {
 	ResponseType     string `json:"responseType,omitempty"` // code or token
 	WebhookPort      int    `json:"webhookPort,omitempty"`
 	TokenStorageKey  string `json:"tokenStorageKey,omitempty"`
+	AutoRefresh      bool   `json:"autoRefresh,omitempty"` // On 401, refresh (or re-auth) the token and retry once
+	RefreshSkew      *int   `json:"refreshSkew,omitempty"` // Seconds before expiry to refresh the token proactively (nil = 60 default)
+	AutoInject       *bool  `json:"autoInject,omitempty"`  // Add "Authorization: Bearer <token>" to requests without one (nil = true default)
+}
+
+// GetAutoInject returns whether the token is injected as an Authorization header or default (true)
+func (o *OAuthConfig) GetAutoInject() bool {
+	if o.AutoInject != nil {
+		return *o.AutoInject
+	}
+	return true // Default enabled
+}
+
+// GetRefreshSkew returns how long before expiry the token is refreshed or default (60s)
+func (o *OAuthConfig) GetRefreshSkew() time.Duration {
+	if o.RefreshSkew != nil {
+		return time.Duration(*o.RefreshSkew) * time.Second
+	}
+	return 60 * time.Second // Default 60 seconds
 }
 
 // TLSConfig contains TLS/mTLS configuration
@@ -204,6 +402,44 @@ type TLSConfig struct {
 
 	// Skip server certificate verification (insecure, for testing only)
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty"`
+
+	// SHA-256 pins of the server certificate or its public key (hex or base64); any match passes
+	PinnedCertSHA256 []string `json:"pinnedCertSha256,omitempty" yaml:"pinnedCertSha256,omitempty"`
+}
+
+// ProtobufSchema names the message of a protobuf response body
+type ProtobufSchema struct {
+	DescriptorFile string `json:"descriptorFile" yaml:"descriptorFile"` // FileDescriptorSet built with protoc --include_imports -o
+	Message        string `json:"message" yaml:"message"`               // Fully-qualified message name (package.Message)
+}
+
+// Request signing algorithms
+const (
+	SigningAWSSigV4   = "aws-sigv4"
+	SigningHMACSHA256 = "hmac-sha256"
+	SigningNone       = "none" // Disables the profile signing for one request
+)
+
+// SigningConfig describes how a request is signed right before it is sent
+type SigningConfig struct {
+	// Algorithm: aws-sigv4, hmac-sha256 or none
+	Algorithm string `json:"algorithm" yaml:"algorithm"`
+
+	// Access key ID (AWS) or key ID (HMAC, optional)
+	AccessKey string `json:"accessKey,omitempty" yaml:"accessKey,omitempty"`
+
+	// Secret access key (AWS) or shared secret (HMAC)
+	SecretKey string `json:"secretKey,omitempty" yaml:"secretKey,omitempty"`
+
+	// Session token for temporary AWS credentials (sent as X-Amz-Security-Token)
+	SessionToken string `json:"sessionToken,omitempty" yaml:"sessionToken,omitempty"`
+
+	// AWS region and service name (e.g. us-east-1, execute-api)
+	Region  string `json:"region,omitempty" yaml:"region,omitempty"`
+	Service string `json:"service,omitempty" yaml:"service,omitempty"`
+
+	// Header receiving the HMAC signature (default: X-Signature)
+	Header string `json:"header,omitempty" yaml:"header,omitempty"`
 }
 
 // StreamCallback is called during streaming responses with each chunk
@@ -214,17 +450,79 @@ type StreamCallback func(chunk []byte, done bool)
 type RequestResult struct {
 	Status         int               `json:"status"`
 	StatusText     string            `json:"statusText"`
+	Protocol       string            `json:"protocol,omitempty"` // Protocol used for the response (e.g. HTTP/1.1, HTTP/2.0)
 	Headers        map[string]string `json:"headers"`
 	Body           string            `json:"body"`
 	Duration       int64             `json:"duration"`       // milliseconds
 	RequestSize    int               `json:"requestSize"`    // bytes
 	ResponseSize   int               `json:"responseSize"`   // bytes
+	CompressedSize int               `json:"compressedSize,omitempty"` // bytes on the wire before decompression (0 = not compressed)
 	Error          string            `json:"error,omitempty"`
 	Timestamp      string            `json:"timestamp,omitempty"` // RFC3339 format
+	Attempts       int               `json:"attempts,omitempty"`  // Number of attempts made (1 = no retries)
+	Timings        *RequestTimings   `json:"timings,omitempty"`   // Per-phase breakdown of Duration
+	GraphQLErrors  []string          `json:"graphqlErrors,omitempty"` // Messages from a GraphQL "errors" array (HTTP status is often still 200)
+	Truncated      bool              `json:"truncated,omitempty"`     // Body holds only a preview; ResponseSize is the full size
+	DownloadPath   string            `json:"downloadPath,omitempty"`  // File the body was written to instead of Body
+	Redirects      []RedirectHop     `json:"redirects,omitempty"`     // Redirect responses before the final one, in order
+	TLS            *TLSInfo          `json:"tls,omitempty"`           // Negotiated TLS connection (nil for plain HTTP)
+	MaxDurationMs  int64             `json:"maxDurationMs,omitempty"` // Latency budget the request was held to (0 = none)
+}
+
+// RedirectHop is a 3xx response that was followed (or stopped the redirect limit)
+type RedirectHop struct {
+	URL        string `json:"url"`
+	Status     int    `json:"status"`
+	Location   string `json:"location,omitempty"`
+	DurationMs int64  `json:"durationMs"` // Time from the previous hop (or the request start) to this response
+}
+
+// TTFBMs returns the time to first byte in milliseconds (0 when not recorded)
+func (r *RequestResult) TTFBMs() int64 {
+	if r.Timings == nil {
+		return 0
+	}
+	return r.Timings.TTFB
+}
+
+// OverBudget reports whether the request took longer than its latency budget
+func (r *RequestResult) OverBudget() bool {
+	return r.MaxDurationMs > 0 && r.Duration > r.MaxDurationMs
+}
+
+// TLSInfo describes the TLS connection a response was received on
+type TLSInfo struct {
+	Version      string            `json:"version"`              // e.g. TLS 1.3
+	CipherSuite  string            `json:"cipherSuite"`          // e.g. TLS_AES_128_GCM_SHA256
+	ServerName   string            `json:"serverName,omitempty"` // SNI sent by the client
+	ALPN         string            `json:"alpn,omitempty"`       // Negotiated application protocol (h2, http/1.1)
+	Certificates []CertificateInfo `json:"certificates"`         // Chain presented by the server, leaf first
+}
+
+// CertificateInfo is one certificate of a server chain
+type CertificateInfo struct {
+	Subject     string    `json:"subject"`
+	Issuer      string    `json:"issuer"`
+	NotBefore   time.Time `json:"notBefore"`
+	NotAfter    time.Time `json:"notAfter"`
+	DNSNames    []string  `json:"dnsNames,omitempty"`
+	IPAddresses []string  `json:"ipAddresses,omitempty"`
+	SHA256      string    `json:"sha256"`     // Certificate fingerprint (hex)
+	SPKISHA256  string    `json:"spkiSha256"` // Public key hash (base64), usable as a pin
+}
+
+// RequestTimings breaks a request down into phases (milliseconds, summed across redirects)
+// Phases are zero when skipped, e.g. DNS/Connect/TLS on a reused connection
+type RequestTimings struct {
+	DNS     int64 `json:"dns"`
+	Connect int64 `json:"connect"`
+	TLS     int64 `json:"tls"`
+	TTFB    int64 `json:"ttfb"` // From acquiring a connection to the first response byte
 }
 
 // HistoryEntry represents a saved request/response pair
 type HistoryEntry struct {
+	ID                 int64             `json:"-"` // Database row, 0 for entries read from JSON files
 	Timestamp          string            `json:"timestamp"`
 	RequestFile        string            `json:"requestFile"`
 	RequestName        string            `json:"requestName,omitempty"`
@@ -240,6 +538,14 @@ type HistoryEntry struct {
 	RequestSize        int               `json:"requestSize,omitempty"`
 	ResponseSize       int               `json:"responseSize,omitempty"`
 	Error              string            `json:"error,omitempty"`
+	Note               string            `json:"note,omitempty"` // Free-text annotation
+	Tags               []string          `json:"tags,omitempty"`
+	Redirects          []RedirectHop     `json:"redirects,omitempty"`
+}
+
+// IsAnnotated reports whether the entry has a note or tags
+func (e *HistoryEntry) IsAnnotated() bool {
+	return e.Note != "" || len(e.Tags) > 0
 }
 
 // RequestFile represents a parsed .http file
@@ -255,6 +561,8 @@ type FileInfo struct {
 	RequestCount  int
 	ModifiedTime  time.Time
 	HTTPMethod    string   // First request's HTTP method
+	URL           string   // First request's URL, variables unresolved
+	IsDir         bool     // Folder entry of the file explorer tree view
 	Tags          []string // Aggregated tags from all requests in file
 }
 
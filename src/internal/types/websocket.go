@@ -10,6 +10,10 @@ type WebSocketRequest struct {
 	Subprotocols  []string          `json:"subprotocols,omitempty" yaml:"subprotocols,omitempty"`
 	Messages      []WebSocketMessage `json:"messages,omitempty" yaml:"messages,omitempty"`
 	TLS           *TLSConfig        `json:"tls,omitempty" yaml:"tls,omitempty"`
+	PingIntervalSec int             `json:"pingIntervalSec,omitempty" yaml:"pingIntervalSec,omitempty"` // Keepalive ping interval in seconds, overrides the profile (0 = profile setting)
+	PongTimeoutSec  int             `json:"pongTimeoutSec,omitempty" yaml:"pongTimeoutSec,omitempty"`   // Seconds to wait for the pong of a ping, overrides the profile (0 = profile setting)
+	Rules          []WebSocketRule  `json:"rules,omitempty" yaml:"rules,omitempty"`                       // Auto-replies to received messages, first match wins
+	MaxAutoReplies int              `json:"maxAutoReplies,omitempty" yaml:"maxAutoReplies,omitempty"`     // Cap on auto-replies per connection (0 = 100 default)
 	Documentation *Documentation    `json:"documentation,omitempty" yaml:"documentation,omitempty"`
 }
 
@@ -22,6 +26,12 @@ type WebSocketMessage struct {
 	Timeout   int    `json:"timeout,omitempty" yaml:"timeout,omitempty"` // Timeout in seconds
 }
 
+// WebSocketRule replies to received messages matching a pattern, for scripted conversations
+type WebSocketRule struct {
+	Pattern string `json:"pattern" yaml:"pattern"` // Regular expression matched against the received content
+	Reply   string `json:"reply" yaml:"reply"`     // Message sent back; $1, ${name} expand the pattern's groups
+}
+
 // WebSocketResult contains the WebSocket session data
 type WebSocketResult struct {
 	Messages     []ReceivedMessage `json:"messages"`                 // All received messages
@@ -0,0 +1,164 @@
+// Package watcher reports changes to request files so they can be re-run on save.
+//
+// Parent directories are watched rather than the files themselves: editors often save by
+// writing a temporary file and renaming it over the original, which drops a watch placed on
+// the file. Bursts of events from a single save are debounced into one change.
+package watcher
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/studiowebux/restcli/internal/chain"
+)
+
+// DefaultDebounce is how long a file must stay quiet before a change is reported
+const DefaultDebounce = 200 * time.Millisecond
+
+// Watcher reports debounced changes to a set of files
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	debounce time.Duration
+	changes  chan string
+	done     chan struct{}
+
+	mu    sync.Mutex
+	files map[string]bool
+	once  sync.Once
+}
+
+// New starts watching the given files
+func New(files []string, debounce time.Duration) (*Watcher, error) {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	w := &Watcher{
+		fs:       fs,
+		debounce: debounce,
+		changes:  make(chan string, 1),
+		done:     make(chan struct{}),
+	}
+	if err := w.SetFiles(files); err != nil {
+		fs.Close()
+		return nil, err
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// Changes returns the channel receiving the path of a changed file
+// It is closed when the watcher is closed.
+func (w *Watcher) Changes() <-chan string {
+	return w.changes
+}
+
+// SetFiles replaces the set of watched files (e.g. when dependencies changed)
+func (w *Watcher) SetFiles(files []string) error {
+	watched := make(map[string]bool, len(files))
+	for _, file := range files {
+		path, err := filepath.Abs(file)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", file, err)
+		}
+		if err := w.fs.Add(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", filepath.Dir(path), err)
+		}
+		watched[path] = true
+	}
+
+	w.mu.Lock()
+	w.files = watched
+	w.mu.Unlock()
+	return nil
+}
+
+// Close stops watching and closes the changes channel
+func (w *Watcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.done)
+		err = w.fs.Close()
+	})
+	return err
+}
+
+// run forwards events on watched files once they settle
+func (w *Watcher) run() {
+	defer close(w.changes)
+
+	var settled <-chan time.Time
+	var changed string
+	for {
+		select {
+		case <-w.done:
+			return
+
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			// Permission and timestamp changes do not alter the request
+			if event.Op == fsnotify.Chmod || !w.isWatched(event.Name) {
+				continue
+			}
+			changed = filepath.Clean(event.Name)
+			settled = time.After(w.debounce)
+
+		case <-settled:
+			settled = nil
+			// Keep a single pending change when the reader is still busy with the previous one
+			select {
+			case w.changes <- changed:
+			default:
+			}
+
+		case _, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// isWatched returns true if the event concerns one of the watched files
+func (w *Watcher) isWatched(name string) bool {
+	path, err := filepath.Abs(name)
+	if err != nil {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.files[path]
+}
+
+// Files returns the request file and every file in its dependency graph
+// A file that cannot be parsed is still returned on its own so that fixing it triggers a run.
+func Files(filePath, workdir string) []string {
+	// The request may be relative to the current directory rather than the workdir
+	if path, err := filepath.Abs(filePath); err == nil {
+		filePath = path
+	}
+	seen := map[string]bool{filePath: true}
+
+	graph := chain.NewGraph(workdir)
+	if err := graph.BuildGraph(filePath); err == nil {
+		for path := range graph.Nodes {
+			seen[path] = true
+		}
+	}
+
+	files := make([]string, 0, len(seen))
+	for path := range seen {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files
+}
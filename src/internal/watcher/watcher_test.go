@@ -0,0 +1,91 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFiles_IncludesDependencies(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "login.http"), "### Login\nPOST https://example.com/login\n")
+	writeFile(t, filepath.Join(dir, "user.http"), "### User\n# @depends login.http\nGET https://example.com/user\n")
+
+	files := Files(filepath.Join(dir, "user.http"), dir)
+	if len(files) != 2 || files[0] != filepath.Join(dir, "login.http") || files[1] != filepath.Join(dir, "user.http") {
+		t.Errorf("Expected the request and its dependency, got %v", files)
+	}
+}
+
+func TestFiles_UnparsableFileIsStillWatched(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "empty.http")
+	writeFile(t, path, "")
+
+	files := Files(path, dir)
+	if len(files) != 1 || files[0] != path {
+		t.Errorf("Expected only the request file, got %v", files)
+	}
+}
+
+func TestWatcher_DebouncesSaves(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "api.http")
+	other := filepath.Join(dir, "other.http")
+	writeFile(t, path, "GET https://example.com\n")
+	writeFile(t, other, "GET https://example.com\n")
+
+	w, err := New([]string{path}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	writeFile(t, other, "GET https://example.com/other\n")
+	for i := 0; i < 3; i++ {
+		writeFile(t, path, "GET https://example.com/v2\n")
+	}
+
+	select {
+	case changed := <-w.Changes():
+		if changed != path {
+			t.Errorf("Expected %s to change, got %s", path, changed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a change to be reported")
+	}
+
+	select {
+	case changed := <-w.Changes():
+		t.Errorf("Expected a single change for a burst of saves, got another for %s", changed)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatcher_CloseClosesChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.http")
+	writeFile(t, path, "GET https://example.com\n")
+
+	w, err := New([]string{path}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	select {
+	case _, ok := <-w.Changes():
+		if ok {
+			t.Error("Expected no change after closing")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the changes channel to be closed")
+	}
+}